@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// loadRegistryIndex loads the registry index, nil is returned if no registry is configured or it can't be loaded,
+// the advisories are informative so they never fail a command
+func loadRegistryIndex() *util.RegistryIndex {
+
+	index, err := util.LoadRegistryIndex()
+	if err != nil {
+		if Verbose() {
+			fmt.Printf("Unable to load registry index: %v\n", err)
+		}
+		return nil
+	}
+
+	return index
+}
+
+// printContribAdvisories prints the deprecation and the advisories of the installed contribution
+func printContribAdvisories(project common.AppProject, flogoImport util.Import) {
+
+	index := loadRegistryIndex()
+	if index == nil {
+		return
+	}
+
+	_, version := importModule(goModRequirements(project.SrcDir()), flogoImport.GoImportPath())
+	for _, warning := range index.Find(flogoImport.GoImportPath()).Warnings(version) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+}
+
+// validateAdvisories warns about the imported contributions which are deprecated or affected by an advisory
+func validateAdvisories(ctx *validationContext) error {
+
+	index := loadRegistryIndex()
+	if index == nil {
+		return nil
+	}
+
+	modules := goModRequirements(ctx.project.SrcDir())
+
+	imports, _ := ctx.appObj["imports"].([]interface{})
+	for i, val := range imports {
+		s, _ := val.(string)
+		imp, err := util.ParseImport(s)
+		if err != nil {
+			continue
+		}
+		_, version := importModule(modules, imp.GoImportPath())
+		for _, warning := range index.Find(imp.GoImportPath()).Warnings(version) {
+			ctx.addWarning(fmt.Sprintf("$.imports[%d]", i), "%s", warning)
+		}
+	}
+
+	return nil
+}
+
+// ListOutdatedContribs lists the modules of the imported contributions for which a newer version is available, along
+// with the deprecations and advisories affecting them
+func ListOutdatedContribs(ctx context.Context, project common.AppProject) error {
+
+	appImports, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), false)
+	if err != nil {
+		return err
+	}
+	imports := appImports.GetAllImports()
+
+	modules := goModRequirements(project.SrcDir())
+
+	pins, err := projectPins(project)
+	if err != nil {
+		return err
+	}
+
+	mods := importedModules(imports, modules)
+	if len(mods) > 0 {
+		outdated, err := findOutdatedModules(ctx, project.SrcDir(), mods)
+		if err != nil {
+			return err
+		}
+
+		for i, mod := range outdated {
+			if i == 0 {
+				fmt.Printf("%-60s %-20s %s\n", "CONTRIBUTION", "INSTALLED", "LATEST")
+			}
+			latest := mod.Latest
+			if pin := pins[mod.Path]; pin != nil {
+				latest += fmt.Sprintf(" (pinned until %s)", pin.Expires)
+			}
+			fmt.Printf("%-60s %-20s %s\n", mod.Path, mod.Version, latest)
+		}
+
+		if len(outdated) == 0 {
+			fmt.Println("All contributions are up to date")
+		}
+	} else {
+		fmt.Println("No contribution modules required by the application")
+	}
+
+	if warnings := pinWarnings(pins, modules, time.Now()); len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range warnings {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+
+	index := loadRegistryIndex()
+	if index == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, imp := range imports {
+		_, version := importModule(modules, imp.GoImportPath())
+		warnings = append(warnings, index.Find(imp.GoImportPath()).Warnings(version)...)
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+		for _, warning := range uniqueStrings(warnings) {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+
+	return nil
+}
+
+// outdatedModule is a module for which a newer version is available
+type outdatedModule struct {
+	Path    string
+	Version string
+	Latest  string
+}
+
+// importedModules gets the modules providing the imports, sorted
+func importedModules(imports []util.Import, modules map[string]string) []string {
+
+	var mods []string
+	seen := make(map[string]bool)
+	for _, imp := range imports {
+		mod, _ := importModule(modules, imp.GoImportPath())
+		if mod != "" && !seen[mod] {
+			seen[mod] = true
+			mods = append(mods, mod)
+		}
+	}
+	sort.Strings(mods)
+
+	return mods
+}
+
+// findOutdatedModules finds the modules for which a newer version is available from the module proxy
+func findOutdatedModules(ctx context.Context, srcDir string, mods []string) ([]*outdatedModule, error) {
+
+	args := append([]string{"list", "-m", "-u", "-f", "{{.Path}} {{.Version}} {{if .Update}}{{.Update.Version}}{{end}}"}, mods...)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = srcDir
+	cmd.Env = util.GoCmdEnv(filepath.Dir(srcDir))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the latest versions: %s", strings.TrimSpace(string(out)))
+	}
+
+	var outdated []*outdatedModule
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		outdated = append(outdated, &outdatedModule{Path: fields[0], Version: fields[1], Latest: fields[2]})
+	}
+
+	return outdated, nil
+}
+
+// importModule finds the module providing the import among the modules required by the project
+func importModule(modules map[string]string, importPath string) (string, string) {
+
+	var found string
+	for mod := range modules {
+		if (importPath == mod || strings.HasPrefix(importPath, mod+"/")) && len(mod) > len(found) {
+			found = mod
+		}
+	}
+	if found == "" {
+		return "", ""
+	}
+
+	return found, modules[found]
+}
+
+func uniqueStrings(values []string) []string {
+
+	var unique []string
+	for _, v := range values {
+		unique = appendUnique(unique, v)
+	}
+
+	return unique
+}
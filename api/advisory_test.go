@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportModule(t *testing.T) {
+	t.Log("Testing finding the module providing an import")
+
+	modules := map[string]string{
+		"github.com/project-flogo/contrib":               "v0.9.0",
+		"github.com/project-flogo/contrib/activity/rest": "v0.10.0",
+		"github.com/project-flogo/core":                  "v0.9.5",
+	}
+
+	mod, version := importModule(modules, "github.com/project-flogo/contrib/activity/log")
+	assert.Equal(t, "github.com/project-flogo/contrib", mod)
+	assert.Equal(t, "v0.9.0", version)
+
+	// the most specific module provides the import
+	mod, version = importModule(modules, "github.com/project-flogo/contrib/activity/rest")
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", mod)
+	assert.Equal(t, "v0.10.0", version)
+
+	mod, version = importModule(modules, "github.com/project-flogo/corex")
+	assert.Equal(t, "", mod)
+	assert.Equal(t, "", version)
+}
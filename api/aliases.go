@@ -0,0 +1,208 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+var aliasNamespaceInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// aliasCollision is an alias shared by imported contributions of the same type, the runtime only registers one of
+// them for it
+type aliasCollision struct {
+	Type    string
+	Alias   string
+	Imports []string // the import paths, in the order of the imports of the app descriptor
+}
+
+// aliasCollisions gets the aliases shared by imported contributions of the same type
+func (r *contribResolver) aliasCollisions(appObj map[string]interface{}) []*aliasCollision {
+
+	order := importOrder(appObj)
+
+	var collisions []*aliasCollision
+	for contribType, aliases := range r.aliasImports {
+		for alias, paths := range aliases {
+			if len(paths) < 2 {
+				continue
+			}
+			imports := append([]string{}, paths...)
+			sort.SliceStable(imports, func(i, j int) bool { return order[imports[i]] < order[imports[j]] })
+			collisions = append(collisions, &aliasCollision{Type: contribType, Alias: alias, Imports: imports})
+		}
+	}
+
+	sort.Slice(collisions, func(i, j int) bool {
+		if collisions[i].Type != collisions[j].Type {
+			return collisions[i].Type < collisions[j].Type
+		}
+		return collisions[i].Alias < collisions[j].Alias
+	})
+
+	return collisions
+}
+
+// importOrder gets the index of the imports of the app descriptor by import path
+func importOrder(appObj map[string]interface{}) map[string]int {
+
+	order := make(map[string]int)
+	for i, s := range descriptorImports(appObj) {
+		if imp, err := util.ParseImport(s); err == nil {
+			order[imp.GoImportPath()] = i
+		}
+	}
+
+	return order
+}
+
+// namespacedAlias gets the alias of the contribution prefixed by the owner of its import path, ex. acme/log for
+// github.com/acme/flogo/activity/log, functions use an underscore as their alias prefixes them in expressions
+func namespacedAlias(importPath, alias, contribType string) string {
+
+	parts := strings.Split(importPath, "/")
+	namespace := parts[0]
+	if strings.Contains(namespace, ".") && len(parts) > 2 {
+		namespace = parts[1]
+	}
+	namespace = aliasNamespaceInvalidChars.ReplaceAllString(namespace, "")
+
+	if contribType == "function" {
+		return namespace + "_" + alias
+	}
+	return namespace + "/" + alias
+}
+
+// validateAliasCollisions detects the imported contributions of the same type sharing an alias, the refs using it
+// would resolve to one of them only
+func validateAliasCollisions(ctx *validationContext) error {
+
+	order := importOrder(ctx.appObj)
+
+	for _, collision := range ctx.contribs.aliasCollisions(ctx.appObj) {
+		for _, path := range collision.Imports[1:] {
+			ctx.addError(fmt.Sprintf("$.imports[%d]", order[path]), "alias '%s' of %s %s is also used by %s, use a namespaced alias, ex. '%s %s'",
+				collision.Alias, collision.Type, path, collision.Imports[0], namespacedAlias(path, collision.Alias, collision.Type), path)
+		}
+	}
+
+	return nil
+}
+
+// namespaceImportAlias imports the contribution using a namespaced alias when its alias is already used by another
+// contribution of the same type, the refs to its import path are rewritten to use the alias
+func namespaceImportAlias(project common.AppProject, imp util.Import) error {
+
+	contribs, err := newContribResolver(project)
+	if err != nil {
+		return err
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	for _, collision := range contribs.aliasCollisions(appObj) {
+		var others []string
+		for _, path := range collision.Imports {
+			if path != imp.GoImportPath() {
+				others = append(others, path)
+			}
+		}
+		if len(others) == len(collision.Imports) {
+			continue
+		}
+
+		alias := namespacedAlias(imp.GoImportPath(), collision.Alias, collision.Type)
+		if len(contribs.aliasImports[collision.Type][alias]) > 0 {
+			return fmt.Errorf("alias '%s' of %s is already used by %s, install it using an alias, ex. 'flogo install \"myalias %s\"'",
+				collision.Alias, imp.GoImportPath(), strings.Join(others, ", "), imp.GoImportPath())
+		}
+
+		refs := 0
+		err = editAppDescriptor(project, func(text string) (string, error) {
+			text, err := rewriteImportAlias(text, imp.GoImportPath(), alias)
+			if err != nil {
+				return "", err
+			}
+			text, refs, err = rewriteImportRefs(text, imp.GoImportPath(), "#"+alias)
+			return text, err
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Alias '%s' is already used by %s, imported %s as '%s'\n", collision.Alias, strings.Join(others, ", "), imp.GoImportPath(), alias)
+		if refs > 0 {
+			fmt.Printf("Rewrote %d ref(s) to %s as '#%s'\n", refs, imp.GoImportPath(), alias)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// rewriteImportAlias sets the alias of the imports of the app descriptor with the import path, the rest of the
+// document is left untouched
+func rewriteImportAlias(text, importPath, alias string) (string, error) {
+
+	updated := make(map[string]string)
+	err := scanJSON(text, func(n *jsonNode) {
+		if !n.isStr || !isImportsElementPath(n.path) {
+			return
+		}
+		if existing, err := util.ParseImport(n.str); err == nil && existing.GoImportPath() == importPath {
+			updated[n.path] = util.NewFlogoImport(existing.ModulePath(), existing.RelativeImportPath(), existing.Version(), alias).CanonicalImport()
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for path, value := range updated {
+		text, err = setJSONValue(text, path, value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return text, nil
+}
+
+// isImportsElementPath determines if the path is the one of an element of the imports of the app descriptor
+func isImportsElementPath(path string) bool {
+	return strings.HasPrefix(path, "$.imports[") && strings.Index(path, "]") == len(path)-1
+}
+
+// rewriteImportRefs rewrites the refs to the import path of the app descriptor as the ref specified, the rest of the
+// document is left untouched. The number of refs rewritten is returned along with the updated document.
+func rewriteImportRefs(text, importPath, ref string) (string, int, error) {
+
+	var paths []string
+	err := scanJSON(text, func(n *jsonNode) {
+		if !n.isStr || !strings.HasSuffix(n.path, ".ref") || strings.HasPrefix(n.str, "#") {
+			return
+		}
+		if imp, err := util.ParseImport(strings.TrimSpace(n.str)); err == nil && imp.GoImportPath() == importPath {
+			paths = append(paths, n.path)
+		}
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	// replacing a string by another doesn't change the paths of the other values
+	for _, path := range paths {
+		text, err = setJSONValue(text, path, ref)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	return text, len(paths), nil
+}
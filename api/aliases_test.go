@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacedAlias(t *testing.T) {
+	t.Log("Testing namespaced alias of a contribution")
+
+	assert.Equal(t, "acme/log", namespacedAlias("github.com/acme/flogo/activity/log", "log", "activity"))
+	assert.Equal(t, "acme_string", namespacedAlias("github.com/acme/flogo/function/string", "string", "function"))
+	assert.Equal(t, "mycorp/log", namespacedAlias("mycorp/activity/log", "log", "activity"))
+}
+
+func TestValidateAliasCollisions(t *testing.T) {
+	t.Log("Testing detection of the contributions sharing an alias")
+
+	appJson := `{
+		"imports": [
+			"github.com/project-flogo/contrib/activity/log",
+			"github.com/project-flogo/contrib/trigger/rest",
+			"github.com/project-flogo/contrib/activity/rest",
+			"github.com/acme/flogo/activity/log"
+		]
+	}`
+	var appObj map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(appJson), &appObj))
+
+	contribs := &contribResolver{aliasImports: map[string]map[string][]string{
+		"activity": {
+			"log":  {"github.com/acme/flogo/activity/log", "github.com/project-flogo/contrib/activity/log"},
+			"rest": {"github.com/project-flogo/contrib/activity/rest"},
+		},
+		"trigger": {"rest": {"github.com/project-flogo/contrib/trigger/rest"}},
+	}}
+
+	collisions := contribs.aliasCollisions(appObj)
+	assert.Len(t, collisions, 1)
+	assert.Equal(t, "log", collisions[0].Alias)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/log", "github.com/acme/flogo/activity/log"}, collisions[0].Imports)
+
+	ctx := &validationContext{appObj: appObj, contribs: contribs}
+	assert.Nil(t, validateAliasCollisions(ctx))
+	assert.Len(t, ctx.issues, 1)
+	assert.Equal(t, "$.imports[3]", ctx.issues[0].Path)
+	assert.Contains(t, ctx.issues[0].Message, "'acme/log github.com/acme/flogo/activity/log'")
+}
+
+func TestRewriteImportRefs(t *testing.T) {
+	t.Log("Testing rewriting of the refs to an import")
+
+	appJson := `{
+  "imports": [
+    "github.com/project-flogo/contrib/activity/log",
+    "github.com/acme/flogo/activity/log@v1.0.0"
+  ],
+  "resources": [{"id": "flow:a", "data": {"tasks": [
+    {"id": "log1", "activity": {"ref": "github.com/acme/flogo/activity/log"}},
+    {"id": "log2", "activity": {"ref": "#log"}},
+    {"id": "log3", "activity": {"ref": "github.com/project-flogo/contrib/activity/log"}}
+  ]}}]
+}`
+
+	text, refs, err := rewriteImportRefs(appJson, "github.com/acme/flogo/activity/log", "#acme/log")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, refs)
+
+	text, err = rewriteImportAlias(text, "github.com/acme/flogo/activity/log", "acme/log")
+	assert.Nil(t, err)
+
+	// only the import and the ref are changed, the formatting of the rest of the document is kept
+	expected := strings.Replace(appJson, `"github.com/acme/flogo/activity/log@v1.0.0"`, `"acme/log github.com/acme/flogo/activity/log@v1.0.0"`, 1)
+	expected = strings.Replace(expected, `{"ref": "github.com/acme/flogo/activity/log"}`, `{"ref": "#acme/log"}`, 1)
+	assert.Equal(t, expected, text)
+}
@@ -6,6 +6,9 @@ import (
 
 var verbose = false
 
+// cliBuild identifies the CLI building the applications, recorded in their build information
+var cliBuild *util.CLIBuildInfo
+
 func SetVerbose(enable bool) {
 	verbose = enable
 	util.SetVerbose(enable)
@@ -15,4 +18,13 @@ func Verbose() bool {
 	return verbose
 }
 
+// SetCLIBuildInfo sets the build information of the CLI, recorded in the applications it builds
+func SetCLIBuildInfo(info *util.CLIBuildInfo) {
+	cliBuild = info
+}
+
+func CLIBuildInfo() *util.CLIBuildInfo {
+	return cliBuild
+}
+
 //TODO use a logger like struct for API that can be used to log or console output
@@ -0,0 +1,469 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	ApplyOpInstall  = "install"  // install a contribution/dependency, ex. github.com/project-flogo/contrib/activity/rest@v0.10.0
+	ApplyOpRemove   = "remove"   // remove a contribution/dependency from the imports
+	ApplyOpProperty = "property" // set the value of an app property, ex. db.url=postgres://db:5432/app
+	ApplyOpVersion  = "version"  // set the version of the application
+)
+
+// ApplyOptions are the options used to apply a script to the project
+type ApplyOptions struct {
+	DryRun bool // roll the project back once the script is applied, only the changes are printed
+}
+
+// applyOperation is an operation of a script
+type applyOperation struct {
+	Line  int
+	Op    string
+	Value string
+}
+
+func (o *applyOperation) String() string {
+	return o.Op + " " + o.Value
+}
+
+// applySnapshot is the state of the project compared to print the changes of a script
+type applySnapshot struct {
+	Version    string
+	Imports    []string
+	Properties map[string]interface{}
+	Modules    map[string]string
+}
+
+// ApplyScript applies the operations of a script as a single transaction, if an operation fails the project is
+// rolled back to its state before the script. The script is a YAML (or JSON) list of operations, ex.
+//
+//   - install: github.com/project-flogo/contrib/activity/rest@v0.10.0
+//   - remove: github.com/project-flogo/contrib/activity/log
+//   - property: db.url=postgres://db:5432/app
+//   - version: 1.1.0
+func ApplyScript(ctx context.Context, project common.AppProject, scriptFile string, options ApplyOptions) error {
+
+	buf, err := ioutil.ReadFile(scriptFile)
+	if err != nil {
+		return err
+	}
+
+	ops, err := parseApplyScript(buf)
+	if err != nil {
+		return fmt.Errorf("invalid script '%s': %v", scriptFile, err)
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf("script '%s' doesn't contain any operation", scriptFile)
+	}
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	backup, err := backupProjectFiles(project)
+	if err != nil {
+		return err
+	}
+
+	before, err := getApplySnapshot(project)
+	if err != nil {
+		return err
+	}
+
+	for i, op := range ops {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(ops), op)
+
+		err = applyOperationTo(ctx, project, op)
+		if err != nil {
+			if rbErr := restoreProjectFiles(backup); rbErr != nil {
+				return fmt.Errorf("operation '%s' (line %d) failed: %v\nunable to roll the project back: %v", op, op.Line, err, rbErr)
+			}
+			return fmt.Errorf("operation '%s' (line %d) failed, the project was rolled back: %v", op, op.Line, err)
+		}
+	}
+
+	after, err := getApplySnapshot(project)
+	if err != nil {
+		return err
+	}
+
+	changes := diffApplySnapshots(before, after)
+
+	fmt.Println()
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+	} else {
+		fmt.Println("Changes:")
+		for _, change := range changes {
+			fmt.Printf("  %s\n", change)
+		}
+	}
+
+	if options.DryRun {
+		err = restoreProjectFiles(backup)
+		if err != nil {
+			return fmt.Errorf("unable to roll the project back: %v", err)
+		}
+		fmt.Println("\nDry run, the project was rolled back")
+	}
+
+	return nil
+}
+
+// parseApplyScript parses the operations of a script, either a JSON list of objects or the equivalent YAML list
+// with an operation per line
+func parseApplyScript(buf []byte) ([]*applyOperation, error) {
+
+	if trimmed := bytes.TrimSpace(buf); len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []map[string]interface{}
+		err := json.Unmarshal(trimmed, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		var ops []*applyOperation
+		for i, item := range items {
+			if len(item) != 1 {
+				return nil, fmt.Errorf("operation %d: expected a single operation, ex. {\"install\": \"<contribution>\"}", i+1)
+			}
+			for op, value := range item {
+				strVal, ok := value.(string)
+				if !ok {
+					buf, _ := json.Marshal(value)
+					strVal = string(buf)
+				}
+				ops = append(ops, &applyOperation{Line: i + 1, Op: op, Value: strVal})
+			}
+		}
+
+		return ops, validateApplyOperations(ops)
+	}
+
+	var ops []*applyOperation
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "- ") {
+			return nil, fmt.Errorf("line %d: expected an operation, ex. '- install: <contribution>'", lineNum)
+		}
+		line = strings.TrimSpace(line[2:])
+
+		idx := strings.Index(line, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("line %d: expected an operation, ex. '- install: <contribution>'", lineNum)
+		}
+
+		value, err := parseYAMLScalar(strings.TrimSpace(line[idx+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+
+		ops = append(ops, &applyOperation{Line: lineNum, Op: strings.TrimSpace(line[:idx]), Value: value})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ops, validateApplyOperations(ops)
+}
+
+// parseYAMLScalar parses a plain, single or double quoted YAML scalar
+func parseYAMLScalar(value string) (string, error) {
+
+	switch {
+	case strings.HasPrefix(value, `"`):
+		return strconv.Unquote(value)
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated string %s", value)
+		}
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1), nil
+	}
+
+	// a plain scalar ends at a comment
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+
+	return value, nil
+}
+
+func validateApplyOperations(ops []*applyOperation) error {
+
+	for _, op := range ops {
+		switch op.Op {
+		case ApplyOpInstall, ApplyOpRemove, ApplyOpVersion:
+		case ApplyOpProperty:
+			if idx := strings.Index(op.Value, "="); idx <= 0 {
+				return fmt.Errorf("line %d: invalid property '%s', expected 'name=value'", op.Line, op.Value)
+			}
+		default:
+			return fmt.Errorf("line %d: unknown operation '%s', expected %s, %s, %s or %s", op.Line, op.Op,
+				ApplyOpInstall, ApplyOpRemove, ApplyOpProperty, ApplyOpVersion)
+		}
+
+		if op.Value == "" {
+			return fmt.Errorf("line %d: missing value of operation '%s'", op.Line, op.Op)
+		}
+	}
+
+	return nil
+}
+
+func applyOperationTo(ctx context.Context, project common.AppProject, op *applyOperation) error {
+
+	switch op.Op {
+	case ApplyOpInstall:
+		return InstallPackage(ctx, project, op.Value)
+	case ApplyOpRemove:
+		return removePackage(project, op.Value)
+	case ApplyOpProperty:
+		kvs, err := parseKeyValues([]string{op.Value})
+		if err != nil {
+			return err
+		}
+		for name, value := range kvs {
+			err = setAppProperty(project, name, value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case ApplyOpVersion:
+		return writeAppDescriptorValue(project, "$.version", op.Value)
+	}
+
+	return fmt.Errorf("unknown operation '%s'", op.Op)
+}
+
+// removePackage removes a contribution/dependency from the imports of the app descriptor and src/imports.go
+func removePackage(project common.AppProject, pkg string) error {
+
+	flogoImport, err := util.ParseImport(pkg)
+	if err != nil {
+		return err
+	}
+
+	var appImports []string
+	_, err = readAppDescriptorValue(project, "$.imports", &appImports)
+	if err != nil {
+		return err
+	}
+
+	var remaining []string
+	removed := false
+	for _, appImport := range appImports {
+		imp, err := util.ParseImport(appImport)
+		if err == nil && imp.GoImportPath() == flogoImport.GoImportPath() {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, appImport)
+	}
+	if !removed {
+		return fmt.Errorf("'%s' isn't imported by the application", flogoImport.GoImportPath())
+	}
+	if remaining == nil {
+		remaining = []string{}
+	}
+
+	err = writeAppDescriptorValue(project, "$.imports", remaining)
+	if err != nil {
+		return err
+	}
+
+	err = project.RemoveImports(flogoImport.GoImportPath())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed: %s\n", flogoImport.GoImportPath())
+
+	return nil
+}
+
+// setAppProperty sets the value of an app property, converted to its declared type, the property is added if the app
+// doesn't declare it
+func setAppProperty(project common.AppProject, name string, value interface{}) error {
+
+	var props []map[string]interface{}
+	_, err := readAppDescriptorValue(project, "$.properties", &props)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, prop := range props {
+		if prop["name"] == name {
+			propType, _ := prop["type"].(string)
+			coerced, err := coercePropertyValue(propType, value)
+			if err != nil {
+				return fmt.Errorf("invalid value for property '%s' at $.properties[%d].value: %v", name, i, err)
+			}
+			prop["value"] = coerced
+			found = true
+		}
+	}
+	if !found {
+		props = append(props, map[string]interface{}{"name": name, "type": propertyType(value), "value": value})
+	}
+
+	return writeAppDescriptorValue(project, "$.properties", props)
+}
+
+// propertyType gets the type of a property added with value
+func propertyType(value interface{}) string {
+
+	switch v := value.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "int"
+		}
+		return "float64"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+
+	return "string"
+}
+
+// backupProjectFiles reads the files modified by the operations of a script, a nil content means the file doesn't exist
+func backupProjectFiles(project common.AppProject) (map[string][]byte, error) {
+
+	files := []string{
+		filepath.Join(project.Dir(), fileFlogoJson),
+		filepath.Join(project.SrcDir(), fileImportsGo),
+		filepath.Join(project.SrcDir(), "go.mod"),
+		filepath.Join(project.SrcDir(), "go.sum"),
+	}
+
+	backup := make(map[string][]byte, len(files))
+	for _, file := range files {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		backup[file] = buf
+	}
+
+	return backup, nil
+}
+
+func restoreProjectFiles(backup map[string][]byte) error {
+
+	for file, buf := range backup {
+		if buf == nil {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := util.WriteFileAtomic(file, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getApplySnapshot(project common.AppProject) (*applySnapshot, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &applySnapshot{Properties: make(map[string]interface{}), Modules: goModRequirements(project.SrcDir())}
+	snapshot.Version, _ = appObj["version"].(string)
+
+	imports, _ := appObj["imports"].([]interface{})
+	for _, imp := range imports {
+		if s, ok := imp.(string); ok {
+			snapshot.Imports = append(snapshot.Imports, s)
+		}
+	}
+
+	for _, prop := range getAppProperties(appObj) {
+		snapshot.Properties[prop.Name] = prop.Value
+	}
+
+	return snapshot, nil
+}
+
+// diffApplySnapshots describes the changes of the project made by a script
+func diffApplySnapshots(before, after *applySnapshot) []string {
+
+	var changes []string
+
+	if before.Version != after.Version {
+		changes = append(changes, fmt.Sprintf("version: %s -> %s", before.Version, after.Version))
+	}
+
+	beforeImports := make(map[string]bool)
+	for _, imp := range before.Imports {
+		beforeImports[imp] = true
+	}
+	afterImports := make(map[string]bool)
+	for _, imp := range after.Imports {
+		afterImports[imp] = true
+		if !beforeImports[imp] {
+			changes = append(changes, fmt.Sprintf("import added: %s", imp))
+		}
+	}
+	for _, imp := range before.Imports {
+		if !afterImports[imp] {
+			changes = append(changes, fmt.Sprintf("import removed: %s", imp))
+		}
+	}
+
+	var propChanges []string
+	for name, value := range after.Properties {
+		oldValue, exists := before.Properties[name]
+		switch {
+		case !exists:
+			propChanges = append(propChanges, fmt.Sprintf("property added: %s = %s", name, formatPropertyValue(value)))
+		case !reflect.DeepEqual(oldValue, value):
+			propChanges = append(propChanges, fmt.Sprintf("property %s: %s -> %s", name, formatPropertyValue(oldValue), formatPropertyValue(value)))
+		}
+	}
+	sort.Strings(propChanges)
+	changes = append(changes, propChanges...)
+
+	for _, change := range diffModules(before.Modules, after.Modules) {
+		changes = append(changes, "module "+change)
+	}
+
+	return changes
+}
+
+func formatPropertyValue(value interface{}) string {
+	buf, _ := json.Marshal(value)
+	return string(buf)
+}
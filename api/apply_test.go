@@ -0,0 +1,67 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApplyScript(t *testing.T) {
+
+	script := `# release 1.1
+---
+- install: github.com/project-flogo/contrib/activity/rest@v0.10.0
+- remove: "github.com/project-flogo/contrib/activity/log"
+- property: db.url=postgres://db:5432/app # new database
+- version: '1.1.0'
+`
+	ops, err := parseApplyScript([]byte(script))
+	assert.Nil(t, err)
+	assert.Len(t, ops, 4)
+	assert.Equal(t, &applyOperation{Line: 3, Op: ApplyOpInstall, Value: "github.com/project-flogo/contrib/activity/rest@v0.10.0"}, ops[0])
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/log", ops[1].Value)
+	assert.Equal(t, "db.url=postgres://db:5432/app", ops[2].Value)
+	assert.Equal(t, "1.1.0", ops[3].Value)
+
+	ops, err = parseApplyScript([]byte(`[{"install": "github.com/project-flogo/flow"}, {"property": "port=8080"}]`))
+	assert.Nil(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, ApplyOpProperty, ops[1].Op)
+
+	_, err = parseApplyScript([]byte("- instal: github.com/project-flogo/flow\n"))
+	assert.NotNil(t, err)
+
+	_, err = parseApplyScript([]byte("- property: port\n"))
+	assert.NotNil(t, err)
+
+	_, err = parseApplyScript([]byte("install: github.com/project-flogo/flow\n"))
+	assert.NotNil(t, err)
+}
+
+func TestDiffApplySnapshots(t *testing.T) {
+
+	before := &applySnapshot{
+		Version:    "1.0.0",
+		Imports:    []string{"github.com/a", "github.com/b"},
+		Properties: map[string]interface{}{"port": 8080.0, "db.url": "localhost"},
+		Modules:    map[string]string{"github.com/a": "v1.0.0"},
+	}
+	after := &applySnapshot{
+		Version:    "1.1.0",
+		Imports:    []string{"github.com/a", "github.com/c"},
+		Properties: map[string]interface{}{"port": 8080.0, "db.url": "db", "retries": 3.0},
+		Modules:    map[string]string{"github.com/a": "v1.1.0"},
+	}
+
+	changes := diffApplySnapshots(before, after)
+	assert.Equal(t, []string{
+		"version: 1.0.0 -> 1.1.0",
+		"import added: github.com/c",
+		"import removed: github.com/b",
+		`property added: retries = 3`,
+		`property db.url: "localhost" -> "db"`,
+		"module github.com/a v1.0.0 -> v1.1.0",
+	}, changes)
+
+	assert.Empty(t, diffApplySnapshots(before, before))
+}
@@ -0,0 +1,524 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	dirBlueprints     = "blueprints"
+	fileReadme        = "README.md"
+	fileBlueprintSpec = "blueprint.json"
+	blueprintFileType = "flogo:blueprint"
+)
+
+var blueprintNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Blueprint is a shareable starting point created from an application, its descriptor stripped of secrets and its
+// app properties exposed as variables
+type Blueprint struct {
+	Type        string               `json:"type"`
+	Name        string               `json:"name"`
+	Version     string               `json:"version,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Readme      string               `json:"readme,omitempty"`
+	Variables   []*BlueprintVariable `json:"variables,omitempty"`
+	Stripped    []string             `json:"stripped,omitempty"` // the paths of the secret values removed from the descriptor
+	Hooks       []*BlueprintHook     `json:"hooks,omitempty"`
+	App         json.RawMessage      `json:"app"`
+}
+
+// BlueprintVariable is a value set when the blueprint is used, it is the value of the app property of the same name,
+// if there is one, and is available to the hooks
+type BlueprintVariable struct {
+	Name     string        `json:"name"`
+	Type     string        `json:"type,omitempty"`
+	Default  interface{}   `json:"default,omitempty"`
+	Required bool          `json:"required,omitempty"` // the value of the property was a secret
+	Prompt   string        `json:"prompt,omitempty"`   // the question asked when the blueprint is used interactively
+	Allowed  []interface{} `json:"allowed,omitempty"`  // the values allowed, any value of the type by default
+}
+
+// BlueprintSpec declares the variables and the hooks of the blueprint published from the project, in the
+// blueprint.json of the project
+type BlueprintSpec struct {
+	Variables []*BlueprintVariable `json:"variables,omitempty"`
+	Hooks     []*BlueprintHook     `json:"hooks,omitempty"`
+}
+
+// BlueprintPublishOptions are the options used to publish a blueprint
+type BlueprintPublishOptions struct {
+	Name   string // the name of the blueprint, the app name by default
+	Output string // the file to write the blueprint to instead of the catalog
+}
+
+// BlueprintUseOptions are the options used to create an application from a blueprint
+type BlueprintUseOptions struct {
+	AppName     string   // the name of the application, the name of the app of the blueprint by default
+	Vars        []string // the values of the variables, as name=value
+	Interactive bool     // prompt for the values of the variables which aren't set
+	Yes         bool     // run the hooks of the blueprint without confirmation
+	NoHooks     bool     // don't run the hooks of the blueprint
+}
+
+// PublishBlueprint packages the app descriptor, with its secrets stripped, its app properties as variables and the
+// README of the project into a blueprint, which is added to the local catalog. The variables and the hooks declared in
+// the blueprint.json of the project are added to the blueprint
+func PublishBlueprint(project common.AppProject, options BlueprintPublishOptions) error {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return err
+	}
+
+	var appObj map[string]interface{}
+	err = json.Unmarshal(buf, &appObj)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %v", fileFlogoJson, err)
+	}
+
+	name := options.Name
+	if name == "" {
+		name, _ = appObj["name"].(string)
+	}
+	if !blueprintNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid blueprint name '%s', only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+
+	appJson, stripped, err := stripSecretValues(string(buf), appObj)
+	if err != nil {
+		return err
+	}
+
+	blueprint := &Blueprint{Type: blueprintFileType, Name: name, Stripped: stripped, App: json.RawMessage(appJson)}
+	blueprint.Version, _ = appObj["version"].(string)
+	blueprint.Description, _ = appObj["description"].(string)
+
+	if readme, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileReadme)); err == nil {
+		blueprint.Readme = string(readme)
+	}
+
+	props, _ := appObj["properties"].([]interface{})
+	for i, prop := range props {
+		propMap, _ := prop.(map[string]interface{})
+		name, _ := propMap["name"].(string)
+		if name == "" {
+			continue
+		}
+		propType, _ := propMap["type"].(string)
+		variable := &BlueprintVariable{Name: name, Type: propType, Default: propMap["value"]}
+		if isStrippedPath(stripped, fmt.Sprintf("$.properties[%d]", i)) {
+			variable.Default = nil
+			variable.Required = true
+		}
+		blueprint.Variables = append(blueprint.Variables, variable)
+	}
+
+	err = applyBlueprintSpec(project, blueprint)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(blueprint, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+
+	file := options.Output
+	if file == "" {
+		dir, err := blueprintCatalogDir()
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		file = filepath.Join(dir, name+".json")
+	}
+
+	err = util.WriteFileAtomic(file, out, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Published blueprint '%s' to %s\n", name, file)
+	if len(stripped) > 0 {
+		fmt.Printf("Stripped %d secret value(s):\n", len(stripped))
+		for _, path := range stripped {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+
+	return nil
+}
+
+// UseBlueprint creates an application project from a blueprint of the catalog, or a blueprint file or URL, setting
+// the app properties to the values of the variables
+func UseBlueprint(ctx context.Context, basePath, source string, options BlueprintUseOptions) (common.AppProject, error) {
+
+	blueprint, err := loadBlueprint(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var ask func(variable *BlueprintVariable) (string, error)
+	if options.Interactive {
+		ask = askBlueprintVariable
+	}
+
+	values, err := resolveBlueprintVariables(blueprint, options.Vars, ask)
+	if err != nil {
+		return nil, err
+	}
+
+	appJson, err := instantiateBlueprint(blueprint, values)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := GetTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	appFile := filepath.Join(tempDir, fileFlogoJson)
+	err = util.WriteFileAtomic(appFile, []byte(appJson), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := CreateProject(ctx, basePath, options.AppName, appFile, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if blueprint.Readme != "" {
+		err = util.WriteFileAtomic(filepath.Join(project.Dir(), fileReadme), []byte(blueprint.Readme), 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("Created application '%s' from blueprint '%s'\n", project.Name(), blueprint.Name)
+
+	if len(blueprint.Hooks) > 0 {
+		if options.NoHooks || (!options.Yes && !confirmBlueprintHooks(blueprint)) {
+			fmt.Printf("Skipped %d hook(s) of the blueprint\n", len(blueprint.Hooks))
+		} else {
+			err = runBlueprintHooks(ctx, project, blueprint, values)
+			if err != nil {
+				return project, err
+			}
+		}
+	}
+
+	// the stripped app properties were set using the variables
+	var unset []string
+	for _, path := range blueprint.Stripped {
+		if !strings.HasPrefix(path, "$.properties[") {
+			unset = append(unset, path)
+		}
+	}
+	if len(unset) > 0 {
+		fmt.Printf("The following secret values were stripped from the blueprint and must be set in %s:\n", fileFlogoJson)
+		for _, path := range unset {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+
+	return project, nil
+}
+
+// ListBlueprints lists the blueprints of the local catalog
+func ListBlueprints() error {
+
+	dir, err := blueprintCatalogDir()
+	if err != nil {
+		return err
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	sort.Strings(files)
+
+	if len(files) == 0 {
+		fmt.Println("No blueprints published")
+		return nil
+	}
+
+	for _, file := range files {
+		blueprint, err := readBlueprintFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		fmt.Printf("%-30s %s\n", blueprint.Name, blueprint.Description)
+	}
+
+	return nil
+}
+
+// stripSecretValues replaces the encrypted and plaintext secret values of the app descriptor by empty values, the
+// paths of the stripped values are returned
+func stripSecretValues(appJson string, appObj map[string]interface{}) (string, []string, error) {
+
+	var findings []*SecretFinding
+	scanForSecrets("$", "", appObj, &findings)
+	findEncryptedSecrets("$", appObj, &findings)
+
+	var stripped []string
+	for _, finding := range findings {
+		stripped = append(stripped, finding.Path)
+	}
+	sort.Strings(stripped)
+
+	var err error
+	for _, path := range stripped {
+		appJson, err = setJSONValue(appJson, path, "")
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return appJson, stripped, nil
+}
+
+// findEncryptedSecrets finds the encrypted secret values, which are resolved at runtime using the key of the author
+func findEncryptedSecrets(path string, item interface{}, findings *[]*SecretFinding) {
+	switch t := item.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			findEncryptedSecrets(jsonMemberPath(path, k), v, findings)
+		}
+	case []interface{}:
+		for i, v := range t {
+			findEncryptedSecrets(fmt.Sprintf("%s[%d]", path, i), v, findings)
+		}
+	case string:
+		if strings.HasPrefix(strings.TrimSpace(t), secretPrefix) {
+			*findings = append(*findings, &SecretFinding{Path: path, Reason: "encrypted secret value"})
+		}
+	}
+}
+
+func isStrippedPath(stripped []string, prefix string) bool {
+	for _, path := range stripped {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveBlueprintVariables gets the values of the variables of the blueprint: the values set, the answers to the
+// prompts, if asked, or the defaults. The values are validated against the types and the allowed values of the
+// variables, the required variables must have a value
+func resolveBlueprintVariables(blueprint *Blueprint, vars []string, ask func(variable *BlueprintVariable) (string, error)) (map[string]interface{}, error) {
+
+	values, err := parseKeyValues(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]*BlueprintVariable, len(blueprint.Variables))
+	for _, variable := range blueprint.Variables {
+		variables[variable.Name] = variable
+	}
+
+	var unknown []string
+	for name := range values {
+		if _, exists := variables[name]; !exists {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown variable(s) '%s', the variables of the blueprint are: %s", strings.Join(unknown, "', '"), joinOrDash(blueprintVariableNames(blueprint)))
+	}
+
+	var missing []string
+	for _, variable := range blueprint.Variables {
+		value, set := values[variable.Name]
+		if !set && ask != nil {
+			answer, err := ask(variable)
+			if err != nil {
+				return nil, err
+			}
+			if answer != "" {
+				value, set = parseValue(answer), true
+			}
+		}
+		if !set {
+			if variable.Required {
+				missing = append(missing, variable.Name)
+			} else if variable.Default != nil {
+				values[variable.Name] = variable.Default
+			}
+			continue
+		}
+
+		value, err = coercePropertyValue(variable.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for variable '%s': %v", variable.Name, err)
+		}
+		if !isAllowedValue(variable.Allowed, value) {
+			return nil, fmt.Errorf("invalid value for variable '%s': expected one of %s", variable.Name, formatAllowedValues(variable.Allowed))
+		}
+		values[variable.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing value for the required variable(s) '%s', set them using --var <name>=<value>", strings.Join(missing, "', '"))
+	}
+
+	return values, nil
+}
+
+// askBlueprintVariable prompts for the value of the variable, the default value is proposed
+func askBlueprintVariable(variable *BlueprintVariable) (string, error) {
+
+	question := variable.Prompt
+	if question == "" {
+		question = variable.Name
+	}
+	if len(variable.Allowed) > 0 {
+		question += " (" + formatAllowedValues(variable.Allowed) + ")"
+	}
+
+	defaultValue := ""
+	if variable.Default != nil {
+		defaultValue = fmt.Sprint(variable.Default)
+	}
+
+	return util.Prompt(question, defaultValue)
+}
+
+// isAllowedValue checks if the value is one of the allowed values, any value is allowed if there are none
+func isAllowedValue(allowed []interface{}, value interface{}) bool {
+
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if fmt.Sprint(a) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatAllowedValues(allowed []interface{}) string {
+	var strs []string
+	for _, a := range allowed {
+		strs = append(strs, fmt.Sprint(a))
+	}
+	return strings.Join(strs, ", ")
+}
+
+// instantiateBlueprint gets the app descriptor of the blueprint with the app properties set to the values of the
+// variables of the same name
+func instantiateBlueprint(blueprint *Blueprint, values map[string]interface{}) (string, error) {
+
+	appJson := string(blueprint.App)
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal(blueprint.App, &appObj)
+	if err != nil {
+		return "", fmt.Errorf("invalid app of blueprint '%s': %v", blueprint.Name, err)
+	}
+
+	props, _ := appObj["properties"].([]interface{})
+	for i, prop := range props {
+		propMap, _ := prop.(map[string]interface{})
+		name, _ := propMap["name"].(string)
+		value, set := values[name]
+		if !set {
+			continue
+		}
+		appJson, err = setJSONValue(appJson, fmt.Sprintf("$.properties[%d].value", i), value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return appJson, nil
+}
+
+func blueprintVariableNames(blueprint *Blueprint) []string {
+	var names []string
+	for _, variable := range blueprint.Variables {
+		names = append(names, variable.Name)
+	}
+	return names
+}
+
+// loadBlueprint loads a blueprint from a URL, a file or the local catalog
+func loadBlueprint(source string) (*Blueprint, error) {
+
+	if util.IsRemote(source) {
+		content, err := util.LoadRemoteFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load remote blueprint '%s' - %s", source, err.Error())
+		}
+		return parseBlueprint(source, []byte(content))
+	}
+
+	if util.FileExists(source) {
+		return readBlueprintFile(source)
+	}
+
+	dir, err := blueprintCatalogDir()
+	if err != nil {
+		return nil, err
+	}
+
+	file := filepath.Join(dir, source+".json")
+	if !blueprintNamePattern.MatchString(source) || !util.FileExists(file) {
+		return nil, fmt.Errorf("blueprint '%s' not found, run 'flogo blueprint list' to list the published blueprints", source)
+	}
+
+	return readBlueprintFile(file)
+}
+
+func readBlueprintFile(file string) (*Blueprint, error) {
+
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlueprint(file, buf)
+}
+
+func parseBlueprint(source string, buf []byte) (*Blueprint, error) {
+
+	blueprint := &Blueprint{}
+	err := json.Unmarshal(buf, blueprint)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse blueprint '%s': %v", source, err)
+	}
+	if blueprint.Type != blueprintFileType || len(blueprint.App) == 0 {
+		return nil, fmt.Errorf("'%s' isn't a blueprint", source)
+	}
+
+	return blueprint, nil
+}
+
+func blueprintCatalogDir() (string, error) {
+
+	home, err := util.GetFlogoHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, dirBlueprints), nil
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const envPrefixBlueprintVar = "FLOGO_VAR_"
+
+var (
+	envNameInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+	hookVariablePattern = regexp.MustCompile(`\$\{[^}]+\}`)
+)
+
+// BlueprintHook is run once the application is created from the blueprint, ex. to install the contributions
+// required by the values of the variables. The values of the variables are referenced as ${name}
+type BlueprintHook struct {
+	If      string   `json:"if,omitempty"`      // condition on a variable: name, !name, name=value or name!=value
+	Install []string `json:"install,omitempty"` // contributions/dependencies to install
+	Command []string `json:"command,omitempty"` // flogo command, ex. the command of a plugin, run in the project directory
+	Run     string   `json:"run,omitempty"`     // shell command run in the project directory
+}
+
+// applyBlueprintSpec adds the variables and the hooks declared in the blueprint.json of the project to the blueprint,
+// the declaration of a variable of an app property completes it
+func applyBlueprintSpec(project common.AppProject, blueprint *Blueprint) error {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileBlueprintSpec))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	spec := &BlueprintSpec{}
+	err = json.Unmarshal(buf, spec)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %v", fileBlueprintSpec, err)
+	}
+
+	variables := make(map[string]*BlueprintVariable)
+	for _, variable := range blueprint.Variables {
+		variables[variable.Name] = variable
+	}
+
+	for _, declared := range spec.Variables {
+		if declared.Name == "" {
+			return fmt.Errorf("invalid %s: variable without a name", fileBlueprintSpec)
+		}
+		variable, exists := variables[declared.Name]
+		if !exists {
+			blueprint.Variables = append(blueprint.Variables, declared)
+			variables[declared.Name] = declared
+			continue
+		}
+		if declared.Type != "" {
+			variable.Type = declared.Type
+		}
+		if declared.Default != nil && !variable.Required {
+			variable.Default = declared.Default
+		}
+		variable.Required = variable.Required || declared.Required
+		variable.Prompt = declared.Prompt
+		variable.Allowed = declared.Allowed
+	}
+
+	for _, variable := range blueprint.Variables {
+		if variable.Default != nil && !isAllowedValue(variable.Allowed, variable.Default) {
+			return fmt.Errorf("invalid %s: the default value of variable '%s' isn't one of %s", fileBlueprintSpec, variable.Name, formatAllowedValues(variable.Allowed))
+		}
+	}
+
+	for i, hook := range spec.Hooks {
+		if len(hook.Install) == 0 && len(hook.Command) == 0 && hook.Run == "" {
+			return fmt.Errorf("invalid %s: hook %d has nothing to install or run", fileBlueprintSpec, i+1)
+		}
+		if name := hookConditionVariable(hook.If); name != "" && variables[name] == nil {
+			return fmt.Errorf("invalid %s: the condition of hook %d references the unknown variable '%s'", fileBlueprintSpec, i+1, name)
+		}
+	}
+	blueprint.Hooks = spec.Hooks
+
+	return nil
+}
+
+// runBlueprintHooks runs the hooks of the blueprint whose condition is met in the directory of the project created
+// from it, the values of the variables are also set in the environment of the commands as FLOGO_VAR_<NAME>
+func runBlueprintHooks(ctx context.Context, project common.AppProject, blueprint *Blueprint, values map[string]interface{}) error {
+
+	// only the ${name} of the variables are replaced, the other ones are left to the shell
+	expand := func(s string) string {
+		return hookVariablePattern.ReplaceAllStringFunc(s, func(ref string) string {
+			if value, set := values[ref[2:len(ref)-1]]; set {
+				return fmt.Sprint(value)
+			}
+			return ref
+		})
+	}
+
+	env := os.Environ()
+	for name, value := range values {
+		envName := envPrefixBlueprintVar + strings.ToUpper(envNameInvalidChars.ReplaceAllString(name, "_"))
+		env = append(env, envName+"="+fmt.Sprint(value))
+	}
+
+	for i, hook := range blueprint.Hooks {
+		if !isHookConditionMet(hook.If, values) {
+			if Verbose() {
+				fmt.Printf("Skipping hook %d, '%s' isn't met\n", i+1, hook.If)
+			}
+			continue
+		}
+
+		for _, pkg := range hook.Install {
+			err := InstallPackage(ctx, project, expand(pkg))
+			if err != nil {
+				return fmt.Errorf("hook %d of blueprint '%s' failed: %v", i+1, blueprint.Name, err)
+			}
+		}
+
+		if len(hook.Command) > 0 {
+			exe, err := os.Executable()
+			if err != nil {
+				return err
+			}
+			var args []string
+			for _, arg := range hook.Command {
+				args = append(args, expand(arg))
+			}
+			fmt.Printf("Running: flogo %s\n", strings.Join(args, " "))
+			err = runHookCmd(project, exec.Command(exe, args...), env)
+			if err != nil {
+				return fmt.Errorf("hook %d of blueprint '%s' failed: %v", i+1, blueprint.Name, err)
+			}
+		}
+
+		if hook.Run != "" {
+			script := expand(hook.Run)
+			var cmd *exec.Cmd
+			if runtime.GOOS == "windows" {
+				cmd = exec.Command("cmd", "/C", script)
+			} else {
+				cmd = exec.Command("sh", "-c", script)
+			}
+			fmt.Printf("Running: %s\n", script)
+			err := runHookCmd(project, cmd, env)
+			if err != nil {
+				return fmt.Errorf("hook %d of blueprint '%s' failed: %v", i+1, blueprint.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func runHookCmd(project common.AppProject, cmd *exec.Cmd, env []string) error {
+	cmd.Dir = project.Dir()
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// isHookConditionMet evaluates the condition of a hook: name and !name check that the value of the variable is or
+// isn't set to a value other than false, 0 or an empty string, name=value and name!=value compare its value
+func isHookConditionMet(condition string, values map[string]interface{}) bool {
+
+	condition = strings.TrimSpace(condition)
+	if condition == "" {
+		return true
+	}
+
+	if idx := strings.Index(condition, "!="); idx > 0 {
+		name, expected := strings.TrimSpace(condition[:idx]), strings.TrimSpace(condition[idx+2:])
+		return hookValue(values, name) != expected
+	}
+	if idx := strings.Index(condition, "="); idx > 0 {
+		name, expected := strings.TrimSpace(condition[:idx]), strings.TrimSpace(condition[idx+1:])
+		return hookValue(values, name) == expected
+	}
+	if strings.HasPrefix(condition, "!") {
+		return !isHookValueSet(hookValue(values, strings.TrimSpace(condition[1:])))
+	}
+
+	return isHookValueSet(hookValue(values, condition))
+}
+
+// hookConditionVariable gets the name of the variable the condition of a hook is on
+func hookConditionVariable(condition string) string {
+
+	name := strings.TrimSpace(condition)
+	if idx := strings.Index(name, "="); idx > 0 {
+		name = strings.TrimSuffix(name[:idx], "!")
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(name, "!"))
+}
+
+func hookValue(values map[string]interface{}, name string) string {
+	value, set := values[name]
+	if !set || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func isHookValueSet(value string) bool {
+	return value != "" && value != "false" && value != "0"
+}
+
+// confirmBlueprintHooks lists the hooks of the blueprint, which run commands on the machine, and asks for the
+// confirmation to run them
+func confirmBlueprintHooks(blueprint *Blueprint) bool {
+
+	fmt.Printf("Blueprint '%s' has the following hooks:\n", blueprint.Name)
+	for _, desc := range blueprintHookDescriptions(blueprint) {
+		fmt.Printf("  %s\n", desc)
+	}
+
+	return util.Confirm("Run them?")
+}
+
+// blueprintHookDescriptions describes the hooks of the blueprint
+func blueprintHookDescriptions(blueprint *Blueprint) []string {
+
+	var descs []string
+	for _, hook := range blueprint.Hooks {
+		var actions []string
+		if len(hook.Install) > 0 {
+			actions = append(actions, "install "+strings.Join(hook.Install, " "))
+		}
+		if len(hook.Command) > 0 {
+			actions = append(actions, "flogo "+strings.Join(hook.Command, " "))
+		}
+		if hook.Run != "" {
+			actions = append(actions, hook.Run)
+		}
+		desc := strings.Join(actions, ", ")
+		if hook.If != "" {
+			desc = "if " + hook.If + ": " + desc
+		}
+		descs = append(descs, desc)
+	}
+
+	return descs
+}
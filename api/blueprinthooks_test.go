@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHookConditionMet(t *testing.T) {
+	t.Log("Testing evaluation of the conditions of the blueprint hooks")
+
+	values := map[string]interface{}{"db": "postgres", "metrics": true, "replicas": 0.0, "tracing": false}
+
+	assert.True(t, isHookConditionMet("", values))
+	assert.True(t, isHookConditionMet("db=postgres", values))
+	assert.False(t, isHookConditionMet("db = mysql", values))
+	assert.True(t, isHookConditionMet("db!=mysql", values))
+	assert.True(t, isHookConditionMet("metrics", values))
+	assert.False(t, isHookConditionMet("tracing", values))
+	assert.False(t, isHookConditionMet("replicas", values))
+	assert.True(t, isHookConditionMet("!tracing", values))
+	assert.False(t, isHookConditionMet("cache", values))
+
+	assert.Equal(t, "db", hookConditionVariable("db!=mysql"))
+	assert.Equal(t, "db", hookConditionVariable(" db = mysql"))
+	assert.Equal(t, "tracing", hookConditionVariable("!tracing"))
+}
+
+func TestApplyBlueprintSpec(t *testing.T) {
+	t.Log("Testing declaration of the variables and hooks of a blueprint")
+
+	tempDir, err := ioutil.TempDir("", "flogo-blueprint")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	blueprint := &Blueprint{
+		Name: "orders",
+		Variables: []*BlueprintVariable{
+			{Name: "port", Type: "int", Default: 8080.0},
+			{Name: "db.password", Type: "string", Required: true},
+		},
+	}
+
+	// no blueprint.json
+	assert.Nil(t, applyBlueprintSpec(project, blueprint))
+	assert.Len(t, blueprint.Variables, 2)
+
+	spec := `{
+  "variables": [
+    {"name": "port", "prompt": "Port of the REST trigger"},
+    {"name": "db.password", "default": "changeme"},
+    {"name": "db", "type": "string", "default": "postgres", "allowed": ["postgres", "mysql"]}
+  ],
+  "hooks": [
+    {"if": "db=postgres", "install": ["github.com/myorg/activity/postgres"]},
+    {"run": "echo ${db}"}
+  ]
+}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileBlueprintSpec), []byte(spec), 0644))
+	assert.Nil(t, applyBlueprintSpec(project, blueprint))
+
+	assert.Len(t, blueprint.Variables, 3)
+	assert.Equal(t, "Port of the REST trigger", blueprint.Variables[0].Prompt)
+	assert.Equal(t, 8080.0, blueprint.Variables[0].Default)
+	// the default value of a secret isn't set
+	assert.Nil(t, blueprint.Variables[1].Default)
+	assert.Equal(t, []interface{}{"postgres", "mysql"}, blueprint.Variables[2].Allowed)
+	assert.Len(t, blueprint.Hooks, 2)
+	assert.Equal(t, []string{"if db=postgres: install github.com/myorg/activity/postgres", "echo ${db}"}, blueprintHookDescriptions(blueprint))
+
+	spec = `{"hooks": [{"if": "cache", "run": "echo"}]}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileBlueprintSpec), []byte(spec), 0644))
+	assert.NotNil(t, applyBlueprintSpec(project, blueprint))
+
+	spec = `{"variables": [{"name": "db", "default": "oracle", "allowed": ["postgres", "mysql"]}]}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileBlueprintSpec), []byte(spec), 0644))
+	assert.NotNil(t, applyBlueprintSpec(project, blueprint))
+}
+
+func TestRunBlueprintHooks(t *testing.T) {
+	t.Log("Testing the run of the hooks of a blueprint")
+
+	tempDir, err := ioutil.TempDir("", "flogo-blueprint")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	blueprint := &Blueprint{
+		Name: "orders",
+		Hooks: []*BlueprintHook{
+			{If: "db=mysql", Run: "echo mysql > mysql.txt"},
+			{If: "db=postgres", Run: "echo ${db} $FLOGO_VAR_DB_PORT > db.txt"},
+		},
+	}
+	values := map[string]interface{}{"db": "postgres", "db.port": 5432.0}
+
+	assert.Nil(t, runBlueprintHooks(context.Background(), NewAppProject(tempDir), blueprint, values))
+
+	buf, err := ioutil.ReadFile(filepath.Join(tempDir, "db.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "postgres 5432\n", string(buf))
+	assert.False(t, util.FileExists(filepath.Join(tempDir, "mysql.txt")))
+
+	blueprint.Hooks = []*BlueprintHook{{Run: "exit 3"}}
+	assert.NotNil(t, runBlueprintHooks(context.Background(), NewAppProject(tempDir), blueprint, values))
+}
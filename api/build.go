@@ -1,14 +1,16 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"go/parser"
-	"go/printer"
 	"go/token"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
@@ -18,9 +20,79 @@ const (
 	fileEmbeddedAppGo string = "embeddedapp.go"
 )
 
-func BuildProject(project common.AppProject, options common.BuildOptions) error {
+func BuildProject(ctx context.Context, project common.AppProject, options common.BuildOptions) error {
 
-	err := project.DepManager().AddReplacedContribForBuild()
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if options.ShimTarget != "" {
+		// the platform is also the one of the provenance
+		restorePlatform, err := setShimTargetPlatform(options.ShimTarget)
+		if err != nil {
+			return err
+		}
+		defer restorePlatform()
+	}
+
+	start := time.Now()
+	err = translateBuildError(project, buildProject(ctx, project, options))
+	if err == nil {
+		err = checkSizeBudget(project, options)
+	}
+	if err == nil && !options.Frozen {
+		updateProjectLock(project)
+	}
+	if err == nil && options.Provenance != nil {
+		err = writeProvenance(ctx, project, options, start, time.Now())
+	}
+	if err == nil && options.Signing != nil {
+		err = signArtifacts(ctx, project, options)
+	}
+
+	recordBuild(project, options, start, err)
+
+	return err
+}
+
+func buildProject(ctx context.Context, project common.AppProject, options common.BuildOptions) error {
+
+	err := common.DispatchHook(&common.HookEvent{Type: common.HookPreBuild, Project: project, Options: &options})
+	if err != nil {
+		return err
+	}
+
+	err = checkGoVersion(project)
+	if err != nil {
+		return err
+	}
+
+	if options.Offline || options.Bundle != "" {
+		if options.Docker != nil {
+			return fmt.Errorf("an offline build can't build a container image")
+		}
+		restoreEnv, err := setOfflineEnv(options.Bundle)
+		if err != nil {
+			return err
+		}
+		defer restoreEnv()
+	}
+
+	if options.Frozen {
+		err = checkFrozenLock(project)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = checkSecrets(project, options.FailOnSecrets)
+	if err != nil {
+		return err
+	}
+
+	err = project.DepManager().AddReplacedContribForBuild(ctx)
 	if err != nil {
 		return err
 	}
@@ -39,15 +111,74 @@ func BuildProject(project common.AppProject, options common.BuildOptions) error
 	var builder common.Builder
 	embedConfig := options.EmbedConfig
 
-	if options.Shim != "" {
-		builder = &ShimBuilder{shim:options.Shim}
+	if options.Shim != "" && len(options.Platforms) > 0 {
+		return fmt.Errorf("a shim can't be built for several platforms, set GOOS and GOARCH instead")
+	}
+
+	if options.Docker != nil && (options.Shim != "" || len(options.Platforms) > 0) {
+		return fmt.Errorf("an image can't be built with a shim or for several platforms")
+	}
+
+	if options.Docker != nil && options.Provenance != nil {
+		return fmt.Errorf("the provenance of an image can't be written, it describes executables")
+	}
+
+	if options.ShimTarget != "" && options.Shim == "" {
+		return fmt.Errorf("the trigger of the shim must be specified to package it for %s", options.ShimTarget)
+	}
+
+	err = validateSigningOptions(options)
+	if err != nil {
+		return err
+	}
+
+	if options.ProfileEnv && options.Profile == "" {
+		return fmt.Errorf("the profile whose values are written to an env file isn't specified")
+	}
+	if options.Profile != "" {
+		if _, err := loadProfile(project, options.Profile); err != nil {
+			return err
+		}
+	}
+
+	info, err := createBuildInfoGoFile(project, options)
+	if err != nil {
+		return err
+	}
+	options.LDFlags = strings.TrimSpace(options.LDFlags + " " + buildInfoLdflags(info))
+
+	if options.Shim != "" && shimPortEnv(options) != "" {
+		// the trigger serves the requests forwarded by the platform, the application is built as usual
+		builder = &AppBuilder{options: options}
+		embedConfig = true
+	} else if options.Shim != "" {
+		builder = &ShimBuilder{shim: options.Shim, options: options}
+		embedConfig = true
+	} else if options.Docker != nil {
+		// the image only contains the executable
+		builder = &DockerBuilder{options: options}
 		embedConfig = true
 	} else {
-		builder = &AppBuilder{}
+		builder = &AppBuilder{options: options}
+	}
+
+	if options.Variant != "" {
+		// the variant only exists in the build, so the configuration has to be embedded
+		embedConfig = true
+	}
+
+	if options.Profile != "" && !options.ProfileEnv {
+		// the values of the profile are baked into the embedded configuration
+		embedConfig = true
 	}
 
 	if embedConfig {
-		err = createEmbeddedAppGoFile(project)
+		flogoJSON, err := getBuildAppDescriptor(project, options)
+		if err != nil {
+			return err
+		}
+
+		err = createEmbeddedAppGoFile(project, flogoJSON)
 		if err != nil {
 			return err
 		}
@@ -58,9 +189,36 @@ func BuildProject(project common.AppProject, options common.BuildOptions) error
 		}
 	}
 
+	if options.Management {
+		err = createManagementGoFile(project)
+	} else {
+		err = cleanupManagementGoFile(project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if options.Trace != "" {
+		err = createTraceGoFile(project, options.Trace)
+	} else {
+		err = cleanupTraceGoFile(project)
+	}
+	if err != nil {
+		return err
+	}
+
+	if options.Debug {
+		err = createDebugGoFile(project)
+	} else {
+		err = cleanupDebugGoFile(project)
+	}
+	if err != nil {
+		return err
+	}
+
 	if options.OptimizeImports {
 		if Verbose() {
-			fmt.Println("Optimizing imports...")
+			fmt.Println(util.T("build.optimizing"))
 		}
 		err := optimizeImports(project)
 		defer restoreImports(project)
@@ -70,11 +228,66 @@ func BuildProject(project common.AppProject, options common.BuildOptions) error
 		}
 	}
 
-	err = builder.Build(project)
+	generated, err := applyImportConstraints(project)
+	defer cleanupConstrainedImports(generated)
+	defer restoreImports(project)
+	if err != nil {
+		return err
+	}
+
+	// the imports constrained to some platforms are dropped by go from the builds of the others
+	platformsGenerated, err := applyBuildConstraints(project)
+	defer cleanupConstrainedImports(platformsGenerated)
 	if err != nil {
 		return err
 	}
 
+	// the executables are only built by go build when an input changed
+	_, cacheable := builder.(*AppBuilder)
+	fingerprint := ""
+	if cacheable {
+		fingerprint, err = buildFingerprint(project, options)
+		if err != nil && Verbose() {
+			fmt.Fprintf(os.Stderr, "Unable to fingerprint build: %v\n", err)
+		}
+	}
+
+	if fingerprint != "" && !options.Force && isBuildUpToDate(project, options, fingerprint) {
+		fmt.Println(util.T("build.upToDate", project.Name()))
+	} else {
+		err = builder.Build(ctx, project)
+		if err != nil {
+			return err
+		}
+		if fingerprint != "" {
+			recordBuildCache(project, options, fingerprint)
+		}
+	}
+
+	if options.Profile != "" && options.ProfileEnv {
+		envFile, err := writeProfileEnvFile(project, options.Profile)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Wrote the values of profile '%s' to %s\n", options.Profile, relProjectPath(project, envFile))
+	}
+
+	if options.ShimTarget != "" {
+		zipFile, err := packageShim(project, options)
+		if err != nil {
+			return err
+		}
+		fmt.Println(util.T("build.packagedShim", options.ShimTarget, zipFile))
+	}
+
+	if options.Frozen {
+		// go build may have updated go.mod
+		err = checkFrozenLock(project)
+		if err != nil {
+			return err
+		}
+	}
+
 	buildPostProcessors := common.BuildPostProcessors()
 
 	if len(buildPostProcessors) > 0 {
@@ -86,6 +299,28 @@ func BuildProject(project common.AppProject, options common.BuildOptions) error
 		}
 	}
 
+	return common.DispatchHook(&common.HookEvent{Type: common.HookPostBuild, Project: project, Options: &options})
+}
+
+func checkSecrets(project common.AppProject, failOnSecrets bool) error {
+
+	findings, err := ScanSecrets(project)
+	if err != nil {
+		return err
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	for _, finding := range findings {
+		fmt.Fprintf(os.Stderr, "Warning: possible plaintext secret at %s\n", finding)
+	}
+
+	if failOnSecrets {
+		return fmt.Errorf("%d possible plaintext secret(s) found in %s", len(findings), fileFlogoJson)
+	}
+
 	return nil
 }
 
@@ -104,19 +339,65 @@ func cleanupEmbeddedAppGoFile(project common.AppProject) error {
 		return nil
 }
 
-func createEmbeddedAppGoFile(project common.AppProject) error {
+// getBuildAppDescriptor gets the app descriptor to use for the build
+func getBuildAppDescriptor(project common.AppProject, options common.BuildOptions) (string, error) {
 
-	embedSrcPath := filepath.Join(project.SrcDir(), fileEmbeddedAppGo)
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return "", err
+	}
 
-	if Verbose() {
-		fmt.Println("Embedding configuration in application...")
+	portEnv := shimPortEnv(options)
+
+	bakeProfile := options.Profile != "" && !options.ProfileEnv
+
+	if options.Variant == "" && portEnv == "" && !bakeProfile {
+		return string(buf), nil
 	}
 
-	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	var appObj map[string]interface{}
+	err = json.Unmarshal(buf, &appObj)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if options.Variant != "" {
+		err = applyVariant(appObj, options.Variant)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if bakeProfile {
+		profile, err := loadProfile(project, options.Profile)
+		if err != nil {
+			return "", err
+		}
+		applyProfile(appObj, profile)
+	}
+
+	if portEnv != "" {
+		err = bindShimTriggerPort(appObj, options.Shim, portEnv)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	buf, err = json.MarshalIndent(appObj, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func createEmbeddedAppGoFile(project common.AppProject, flogoJSON string) error {
+
+	embedSrcPath := filepath.Join(project.SrcDir(), fileEmbeddedAppGo)
+
+	if Verbose() {
+		fmt.Println(util.T("build.embedding"))
 	}
-	flogoJSON := string(buf)
 
 	tplFile := tplEmbeddedAppGoFile
 	if !isNewMain(project) {
@@ -126,30 +407,36 @@ func createEmbeddedAppGoFile(project common.AppProject) error {
 	engineJSON := ""
 
 	if util.FileExists(filepath.Join(project.Dir(), fileEngineJson)) {
-		buf, err = ioutil.ReadFile(filepath.Join(project.Dir(), fileEngineJson))
+		buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileEngineJson))
+		if err != nil {
+			return err
+		}
+
+		// the engine configuration is compressed as well, the engine decompresses both if cfgCompressed is set
+		engineJSON, err = encodeEmbeddedConfig(buf)
 		if err != nil {
 			return err
 		}
+	}
 
-		engineJSON = string(buf)
+	config, start, end, err := embeddedConfigValue([]byte(flogoJSON))
+	if err != nil {
+		return err
 	}
 
 	data := struct {
-		FlogoJSON string
-		EngineJSON string
+		Config      string
+		ConfigStart int
+		ConfigEnd   int
+		EngineJSON  string
 	}{
-		flogoJSON,
+		config,
+		start,
+		end,
 		engineJSON,
 	}
 
-	f, err := os.Create(embedSrcPath)
-	if err != nil {
-		return err
-	}
-	RenderTemplate(f, tplFile, &data)
-	_ = f.Close()
-
-	return nil
+	return writeTemplateFile(embedSrcPath, tplFile, &data)
 }
 
 func isNewMain(project common.AppProject) bool {
@@ -169,13 +456,21 @@ var tplEmbeddedAppGoFile = `// Do not change this file, it has been generated us
 // If you change it and rebuild the application your changes might get lost
 package main
 
-// embedded flogo app descriptor file
-const flogoJSON string = ` + "`{{.FlogoJSON}}`" + `
+import (
+	"runtime"
+)
+
+// embedded flogo app descriptor file, gzip compressed and base64 encoded, along with its SHA-256 read by 'flogo inspect'
+var flogoEmbeddedConfig = {{.Config}}
+
 const engineJSON string = ` + "`{{.EngineJSON}}`" + `
 
 func init () {
-	cfgJson = flogoJSON
+	// keeps the whole embedded configuration in the executable
+	runtime.KeepAlive(flogoEmbeddedConfig)
+	cfgJson = flogoEmbeddedConfig[{{.ConfigStart}}:{{.ConfigEnd}}]
 	cfgEngine = engineJSON
+	cfgCompressed = true
 }
 `
 
@@ -183,11 +478,18 @@ var tplEmbeddedAppOldGoFile = `// Do not change this file, it has been generated
 // If you change it and rebuild the application your changes might get lost
 package main
 
-// embedded flogo app descriptor file
-const flogoJSON string = ` + "`{{.FlogoJSON}}`" + `
+import (
+	"runtime"
+)
+
+// embedded flogo app descriptor file, gzip compressed and base64 encoded, along with its SHA-256 read by 'flogo inspect'
+var flogoEmbeddedConfig = {{.Config}}
 
 func init () {
-	cfgJson = flogoJSON
+	// keeps the whole embedded configuration in the executable
+	runtime.KeepAlive(flogoEmbeddedConfig)
+	cfgJson = flogoEmbeddedConfig[{{.ConfigStart}}:{{.ConfigEnd}}]
+	cfgCompressed = true
 }
 `
 
@@ -251,14 +553,13 @@ func optimizeImports(project common.AppProject) error {
 
 	for _, i := range unused {
 		if Verbose() {
-			fmt.Printf("  Removing Import: %s\n", i.GoImportPath())
+			fmt.Println("  " + util.T("build.removingImport", i.GoImportPath()))
 		}
 		util.DeleteImport(fset, file, i.GoImportPath())
 	}
 
-	f, err := os.Create(importsFile)
-	defer f.Close()
-	if err := printer.Fprint(f, fset, file); err != nil {
+	err = util.WriteGoFile(importsFile, fset, file)
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,238 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileBuildCache = "build.json"
+
+// the environment variables changing the executables built by go build
+var buildEnvVars = []string{"GOOS", "GOARCH", "GOARM", "GOAMD64", "GO386", "CGO_ENABLED", "GOFLAGS", "GOEXPERIMENT", "CC", "CGO_CFLAGS", "CGO_LDFLAGS"}
+
+// buildCacheContent records the inputs of the last build of the executables, so it is skipped when they didn't change
+type buildCacheContent struct {
+	Fingerprint string            `json:"fingerprint"` // the hash of the inputs of the build
+	Executables map[string]string `json:"executables"` // the hash of the executables built, keyed by path
+}
+
+// buildFingerprint hashes the inputs of the go build of the executables: flogo.json and engine.json, whose resources
+// are embedded in the application, the sources of the src directory (imports.go, go.mod, go.sum and the generated
+// files), the local directories replacing modules, the build tags and platforms, the environment, the git commit and
+// the version of Go. The build information is left out, it records the time of the build.
+func buildFingerprint(project common.AppProject, options common.BuildOptions) (string, error) {
+
+	h := sha256.New()
+
+	for _, file := range []string{filepath.Join(project.Dir(), fileFlogoJson), filepath.Join(project.Dir(), fileEngineJson)} {
+		err := hashBuildFile(h, file, filepath.Base(file))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err := hashBuildDir(h, project.SrcDir(), "src", func(rel string) bool {
+		return rel == fileBuildInfoGo
+	})
+	if err != nil {
+		return "", err
+	}
+
+	replaces := goModReplaces(project.SrcDir())
+	var modules []string
+	for module, target := range replaces {
+		if isLocalReplacePath(target) {
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		dir := filepath.FromSlash(replaces[module])
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(project.SrcDir(), dir)
+		}
+		// only the sources are hashed, the local directory may contain anything else
+		err = hashBuildDir(h, dir, module, func(rel string) bool {
+			return filepath.Ext(rel) != ".go" && filepath.Ext(rel) != ".json" && filepath.Base(rel) != "go.mod" && filepath.Base(rel) != "go.sum"
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	fmt.Fprintf(h, "tags %s\n", strings.Join(options.Tags, ","))
+	fmt.Fprintf(h, "platforms %s\n", strings.Join(options.Platforms, ","))
+	fmt.Fprintf(h, "goos %s\n", GOOSENV)
+	// the commit is embedded in the executables
+	fmt.Fprintf(h, "commit %s\n", projectCommit(project))
+	for _, name := range buildEnvVars {
+		fmt.Fprintf(h, "env %s=%s\n", name, os.Getenv(name))
+	}
+	out, err := exec.Command("go", "version").Output()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "%s", out)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isBuildUpToDate determines if the executables of the build exist and were built from the inputs of the fingerprint
+func isBuildUpToDate(project common.AppProject, options common.BuildOptions, fingerprint string) bool {
+
+	cache := readBuildCache(project)
+	if cache == nil || cache.Fingerprint != fingerprint {
+		return false
+	}
+
+	executables, err := buildExecutables(project, options)
+	if err != nil || len(executables) != len(cache.Executables) {
+		return false
+	}
+	for _, exe := range executables {
+		// the executable may have been rebuilt or replaced since
+		if hash := util.FileHash(exe); hash == "" || hash != cache.Executables[exe] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordBuildCache records the fingerprint of the inputs of the executables built, failing to do so doesn't fail the
+// build
+func recordBuildCache(project common.AppProject, options common.BuildOptions, fingerprint string) {
+
+	cache := &buildCacheContent{Fingerprint: fingerprint, Executables: make(map[string]string)}
+
+	executables, err := buildExecutables(project, options)
+	for _, exe := range executables {
+		if err != nil {
+			break
+		}
+		cache.Executables[exe] = util.FileHash(exe)
+		if cache.Executables[exe] == "" {
+			err = fmt.Errorf("unable to read executable '%s'", exe)
+		}
+	}
+
+	var buf []byte
+	if err == nil {
+		buf, err = json.MarshalIndent(cache, "", jsonIndent)
+	}
+	if err == nil {
+		err = os.MkdirAll(filepath.Join(project.Dir(), dirProjectFlogo, dirProjectCache), 0755)
+	}
+	if err == nil {
+		err = util.WriteFileAtomic(buildCacheFile(project), buf, 0644)
+	}
+	if err != nil && Verbose() {
+		fmt.Fprintf(os.Stderr, "Unable to record build cache: %v\n", err)
+	}
+}
+
+func readBuildCache(project common.AppProject) *buildCacheContent {
+
+	buf, err := ioutil.ReadFile(buildCacheFile(project))
+	if err != nil {
+		return nil
+	}
+
+	cache := &buildCacheContent{}
+	if json.Unmarshal(buf, cache) != nil {
+		return nil
+	}
+
+	return cache
+}
+
+func buildCacheFile(project common.AppProject) string {
+	return filepath.Join(project.Dir(), dirProjectFlogo, dirProjectCache, fileBuildCache)
+}
+
+// buildExecutables gets the executables built: the one of the project or one per platform
+func buildExecutables(project common.AppProject, options common.BuildOptions) ([]string, error) {
+
+	if len(options.Platforms) == 0 {
+		return []string{project.Executable()}, nil
+	}
+
+	platforms, err := ParsePlatforms(options.Platforms)
+	if err != nil {
+		return nil, err
+	}
+
+	var executables []string
+	for _, platform := range platforms {
+		executables = append(executables, PlatformExecutable(project, platform))
+	}
+
+	return executables, nil
+}
+
+func hashBuildFile(h io.Writer, file, name string) error {
+
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(h, "file %s\n", name)
+	_, err = io.Copy(h, f)
+
+	return err
+}
+
+// hashBuildDir hashes the files of the directory, sorted by path, except the skipped ones
+func hashBuildDir(h io.Writer, dir, name string, skip func(rel string) bool) error {
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if !skip(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, rel := range files {
+		err = hashBuildFile(h, filepath.Join(dir, rel), name+"/"+filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCache(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "buildcache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	appDir := filepath.Join(tempDir, "app")
+	contribDir := filepath.Join(tempDir, "contrib")
+	for _, dir := range []string{filepath.Join(appDir, dirSrc), filepath.Join(appDir, dirBin), contribDir} {
+		assert.Nil(t, os.MkdirAll(dir, 0755))
+	}
+	files := map[string]string{
+		filepath.Join(appDir, fileFlogoJson):           `{"name": "app"}`,
+		filepath.Join(appDir, dirSrc, "go.mod"):        "module main\n\nreplace example.com/contrib => ../../contrib\n",
+		filepath.Join(appDir, dirSrc, fileImportsGo):   "package main\n",
+		filepath.Join(appDir, dirSrc, fileBuildInfoGo): "package main\n",
+		filepath.Join(contribDir, "activity.go"):       "package contrib\n",
+		filepath.Join(contribDir, "README.md"):         "contrib\n",
+	}
+	for file, content := range files {
+		assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0644))
+	}
+
+	project := NewAppProject(appDir)
+	options := common.BuildOptions{}
+
+	fingerprint, err := buildFingerprint(project, options)
+	assert.Nil(t, err)
+	assert.False(t, isBuildUpToDate(project, options, fingerprint))
+
+	assert.Nil(t, ioutil.WriteFile(project.Executable(), []byte("executable"), 0755))
+	recordBuildCache(project, options, fingerprint)
+	assert.True(t, isBuildUpToDate(project, options, fingerprint))
+
+	// the build information and the other files of the local directories aren't inputs of the build
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, dirSrc, fileBuildInfoGo), []byte("package main\n// built\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(contribDir, "README.md"), []byte("changed\n"), 0644))
+	unchanged, err := buildFingerprint(project, options)
+	assert.Nil(t, err)
+	assert.Equal(t, fingerprint, unchanged)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(contribDir, "activity.go"), []byte("package contrib\n// changed\n"), 0644))
+	changed, err := buildFingerprint(project, options)
+	assert.Nil(t, err)
+	assert.NotEqual(t, fingerprint, changed)
+	assert.False(t, isBuildUpToDate(project, options, changed))
+
+	tagged, err := buildFingerprint(project, common.BuildOptions{Tags: []string{"dev"}})
+	assert.Nil(t, err)
+	assert.NotEqual(t, changed, tagged)
+
+	// a replaced executable is built again
+	recordBuildCache(project, options, changed)
+	assert.Nil(t, ioutil.WriteFile(project.Executable(), []byte("other executable"), 0755))
+	assert.False(t, isBuildUpToDate(project, options, changed))
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileBuildConstraints = "build-constraints.json"
+
+// importPlatformConstraint restricts the platforms an import is built for, the platforms are os/arch patterns whose
+// os or arch can be *, ex. linux/arm or windows/*
+type importPlatformConstraint struct {
+	Platforms []string `json:"platforms,omitempty"` // the only platforms the import is built for
+	Exclude   []string `json:"exclude,omitempty"`   // the platforms the import isn't built for
+}
+
+// includes determines if the import is built for the platform
+func (c *importPlatformConstraint) includes(goos, goarch string) bool {
+
+	matches := func(pattern string) bool {
+		parts := strings.SplitN(pattern, "/", 2)
+		return (parts[0] == "*" || parts[0] == goos) && (parts[1] == "*" || parts[1] == goarch)
+	}
+
+	for _, pattern := range c.Exclude {
+		if matches(pattern) {
+			return false
+		}
+	}
+	if len(c.Platforms) == 0 {
+		return true
+	}
+	for _, pattern := range c.Platforms {
+		if matches(pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildLines gets the //go:build expression and the // +build lines of the platforms of the import
+func (c *importPlatformConstraint) buildLines() (string, []string) {
+
+	// the os and arch are build tags, * matches any of them
+	and := func(pattern, sep string) string {
+		var tags []string
+		for _, part := range strings.SplitN(pattern, "/", 2) {
+			if part != "*" {
+				tags = append(tags, part)
+			}
+		}
+		return strings.Join(tags, sep)
+	}
+	not := func(pattern, sep string) string {
+		var tags []string
+		for _, part := range strings.SplitN(pattern, "/", 2) {
+			if part != "*" {
+				tags = append(tags, "!"+part)
+			}
+		}
+		return strings.Join(tags, sep)
+	}
+
+	group := func(expr, op string) string {
+		if strings.Contains(expr, op) {
+			return "(" + expr + ")"
+		}
+		return expr
+	}
+
+	var exprs, plusLines []string
+
+	if len(c.Platforms) > 0 {
+		var included, plusIncluded []string
+		for _, pattern := range c.Platforms {
+			included = append(included, and(pattern, " && "))
+			plusIncluded = append(plusIncluded, and(pattern, ","))
+		}
+		if len(included) > 1 {
+			for i := range included {
+				included[i] = group(included[i], "&&")
+			}
+		}
+		exprs = append(exprs, strings.Join(included, " || "))
+		plusLines = append(plusLines, strings.Join(plusIncluded, " "))
+	}
+	for _, pattern := range c.Exclude {
+		// !(os && arch) is !os || !arch
+		exprs = append(exprs, not(pattern, " || "))
+		plusLines = append(plusLines, not(pattern, " "))
+	}
+
+	if len(exprs) > 1 {
+		for i := range exprs {
+			exprs[i] = group(exprs[i], "||")
+		}
+	}
+
+	return strings.Join(exprs, " && "), plusLines
+}
+
+// loadBuildConstraints loads the platform constraints of the imports from the build-constraints.json of the project,
+// keyed by import path or alias, nil if the project has none
+func loadBuildConstraints(project common.AppProject) (map[string]*importPlatformConstraint, error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileBuildConstraints))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	constraints := make(map[string]*importPlatformConstraint)
+	err = json.Unmarshal(buf, &constraints)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", fileBuildConstraints, err)
+	}
+
+	for imp, constraint := range constraints {
+		if constraint == nil || len(constraint.Platforms) == 0 && len(constraint.Exclude) == 0 {
+			return nil, fmt.Errorf("no platforms or exclusions defined for import '%s' in %s", imp, fileBuildConstraints)
+		}
+		for _, pattern := range append(append([]string{}, constraint.Platforms...), constraint.Exclude...) {
+			parts := strings.Split(pattern, "/")
+			if len(parts) != 2 || parts[0] == "*" && parts[1] == "*" ||
+				parts[0] != "*" && !platformPartPattern.MatchString(parts[0]) || parts[1] != "*" && !platformPartPattern.MatchString(parts[1]) {
+				return nil, fmt.Errorf("invalid platform '%s' of import '%s' in %s, expected os/arch, the os or the arch can be *", pattern, imp, fileBuildConstraints)
+			}
+		}
+	}
+
+	return constraints, nil
+}
+
+// excludedImports gets the imports of the application which aren't built for the platform
+func excludedImports(project common.AppProject, goos, goarch string) []string {
+
+	constraints, err := loadBuildConstraints(project)
+	if err != nil || len(constraints) == 0 {
+		return nil
+	}
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil
+	}
+
+	var excluded []string
+	for impPath, alias := range importAliases(appObj) {
+		constraint, ok := constraints[impPath]
+		if !ok {
+			constraint, ok = constraints[alias]
+		}
+		if ok && !constraint.includes(goos, goarch) {
+			excluded = append(excluded, impPath)
+		}
+	}
+	sort.Strings(excluded)
+
+	return excluded
+}
+
+// buildTargetPlatform gets the os and arch of the executable built when no platforms are specified
+func buildTargetPlatform() (string, string) {
+
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+	if GOOSENV != "" {
+		goos = GOOSENV
+	}
+	if env := os.Getenv("GOARCH"); env != "" {
+		goarch = env
+	}
+
+	return goos, goarch
+}
+
+// applyBuildConstraints moves the imports constrained to some platforms from imports.go to generated
+// imports_platforms_<n>.go files, which go only compiles for these platforms, the list of generated files is returned
+func applyBuildConstraints(project common.AppProject) ([]string, error) {
+
+	constraints, err := loadBuildConstraints(project)
+	if err != nil || len(constraints) == 0 {
+		return nil, err
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+	aliases := importAliases(appObj)
+
+	importsFile := filepath.Join(project.SrcDir(), fileImportsGo)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, importsFile, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	constrained := make(map[string]*importPlatformConstraint)
+	var impPaths []string
+
+	for _, is := range file.Imports {
+		impPath, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		constraint, ok := constraints[impPath]
+		if !ok {
+			constraint, ok = constraints[aliases[impPath]]
+		}
+		if !ok {
+			continue
+		}
+
+		constrained[impPath] = constraint
+		impPaths = append(impPaths, impPath)
+	}
+
+	if len(constrained) == 0 {
+		return nil, nil
+	}
+	sort.Strings(impPaths)
+
+	importsFileOrig := importsFile + ".orig"
+	if !util.FileExists(importsFileOrig) {
+		err = util.CopyFile(importsFile, importsFileOrig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var generated []string
+
+	for i, impPath := range impPaths {
+		expr, plusLines := constrained[impPath].buildLines()
+		if Verbose() {
+			fmt.Println("  " + util.T("build.constrainedImport", impPath, expr))
+		}
+		util.DeleteImport(fset, file, impPath)
+
+		platformsFile := filepath.Join(project.SrcDir(), fmt.Sprintf("imports_platforms_%d.go", i+1))
+		var buf bytes.Buffer
+		err := tplPlatformImportsGoFile.Execute(&buf, struct {
+			Expr      string
+			PlusLines []string
+			Import    string
+		}{expr, plusLines, impPath})
+		if err == nil {
+			err = util.WriteFileAtomic(platformsFile, buf.Bytes(), 0644)
+		}
+		if err != nil {
+			return generated, err
+		}
+
+		generated = append(generated, platformsFile)
+	}
+
+	err = util.WriteGoFile(importsFile, fset, file)
+
+	return generated, err
+}
+
+var tplPlatformImportsGoFile = template.Must(template.New("").Parse(`// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+
+//go:build {{.Expr}}
+{{range .PlusLines}}// +build {{.}}
+{{end}}
+package main
+
+import (
+	_ "{{.Import}}"
+)
+`))
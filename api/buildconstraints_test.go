@@ -0,0 +1,99 @@
+package api
+
+import (
+	"go/build/constraint"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportPlatformConstraint(t *testing.T) {
+
+	platforms := [][2]string{{"linux", "arm"}, {"linux", "arm64"}, {"linux", "amd64"}, {"windows", "amd64"}, {"darwin", "arm64"}, {"windows", "386"}}
+
+	for _, c := range []*importPlatformConstraint{
+		{Platforms: []string{"linux/arm"}},
+		{Platforms: []string{"linux/arm", "linux/arm64"}},
+		{Platforms: []string{"linux/*"}, Exclude: []string{"*/amd64"}},
+		{Exclude: []string{"windows/*"}},
+		{Exclude: []string{"windows/386", "darwin/arm64"}},
+	} {
+		expr, plusLines := c.buildLines()
+
+		goBuild, err := constraint.Parse("//go:build " + expr)
+		assert.Nil(t, err, expr)
+		var plusBuild []constraint.Expr
+		for _, line := range plusLines {
+			e, err := constraint.Parse("// +build " + line)
+			assert.Nil(t, err, line)
+			plusBuild = append(plusBuild, e)
+		}
+
+		// the generated lines and the constraint agree on every platform
+		for _, p := range platforms {
+			tags := func(tag string) bool { return tag == p[0] || tag == p[1] }
+			included := c.includes(p[0], p[1])
+			assert.Equal(t, included, goBuild.Eval(tags), "%s on %s/%s", expr, p[0], p[1])
+			plusIncluded := true
+			for _, e := range plusBuild {
+				plusIncluded = plusIncluded && e.Eval(tags)
+			}
+			assert.Equal(t, included, plusIncluded, "%v on %s/%s", plusLines, p[0], p[1])
+		}
+	}
+
+	expr, plusLines := (&importPlatformConstraint{Platforms: []string{"linux/arm", "linux/arm64"}, Exclude: []string{"*/arm64"}}).buildLines()
+	assert.Equal(t, "((linux && arm) || (linux && arm64)) && !arm64", expr)
+	assert.Equal(t, []string{"linux,arm linux,arm64", "!arm64"}, plusLines)
+}
+
+func TestApplyBuildConstraints(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "constraints")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"imports": ["serial github.com/myorg/contrib/trigger/serial", "github.com/project-flogo/contrib/activity/log"]}`), 0644))
+	imports := "package main\n\nimport (\n\t_ \"github.com/myorg/contrib/trigger/serial\"\n\t_ \"github.com/project-flogo/contrib/activity/log\"\n)\n"
+	importsFile := filepath.Join(project.SrcDir(), fileImportsGo)
+	assert.Nil(t, ioutil.WriteFile(importsFile, []byte(imports), 0644))
+
+	// no constraints
+	generated, err := applyBuildConstraints(project)
+	assert.Nil(t, err)
+	assert.Empty(t, generated)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileBuildConstraints), []byte(`{"serial": {"platforms": ["linux/arm"]}}`), 0644))
+	generated, err = applyBuildConstraints(project)
+	assert.Nil(t, err)
+	assert.Len(t, generated, 1)
+
+	buf, err := ioutil.ReadFile(importsFile)
+	assert.Nil(t, err)
+	assert.False(t, strings.Contains(string(buf), "serial"))
+	buf, err = ioutil.ReadFile(generated[0])
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), "//go:build linux && arm\n// +build linux,arm\n")
+	assert.Contains(t, string(buf), `_ "github.com/myorg/contrib/trigger/serial"`)
+
+	assert.Equal(t, []string{"github.com/myorg/contrib/trigger/serial"}, excludedImports(project, "windows", "amd64"))
+	assert.Empty(t, excludedImports(project, "linux", "arm"))
+
+	cleanupConstrainedImports(generated)
+	restoreImports(project)
+	buf, err = ioutil.ReadFile(importsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, imports, string(buf))
+
+	for _, invalid := range []string{`{"serial": {}}`, `{"serial": {"exclude": ["*/*"]}}`, `{"serial": {"platforms": ["linux"]}}`} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileBuildConstraints), []byte(invalid), 0644))
+		_, err = loadBuildConstraints(project)
+		assert.NotNil(t, err, invalid)
+	}
+}
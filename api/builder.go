@@ -1,26 +1,28 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
 )
 
 type AppBuilder struct {
-
+	options common.BuildOptions
 }
 
-func (*AppBuilder) Build(project common.AppProject) error {
+func (ab *AppBuilder) Build(ctx context.Context, project common.AppProject) error {
 
 	err := restoreMain(project)
 	if err != nil {
 		return err
 	}
 
-	err = simpleGoBuild(project)
+	err = simpleGoBuild(ctx, project, ab.options)
 	if err != nil {
 		return err
 	}
@@ -29,7 +31,7 @@ func (*AppBuilder) Build(project common.AppProject) error {
 }
 
 
-func simpleGoBuild(project common.AppProject) error {
+func simpleGoBuild(ctx context.Context, project common.AppProject, options common.BuildOptions) error {
 	if _, err := os.Stat(project.BinDir()); err != nil {
 		if Verbose() {
 			fmt.Println("Creating 'bin' directory")
@@ -44,11 +46,77 @@ func simpleGoBuild(project common.AppProject) error {
 		fmt.Println("Performing 'go build'...")
 	}
 
-	err := util.ExecCmd(exec.Command("go", "build", "-o", project.Executable()), project.SrcDir())
+	if len(options.Platforms) > 0 {
+		return platformsGoBuild(ctx, project, options)
+	}
+
+	goos, goarch := buildTargetPlatform()
+	for _, imp := range excludedImports(project, goos, goarch) {
+		fmt.Printf("Excluding '%s', it isn't built for %s/%s\n", imp, goos, goarch)
+	}
+
+	args := []string{"build", "-o", project.Executable()}
+	if len(options.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(options.Tags, ","))
+	}
+	if options.LDFlags != "" {
+		args = append(args, "-ldflags", options.LDFlags)
+	}
+
+	err := util.ExecCmd(exec.CommandContext(ctx, "go", args...), project.SrcDir())
 	if err != nil {
 		fmt.Println("Error in building", project.SrcDir())
 		return err
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// platformsGoBuild builds one executable per platform, the build continues with the next platforms when one fails
+func platformsGoBuild(ctx context.Context, project common.AppProject, options common.BuildOptions) error {
+
+	platforms, err := ParsePlatforms(options.Platforms)
+	if err != nil {
+		return err
+	}
+
+	installedGo := installedGoVersion()
+
+	var failures []string
+	for _, platform := range platforms {
+		exe := PlatformExecutable(project, platform)
+
+		fmt.Printf("Building for %s...\n", platform)
+
+		err := checkPlatformSupport(platform, installedGo)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", platform, err))
+			continue
+		}
+		for _, warning := range platformWarnings(project, platform) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		for _, imp := range excludedImports(project, platform.OS, platform.Arch) {
+			fmt.Printf("  Excluding '%s', it isn't built for %s/%s\n", imp, platform.OS, platform.Arch)
+		}
+
+		args, env := platformBuild(platform, exe, options.Tags, options.LDFlags)
+		cmd := exec.CommandContext(ctx, "go", args...)
+		cmd.Env = append(util.GoCmdEnv(project.Dir()), env...)
+		err = util.ExecCmd(cmd, project.SrcDir())
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", platform, strings.TrimSpace(err.Error())))
+			continue
+		}
+
+		if Verbose() {
+			fmt.Printf("Built %s\n", exe)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("build failed for %d of %d platforms:\n%s", len(failures), len(platforms), strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+var (
+	missingPackagePattern  = regexp.MustCompile(`(?:cannot find module providing package|no required module provides package) ([^\s;:]+)`)
+	unknownVersionPattern  = regexp.MustCompile(`([^\s:]+)@([^\s:]+): (?:invalid version: )?(?:unknown revision|reading \S+: (?:404|410))`)
+	checksumPattern        = regexp.MustCompile(`verifying ([^\s@]+)@([^\s:/]+)(?:/go\.mod)?: checksum mismatch`)
+	dependencyErrorPattern = regexp.MustCompile(`/pkg/mod/([^@\s]+)@([^/\s]+)/\S+\.go:\d+(?::\d+)?: (.+)`)
+	redeclaredPattern      = regexp.MustCompile(`imports\S*\.go:\d+(?::\d+)?: (\S+) redeclared`)
+	registeredPattern      = regexp.MustCompile(`(action|activity|trigger|function|service factory|resource loader) already registered: (\S+)`)
+)
+
+// buildError is a failure of the go tooling during the build, along with guidance on fixing it
+type buildError struct {
+	err   error
+	hints []string
+}
+
+func (e *buildError) Error() string {
+	msg := strings.TrimSpace(e.err.Error())
+	for _, hint := range e.hints {
+		msg += "\n\nHint: " + hint
+	}
+	return msg
+}
+
+// translateBuildError adds guidance to the common failures of 'go build' and 'go mod', the error is returned as
+// is if it isn't recognized
+func translateBuildError(project common.AppProject, err error) error {
+
+	if err == nil {
+		return nil
+	}
+
+	hints := buildErrorHints(err.Error(), coreVersion(project))
+	if len(hints) == 0 {
+		return err
+	}
+
+	return &buildError{err: err, hints: hints}
+}
+
+func buildErrorHints(output, coreVer string) []string {
+
+	var hints []string
+	seen := make(map[string]bool)
+
+	addHint := func(format string, args ...interface{}) {
+		hint := fmt.Sprintf(format, args...)
+		if !seen[hint] {
+			seen[hint] = true
+			hints = append(hints, hint)
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+
+		if m := missingPackagePattern.FindStringSubmatch(line); m != nil {
+			addHint("the import '%s' can't be found, check that it is spelled correctly in %s and install it using 'flogo install %s'", m[1], fileFlogoJson, m[1])
+			continue
+		}
+
+		if m := checksumPattern.FindStringSubmatch(line); m != nil {
+			addHint("the checksum of '%s@%s' doesn't match the one in src/go.sum, which happens when a version is republished. "+
+				"If the new content is trusted, remove the lines of '%s %s' from src/go.sum and rebuild", m[1], m[2], m[1], m[2])
+			continue
+		}
+
+		if m := unknownVersionPattern.FindStringSubmatch(line); m != nil {
+			addHint("version '%s' of '%s' doesn't exist, update it to an existing version using 'flogo update %s@<version>'", m[2], m[1], m[1])
+			continue
+		}
+
+		if m := redeclaredPattern.FindStringSubmatch(line); m != nil {
+			addHint("'%s' is imported more than once, remove the duplicate import from %s and run 'flogo imports sync'", m[1], fileFlogoJson)
+			continue
+		}
+
+		if m := registeredPattern.FindStringSubmatch(line); m != nil {
+			addHint("the %s '%s' is registered more than once, which happens when the same contribution is imported from two "+
+				"modules or versions, remove one of them from %s", m[1], m[2], fileFlogoJson)
+			continue
+		}
+
+		if m := dependencyErrorPattern.FindStringSubmatch(line); m != nil && m[1] != flogoCoreRepo {
+			core := flogoCoreRepo
+			if coreVer != "" {
+				core += " " + coreVer
+			}
+			addHint("'%s@%s' doesn't compile, it probably isn't compatible with the version of %s used by the application. "+
+				"Update it using 'flogo update %s' or use the version of the core it was built for using 'flogo update %s@<version>'",
+				m[1], m[2], core, m[1], flogoCoreRepo)
+		}
+	}
+
+	return hints
+}
+
+// coreVersion gets the version of the core required by the project
+func coreVersion(project common.AppProject) string {
+	return goModRequirements(project.SrcDir())[flogoCoreRepo]
+}
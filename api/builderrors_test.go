@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildErrorHints(t *testing.T) {
+	t.Log("Testing translation of go build and go mod failures")
+
+	hints := buildErrorHints(`imports.go:5:2: no required module provides package github.com/myuser/activity/foo; to add it:
+	go get github.com/myuser/activity/foo`, "")
+	assert.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "'flogo install github.com/myuser/activity/foo'")
+
+	hints = buildErrorHints(`go: github.com/myuser/activity@v1.9.9: invalid version: unknown revision v1.9.9`, "")
+	assert.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "version 'v1.9.9' of 'github.com/myuser/activity'")
+
+	hints = buildErrorHints(`verifying github.com/myuser/activity@v1.0.0/go.mod: checksum mismatch
+	downloaded: h1:abc
+	go.sum:     h1:def`, "")
+	assert.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "'github.com/myuser/activity v1.0.0'")
+
+	hints = buildErrorHints(`# github.com/myuser/activity/foo
+/root/go/pkg/mod/github.com/myuser/activity@v0.1.0/foo/activity.go:20:9: undefined: coerce.ToConnection
+/root/go/pkg/mod/github.com/myuser/activity@v0.1.0/foo/activity.go:31:2: too many arguments`, "v0.9.5")
+	assert.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "github.com/project-flogo/core v0.9.5")
+	assert.Contains(t, hints[0], "'flogo update github.com/myuser/activity'")
+
+	hints = buildErrorHints(`./imports.go:9:2: log redeclared as imported package name`, "")
+	assert.Len(t, hints, 1)
+
+	assert.Empty(t, buildErrorHints(`main.go:10:2: syntax error: unexpected }`, ""))
+}
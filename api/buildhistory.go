@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	dirProjectFlogo  = ".flogo"
+	fileBuildHistory = "builds.json"
+
+	maxBuildHistory = 20
+)
+
+// BuildRecord records a build of the application
+type BuildRecord struct {
+	Time     time.Time           `json:"time"`
+	Duration string              `json:"duration"`
+	Options  common.BuildOptions `json:"options"`
+	Success  bool                `json:"success"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// GetBuildHistory gets the most recent builds of the application, most recent first
+func GetBuildHistory(project common.AppProject) ([]*BuildRecord, error) {
+
+	historyFile := filepath.Join(project.Dir(), dirProjectFlogo, fileBuildHistory)
+
+	buf, err := ioutil.ReadFile(historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var history []*BuildRecord
+	err = json.Unmarshal(buf, &history)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse build history: %v", err)
+	}
+
+	return history, nil
+}
+
+// recordBuild adds the build to the build history, failing to do so doesn't fail the build
+func recordBuild(project common.AppProject, options common.BuildOptions, start time.Time, buildErr error) {
+
+	record := &BuildRecord{Time: start, Duration: time.Since(start).Round(time.Millisecond).String(), Options: options, Success: buildErr == nil}
+	if buildErr != nil {
+		record.Error = buildErr.Error()
+	}
+
+	history, _ := GetBuildHistory(project)
+	history = append([]*BuildRecord{record}, history...)
+	if len(history) > maxBuildHistory {
+		history = history[:maxBuildHistory]
+	}
+
+	buf, err := json.MarshalIndent(history, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Join(project.Dir(), dirProjectFlogo), 0755)
+	}
+	if err == nil {
+		err = util.WriteFileAtomic(filepath.Join(project.Dir(), dirProjectFlogo, fileBuildHistory), buf, 0644)
+	}
+	if err != nil && Verbose() {
+		fmt.Fprintf(os.Stderr, "Unable to record build: %v\n", err)
+	}
+}
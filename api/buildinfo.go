@@ -0,0 +1,315 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileBuildInfoGo = "buildinfo.go"
+
+	buildInfoMarker = "FLOGO_BUILD_INFO"
+)
+
+// BuildInfo is the information recorded in the application at build time, it identifies what it was built from
+type BuildInfo struct {
+	Name           string             `json:"name"`
+	Version        string             `json:"version"`
+	Built          time.Time          `json:"built"`
+	Variant        string             `json:"variant,omitempty"`
+	Profile        string             `json:"profile,omitempty"` // the property profile baked into the application
+	Commit         string             `json:"commit,omitempty"`  // the git commit of the project, if it is in a repository
+	Core           string             `json:"core,omitempty"`    // the version of the core the application was built with
+	CLI            *util.CLIBuildInfo `json:"cli,omitempty"`     // the CLI which built the application
+	DescriptorHash string             `json:"descriptorHash"`    // SHA-256 of the flogo.json the application was built from
+	Modules        map[string]string  `json:"modules"`           // the modules required by the application and their version
+}
+
+// createBuildInfoGoFile generates the file recording the build information in the application, the information is
+// returned
+func createBuildInfoGoFile(project common.AppProject, options common.BuildOptions) (*BuildInfo, error) {
+
+	info, err := getBuildInfo(project)
+	if err != nil {
+		return nil, err
+	}
+	info.Built = buildTime()
+	info.Variant = options.Variant
+	if !options.ProfileEnv {
+		info.Profile = options.Profile
+	}
+	info.Commit = projectCommit(project)
+	info.Core = info.Modules[flogoCoreRepo]
+	info.CLI = CLIBuildInfo()
+
+	buf, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeTemplateFile(filepath.Join(project.SrcDir(), fileBuildInfoGo), tplBuildInfoGoFile, &struct{ Info string }{strconv.Quote(buildInfoMarker + string(buf) + buildInfoMarker)})
+
+	return info, err
+}
+
+// buildTime gets the time of the build, SOURCE_DATE_EPOCH when set for a reproducible build
+func buildTime() time.Time {
+
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+	}
+
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// projectCommit gets the git commit of the project, empty if it isn't in a repository
+func projectCommit(project common.AppProject) string {
+
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = project.Dir()
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// buildInfoLdflags gets the -ldflags setting the version, commit and time of the build in the application
+func buildInfoLdflags(info *BuildInfo) string {
+
+	values := []struct{ name, value string }{
+		{"flogoBuildVersion", info.Version},
+		{"flogoBuildCommit", info.Commit},
+		{"flogoBuildTime", info.Built.Format(time.RFC3339)},
+	}
+
+	var flags []string
+	for _, v := range values {
+		if v.value == "" {
+			continue
+		}
+		// the values are quoted as go build splits the flags, they can't contain a quote
+		flags = append(flags, fmt.Sprintf("-X 'main.%s=%s'", v.name, strings.Replace(v.value, "'", "", -1)))
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// getBuildInfo gets the build information of the current state of the project
+func getBuildInfo(project common.AppProject) (*BuildInfo, error) {
+
+	descriptorFile := filepath.Join(project.Dir(), fileFlogoJson)
+
+	info := &BuildInfo{DescriptorHash: util.FileHash(descriptorFile), Modules: goModRequirements(project.SrcDir())}
+	if info.DescriptorHash == "" {
+		return nil, fmt.Errorf("unable to read %s", descriptorFile)
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+	info.Name, _ = appObj["name"].(string)
+	info.Version, _ = appObj["version"].(string)
+
+	return info, nil
+}
+
+// PrintCLIBuildInfo prints the build information of the CLI
+func PrintCLIBuildInfo(w io.Writer, jsonFormat bool) error {
+
+	info := CLIBuildInfo()
+	if info == nil {
+		return fmt.Errorf("no build information of the cli")
+	}
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(info, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w, string(resp))
+		return err
+	}
+
+	fmt.Fprintf(w, "flogo cli version %s\n", info.Version)
+	fmt.Fprintf(w, "  channel : %s\n", info.Channel)
+	if info.Commit != "" {
+		fmt.Fprintf(w, "  commit  : %s\n", info.Commit)
+	}
+	if info.CoreVersion != "" {
+		fmt.Fprintf(w, "  core    : %s\n", info.CoreVersion)
+	}
+	_, err := fmt.Fprintf(w, "  go      : %s\n", info.GoVersion)
+
+	return err
+}
+
+// ReadBuildInfo reads the build information recorded in an application
+func ReadBuildInfo(binary string) (*BuildInfo, error) {
+
+	buf, err := ioutil.ReadFile(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cli itself contains the marker, the last occurrence followed by valid build information is used
+	marker := []byte(buildInfoMarker + "{")
+	end := len(buf)
+	for {
+		start := bytes.LastIndex(buf[:end], marker)
+		if start < 0 {
+			return nil, fmt.Errorf("no build information found in '%s', it isn't a flogo application or was built using an older version of the cli", binary)
+		}
+		end = start
+
+		start += len(buildInfoMarker)
+		length := bytes.Index(buf[start:], []byte("}"+buildInfoMarker))
+		if length < 0 {
+			continue
+		}
+
+		info := &BuildInfo{}
+		if json.Unmarshal(buf[start:start+length+1], info) == nil && info.DescriptorHash != "" {
+			return info, nil
+		}
+	}
+}
+
+// VerifyBinary compares the build information of the application with the project, or the specified app descriptor,
+// an error is returned if they differ
+func VerifyBinary(project common.AppProject, binary, descriptorFile string) error {
+
+	if descriptorFile == "" {
+		if project == nil {
+			return fmt.Errorf("not in a project, specify the app descriptor to compare the application with")
+		}
+		descriptorFile = filepath.Join(project.Dir(), fileFlogoJson)
+	}
+
+	built, err := ReadBuildInfo(binary)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s was built from:\n", binary)
+	fmt.Printf("  app       : %s %s\n", built.Name, built.Version)
+	fmt.Printf("  built     : %s\n", util.FormatDateTime(built.Built.Local()))
+	if built.Commit != "" {
+		fmt.Printf("  commit    : %s\n", built.Commit)
+	}
+	if built.Variant != "" {
+		fmt.Printf("  variant   : %s\n", built.Variant)
+	}
+	if built.Profile != "" {
+		fmt.Printf("  profile   : %s\n", built.Profile)
+	}
+	if built.Core != "" {
+		fmt.Printf("  core      : %s\n", built.Core)
+	}
+	if built.CLI != nil {
+		fmt.Printf("  cli       : %s\n", built.CLI)
+	}
+	fmt.Println()
+
+	drift := false
+
+	hash := util.FileHash(descriptorFile)
+	if hash == "" {
+		return fmt.Errorf("unable to read %s", descriptorFile)
+	}
+	if hash == built.DescriptorHash {
+		fmt.Printf("Descriptor: matches %s\n", descriptorFile)
+	} else {
+		drift = true
+		fmt.Printf("Descriptor: differs from %s\n", descriptorFile)
+		fmt.Printf("  built from sha256:%s\n", built.DescriptorHash)
+		fmt.Printf("  current    sha256:%s\n", hash)
+	}
+
+	if project != nil {
+		changes := diffModules(built.Modules, goModRequirements(project.SrcDir()))
+		if len(changes) == 0 {
+			fmt.Println("Modules   : match src/go.mod")
+		} else {
+			drift = true
+			fmt.Println("Modules   : differ from src/go.mod (application -> project)")
+			for _, change := range changes {
+				fmt.Printf("  %s\n", change)
+			}
+		}
+	}
+
+	if drift {
+		return fmt.Errorf("%s doesn't match the project", binary)
+	}
+
+	return nil
+}
+
+// diffModules describes the differences between the modules of the application and the project
+func diffModules(built, current map[string]string) []string {
+
+	var changes []string
+
+	for mod, ver := range built {
+		curVer, exists := current[mod]
+		switch {
+		case !exists:
+			changes = append(changes, fmt.Sprintf("%s %s -> removed", mod, ver))
+		case curVer != ver:
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", mod, ver, curVer))
+		}
+	}
+
+	for mod, ver := range current {
+		if _, exists := built[mod]; !exists {
+			changes = append(changes, fmt.Sprintf("%s added -> %s", mod, ver))
+		}
+	}
+
+	sort.Strings(changes)
+
+	return changes
+}
+
+var tplBuildInfoGoFile = `// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+package main
+
+import (
+	"runtime"
+)
+
+// flogoBuildInfo identifies what the application was built from, it is read by 'flogo verify'
+var flogoBuildInfo = {{.Info}}
+
+// the version, commit and time of the build, set by 'flogo build' using -ldflags
+var (
+	flogoBuildVersion string
+	flogoBuildCommit  string
+	flogoBuildTime    string
+)
+
+func init() {
+	// keeps the build information in the executable
+	runtime.KeepAlive(flogoBuildInfo)
+}
+`
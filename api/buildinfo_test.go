@@ -0,0 +1,85 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBuildInfo(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "buildinfo")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "app")
+	content := `junk FLOGO_BUILD_INFO{ FLOGO_BUILD_INFO{"name":"a","version":"1.0.0","descriptorHash":"x","modules":{"m":"v1"}}FLOGO_BUILD_INFO junk`
+	err = ioutil.WriteFile(binary, []byte(content), 0644)
+	assert.Nil(t, err)
+
+	info, err := ReadBuildInfo(binary)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", info.Name)
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, "x", info.DescriptorHash)
+	assert.Equal(t, map[string]string{"m": "v1"}, info.Modules)
+
+	err = ioutil.WriteFile(binary, []byte("junk FLOGO_BUILD_INFO{} junk"), 0644)
+	assert.Nil(t, err)
+
+	_, err = ReadBuildInfo(binary)
+	assert.NotNil(t, err)
+}
+
+func TestDiffModules(t *testing.T) {
+
+	built := map[string]string{"a": "v1.0.0", "b": "v1.0.0", "c": "v1.0.0"}
+	current := map[string]string{"a": "v1.0.0", "b": "v1.1.0", "d": "v0.1.0"}
+
+	changes := diffModules(built, current)
+	assert.Equal(t, []string{"b v1.0.0 -> v1.1.0", "c v1.0.0 -> removed", "d added -> v0.1.0"}, changes)
+
+	assert.Empty(t, diffModules(built, built))
+}
+
+func TestBuildInfoLdflags(t *testing.T) {
+
+	built := time.Date(2019, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	ldflags := buildInfoLdflags(&BuildInfo{Version: "1.0.0", Commit: "abc123", Built: built})
+	assert.Equal(t, "-X 'main.flogoBuildVersion=1.0.0' -X 'main.flogoBuildCommit=abc123' -X 'main.flogoBuildTime=2019-03-14T10:00:00Z'", ldflags)
+
+	// the empty values aren't set
+	ldflags = buildInfoLdflags(&BuildInfo{Version: "it's 1", Built: built})
+	assert.Equal(t, "-X 'main.flogoBuildVersion=its 1' -X 'main.flogoBuildTime=2019-03-14T10:00:00Z'", ldflags)
+
+	os.Setenv("SOURCE_DATE_EPOCH", "1552557600")
+	defer os.Unsetenv("SOURCE_DATE_EPOCH")
+	assert.Equal(t, built, buildTime())
+}
+
+func TestPrintCLIBuildInfo(t *testing.T) {
+
+	defer SetCLIBuildInfo(CLIBuildInfo())
+	SetCLIBuildInfo(&util.CLIBuildInfo{Version: "v1.7.0-rc.1", Channel: util.ChannelBeta, Commit: "deadbeef", CoreVersion: "v1.6.0", GoVersion: "go1.21.0"})
+
+	var buf bytes.Buffer
+	err := PrintCLIBuildInfo(&buf, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "flogo cli version v1.7.0-rc.1\n  channel : beta\n  commit  : deadbeef\n  core    : v1.6.0\n  go      : go1.21.0\n", buf.String())
+
+	buf.Reset()
+	err = PrintCLIBuildInfo(&buf, true)
+	assert.Nil(t, err)
+	info := &util.CLIBuildInfo{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), info))
+	assert.Equal(t, CLIBuildInfo(), info)
+	assert.Equal(t, "v1.7.0-rc.1 (beta, commit deadbeef, core v1.6.0)", info.String())
+}
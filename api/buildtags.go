@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	sectionImportConstraints = "importConstraints"
+)
+
+var buildTagPattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// applyImportConstraints moves the imports which are conditional on a build tag from imports.go
+// to a generated imports_<tag>.go file, the list of generated files is returned
+func applyImportConstraints(project common.AppProject) ([]string, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, _ := appObj[sectionImportConstraints].(map[string]interface{})
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+
+	// constraints can be specified using the import path or its alias
+	aliases := importAliases(appObj)
+
+	importsFile := filepath.Join(project.SrcDir(), fileImportsGo)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, importsFile, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	constrained := make(map[string][]string)
+
+	for _, is := range file.Imports {
+		impPath, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		tag, ok := constraints[impPath].(string)
+		if !ok {
+			tag, ok = constraints[aliases[impPath]].(string)
+		}
+		if !ok {
+			continue
+		}
+
+		if !buildTagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid build tag '%s' for import '%s'", tag, impPath)
+		}
+
+		constrained[tag] = append(constrained[tag], impPath)
+	}
+
+	if len(constrained) == 0 {
+		return nil, nil
+	}
+
+	importsFileOrig := importsFile + ".orig"
+	if !util.FileExists(importsFileOrig) {
+		err = util.CopyFile(importsFile, importsFileOrig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var generated []string
+
+	for tag, impPaths := range constrained {
+		sort.Strings(impPaths)
+
+		for _, impPath := range impPaths {
+			if Verbose() {
+				fmt.Printf("  Import '%s' requires build tag: %s\n", impPath, tag)
+			}
+			util.DeleteImport(fset, file, impPath)
+		}
+
+		tagFile := filepath.Join(project.SrcDir(), "imports_"+tag+".go")
+		var buf bytes.Buffer
+		err := tplConstrainedImportsGoFile.Execute(&buf, struct {
+			Tag     string
+			Imports []string
+		}{tag, impPaths})
+		if err == nil {
+			err = util.WriteFileAtomic(tagFile, buf.Bytes(), 0644)
+		}
+		if err != nil {
+			return generated, err
+		}
+
+		generated = append(generated, tagFile)
+	}
+
+	err = util.WriteGoFile(importsFile, fset, file)
+
+	return generated, err
+}
+
+// importAliases gets the aliases of the imports of the app descriptor, keyed by import path
+func importAliases(appObj map[string]interface{}) map[string]string {
+
+	aliases := make(map[string]string)
+	if imports, ok := appObj["imports"].([]interface{}); ok {
+		for _, anImport := range imports {
+			if strImport, ok := anImport.(string); ok {
+				imp, err := util.ParseImport(strImport)
+				if err == nil {
+					aliases[imp.GoImportPath()] = imp.CanonicalAlias()
+				}
+			}
+		}
+	}
+
+	return aliases
+}
+
+func cleanupConstrainedImports(generated []string) {
+	for _, tagFile := range generated {
+		err := util.DeleteFile(tagFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing generated imports file '%s': %v\n", tagFile, err)
+		}
+	}
+}
+
+var tplConstrainedImportsGoFile = template.Must(template.New("").Parse(`// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+
+//go:build {{.Tag}}
+// +build {{.Tag}}
+
+package main
+
+import (
+{{range .Imports}}	_ "{{.}}"
+{{end}})
+`))
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyImportConstraints(t *testing.T) {
+	t.Log("Testing the imports conditional on a build tag")
+
+	tempDir, err := ioutil.TempDir("", "flogo-buildtags")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+
+	imports := "package main\n\nimport (\n\t_ \"github.com/myorg/contrib/trigger/kafka\"\n\t_ \"github.com/myorg/contrib/activity/debug\"\n\t_ \"github.com/project-flogo/contrib/activity/log\"\n)\n"
+	importsFile := filepath.Join(project.SrcDir(), fileImportsGo)
+	assert.Nil(t, ioutil.WriteFile(importsFile, []byte(imports), 0644))
+
+	// no constraints
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"imports": ["github.com/project-flogo/contrib/activity/log"]}`), 0644))
+	generated, err := applyImportConstraints(project)
+	assert.Nil(t, err)
+	assert.Empty(t, generated)
+
+	// the constraints are resolved using the import path or the alias of the import
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{
+  "imports": ["kafka github.com/myorg/contrib/trigger/kafka", "github.com/myorg/contrib/activity/debug", "github.com/project-flogo/contrib/activity/log"],
+  "importConstraints": {"kafka": "kafka", "github.com/myorg/contrib/activity/debug": "debug"}
+}`), 0644))
+	generated, err = applyImportConstraints(project)
+	assert.Nil(t, err)
+	sort.Strings(generated)
+	assert.Equal(t, []string{filepath.Join(project.SrcDir(), "imports_debug.go"), filepath.Join(project.SrcDir(), "imports_kafka.go")}, generated)
+
+	buf, err := ioutil.ReadFile(importsFile)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(buf), "kafka")
+	assert.NotContains(t, string(buf), "debug")
+	assert.Contains(t, string(buf), "github.com/project-flogo/contrib/activity/log")
+
+	buf, err = ioutil.ReadFile(filepath.Join(project.SrcDir(), "imports_kafka.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), "//go:build kafka\n// +build kafka\n")
+	assert.Contains(t, string(buf), `_ "github.com/myorg/contrib/trigger/kafka"`)
+
+	cleanupConstrainedImports(generated)
+	restoreImports(project)
+	for _, file := range generated {
+		assert.False(t, util.FileExists(file))
+	}
+	buf, err = ioutil.ReadFile(importsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, imports, string(buf))
+
+	// tags are used as file names and in build constraints
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"importConstraints": {"github.com/myorg/contrib/activity/debug": "debug || test"}}`), 0644))
+	_, err = applyImportConstraints(project)
+	assert.EqualError(t, err, "invalid build tag 'debug || test' for import 'github.com/myorg/contrib/activity/debug'")
+}
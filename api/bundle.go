@@ -0,0 +1,284 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	// the directory of the module cache containing the downloaded modules, as served by a module proxy
+	dirModCacheDownload = "cache/download"
+
+	fileBundleComplete = ".complete"
+
+	// the first version of Go supporting GOMODCACHE
+	minGoVersionModCache = "1.15"
+)
+
+// BundleOptions are the options used to bundle the modules of the application
+type BundleOptions struct {
+	Output string // the file the bundle is written to, <app name>-modules.tar.gz by default
+}
+
+// BundleModules writes a tarball of the modules required to build the application, the subset of a module cache used
+// by 'flogo build --offline --bundle' where the module proxy can't be reached. The modules are resolved in an empty
+// module cache, downloading them from the local one first, then from the module proxy.
+func BundleModules(ctx context.Context, project common.AppProject, options BundleOptions) error {
+
+	err := checkModCacheSupport()
+	if err != nil {
+		return err
+	}
+
+	output := options.Output
+	if output == "" {
+		output = filepath.Join(project.Dir(), project.Name()+"-modules.tar.gz")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	goEnv, err := doctorGoEnv(project, "GOMODCACHE", "GOPROXY")
+	if err != nil {
+		return fmt.Errorf("unable to read the Go environment: %v", err)
+	}
+	proxy := goEnv["GOPROXY"]
+	if localCache := goEnv["GOMODCACHE"]; localCache != "" {
+		proxy = fileProxyURL(filepath.Join(localCache, filepath.FromSlash(dirModCacheDownload))) + "," + proxy
+	}
+
+	fmt.Printf("Resolving the modules of '%s'...\n", project.Name())
+	env := append(os.Environ(), "GOMODCACHE="+tempDir, "GOPROXY="+proxy, "GOFLAGS=-mod=mod -modcacherw")
+	// the modules of the build list, then the ones providing the packages the build loads
+	for _, args := range [][]string{{"mod", "download"}, {"list", "-deps", "./..."}} {
+		cmd := exec.CommandContext(ctx, "go", args...)
+		cmd.Env = env
+		err = util.ExecCmd(cmd, project.SrcDir())
+		if err != nil {
+			return fmt.Errorf("unable to resolve the modules: %v", err)
+		}
+	}
+
+	modules, err := writeBundle(output, tempDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bundled %d modules in %s, build from it using 'flogo build --offline --bundle %s'\n", modules, output, output)
+	return nil
+}
+
+// fileProxyURL gets the URL of a module proxy serving the directory
+func fileProxyURL(dir string) string {
+	dir = filepath.ToSlash(dir)
+	if !strings.HasPrefix(dir, "/") {
+		dir = "/" + dir
+	}
+	return "file://" + dir
+}
+
+// writeBundle writes the downloaded modules of the module cache to a gzip compressed tarball, returns the number of
+// modules written
+func writeBundle(file, modCache string) (int, error) {
+
+	f, err := os.Create(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	modules := 0
+	root := filepath.Join(modCache, filepath.FromSlash(dirModCacheDownload))
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(modCache, path)
+		if err != nil {
+			return err
+		}
+		// the checksum database is only used to verify downloads
+		if info.IsDir() && info.Name() == "sumdb" {
+			return filepath.SkipDir
+		}
+		if strings.HasSuffix(path, ".lock") {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		err = tw.WriteHeader(header)
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		if strings.HasSuffix(path, ".zip") {
+			modules++
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return 0, err
+	}
+	err = gw.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	return modules, nil
+}
+
+// setOfflineEnv sets the environment of an offline build, using the modules of the bundle if specified or else the
+// ones of the module cache, the returned function restores the previous environment
+func setOfflineEnv(bundle string) (func(), error) {
+
+	// the checksum database can't be reached either, the modules missing from go.sum are trusted
+	vars := []string{"GOPROXY=off", "GOSUMDB=off", "GOFLAGS=-mod=mod -modcacherw"}
+
+	if bundle != "" {
+		err := checkModCacheSupport()
+		if err != nil {
+			return nil, err
+		}
+		modCache, err := extractBundle(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to extract bundle '%s': %v", bundle, err)
+		}
+		vars = append(vars, "GOMODCACHE="+modCache)
+	}
+
+	return setEnvVars(vars...), nil
+}
+
+// extractBundle extracts the bundle to the bundles cache, unless already extracted, returns the module cache it
+// contains
+func extractBundle(bundle string) (string, error) {
+
+	hash := util.FileHash(bundle)
+	if hash == "" {
+		return "", fmt.Errorf("unable to read the bundle")
+	}
+
+	cacheDir, err := util.GetCacheDir(util.CacheBundles)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, hash)
+	if util.FileExists(filepath.Join(dir, fileBundleComplete)) {
+		return dir, nil
+	}
+
+	if Verbose() {
+		fmt.Printf("Extracting bundle to %s\n", dir)
+	}
+	_ = os.RemoveAll(dir)
+
+	f, err := os.Open(bundle)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	tr := tar.NewReader(gr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		name := filepath.FromSlash(header.Name)
+		if !strings.HasPrefix(header.Name, dirModCacheDownload+"/") || strings.Contains(header.Name, "..") {
+			return "", fmt.Errorf("unexpected entry '%s', it isn't a bundle of modules", header.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeReg:
+			err = extractBundleFile(tr, target)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, fileBundleComplete), nil, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func extractBundleFile(r io.Reader, target string) error {
+
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// checkModCacheSupport checks that the installed version of Go supports GOMODCACHE
+func checkModCacheSupport() error {
+
+	installedGo := installedGoVersion()
+	if installedGo != "" && compareGoVersions(installedGo, minGoVersionModCache) < 0 {
+		return fmt.Errorf("go %s or later is required to bundle the modules, go %s is installed", minGoVersionModCache, installedGo)
+	}
+
+	return nil
+}
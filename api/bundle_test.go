@@ -0,0 +1,87 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleModules(t *testing.T) {
+	t.Log("Testing writing and extraction of a bundle of modules")
+
+	tempDir, err := ioutil.TempDir("", "flogo-bundle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(util.EnvKeyFlogoHome, os.Getenv(util.EnvKeyFlogoHome))
+	os.Setenv(util.EnvKeyFlogoHome, filepath.Join(tempDir, "home"))
+
+	modCache := filepath.Join(tempDir, "mod")
+	versions := filepath.Join(modCache, "cache", "download", "github.com", "org", "mod", "@v")
+	assert.Nil(t, os.MkdirAll(versions, 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(modCache, "cache", "download", "sumdb", "sum.golang.org"), 0755))
+	for name, content := range map[string]string{"list": "v1.0.0\n", "v1.0.0.mod": "module github.com/org/mod\n", "v1.0.0.zip": "zip", "v1.0.0.lock": ""} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(versions, name), []byte(content), 0644))
+	}
+	// the extracted sources aren't bundled
+	assert.Nil(t, os.MkdirAll(filepath.Join(modCache, "github.com", "org", "mod@v1.0.0"), 0755))
+
+	bundle := filepath.Join(tempDir, "modules.tar.gz")
+	modules, err := writeBundle(bundle, modCache)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, modules)
+
+	dir, err := extractBundle(bundle)
+	assert.Nil(t, err)
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "cache", "download", "github.com", "org", "mod", "@v", "v1.0.0.mod"))
+	assert.Nil(t, err)
+	assert.Equal(t, "module github.com/org/mod\n", string(buf))
+	assert.True(t, util.FileExists(filepath.Join(dir, "cache", "download", "github.com", "org", "mod", "@v", "v1.0.0.zip")))
+	assert.False(t, util.FileExists(filepath.Join(dir, "cache", "download", "github.com", "org", "mod", "@v", "v1.0.0.lock")))
+	assert.False(t, util.FileExists(filepath.Join(dir, "cache", "download", "sumdb")))
+	assert.False(t, util.FileExists(filepath.Join(dir, "github.com")))
+
+	// an extracted bundle is reused
+	assert.Nil(t, os.Remove(filepath.Join(dir, "cache", "download", "github.com", "org", "mod", "@v", "list")))
+	reused, err := extractBundle(bundle)
+	assert.Nil(t, err)
+	assert.Equal(t, dir, reused)
+	assert.False(t, util.FileExists(filepath.Join(dir, "cache", "download", "github.com", "org", "mod", "@v", "list")))
+}
+
+func TestExtractBundleOutsideCache(t *testing.T) {
+	t.Log("Testing rejection of the bundles with entries outside of the module cache")
+
+	tempDir, err := ioutil.TempDir("", "flogo-bundle")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(util.EnvKeyFlogoHome, os.Getenv(util.EnvKeyFlogoHome))
+	os.Setenv(util.EnvKeyFlogoHome, filepath.Join(tempDir, "home"))
+
+	for _, name := range []string{"cache/download/../../../../evil", "src/main.go"} {
+		bundle := filepath.Join(tempDir, "modules.tar.gz")
+		f, err := os.Create(bundle)
+		assert.Nil(t, err)
+		gw := gzip.NewWriter(f)
+		tw := tar.NewWriter(gw)
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: 4}))
+		_, err = tw.Write([]byte("evil"))
+		assert.Nil(t, err)
+		assert.Nil(t, tw.Close())
+		assert.Nil(t, gw.Close())
+		assert.Nil(t, f.Close())
+
+		_, err = extractBundle(bundle)
+		if assert.NotNil(t, err, name) {
+			assert.Contains(t, err.Error(), "isn't a bundle of modules")
+		}
+	}
+	assert.False(t, util.FileExists(filepath.Join(tempDir, "evil")))
+}
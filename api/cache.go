@@ -0,0 +1,220 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	cacheDescriptors = "descriptors"
+	cacheRegistry    = "registry"
+)
+
+// CachePruneOptions are the options used to prune the caches of the CLI
+type CachePruneOptions struct {
+	Caches  []string      // the caches to prune, all of them by default
+	MaxAge  time.Duration // the entries not modified for longer are removed, 0 to keep them whatever their age
+	MaxSize int64         // the oldest entries are removed until the caches fit in this size, 0 for no limit
+	All     bool          // remove all the entries
+	DryRun  bool          // only print the entries which would be removed
+}
+
+// cacheArea is a cache of the CLI, whose entries are the files and directories of its directory
+type cacheArea struct {
+	Name string
+	Dir  string
+}
+
+type cacheEntry struct {
+	Cache   string
+	Path    string
+	Size    int64
+	ModTime time.Time // the latest modification of the entry or of one of its files
+}
+
+// cacheAreas gets the caches of the CLI: the descriptor cache of the project, if there is one, and the caches of the
+// flogo home
+func cacheAreas(project common.AppProject) ([]*cacheArea, error) {
+
+	var areas []*cacheArea
+	if project != nil {
+		areas = append(areas, &cacheArea{Name: cacheDescriptors, Dir: filepath.Join(project.Dir(), dirProjectFlogo, dirProjectCache)})
+	}
+
+	registryDir, err := util.GetRegistryCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	areas = append(areas, &cacheArea{Name: cacheRegistry, Dir: registryDir})
+
+	for _, name := range []string{util.CacheTemplates, util.CacheToolchains, util.CacheBundles} {
+		dir, err := util.GetCacheDir(name)
+		if err != nil {
+			return nil, err
+		}
+		areas = append(areas, &cacheArea{Name: name, Dir: dir})
+	}
+
+	return areas, nil
+}
+
+// PruneCaches removes the entries of the caches of the CLI which are older than the maximum age, then the oldest
+// ones until the caches fit in the maximum size
+func PruneCaches(project common.AppProject, options CachePruneOptions) error {
+
+	areas, err := cacheAreas(project)
+	if err != nil {
+		return err
+	}
+
+	if len(options.Caches) > 0 {
+		byName := make(map[string]*cacheArea)
+		var names []string
+		for _, area := range areas {
+			byName[area.Name] = area
+			names = append(names, area.Name)
+		}
+
+		var selected []*cacheArea
+		for _, name := range options.Caches {
+			area, exists := byName[name]
+			if !exists {
+				if name == cacheDescriptors {
+					return fmt.Errorf("the descriptor cache belongs to a project, run the command from the project directory")
+				}
+				return fmt.Errorf("unknown cache '%s', expected one of: %s", name, strings.Join(names, ", "))
+			}
+			selected = append(selected, area)
+		}
+		areas = selected
+	}
+
+	var entries []*cacheEntry
+	for _, area := range areas {
+		areaEntries, err := readCacheEntries(area)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, areaEntries...)
+	}
+
+	now := time.Now()
+	pruned, kept := selectPrunedEntries(entries, options, now)
+
+	var freed, left int64
+	for _, entry := range pruned {
+		freed += entry.Size
+		age := formatCacheAge(now.Sub(entry.ModTime))
+		if options.DryRun {
+			fmt.Printf("Would remove %s/%s (%s, %s)\n", entry.Cache, filepath.Base(entry.Path), util.FormatByteSize(entry.Size), age)
+			continue
+		}
+		if Verbose() {
+			fmt.Printf("Removing %s/%s (%s, %s)\n", entry.Cache, filepath.Base(entry.Path), util.FormatByteSize(entry.Size), age)
+		}
+		err := os.RemoveAll(entry.Path)
+		if err != nil {
+			return fmt.Errorf("unable to remove '%s': %v", entry.Path, err)
+		}
+	}
+	for _, entry := range kept {
+		left += entry.Size
+	}
+
+	switch {
+	case len(pruned) == 0:
+		fmt.Printf("Nothing to prune, the caches use %s\n", util.FormatByteSize(left))
+	case options.DryRun:
+		fmt.Printf("%d entry(ies) would be removed, freeing %s, %s would be left in the caches\n", len(pruned), util.FormatByteSize(freed), util.FormatByteSize(left))
+	default:
+		fmt.Printf("Removed %d entry(ies), freeing %s, %s left in the caches\n", len(pruned), util.FormatByteSize(freed), util.FormatByteSize(left))
+	}
+
+	return nil
+}
+
+// selectPrunedEntries splits the entries into the ones to remove and the ones to keep, the oldest first
+func selectPrunedEntries(entries []*cacheEntry, options CachePruneOptions, now time.Time) (pruned, kept []*cacheEntry) {
+
+	sorted := make([]*cacheEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ModTime.Before(sorted[j].ModTime)
+	})
+
+	var total int64
+	for _, entry := range sorted {
+		if options.All || (options.MaxAge > 0 && now.Sub(entry.ModTime) > options.MaxAge) {
+			pruned = append(pruned, entry)
+			continue
+		}
+		kept = append(kept, entry)
+		total += entry.Size
+	}
+
+	if options.MaxSize > 0 {
+		for len(kept) > 0 && total > options.MaxSize {
+			total -= kept[0].Size
+			pruned = append(pruned, kept[0])
+			kept = kept[1:]
+		}
+	}
+
+	return pruned, kept
+}
+
+// readCacheEntries reads the entries of the cache, a cache which doesn't exist yet has none
+func readCacheEntries(area *cacheArea) ([]*cacheEntry, error) {
+
+	infos, err := ioutil.ReadDir(area.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []*cacheEntry
+	for _, info := range infos {
+		entry := &cacheEntry{Cache: area.Name, Path: filepath.Join(area.Dir, info.Name()), Size: info.Size(), ModTime: info.ModTime()}
+		if info.IsDir() {
+			entry.Size = 0
+			err = filepath.Walk(entry.Path, func(path string, fi os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !fi.IsDir() {
+					entry.Size += fi.Size()
+				}
+				if fi.ModTime().After(entry.ModTime) {
+					entry.ModTime = fi.ModTime()
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func formatCacheAge(age time.Duration) string {
+	switch {
+	case age >= 48*time.Hour:
+		return fmt.Sprintf("%d days old", int(age.Hours()/24))
+	case age >= 2*time.Hour:
+		return fmt.Sprintf("%d hours old", int(age.Hours()))
+	}
+	return "recent"
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPrunedEntries(t *testing.T) {
+	t.Log("Testing selection of the cache entries to prune")
+
+	now := time.Now()
+	entries := []*cacheEntry{
+		{Cache: "templates", Path: "recent", Size: 300, ModTime: now.Add(-time.Hour)},
+		{Cache: "bundles", Path: "old", Size: 100, ModTime: now.Add(-60 * 24 * time.Hour)},
+		{Cache: "templates", Path: "older", Size: 200, ModTime: now.Add(-10 * 24 * time.Hour)},
+	}
+
+	paths := func(entries []*cacheEntry) []string {
+		var paths []string
+		for _, entry := range entries {
+			paths = append(paths, entry.Path)
+		}
+		return paths
+	}
+
+	pruned, kept := selectPrunedEntries(entries, CachePruneOptions{}, now)
+	assert.Empty(t, pruned)
+	assert.Equal(t, []string{"old", "older", "recent"}, paths(kept))
+
+	pruned, kept = selectPrunedEntries(entries, CachePruneOptions{MaxAge: 30 * 24 * time.Hour}, now)
+	assert.Equal(t, []string{"old"}, paths(pruned))
+	assert.Equal(t, []string{"older", "recent"}, paths(kept))
+
+	// the oldest entries are removed until the caches fit
+	pruned, kept = selectPrunedEntries(entries, CachePruneOptions{MaxSize: 350}, now)
+	assert.Equal(t, []string{"old", "older"}, paths(pruned))
+	assert.Equal(t, []string{"recent"}, paths(kept))
+
+	pruned, kept = selectPrunedEntries(entries, CachePruneOptions{All: true}, now)
+	assert.Len(t, pruned, 3)
+	assert.Empty(t, kept)
+}
+
+func TestPruneCaches(t *testing.T) {
+	t.Log("Testing pruning of the caches of the flogo home")
+
+	tempDir, err := ioutil.TempDir("", "flogo-cache-prune")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(util.EnvKeyFlogoHome, os.Getenv(util.EnvKeyFlogoHome))
+	os.Setenv(util.EnvKeyFlogoHome, tempDir)
+
+	templatesDir, err := util.GetCacheDir(util.CacheTemplates)
+	assert.Nil(t, err)
+	oldTemplate := filepath.Join(templatesDir, "old")
+	newTemplate := filepath.Join(templatesDir, "new")
+	for _, dir := range []string{oldTemplate, newTemplate} {
+		assert.Nil(t, os.MkdirAll(dir, 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "flogo.json"), []byte("{}"), 0644))
+	}
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	assert.Nil(t, os.Chtimes(filepath.Join(oldTemplate, "flogo.json"), old, old))
+	assert.Nil(t, os.Chtimes(oldTemplate, old, old))
+
+	err = PruneCaches(nil, CachePruneOptions{MaxAge: 30 * 24 * time.Hour, DryRun: true})
+	assert.Nil(t, err)
+	assert.True(t, util.DirExists(oldTemplate))
+
+	err = PruneCaches(nil, CachePruneOptions{MaxAge: 30 * 24 * time.Hour})
+	assert.Nil(t, err)
+	assert.False(t, util.DirExists(oldTemplate))
+	assert.True(t, util.DirExists(newTemplate))
+
+	err = PruneCaches(nil, CachePruneOptions{Caches: []string{"unknown"}})
+	assert.NotNil(t, err)
+	err = PruneCaches(nil, CachePruneOptions{Caches: []string{cacheDescriptors}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,325 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/project-flogo/cli/util"
+)
+
+var namespacePattern = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// composedApp is an app descriptor being composed, its ids are prefixed with the namespace
+type composedApp struct {
+	file      string
+	namespace string
+	appObj    map[string]interface{}
+}
+
+// ComposeAppDescriptors merges several app descriptors into one, written to outFile, so they are built into a single
+// binary. The ids of the resources, triggers, shared actions and connections of each app are prefixed with the name of
+// the app, and the references to them updated. The imports and properties are the union of the ones of the apps, an
+// import required at different versions or a property with different values is reported as a conflict.
+func ComposeAppDescriptors(files []string, outFile string) error {
+
+	if len(files) < 2 {
+		return fmt.Errorf("at least two app descriptors must be specified to compose")
+	}
+
+	var apps []*composedApp
+	namespaces := make(map[string]string)
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var appObj map[string]interface{}
+		err = json.Unmarshal(content, &appObj)
+		if err != nil {
+			return fmt.Errorf("unable to parse '%s': %v", file, err)
+		}
+
+		name, _ := appObj["name"].(string)
+		if name == "" {
+			return fmt.Errorf("app name not specified in '%s'", file)
+		}
+		ns := namespacePattern.ReplaceAllString(name, "_")
+		if other, exists := namespaces[ns]; exists {
+			return fmt.Errorf("'%s' and '%s' have the same app name '%s', the names are used to namespace the ids of the apps", other, file, name)
+		}
+		namespaces[ns] = file
+
+		apps = append(apps, &composedApp{file: file, namespace: ns, appObj: appObj})
+	}
+
+	composed, err := composeApps(apps)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(composed, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(outFile, out, 0644)
+}
+
+// composeApps merges the apps into a single app descriptor
+func composeApps(apps []*composedApp) (map[string]interface{}, error) {
+
+	var names []string
+	for _, app := range apps {
+		name, _ := app.appObj["name"].(string)
+		names = append(names, name)
+	}
+
+	first := apps[0].appObj
+	composed := map[string]interface{}{
+		"name": strings.Join(names, "-"),
+		"type": "flogo:app",
+	}
+	for _, key := range []string{"version", "appModel"} {
+		if val, exists := first[key]; exists {
+			composed[key] = val
+		}
+	}
+	composed["description"] = fmt.Sprintf("Composition of %s", strings.Join(names, ", "))
+
+	imports, err := composeImports(apps)
+	if err != nil {
+		return nil, err
+	}
+	composed["imports"] = imports
+
+	props, err := composeProperties(apps)
+	if err != nil {
+		return nil, err
+	}
+	if len(props) > 0 {
+		composed["properties"] = props
+	}
+
+	var triggers, resources, actions, channels []interface{}
+	connections := make(map[string]interface{})
+
+	for _, app := range apps {
+		app.namespaceIds()
+
+		if vals, ok := app.appObj["triggers"].([]interface{}); ok {
+			triggers = append(triggers, vals...)
+		}
+		if vals, ok := app.appObj["resources"].([]interface{}); ok {
+			resources = append(resources, vals...)
+		}
+		if vals, ok := app.appObj["actions"].([]interface{}); ok {
+			actions = append(actions, vals...)
+		}
+		if vals, ok := app.appObj["channels"].([]interface{}); ok {
+			for _, val := range vals {
+				if !containsValue(channels, val) {
+					channels = append(channels, val)
+				}
+			}
+		}
+		if conns, ok := app.appObj["connections"].(map[string]interface{}); ok {
+			for id, conn := range conns {
+				connections[id] = conn
+			}
+		}
+	}
+
+	composed["triggers"] = triggers
+	composed["resources"] = resources
+	if len(actions) > 0 {
+		composed["actions"] = actions
+	}
+	if len(channels) > 0 {
+		composed["channels"] = channels
+	}
+	if len(connections) > 0 {
+		composed["connections"] = connections
+	}
+
+	// the triggers of the apps must be able to run side by side, ex. they can't listen on the same port
+	ctx := &validationContext{appObj: composed}
+	if err := validateTriggerConflicts(ctx); err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for _, issue := range ctx.issues {
+		if issue.Severity == SeverityError {
+			conflicts = append(conflicts, fmt.Sprintf("  %s: %s", issue.Path, issue.Message))
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("the triggers of the apps conflict:\n%s", strings.Join(conflicts, "\n"))
+	}
+
+	return composed, nil
+}
+
+// namespaceIds prefixes the ids of the resources, triggers, shared actions and connections of the app with its
+// namespace and updates the references to them
+func (app *composedApp) namespaceIds() {
+
+	refs := make(map[string]string)
+
+	if resources, ok := app.appObj["resources"].([]interface{}); ok {
+		for _, res := range resources {
+			resMap, _ := res.(map[string]interface{})
+			id, _ := resMap["id"].(string)
+			if id == "" {
+				continue
+			}
+			nsId := app.namespace + "_" + id
+			// the type of the resource is kept, ex. flow:app_name
+			if idx := strings.Index(id, ":"); idx >= 0 {
+				nsId = id[:idx+1] + app.namespace + "_" + id[idx+1:]
+			}
+			resMap["id"] = nsId
+			refs[resURIPrefix+id] = resURIPrefix + nsId
+		}
+	}
+
+	if conns, ok := app.appObj["connections"].(map[string]interface{}); ok {
+		nsConns := make(map[string]interface{}, len(conns))
+		for id, conn := range conns {
+			nsConns[app.namespace+"_"+id] = conn
+			refs[connRefPrefix+id] = connRefPrefix + app.namespace + "_" + id
+		}
+		app.appObj["connections"] = nsConns
+	}
+
+	actionIds := make(map[string]string)
+	if actions, ok := app.appObj["actions"].([]interface{}); ok {
+		for _, act := range actions {
+			actMap, _ := act.(map[string]interface{})
+			if id, _ := actMap["id"].(string); id != "" {
+				actionIds[id] = app.namespace + "_" + id
+				actMap["id"] = actionIds[id]
+			}
+		}
+	}
+
+	if triggers, ok := app.appObj["triggers"].([]interface{}); ok {
+		for _, trg := range triggers {
+			trgMap, _ := trg.(map[string]interface{})
+			if id, _ := trgMap["id"].(string); id != "" {
+				trgMap["id"] = app.namespace + "_" + id
+			}
+			handlers, _ := trgMap["handlers"].([]interface{})
+			for _, handler := range handlers {
+				hMap, _ := handler.(map[string]interface{})
+				action, _ := hMap["action"].(map[string]interface{})
+				if id, _ := action["id"].(string); actionIds[id] != "" {
+					action["id"] = actionIds[id]
+				}
+			}
+		}
+	}
+
+	for _, key := range []string{"triggers", "resources", "actions", "connections"} {
+		if val, exists := app.appObj[key]; exists {
+			app.appObj[key] = replaceRefs(val, refs)
+		}
+	}
+}
+
+// replaceRefs replaces the string values which are references to the ids of the app
+func replaceRefs(item interface{}, refs map[string]string) interface{} {
+	switch t := item.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			t[k] = replaceRefs(v, refs)
+		}
+	case []interface{}:
+		for i, v := range t {
+			t[i] = replaceRefs(v, refs)
+		}
+	case string:
+		if ref, exists := refs[t]; exists {
+			return ref
+		}
+	}
+	return item
+}
+
+// composeImports gets the union of the imports of the apps, an import can't be required at different versions
+func composeImports(apps []*composedApp) ([]interface{}, error) {
+
+	var imports []interface{}
+	seen := make(map[string]string)   // the import by go import path
+	origin := make(map[string]string) // the file of the import by go import path
+
+	for _, app := range apps {
+		vals, _ := app.appObj["imports"].([]interface{})
+		for _, val := range vals {
+			s, _ := val.(string)
+			imp, err := util.ParseImport(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid import '%s' in '%s': %v", s, app.file, err)
+			}
+
+			path := imp.GoImportPath()
+			if other, exists := seen[path]; exists {
+				otherImp, _ := util.ParseImport(other)
+				if otherImp.Version() != imp.Version() {
+					return nil, fmt.Errorf("'%s' is imported at different versions, '%s' in '%s' and '%s' in '%s'", path, other, origin[path], s, app.file)
+				}
+				continue
+			}
+
+			seen[path] = s
+			origin[path] = app.file
+			imports = append(imports, s)
+		}
+	}
+
+	return imports, nil
+}
+
+// composeProperties gets the union of the properties of the apps, a property can't have different values as the
+// references to the properties aren't namespaced
+func composeProperties(apps []*composedApp) ([]interface{}, error) {
+
+	var props []interface{}
+	seen := make(map[string]*appProperty)
+	origin := make(map[string]string)
+
+	for _, app := range apps {
+		for _, prop := range getAppProperties(app.appObj) {
+			if other, exists := seen[prop.Name]; exists {
+				if other.Type != prop.Type || !reflect.DeepEqual(other.Value, prop.Value) {
+					return nil, fmt.Errorf("property '%s' has different values in '%s' and '%s'", prop.Name, origin[prop.Name], app.file)
+				}
+				continue
+			}
+
+			seen[prop.Name] = prop
+			origin[prop.Name] = app.file
+			propMap := map[string]interface{}{"name": prop.Name, "value": prop.Value}
+			if prop.Type != "" {
+				propMap["type"] = prop.Type
+			}
+			props = append(props, propMap)
+		}
+	}
+
+	return props, nil
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testComposeOrders = `{
+  "name": "orders",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "appModel": "1.1.0",
+  "imports": ["github.com/project-flogo/contrib/trigger/rest", "github.com/project-flogo/flow"],
+  "properties": [{"name": "log.level", "type": "string", "value": "INFO"}],
+  "connections": {"db": {"ref": "#postgres", "settings": {"host": "localhost"}}},
+  "triggers": [{"id": "rest", "ref": "#rest", "settings": {"port": 8080},
+    "handlers": [{"settings": {"method": "GET", "path": "/orders"}, "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:get"}}}]}],
+  "resources": [{"id": "flow:get", "data": {"tasks": [{"id": "query", "activity": {"settings": {"connection": "conn://db"}}}]}}]
+}`
+
+const testComposeUsers = `{
+  "name": "users",
+  "type": "flogo:app",
+  "version": "2.0.0",
+  "imports": ["github.com/project-flogo/contrib/trigger/rest", "github.com/project-flogo/flow"],
+  "properties": [{"name": "log.level", "type": "string", "value": "INFO"}],
+  "triggers": [{"id": "rest", "ref": "#rest", "settings": {"port": 8081},
+    "handlers": [{"settings": {"method": "GET", "path": "/users"}, "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:get"}}}]}],
+  "resources": [{"id": "flow:get", "data": {}}]
+}`
+
+func TestComposeAppDescriptors(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "compose")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeApp := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0644))
+		return file
+	}
+	orders := writeApp("orders.json", testComposeOrders)
+	users := writeApp("users.json", testComposeUsers)
+	outFile := filepath.Join(dir, "flogo.json")
+
+	err = ComposeAppDescriptors([]string{orders, users}, outFile)
+	assert.Nil(t, err)
+
+	buf, _ := ioutil.ReadFile(outFile)
+	var composed map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf, &composed))
+
+	assert.Equal(t, "orders-users", composed["name"])
+	assert.Len(t, composed["imports"], 2)
+	assert.Len(t, composed["properties"], 1)
+	assert.Len(t, composed["triggers"], 2)
+	assert.Contains(t, composed["connections"], "orders_db")
+
+	content := string(buf)
+	for _, ref := range []string{`"flow:orders_get"`, `"flow:users_get"`, `"res://flow:orders_get"`, `"res://flow:users_get"`,
+		`"conn://orders_db"`, `"orders_rest"`, `"users_rest"`} {
+		assert.Contains(t, content, ref)
+	}
+
+	// conflicts
+	err = ComposeAppDescriptors([]string{orders, writeApp("port.json", strings.Replace(testComposeUsers, "8081", "8080", 1))}, outFile)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "port 8080")
+
+	err = ComposeAppDescriptors([]string{orders, writeApp("prop.json", strings.Replace(testComposeUsers, `"INFO"`, `"DEBUG"`, 1))}, outFile)
+	assert.NotNil(t, err)
+
+	err = ComposeAppDescriptors([]string{orders, writeApp("version.json", strings.Replace(testComposeUsers, "/flow\"", "/flow@v1.0.0\"", 1))}, outFile)
+	assert.NotNil(t, err)
+
+	err = ComposeAppDescriptors([]string{orders, orders}, outFile)
+	assert.NotNil(t, err)
+
+	err = ComposeAppDescriptors([]string{orders}, outFile)
+	assert.NotNil(t, err)
+}
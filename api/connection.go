@@ -0,0 +1,479 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	connRefPrefix         = "conn://"
+	settingTypeConnection = "connection"
+)
+
+var probeTimeout = 5 * time.Second
+
+var defaultSchemePorts = map[string]int{
+	"http":     80,
+	"https":    443,
+	"ws":       80,
+	"wss":      443,
+	"tcp":      1883,
+	"mqtt":     1883,
+	"ssl":      8883,
+	"mqtts":    8883,
+	"amqp":     5672,
+	"amqps":    5671,
+	"postgres": 5432,
+	"mysql":    3306,
+	"mongodb":  27017,
+	"redis":    6379,
+}
+
+// validateConnections validates the connection settings of triggers and shared connections, the ones of the activities are
+// validated along with their resource
+func validateConnections(ctx *validationContext) error {
+
+	if triggers, ok := ctx.appObj["triggers"].([]interface{}); ok {
+		for i, trg := range triggers {
+			trgMap, ok := trg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ref, _ := trgMap["ref"].(string)
+			desc := ctx.contribs.Resolve("trigger", ref)
+			if desc == nil {
+				continue
+			}
+			settings, _ := trgMap["settings"].(map[string]interface{})
+			validateConnectionSettings(ctx, fmt.Sprintf("$.triggers[%d].settings", i), desc.Settings, settings)
+		}
+	}
+
+	if connections, ok := ctx.appObj["connections"].(map[string]interface{}); ok {
+		for id, conn := range connections {
+			connMap, ok := conn.(map[string]interface{})
+			if !ok {
+				ctx.addError("$.connections."+id, "invalid connection definition")
+				continue
+			}
+			validateConnectionConfig(ctx, "$.connections."+id, connMap)
+		}
+	}
+
+	return nil
+}
+
+// validateResourceConnections validates the connection settings of the activities of a resource
+func validateResourceConnections(ctx *validationContext, path string, resource map[string]interface{}) {
+
+	walkActivities(resource, path, func(path string, activity map[string]interface{}) {
+		ref, _ := activity["ref"].(string)
+		desc := ctx.contribs.Resolve("activity", ref)
+		if desc == nil {
+			return
+		}
+		settings, _ := activity["settings"].(map[string]interface{})
+		validateConnectionSettings(ctx, path+".settings", desc.Settings, settings)
+	})
+}
+
+// walkActivities calls the function for every activity configuration found in the item
+func walkActivities(item interface{}, path string, f func(path string, activity map[string]interface{})) {
+	switch t := item.(type) {
+	case map[string]interface{}:
+		for key, val := range t {
+			if activity, ok := val.(map[string]interface{}); ok && key == "activity" {
+				if _, hasRef := activity["ref"]; hasRef {
+					f(path+".activity", activity)
+					continue
+				}
+			}
+			walkActivities(val, jsonMemberPath(path, key), f)
+		}
+	case []interface{}:
+		for i, val := range t {
+			walkActivities(val, fmt.Sprintf("%s[%d]", path, i), f)
+		}
+	}
+}
+
+func validateConnectionSettings(ctx *validationContext, path string, mdSettings []*util.FlogoSettingDescriptor, settings map[string]interface{}) {
+
+	for _, mdSetting := range mdSettings {
+		if mdSetting.Type != settingTypeConnection {
+			continue
+		}
+
+		settingPath := jsonMemberPath(path, mdSetting.Name)
+		val, exists := settings[mdSetting.Name]
+
+		if !exists || val == nil || val == "" {
+			if mdSetting.Required {
+				ctx.addError(settingPath, "required connection setting '%s' not set", mdSetting.Name)
+			}
+			continue
+		}
+
+		if isExpression(val) {
+			continue
+		}
+
+		switch t := val.(type) {
+		case string:
+			if !strings.HasPrefix(t, connRefPrefix) {
+				ctx.addError(settingPath, "invalid connection reference '%s', expected '%s<id>'", t, connRefPrefix)
+				continue
+			}
+
+			id := t[len(connRefPrefix):]
+			connections, _ := ctx.appObj["connections"].(map[string]interface{})
+			if _, exists := connections[id]; !exists {
+				ctx.addError(settingPath, "shared connection '%s' not found", id)
+			}
+		case map[string]interface{}:
+			validateConnectionConfig(ctx, settingPath, t)
+		default:
+			ctx.addError(settingPath, "invalid connection value")
+		}
+	}
+}
+
+func validateConnectionConfig(ctx *validationContext, path string, conn map[string]interface{}) {
+
+	settings, _ := conn["settings"].(map[string]interface{})
+
+	ref, _ := conn["ref"].(string)
+	if ref == "" {
+		ctx.addError(path, "connection ref not specified")
+		return
+	}
+
+	desc := ctx.contribs.Resolve(settingTypeConnection, ref)
+	if desc == nil {
+		ctx.addWarning(path+".ref", "unable to resolve connection '%s', settings not validated", ref)
+	} else {
+		for _, mdSetting := range desc.Settings {
+			settingPath := path + ".settings." + mdSetting.Name
+			val, exists := settings[mdSetting.Name]
+			if !exists || val == nil || val == "" {
+				if mdSetting.Required && mdSetting.Value == nil {
+					ctx.addError(settingPath, "required setting '%s' not set", mdSetting.Name)
+				}
+				continue
+			}
+
+			if !checkSettingType(mdSetting.Type, val) {
+				ctx.addError(settingPath, "expected value of type '%s'", mdSetting.Type)
+			}
+		}
+	}
+
+	if ctx.options.Probe {
+		address := connectionAddress(settings)
+		if address == "" {
+			ctx.addWarning(path, "live connectivity test not supported for this connection")
+			return
+		}
+
+		conn, err := net.DialTimeout("tcp", address, probeTimeout)
+		if err != nil {
+			ctx.addError(path, "unable to connect to '%s': %s", address, err.Error())
+			return
+		}
+		_ = conn.Close()
+
+		if Verbose() {
+			fmt.Printf("Successfully connected to: %s\n", address)
+		}
+	}
+}
+
+// connectionAddress determines the network address of a connection from its settings
+func connectionAddress(settings map[string]interface{}) string {
+
+	for _, key := range []string{"url", "uri", "brokerUrl", "broker", "server", "endpoint"} {
+		strVal, ok := settings[key].(string)
+		if !ok || strVal == "" || isExpression(strVal) {
+			continue
+		}
+
+		u, err := url.Parse(strVal)
+		if err != nil || u.Host == "" {
+			continue
+		}
+
+		if u.Port() != "" {
+			return u.Host
+		}
+		if port, ok := defaultSchemePorts[strings.ToLower(u.Scheme)]; ok {
+			return net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+		}
+	}
+
+	for _, key := range []string{"host", "hostname"} {
+		host, ok := settings[key].(string)
+		if !ok || host == "" || isExpression(host) {
+			continue
+		}
+
+		switch port := settings["port"].(type) {
+		case float64:
+			return net.JoinHostPort(host, strconv.Itoa(int(port)))
+		case string:
+			if port != "" && !isExpression(port) {
+				return net.JoinHostPort(host, port)
+			}
+		}
+	}
+
+	return ""
+}
+
+// ConnectionSpec describes a shared connection of the application
+type ConnectionSpec struct {
+	Id         string                 `json:"id"`
+	Ref        string                 `json:"ref"`
+	Settings   map[string]interface{} `json:"settings,omitempty"`
+	References []string               `json:"references,omitempty"`
+}
+
+// AddConnection adds a shared connection to the app descriptor and rewrites identical inline connections to reference it
+func AddConnection(project common.AppProject, id, ref string, settings []string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if id == "" || ref == "" {
+		return fmt.Errorf("connection id and ref must be specified")
+	}
+	if strings.ContainsAny(id, ".[]") {
+		return fmt.Errorf("invalid connection id '%s', it can't contain '.', '[' or ']'", id)
+	}
+
+	connSettings, err := parseKeyValues(settings)
+	if err != nil {
+		return err
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	connections, hasConnections := appObj["connections"].(map[string]interface{})
+	if _, exists := connections[id]; exists {
+		return fmt.Errorf("connection '%s' already exists", id)
+	}
+
+	conn := map[string]interface{}{"ref": ref, "settings": connSettings}
+
+	var inlinePaths []string
+	forEachConnectionSetting(appObj, func(path string, settings map[string]interface{}, name string) {
+		if inline, ok := settings[name].(map[string]interface{}); ok && sameConnection(inline, conn) {
+			inlinePaths = append(inlinePaths, jsonMemberPath(path, name))
+			if Verbose() {
+				fmt.Printf("Rewriting inline connection: %s\n", jsonMemberPath(path, name))
+			}
+		}
+	})
+
+	err = editAppDescriptor(project, func(text string) (string, error) {
+		var err error
+		if hasConnections {
+			text, err = setJSONValue(text, jsonMemberPath("$.connections", id), conn)
+		} else {
+			text, err = setJSONValue(text, "$.connections", map[string]interface{}{id: conn})
+		}
+		for _, path := range inlinePaths {
+			if err != nil {
+				break
+			}
+			text, err = setJSONValue(text, path, connRefPrefix+id)
+		}
+		return text, err
+	})
+	if err != nil {
+		return err
+	}
+	rewritten := len(inlinePaths)
+
+	fmt.Printf("Added connection '%s'", id)
+	if rewritten > 0 {
+		fmt.Printf(", replaced %d inline connection(s)", rewritten)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// RemoveConnection removes a shared connection, if inline is set the references are replaced by a copy of the connection
+func RemoveConnection(project common.AppProject, id string, inline bool) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	connections, _ := appObj["connections"].(map[string]interface{})
+	if _, exists := connections[id]; !exists {
+		return fmt.Errorf("connection '%s' not found", id)
+	}
+
+	var refs []string
+	forEachConnectionSetting(appObj, func(path string, settings map[string]interface{}, name string) {
+		if settings[name] == connRefPrefix+id {
+			refs = append(refs, jsonMemberPath(path, name))
+		}
+	})
+
+	if len(refs) > 0 && !inline {
+		return fmt.Errorf("connection '%s' is referenced by: %s", id, strings.Join(refs, ", "))
+	}
+
+	err = editAppDescriptor(project, func(text string) (string, error) {
+		// the references are replaced by the connection as it is written in the descriptor
+		raw, _, err := jsonValue(text, jsonMemberPath("$.connections", id))
+		if err != nil {
+			return "", err
+		}
+		for _, ref := range refs {
+			text, err = setJSONValue(text, ref, json.RawMessage(raw))
+			if err != nil {
+				return "", err
+			}
+		}
+		if len(connections) == 1 {
+			return removeJSONValue(text, "$.connections")
+		}
+		return removeJSONValue(text, jsonMemberPath("$.connections", id))
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed connection '%s'\n", id)
+
+	return nil
+}
+
+// ListConnections lists the shared connections of the application
+func ListConnections(project common.AppProject, jsonFormat bool) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	refs := make(map[string][]string)
+	forEachConnectionSetting(appObj, func(path string, settings map[string]interface{}, name string) {
+		if strVal, ok := settings[name].(string); ok && strings.HasPrefix(strVal, connRefPrefix) {
+			id := strVal[len(connRefPrefix):]
+			refs[id] = append(refs[id], path+"."+name)
+		}
+	})
+
+	connections, _ := appObj["connections"].(map[string]interface{})
+
+	specs := []*ConnectionSpec{}
+	for id, conn := range connections {
+		spec := &ConnectionSpec{Id: id, References: refs[id]}
+		if connMap, ok := conn.(map[string]interface{}); ok {
+			spec.Ref, _ = connMap["ref"].(string)
+			spec.Settings, _ = connMap["settings"].(map[string]interface{})
+		}
+		specs = append(specs, spec)
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Id < specs[j].Id
+	})
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%v \n", string(resp))
+	} else {
+		for _, spec := range specs {
+			fmt.Println("Connection: " + spec.Id)
+			fmt.Println("  Ref        : " + spec.Ref)
+			fmt.Printf("  References : %d\n", len(spec.References))
+			for _, ref := range spec.References {
+				fmt.Println("    " + ref)
+			}
+		}
+	}
+
+	return nil
+}
+
+// forEachConnectionSetting calls the function for every trigger or activity setting that holds a connection
+func forEachConnectionSetting(appObj map[string]interface{}, f func(path string, settings map[string]interface{}, name string)) {
+
+	visit := func(path string, settings map[string]interface{}) {
+		for name, val := range settings {
+			switch t := val.(type) {
+			case string:
+				if strings.HasPrefix(t, connRefPrefix) {
+					f(path, settings, name)
+				}
+			case map[string]interface{}:
+				if _, hasRef := t["ref"].(string); hasRef {
+					f(path, settings, name)
+				}
+			}
+		}
+	}
+
+	if triggers, ok := appObj["triggers"].([]interface{}); ok {
+		for i, trg := range triggers {
+			if trgMap, ok := trg.(map[string]interface{}); ok {
+				if settings, ok := trgMap["settings"].(map[string]interface{}); ok {
+					visit(fmt.Sprintf("$.triggers[%d].settings", i), settings)
+				}
+			}
+		}
+	}
+
+	walkActivities(appObj["resources"], "$.resources", func(path string, activity map[string]interface{}) {
+		if settings, ok := activity["settings"].(map[string]interface{}); ok {
+			visit(path+".settings", settings)
+		}
+	})
+}
+
+func sameConnection(inline, shared map[string]interface{}) bool {
+	if inline["ref"] != shared["ref"] {
+		return false
+	}
+
+	inlineSettings, _ := inline["settings"].(map[string]interface{})
+	sharedSettings, _ := shared["settings"].(map[string]interface{})
+	if len(inlineSettings) == 0 && len(sharedSettings) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(inlineSettings, sharedSettings)
+}
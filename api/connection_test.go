@@ -0,0 +1,100 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const connectionsTestDescriptor = `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "triggers": [
+    {
+      "id": "kafka",
+      "ref": "#kafka",
+      "settings": {
+        "connection": {
+          "ref": "#kafkaconn",
+          "settings": {
+            "brokers": "kafka:9092"
+          }
+        }
+      }
+    }
+  ],
+  "resources": []
+}`
+
+func TestSameConnection(t *testing.T) {
+
+	shared := map[string]interface{}{"ref": "#kafkaconn", "settings": map[string]interface{}{"brokers": "kafka:9092"}}
+
+	assert.True(t, sameConnection(map[string]interface{}{"ref": "#kafkaconn", "settings": map[string]interface{}{"brokers": "kafka:9092"}}, shared))
+	assert.False(t, sameConnection(map[string]interface{}{"ref": "#kafkaconn", "settings": map[string]interface{}{"brokers": "other:9092"}}, shared))
+	assert.False(t, sameConnection(map[string]interface{}{"ref": "#sqlconn", "settings": map[string]interface{}{"brokers": "kafka:9092"}}, shared))
+
+	// no settings at all is the same as empty settings
+	assert.True(t, sameConnection(map[string]interface{}{"ref": "#kafkaconn"}, map[string]interface{}{"ref": "#kafkaconn", "settings": map[string]interface{}{}}))
+}
+
+func TestAddRemoveConnection(t *testing.T) {
+	t.Log("Testing the shared connections of the app descriptor")
+
+	tempDir, err := ioutil.TempDir("", "flogo-connection")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	descriptorFile := filepath.Join(tempDir, fileFlogoJson)
+	err = ioutil.WriteFile(descriptorFile, []byte(connectionsTestDescriptor), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(tempDir)
+
+	// the identical inline connection is replaced by a reference, the rest of the descriptor is left as is
+	err = AddConnection(project, "kafka", "#kafkaconn", []string{"brokers=kafka:9092"})
+	assert.Nil(t, err)
+	buf, err := ioutil.ReadFile(descriptorFile)
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "triggers": [
+    {
+      "id": "kafka",
+      "ref": "#kafka",
+      "settings": {
+        "connection": "conn://kafka"
+      }
+    }
+  ],
+  "resources": [],
+  "connections": {
+    "kafka": {
+      "ref": "#kafkaconn",
+      "settings": {
+        "brokers": "kafka:9092"
+      }
+    }
+  }
+}`, string(buf))
+
+	assert.NotNil(t, AddConnection(project, "kafka", "#kafkaconn", nil))
+	assert.NotNil(t, AddConnection(project, "kafka.eu", "#kafkaconn", nil))
+	assert.Nil(t, AddConnection(project, "sql", "#sqlconn", []string{"host=db"}))
+	assert.Nil(t, ListConnections(project, true))
+
+	// a referenced connection isn't removed unless its references are replaced by a copy
+	assert.NotNil(t, RemoveConnection(project, "kafka", false))
+	assert.Nil(t, RemoveConnection(project, "sql", false))
+	assert.Nil(t, RemoveConnection(project, "kafka", true))
+	assert.NotNil(t, RemoveConnection(project, "kafka", true))
+
+	buf, err = ioutil.ReadFile(descriptorFile)
+	assert.Nil(t, err)
+	assert.Equal(t, connectionsTestDescriptor, string(buf))
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+var (
+	goroutinePattern = regexp.MustCompile(`^goroutine (\d+) \[([^\]]+)\]:$`)
+	frameFilePattern = regexp.MustCompile(`^\s+(\S+\.go):(\d+)`)
+	logFlowPattern   = regexp.MustCompile(`\b(flow:[\w.@-]+)`)
+	logTaskPattern   = regexp.MustCompile(`(?i)\btask\s*[\['"]\s*([\w.:-]+)`)
+)
+
+// CrashReport is the analysis of a panic found in the output of an application
+type CrashReport struct {
+	Panic     string        // the panic message
+	Goroutine string        // the goroutine that panicked
+	Frames    []*CrashFrame // the frames of the goroutine, most recent first
+	Culprit   *CrashFrame   // the frame most likely to have caused the panic
+	Tasks     []string      // the tasks using the contribution of the culprit, as flow/task
+	LastFlow  string        // the last flow mentioned in the log before the panic
+	LastTask  string        // the last task mentioned in the log before the panic
+}
+
+// CrashFrame is a frame of the stack trace of a panic
+type CrashFrame struct {
+	Function string
+	Package  string
+	File     string
+	Line     int
+	Contrib  string // the contribution the frame belongs to, if any
+}
+
+// AnalyzeCrash analyzes the panic in the log of an application, the frames of the stack trace are mapped
+// to the contributions and tasks of the project, if specified
+func AnalyzeCrash(project common.AppProject, logFile string) error {
+
+	if logFile == "" {
+		if project == nil {
+			return fmt.Errorf("log file not specified")
+		}
+		logFile = appLogFile(project)
+	}
+
+	buf, err := ioutil.ReadFile(logFile)
+	if err != nil {
+		return err
+	}
+
+	if f, err := elf.NewFile(bytes.NewReader(buf)); err == nil {
+		if f.Type == elf.ET_CORE {
+			return fmt.Errorf("'%s' is a core dump, analyze it using 'dlv core <executable> %s' or analyze the output of the application, which contains the stack trace when GOTRACEBACK=crash", logFile, logFile)
+		}
+		return fmt.Errorf("'%s' isn't a log", logFile)
+	}
+
+	report := parseCrash(string(buf))
+	if report == nil {
+		return fmt.Errorf("no panic found in '%s'", logFile)
+	}
+
+	if project != nil {
+		appObj, err := readAppDescriptorObj(project)
+		if err != nil {
+			return err
+		}
+		mapCrashToApp(report, appObj)
+	} else {
+		mapCrashToApp(report, nil)
+	}
+
+	printCrashReport(report)
+
+	return nil
+}
+
+// parseCrash parses the last stack trace in the log, nil is returned if there isn't one
+func parseCrash(log string) *CrashReport {
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+
+	// the goroutine that panicked is the first one following the last panic, if the panic was recovered and
+	// its stack trace logged the last running goroutine is used
+	panicLine, start := -1, -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+			panicLine, start = i, -1
+		} else if m := goroutinePattern.FindStringSubmatch(line); m != nil {
+			if panicLine >= 0 && start < 0 || panicLine < 0 && m[2] == "running" {
+				start = i
+			}
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	report := &CrashReport{Goroutine: strings.TrimSuffix(lines[start], ":")}
+
+	// the message of a logged stack trace is the line logged before it
+	msgLine := panicLine
+	for i := start - 1; msgLine < 0 && i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			msgLine = i
+		}
+	}
+	if msgLine >= 0 {
+		report.Panic = strings.TrimSpace(lines[msgLine])
+	} else {
+		msgLine = start
+	}
+
+	for i := start + 1; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		matches := frameFilePattern.FindStringSubmatch(lines[i+1])
+		if function == "" || strings.HasPrefix(function, "created by ") || matches == nil {
+			break
+		}
+		line, _ := strconv.Atoi(matches[2])
+		report.Frames = append(report.Frames, &CrashFrame{Function: function, Package: framePackage(function), File: matches[1], Line: line})
+	}
+
+	for i := msgLine - 1; i >= 0 && (report.LastFlow == "" || report.LastTask == ""); i-- {
+		if report.LastFlow == "" {
+			if matches := logFlowPattern.FindStringSubmatch(lines[i]); matches != nil {
+				report.LastFlow = matches[1]
+			}
+		}
+		if report.LastTask == "" {
+			if matches := logTaskPattern.FindStringSubmatch(lines[i]); matches != nil {
+				report.LastTask = matches[1]
+			}
+		}
+	}
+
+	return report
+}
+
+// framePackage gets the package of the function of a frame, ex. github.com/org/repo/pkg.(*T).Method(0x1, 0x2)
+func framePackage(function string) string {
+
+	if idx := strings.LastIndex(function, "("); idx > 0 && strings.HasSuffix(function, ")") {
+		// remove the arguments, the receiver doesn't end the function
+		if !strings.HasSuffix(function[:idx], ".") {
+			function = function[:idx]
+		}
+	}
+
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+
+	return function[:slash+1+dot]
+}
+
+// mapCrashToApp maps the frames to the contributions imported by the app and determines the culprit, the
+// app descriptor is optional
+func mapCrashToApp(report *CrashReport, appObj map[string]interface{}) {
+
+	aliases := make(map[string]string)
+	var contribs []string
+
+	if appObj != nil {
+		var imports []string
+		if vals, ok := appObj["imports"].([]interface{}); ok {
+			for _, val := range vals {
+				if s, ok := val.(string); ok {
+					imports = append(imports, s)
+				}
+			}
+		}
+		if parsed, err := util.ParseImports(imports); err == nil {
+			for _, imp := range parsed {
+				contribs = append(contribs, imp.GoImportPath())
+				aliases[imp.CanonicalAlias()] = imp.GoImportPath()
+			}
+		}
+	}
+
+	// the longest contribution matching a package is used, in case a contribution is nested in another
+	sort.Slice(contribs, func(i, j int) bool {
+		return len(contribs[i]) > len(contribs[j])
+	})
+
+	for _, frame := range report.Frames {
+		for _, contrib := range contribs {
+			if frame.Package == contrib || strings.HasPrefix(frame.Package, contrib+"/") {
+				frame.Contrib = contrib
+				break
+			}
+		}
+	}
+
+	// the culprit is the most recent frame of a contribution, otherwise of code that isn't part of the runtime,
+	// the standard library or the engine
+	for _, frame := range report.Frames {
+		if frame.Contrib != "" && !isEngineFrame(frame) {
+			report.Culprit = frame
+			break
+		}
+	}
+	if report.Culprit == nil {
+		for _, frame := range report.Frames {
+			if isThirdPartyFrame(frame) && !isEngineFrame(frame) {
+				report.Culprit = frame
+				break
+			}
+		}
+	}
+
+	if report.Culprit == nil || report.Culprit.Contrib == "" || appObj == nil {
+		return
+	}
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		data, _ := resMap["data"].(map[string]interface{})
+
+		var tasks []interface{}
+		if t, ok := data["tasks"].([]interface{}); ok {
+			tasks = append(tasks, t...)
+		}
+		if eh, ok := data["errorHandler"].(map[string]interface{}); ok {
+			if t, ok := eh["tasks"].([]interface{}); ok {
+				tasks = append(tasks, t...)
+			}
+		}
+
+		for _, task := range tasks {
+			taskMap, _ := task.(map[string]interface{})
+			activity, _ := taskMap["activity"].(map[string]interface{})
+			ref, _ := activity["ref"].(string)
+			if strings.HasPrefix(ref, "#") {
+				ref = aliases[ref[1:]]
+			}
+			if ref == report.Culprit.Contrib {
+				taskId, _ := taskMap["id"].(string)
+				report.Tasks = append(report.Tasks, resId+"/"+taskId)
+			}
+		}
+	}
+}
+
+// isThirdPartyFrame checks if the frame isn't part of the runtime or the standard library
+func isThirdPartyFrame(frame *CrashFrame) bool {
+	firstElem := strings.SplitN(frame.Package, "/", 2)[0]
+	return strings.Contains(firstElem, ".")
+}
+
+// isEngineFrame checks if the frame is part of the engine, which runs the flows and activities
+func isEngineFrame(frame *CrashFrame) bool {
+	for _, pkg := range []string{"github.com/project-flogo/core", "github.com/project-flogo/flow"} {
+		if frame.Package == pkg || strings.HasPrefix(frame.Package, pkg+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func printCrashReport(report *CrashReport) {
+
+	if report.Panic != "" {
+		fmt.Println(report.Panic)
+	}
+	fmt.Printf("in %s\n\n", report.Goroutine)
+
+	if report.Culprit != nil {
+		if report.Culprit.Contrib != "" {
+			fmt.Printf("Probable failing contribution: %s\n", report.Culprit.Contrib)
+		} else {
+			fmt.Printf("Probable failing package: %s\n", report.Culprit.Package)
+		}
+		fmt.Printf("  at %s\n", report.Culprit.Function)
+		fmt.Printf("     %s:%d\n", report.Culprit.File, report.Culprit.Line)
+		if len(report.Tasks) > 0 {
+			fmt.Printf("  used by: %s\n", strings.Join(report.Tasks, ", "))
+		}
+		fmt.Println()
+	} else {
+		fmt.Println("The panic occurred in the runtime or the engine, no failing contribution found")
+		fmt.Println()
+	}
+
+	if report.LastFlow != "" || report.LastTask != "" {
+		fmt.Println("Last flow/task in the log before the panic:")
+		if report.LastFlow != "" {
+			fmt.Printf("  flow: %s\n", report.LastFlow)
+		}
+		if report.LastTask != "" {
+			fmt.Printf("  task: %s\n", report.LastTask)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("Stack:")
+	for _, frame := range report.Frames {
+		marker := "  "
+		if frame == report.Culprit {
+			marker = "> "
+		}
+		origin := ""
+		switch {
+		case isEngineFrame(frame):
+			origin = " [engine]"
+		case frame.Contrib != "":
+			origin = " [contrib]"
+		case !isThirdPartyFrame(frame):
+			origin = " [runtime]"
+		}
+		fmt.Printf("%s%s%s\n      %s:%d\n", marker, frame.Function, origin, filepath.Base(frame.File), frame.Line)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCrashLog = `2026-10-15T10:00:01.000Z	DEBUG	[flogo.flow] -	Executing instance [abc] of flow:main
+2026-10-15T10:00:01.001Z	DEBUG	[flogo.flow] -	Eval task 'call'
+panic: runtime error: invalid memory address or nil pointer dereference
+
+goroutine 42 [running]:
+github.com/project-flogo/contrib/activity/rest.(*Activity).Eval(0xc0001a2000, 0x9a8b60)
+	/go/pkg/mod/github.com/project-flogo/contrib/activity/rest@v0.9.0/activity.go:102 +0x1fa
+github.com/project-flogo/flow/instance.(*IndependentInstance).execTask(0xc000123400)
+	/go/pkg/mod/github.com/project-flogo/flow@v0.9.4/instance/ind_instance.go:540 +0x4c5
+created by github.com/project-flogo/core/engine/runner.(*PooledRunner).Start
+	/go/pkg/mod/github.com/project-flogo/core@v0.9.5/engine/runner/pooled.go:60 +0x1ad
+
+goroutine 1 [chan receive]:
+main.main()
+	/app/src/main.go:58 +0x2d
+`
+
+func TestParseCrash(t *testing.T) {
+	t.Log("Testing parsing of the stack trace of a panic")
+
+	report := parseCrash(testCrashLog)
+	assert.NotNil(t, report)
+	assert.Equal(t, "panic: runtime error: invalid memory address or nil pointer dereference", report.Panic)
+	assert.Equal(t, "goroutine 42 [running]", report.Goroutine)
+	assert.Equal(t, "flow:main", report.LastFlow)
+	assert.Equal(t, "call", report.LastTask)
+
+	assert.Len(t, report.Frames, 2)
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", report.Frames[0].Package)
+	assert.Equal(t, 102, report.Frames[0].Line)
+	assert.Equal(t, "github.com/project-flogo/flow/instance", report.Frames[1].Package)
+
+	assert.Nil(t, parseCrash("2026-10-15T10:00:01.000Z	INFO	[flogo.engine] -	Engine Started\n"))
+}
+
+func TestMapCrashToApp(t *testing.T) {
+	t.Log("Testing mapping of the stack trace of a panic to the contributions and tasks of the app")
+
+	appObj := map[string]interface{}{
+		"imports": []interface{}{"github.com/project-flogo/contrib/activity/rest", "github.com/project-flogo/flow"},
+		"resources": []interface{}{
+			map[string]interface{}{"id": "flow:main", "data": map[string]interface{}{
+				"tasks": []interface{}{
+					map[string]interface{}{"id": "call", "activity": map[string]interface{}{"ref": "#rest"}},
+					map[string]interface{}{"id": "log", "activity": map[string]interface{}{"ref": "#log"}},
+				},
+			}},
+		},
+	}
+
+	report := parseCrash(testCrashLog)
+	mapCrashToApp(report, appObj)
+
+	assert.NotNil(t, report.Culprit)
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", report.Culprit.Contrib)
+	assert.Equal(t, []string{"flow:main/call"}, report.Tasks)
+	assert.True(t, isEngineFrame(report.Frames[1]))
+}
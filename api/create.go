@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -14,11 +15,24 @@ import (
 
 var fileSampleEngineMain = filepath.Join("examples", "engine", "main.go")
 
-func CreateProject(basePath, appName, appCfgPath, coreVersion string) (common.AppProject, error) {
+func CreateProject(ctx context.Context, basePath, appName, appCfgPath, coreVersion string) (common.AppProject, error) {
 
 	var err error
 	var appJson string
 
+	coreVersion = defaultCoreVersion(coreVersion)
+
+	// the descriptor of a git repository is created from as a local file, along with the flogo.lock next to it
+	fromGit := appCfgPath != "" && isGitDescriptor(appCfgPath)
+	if fromGit {
+		file, cleanup, err := fetchGitDescriptor(appCfgPath)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		appCfgPath = file
+	}
+
 	if appCfgPath != "" {
 
 		if util.IsRemote(appCfgPath) {
@@ -33,6 +47,15 @@ func CreateProject(basePath, appName, appCfgPath, coreVersion string) (common.Ap
 				return nil, fmt.Errorf("unable to load app file '%s' - %s", appCfgPath, err.Error())
 			}
 		}
+
+		// a YAML app descriptor is converted, the application keeping it as its app descriptor
+		if util.IsYAMLFile(appCfgPath) {
+			converted, err := util.YAMLToJSON([]byte(appJson), jsonIndent)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse app file '%s' - %s", appCfgPath, err.Error())
+			}
+			appJson = string(converted)
+		}
 	} else {
 		if len(appName) == 0 {
 			return nil, fmt.Errorf("app name not specified")
@@ -44,7 +67,7 @@ func CreateProject(basePath, appName, appCfgPath, coreVersion string) (common.Ap
 		return nil, err
 	}
 
-	fmt.Printf("Creating Flogo App: %s\n", appName)
+	fmt.Println(util.T("create.creating", appName))
 
 	appDir, err := createAppDirectory(basePath, appName)
 	if err != nil {
@@ -55,17 +78,17 @@ func CreateProject(basePath, appName, appCfgPath, coreVersion string) (common.Ap
 	dm := util.NewDepManager(srcDir)
 
 	if Verbose() {
-		fmt.Printf("Setting up app directory: %s\n", appDir)
+		fmt.Println(util.T("create.appDir", appDir))
 	}
 
-	err = setupAppDirectory(dm, appDir, coreVersion)
+	err = setupAppDirectory(ctx, dm, appDir, coreVersion)
 	if err != nil {
 		return nil, err
 	}
 
 	if Verbose() {
 		if appJson == "" {
-			fmt.Println("Adding sample flogo.json")
+			fmt.Println(util.T("create.sample"))
 		}
 	}
 	err = createAppJson(dm, appDir, appName, appJson)
@@ -73,24 +96,63 @@ func CreateProject(basePath, appName, appCfgPath, coreVersion string) (common.Ap
 		return nil, err
 	}
 
+	if appCfgPath != "" && util.IsYAMLFile(appCfgPath) {
+		err = createAppYaml(appDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = createMain(dm, appDir)
 	if err != nil {
 		return nil, err
 	}
 
+	// the imports without a version are created from the versions locked next to the app descriptor
+	if appCfgPath != "" && !util.IsRemote(appCfgPath) {
+		lockFile := filepath.Join(filepath.Dir(appCfgPath), fileFlogoLock)
+		if util.FileExists(lockFile) {
+			err = util.CopyFile(lockFile, filepath.Join(appDir, fileFlogoLock))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	project := NewAppProject(appDir)
 
 	if Verbose() {
-		fmt.Println("Importing Dependencies...")
+		fmt.Println(util.T("create.importing"))
 	}
 
-	err = importDependencies(project)
+	// the local directories replacing contributions are relative to the descriptor the project is created from, unless
+	// it is in a git repository whose clone is removed
+	baseDir := appDir
+	if appCfgPath != "" && !util.IsRemote(appCfgPath) && !fromGit {
+		baseDir, _ = filepath.Abs(filepath.Dir(appCfgPath))
+	}
+	err = applyImportReplaces(ctx, project, baseDir)
 	if err != nil {
 		return nil, err
 	}
 
+	err = importDependencies(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkGoVersion(project)
+	if err != nil {
+		return nil, err
+	}
+
+	// a copied flogo.lock is kept as is, so a build using --frozen detects the imports not resolved to its versions
+	if !util.FileExists(filepath.Join(appDir, fileFlogoLock)) {
+		updateProjectLock(project)
+	}
+
 	if Verbose() {
-		fmt.Printf("Created App: %s\n", appName)
+		fmt.Println(util.T("create.created", appName))
 	}
 
 	return project, nil
@@ -118,7 +180,7 @@ func createAppDirectory(basePath, appName string) (string, error) {
 }
 
 //setupAppDirectory sets up the flogo app directory
-func setupAppDirectory(dm util.DepManager, appPath, coreVersion string) error {
+func setupAppDirectory(ctx context.Context, dm util.DepManager, appPath, coreVersion string) error {
 
 	err := os.Mkdir(filepath.Join(appPath, dirBin), os.ModePerm)
 	if err != nil {
@@ -131,16 +193,12 @@ func setupAppDirectory(dm util.DepManager, appPath, coreVersion string) error {
 		return err
 	}
 
-	_, err = os.Create(filepath.Join(srcDir, fileImportsGo))
-	if err != nil {
-		return err
-	}
-	err = ioutil.WriteFile(filepath.Join(srcDir, fileImportsGo), []byte("package main\n"), 0644)
+	err = util.WriteFileAtomic(filepath.Join(srcDir, fileImportsGo), []byte("package main\n"), 0644)
 	if err != nil {
 		return err
 	}
 
-	err = dm.Init()
+	err = dm.Init(ctx)
 	if err != nil {
 		return err
 	}
@@ -149,13 +207,13 @@ func setupAppDirectory(dm util.DepManager, appPath, coreVersion string) error {
 
 	//todo get the actual version installed from the go.mod file
 	if coreVersion == "" {
-		fmt.Printf("Installing: %s@latest\n", flogoCoreImport.CanonicalImport())
+		fmt.Println(util.T("create.installing", flogoCoreImport.CanonicalImport()+"@latest"))
 	} else {
-		fmt.Printf("Installing: %s\n", flogoCoreImport.CanonicalImport())
+		fmt.Println(util.T("create.installing", flogoCoreImport.CanonicalImport()))
 	}
 
 	// add & fetch the core library
-	err = dm.AddDependency(flogoCoreImport)
+	err = dm.AddDependency(ctx, flogoCoreImport)
 	if err != nil {
 		return err
 	}
@@ -171,7 +229,7 @@ func createAppJson(dm util.DepManager, appDir, appName, appJson string) error {
 		return err
 	}
 
-	err = ioutil.WriteFile(filepath.Join(appDir, fileFlogoJson), []byte(updatedJson), 0644)
+	err = util.WriteFileAtomic(filepath.Join(appDir, fileFlogoJson), []byte(updatedJson), 0644)
 	if err != nil {
 		return err
 	}
@@ -179,8 +237,19 @@ func createAppJson(dm util.DepManager, appDir, appName, appJson string) error {
 	return nil
 }
 
+// createAppYaml creates the flogo.yaml app descriptor of the app from its flogo.json
+func createAppYaml(appDir string) error {
+
+	buf, err := ioutil.ReadFile(filepath.Join(appDir, fileFlogoJson))
+	if err != nil {
+		return err
+	}
+
+	return writeYamlFile(appDir, filepath.Join(appDir, fileFlogoYaml), buf)
+}
+
 // importDependencies import all dependencies
-func importDependencies(project common.AppProject) error {
+func importDependencies(ctx context.Context, project common.AppProject) error {
 
 	ai, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), true)
 	if err != nil {
@@ -193,7 +262,13 @@ func importDependencies(project common.AppProject) error {
 		return nil
 	}
 
-	err = project.AddImports(true, false, imports...)
+	lock, err := readProjectLock(project)
+	if err != nil {
+		return err
+	}
+	imports = lockedImports(lock, imports)
+
+	err = project.AddImports(ctx, true, false, imports...)
 	if err != nil {
 		return err
 	}
@@ -225,14 +300,14 @@ func importDependencies(project common.AppProject) error {
 				}
 			}
 
-			fmt.Printf("Installed %s: %s\n", cType, details.Imp)
+			fmt.Println(util.T("install.installed", cType, details.Imp))
 			//instStr := fmt.Sprintf("Installed %s:", cType)
 			//fmt.Printf("%-20s %s\n", instStr, imp)
 		}
 	}
 
 	if legacySupportRequired {
-		err := InstallLegacySupport(project)
+		err := InstallLegacySupport(ctx, project)
 		return err
 	}
 
@@ -256,7 +331,7 @@ func createMain(dm util.DepManager, appDir string) error {
 		return err
 	}
 
-	err = ioutil.WriteFile(filepath.Join(appDir, dirSrc, fileMainGo), bytes, 0644)
+	err = util.WriteFileAtomic(filepath.Join(appDir, dirSrc, fileMainGo), bytes, 0644)
 	if err != nil {
 		return err
 	}
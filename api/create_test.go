@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -105,7 +106,7 @@ func TestCmdCreate_noflag(t *testing.T) {
 	defer testEnv.cleanup()
 
 	t.Logf("Current dir '%s'", testEnv.currentDir)
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "")
 	assert.Equal(t, nil, err)
 
 	_, err = os.Stat(filepath.Join(tempDir, "myApp", "src", "go.mod"))
@@ -140,7 +141,7 @@ func TestCmdCreate_flag(t *testing.T) {
 	}
 	defer file.Close()
 	fmt.Fprintf(file, jsonString)
-	_, err = CreateProject(testEnv.currentDir, "flogo", "flogo.json", "")
+	_, err = CreateProject(context.Background(), testEnv.currentDir, "flogo", "flogo.json", "")
 	assert.Equal(t, nil, err)
 
 	_, err = os.Stat(filepath.Join(tempDir, "flogo", "src", "go.mod"))
@@ -169,7 +170,7 @@ func TestCmdCreate_masterCore(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	os.Chdir(testEnv.currentDir)
 
-	_, err = CreateProject(testEnv.currentDir, "myApp", "", "master")
+	_, err = CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "master")
 	assert.Equal(t, nil, err)
 }
 
@@ -189,7 +190,7 @@ func TestCmdCreate_masterCore(t *testing.T) {
 //	t.Logf("Current dir '%s'", testEnv.currentDir)
 //	os.Chdir(testEnv.currentDir)
 //
-//	_, err = CreateProject(testEnv.currentDir, "myApp", "", "v0.9.0-alpha.4")
+//	_, err = CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "v0.9.0-alpha.4")
 //	assert.Equal(t, nil, err)
 //
 //	_, err = os.Stat(filepath.Join(tempDir, "myApp", "src", "go.mod"))
@@ -216,6 +217,6 @@ func TestCmdCreate_masterCore(t *testing.T) {
 //
 //	common.SetCurrentProject(appProject)
 //
-//	err = BuildProject(common.CurrentProject(), BuildOptions{})
+//	err = BuildProject(context.Background(), common.CurrentProject(), BuildOptions{})
 //	assert.Nil(t, err)
 //}
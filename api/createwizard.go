@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// the maximum number of search results the create wizard offers to install
+const wizardSearchResults = 10
+
+// CreateProjectInteractive guides the user through the creation of a project: its name, the version of the core, the
+// triggers and activities installed, found searching the registry, the Go module path and the license
+func CreateProjectInteractive(ctx context.Context, basePath, appName, coreVersion string) (common.AppProject, error) {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(util.T("wizard.application"))
+	for {
+		appName, err = util.Prompt("  "+util.T("wizard.namePrompt"), appName)
+		if err != nil {
+			return nil, err
+		}
+		if appName == "" {
+			continue
+		}
+		if util.FileExists(filepath.Join(basePath, appName)) {
+			fmt.Println("  " + util.T("wizard.dirExists", appName))
+			appName = ""
+			continue
+		}
+		break
+	}
+
+	fmt.Println("\n" + util.T("wizard.coreVersion"))
+	version, err := util.Prompt("  "+util.T("wizard.coreVersionPrompt"), orDefault(orDefault(coreVersion, config.CoreVersion), "latest"))
+	if err != nil {
+		return nil, err
+	}
+	if version == "latest" {
+		version = ""
+	}
+
+	fmt.Println("\n" + util.T("wizard.contribs"))
+	fmt.Println("  " + util.T("wizard.contribsHelp"))
+	contribs, err := promptContributions()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("\n" + util.T("wizard.module"))
+	module, err := util.Prompt("  "+util.T("wizard.modulePrompt"), "main")
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("\n" + util.T("wizard.license"))
+	license, err := promptLicense()
+	if err != nil {
+		return nil, err
+	}
+	var holder string
+	if license != licenseNone {
+		holder, err = util.Prompt("  "+util.T("wizard.holderPrompt"), gitUserName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Println("\n" + util.T("wizard.summary"))
+	fmt.Println("  " + util.T("wizard.summaryName", appName))
+	fmt.Println("  " + util.T("wizard.summaryCoreVersion", orDefault(version, "latest")))
+	fmt.Println("  " + util.T("wizard.summaryContribs", orDefault(strings.Join(contribs, ", "), "none")))
+	fmt.Println("  " + util.T("wizard.summaryModule", module))
+	if license != licenseNone {
+		fmt.Println("  " + util.T("wizard.summaryLicenseHolder", license, holder))
+	} else {
+		fmt.Println("  " + util.T("wizard.summaryLicense", license))
+	}
+	if !util.Confirm(util.T("wizard.confirm")) {
+		return nil, fmt.Errorf("creation canceled")
+	}
+	fmt.Println()
+
+	project, err := CreateProject(ctx, basePath, appName, "", version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, contrib := range contribs {
+		err = InstallPackage(ctx, project, contrib)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("wizard.installFailed", contrib, contrib, err))
+		}
+	}
+
+	if module != "main" {
+		err = util.ExecCmd(exec.CommandContext(ctx, "go", "mod", "edit", "-module", module), project.SrcDir())
+		if err != nil {
+			return project, fmt.Errorf("unable to set the module path '%s': %v", module, err)
+		}
+	}
+
+	if license != licenseNone {
+		text, err := renderLicense(license, holder)
+		if err != nil {
+			return project, err
+		}
+		err = ioutil.WriteFile(filepath.Join(project.Dir(), fileLicense), text, 0644)
+		if err != nil {
+			return project, err
+		}
+	}
+
+	fmt.Println("\n" + util.T("wizard.created", appName, project.Dir()))
+
+	return project, nil
+}
+
+// promptContributions searches the registry until the answer is empty, the import paths of the contributions
+// selected are returned
+func promptContributions() ([]string, error) {
+
+	var contribs []string
+	selected := make(map[string]bool)
+
+	add := func(ref string) {
+		if !selected[ref] {
+			selected[ref] = true
+			contribs = append(contribs, ref)
+			fmt.Println("  " + util.T("wizard.added", ref))
+		}
+	}
+
+	for {
+		query, err := util.Prompt("  "+util.T("wizard.searchPrompt"), "")
+		if err != nil {
+			return nil, err
+		}
+		if query == "" {
+			return contribs, nil
+		}
+
+		// an import path, ex. github.com/project-flogo/contrib/activity/log@v0.9.0
+		if strings.Contains(query, "/") {
+			add(query)
+			continue
+		}
+
+		entries, err := SearchContributions(query, SearchOptions{})
+		if err != nil {
+			fmt.Println("  " + util.T("wizard.searchFailed", err))
+			continue
+		}
+
+		var results []*common.RegistryEntry
+		for _, entry := range entries {
+			if entry.Type == ContribTrigger || entry.Type == ContribActivity || entry.Type == "" {
+				results = append(results, entry)
+			}
+		}
+		if len(results) == 0 {
+			fmt.Println("  " + util.T("wizard.noMatch", query))
+			continue
+		}
+		if len(results) > wizardSearchResults {
+			results = results[:wizardSearchResults]
+		}
+
+		for i, entry := range results {
+			fmt.Printf("  %2d) %-10s %s\n", i+1, entry.Type, entry.Ref)
+			if entry.Description != "" {
+				fmt.Printf("      %s\n", entry.Description)
+			}
+		}
+
+		for {
+			answer, err := util.Prompt("  "+util.T("wizard.installPrompt"), "")
+			if err != nil {
+				return nil, err
+			}
+			indexes, err := parseSelection(answer, len(results))
+			if err != nil {
+				fmt.Printf("  %v\n", err)
+				continue
+			}
+			for _, i := range indexes {
+				add(results[i].Ref)
+			}
+			break
+		}
+	}
+}
+
+// parseSelection parses a selection of numbered items, ex. 1,3, the indexes of the items are returned
+func parseSelection(answer string, count int) ([]int, error) {
+
+	var indexes []int
+	seen := make(map[int]bool)
+
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > count {
+			return nil, fmt.Errorf("invalid selection '%s', expected numbers from 1 to %d", field, count)
+		}
+		if !seen[n] {
+			seen[n] = true
+			indexes = append(indexes, n-1)
+		}
+	}
+
+	return indexes, nil
+}
+
+// promptLicense asks for the license of the application, none or one of the licenses a project can be created with
+func promptLicense() (string, error) {
+
+	choices := append([]string{licenseNone}, Licenses()...)
+
+	for {
+		answer, err := util.Prompt("  "+util.T("wizard.licensePrompt", strings.Join(choices, ", ")), licenseNone)
+		if err != nil {
+			return "", err
+		}
+		if strings.EqualFold(answer, licenseNone) {
+			return licenseNone, nil
+		}
+		if license := findLicense(answer); license != "" {
+			return license, nil
+		}
+		fmt.Println("  " + util.T("wizard.unknownLicense", answer))
+	}
+}
+
+// gitUserName gets the user name of the git configuration, empty if git isn't configured
+func gitUserName() string {
+
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelection(t *testing.T) {
+
+	indexes, err := parseSelection("1, 3,3 2", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 2, 1}, indexes)
+
+	indexes, err = parseSelection("", 3)
+	assert.Nil(t, err)
+	assert.Empty(t, indexes)
+
+	_, err = parseSelection("4", 3)
+	assert.NotNil(t, err)
+
+	_, err = parseSelection("1,rest", 3)
+	assert.NotNil(t, err)
+}
+
+func TestRenderLicense(t *testing.T) {
+
+	assert.Equal(t, []string{"Apache-2.0", "BSD-3-Clause", "MIT"}, Licenses())
+	assert.Equal(t, "MIT", findLicense("mit"))
+	assert.Equal(t, "", findLicense("GPL-3.0"))
+
+	for _, license := range Licenses() {
+		text, err := renderLicense(license, "Acme Inc")
+		assert.Nil(t, err)
+		assert.True(t, strings.Contains(string(text), fmt.Sprintf("%d", time.Now().Year())), license)
+		assert.True(t, strings.Contains(string(text), "Acme Inc"), license)
+	}
+
+	_, err := renderLicense("GPL-3.0", "Acme Inc")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,663 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	fileDebugGo      = "debug.go"
+	fileDebugSession = "session.json"
+
+	flowActionRef = "github.com/project-flogo/flow"
+
+	envKeyDebugFlow  = "FLOGO_DEBUG_FLOW"
+	envKeyConfigPath = "FLOGO_CONFIG_PATH"
+)
+
+// DebugFlowOptions are the options used to debug a flow
+type DebugFlowOptions struct {
+	Input  string   // JSON file containing the input of the flow
+	Data   []string // input values of the flow, as name=value, overriding the ones of the input file
+	Breaks []string // ids of the tasks to pause at, all the tasks if not specified
+}
+
+// debugSession is the debug session passed to the debugger of the application
+type debugSession struct {
+	Ref         string                 `json:"ref"`                // the ref of the action to run
+	Settings    map[string]interface{} `json:"settings,omitempty"` // the settings of the action
+	FlowURI     string                 `json:"flowURI,omitempty"`  // the flow to run, when running the flow action
+	Input       map[string]interface{} `json:"input,omitempty"`
+	Breakpoints []string               `json:"breakpoints,omitempty"`
+	Tasks       []string               `json:"tasks,omitempty"` // the ids of the tasks of the app, to map task instances to tasks
+	Run         bool                   `json:"run,omitempty"`   // run the action once without pausing, only printing its output
+}
+
+// DebugFlow runs a flow of the application against the input, pausing before each task to show the data of the flow
+// and let the user continue, skip the task or override its outputs. The application is built with a debugger, which
+// is inactive unless started by this command, and run without its triggers.
+func DebugFlow(ctx context.Context, project common.AppProject, flow string, options DebugFlowOptions) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	session, err := newDebugSession(appObj, flow, options)
+	if err != nil {
+		return err
+	}
+
+	err = runDebugSession(ctx, project, appObj, session, os.Stdout)
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("flow '%s' didn't complete", flow)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// runDebugSession builds the application with the debugger and runs it without its triggers for the session
+func runDebugSession(ctx context.Context, project common.AppProject, appObj map[string]interface{}, session *debugSession, status io.Writer) error {
+
+	tempDir, err := ioutil.TempDir("", "flogo-debug")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	appFile, err := writeDebugAppDescriptor(tempDir, appObj)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(status, "Building application '%s' with the debugger...\n", project.Name())
+	err = BuildProject(ctx, project, common.BuildOptions{Debug: true})
+	if err != nil {
+		return err
+	}
+
+	cmd, err := debugSessionCmd(project, tempDir, appFile, session)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// writeDebugAppDescriptor writes the app descriptor run by the debugger in the directory
+func writeDebugAppDescriptor(dir string, appObj map[string]interface{}) (string, error) {
+
+	// the action is started by the debugger, so the triggers are removed to not have the app handle any other request
+	delete(appObj, "triggers")
+	appJson, err := json.MarshalIndent(appObj, "", jsonIndent)
+	if err != nil {
+		return "", err
+	}
+	appFile := filepath.Join(dir, fileFlogoJson)
+	if err := ioutil.WriteFile(appFile, appJson, 0644); err != nil {
+		return "", err
+	}
+
+	return appFile, nil
+}
+
+// debugSessionCmd writes the session in the directory and gets the command running the application built with the
+// debugger for it
+func debugSessionCmd(project common.AppProject, dir, appFile string, session *debugSession) (*exec.Cmd, error) {
+
+	sessionJson, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	sessionFile := filepath.Join(dir, fileDebugSession)
+	if err := ioutil.WriteFile(sessionFile, sessionJson, 0644); err != nil {
+		return nil, err
+	}
+
+	env := append(os.Environ(), envKeyConfigPath+"="+appFile, envKeyDebugFlow+"="+sessionFile)
+	if os.Getenv(envKeyLogLevel) == "" {
+		env = append(env, envKeyLogLevel+"=WARN")
+	}
+
+	cmd := exec.Command(project.Executable())
+	cmd.Dir = project.Dir()
+	cmd.Env = env
+
+	return cmd, nil
+}
+
+// newDebugSession creates the debug session of the flow, checking that the flow and the tasks to pause at exist
+func newDebugSession(appObj map[string]interface{}, flow string, options DebugFlowOptions) (*debugSession, error) {
+
+	flowId := normalizeFlowId(flow)
+
+	session := &debugSession{Ref: flowActionRef, FlowURI: resURIPrefix + flowId}
+
+	found := false
+	flowTasks := make(map[string]bool)
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		data, _ := resMap["data"].(map[string]interface{})
+
+		var tasks []interface{}
+		if t, ok := data["tasks"].([]interface{}); ok {
+			tasks = append(tasks, t...)
+		}
+		if eh, ok := data["errorHandler"].(map[string]interface{}); ok {
+			if t, ok := eh["tasks"].([]interface{}); ok {
+				tasks = append(tasks, t...)
+			}
+		}
+
+		for _, task := range tasks {
+			taskMap, _ := task.(map[string]interface{})
+			if taskId, _ := taskMap["id"].(string); taskId != "" {
+				session.Tasks = appendUnique(session.Tasks, taskId)
+				if resId == flowId {
+					flowTasks[taskId] = true
+				}
+			}
+		}
+		if resId == flowId {
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("flow '%s' not found", flow)
+	}
+
+	for _, task := range options.Breaks {
+		if !flowTasks[task] {
+			return nil, fmt.Errorf("task '%s' not found in flow '%s'", task, flow)
+		}
+		session.Breakpoints = append(session.Breakpoints, task)
+	}
+
+	input, err := readDebugInput(options.Input, options.Data)
+	if err != nil {
+		return nil, err
+	}
+	session.Input = input
+
+	return session, nil
+}
+
+// readDebugInput reads the input of an action from a JSON file and name=value pairs, overriding the values of the file
+func readDebugInput(file string, data []string) (map[string]interface{}, error) {
+
+	input := make(map[string]interface{})
+
+	if file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, &input); err != nil {
+			return nil, fmt.Errorf("invalid input '%s', expected a JSON object: %v", file, err)
+		}
+	}
+
+	values, err := parseKeyValues(data)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		input[name] = value
+	}
+
+	return input, nil
+}
+
+// createDebugGoFile generates the debugger, started when the application is run by 'flogo debug-flow'
+func createDebugGoFile(project common.AppProject) error {
+
+	if Verbose() {
+		fmt.Println("Adding flow debugger to application...")
+	}
+
+	return writeTemplateFile(filepath.Join(project.SrcDir(), fileDebugGo), tplDebugGoFile, nil)
+}
+
+func cleanupDebugGoFile(project common.AppProject) error {
+
+	debugSrcPath := filepath.Join(project.SrcDir(), fileDebugGo)
+
+	if _, err := os.Stat(debugSrcPath); err == nil {
+		if Verbose() {
+			fmt.Println("Removing flow debugger")
+		}
+		return os.Remove(debugSrcPath)
+	}
+
+	return nil
+}
+
+var tplDebugGoFile = `// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-flogo/core/action"
+	"github.com/project-flogo/core/engine"
+	"github.com/project-flogo/core/engine/event"
+	"github.com/project-flogo/core/engine/runner"
+	"github.com/project-flogo/core/support/trace"
+	"github.com/project-flogo/flow/instance"
+	flowsupport "github.com/project-flogo/flow/support"
+)
+
+const (
+	debugListener   = "flogo-cli-debug"
+	debugEventsWait = time.Second
+)
+
+// the debugger is only active when the application is run by 'flogo debug-flow'
+func init() {
+
+	sessionFile := os.Getenv("FLOGO_DEBUG_FLOW")
+	if sessionFile == "" {
+		return
+	}
+
+	d := &flowDebugger{
+		in:          bufio.NewReader(os.Stdin),
+		interceptor: &flowsupport.Interceptor{},
+		scopes:      make(map[string]map[string]interface{}),
+	}
+
+	content, err := ioutil.ReadFile(sessionFile)
+	if err == nil {
+		err = json.Unmarshal(content, &d.session)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to read debug session: %v\n", err)
+		os.Exit(1)
+	}
+
+	// the action is only run once when not debugged
+	if !d.session.Run {
+		if err := trace.RegisterTracer(d); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to start debug session, a tracer is already registered by the application")
+			os.Exit(1)
+		}
+	}
+
+	engine.LifeCycle(&flowDebugRun{d})
+}
+
+// debugTaskEvent is the event posted by the flow action when the status of a task changes
+type debugTaskEvent interface {
+	FlowID() string
+	TaskInstanceId() string
+	TaskOutput() map[string]interface{}
+}
+
+// debugFlowEvent is the event posted by the flow action when the status of a flow instance changes
+type debugFlowEvent interface {
+	FlowID() string
+	FlowInput() map[string]interface{}
+}
+
+// flowDebugger pauses the flow before its tasks, it is registered as tracer as the tracer is called synchronously
+// before the evaluation of each task. The task is skipped or its outputs overridden using the interceptor of the flow
+// instance, which is looked up once the tracer returns.
+type flowDebugger struct {
+	session struct {
+		Ref         string
+		Settings    map[string]interface{}
+		FlowURI     string
+		Input       map[string]interface{}
+		Breakpoints []string
+		Tasks       []string
+		Run         bool
+	}
+
+	in          *bufio.Reader
+	interceptor *flowsupport.Interceptor
+	running     bool // run to the end of the flow without pausing
+
+	mutex    sync.Mutex
+	expected int // events expected from the flow action
+	received int
+	scopes   map[string]map[string]interface{} // the data of the flow instances by instance id
+}
+
+// debugContext is the tracing context of a flow instance or a task
+type debugContext struct {
+	flowID string
+	task   string
+}
+
+func (c *debugContext) TraceObject() interface{}                        { return nil }
+func (c *debugContext) SetTags(tags map[string]interface{}) bool        { return true }
+func (c *debugContext) SetTag(tagKey string, tagValue interface{}) bool { return true }
+func (c *debugContext) LogKV(kvs map[string]interface{}) bool           { return true }
+
+func (d *flowDebugger) Name() string { return "flogo-cli-debugger" }
+func (d *flowDebugger) Start() error { return nil }
+func (d *flowDebugger) Stop() error  { return nil }
+
+func (d *flowDebugger) Extract(format trace.CarrierFormat, carrier interface{}) (trace.TracingContext, error) {
+	return nil, nil
+}
+
+func (d *flowDebugger) Inject(tCtx trace.TracingContext, format trace.CarrierFormat, carrier interface{}) error {
+	return nil
+}
+
+func (d *flowDebugger) StartTrace(config trace.Config, parent trace.TracingContext) (trace.TracingContext, error) {
+
+	flowID := fmt.Sprint(config.Tags["flow_id"])
+	taskInstance, isTask := config.Tags["task_instance_id"]
+	if !isTask {
+		d.expectEvent()
+		return &debugContext{flowID: flowID}, nil
+	}
+
+	ctx := &debugContext{flowID: flowID, task: d.taskID(fmt.Sprint(taskInstance))}
+
+	// the decision taken for a previous execution of the task doesn't apply to this one
+	d.intercept(ctx.task, false, nil)
+
+	if !d.running && d.isBreakpoint(ctx.task) {
+		d.pause(ctx, fmt.Sprint(config.Tags["flow_name"]))
+	}
+
+	return ctx, nil
+}
+
+func (d *flowDebugger) FinishTrace(tContext trace.TracingContext, err error) error {
+	if ctx, ok := tContext.(*debugContext); ok && ctx.task != "" {
+		d.expectEvent()
+	}
+	return nil
+}
+
+// taskID gets the id of the task of a task instance, the id of the instances of a repeating task have a counter
+func (d *flowDebugger) taskID(taskInstance string) string {
+	for _, id := range d.session.Tasks {
+		if id == taskInstance {
+			return id
+		}
+	}
+	if idx := strings.LastIndex(taskInstance, "-"); idx > 0 {
+		return taskInstance[:idx]
+	}
+	return taskInstance
+}
+
+func (d *flowDebugger) isBreakpoint(task string) bool {
+	if len(d.session.Breakpoints) == 0 {
+		return true
+	}
+	for _, bp := range d.session.Breakpoints {
+		if bp == task {
+			return true
+		}
+	}
+	return false
+}
+
+// intercept sets the interceptor of the task, the interceptors are updated in place as they can't be removed
+func (d *flowDebugger) intercept(task string, skip bool, outputs map[string]interface{}) {
+	for _, ti := range d.interceptor.TaskInterceptors {
+		if ti.ID == task {
+			ti.Skip = skip
+			ti.Outputs = outputs
+			return
+		}
+	}
+	if !skip {
+		return
+	}
+	d.interceptor.TaskInterceptors = append(d.interceptor.TaskInterceptors, &flowsupport.TaskInterceptor{ID: task, Skip: skip, Outputs: outputs})
+	d.interceptor.Init()
+}
+
+func (d *flowDebugger) pause(ctx *debugContext, flowName string) {
+
+	fmt.Printf("\nPaused before task '%s' of flow '%s' (%s)\n", ctx.task, flowName, ctx.flowID)
+	d.printScope(ctx.flowID)
+
+	for {
+		fmt.Print("debug> ")
+		line, err := d.in.ReadString('\n')
+		if err == io.EOF {
+			d.running = true
+			return
+		}
+
+		cmd := strings.TrimSpace(line)
+		arg := ""
+		if idx := strings.Index(cmd, " "); idx > 0 {
+			cmd, arg = cmd[:idx], strings.TrimSpace(cmd[idx+1:])
+		}
+
+		switch cmd {
+		case "", "c", "continue":
+			return
+		case "s", "skip":
+			d.intercept(ctx.task, true, nil)
+			fmt.Printf("Skipping task '%s'\n", ctx.task)
+			return
+		case "o", "outputs":
+			var outputs map[string]interface{}
+			if err := json.Unmarshal([]byte(arg), &outputs); err != nil || len(outputs) == 0 {
+				fmt.Println("Invalid outputs, expected a JSON object, ex. o {\"code\": 200}")
+				continue
+			}
+			d.intercept(ctx.task, true, outputs)
+			fmt.Printf("Skipping task '%s' with outputs %s\n", ctx.task, arg)
+			return
+		case "d", "data":
+			d.printScope(ctx.flowID)
+		case "r", "run":
+			d.running = true
+			return
+		case "q", "quit":
+			fmt.Println("Debug session aborted")
+			os.Exit(1)
+		default:
+			fmt.Println("c, continue        execute the task")
+			fmt.Println("s, skip            skip the task")
+			fmt.Println("o, outputs <json>  skip the task and use the outputs, ex. o {\"code\": 200}")
+			fmt.Println("d, data            show the data of the flow")
+			fmt.Println("r, run             run to the end of the flow without pausing")
+			fmt.Println("q, quit            abort the debug session")
+		}
+	}
+}
+
+// printScope prints the data of the flow instance, its input and the outputs of its tasks. The data is collected from
+// the events of the flow action, which are published asynchronously, so the pending events are waited for.
+func (d *flowDebugger) printScope(flowID string) {
+
+	d.mutex.Lock()
+	deadline := time.Now().Add(debugEventsWait)
+	for d.received < d.expected && time.Now().Before(deadline) {
+		d.mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		d.mutex.Lock()
+	}
+	scope := d.scopes[flowID]
+	d.mutex.Unlock()
+
+	var names []string
+	for name := range scope {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := json.Marshal(scope[name])
+		if err != nil {
+			value = []byte(fmt.Sprintf("%v", scope[name]))
+		}
+		fmt.Printf("  %s = %s\n", name, value)
+	}
+}
+
+func (d *flowDebugger) expectEvent() {
+	d.mutex.Lock()
+	d.expected++
+	d.mutex.Unlock()
+}
+
+func (d *flowDebugger) HandleEvent(ctx *event.Context) error {
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	switch evt := ctx.GetEvent().(type) {
+	case debugTaskEvent:
+		switch debugEventStatus(evt, "TaskStatus") {
+		case "Completed":
+			d.scope(evt.FlowID())["$activity["+d.taskID(evt.TaskInstanceId())+"]"] = evt.TaskOutput()
+			d.received++
+		case "Failed", "Skipped":
+			d.received++
+		}
+	case debugFlowEvent:
+		if debugEventStatus(evt, "FlowStatus") == "Started" {
+			d.scope(evt.FlowID())["$flow"] = evt.FlowInput()
+			d.received++
+		}
+	}
+
+	return nil
+}
+
+func (d *flowDebugger) scope(flowID string) map[string]interface{} {
+	scope, exists := d.scopes[flowID]
+	if !exists {
+		scope = make(map[string]interface{})
+		d.scopes[flowID] = scope
+	}
+	return scope
+}
+
+// flowDebugRun runs the action of the session once the engine is started, the application exits when it's done
+type flowDebugRun struct {
+	d *flowDebugger
+}
+
+// Start starts the action, the application exits if it can't be started as the engine keeps running when a service
+// fails to start
+func (r *flowDebugRun) Start() error {
+
+	session := r.d.session
+
+	factory := action.GetFactory(session.Ref)
+	if factory == nil {
+		fmt.Fprintf(os.Stderr, "Action '%s' isn't installed\n", session.Ref)
+		os.Exit(1)
+	}
+
+	settings := session.Settings
+	if session.FlowURI != "" {
+		settings = map[string]interface{}{"flowURI": session.FlowURI}
+	}
+
+	act, err := factory.New(&action.Config{Ref: session.Ref, Settings: settings})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to create action '%s': %v\n", session.Ref, err)
+		os.Exit(1)
+	}
+
+	if !session.Run {
+		err := event.RegisterListener(debugListener, r.d, []string{"flowevent", "taskevent"})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to start debug session: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Debugging %s, enter 'h' at a breakpoint for help\n", session.FlowURI)
+	}
+
+	go r.run(act)
+
+	return nil
+}
+
+func (r *flowDebugRun) Stop() error {
+	if !r.d.session.Run {
+		event.UnRegisterListener(debugListener, []string{"flowevent", "taskevent"})
+	}
+	return nil
+}
+
+func (r *flowDebugRun) run(act action.Action) {
+
+	inputs := make(map[string]interface{}, len(r.d.session.Input)+1)
+	for name, value := range r.d.session.Input {
+		inputs[name] = value
+	}
+	if r.d.session.FlowURI != "" {
+		inputs["_run_options"] = &instance.RunOptions{Op: instance.OpStart, FlowURI: r.d.session.FlowURI,
+			ExecOptions: &instance.ExecOptions{Interceptor: r.d.interceptor}}
+	}
+
+	results, err := runner.NewDirect().RunAction(context.Background(), act, inputs)
+	if err != nil {
+		if r.d.session.Run {
+			fmt.Fprintf(os.Stderr, "Action failed: %v\n", err)
+		} else {
+			fmt.Printf("\nFlow failed: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	if results == nil {
+		results = make(map[string]interface{})
+	}
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		output = []byte(fmt.Sprintf("%v", results))
+	}
+	if r.d.session.Run {
+		fmt.Println(string(output))
+	} else {
+		fmt.Printf("\nFlow completed, output: %s\n", output)
+	}
+	os.Exit(0)
+}
+
+// debugEventStatus gets the status of the event, the status type is declared by the flow action
+func debugEventStatus(evt interface{}, method string) string {
+
+	m := reflect.ValueOf(evt).MethodByName(method)
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return ""
+	}
+
+	return fmt.Sprint(m.Call(nil)[0].Interface())
+}
+`
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDebugSession(t *testing.T) {
+	t.Log("Testing creation of the debug session of a flow")
+
+	appObj := map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{"id": "flow:orders", "data": map[string]interface{}{
+				"tasks": []interface{}{
+					map[string]interface{}{"id": "log"},
+					map[string]interface{}{"id": "call"},
+				},
+				"errorHandler": map[string]interface{}{
+					"tasks": []interface{}{map[string]interface{}{"id": "reply"}},
+				},
+			}},
+			map[string]interface{}{"id": "flow:audit", "data": map[string]interface{}{
+				"tasks": []interface{}{map[string]interface{}{"id": "store"}},
+			}},
+		},
+	}
+
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	input := filepath.Join(tempDir, "input.json")
+	assert.Nil(t, ioutil.WriteFile(input, []byte(`{"id": 1, "name": "order"}`), 0644))
+
+	session, err := newDebugSession(appObj, "orders", DebugFlowOptions{Input: input, Data: []string{"id=2"}, Breaks: []string{"call"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "res://flow:orders", session.FlowURI)
+	assert.Equal(t, map[string]interface{}{"id": float64(2), "name": "order"}, session.Input)
+	assert.Equal(t, []string{"call"}, session.Breakpoints)
+	assert.Equal(t, []string{"log", "call", "reply", "store"}, session.Tasks)
+
+	_, err = newDebugSession(appObj, "flow:missing", DebugFlowOptions{})
+	assert.NotNil(t, err)
+
+	_, err = newDebugSession(appObj, "res://flow:orders", DebugFlowOptions{Breaks: []string{"store"}})
+	assert.NotNil(t, err)
+}
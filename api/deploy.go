@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileDeployJson = "deploy.json"
+
+// buildConfig is the JSON representation of the build options
+type buildConfig struct {
+	OptimizeImports bool     `json:"optimize,omitempty"`
+	EmbedConfig     bool     `json:"embed,omitempty"`
+	Shim            string   `json:"shim,omitempty"`
+	FailOnSecrets   bool     `json:"failOnSecrets,omitempty"`
+	Variant         string   `json:"variant,omitempty"`
+	Profile         string   `json:"profile,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Management      bool     `json:"management,omitempty"`
+	Trace           string   `json:"trace,omitempty"`
+}
+
+func (c *buildConfig) options() common.BuildOptions {
+	if c == nil {
+		return common.BuildOptions{}
+	}
+	return common.BuildOptions{OptimizeImports: c.OptimizeImports, EmbedConfig: c.EmbedConfig, Shim: c.Shim,
+		FailOnSecrets: c.FailOnSecrets, Variant: c.Variant, Profile: c.Profile, Tags: c.Tags, Management: c.Management, Trace: c.Trace}
+}
+
+type deployConfig struct {
+	Targets map[string]*deployTargetConfig `json:"targets"`
+}
+
+// deployTargetConfig is the definition of a deploy target in deploy.json
+type deployTargetConfig struct {
+	Provider string                 `json:"provider"`
+	OS       string                 `json:"os,omitempty"`
+	Arch     string                 `json:"arch,omitempty"`
+	Build    *buildConfig           `json:"build,omitempty"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// DeployApp builds the application and deploys it to the specified target defined in the project's deploy.json
+func DeployApp(ctx context.Context, project common.AppProject, targetName string, skipBuild bool) error {
+
+	targets, err := loadDeployTargets(project)
+	if err != nil {
+		return err
+	}
+
+	cfg, exists := targets[targetName]
+	if !exists {
+		return fmt.Errorf("target '%s' not defined in %s, defined targets: %s", targetName, fileDeployJson, strings.Join(deployTargetNames(targets), ", "))
+	}
+
+	provider := common.GetDeployProvider(cfg.Provider)
+	if provider == nil {
+		return fmt.Errorf("unknown deploy provider '%s' for target '%s', available providers: %s", cfg.Provider, targetName, strings.Join(common.DeployProviders(), ", "))
+	}
+
+	target := &common.DeployTarget{Name: targetName, Provider: cfg.Provider, Settings: cfg.Settings}
+	if target.Settings == nil {
+		target.Settings = make(map[string]interface{})
+	}
+
+	goos, goarch := cfg.OS, cfg.Arch
+	staticBuild := false
+	if platform, ok := provider.(common.DeployPlatform); ok {
+		pOS, pArch := platform.Platform()
+		if goos == "" {
+			goos = pOS
+		}
+		if goarch == "" {
+			goarch = pArch
+		}
+		staticBuild = true
+	} else if detector, ok := provider.(common.DeployPlatformDetector); ok {
+		// the platform of the target is only detected if it isn't defined in deploy.json
+		if goos == "" || goarch == "" {
+			pOS, pArch, err := detector.DetectPlatform(target)
+			if err != nil {
+				return fmt.Errorf("unable to detect the platform of target '%s', set its os and arch in %s: %v", targetName, fileDeployJson, err)
+			}
+			if goos == "" {
+				goos = pOS
+			}
+			if goarch == "" {
+				goarch = pArch
+			}
+		}
+		staticBuild = true
+	}
+
+	restore := setBuildPlatform(goos, goarch, staticBuild)
+	defer restore()
+
+	if !skipBuild {
+		err = BuildProject(ctx, project, cfg.Build.options())
+		if err != nil {
+			return err
+		}
+	}
+
+	exe := project.Executable()
+	if _, err := os.Stat(exe); err != nil {
+		return fmt.Errorf("executable '%s' not found, build the application with 'flogo build'", exe)
+	}
+
+	fmt.Printf("Deploying '%s' to target '%s' using provider '%s'...\n", project.Name(), targetName, cfg.Provider)
+
+	err = provider.Deploy(project, target, exe)
+	if err != nil {
+		return fmt.Errorf("deploying to target '%s' failed: %v", targetName, err)
+	}
+
+	fmt.Printf("Deployed '%s' to target '%s'\n", project.Name(), targetName)
+
+	return nil
+}
+
+// ListDeployTargets lists the deploy targets defined in the project's deploy.json
+func ListDeployTargets(project common.AppProject) error {
+
+	targets, err := loadDeployTargets(project)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range deployTargetNames(targets) {
+		cfg := targets[name]
+		platform := ""
+		if cfg.OS != "" || cfg.Arch != "" {
+			platform = fmt.Sprintf(" (%s/%s)", cfg.OS, cfg.Arch)
+		}
+		status := ""
+		if common.GetDeployProvider(cfg.Provider) == nil {
+			status = " [unknown provider]"
+		}
+		fmt.Printf("%-20s %s%s%s\n", name, cfg.Provider, platform, status)
+	}
+
+	return nil
+}
+
+func loadDeployTargets(project common.AppProject) (map[string]*deployTargetConfig, error) {
+
+	deployFile := filepath.Join(project.Dir(), fileDeployJson)
+
+	buf, err := ioutil.ReadFile(deployFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no deploy targets defined, %s not found in the project directory", fileDeployJson)
+		}
+		return nil, err
+	}
+
+	cfg := &deployConfig{}
+	err = json.Unmarshal(buf, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", fileDeployJson, err)
+	}
+
+	for name, target := range cfg.Targets {
+		if target == nil || target.Provider == "" {
+			return nil, fmt.Errorf("provider not specified for target '%s'", name)
+		}
+	}
+
+	return cfg.Targets, nil
+}
+
+func deployTargetNames(targets map[string]*deployTargetConfig) []string {
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setBuildPlatform sets the platform the application is built for, the returned function restores the previous one
+func setBuildPlatform(goos, goarch string, static bool) func() {
+
+	var vars []string
+	if goos != "" {
+		vars = append(vars, "GOOS="+goos)
+	}
+	if goarch != "" {
+		vars = append(vars, "GOARCH="+goarch)
+	}
+	if static {
+		vars = append(vars, "CGO_ENABLED=0")
+	}
+	restoreEnv := setEnvVars(vars...)
+
+	prevGOOS := GOOSENV
+	if goos != "" {
+		GOOSENV = goos
+	}
+
+	return func() {
+		GOOSENV = prevGOOS
+		restoreEnv()
+	}
+}
+
+// setEnvVars sets the environment variables, as KEY=VALUE, the returned function restores their previous values
+func setEnvVars(vars ...string) func() {
+
+	var restores []func()
+	for _, v := range vars {
+		idx := strings.Index(v, "=")
+		if idx <= 0 {
+			continue
+		}
+		key, val := v[:idx], v[idx+1:]
+		prev, isSet := os.LookupEnv(key)
+		_ = os.Setenv(key, val)
+		restores = append(restores, func() {
+			if isSet {
+				_ = os.Setenv(key, prev)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		})
+	}
+
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// DeploySetting gets a string setting of a deploy target, an error is returned if it is required and not set
+func DeploySetting(target *common.DeployTarget, name string, required bool) (string, error) {
+
+	val, exists := target.Settings[name]
+	if !exists || val == nil || val == "" {
+		if required {
+			return "", fmt.Errorf("setting '%s' not specified for target '%s'", name, target.Name)
+		}
+		return "", nil
+	}
+
+	return fmt.Sprint(val), nil
+}
+
+// runDeployCmd runs a command used to deploy or publish the application, such as docker or kubectl
+func runDeployCmd(dir string, name string, args ...string) error {
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("'%s' not found, it must be installed to use this target", name)
+	}
+
+	if Verbose() {
+		fmt.Printf("Running: %s %s\n", name, strings.Join(args, " "))
+	}
+
+	err := util.ExecCmd(exec.Command(name, args...), dir)
+	if err != nil {
+		msg := strings.TrimSpace(err.Error())
+		if msg == "" {
+			msg = "failed"
+		}
+		return fmt.Errorf("'%s %s': %s", name, strings.Join(args, " "), msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// detectingProvider is a deploy provider which detects the platform of its targets
+type detectingProvider struct {
+	detected bool
+	platform string // the platform the application was built for when deployed
+}
+
+func (p *detectingProvider) DetectPlatform(target *common.DeployTarget) (string, string, error) {
+	p.detected = true
+	return "linux", "arm", nil
+}
+
+func (p *detectingProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+	p.platform = os.Getenv("GOOS") + "/" + os.Getenv("GOARCH")
+	return nil
+}
+
+func TestDeployAppDetectPlatform(t *testing.T) {
+	t.Log("Testing deployment to a target whose platform is detected")
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	err = os.MkdirAll(project.BinDir(), 0755)
+	assert.Nil(t, err)
+
+	provider := &detectingProvider{}
+	common.RegisterDeployProvider("test-detecting", provider)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileDeployJson), []byte(`{"targets": {
+		"detected": {"provider": "test-detecting"},
+		"defined": {"provider": "test-detecting", "os": "linux", "arch": "amd64"}
+	}}`), 0644)
+	assert.Nil(t, err)
+
+	restore := setBuildPlatform("windows", "", false)
+	defer restore()
+	// the executable is named for the platform of the target
+	err = ioutil.WriteFile(filepath.Join(project.BinDir(), project.Name()), []byte("binary"), 0755)
+	assert.Nil(t, err)
+
+	err = DeployApp(context.Background(), project, "detected", true)
+	assert.Nil(t, err)
+	assert.True(t, provider.detected)
+	assert.Equal(t, "linux/arm", provider.platform)
+
+	// the platform of the target isn't detected when it is defined
+	provider.detected = false
+	err = DeployApp(context.Background(), project, "defined", true)
+	assert.Nil(t, err)
+	assert.False(t, provider.detected)
+	assert.Equal(t, "linux/amd64", provider.platform)
+}
+
+// recordingProvider is a deploy provider which records the deployments
+type recordingProvider struct {
+	executable string
+	target     *common.DeployTarget
+	err        error
+}
+
+func (p *recordingProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+	p.executable, p.target = executable, target
+	return p.err
+}
+
+func TestDeployApp(t *testing.T) {
+	t.Log("Testing deployment to the targets of deploy.json")
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	provider := &recordingProvider{}
+	common.RegisterDeployProvider("test-recording", provider)
+
+	err = DeployApp(context.Background(), project, "prod", true)
+	assert.EqualError(t, err, "no deploy targets defined, deploy.json not found in the project directory")
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileDeployJson), []byte(`{"targets": {
+		"prod": {"provider": "test-recording", "settings": {"region": "eu"}},
+		"staging": {"provider": "test-recording"},
+		"cloud": {"provider": "test-unknown"}
+	}}`), 0644)
+	assert.Nil(t, err)
+
+	err = DeployApp(context.Background(), project, "dev", true)
+	assert.EqualError(t, err, "target 'dev' not defined in deploy.json, defined targets: cloud, prod, staging")
+
+	err = DeployApp(context.Background(), project, "cloud", true)
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "unknown deploy provider 'test-unknown' for target 'cloud', available providers: "))
+
+	// the executable must exist when it isn't built
+	err = DeployApp(context.Background(), project, "prod", true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "build the application with 'flogo build'")
+
+	err = os.MkdirAll(project.BinDir(), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(project.Executable(), []byte("binary"), 0755)
+	assert.Nil(t, err)
+
+	err = DeployApp(context.Background(), project, "prod", true)
+	assert.Nil(t, err)
+	assert.Equal(t, project.Executable(), provider.executable)
+	assert.Equal(t, "prod", provider.target.Name)
+	assert.Equal(t, "eu", provider.target.Settings["region"])
+
+	// the settings are never nil
+	err = DeployApp(context.Background(), project, "staging", true)
+	assert.Nil(t, err)
+	assert.NotNil(t, provider.target.Settings)
+
+	provider.err = fmt.Errorf("unreachable")
+	err = DeployApp(context.Background(), project, "prod", true)
+	assert.EqualError(t, err, "deploying to target 'prod' failed: unreachable")
+}
+
+func TestLoadDeployTargets(t *testing.T) {
+	t.Log("Testing the targets of deploy.json")
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	common.RegisterDeployProvider("test-recording", &recordingProvider{})
+
+	for _, invalid := range []string{`{"targets": `, `{"targets": {"prod": {}}}`, `{"targets": {"prod": null}}`} {
+		err = ioutil.WriteFile(filepath.Join(tempDir, fileDeployJson), []byte(invalid), 0644)
+		assert.Nil(t, err)
+		_, err = loadDeployTargets(project)
+		assert.NotNil(t, err, invalid)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileDeployJson), []byte(`{"targets": {
+		"prod": {"provider": "test-recording", "os": "linux", "arch": "arm64", "build": {"embed": true, "tags": ["prod"]}},
+		"cloud": {"provider": "test-unknown"}
+	}}`), 0644)
+	assert.Nil(t, err)
+
+	targets, err := loadDeployTargets(project)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cloud", "prod"}, deployTargetNames(targets))
+
+	options := targets["prod"].Build.options()
+	assert.True(t, options.EmbedConfig)
+	assert.Equal(t, []string{"prod"}, options.Tags)
+	assert.Equal(t, common.BuildOptions{}, targets["cloud"].Build.options())
+
+	out := captureStdout(t, func() {
+		err = ListDeployTargets(project)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("%-20s %s%s\n%-20s %s (linux/arm64)\n", "cloud", "test-unknown", " [unknown provider]", "prod", "test-recording"), out)
+}
+
+func TestSetBuildPlatform(t *testing.T) {
+	t.Log("Testing the platform the application is built for")
+
+	defer os.Setenv("GOARCH", os.Getenv("GOARCH"))
+	os.Setenv("GOARCH", "386")
+	prevGOOS := GOOSENV
+
+	restore := setBuildPlatform("linux", "arm64", true)
+	assert.Equal(t, "linux", GOOSENV)
+	assert.Equal(t, "arm64", os.Getenv("GOARCH"))
+	assert.Equal(t, "0", os.Getenv("CGO_ENABLED"))
+
+	restore()
+	assert.Equal(t, prevGOOS, GOOSENV)
+	assert.Equal(t, "386", os.Getenv("GOARCH"))
+}
+
+func TestDeploySetting(t *testing.T) {
+	t.Log("Testing the settings of the deploy targets")
+
+	target := &common.DeployTarget{Name: "prod", Settings: map[string]interface{}{"image": "myapp:1.0", "replicas": float64(3), "empty": "", "retries": "4"}}
+
+	val, err := DeploySetting(target, "image", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "myapp:1.0", val)
+
+	val, err = DeploySetting(target, "replicas", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "3", val)
+
+	val, err = DeploySetting(target, "empty", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "", val)
+
+	_, err = DeploySetting(target, "empty", true)
+	assert.EqualError(t, err, "setting 'empty' not specified for target 'prod'")
+
+	assert.Equal(t, 3, intDeploySetting(target, "replicas", 1))
+	assert.Equal(t, 4, intDeploySetting(target, "retries", 1))
+	assert.Equal(t, 1, intDeploySetting(target, "image", 1))
+}
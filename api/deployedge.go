@@ -0,0 +1,365 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileEdgeManifest = "manifest.json"
+	fileEdgeUpdate   = "update.sh"
+	fileEdgeWatchdog = "watchdog.sh"
+	fileEdgeWdConfig = "watchdog.conf"
+
+	defaultEdgeKeepReleases     = 3
+	defaultEdgeHealthRetries    = 10
+	defaultEdgeWatchdogInterval = 30
+	defaultEdgeWatchdogFailures = 3
+)
+
+func init() {
+	common.RegisterDeployProvider("edge", &edgeProvider{})
+}
+
+// edgeProvider packages the application into a bundle for edge gateways, which are updated offline by copying the
+// bundle to the device, ex. from a USB drive or using an MDM, and running its update script. The bundle contains the
+// executable, a systemd unit, a watchdog restarting the unit when its health check fails and the update script.
+//
+// settings: output, dir, unit, user, props, healthCheck, healthRetries, watchdogInterval, watchdogFailures, keepReleases
+type edgeProvider struct {
+}
+
+// edgeBundle is the configuration of an edge bundle, used by the templates of its files
+type edgeBundle struct {
+	App              string
+	Executable       string
+	Version          string
+	Release          string
+	OS               string
+	Arch             string
+	Dir              string
+	Unit             string
+	User             string
+	Props            bool
+	PropsEnv         bool   // the app properties without a value are set using environment variables
+	Env              string // the environment variables required by the triggers
+	HealthCheck      string
+	HealthRetries    int
+	WatchdogInterval int
+	WatchdogFailures int
+	KeepReleases     int
+}
+
+// edgeManifest describes the content of an edge bundle
+type edgeManifest struct {
+	App     string `json:"app"`
+	Version string `json:"version,omitempty"`
+	Release string `json:"release"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	SHA256  string `json:"sha256"` // the hash of the executable
+}
+
+func (p *edgeProvider) Platform() (string, string) {
+	return "linux", "arm64"
+}
+
+func (p *edgeProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+
+	bundle := &edgeBundle{
+		App:              project.Name(),
+		Executable:       filepath.Base(executable),
+		Release:          time.Now().UTC().Format("20060102T150405Z"),
+		OS:               os.Getenv("GOOS"),
+		Arch:             os.Getenv("GOARCH"),
+		HealthRetries:    intDeploySetting(target, "healthRetries", defaultEdgeHealthRetries),
+		WatchdogInterval: intDeploySetting(target, "watchdogInterval", defaultEdgeWatchdogInterval),
+		WatchdogFailures: intDeploySetting(target, "watchdogFailures", defaultEdgeWatchdogFailures),
+		KeepReleases:     intDeploySetting(target, "keepReleases", defaultEdgeKeepReleases),
+	}
+
+	_, _ = readAppDescriptorValue(project, "$.version", &bundle.Version)
+
+	bundle.Dir, _ = DeploySetting(target, "dir", false)
+	if bundle.Dir == "" {
+		bundle.Dir = "/opt/" + project.Name()
+	}
+	bundle.Unit, _ = DeploySetting(target, "unit", false)
+	if bundle.Unit == "" {
+		bundle.Unit = project.Name()
+	}
+	bundle.User, _ = DeploySetting(target, "user", false)
+	bundle.HealthCheck, _ = DeploySetting(target, "healthCheck", false)
+
+	exposure := projectExposure(project)
+	if bundle.HealthCheck == "" {
+		bundle.HealthCheck = exposure.HealthCheck
+	}
+	bundle.PropsEnv = exposure.PropsEnv
+	bundle.Env = strings.Join(exposure.Env, ", ")
+
+	props, _ := DeploySetting(target, "props", false)
+	if props != "" && !filepath.IsAbs(props) {
+		props = filepath.Join(project.Dir(), props)
+	}
+	bundle.Props = props != ""
+
+	output, _ := DeploySetting(target, "output", false)
+	if output == "" {
+		output = project.BinDir()
+	} else if !filepath.IsAbs(output) {
+		output = filepath.Join(project.Dir(), output)
+	}
+
+	hash := util.FileHash(executable)
+	if hash == "" {
+		return fmt.Errorf("unable to read executable '%s'", executable)
+	}
+
+	bundleDir, err := ioutil.TempDir("", "flogo-deploy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bundleDir)
+
+	files := map[string]string{
+		bundle.Executable: executable,
+		fileFlogoJson:     filepath.Join(project.Dir(), fileFlogoJson),
+	}
+	if props != "" {
+		files[fileRemoteProps] = props
+	}
+
+	generated := []struct {
+		name string
+		tmpl string
+		perm os.FileMode
+	}{
+		{bundle.Unit + ".service", edgeUnitTemplate, 0644},
+		{bundle.Unit + "-watchdog.service", edgeWatchdogUnitTemplate, 0644},
+		{bundle.Unit + "-watchdog.timer", edgeWatchdogTimerTemplate, 0644},
+		{fileEdgeWdConfig, edgeWatchdogConfigTemplate, 0644},
+		{fileEdgeWatchdog, edgeWatchdogScriptTemplate, 0755},
+		{fileEdgeUpdate, edgeUpdateScriptTemplate, 0755},
+	}
+	for _, g := range generated {
+		content, err := renderEdgeFile(g.tmpl, bundle)
+		if err != nil {
+			return fmt.Errorf("unable to generate '%s': %v", g.name, err)
+		}
+		path := filepath.Join(bundleDir, g.name)
+		if err := ioutil.WriteFile(path, content, g.perm); err != nil {
+			return err
+		}
+		files[g.name] = path
+	}
+
+	manifest, err := json.MarshalIndent(&edgeManifest{App: bundle.App, Version: bundle.Version, Release: bundle.Release,
+		OS: bundle.OS, Arch: bundle.Arch, SHA256: hash}, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+	manifestFile := filepath.Join(bundleDir, fileEdgeManifest)
+	if err := ioutil.WriteFile(manifestFile, manifest, 0644); err != nil {
+		return err
+	}
+	files[fileEdgeManifest] = manifestFile
+
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return err
+	}
+
+	zipFile := filepath.Join(output, fmt.Sprintf("%s-%s-%s-%s.zip", bundle.App, bundle.Release, bundle.OS, bundle.Arch))
+	err = zipFiles(zipFile, files)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created edge bundle %s\n", zipFile)
+	fmt.Printf("To install or update the application, extract the bundle on the device and run 'sudo sh %s'\n", fileEdgeUpdate)
+
+	return nil
+}
+
+// renderEdgeFile renders a file of the bundle, q quotes a value for the shell
+func renderEdgeFile(tmpl string, bundle *edgeBundle) ([]byte, error) {
+
+	t, err := template.New("edge").Funcs(template.FuncMap{"q": shellQuote}).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = t.Execute(&buf, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strings.TrimLeft(buf.String(), "\n")), nil
+}
+
+var edgeUnitTemplate = `
+[Unit]
+Description={{.App}} flogo application
+After=network-online.target
+Wants=network-online.target
+StartLimitIntervalSec=300
+StartLimitBurst=10
+
+[Service]
+WorkingDirectory={{.Dir}}/current
+{{- if .Props}}
+Environment=FLOGO_APP_PROPS_JSON={{.Dir}}/current/props.json
+{{- end}}
+{{- if .PropsEnv}}
+Environment=FLOGO_APP_PROPS_ENV=auto
+{{- end}}
+{{- if .Env}}
+# required environment variables, set them in a drop-in: {{.Env}}
+{{- end}}
+ExecStart={{.Dir}}/current/{{.Executable}}
+{{- if .User}}
+User={{.User}}
+{{- end}}
+Restart=always
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+`
+
+var edgeWatchdogUnitTemplate = `
+[Unit]
+Description=Watchdog of {{.Unit}}
+
+[Service]
+Type=oneshot
+ExecStart=/bin/sh {{.Dir}}/watchdog.sh
+`
+
+var edgeWatchdogTimerTemplate = `
+[Unit]
+Description=Runs the watchdog of {{.Unit}}
+
+[Timer]
+OnBootSec={{.WatchdogInterval}}
+OnUnitActiveSec={{.WatchdogInterval}}
+
+[Install]
+WantedBy=timers.target
+`
+
+var edgeWatchdogConfigTemplate = `
+# configuration of the watchdog of {{.Unit}}, the unit is restarted when its health check fails MAX_FAILURES times in a row
+UNIT={{q .Unit}}
+HEALTH_CHECK={{q .HealthCheck}}
+MAX_FAILURES={{.WatchdogFailures}}
+`
+
+var edgeWatchdogScriptTemplate = `
+#!/bin/sh
+# restarts {{.Unit}} when its health check fails, run by {{.Unit}}-watchdog.timer
+
+. "$(dirname "$0")/watchdog.conf"
+
+[ -n "$HEALTH_CHECK" ] || exit 0
+systemctl is-active --quiet "$UNIT" || exit 0
+
+STATE="/run/$UNIT-watchdog.failures"
+
+if command -v curl >/dev/null 2>&1; then
+	curl -fsS -o /dev/null --max-time 5 "$HEALTH_CHECK" && { rm -f "$STATE"; exit 0; }
+else
+	wget -q -O /dev/null -T 5 "$HEALTH_CHECK" && { rm -f "$STATE"; exit 0; }
+fi
+
+FAILURES=$(( $(cat "$STATE" 2>/dev/null || echo 0) + 1 ))
+if [ "$FAILURES" -ge "$MAX_FAILURES" ]; then
+	logger -t "$UNIT-watchdog" "health check failed $FAILURES times, restarting $UNIT"
+	rm -f "$STATE"
+	systemctl restart "$UNIT"
+else
+	echo "$FAILURES" > "$STATE"
+fi
+`
+
+var edgeUpdateScriptTemplate = `
+#!/bin/sh
+# installs or updates {{.App}} from the extracted bundle, run as root on the device:
+#   sudo sh update.sh
+# the release is rolled back to the previous one if the application isn't healthy once restarted
+
+set -e
+
+DIR={{q .Dir}}
+UNIT={{q .Unit}}
+RELEASE={{q .Release}}
+EXECUTABLE={{q .Executable}}
+HEALTH_RETRIES={{.HealthRetries}}
+KEEP_RELEASES={{.KeepReleases}}
+
+BUNDLE=$(cd "$(dirname "$0")" && pwd)
+. "$BUNDLE/watchdog.conf"
+
+healthy() {
+	systemctl is-active --quiet "$UNIT" || return 1
+	[ -n "$HEALTH_CHECK" ] || return 0
+	if command -v curl >/dev/null 2>&1; then
+		curl -fsS -o /dev/null --max-time 5 "$HEALTH_CHECK"
+	else
+		wget -q -O /dev/null -T 5 "$HEALTH_CHECK"
+	fi
+}
+
+activate() {
+	ln -sfn "$1" "$DIR/current.tmp"
+	mv -Tf "$DIR/current.tmp" "$DIR/current"
+	systemctl restart "$UNIT"
+}
+
+echo "Installing release $RELEASE of {{.App}} in $DIR"
+
+mkdir -p "$DIR/releases/$RELEASE"
+cp "$BUNDLE/$EXECUTABLE" "$BUNDLE/flogo.json" "$DIR/releases/$RELEASE/"
+[ ! -f "$BUNDLE/props.json" ] || cp "$BUNDLE/props.json" "$DIR/releases/$RELEASE/"
+chmod +x "$DIR/releases/$RELEASE/$EXECUTABLE"
+cp "$BUNDLE/watchdog.sh" "$BUNDLE/watchdog.conf" "$DIR/"
+
+cp "$BUNDLE/$UNIT.service" "$BUNDLE/$UNIT-watchdog.service" "$BUNDLE/$UNIT-watchdog.timer" /etc/systemd/system/
+systemctl daemon-reload
+systemctl enable "$UNIT" "$UNIT-watchdog.timer"
+systemctl start "$UNIT-watchdog.timer"
+
+PREVIOUS=$(readlink "$DIR/current" || true)
+activate "releases/$RELEASE"
+
+i=0
+until healthy; do
+	i=$((i + 1))
+	if [ "$i" -ge "$HEALTH_RETRIES" ]; then
+		echo "Release $RELEASE isn't healthy" >&2
+		if [ -n "$PREVIOUS" ]; then
+			activate "$PREVIOUS"
+			echo "Rolled back to $PREVIOUS" >&2
+		fi
+		exit 1
+	fi
+	sleep 2
+done
+
+if [ "$KEEP_RELEASES" -gt 0 ]; then
+	(cd "$DIR/releases" && ls -1t | tail -n +$((KEEP_RELEASES + 1)) | xargs -r rm -rf)
+fi
+
+echo "Release $RELEASE of {{.App}} is running"
+`
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeBundle(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "edge")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	appDir := filepath.Join(dir, "myApp")
+	err = os.MkdirAll(filepath.Join(appDir, "bin"), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "flogo.json"), []byte(`{"name": "myApp", "version": "1.2.0"}`), 0644)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "prod.json"), []byte(`{"port": 8080}`), 0644)
+	assert.Nil(t, err)
+
+	project := NewAppProject(appDir)
+	err = ioutil.WriteFile(project.Executable(), []byte("binary"), 0755)
+	assert.Nil(t, err)
+
+	target := &common.DeployTarget{Name: "gateways", Provider: "edge", Settings: map[string]interface{}{
+		"props":       "prod.json",
+		"healthCheck": "http://localhost:9999/ping",
+		"user":        "flogo",
+	}}
+
+	restore := setBuildPlatform("linux", "arm64", true)
+	defer restore()
+
+	err = (&edgeProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	bundles, _ := filepath.Glob(filepath.Join(project.BinDir(), "myApp-*-linux-arm64.zip"))
+	assert.Len(t, bundles, 1)
+
+	zr, err := zip.OpenReader(bundles[0])
+	assert.Nil(t, err)
+	defer zr.Close()
+
+	content := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		assert.Nil(t, err)
+		buf, _ := ioutil.ReadAll(r)
+		r.Close()
+		content[f.Name] = string(buf)
+	}
+
+	for _, name := range []string{"myApp", "flogo.json", "props.json", "myApp.service", "myApp-watchdog.service",
+		"myApp-watchdog.timer", "watchdog.conf", "watchdog.sh", "update.sh", "manifest.json"} {
+		assert.Contains(t, content, name)
+	}
+
+	assert.Contains(t, content["myApp.service"], "ExecStart=/opt/myApp/current/myApp\n")
+	assert.Contains(t, content["myApp.service"], "Environment=FLOGO_APP_PROPS_JSON=/opt/myApp/current/props.json\n")
+	assert.Contains(t, content["myApp.service"], "User=flogo\n")
+	assert.Contains(t, content["watchdog.conf"], "HEALTH_CHECK='http://localhost:9999/ping'\n")
+	assert.Contains(t, content["manifest.json"], `"version": "1.2.0"`)
+	assert.Contains(t, content["manifest.json"], `"arch": "arm64"`)
+
+	if runtime.GOOS != "windows" {
+		// the generated scripts must be valid
+		for _, script := range []string{"update.sh", "watchdog.sh"} {
+			out, err := exec.Command("sh", "-n", "-c", content[script]).CombinedOutput()
+			assert.Nil(t, err, strings.TrimSpace(string(out)))
+		}
+	}
+}
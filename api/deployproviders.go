@@ -0,0 +1,268 @@
+package api
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const defaultDockerBase = "alpine:3.10"
+
+func init() {
+	common.RegisterDeployProvider("docker", &dockerProvider{})
+	common.RegisterDeployProvider("kubernetes", &kubernetesProvider{})
+	common.RegisterDeployProvider("cloudrun", &cloudRunProvider{})
+	common.RegisterDeployProvider("lambda", &lambdaProvider{})
+}
+
+// dockerProvider builds an image containing the application and pushes it to a registry
+//
+// settings: image (required), base, push (default true)
+type dockerProvider struct {
+}
+
+func (p *dockerProvider) Platform() (string, string) {
+	return "linux", "amd64"
+}
+
+func (p *dockerProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+	_, err := buildAndPushImage(project, target, executable)
+	return err
+}
+
+// buildAndPushImage builds the image of the application and pushes it, unless push is disabled, the image is returned
+func buildAndPushImage(project common.AppProject, target *common.DeployTarget, executable string) (string, error) {
+
+	image, err := DeploySetting(target, "image", true)
+	if err != nil {
+		return "", err
+	}
+
+	base, _ := DeploySetting(target, "base", false)
+	if base == "" {
+		base = defaultDockerBase
+	}
+
+	ctxDir, err := ioutil.TempDir("", "flogo-deploy")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(ctxDir)
+
+	exeName := filepath.Base(executable)
+	err = util.CopyFile(executable, filepath.Join(ctxDir, exeName))
+	if err != nil {
+		return "", err
+	}
+	err = os.Chmod(filepath.Join(ctxDir, exeName), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	// the app descriptor is used by the application if its configuration isn't embedded
+	err = util.CopyFile(filepath.Join(project.Dir(), fileFlogoJson), filepath.Join(ctxDir, fileFlogoJson))
+	if err != nil {
+		return "", err
+	}
+
+	dockerfile := fmt.Sprintf("FROM %s\nWORKDIR /app\nCOPY %s %s /app/\n%sENTRYPOINT [\"/app/%s\"]\n", base, exeName, fileFlogoJson, projectExposure(project).dockerfileInstructions(), exeName)
+	err = ioutil.WriteFile(filepath.Join(ctxDir, "Dockerfile"), []byte(dockerfile), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Building image '%s'...\n", image)
+	err = runDeployCmd(ctxDir, "docker", "build", "-t", image, ".")
+	if err != nil {
+		return "", err
+	}
+
+	if push, exists := target.Settings["push"].(bool); exists && !push {
+		return image, nil
+	}
+
+	fmt.Printf("Pushing image '%s'...\n", image)
+	err = runDeployCmd("", "docker", "push", image)
+	if err != nil {
+		return "", err
+	}
+
+	return image, nil
+}
+
+// kubernetesProvider pushes the image of the application and updates the image of a deployment
+//
+// settings: image (required), deployment (required), container, namespace, context, base
+type kubernetesProvider struct {
+}
+
+func (p *kubernetesProvider) Platform() (string, string) {
+	return "linux", "amd64"
+}
+
+func (p *kubernetesProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+
+	deployment, err := DeploySetting(target, "deployment", true)
+	if err != nil {
+		return err
+	}
+
+	container, _ := DeploySetting(target, "container", false)
+	if container == "" {
+		container = project.Name()
+	}
+
+	var kubectlArgs []string
+	if namespace, _ := DeploySetting(target, "namespace", false); namespace != "" {
+		kubectlArgs = append(kubectlArgs, "--namespace", namespace)
+	}
+	if context, _ := DeploySetting(target, "context", false); context != "" {
+		kubectlArgs = append(kubectlArgs, "--context", context)
+	}
+
+	image, err := buildAndPushImage(project, target, executable)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updating deployment '%s'...\n", deployment)
+	err = runDeployCmd("", "kubectl", append(kubectlArgs, "set", "image", "deployment/"+deployment, container+"="+image)...)
+	if err != nil {
+		return err
+	}
+
+	return runDeployCmd("", "kubectl", append(kubectlArgs, "rollout", "status", "deployment/"+deployment)...)
+}
+
+// cloudRunProvider pushes the image of the application and deploys it to a Cloud Run service
+//
+// settings: image (required), service, region, project, base
+type cloudRunProvider struct {
+}
+
+func (p *cloudRunProvider) Platform() (string, string) {
+	return "linux", "amd64"
+}
+
+func (p *cloudRunProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+
+	service, _ := DeploySetting(target, "service", false)
+	if service == "" {
+		service = strings.ToLower(project.Name())
+	}
+
+	image, err := buildAndPushImage(project, target, executable)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"run", "deploy", service, "--image", image, "--platform", "managed", "--quiet"}
+	if region, _ := DeploySetting(target, "region", false); region != "" {
+		args = append(args, "--region", region)
+	}
+	if gcpProject, _ := DeploySetting(target, "project", false); gcpProject != "" {
+		args = append(args, "--project", gcpProject)
+	}
+
+	fmt.Printf("Deploying service '%s'...\n", service)
+	return runDeployCmd("", "gcloud", args...)
+}
+
+// lambdaProvider updates the code of a Lambda function, the application should be built using the lambda shim
+//
+// settings: function (required), region, profile, handler (default bootstrap)
+type lambdaProvider struct {
+}
+
+func (p *lambdaProvider) Platform() (string, string) {
+	return "linux", "amd64"
+}
+
+func (p *lambdaProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+
+	function, err := DeploySetting(target, "function", true)
+	if err != nil {
+		return err
+	}
+
+	handler, _ := DeploySetting(target, "handler", false)
+	if handler == "" {
+		handler = "bootstrap"
+	}
+
+	zipDir, err := ioutil.TempDir("", "flogo-deploy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(zipDir)
+
+	zipFile := filepath.Join(zipDir, "function.zip")
+	err = zipFiles(zipFile, map[string]string{
+		handler:       executable,
+		fileFlogoJson: filepath.Join(project.Dir(), fileFlogoJson),
+	})
+	if err != nil {
+		return err
+	}
+
+	args := []string{"lambda", "update-function-code", "--function-name", function, "--zip-file", "fileb://" + zipFile}
+	if region, _ := DeploySetting(target, "region", false); region != "" {
+		args = append(args, "--region", region)
+	}
+	if profile, _ := DeploySetting(target, "profile", false); profile != "" {
+		args = append(args, "--profile", profile)
+	}
+
+	fmt.Printf("Updating function '%s'...\n", function)
+	return runDeployCmd("", "aws", args...)
+}
+
+// zipFiles creates a zip file containing the files, which are keyed by their name in the zip
+func zipFiles(zipFile string, files map[string]string) error {
+
+	out, err := os.Create(zipFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+
+	for name, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, in)
+		_ = in.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
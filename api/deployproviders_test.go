@@ -0,0 +1,216 @@
+package api
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDeployCommands replaces the commands by scripts recording their arguments, docker build also keeps
+// a copy of the Dockerfile in the directory
+func fakeDeployCommands(t *testing.T, dir string, names ...string) (logFile string, restore func()) {
+
+	logFile = filepath.Join(dir, "commands.log")
+	for _, name := range names {
+		script := "#!/bin/sh\necho \"" + name + " $*\" >> " + logFile + "\n"
+		if name == "docker" {
+			script += "[ \"$1\" != build ] || cp Dockerfile " + filepath.Join(dir, "Dockerfile") + "\n"
+		}
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(script), 0755))
+	}
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+
+	return logFile, func() {
+		os.Setenv("PATH", path)
+	}
+}
+
+func newTestDeployProject(t *testing.T, dir string) common.AppProject {
+
+	appDir := filepath.Join(dir, "myApp")
+	assert.Nil(t, os.MkdirAll(filepath.Join(appDir, "bin"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, fileFlogoJson), []byte(`{"name": "myApp"}`), 0644))
+
+	project := NewAppProject(appDir)
+	assert.Nil(t, ioutil.WriteFile(project.Executable(), []byte("binary"), 0755))
+
+	return project
+}
+
+func TestDockerProvider(t *testing.T) {
+	t.Log("Testing deployment of an image using the docker provider")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("docker is replaced by a shell script")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeDeployCommands(t, tempDir, "docker")
+	defer restore()
+	project := newTestDeployProject(t, tempDir)
+
+	target := &common.DeployTarget{Name: "prod", Provider: "docker", Settings: map[string]interface{}{}}
+	err = (&dockerProvider{}).Deploy(project, target, project.Executable())
+	assert.EqualError(t, err, "setting 'image' not specified for target 'prod'")
+
+	target.Settings["image"] = "registry.io/myapp:1.0"
+	err = (&dockerProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "docker build -t registry.io/myapp:1.0 .\ndocker push registry.io/myapp:1.0\n", string(buf))
+
+	buf, err = ioutil.ReadFile(filepath.Join(tempDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf), "FROM "+defaultDockerBase+"\nWORKDIR /app\nCOPY myApp flogo.json /app/\n"))
+	assert.True(t, strings.HasSuffix(string(buf), "ENTRYPOINT [\"/app/myApp\"]\n"))
+
+	// the image isn't pushed if push is disabled
+	assert.Nil(t, os.Remove(logFile))
+	target.Settings["push"] = false
+	target.Settings["base"] = "scratch"
+	err = (&dockerProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err = ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "docker build -t registry.io/myapp:1.0 .\n", string(buf))
+	buf, err = ioutil.ReadFile(filepath.Join(tempDir, "Dockerfile"))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf), "FROM scratch\n"))
+}
+
+func TestKubernetesProvider(t *testing.T) {
+	t.Log("Testing deployment to a kubernetes deployment")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("docker and kubectl are replaced by shell scripts")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeDeployCommands(t, tempDir, "docker", "kubectl")
+	defer restore()
+	project := newTestDeployProject(t, tempDir)
+
+	target := &common.DeployTarget{Name: "prod", Provider: "kubernetes", Settings: map[string]interface{}{"image": "myapp:1.0"}}
+	err = (&kubernetesProvider{}).Deploy(project, target, project.Executable())
+	assert.EqualError(t, err, "setting 'deployment' not specified for target 'prod'")
+
+	target.Settings["deployment"] = "myapp"
+	target.Settings["namespace"] = "apps"
+	err = (&kubernetesProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "docker build -t myapp:1.0 .\ndocker push myapp:1.0\n"+
+		"kubectl --namespace apps set image deployment/myapp myApp=myapp:1.0\n"+
+		"kubectl --namespace apps rollout status deployment/myapp\n", string(buf))
+}
+
+func TestCloudRunProvider(t *testing.T) {
+	t.Log("Testing deployment to a Cloud Run service")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("docker and gcloud are replaced by shell scripts")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeDeployCommands(t, tempDir, "docker", "gcloud")
+	defer restore()
+	project := newTestDeployProject(t, tempDir)
+
+	target := &common.DeployTarget{Name: "prod", Provider: "cloudrun", Settings: map[string]interface{}{"image": "gcr.io/proj/myapp", "region": "europe-west1"}}
+	err = (&cloudRunProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	// the service is named after the app by default
+	assert.Contains(t, string(buf), "gcloud run deploy myapp --image gcr.io/proj/myapp --platform managed --quiet --region europe-west1\n")
+}
+
+func TestLambdaProvider(t *testing.T) {
+	t.Log("Testing deployment to a Lambda function")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("aws is replaced by a shell script")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeDeployCommands(t, tempDir, "aws")
+	defer restore()
+	project := newTestDeployProject(t, tempDir)
+
+	target := &common.DeployTarget{Name: "prod", Provider: "lambda", Settings: map[string]interface{}{}}
+	err = (&lambdaProvider{}).Deploy(project, target, project.Executable())
+	assert.EqualError(t, err, "setting 'function' not specified for target 'prod'")
+
+	target.Settings["function"] = "myFunction"
+	target.Settings["profile"] = "deploy"
+	err = (&lambdaProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(buf), "aws lambda update-function-code --function-name myFunction --zip-file fileb://"))
+	assert.True(t, strings.HasSuffix(string(buf), "function.zip --profile deploy\n"))
+}
+
+func TestZipFiles(t *testing.T) {
+	t.Log("Testing the zip of the Lambda function")
+
+	tempDir, err := ioutil.TempDir("", "flogo-deploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := newTestDeployProject(t, tempDir)
+
+	zipFile := filepath.Join(tempDir, "function.zip")
+	err = zipFiles(zipFile, map[string]string{"bootstrap": project.Executable(), fileFlogoJson: filepath.Join(project.Dir(), fileFlogoJson)})
+	assert.Nil(t, err)
+
+	zr, err := zip.OpenReader(zipFile)
+	assert.Nil(t, err)
+	defer zr.Close()
+
+	content := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		assert.Nil(t, err)
+		buf, _ := ioutil.ReadAll(r)
+		r.Close()
+		content[f.Name] = string(buf)
+
+		// the executable stays executable
+		if f.Name == "bootstrap" && runtime.GOOS != "windows" {
+			assert.Equal(t, os.FileMode(0755), f.Mode().Perm())
+		}
+	}
+	assert.Equal(t, map[string]string{"bootstrap": "binary", fileFlogoJson: `{"name": "myApp"}`}, content)
+
+	err = zipFiles(zipFile, map[string]string{"bootstrap": filepath.Join(tempDir, "missing")})
+	assert.NotNil(t, err)
+}
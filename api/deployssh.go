@@ -0,0 +1,459 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	fileRemoteProps = "props.json"
+	fileUnitProps   = "flogo-props.conf"
+
+	defaultSSHKeepReleases  = 5
+	defaultSSHHealthRetries = 10
+)
+
+// the interval between the health checks of a host
+var sshHealthInterval = 2 * time.Second
+
+func init() {
+	common.RegisterDeployProvider("ssh", &sshProvider{})
+}
+
+// sshProvider copies the application to remote hosts and restarts its systemd unit, the hosts are
+// updated one at a time and the rollout is rolled back if a host fails its health check. The application
+// is built for the platform of the hosts, unless the os and arch of the target are defined.
+//
+// settings: hosts (required), dir, unit, props, identity, sudo, healthCheck, healthRetries, keepReleases
+type sshProvider struct {
+}
+
+type sshHost struct {
+	user     string
+	host     string // a host name or an ip address, ipv6 addresses aren't bracketed
+	port     string
+	identity string
+}
+
+// sshRelease is a release deployed to a host
+type sshRelease struct {
+	host     *sshHost
+	previous string // the release that was current before it was deployed
+}
+
+// DetectPlatform detects the platform of the hosts, which must all be of the same platform
+func (p *sshProvider) DetectPlatform(target *common.DeployTarget) (string, string, error) {
+
+	hosts, err := sshHosts(target)
+	if err != nil {
+		return "", "", err
+	}
+
+	var goos, goarch string
+	for _, host := range hosts {
+		out, err := host.output("uname -sm")
+		if err != nil {
+			return "", "", err
+		}
+		hOS, hArch, err := unamePlatform(out)
+		if err != nil {
+			return "", "", fmt.Errorf("host '%s': %v", host.dest(), err)
+		}
+		if goos != "" && (hOS != goos || hArch != goarch) {
+			return "", "", fmt.Errorf("host '%s' is %s/%s while the previous hosts are %s/%s", host.dest(), hOS, hArch, goos, goarch)
+		}
+		goos, goarch = hOS, hArch
+	}
+
+	return goos, goarch, nil
+}
+
+// unamePlatform gets the go platform of the output of 'uname -sm', ex. Linux x86_64 is linux/amd64
+func unamePlatform(uname string) (string, string, error) {
+
+	fields := strings.Fields(uname)
+	if len(fields) != 2 {
+		return "", "", fmt.Errorf("unexpected output of uname: '%s'", strings.TrimSpace(uname))
+	}
+
+	goos := strings.ToLower(fields[0])
+	switch goos {
+	case "linux", "darwin", "freebsd", "netbsd", "openbsd":
+	default:
+		return "", "", fmt.Errorf("unsupported os '%s'", fields[0])
+	}
+
+	var goarch string
+	switch fields[1] {
+	case "x86_64", "amd64":
+		goarch = "amd64"
+	case "aarch64", "arm64":
+		goarch = "arm64"
+	case "i386", "i486", "i586", "i686":
+		goarch = "386"
+	case "armv6l", "armv7l", "arm":
+		goarch = "arm"
+	case "ppc64le", "s390x", "riscv64":
+		goarch = fields[1]
+	default:
+		return "", "", fmt.Errorf("unsupported architecture '%s'", fields[1])
+	}
+
+	return goos, goarch, nil
+}
+
+func (p *sshProvider) Deploy(project common.AppProject, target *common.DeployTarget, executable string) error {
+
+	hosts, err := sshHosts(target)
+	if err != nil {
+		return err
+	}
+
+	cfg := &sshDeployConfig{project: project, target: target, executable: executable,
+		release: time.Now().UTC().Format("20060102T150405Z")}
+
+	cfg.dir, _ = DeploySetting(target, "dir", false)
+	if cfg.dir == "" {
+		cfg.dir = "/opt/" + project.Name()
+	}
+	cfg.unit, _ = DeploySetting(target, "unit", false)
+	if cfg.unit == "" {
+		cfg.unit = project.Name()
+	}
+	cfg.props, _ = DeploySetting(target, "props", false)
+	if cfg.props != "" && !filepath.IsAbs(cfg.props) {
+		cfg.props = filepath.Join(project.Dir(), cfg.props)
+	}
+	cfg.healthCheck, _ = DeploySetting(target, "healthCheck", false)
+	if cfg.healthCheck == "" {
+		cfg.healthCheck = projectExposure(project).HealthCheck
+	}
+	cfg.sudo = true
+	if sudo, ok := target.Settings["sudo"].(bool); ok {
+		cfg.sudo = sudo
+	}
+	cfg.healthRetries = intDeploySetting(target, "healthRetries", defaultSSHHealthRetries)
+	cfg.keepReleases = intDeploySetting(target, "keepReleases", defaultSSHKeepReleases)
+
+	var deployed []*sshRelease
+
+	for i, host := range hosts {
+		fmt.Printf("[%d/%d] Deploying release '%s' to '%s'...\n", i+1, len(hosts), cfg.release, host.dest())
+
+		release, err := cfg.deployToHost(host)
+		if release != nil {
+			deployed = append(deployed, release)
+		}
+		if err != nil {
+			fmt.Printf("Deploying to '%s' failed, rolling back\n", host.dest())
+			cfg.rollback(deployed)
+			return fmt.Errorf("host '%s': %v", host.dest(), err)
+		}
+	}
+
+	for _, host := range hosts {
+		cfg.pruneReleases(host)
+	}
+
+	return nil
+}
+
+type sshDeployConfig struct {
+	project    common.AppProject
+	target     *common.DeployTarget
+	executable string
+	release    string
+
+	dir           string
+	unit          string
+	props         string
+	healthCheck   string
+	sudo          bool
+	healthRetries int
+	keepReleases  int
+}
+
+// deployToHost deploys the release to the host, the release is returned once the host's current release has been switched
+func (c *sshDeployConfig) deployToHost(host *sshHost) (*sshRelease, error) {
+
+	releaseDir := c.dir + "/releases/" + c.release
+
+	previous, err := host.output(fmt.Sprintf("mkdir -p %s && (readlink %s || true)", shellQuote(releaseDir), shellQuote(c.dir+"/current")))
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{c.executable, filepath.Join(c.project.Dir(), fileFlogoJson)}
+	err = host.copy(files, releaseDir+"/")
+	if err != nil {
+		return nil, err
+	}
+	if c.props != "" {
+		err = host.copy([]string{c.props}, releaseDir+"/"+fileRemoteProps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = host.run(fmt.Sprintf("chmod +x %s", shellQuote(releaseDir+"/"+filepath.Base(c.executable))))
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.configureUnitProps(host)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &sshRelease{host: host, previous: strings.TrimSpace(previous)}
+
+	err = c.activate(host, "releases/"+c.release)
+	if err != nil {
+		return release, err
+	}
+
+	return release, c.checkHealth(host)
+}
+
+// configureUnitProps points the unit at the property file of the current release using a drop-in, the drop-in is
+// removed if the target has no property file
+func (c *sshDeployConfig) configureUnitProps(host *sshHost) error {
+
+	unit := c.unit
+	if !strings.HasSuffix(unit, ".service") {
+		unit += ".service"
+	}
+	dropInDir := "/etc/systemd/system/" + unit + ".d"
+	dropIn := dropInDir + "/" + fileUnitProps
+
+	if c.props == "" {
+		return host.run(fmt.Sprintf("if [ -f %s ]; then %s && %s; fi",
+			shellQuote(dropIn), c.privileged("rm -f "+shellQuote(dropIn)), c.privileged("systemctl daemon-reload")))
+	}
+
+	content := fmt.Sprintf("[Service]\nEnvironment=%s=%s\n", envKeyAppPropsJson, c.dir+"/current/"+fileRemoteProps)
+
+	return host.run(fmt.Sprintf("%s && printf '%%s' %s | %s >/dev/null && %s",
+		c.privileged("mkdir -p "+shellQuote(dropInDir)), shellQuote(content),
+		c.privileged("tee "+shellQuote(dropIn)), c.privileged("systemctl daemon-reload")))
+}
+
+// privileged prefixes the command with sudo, unless sudo is disabled for the target
+func (c *sshDeployConfig) privileged(command string) string {
+	if c.sudo {
+		return "sudo " + command
+	}
+	return command
+}
+
+// activate atomically switches the current release of the host and restarts the unit
+func (c *sshDeployConfig) activate(host *sshHost, release string) error {
+
+	current := shellQuote(c.dir + "/current")
+	tmp := shellQuote(c.dir + "/current.tmp")
+
+	return host.run(fmt.Sprintf("ln -sfn %s %s && mv -Tf %s %s && %s",
+		shellQuote(release), tmp, tmp, current, c.privileged("systemctl restart "+shellQuote(c.unit))))
+}
+
+// checkHealth checks the health of the application on the host, retrying until it is healthy or the retries are exhausted
+func (c *sshDeployConfig) checkHealth(host *sshHost) error {
+
+	check := fmt.Sprintf("systemctl is-active --quiet %s", shellQuote(c.unit))
+	if c.healthCheck != "" {
+		check += fmt.Sprintf(" && curl -fsS -o /dev/null --max-time 5 %s", shellQuote(c.healthCheck))
+	}
+
+	var err error
+	for i := 0; i < c.healthRetries; i++ {
+		time.Sleep(sshHealthInterval)
+		err = host.run(check)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("health check failed: %v", err)
+}
+
+// rollback switches the hosts back to their previous release, most recently deployed first
+func (c *sshDeployConfig) rollback(deployed []*sshRelease) {
+
+	for i := len(deployed) - 1; i >= 0; i-- {
+		release := deployed[i]
+		if release.previous == "" {
+			fmt.Printf("No previous release on '%s' to roll back to\n", release.host.dest())
+			continue
+		}
+
+		err := c.activate(release.host, release.previous)
+		if err != nil {
+			fmt.Printf("Rolling back '%s' failed: %v\n", release.host.dest(), err)
+			continue
+		}
+		fmt.Printf("Rolled back '%s' to '%s'\n", release.host.dest(), release.previous)
+	}
+}
+
+// pruneReleases removes all but the most recent releases from the host
+func (c *sshDeployConfig) pruneReleases(host *sshHost) {
+
+	if c.keepReleases <= 0 {
+		return
+	}
+
+	err := host.run(fmt.Sprintf("cd %s && ls -1t | tail -n +%d | xargs -r rm -rf", shellQuote(c.dir+"/releases"), c.keepReleases+1))
+	if err != nil && Verbose() {
+		fmt.Printf("Unable to prune releases on '%s': %v\n", host.dest(), err)
+	}
+}
+
+func sshHosts(target *common.DeployTarget) ([]*sshHost, error) {
+
+	identity, _ := DeploySetting(target, "identity", false)
+
+	var specs []string
+	switch t := target.Settings["hosts"].(type) {
+	case []interface{}:
+		for _, h := range t {
+			specs = append(specs, fmt.Sprint(h))
+		}
+	case string:
+		specs = strings.Split(t, ",")
+	}
+
+	var hosts []*sshHost
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		host, err := parseSSHHost(spec)
+		if err != nil {
+			return nil, err
+		}
+		host.identity = identity
+		hosts = append(hosts, host)
+	}
+
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("setting 'hosts' not specified for target '%s'", target.Name)
+	}
+
+	return hosts, nil
+}
+
+// parseSSHHost parses a host of the form [user@]host[:port], an ipv6 address with a port is bracketed, ex. [::1]:2222
+func parseSSHHost(spec string) (*sshHost, error) {
+
+	host := &sshHost{}
+
+	hostPort := spec
+	if idx := strings.LastIndex(spec, "@"); idx >= 0 {
+		host.user, hostPort = spec[:idx], spec[idx+1:]
+	}
+
+	host.host = hostPort
+	if strings.Contains(hostPort, ":") {
+		h, port, err := net.SplitHostPort(hostPort)
+		switch {
+		case err == nil:
+			host.host, host.port = h, port
+		case !strings.Contains(hostPort, "[") && net.ParseIP(hostPort) != nil:
+			// an ipv6 address without a port
+		default:
+			return nil, fmt.Errorf("invalid host '%s': %v", spec, err)
+		}
+	}
+
+	if host.host == "" {
+		return nil, fmt.Errorf("invalid host '%s'", spec)
+	}
+
+	return host, nil
+}
+
+// dest gets the destination of ssh, [user@]host
+func (h *sshHost) dest() string {
+	if h.user != "" {
+		return h.user + "@" + h.host
+	}
+	return h.host
+}
+
+// remotePath gets the remote path of scp, [user@]host:path with an ipv6 address bracketed
+func (h *sshHost) remotePath(path string) string {
+	host := h.host
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if h.user != "" {
+		host = h.user + "@" + host
+	}
+	return host + ":" + path
+}
+
+func (h *sshHost) options(portFlag string) []string {
+	opts := []string{"-o", "BatchMode=yes"}
+	if h.port != "" {
+		opts = append(opts, portFlag, h.port)
+	}
+	if h.identity != "" {
+		opts = append(opts, "-i", h.identity)
+	}
+	return opts
+}
+
+// run runs a shell command on the host
+func (h *sshHost) run(command string) error {
+	return runDeployCmd("", "ssh", append(h.options("-p"), h.dest(), command)...)
+}
+
+// output runs a shell command on the host and returns its output
+func (h *sshHost) output(command string) (string, error) {
+
+	args := append(h.options("-p"), h.dest(), command)
+	if Verbose() {
+		fmt.Printf("Running: ssh %s\n", strings.Join(args, " "))
+	}
+
+	out, err := exec.Command("ssh", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("'ssh %s': %s", h.dest(), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("'ssh %s': %v", h.dest(), err)
+	}
+
+	return string(out), nil
+}
+
+// copy copies local files to the host
+func (h *sshHost) copy(files []string, remotePath string) error {
+	args := append(h.options("-P"), files...)
+	return runDeployCmd("", "scp", append(args, h.remotePath(remotePath))...)
+}
+
+func intDeploySetting(target *common.DeployTarget, name string, defaultVal int) int {
+	switch t := target.Settings[name].(type) {
+	case float64:
+		return int(t)
+	case string:
+		if v, err := strconv.Atoi(t); err == nil {
+			return v
+		}
+	}
+	return defaultVal
+}
+
+// shellQuote quotes a value for use in a remote shell command
+func shellQuote(val string) string {
+	return "'" + strings.Replace(val, "'", `'\''`, -1) + "'"
+}
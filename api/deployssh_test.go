@@ -0,0 +1,202 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSSHHost(t *testing.T) {
+	t.Log("Testing the parsing of the hosts of the ssh provider")
+
+	tests := []struct {
+		spec   string
+		dest   string
+		port   string
+		remote string
+	}{
+		{"vm1", "vm1", "", "vm1:/opt"},
+		{"deploy@vm1:2222", "deploy@vm1", "2222", "deploy@vm1:/opt"},
+		{"10.0.0.1:22", "10.0.0.1", "22", "10.0.0.1:/opt"},
+		{"2001:db8::1", "2001:db8::1", "", "[2001:db8::1]:/opt"},
+		{"deploy@[2001:db8::1]:2222", "deploy@2001:db8::1", "2222", "deploy@[2001:db8::1]:/opt"},
+	}
+
+	for _, test := range tests {
+		host, err := parseSSHHost(test.spec)
+		assert.Nil(t, err, test.spec)
+		assert.Equal(t, test.dest, host.dest(), test.spec)
+		assert.Equal(t, test.port, host.port, test.spec)
+		assert.Equal(t, test.remote, host.remotePath("/opt"), test.spec)
+	}
+
+	for _, spec := range []string{"[2001:db8::1", "vm1:22:33", "deploy@", ":22"} {
+		_, err := parseSSHHost(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestUnamePlatform(t *testing.T) {
+	t.Log("Testing the platform of the hosts of the ssh provider")
+
+	platforms := map[string]string{
+		"Linux x86_64\n":  "linux/amd64",
+		"Linux aarch64\n": "linux/arm64",
+		"Linux armv7l":    "linux/arm",
+		"Darwin arm64":    "darwin/arm64",
+		"FreeBSD amd64":   "freebsd/amd64",
+	}
+	for uname, expected := range platforms {
+		goos, goarch, err := unamePlatform(uname)
+		assert.Nil(t, err, uname)
+		assert.Equal(t, expected, goos+"/"+goarch, uname)
+	}
+
+	for _, uname := range []string{"", "Linux", "SunOS sun4v", "Linux sparc64"} {
+		_, _, err := unamePlatform(uname)
+		assert.NotNil(t, err, uname)
+	}
+}
+
+// fakeSSH replaces ssh and scp by scripts recording their arguments, the hosts are linux/arm64 and ssh
+// commands containing the value of FAKE_SSH_FAIL fail
+func fakeSSH(t *testing.T, dir string) (logFile string, restore func()) {
+
+	logFile = filepath.Join(dir, "ssh.log")
+	ssh := `#!/bin/sh
+echo "ssh $*" >> ` + logFile + `
+case "$*" in
+  *"uname -sm"*) echo "Linux aarch64" ;;
+  *readlink*) echo "releases/20260101T000000Z" ;;
+esac
+if [ -n "$FAKE_SSH_FAIL" ]; then
+  case "$*" in *"$FAKE_SSH_FAIL"*) exit 1 ;; esac
+fi
+`
+	scp := "#!/bin/sh\necho \"scp $*\" >> " + logFile + "\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "ssh"), []byte(ssh), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "scp"), []byte(scp), 0755))
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+	interval := sshHealthInterval
+	sshHealthInterval = 0
+
+	return logFile, func() {
+		os.Setenv("PATH", path)
+		os.Unsetenv("FAKE_SSH_FAIL")
+		sshHealthInterval = interval
+	}
+}
+
+func TestSSHDeploy(t *testing.T) {
+	t.Log("Testing deployment to hosts using the ssh provider")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("ssh and scp are replaced by shell scripts")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-ssh")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeSSH(t, tempDir)
+	defer restore()
+
+	appDir := filepath.Join(tempDir, "myApp")
+	err = os.MkdirAll(filepath.Join(appDir, "bin"), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, fileFlogoJson), []byte(`{"name": "myApp"}`), 0644)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "prod.json"), []byte(`{"port": 8080}`), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(appDir)
+
+	target := &common.DeployTarget{Name: "prod", Provider: "ssh", Settings: map[string]interface{}{
+		"hosts": []interface{}{"deploy@vm1", "deploy@[2001:db8::1]:2222"},
+		"props": "prod.json",
+	}}
+
+	goos, goarch, err := (&sshProvider{}).DetectPlatform(target)
+	assert.Nil(t, err)
+	assert.Equal(t, "linux", goos)
+	assert.Equal(t, "arm64", goarch)
+
+	err = (&sshProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	log := string(buf)
+
+	// ipv6 addresses are bracketed for scp only
+	assert.Contains(t, log, "scp -o BatchMode=yes -P 2222 "+filepath.Join(appDir, "prod.json")+" deploy@[2001:db8::1]:/opt/myApp/releases/")
+	assert.Contains(t, log, "ssh -o BatchMode=yes -p 2222 deploy@2001:db8::1 ")
+
+	// the unit is pointed at the property file of the current release
+	assert.Contains(t, log, "sudo tee '/etc/systemd/system/myApp.service.d/flogo-props.conf'")
+	assert.Contains(t, log, "Environment=FLOGO_APP_PROPS_JSON=/opt/myApp/current/props.json")
+	assert.Contains(t, log, "sudo systemctl daemon-reload")
+
+	// without property file the drop-in is removed
+	err = os.Remove(logFile)
+	assert.Nil(t, err)
+	delete(target.Settings, "props")
+	target.Settings["sudo"] = false
+
+	err = (&sshProvider{}).Deploy(project, target, project.Executable())
+	assert.Nil(t, err)
+
+	buf, err = ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+	log = string(buf)
+	assert.NotContains(t, log, "FLOGO_APP_PROPS_JSON")
+	assert.NotContains(t, log, "sudo")
+	assert.Contains(t, log, "rm -f '/etc/systemd/system/myApp.service.d/flogo-props.conf'")
+}
+
+func TestSSHDeployRollback(t *testing.T) {
+	t.Log("Testing the rollback of a deployment using the ssh provider")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("ssh and scp are replaced by shell scripts")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-ssh")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	logFile, restore := fakeSSH(t, tempDir)
+	defer restore()
+
+	project := NewAppProject(tempDir)
+
+	// the second host fails its health check
+	os.Setenv("FAKE_SSH_FAIL", "vm2 systemctl is-active")
+	target := &common.DeployTarget{Name: "prod", Provider: "ssh", Settings: map[string]interface{}{
+		"hosts":         "vm1, vm2",
+		"healthRetries": 2,
+	}}
+
+	err = (&sshProvider{}).Deploy(project, target, project.Executable())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "host 'vm2'")
+
+	buf, err := ioutil.ReadFile(logFile)
+	assert.Nil(t, err)
+
+	// both hosts are switched back to their previous release
+	var rollbacks []string
+	for _, line := range strings.Split(string(buf), "\n") {
+		if strings.Contains(line, "ln -sfn 'releases/20260101T000000Z'") {
+			rollbacks = append(rollbacks, strings.Fields(line)[3])
+		}
+	}
+	assert.Equal(t, []string{"vm2", "vm1"}, rollbacks)
+}
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileDockerfile = "Dockerfile"
+
+	defaultDockerBuildBase = "gcr.io/distroless/static"
+	dockerBaseScratch      = "scratch"
+)
+
+var (
+	dockerNameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+	dockerTagInvalidChars  = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// DockerBuilder builds a container image of the application using a multi-stage Dockerfile: the application is
+// compiled in a Go image and its executable, with its embedded configuration, is copied to a minimal base image
+type DockerBuilder struct {
+	options common.BuildOptions
+}
+
+func (db *DockerBuilder) Build(ctx context.Context, project common.AppProject) error {
+
+	err := restoreMain(project)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return fmt.Errorf("'docker' not found, it must be installed to build the image of the application")
+	}
+
+	if replaced := localModuleReplaces(project.SrcDir()); len(replaced) > 0 {
+		return fmt.Errorf("go.mod replaces %s with local directories, which aren't part of the docker build context", strings.Join(replaced, ", "))
+	}
+
+	image := db.options.Docker.Image
+	if image == "" {
+		image = defaultDockerImage(project)
+	}
+
+	dockerfile := generateDockerfile(project, db.options)
+
+	err = os.MkdirAll(project.BinDir(), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	dockerfilePath := filepath.Join(project.BinDir(), fileDockerfile)
+	err = ioutil.WriteFile(dockerfilePath, dockerfile, 0644)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"build", "-f", dockerfilePath, "-t", image}
+	if goEnv, err := doctorGoEnv(project, "GOPROXY", "GOPRIVATE", "GONOSUMDB"); err == nil {
+		for _, name := range []string{"GOPROXY", "GOPRIVATE", "GONOSUMDB"} {
+			if goEnv[name] != "" {
+				args = append(args, "--build-arg", name+"="+goEnv[name])
+			}
+		}
+	}
+	args = append(args, ".")
+
+	fmt.Printf("Building image '%s'...\n", image)
+	err = runDockerCmd(project.SrcDir(), args...)
+	if err != nil {
+		return err
+	}
+
+	if !db.options.Docker.Push {
+		return nil
+	}
+
+	fmt.Printf("Pushing image '%s'...\n", image)
+	return runDockerCmd("", "push", image)
+}
+
+// generateDockerfile generates the multi-stage Dockerfile building the image of the application from its src
+// directory, the build context
+func generateDockerfile(project common.AppProject, options common.BuildOptions) []byte {
+
+	base := options.Docker.Base
+	if base == "" {
+		base = defaultDockerBuildBase
+	}
+
+	goImage := "golang:1"
+	if installed := installedGoVersion(); installed != "" {
+		goImage = "golang:" + installed
+	}
+
+	data := &struct {
+		GoImage  string
+		Base     string
+		Name     string
+		Tags     string
+		LDFlags  string
+		Certs    bool
+		Exposure string
+	}{
+		GoImage: goImage,
+		Base:    base,
+		Name:    dockerAppName(project),
+		Tags:    strings.Join(options.Tags, ","),
+		LDFlags: options.LDFlags,
+		// scratch is empty, the CA certificates are copied so the application can call HTTPS services
+		Certs:    base == dockerBaseScratch,
+		Exposure: projectExposure(project).dockerfileInstructions(),
+	}
+
+	var buf bytes.Buffer
+	RenderTemplate(&buf, tplDockerfile, data)
+
+	return buf.Bytes()
+}
+
+// dockerAppName gets the name of the application usable in the name of an image
+func dockerAppName(project common.AppProject) string {
+
+	name := strings.Trim(dockerNameInvalidChars.ReplaceAllString(strings.ToLower(project.Name()), "-"), "-._")
+	if name == "" {
+		return "flogo-app"
+	}
+
+	return name
+}
+
+// defaultDockerImage gets the default name of the image of the application, <app name>:<app version>
+func defaultDockerImage(project common.AppProject) string {
+
+	tag := "latest"
+	var version string
+	if ok, err := readAppDescriptorValue(project, "$.version", &version); err == nil && ok && version != "" {
+		tag = dockerTagInvalidChars.ReplaceAllString(version, "-")
+	}
+
+	return dockerAppName(project) + ":" + tag
+}
+
+// localModuleReplaces gets the modules replaced with a local directory outside of the directory by its go.mod, the
+// packages added to src using 'flogo src add-package' are part of the build context
+func localModuleReplaces(srcDir string) []string {
+
+	var replaced []string
+	for module, target := range goModReplaces(srcDir) {
+		if strings.HasPrefix(filepath.Clean(target), "..") || filepath.IsAbs(target) {
+			replaced = append(replaced, module)
+		}
+	}
+	sort.Strings(replaced)
+
+	return replaced
+}
+
+// goModReplaces gets the replacements of the modules declared by the go.mod of the directory, keyed by module
+func goModReplaces(srcDir string) map[string]string {
+
+	replaces := make(map[string]string)
+
+	buf, err := ioutil.ReadFile(filepath.Join(srcDir, "go.mod"))
+	if err != nil {
+		return replaces
+	}
+
+	inBlock := false
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "replace ("):
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "replace "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "replace "))
+		case !inBlock:
+			continue
+		}
+
+		idx := strings.Index(line, "=>")
+		if idx < 0 {
+			continue
+		}
+		module := strings.Fields(line[:idx])
+		target := strings.Fields(line[idx+2:])
+		if len(module) > 0 && len(target) > 0 {
+			replaces[module[0]] = target[0]
+		}
+	}
+
+	return replaces
+}
+
+func runDockerCmd(dir string, args ...string) error {
+
+	if Verbose() {
+		fmt.Printf("Running: docker %s\n", strings.Join(args, " "))
+	}
+
+	err := util.ExecCmd(exec.Command("docker", args...), dir)
+	if err != nil {
+		msg := strings.TrimSpace(err.Error())
+		if msg == "" {
+			msg = "failed"
+		}
+		return fmt.Errorf("'docker %s': %s", strings.Join(args, " "), msg)
+	}
+
+	return nil
+}
+
+var tplDockerfile = `# Do not change this file, it has been generated using flogo-cli
+# If you change it and rebuild the application your changes might get lost
+FROM {{.GoImage}} AS build
+ARG GOPROXY
+ARG GOPRIVATE
+ARG GONOSUMDB
+WORKDIR /src
+COPY . .
+RUN CGO_ENABLED=0 GOOS=linux go build -o /out/{{.Name}}{{if .Tags}} -tags {{.Tags}}{{end}}{{if .LDFlags}} -ldflags "{{.LDFlags}}"{{end}} .
+
+FROM {{.Base}}
+{{- if .Certs}}
+COPY --from=build /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/
+{{- end}}
+COPY --from=build /out/{{.Name}} /app/{{.Name}}
+WORKDIR /app
+{{.Exposure}}ENTRYPOINT ["/app/{{.Name}}"]
+`
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDockerfile(t *testing.T) {
+	t.Log("Testing generation of the Dockerfile of an application")
+
+	project := NewAppProject(filepath.Join("apps", "My App"))
+
+	dockerfile := string(generateDockerfile(project, common.BuildOptions{Docker: &common.DockerOptions{}}))
+	assert.Contains(t, dockerfile, "AS build\n")
+	assert.Contains(t, dockerfile, "RUN CGO_ENABLED=0 GOOS=linux go build -o /out/my-app .\n")
+	assert.Contains(t, dockerfile, "FROM "+defaultDockerBuildBase+"\n")
+	assert.Contains(t, dockerfile, `ENTRYPOINT ["/app/my-app"]`)
+	assert.NotContains(t, dockerfile, "ca-certificates")
+
+	dockerfile = string(generateDockerfile(project, common.BuildOptions{Tags: []string{"kafka", "mqtt"}, Docker: &common.DockerOptions{Base: dockerBaseScratch}}))
+	assert.Contains(t, dockerfile, "go build -o /out/my-app -tags kafka,mqtt .\n")
+	assert.Contains(t, dockerfile, "FROM scratch\nCOPY --from=build /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/\n")
+}
+
+func TestDefaultDockerImage(t *testing.T) {
+	t.Log("Testing the default name of the image of an application")
+
+	tempDir, err := ioutil.TempDir("", "flogo-docker")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(filepath.Join(tempDir, "MyApp"))
+	assert.Equal(t, "myapp:latest", defaultDockerImage(project))
+
+	assert.Nil(t, os.MkdirAll(project.Dir(), 0755))
+	err = ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(`{"name": "MyApp", "version": "1.0.0+build 2"}`), 0644)
+	assert.Nil(t, err)
+	assert.Equal(t, "myapp:1.0.0-build-2", defaultDockerImage(project))
+}
+
+func TestLocalModuleReplaces(t *testing.T) {
+	t.Log("Testing detection of the modules replaced with local directories")
+
+	tempDir, err := ioutil.TempDir("", "flogo-docker")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	goMod := `module main
+
+require github.com/project-flogo/core v0.9.5
+
+replace github.com/project-flogo/contrib => ../contrib
+
+replace (
+	github.com/project-flogo/flow v0.9.0 => github.com/fork/flow v0.9.1
+	github.com/project-flogo/stream => /home/user/stream
+	github.com/example/helpers => ./helpers
+)
+`
+	err = ioutil.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goMod), 0644)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"github.com/project-flogo/contrib", "github.com/project-flogo/stream"}, localModuleReplaces(tempDir))
+}
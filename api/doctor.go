@@ -0,0 +1,349 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	DoctorOk      = "ok"
+	DoctorWarning = "warning"
+	DoctorError   = "error"
+
+	doctorProxyTimeout = 5 * time.Second
+	doctorProbeModule  = flogoCoreRepo
+)
+
+// DoctorCheck is the result of a diagnostic of the toolchain or the project
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// DoctorReport is the result of the diagnostics
+type DoctorReport struct {
+	Checks []*DoctorCheck `json:"checks"`
+}
+
+func (r *DoctorReport) add(name, status, fix, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, &DoctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...), Fix: fix})
+}
+
+// HasErrors determines if any of the checks failed
+func (r *DoctorReport) HasErrors() bool {
+	for _, check := range r.Checks {
+		if check.Status == DoctorError {
+			return true
+		}
+	}
+	return false
+}
+
+// doctorGoEnv gets the values of the go environment variables, the Go environment configured for the project, which
+// is optional, included
+func doctorGoEnv(project common.AppProject, names ...string) (map[string]string, error) {
+
+	projectDir := ""
+	if project != nil {
+		projectDir = project.Dir()
+	}
+
+	cmd := exec.Command("go", append([]string{"env"}, names...)...)
+	cmd.Env = util.GoCmdEnv(projectDir)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	for i, name := range names {
+		if i < len(lines) {
+			values[name] = strings.TrimSpace(lines[i])
+		}
+	}
+
+	return values, nil
+}
+
+// RunDoctor diagnoses the toolchain and, if there is one, the project, the checks which fail have a fix
+func RunDoctor(project common.AppProject) *DoctorReport {
+
+	report := &DoctorReport{}
+
+	goEnv := checkDoctorToolchain(report, project)
+	checkDoctorProxy(report, goEnv)
+
+	if project != nil {
+		checkDoctorProject(report, project)
+	}
+
+	return report
+}
+
+// checkDoctorToolchain checks the version and the environment of Go, with the Go environment configured for the project
+func checkDoctorToolchain(report *DoctorReport, project common.AppProject) map[string]string {
+
+	if _, err := exec.LookPath("go"); err != nil {
+		report.add("go", DoctorError, "install Go (https://golang.org/dl/) and add it to the PATH", "go not found in the PATH")
+		return nil
+	}
+
+	if installed := installedGoVersion(); installed != "" {
+		report.add("go", DoctorOk, "", "go %s", installed)
+	} else {
+		report.add("go", DoctorWarning, "", "unable to determine the version of go, it may be a development version")
+	}
+
+	goEnv, err := doctorGoEnv(project, "GOFLAGS", "GO111MODULE", "GOPROXY")
+	if err != nil {
+		report.add("go env", DoctorError, "check the installation of Go using 'go env'", "unable to get the go environment: %v", err)
+		return nil
+	}
+
+	switch goEnv["GO111MODULE"] {
+	case "off":
+		report.add("GO111MODULE", DoctorError, "unset GO111MODULE or set it to 'on'", "modules are disabled, the applications can't be built")
+	default:
+		report.add("GO111MODULE", DoctorOk, "", "modules enabled")
+	}
+
+	flags := goEnv["GOFLAGS"]
+	switch {
+	case strings.Contains(flags, "-mod=vendor"):
+		report.add("GOFLAGS", DoctorWarning, "remove -mod=vendor from GOFLAGS",
+			"'%s' builds using the vendor directory, ignoring the contributions installed in the module cache", flags)
+	case strings.Contains(flags, "-mod=readonly"):
+		report.add("GOFLAGS", DoctorWarning, "remove -mod=readonly from GOFLAGS",
+			"'%s' prevents the go.mod of the application from being updated by the install and the build", flags)
+	case flags == "":
+		report.add("GOFLAGS", DoctorOk, "", "not set")
+	default:
+		report.add("GOFLAGS", DoctorOk, "", "%s", flags)
+	}
+
+	return goEnv
+}
+
+// checkDoctorProxy checks that the module proxies are reachable, by listing the versions of the core
+func checkDoctorProxy(report *DoctorReport, goEnv map[string]string) {
+
+	if goEnv == nil {
+		return
+	}
+
+	proxies := goEnv["GOPROXY"]
+	if proxies == "" {
+		report.add("GOPROXY", DoctorOk, "", "not set, the modules are downloaded from their repository")
+		return
+	}
+
+	client := &http.Client{Timeout: doctorProxyTimeout}
+	for _, proxy := range strings.FieldsFunc(proxies, func(r rune) bool { return r == ',' || r == '|' }) {
+		switch proxy {
+		case "direct":
+			continue
+		case "off":
+			report.add("GOPROXY", DoctorWarning, "set GOPROXY to a proxy or 'direct' to install contributions, ex. using 'flogo config set goproxy direct'",
+				"downloads of modules are disabled, only the modules in the module cache can be used")
+			continue
+		}
+
+		url := strings.TrimSuffix(proxy, "/") + "/" + doctorProbeModule + "/@v/list"
+		resp, err := client.Get(url)
+		if err != nil {
+			report.add("GOPROXY", DoctorError, "check the network, the proxy settings (HTTPS_PROXY) or set GOPROXY to a reachable proxy",
+				"%s unreachable: %v", proxy, err)
+			continue
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			report.add("GOPROXY", DoctorWarning, "check that GOPROXY is a Go module proxy",
+				"%s responded %s to the listing of %s", proxy, resp.Status, doctorProbeModule)
+			continue
+		}
+		report.add("GOPROXY", DoctorOk, "", "%s reachable", proxy)
+	}
+}
+
+// checkDoctorProject checks the layout of the project and the consistency of flogo.json, imports.go and go.mod
+func checkDoctorProject(report *DoctorReport, project common.AppProject) {
+
+	err := project.Validate()
+	if err != nil {
+		report.add("project", DoctorError, "run the command from the directory of a project created using 'flogo create'", "%v", err)
+		return
+	}
+	report.add("project", DoctorOk, "", "%s", project.Dir())
+
+	if installed := installedGoVersion(); installed != "" {
+		conflicts := goVersionConflicts(installed, goRequirements(project.SrcDir()))
+		for _, req := range conflicts {
+			report.add("go version", DoctorError, fmt.Sprintf("install go %s or later, or use a version of %s compatible with go %s", req.GoVersion, req.Module, installed),
+				"%s %s requires go %s", req.Module, req.Version, req.GoVersion)
+		}
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		report.add(fileFlogoJson, DoctorError, "fix the syntax of the app descriptor", "%v", err)
+		return
+	}
+
+	var appImports []string
+	if vals, ok := appObj["imports"].([]interface{}); ok {
+		for _, val := range vals {
+			if s, ok := val.(string); ok {
+				appImports = append(appImports, s)
+			}
+		}
+	}
+	imports, err := util.ParseImports(appImports)
+	if err != nil {
+		report.add(fileFlogoJson, DoctorError, "fix the imports of the app descriptor", "invalid imports: %v", err)
+		return
+	}
+
+	goImports, err := project.GetGoImports(false)
+	if err != nil {
+		report.add(fileImportsGo, DoctorError, "fix the syntax of src/imports.go", "%v", err)
+		return
+	}
+
+	requires := goModRequirements(project.SrcDir())
+	report.Checks = append(report.Checks, doctorImportChecks(imports, goImports, requires)...)
+
+	pins, err := projectPins(project)
+	if err != nil {
+		report.add(fileFlogoLock, DoctorError, "fix the syntax of flogo.lock", "%v", err)
+		return
+	}
+	for _, warning := range pinWarnings(pins, requires, time.Now()) {
+		report.add("pin", DoctorWarning, "upgrade the module and remove its pin using 'flogo imports unpin', or extend the pin using 'flogo imports pin'", "%s", warning)
+	}
+}
+
+// doctorImportChecks checks that the imports of flogo.json are imported by imports.go and required by go.mod at
+// the same version, and that imports.go doesn't import contributions missing from flogo.json
+func doctorImportChecks(imports []util.Import, goImports []util.Import, requires map[string]string) []*DoctorCheck {
+
+	report := &DoctorReport{}
+
+	inGo := make(map[string]bool)
+	for _, imp := range goImports {
+		inGo[imp.GoImportPath()] = true
+	}
+	inJson := make(map[string]bool)
+
+	for _, imp := range imports {
+		path := imp.GoImportPath()
+		inJson[path] = true
+
+		if !inGo[path] {
+			report.add("imports", DoctorError, fmt.Sprintf("run 'flogo install %s'", imp.CanonicalImport()),
+				"'%s' is imported by %s but not by %s", path, fileFlogoJson, fileImportsGo)
+		}
+
+		module, version := requiredModule(requires, path)
+		if module == "" {
+			report.add("go.mod", DoctorError, fmt.Sprintf("run 'flogo install %s'", imp.CanonicalImport()),
+				"no module required by go.mod provides '%s'", path)
+			continue
+		}
+
+		wanted := imp.Version()
+		if wanted == "" || wanted == "latest" || wanted == "master" {
+			continue
+		}
+		if !strings.HasPrefix(wanted, "v") {
+			wanted = "v" + wanted
+		}
+		if wanted != version {
+			report.add("versions", DoctorWarning,
+				fmt.Sprintf("run 'flogo imports resolve' to use %s, or 'flogo install %s@%s' to use %s", version, module, wanted, wanted),
+				"'%s' is imported at %s by %s but %s %s is required by go.mod", path, wanted, fileFlogoJson, module, version)
+		}
+	}
+
+	var extra []string
+	for path := range inGo {
+		if !inJson[path] && path != flogoCoreRepo {
+			extra = append(extra, path)
+		}
+	}
+	sort.Strings(extra)
+	for _, path := range extra {
+		report.add("imports", DoctorWarning, "run 'flogo imports sync'", "'%s' is imported by %s but not by %s", path, fileImportsGo, fileFlogoJson)
+	}
+
+	if len(report.Checks) == 0 {
+		report.add("imports", DoctorOk, "", "%d imports consistent between %s, %s and go.mod", len(imports), fileFlogoJson, fileImportsGo)
+	}
+
+	return report.Checks
+}
+
+// requiredModule gets the module required by go.mod providing the package, the one with the longest path
+func requiredModule(requires map[string]string, pkg string) (string, string) {
+
+	module := ""
+	for mod := range requires {
+		if (pkg == mod || strings.HasPrefix(pkg, mod+"/")) && len(mod) > len(module) {
+			module = mod
+		}
+	}
+	if module == "" {
+		return "", ""
+	}
+
+	return module, requires[module]
+}
+
+// PrintDoctorReport prints the checks with their fixes, or the report in JSON
+func PrintDoctorReport(report *DoctorReport, jsonFormat bool) error {
+
+	if jsonFormat {
+		if report.Checks == nil {
+			report.Checks = []*DoctorCheck{}
+		}
+		resp, err := json.MarshalIndent(report, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, string(resp))
+		return nil
+	}
+
+	for _, check := range report.Checks {
+		fmt.Printf("%-9s %s: %s\n", "["+check.Status+"]", check.Name, check.Message)
+		if check.Fix != "" {
+			fmt.Printf("%-9s fix: %s\n", "", check.Fix)
+		}
+	}
+
+	return nil
+}
+
+// DoctorProject gets the project of the directory, even if it isn't valid, nil if the directory has no app descriptor
+func DoctorProject(dir string) common.AppProject {
+
+	if util.FileExists(filepath.Join(dir, fileFlogoJson)) || yamlDescriptorFile(dir) != "" {
+		return NewAppProject(dir)
+	}
+
+	return nil
+}
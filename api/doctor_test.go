@@ -0,0 +1,71 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorImportChecks(t *testing.T) {
+	t.Log("Testing consistency checks of the imports of flogo.json, imports.go and go.mod")
+
+	imports, err := util.ParseImports([]string{
+		"github.com/project-flogo/contrib/activity/log@v0.9.0",
+		"github.com/project-flogo/contrib/trigger/rest@v0.10.0",
+		"github.com/project-flogo/flow",
+		"github.com/myorg/activity/audit",
+	})
+	assert.Nil(t, err)
+	goImports, err := util.ParseImports([]string{
+		"github.com/project-flogo/contrib/activity/log",
+		"github.com/project-flogo/contrib/trigger/rest",
+		"github.com/project-flogo/flow",
+		"github.com/project-flogo/core",
+		"github.com/acme/activity/kafka",
+	})
+	assert.Nil(t, err)
+	requires := map[string]string{
+		"github.com/project-flogo/core":                  "v1.0.0",
+		"github.com/project-flogo/flow":                  "v1.0.0",
+		"github.com/project-flogo/contrib":               "v0.9.0",
+		"github.com/project-flogo/contrib/activity/rest": "v0.10.0",
+		"github.com/acme/activity":                       "v1.0.0",
+	}
+
+	checks := doctorImportChecks(imports, goImports, requires)
+	assert.Len(t, checks, 4)
+
+	assert.Equal(t, "versions", checks[0].Name)
+	assert.Equal(t, DoctorWarning, checks[0].Status)
+	assert.Contains(t, checks[0].Message, "'github.com/project-flogo/contrib/trigger/rest' is imported at v0.10.0")
+
+	assert.Equal(t, DoctorError, checks[1].Status)
+	assert.Equal(t, "'github.com/myorg/activity/audit' is imported by flogo.json but not by imports.go", checks[1].Message)
+	assert.Equal(t, "run 'flogo install github.com/myorg/activity/audit'", checks[1].Fix)
+	assert.Equal(t, "go.mod", checks[2].Name)
+
+	assert.Equal(t, DoctorWarning, checks[3].Status)
+	assert.Equal(t, "run 'flogo imports sync'", checks[3].Fix)
+	assert.Contains(t, checks[3].Message, "github.com/acme/activity/kafka")
+
+	checks = doctorImportChecks(imports[:1], goImports[:1], requires)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, DoctorOk, checks[0].Status)
+}
+
+func TestRequiredModule(t *testing.T) {
+	t.Log("Testing lookup of the module providing a package")
+
+	requires := map[string]string{"github.com/project-flogo/contrib": "v0.9.0", "github.com/project-flogo/contrib/activity/rest": "v0.10.0"}
+
+	mod, ver := requiredModule(requires, "github.com/project-flogo/contrib/activity/rest")
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", mod)
+	assert.Equal(t, "v0.10.0", ver)
+
+	mod, _ = requiredModule(requires, "github.com/project-flogo/contrib/activity/log")
+	assert.Equal(t, "github.com/project-flogo/contrib", mod)
+
+	mod, _ = requiredModule(requires, "github.com/project-flogo/contribx/activity/log")
+	assert.Equal(t, "", mod)
+}
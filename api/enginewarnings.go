@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// engineWarningHint is the remediation of the engine warnings matching the pattern, the submatches are the arguments
+// of the hint
+type engineWarningHint struct {
+	pattern *regexp.Regexp
+	hint    string
+}
+
+var engineWarningHints = []*engineWarningHint{
+	{regexp.MustCompile(`(?i)propert(?:y|ies) '?([^'\s,]+)'? (?:not found|is not defined|undefined)`),
+		"define property '%[1]s' in the properties of flogo.json, or set it using 'flogo props' or FLOGO_APP_PROPS_JSON"},
+	{regexp.MustCompile(`(?i)(?:setting|property|option) '?([^'\s,]+)'? (?:is )?deprecated`),
+		"'%[1]s' is deprecated, replace it as described by the documentation of its contribution, 'flogo explain' shows the settings it supports"},
+	{regexp.MustCompile(`(?i)deprecated`),
+		"a deprecated feature is used, check the documentation of the contribution for its replacement"},
+	{regexp.MustCompile(`(?i)FLOGO_DATA_SECRET_KEY|secret key (?:not set|not provided|not specified)`),
+		"set FLOGO_DATA_SECRET_KEY to the key the secrets were encrypted with, see 'flogo secrets'"},
+	{regexp.MustCompile(`(?i)no engine config|(?:engine config(?:uration)?|engine\.json)[^,]* not (?:found|provided|specified)`),
+		"create engine.json next to flogo.json to configure the engine, the build embeds it along with the configuration"},
+	{regexp.MustCompile(`(?i)(?:missing|no) (?:optional )?(?:config(?:uration)?|setting) '?([^'\s,]+)'?`),
+		"set '%[1]s' in the settings of the contribution in flogo.json to configure it explicitly"},
+	{regexp.MustCompile(`(?i)(?:using|use|falling back to|fall back to|fallback to|defaulting to) (?:the )?default`),
+		"the engine falls back to a default, set the value explicitly in flogo.json or engine.json to remove the warning"},
+}
+
+// EngineWarning is a warning logged by the engine while it starts, along with guidance on fixing it
+type EngineWarning struct {
+	Logger  string // the logger of the warning, ex. flogo.engine
+	Message string
+	Hint    string // the remediation of the warning, empty if it isn't recognized
+}
+
+func (w *EngineWarning) String() string {
+	if w.Logger == "" {
+		return w.Message
+	}
+	return fmt.Sprintf("[%s] %s", w.Logger, w.Message)
+}
+
+// engineWarnings collects the warnings of the output of the engine, once per message
+type engineWarnings struct {
+	warnings []*EngineWarning
+	seen     map[string]bool
+}
+
+// add collects the warning of a line of the output of the engine, if it is one
+func (ew *engineWarnings) add(line string) {
+
+	warning := parseEngineWarning(line)
+	if warning == nil || ew.seen[warning.String()] {
+		return
+	}
+
+	if ew.seen == nil {
+		ew.seen = make(map[string]bool)
+	}
+	ew.seen[warning.String()] = true
+	ew.warnings = append(ew.warnings, warning)
+}
+
+// report prints the warnings collected with their remediation
+func (ew *engineWarnings) report(w io.Writer) {
+
+	if len(ew.warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "The engine logged %d warning(s) while starting:\n", len(ew.warnings))
+	for _, warning := range ew.warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+		if warning.Hint != "" {
+			fmt.Fprintf(w, "  Hint: %s\n", warning.Hint)
+		}
+	}
+}
+
+// parseEngineWarning parses a warning logged by the engine in its console or JSON format, nil if the line isn't one
+func parseEngineWarning(line string) *EngineWarning {
+
+	warning := &EngineWarning{}
+
+	if entry, isJSON := parseJSONLogEntry(line); isJSON {
+		level, _ := entry["level"].(string)
+		if logLevelIndex(level) != logLevelIndex("warn") {
+			return nil
+		}
+		warning.Logger, _ = entry["logger"].(string)
+		warning.Message, _ = entry["msg"].(string)
+		if warning.Message == "" {
+			warning.Message, _ = entry["message"].(string)
+		}
+	} else {
+		// ex. 2019-03-14T10:00:00.000-0400	WARN	[flogo.engine] -	Property 'port' not found
+		if consoleLogLevel(line) != logLevelIndex("warn") {
+			return nil
+		}
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) < 3 {
+			return nil
+		}
+		message := strings.TrimSpace(fields[2])
+		if len(fields) == 4 {
+			warning.Logger = strings.Trim(strings.TrimSuffix(strings.TrimSpace(fields[2]), "-"), "[] ")
+			message = strings.TrimSpace(fields[3])
+		}
+		warning.Message = message
+	}
+
+	warning.Message = strings.TrimSpace(warning.Message)
+	if warning.Message == "" {
+		return nil
+	}
+
+	for _, h := range engineWarningHints {
+		if m := h.pattern.FindStringSubmatch(warning.Message); m != nil {
+			args := make([]interface{}, len(m)-1)
+			for i, arg := range m[1:] {
+				args[i] = arg
+			}
+			warning.Hint = fmt.Sprintf(h.hint, args...)
+			break
+		}
+	}
+
+	return warning
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEngineWarning(t *testing.T) {
+
+	warning := parseEngineWarning("2019-03-14T10:00:00.000-0400\tWARN\t[flogo.engine] -\tProperty 'port' not found")
+	assert.NotNil(t, warning)
+	assert.Equal(t, "flogo.engine", warning.Logger)
+	assert.Equal(t, "Property 'port' not found", warning.Message)
+	assert.Contains(t, warning.Hint, "define property 'port'")
+
+	warning = parseEngineWarning(`{"level":"warn","ts":"2019-03-14T10:00:00.000-0400","logger":"flogo.trigger.rest","msg":"setting 'secure' is deprecated"}`)
+	assert.NotNil(t, warning)
+	assert.Equal(t, "flogo.trigger.rest", warning.Logger)
+	assert.Equal(t, "'secure' is deprecated, replace it as described by the documentation of its contribution, 'flogo explain' shows the settings it supports", warning.Hint)
+
+	warning = parseEngineWarning("2019-03-14T10:00:00.000-0400\tWARN\t[flogo] -\tsomething unusual happened")
+	assert.NotNil(t, warning)
+	assert.Equal(t, "", warning.Hint)
+
+	assert.Nil(t, parseEngineWarning("2019-03-14T10:00:00.000-0400\tINFO\t[flogo.engine] -\tEngine Started"))
+	assert.Nil(t, parseEngineWarning(`{"level":"error","msg":"failed"}`))
+	assert.Nil(t, parseEngineWarning("plain output"))
+
+	var warnings engineWarnings
+	warnings.add("2019-03-14T10:00:00.000-0400\tWARN\t[flogo.engine] -\tno engine configuration provided, using default")
+	warnings.add("2019-03-14T10:00:01.000-0400\tWARN\t[flogo.engine] -\tno engine configuration provided, using default")
+
+	var out bytes.Buffer
+	warnings.report(&out)
+	assert.Equal(t, "The engine logged 1 warning(s) while starting:\n"+
+		"Warning: [flogo.engine] no engine configuration provided, using default\n"+
+		"  Hint: create engine.json next to flogo.json to configure the engine, the build embeds it along with the configuration\n", out.String())
+}
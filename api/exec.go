@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// ExecOptions are the options used to run an action of the application once
+type ExecOptions struct {
+	Input string   // JSON file containing the input of the action
+	Data  []string // input values of the action, as name=value, overriding the ones of the input file
+}
+
+// ExecAction runs a flow or a shared action of the application once against the input and prints its output as JSON.
+// The application is built with the debugger and run without its triggers, the progress and the logs of the engine
+// are written to stderr so the output can be piped.
+func ExecAction(ctx context.Context, project common.AppProject, id string, options ExecOptions) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	session, err := newExecSession(appObj, id)
+	if err != nil {
+		return err
+	}
+
+	session.Input, err = readDebugInput(options.Input, options.Data)
+	if err != nil {
+		return err
+	}
+
+	err = runDebugSession(ctx, project, appObj, session, os.Stderr)
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("'%s' failed", id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// newExecSession creates the session running the flow or the shared action once, a flow is looked up first
+func newExecSession(appObj map[string]interface{}, id string) (*debugSession, error) {
+
+	flowId := normalizeFlowId(id)
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		if resId, _ := resMap["id"].(string); resId == flowId {
+			return &debugSession{Ref: flowActionRef, FlowURI: resURIPrefix + flowId, Run: true}, nil
+		}
+	}
+
+	actions, _ := appObj["actions"].([]interface{})
+	for _, act := range actions {
+		actMap, _ := act.(map[string]interface{})
+		if actId, _ := actMap["id"].(string); actId != id {
+			continue
+		}
+
+		ref, _ := actMap["ref"].(string)
+		if strings.HasPrefix(ref, "#") {
+			ref = resolveImportAlias(appObj, ref[1:])
+			if ref == "" {
+				return nil, fmt.Errorf("import of action '%s' not found", id)
+			}
+		}
+		settings, _ := actMap["settings"].(map[string]interface{})
+
+		session := &debugSession{Ref: ref, Settings: settings, Run: true}
+		// a shared flow action is run like a flow, so it's given the flow to run
+		if uri, _ := settings["flowURI"].(string); ref == flowActionRef && uri != "" {
+			session.FlowURI = uri
+		}
+		return session, nil
+	}
+
+	return nil, fmt.Errorf("no flow or shared action '%s' found", id)
+}
+
+// resolveImportAlias gets the import path of the contribution imported with the alias
+func resolveImportAlias(appObj map[string]interface{}, alias string) string {
+
+	var imports []string
+	vals, _ := appObj["imports"].([]interface{})
+	for _, val := range vals {
+		if s, ok := val.(string); ok {
+			imports = append(imports, s)
+		}
+	}
+
+	parsed, err := util.ParseImports(imports)
+	if err != nil {
+		return ""
+	}
+	for _, imp := range parsed {
+		if imp.CanonicalAlias() == alias {
+			return imp.GoImportPath()
+		}
+	}
+
+	return ""
+}
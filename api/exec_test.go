@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExecSession(t *testing.T) {
+	t.Log("Testing resolution of the flow or action run by exec")
+
+	appObj := map[string]interface{}{
+		"imports": []interface{}{"github.com/project-flogo/flow", "myrest github.com/myuser/action/rest"},
+		"resources": []interface{}{
+			map[string]interface{}{"id": "flow:orders"},
+		},
+		"actions": []interface{}{
+			map[string]interface{}{"id": "shared_orders", "ref": "#flow", "settings": map[string]interface{}{"flowURI": "res://flow:orders"}},
+			map[string]interface{}{"id": "proxy", "ref": "#myrest", "settings": map[string]interface{}{"uri": "http://localhost"}},
+			map[string]interface{}{"id": "unknown", "ref": "#missing"},
+		},
+	}
+
+	session, err := newExecSession(appObj, "orders")
+	assert.Nil(t, err)
+	assert.Equal(t, flowActionRef, session.Ref)
+	assert.Equal(t, "res://flow:orders", session.FlowURI)
+	assert.True(t, session.Run)
+
+	session, err = newExecSession(appObj, "shared_orders")
+	assert.Nil(t, err)
+	assert.Equal(t, flowActionRef, session.Ref)
+	assert.Equal(t, "res://flow:orders", session.FlowURI)
+
+	session, err = newExecSession(appObj, "proxy")
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/myuser/action/rest", session.Ref)
+	assert.Equal(t, "", session.FlowURI)
+	assert.Equal(t, "http://localhost", session.Settings["uri"])
+
+	_, err = newExecSession(appObj, "unknown")
+	assert.NotNil(t, err)
+
+	_, err = newExecSession(appObj, "missing")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,481 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	elementTrigger = "trigger"
+	elementHandler = "handler"
+	elementFlow    = "flow"
+	elementTask    = "task"
+	elementAction  = "action"
+)
+
+// the paths of the elements which can be explained, a path within an element explains the element
+var elementPathPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{elementHandler, regexp.MustCompile(`^\$\.triggers\[(\d+)\]\.handlers\[(\d+)\]`)},
+	{elementTrigger, regexp.MustCompile(`^\$\.triggers\[(\d+)\]`)},
+	{elementTask, regexp.MustCompile(`^\$\.resources\[(\d+)\]\.data\.((?:errorHandler\.)?tasks)\[(\d+)\]`)},
+	{elementFlow, regexp.MustCompile(`^\$\.resources\[(\d+)\]`)},
+	{elementAction, regexp.MustCompile(`^\$\.actions\[(\d+)\]`)},
+}
+
+// Explanation describes an element of the app descriptor: what it is, the contribution implementing it, its settings
+// and the flows related to it
+type Explanation struct {
+	Kind         string              `json:"kind"`
+	Id           string              `json:"id"`
+	Path         string              `json:"path"`
+	Contrib      string              `json:"contrib,omitempty"` // the import path of the contribution implementing it
+	Version      string              `json:"version,omitempty"`
+	Description  string              `json:"description,omitempty"`
+	Settings     []*ExplainedSetting `json:"settings,omitempty"`
+	Input        []*ExplainedSetting `json:"input,omitempty"`
+	Action       string              `json:"action,omitempty"` // the import path of the action run by a handler
+	Flows        []string            `json:"flows,omitempty"`  // the flows run by the element, or containing it
+	ReferencedBy []string            `json:"referencedBy,omitempty"`
+}
+
+// ExplainedSetting is a setting of an element, with the app property it is resolved from if any
+type ExplainedSetting struct {
+	Name     string      `json:"name"`
+	Value    interface{} `json:"value"`
+	Property string      `json:"property,omitempty"`
+}
+
+// ExplainElement explains the trigger, handler, flow, task or action identified by its id or by a JSON path of the app
+// descriptor, ex. 'rest/get_order', 'flow:orders/log' or '$.triggers[0].handlers[1]'
+func ExplainElement(project common.AppProject, element string, jsonFormat bool) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := explainElement(appObj, element)
+	if err != nil {
+		return err
+	}
+
+	if explanation.Contrib != "" {
+		_, explanation.Version = requiredModule(goModRequirements(project.SrcDir()), explanation.Contrib)
+		// the descriptors are only available once the contributions are installed
+		if contribs, err := newContribResolver(project); err == nil {
+			if desc := contribs.Resolve(explainedContribType(explanation.Kind), explanation.Contrib); desc != nil {
+				explanation.Description = desc.Description
+			}
+		}
+	}
+
+	if jsonFormat {
+		buf, err := json.MarshalIndent(explanation, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(buf))
+		return nil
+	}
+
+	printExplanation(os.Stdout, explanation)
+	return nil
+}
+
+// explainElement explains the element identified by its id or JSON path
+func explainElement(appObj map[string]interface{}, element string) (*Explanation, error) {
+
+	path := element
+	if !strings.HasPrefix(element, "$") {
+		paths := findElementPaths(appObj, element)
+		switch len(paths) {
+		case 0:
+			return nil, fmt.Errorf("no trigger, handler, flow, task or action '%s' found", element)
+		case 1:
+			path = paths[0]
+		default:
+			return nil, fmt.Errorf("'%s' is ambiguous, use one of: %s", element, strings.Join(paths, ", "))
+		}
+	}
+
+	for _, p := range elementPathPatterns {
+		m := p.pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		return explainPath(appObj, p.kind, m)
+	}
+
+	return nil, fmt.Errorf("'%s' isn't within a trigger, handler, flow, task or action of %s", element, fileFlogoJson)
+}
+
+// findElementPaths finds the paths of the elements with the id: a trigger, a handler as trigger/handler, a flow, a task
+// as flow/task or by its id alone, or an action
+func findElementPaths(appObj map[string]interface{}, id string) []string {
+
+	var paths []string
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for i, trg := range triggers {
+		trgMap, _ := trg.(map[string]interface{})
+		trgId, _ := trgMap["id"].(string)
+		if trgId == id || "trigger:"+trgId == id {
+			paths = append(paths, fmt.Sprintf("$.triggers[%d]", i))
+		}
+		handlers, _ := trgMap["handlers"].([]interface{})
+		for j, handler := range handlers {
+			name := handlerName(handler, j)
+			if id == trgId+"/"+name || id == "trigger:"+trgId+"/"+name {
+				paths = append(paths, fmt.Sprintf("$.triggers[%d].handlers[%d]", i, j))
+			}
+		}
+	}
+
+	flowId, taskId := id, ""
+	if idx := strings.Index(id, "/"); idx > 0 {
+		flowId, taskId = id[:idx], id[idx+1:]
+	}
+	resources, _ := appObj["resources"].([]interface{})
+	for i, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		if taskId == "" && (resId == id || strings.HasPrefix(resId, flowResPrefix) && resId == normalizeFlowId(id)) {
+			paths = append(paths, fmt.Sprintf("$.resources[%d]", i))
+		}
+		if taskId != "" && resId != flowId && resId != normalizeFlowId(flowId) {
+			continue
+		}
+		data, _ := resMap["data"].(map[string]interface{})
+		for _, tasksPath := range []string{"tasks", "errorHandler.tasks"} {
+			for j, task := range flowTasks(data, tasksPath) {
+				taskMap, _ := task.(map[string]interface{})
+				if tId, _ := taskMap["id"].(string); tId == id || taskId != "" && tId == taskId {
+					paths = append(paths, fmt.Sprintf("$.resources[%d].data.%s[%d]", i, tasksPath, j))
+				}
+			}
+		}
+	}
+
+	actions, _ := appObj["actions"].([]interface{})
+	for i, act := range actions {
+		actMap, _ := act.(map[string]interface{})
+		if actId, _ := actMap["id"].(string); actId == id {
+			paths = append(paths, fmt.Sprintf("$.actions[%d]", i))
+		}
+	}
+
+	return paths
+}
+
+// flowTasks gets the tasks of the flow, or of its error handler
+func flowTasks(data map[string]interface{}, tasksPath string) []interface{} {
+
+	if tasksPath == "errorHandler.tasks" {
+		eh, _ := data["errorHandler"].(map[string]interface{})
+		tasks, _ := eh["tasks"].([]interface{})
+		return tasks
+	}
+
+	tasks, _ := data["tasks"].([]interface{})
+	return tasks
+}
+
+// explainPath explains the element of the kind located at the path matched by its pattern
+func explainPath(appObj map[string]interface{}, kind string, m []string) (*Explanation, error) {
+
+	path := m[0]
+	notFound := fmt.Errorf("no %s found at '%s'", kind, path)
+	item := func(items []interface{}, s string) map[string]interface{} {
+		i, err := strconv.Atoi(s)
+		if err != nil || i >= len(items) {
+			return nil
+		}
+		itemMap, _ := items[i].(map[string]interface{})
+		return itemMap
+	}
+
+	props := make(map[string]interface{})
+	for _, prop := range getAppProperties(appObj) {
+		props[prop.Name] = prop.Value
+	}
+
+	e := &Explanation{Kind: kind, Path: path}
+
+	switch kind {
+	case elementTrigger, elementHandler:
+		triggers, _ := appObj["triggers"].([]interface{})
+		trgMap := item(triggers, m[1])
+		if trgMap == nil {
+			return nil, notFound
+		}
+		trgId, _ := trgMap["id"].(string)
+		ref, _ := trgMap["ref"].(string)
+		e.Id, e.Contrib = trgId, refImportPath(appObj, ref, elementTrigger)
+
+		handlers, _ := trgMap["handlers"].([]interface{})
+		if kind == elementTrigger {
+			e.Settings = explainSettings(trgMap["settings"], props)
+			for _, handler := range handlers {
+				for _, flow := range handlerFlows(handler) {
+					e.Flows = appendUnique(e.Flows, flow)
+				}
+			}
+			break
+		}
+
+		hMap := item(handlers, m[2])
+		if hMap == nil {
+			return nil, notFound
+		}
+		hIdx, _ := strconv.Atoi(m[2])
+		e.Id = trgId + "/" + handlerName(hMap, hIdx)
+		e.Settings = explainSettings(hMap["settings"], props)
+		if action, ok := hMap["action"].(map[string]interface{}); ok {
+			actionRef, _ := action["ref"].(string)
+			e.Action = refImportPath(appObj, actionRef, elementAction)
+		}
+		e.Flows = handlerFlows(hMap)
+
+	case elementFlow:
+		resources, _ := appObj["resources"].([]interface{})
+		resMap := item(resources, m[1])
+		if resMap == nil {
+			return nil, notFound
+		}
+		e.Id, _ = resMap["id"].(string)
+		e.ReferencedBy = flowReferences(appObj, e.Id)
+
+	case elementTask:
+		resources, _ := appObj["resources"].([]interface{})
+		resMap := item(resources, m[1])
+		if resMap == nil {
+			return nil, notFound
+		}
+		data, _ := resMap["data"].(map[string]interface{})
+		taskMap := item(flowTasks(data, m[2]), m[3])
+		if taskMap == nil {
+			return nil, notFound
+		}
+		flowId, _ := resMap["id"].(string)
+		taskId, _ := taskMap["id"].(string)
+		e.Id = flowId + "/" + taskId
+		e.Flows = []string{flowId}
+
+		activity, _ := taskMap["activity"].(map[string]interface{})
+		ref, _ := activity["ref"].(string)
+		e.Contrib = refImportPath(appObj, ref, "activity")
+		e.Settings = explainSettings(activity["settings"], props)
+		e.Input = explainSettings(activity["input"], props)
+
+	case elementAction:
+		actions, _ := appObj["actions"].([]interface{})
+		actMap := item(actions, m[1])
+		if actMap == nil {
+			return nil, notFound
+		}
+		e.Id, _ = actMap["id"].(string)
+		ref, _ := actMap["ref"].(string)
+		e.Contrib = refImportPath(appObj, ref, elementAction)
+		e.Settings = explainSettings(actMap["settings"], props)
+		visitActionFlowURI(actMap, func(settings map[string]interface{}, flowURI string) {
+			e.Flows = appendUnique(e.Flows, strings.TrimPrefix(flowURI, resURIPrefix))
+		})
+	}
+
+	return e, nil
+}
+
+// explainSettings gets the settings sorted by name, the references to app properties are substituted
+func explainSettings(val interface{}, props map[string]interface{}) []*ExplainedSetting {
+
+	settings, _ := val.(map[string]interface{})
+
+	var names []string
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var explained []*ExplainedSetting
+	for _, name := range names {
+		setting := &ExplainedSetting{Name: name, Value: settings[name]}
+		if s, ok := setting.Value.(string); ok {
+			if m := propertyRefPattern.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+				setting.Property = m[1]
+				setting.Value = props[m[1]]
+			}
+		}
+		explained = append(explained, setting)
+	}
+
+	return explained
+}
+
+// handlerFlows gets the flows run by the handler
+func handlerFlows(handler interface{}) []string {
+
+	hMap, _ := handler.(map[string]interface{})
+
+	var flows []string
+	visit := func(settings map[string]interface{}, flowURI string) {
+		flows = appendUnique(flows, strings.TrimPrefix(flowURI, resURIPrefix))
+	}
+	visitActionFlowURI(hMap["action"], visit)
+	actions, _ := hMap["actions"].([]interface{})
+	for _, action := range actions {
+		visitActionFlowURI(action, visit)
+	}
+
+	return flows
+}
+
+// flowReferences gets the handlers and actions running the flow, and the tasks of other flows calling it as a subflow
+func flowReferences(appObj map[string]interface{}, flowId string) []string {
+
+	var refs []string
+	runs := func(flowURI string) bool {
+		return strings.TrimPrefix(flowURI, resURIPrefix) == flowId
+	}
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		trgMap, _ := trg.(map[string]interface{})
+		trgId, _ := trgMap["id"].(string)
+		handlers, _ := trgMap["handlers"].([]interface{})
+		for i, handler := range handlers {
+			for _, flow := range handlerFlows(handler) {
+				if runs(flow) {
+					refs = append(refs, "trigger:"+trgId+"/"+handlerName(handler, i))
+				}
+			}
+		}
+	}
+
+	actions, _ := appObj["actions"].([]interface{})
+	for _, act := range actions {
+		actMap, _ := act.(map[string]interface{})
+		actId, _ := actMap["id"].(string)
+		visitActionFlowURI(actMap, func(settings map[string]interface{}, flowURI string) {
+			if runs(flowURI) {
+				refs = append(refs, "action:"+actId)
+			}
+		})
+	}
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		data, _ := resMap["data"].(map[string]interface{})
+		for _, tasksPath := range []string{"tasks", "errorHandler.tasks"} {
+			for _, task := range flowTasks(data, tasksPath) {
+				taskMap, _ := task.(map[string]interface{})
+				taskId, _ := taskMap["id"].(string)
+				activity, _ := taskMap["activity"].(map[string]interface{})
+				settings, _ := activity["settings"].(map[string]interface{})
+				if flowURI, _ := settings["flowURI"].(string); flowURI != "" && runs(flowURI) {
+					refs = append(refs, resId+"/"+taskId)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// refImportPath gets the import path of the contribution of the type referenced by ref
+func refImportPath(appObj map[string]interface{}, ref, contribType string) string {
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(ref, "#") {
+		var paths []string
+		for _, s := range descriptorImports(appObj) {
+			if imp, err := util.ParseImport(s); err == nil && imp.CanonicalAlias() == ref[1:] {
+				paths = append(paths, imp.GoImportPath())
+			}
+		}
+		return resolveAlias(paths, contribType)
+	}
+
+	imp, err := util.ParseImport(ref)
+	if err != nil {
+		return ""
+	}
+
+	return imp.GoImportPath()
+}
+
+// explainedContribType gets the type of the contribution implementing an element of the kind
+func explainedContribType(kind string) string {
+	switch kind {
+	case elementTrigger, elementHandler:
+		return "trigger"
+	case elementTask:
+		return "activity"
+	}
+	return "action"
+}
+
+func printExplanation(w io.Writer, e *Explanation) {
+
+	fmt.Fprintf(w, "%s '%s' (%s)\n", e.Kind, e.Id, e.Path)
+
+	if e.Contrib != "" {
+		contrib := e.Contrib
+		if e.Version != "" {
+			contrib += " " + e.Version
+		}
+		fmt.Fprintf(w, "  Contribution  : %s\n", contrib)
+		if e.Description != "" {
+			fmt.Fprintf(w, "                  %s\n", e.Description)
+		}
+	}
+	if e.Action != "" {
+		fmt.Fprintf(w, "  Action        : %s\n", e.Action)
+	}
+
+	printSettings := func(title string, settings []*ExplainedSetting) {
+		if len(settings) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "  %-14s:\n", title)
+		width := 0
+		for _, setting := range settings {
+			if len(setting.Name) > width {
+				width = len(setting.Name)
+			}
+		}
+		for _, setting := range settings {
+			value := outputValue(setting.Value)
+			if setting.Property != "" {
+				value += fmt.Sprintf(" (from $property[%s])", setting.Property)
+			}
+			fmt.Fprintf(w, "    %-*s = %s\n", width, setting.Name, value)
+		}
+	}
+	printSettings("Settings", e.Settings)
+	printSettings("Input", e.Input)
+
+	if len(e.Flows) > 0 {
+		fmt.Fprintf(w, "  Flows         : %s\n", strings.Join(e.Flows, ", "))
+	}
+	if e.Kind == elementFlow {
+		fmt.Fprintf(w, "  Referenced by : %s\n", joinOrDash(e.ReferencedBy))
+	}
+}
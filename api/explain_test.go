@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const explainAppJson = `{
+  "imports": ["github.com/project-flogo/flow", "github.com/project-flogo/contrib/trigger/rest", "github.com/project-flogo/contrib/activity/rest", "github.com/project-flogo/contrib/activity/log"],
+  "properties": [{"name": "port", "type": "int", "value": 8080}],
+  "triggers": [{"id": "api", "ref": "#rest", "settings": {"port": "=$property[port]"}, "handlers": [
+    {"name": "get_order", "settings": {"method": "GET", "path": "/orders/:id"}, "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:orders"}}}
+  ]}],
+  "resources": [
+    {"id": "flow:orders", "data": {"tasks": [
+      {"id": "log", "activity": {"ref": "#log", "input": {"message": "=$.id"}}},
+      {"id": "billing", "activity": {"ref": "#subflow", "settings": {"flowURI": "res://flow:billing"}}}
+    ]}},
+    {"id": "flow:billing", "data": {"tasks": [{"id": "log", "activity": {"ref": "#log"}}]}}
+  ]
+}`
+
+func TestExplainElement(t *testing.T) {
+	t.Log("Testing explanation of the elements of an app descriptor")
+
+	var appObj map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(explainAppJson), &appObj))
+
+	e, err := explainElement(appObj, "api")
+	assert.Nil(t, err)
+	assert.Equal(t, elementTrigger, e.Kind)
+	assert.Equal(t, "github.com/project-flogo/contrib/trigger/rest", e.Contrib)
+	assert.Equal(t, []*ExplainedSetting{{Name: "port", Value: 8080.0, Property: "port"}}, e.Settings)
+	assert.Equal(t, []string{"flow:orders"}, e.Flows)
+
+	e, err = explainElement(appObj, "$.triggers[0].handlers[0].settings.method")
+	assert.Nil(t, err)
+	assert.Equal(t, elementHandler, e.Kind)
+	assert.Equal(t, "api/get_order", e.Id)
+	assert.Equal(t, "$.triggers[0].handlers[0]", e.Path)
+	assert.Equal(t, "github.com/project-flogo/flow", e.Action)
+	assert.Len(t, e.Settings, 2)
+
+	e, err = explainElement(appObj, "flow:billing")
+	assert.Nil(t, err)
+	assert.Equal(t, elementFlow, e.Kind)
+	assert.Equal(t, []string{"flow:orders/billing"}, e.ReferencedBy)
+
+	e, err = explainElement(appObj, "orders")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"trigger:api/get_order"}, e.ReferencedBy)
+
+	e, err = explainElement(appObj, "flow:orders/log")
+	assert.Nil(t, err)
+	assert.Equal(t, elementTask, e.Kind)
+	assert.Equal(t, "$.resources[0].data.tasks[0]", e.Path)
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/log", e.Contrib)
+	assert.Equal(t, []*ExplainedSetting{{Name: "message", Value: "=$.id"}}, e.Input)
+
+	_, err = explainElement(appObj, "log")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "ambiguous")
+
+	_, err = explainElement(appObj, "missing")
+	assert.NotNil(t, err)
+
+	_, err = explainElement(appObj, "$.properties[0]")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+var (
+	// the paths of the handlers usable as the liveness probe of the application
+	healthPathPattern = regexp.MustCompile(`(?i)^/(health|healthz|healthcheck|live|livez|liveness|ping)/?$`)
+	envRefPattern     = regexp.MustCompile(`\$env\[([^\]]+)\]`)
+)
+
+// appExposure is what the application exposes and requires at runtime, derived from the settings of its enabled
+// triggers and their handlers, so the generated deploy assets follow the app descriptor
+type appExposure struct {
+	Ports       []int    // the ports the triggers listen on
+	HealthCheck string   // the URL of a handler usable as the liveness probe, ex. http://localhost:9999/health
+	Env         []string // the environment variables the settings require: $env references and app properties without a value
+	PropsEnv    bool     // the required app properties are set using environment variables, with FLOGO_APP_PROPS_ENV=auto
+}
+
+// projectExposure gets the exposure of the application of the project, empty if its descriptor can't be read
+func projectExposure(project common.AppProject) *appExposure {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return &appExposure{}
+	}
+
+	return appExposureOf(appObj)
+}
+
+func appExposureOf(appObj map[string]interface{}) *appExposure {
+
+	exposure := &appExposure{}
+
+	props := make(map[string]interface{})
+	for _, prop := range getAppProperties(appObj) {
+		props[prop.Name] = prop.Value
+	}
+
+	ports := make(map[int]bool)
+	env := make(map[string]bool)
+
+	addEnv := func(settings map[string]interface{}) {
+		for _, val := range settings {
+			s, ok := val.(string)
+			if !ok {
+				continue
+			}
+			if m := propertyRefPattern.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+				if value, exists := props[m[1]]; exists && (value == nil || value == "") {
+					env[envPropName(m[1])] = true
+					exposure.PropsEnv = true
+				}
+			}
+			for _, m := range envRefPattern.FindAllStringSubmatch(s, -1) {
+				env[m[1]] = true
+			}
+		}
+	}
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		trgMap, ok := trg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		settings, _ := trgMap["settings"].(map[string]interface{})
+		addEnv(settings)
+
+		port, hasPort := triggerPort(settings["port"], props)
+		if hasPort {
+			ports[port] = true
+		}
+
+		handlers, _ := trgMap["handlers"].([]interface{})
+		for _, handler := range handlers {
+			hMap, ok := handler.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hSettings, _ := hMap["settings"].(map[string]interface{})
+			addEnv(hSettings)
+
+			method, _ := hSettings["method"].(string)
+			path, _ := hSettings["path"].(string)
+			if hasPort && exposure.HealthCheck == "" && (method == "" || strings.EqualFold(method, "GET")) && healthPathPattern.MatchString(path) {
+				exposure.HealthCheck = fmt.Sprintf("http://localhost:%d%s", port, path)
+			}
+		}
+	}
+
+	for port := range ports {
+		exposure.Ports = append(exposure.Ports, port)
+	}
+	sort.Ints(exposure.Ports)
+
+	for name := range env {
+		exposure.Env = append(exposure.Env, name)
+	}
+	sort.Strings(exposure.Env)
+
+	return exposure
+}
+
+// dockerfileInstructions gets the instructions of a Dockerfile declaring the ports and the environment of the
+// application, each followed by a new line
+func (e *appExposure) dockerfileInstructions() string {
+
+	var b strings.Builder
+	if e.PropsEnv {
+		b.WriteString("ENV FLOGO_APP_PROPS_ENV=auto\n")
+	}
+	if len(e.Env) > 0 {
+		fmt.Fprintf(&b, "# required environment variables: %s\n", strings.Join(e.Env, ", "))
+	}
+	for _, port := range e.Ports {
+		fmt.Fprintf(&b, "EXPOSE %d\n", port)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppExposure(t *testing.T) {
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"properties": [{"name": "port", "type": "int", "value": 9090}, {"name": "apiKey", "type": "string", "value": ""}],
+		"triggers": [
+			{
+				"id": "rest",
+				"settings": {"port": "=$property[port]"},
+				"handlers": [
+					{"settings": {"method": "POST", "path": "/health"}},
+					{"settings": {"method": "GET", "path": "/healthz"}},
+					{"settings": {"method": "GET", "path": "/orders", "key": "=$property[apiKey]"}}
+				]
+			},
+			{"id": "grpc", "settings": {"port": 8080, "token": "=$env[GRPC_TOKEN]"}},
+			{"id": "timer", "handlers": [{"settings": {"repeatInterval": "1m"}}]}
+		]
+	}`), &appObj)
+	assert.Nil(t, err)
+
+	exposure := appExposureOf(appObj)
+	assert.Equal(t, []int{8080, 9090}, exposure.Ports)
+	assert.Equal(t, "http://localhost:9090/healthz", exposure.HealthCheck)
+	assert.Equal(t, []string{"APIKEY", "GRPC_TOKEN"}, exposure.Env)
+	assert.True(t, exposure.PropsEnv)
+
+	assert.Equal(t, "ENV FLOGO_APP_PROPS_ENV=auto\n# required environment variables: APIKEY, GRPC_TOKEN\nEXPOSE 8080\nEXPOSE 9090\n", exposure.dockerfileInstructions())
+	assert.Equal(t, "", (&appExposure{}).dockerfileInstructions())
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	flowResPrefix = "flow:"
+	resURIPrefix  = "res://"
+)
+
+// the flowURI of the actions of the handlers, enabled or not, and of the app actions, see forEachHandlerFlowURI
+var flowURIPathPattern = regexp.MustCompile(`^\$\.(triggers\[\d+\]\.(handlers|disabledHandlers)\[\d+\]\.(action|actions\[\d+\])|actions\[\d+\])\.(settings|data)\.flowURI$`)
+
+// FlowVersions describes the versions of a flow resource
+type FlowVersions struct {
+	Flow     string `json:"flow"`
+	Versions []int  `json:"versions"`
+	Active   []int  `json:"active"`
+}
+
+// ListFlowVersions lists the flows of the application with their versions and the versions used by handlers
+func ListFlowVersions(project common.AppProject) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	flows := getFlowVersions(appObj)
+
+	var names []string
+	for name := range flows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fv := flows[name]
+		var versions []string
+		for _, v := range fv.Versions {
+			vStr := "v" + strconv.Itoa(v)
+			if containsInt(fv.Active, v) {
+				vStr += "*"
+			}
+			versions = append(versions, vStr)
+		}
+		fmt.Printf("%-30s %s\n", name, strings.Join(versions, " "))
+	}
+
+	return nil
+}
+
+// NewFlowVersion creates a new version of the flow, copied from the active version
+func NewFlowVersion(project common.AppProject, flow string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	flow = normalizeFlowId(flow)
+	fv, exists := getFlowVersions(appObj)[flow]
+	if !exists {
+		return fmt.Errorf("flow '%s' not found", flow)
+	}
+
+	from := fv.Versions[len(fv.Versions)-1]
+	if len(fv.Active) == 1 {
+		from = fv.Active[0]
+	}
+
+	resources, _ := appObj["resources"].([]interface{})
+	res, idx := findById(resources, flowVersionId(flow, from))
+	if res == nil {
+		return fmt.Errorf("resource '%s' not found", flowVersionId(flow, from))
+	}
+
+	newVersion := fv.Versions[len(fv.Versions)-1] + 1
+	newId := flowVersionId(flow, newVersion)
+
+	// the resource is copied as it is written in the descriptor, with the id of the new version
+	err = editAppDescriptor(project, func(text string) (string, error) {
+		raw, _, err := jsonValue(text, fmt.Sprintf("$.resources[%d]", idx))
+		if err != nil {
+			return "", err
+		}
+		newRes, err := setJSONValue(raw, "$.id", newId)
+		if err != nil {
+			return "", err
+		}
+		return appendJSONValue(text, "$.resources", json.RawMessage(newRes))
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s from v%d\n", newId, from)
+
+	return nil
+}
+
+// PromoteFlowVersion points all the handlers using the flow at the specified version
+func PromoteFlowVersion(project common.AppProject, flow string, version string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	v, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return fmt.Errorf("invalid flow version '%s'", version)
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	flow = normalizeFlowId(flow)
+	fv, exists := getFlowVersions(appObj)[flow]
+	if !exists {
+		return fmt.Errorf("flow '%s' not found", flow)
+	}
+
+	if !containsInt(fv.Versions, v) {
+		return fmt.Errorf("version v%d of flow '%s' not found", v, flow)
+	}
+
+	return switchFlowVersion(project, flow, v)
+}
+
+// RollbackFlowVersion points all the handlers using the flow at the version preceding the active one
+func RollbackFlowVersion(project common.AppProject, flow string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	flow = normalizeFlowId(flow)
+	fv, exists := getFlowVersions(appObj)[flow]
+	if !exists {
+		return fmt.Errorf("flow '%s' not found", flow)
+	}
+
+	if len(fv.Active) == 0 {
+		return fmt.Errorf("flow '%s' is not used by any handler", flow)
+	}
+
+	// if handlers point at different versions, rollback from the most recent one
+	current := fv.Active[len(fv.Active)-1]
+
+	previous := 0
+	for _, v := range fv.Versions {
+		if v < current {
+			previous = v
+		}
+	}
+
+	if previous == 0 {
+		return fmt.Errorf("no version of flow '%s' prior to v%d", flow, current)
+	}
+
+	return switchFlowVersion(project, flow, previous)
+}
+
+// switchFlowVersion points the handlers and app actions using the flow at the version, in place
+func switchFlowVersion(project common.AppProject, flow string, version int) error {
+
+	newURI := resURIPrefix + flowVersionId(flow, version)
+
+	updated := 0
+	err := editAppDescriptor(project, func(text string) (string, error) {
+		var paths []string
+		err := scanJSON(text, func(n *jsonNode) {
+			if !n.isStr || n.str == newURI || !flowURIPathPattern.MatchString(n.path) {
+				return
+			}
+			if base, _ := splitFlowVersion(strings.TrimPrefix(n.str, resURIPrefix)); base == flow {
+				paths = append(paths, n.path)
+			}
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to parse %s: %v", fileFlogoJson, err)
+		}
+
+		for _, path := range paths {
+			text, err = setJSONValue(text, path, newURI)
+			if err != nil {
+				return "", err
+			}
+		}
+		updated = len(paths)
+
+		return text, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pointed %d handler action(s) at %s\n", updated, newURI)
+
+	return nil
+}
+
+// getFlowVersions gets the versions of all the flows in the app descriptor
+func getFlowVersions(appObj map[string]interface{}) map[string]*FlowVersions {
+
+	flows := make(map[string]*FlowVersions)
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, ok := res.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := resMap["id"].(string)
+		if !strings.HasPrefix(id, flowResPrefix) {
+			continue
+		}
+
+		base, v := splitFlowVersion(id)
+		fv, exists := flows[base]
+		if !exists {
+			fv = &FlowVersions{Flow: base}
+			flows[base] = fv
+		}
+		fv.Versions = append(fv.Versions, v)
+	}
+
+	forEachHandlerFlowURI(appObj, func(settings map[string]interface{}, flowURI string) {
+		base, v := splitFlowVersion(strings.TrimPrefix(flowURI, resURIPrefix))
+		if fv, exists := flows[base]; exists && !containsInt(fv.Active, v) {
+			fv.Active = append(fv.Active, v)
+		}
+	})
+
+	for _, fv := range flows {
+		sort.Ints(fv.Versions)
+		sort.Ints(fv.Active)
+	}
+
+	return flows
+}
+
+// forEachHandlerFlowURI calls the function for the settings of every handler or app action that references a flow
+func forEachHandlerFlowURI(appObj map[string]interface{}, f func(settings map[string]interface{}, flowURI string)) {
+
+	visitAction := func(action interface{}) {
+		visitActionFlowURI(action, f)
+	}
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		trgMap, ok := trg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		handlers, _ := trgMap["handlers"].([]interface{})
+		disabledHandlers, _ := trgMap[sectionDisabledHandlers].([]interface{})
+		for _, h := range append(handlers, disabledHandlers...) {
+			hMap, ok := h.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			visitAction(hMap["action"])
+			actions, _ := hMap["actions"].([]interface{})
+			for _, action := range actions {
+				visitAction(action)
+			}
+		}
+	}
+
+	actions, _ := appObj["actions"].([]interface{})
+	for _, action := range actions {
+		visitAction(action)
+	}
+}
+
+// visitActionFlowURI calls the function for the settings of the action if it references a flow
+func visitActionFlowURI(action interface{}, f func(settings map[string]interface{}, flowURI string)) {
+
+	actionMap, ok := action.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, key := range []string{"settings", "data"} {
+		if settings, ok := actionMap[key].(map[string]interface{}); ok {
+			if flowURI, ok := settings["flowURI"].(string); ok {
+				f(settings, flowURI)
+			}
+		}
+	}
+}
+
+// splitFlowVersion splits a flow resource id into its base id and version, unversioned flows are version 1
+func splitFlowVersion(resId string) (string, int) {
+
+	if idx := strings.LastIndex(resId, "@v"); idx > 0 {
+		if v, err := strconv.Atoi(resId[idx+2:]); err == nil {
+			return resId[:idx], v
+		}
+	}
+
+	return resId, 1
+}
+
+func flowVersionId(flow string, version int) string {
+	if version == 1 {
+		return flow
+	}
+	return fmt.Sprintf("%s@v%d", flow, version)
+}
+
+func normalizeFlowId(flow string) string {
+	flow = strings.TrimPrefix(flow, resURIPrefix)
+	if !strings.HasPrefix(flow, flowResPrefix) {
+		flow = flowResPrefix + flow
+	}
+	return flow
+}
+
+func containsInt(values []int, val int) bool {
+	for _, v := range values {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
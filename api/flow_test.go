@@ -0,0 +1,123 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFlowVersion(t *testing.T) {
+
+	tests := []struct {
+		id      string
+		base    string
+		version int
+	}{
+		{"flow:orders", "flow:orders", 1},
+		{"flow:orders@v2", "flow:orders", 2},
+		{"flow:orders@v12", "flow:orders", 12},
+		{"flow:orders@vnext", "flow:orders@vnext", 1},
+		{"flow:orders@v", "flow:orders@v", 1},
+		{"flow:orders@v1@v3", "flow:orders@v1", 3},
+		{"@v2", "@v2", 1},
+	}
+
+	for _, test := range tests {
+		base, version := splitFlowVersion(test.id)
+		assert.Equal(t, test.base, base, test.id)
+		assert.Equal(t, test.version, version, test.id)
+	}
+
+	assert.Equal(t, "flow:orders", flowVersionId("flow:orders", 1))
+	assert.Equal(t, "flow:orders@v3", flowVersionId("flow:orders", 3))
+}
+
+const flowsTestDescriptor = `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "triggers": [
+    {
+      "id": "rest",
+      "ref": "#rest",
+      "handlers": [
+        {
+          "name": "orders",
+          "action": {
+            "ref": "#flow",
+            "settings": {
+              "flowURI": "res://flow:orders"
+            }
+          }
+        },
+        {
+          "name": "payments",
+          "action": {
+            "ref": "#flow",
+            "settings": {
+              "flowURI": "res://flow:payments"
+            }
+          }
+        }
+      ]
+    }
+  ],
+  "resources": [
+    {
+      "id": "flow:orders",
+      "data": {
+        "name": "orders",
+        "tasks": []
+      }
+    },
+    {
+      "id": "flow:payments",
+      "data": {
+        "name": "payments"
+      }
+    }
+  ]
+}`
+
+func TestSwitchFlowVersion(t *testing.T) {
+	t.Log("Testing the versions of the flows used by the handlers")
+
+	tempDir, err := ioutil.TempDir("", "flogo-flow")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	descriptorFile := filepath.Join(tempDir, fileFlogoJson)
+	err = ioutil.WriteFile(descriptorFile, []byte(flowsTestDescriptor), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(tempDir)
+
+	readDescriptor := func() string {
+		buf, err := ioutil.ReadFile(descriptorFile)
+		assert.Nil(t, err)
+		return string(buf)
+	}
+
+	// the new version is a copy of the resource, the order of its members is kept
+	assert.Nil(t, NewFlowVersion(project, "orders"))
+	assert.True(t, strings.HasPrefix(readDescriptor(), flowsTestDescriptor[:strings.LastIndex(flowsTestDescriptor, "\n  ]")]))
+	assert.Contains(t, readDescriptor(), "    },\n    {\n      \"id\": \"flow:orders@v2\",\n      \"data\": {\n        \"name\": \"orders\",")
+
+	assert.Nil(t, PromoteFlowVersion(project, "orders", "v2"))
+	assert.Contains(t, readDescriptor(), `"flowURI": "res://flow:orders@v2"`)
+	assert.Contains(t, readDescriptor(), `"flowURI": "res://flow:payments"`)
+	appObj, err := readAppDescriptorObj(project)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, getFlowVersions(appObj)["flow:orders"].Active)
+
+	assert.Nil(t, RollbackFlowVersion(project, "flow:orders"))
+	assert.True(t, strings.HasPrefix(readDescriptor(), flowsTestDescriptor[:strings.LastIndex(flowsTestDescriptor, "\n  ]")]))
+	assert.NotNil(t, RollbackFlowVersion(project, "orders"))
+
+	assert.NotNil(t, PromoteFlowVersion(project, "orders", "v3"))
+	assert.NotNil(t, PromoteFlowVersion(project, "orders", "latest"))
+	assert.NotNil(t, PromoteFlowVersion(project, "shipping", "v1"))
+}
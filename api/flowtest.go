@@ -0,0 +1,294 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// TestOptions are the options used to test the application
+type TestOptions struct {
+	Flows    bool   // also run the flow tests of the tests directory
+	Contribs bool   // also run the Go tests of the imported contributions
+	Run      string // only run the flow tests whose name contains it
+}
+
+// FlowTest is a declarative test of a flow, read from a JSON file of the tests directory: the flow is run against the
+// input and its output must contain the expected values
+type FlowTest struct {
+	Name     string                 `json:"name"`
+	Flow     string                 `json:"flow"`
+	Input    map[string]interface{} `json:"input"`
+	Expected map[string]interface{} `json:"expected"`
+}
+
+// FlowTestResult is the result of a flow test
+type FlowTestResult struct {
+	Test     *FlowTest
+	Failures []string // the differences with the expected output, or the error of the flow
+}
+
+// TestProject runs 'go test' on the packages of the application, then the flow tests if requested. The flows are run by
+// the application built with the debugger, without its triggers, as done by 'flogo exec'.
+func TestProject(ctx context.Context, project common.AppProject, options TestOptions) error {
+
+	args := []string{"./..."}
+	if Verbose() {
+		args = append([]string{"-v"}, args...)
+	}
+	if options.Contribs {
+		contribs, err := importedContribPackages(project)
+		if err != nil {
+			return err
+		}
+		args = append(args, contribs...)
+	}
+
+	fmt.Println("Running go tests...")
+	goErr := project.DepManager().Test(ctx, args...)
+
+	if !options.Flows {
+		if goErr != nil {
+			return fmt.Errorf("go tests failed")
+		}
+		return nil
+	}
+
+	tests, err := readFlowTests(project, options.Run)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	results, err := runFlowTests(ctx, project, tests)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if len(result.Failures) == 0 {
+			fmt.Printf("--- PASS: %s (%s)\n", result.Test.Name, result.Test.Flow)
+			continue
+		}
+		failed++
+		fmt.Printf("--- FAIL: %s (%s)\n", result.Test.Name, result.Test.Flow)
+		for _, failure := range result.Failures {
+			fmt.Printf("    %s\n", failure)
+		}
+	}
+	fmt.Printf("%d flow test(s), %d failed\n", len(results), failed)
+
+	switch {
+	case goErr != nil && failed > 0:
+		return fmt.Errorf("go tests and %d flow test(s) failed", failed)
+	case goErr != nil:
+		return fmt.Errorf("go tests failed")
+	case failed > 0:
+		return fmt.Errorf("%d flow test(s) failed", failed)
+	}
+
+	return nil
+}
+
+// importedContribPackages gets the import paths of the contributions imported by the application
+func importedContribPackages(project common.AppProject) ([]string, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := util.ParseImports(descriptorImports(appObj))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []string
+	for _, imp := range imports {
+		pkgs = append(pkgs, imp.GoImportPath())
+	}
+	sort.Strings(pkgs)
+
+	return pkgs, nil
+}
+
+// readFlowTests reads the flow tests of the tests directory, sorted by name, the name of a test defaults to the name of
+// its file
+func readFlowTests(project common.AppProject, run string) ([]*FlowTest, error) {
+
+	files, err := filepath.Glob(filepath.Join(project.Dir(), dirTests, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []*FlowTest
+	for _, file := range files {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		test := &FlowTest{}
+		err = json.Unmarshal(buf, test)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse flow test '%s': %v", file, err)
+		}
+		if test.Flow == "" {
+			return nil, fmt.Errorf("flow test '%s' doesn't specify the flow to test", file)
+		}
+		if test.Name == "" {
+			test.Name = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+		if run != "" && !strings.Contains(test.Name, run) {
+			continue
+		}
+		tests = append(tests, test)
+	}
+
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("no flow test found in the %s directory", dirTests)
+	}
+
+	sort.SliceStable(tests, func(i, j int) bool { return tests[i].Name < tests[j].Name })
+
+	return tests, nil
+}
+
+// runFlowTests builds the application with the debugger once and runs each flow test with it
+func runFlowTests(ctx context.Context, project common.AppProject, tests []*FlowTest) ([]*FlowTestResult, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*debugSession
+	for _, test := range tests {
+		session, err := newExecSession(appObj, test.Flow)
+		if err != nil {
+			return nil, fmt.Errorf("flow test '%s': %v", test.Name, err)
+		}
+		session.Input = test.Input
+		sessions = append(sessions, session)
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-test")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	appFile, err := writeDebugAppDescriptor(tempDir, appObj)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Building application '%s' with the debugger...\n", project.Name())
+	err = BuildProject(ctx, project, common.BuildOptions{Debug: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*FlowTestResult
+	for i, test := range tests {
+		result := &FlowTestResult{Test: test}
+		results = append(results, result)
+
+		cmd, err := debugSessionCmd(project, tempDir, appFile, sessions[i])
+		if err != nil {
+			return nil, err
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err = cmd.Run()
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("flow failed: %s", lastLine(stderr.String(), err.Error())))
+			continue
+		}
+
+		var output interface{}
+		err = json.Unmarshal(stdout.Bytes(), &output)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("unable to parse the output of the flow: %v", err))
+			continue
+		}
+
+		var expected interface{} = test.Expected
+		if test.Expected == nil {
+			expected = map[string]interface{}{}
+		}
+		result.Failures = matchExpectedOutput("$", expected, output)
+	}
+
+	return results, nil
+}
+
+// matchExpectedOutput compares the output with the expected values, the objects of the output may have other keys than
+// the expected ones, returns the differences found
+func matchExpectedOutput(path string, expected, actual interface{}) []string {
+
+	expectedObj, ok := expected.(map[string]interface{})
+	if !ok {
+		if reflect.DeepEqual(expected, actual) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, outputValue(expected), outputValue(actual))}
+	}
+
+	actualObj, ok := actual.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: expected an object, got %s", path, outputValue(actual))}
+	}
+
+	var keys []string
+	for key := range expectedObj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var diffs []string
+	for _, key := range keys {
+		keyPath := path + "." + key
+		val, exists := actualObj[key]
+		if !exists {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %s, got nothing", keyPath, outputValue(expectedObj[key])))
+			continue
+		}
+		diffs = append(diffs, matchExpectedOutput(keyPath, expectedObj[key], val)...)
+	}
+
+	return diffs
+}
+
+// outputValue formats the value as JSON
+func outputValue(val interface{}) string {
+	buf, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+	return string(buf)
+}
+
+// lastLine gets the last non empty line of the output, or the default if there is none
+func lastLine(output, def string) string {
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+
+	return def
+}
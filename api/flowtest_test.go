@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFlowTests(t *testing.T) {
+	t.Log("Testing reading of the flow tests")
+
+	tempDir, err := ioutil.TempDir("", "flogo-flowtest")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(project.Dir(), dirTests), 0755))
+
+	_, err = readFlowTests(project, "")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), dirTests, "orders.json"), []byte(`{"flow": "orders", "input": {"id": 1}, "expected": {"total": 3}}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), dirTests, "billing.json"), []byte(`{"name": "billing-empty", "flow": "flow:billing"}`), 0644))
+
+	tests, err := readFlowTests(project, "")
+	assert.Nil(t, err)
+	assert.Len(t, tests, 2)
+	assert.Equal(t, "billing-empty", tests[0].Name)
+	assert.Equal(t, "orders", tests[1].Name)
+	assert.Equal(t, map[string]interface{}{"id": 1.0}, tests[1].Input)
+
+	tests, err = readFlowTests(project, "bill")
+	assert.Nil(t, err)
+	assert.Len(t, tests, 1)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), dirTests, "invalid.json"), []byte(`{"input": {}}`), 0644))
+	_, err = readFlowTests(project, "")
+	assert.NotNil(t, err)
+}
+
+func TestMatchExpectedOutput(t *testing.T) {
+	t.Log("Testing comparison of the output of a flow with the expected one")
+
+	var output, expected map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`{"total": 3, "status": "ok", "order": {"id": 1, "items": [1, 2]}}`), &output))
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"total": 3, "order": {"items": [1, 2]}}`), &expected))
+	assert.Empty(t, matchExpectedOutput("$", expected, output))
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"total": 2, "order": {"id": "1"}, "code": 200}`), &expected))
+	assert.Equal(t, []string{
+		"$.code: expected 200, got nothing",
+		`$.order.id: expected "1", got 1`,
+		"$.total: expected 2, got 3",
+	}, matchExpectedOutput("$", expected, output))
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-flogo/cli/util"
+)
+
+// gitDescriptor is an app descriptor in a git repository: <repository>#[<ref>:]<path>, ex.
+// git@github.com:org/app.git#path/to/flogo.json or https://github.com/org/app.git#v1.0.0:flogo.yaml
+type gitDescriptor struct {
+	Repo string // the URL of the repository, as cloned by git
+	Ref  string // the branch or tag, the default branch if empty
+	Path string // the path of the descriptor in the repository, flogo.json by default
+}
+
+// isGitDescriptor determines if the app descriptor is in a git repository: its URL is a git URL (git@..., ssh://,
+// git://, git+<scheme>://) or the one of a repository ending with .git
+func isGitDescriptor(spec string) bool {
+
+	repo := strings.SplitN(spec, "#", 2)[0]
+	for _, prefix := range []string{"git@", "ssh://", "git://", "git+"} {
+		if strings.HasPrefix(repo, prefix) {
+			return true
+		}
+	}
+
+	return strings.HasSuffix(repo, ".git")
+}
+
+func parseGitDescriptor(spec string) (*gitDescriptor, error) {
+
+	parts := strings.SplitN(spec, "#", 2)
+	descriptor := &gitDescriptor{Repo: strings.TrimPrefix(parts[0], "git+"), Path: fileFlogoJson}
+
+	if len(parts) == 2 && parts[1] != "" {
+		file := parts[1]
+		if i := strings.Index(file, ":"); i >= 0 {
+			descriptor.Ref, file = file[:i], file[i+1:]
+		}
+		if file != "" {
+			descriptor.Path = path.Clean(file)
+		}
+	}
+
+	if descriptor.Repo == "" {
+		return nil, fmt.Errorf("invalid git app descriptor '%s', expected <repository>#[<ref>:]<path>", spec)
+	}
+	if strings.HasPrefix(descriptor.Path, "../") || path.IsAbs(descriptor.Path) {
+		return nil, fmt.Errorf("path '%s' of the app descriptor must be relative to the repository", descriptor.Path)
+	}
+
+	return descriptor, nil
+}
+
+// fetchGitDescriptor clones the repository of the app descriptor, using the credentials of git (ssh keys, credential
+// helpers), the file of the descriptor in the clone is returned along with the function removing the clone
+func fetchGitDescriptor(spec string) (string, func(), error) {
+
+	descriptor, err := parseGitDescriptor(spec)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "flogo-git")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if descriptor.Ref != "" {
+		args = append(args, "--branch", descriptor.Ref)
+	}
+	args = append(args, descriptor.Repo, dir)
+
+	if Verbose() {
+		fmt.Printf("Cloning %s\n", descriptor.Repo)
+	}
+
+	cmd := exec.Command("git", args...)
+	// the credentials can't be prompted for, ex. in a CI system
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	err = util.ExecCmd(cmd, "")
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("unable to clone '%s': %s", descriptor.Repo, strings.TrimSpace(err.Error()))
+	}
+
+	file := filepath.Join(dir, filepath.FromSlash(descriptor.Path))
+	if !util.FileExists(file) {
+		cleanup()
+		return "", nil, fmt.Errorf("app descriptor '%s' not found in '%s'", descriptor.Path, descriptor.Repo)
+	}
+
+	return file, cleanup, nil
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGitDescriptor(t *testing.T) {
+
+	assert.True(t, isGitDescriptor("git@github.com:org/app.git#path/to/flogo.json"))
+	assert.True(t, isGitDescriptor("https://github.com/org/app.git"))
+	assert.True(t, isGitDescriptor("git+https://example.com/org/app#flogo.json"))
+	assert.False(t, isGitDescriptor("https://example.com/app.json"))
+	assert.False(t, isGitDescriptor("flogo.json"))
+
+	descriptor, err := parseGitDescriptor("git@github.com:org/app.git#path/to/flogo.json")
+	assert.Nil(t, err)
+	assert.Equal(t, &gitDescriptor{Repo: "git@github.com:org/app.git", Path: "path/to/flogo.json"}, descriptor)
+
+	descriptor, err = parseGitDescriptor("git+https://example.com/org/app#v1.0.0:flogo.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, &gitDescriptor{Repo: "https://example.com/org/app", Ref: "v1.0.0", Path: "flogo.yaml"}, descriptor)
+
+	descriptor, err = parseGitDescriptor("https://github.com/org/app.git")
+	assert.Nil(t, err)
+	assert.Equal(t, fileFlogoJson, descriptor.Path)
+
+	_, err = parseGitDescriptor("https://github.com/org/app.git#../flogo.json")
+	assert.NotNil(t, err)
+}
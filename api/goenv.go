@@ -0,0 +1,161 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	goEnvFromEnvironment = "environment"
+	goEnvFromProject     = "project"
+	goEnvFromUser        = "user"
+)
+
+// GoEnvSetting is a Go environment variable of the go commands run by the CLI, with where its value comes from
+type GoEnvSetting struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	From  string `json:"from,omitempty"` // environment, project or user
+}
+
+// SetGoEnvConfig sets a Go environment variable of the go commands run by the CLI, ex. goproxy, in the configuration of
+// the project if specified, otherwise in the configuration of the CLI shared by all projects
+func SetGoEnvConfig(project common.AppProject, key, value string) error {
+
+	name, err := goEnvName(key)
+	if err != nil {
+		return err
+	}
+	err = util.ValidateGoEnv(name, value)
+	if err != nil {
+		return err
+	}
+
+	err = updateGoEnvConfig(project, func(env map[string]string) map[string]string {
+		if env == nil {
+			env = make(map[string]string)
+		}
+		env[name] = value
+		return env
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, set := os.LookupEnv(name); set {
+		fmt.Printf("Warning: %s is set in the environment, which takes precedence over the configuration\n", name)
+	}
+
+	return nil
+}
+
+// UnsetGoEnvConfig removes a Go environment variable from the configuration of the project if specified, otherwise
+// from the configuration of the CLI
+func UnsetGoEnvConfig(project common.AppProject, key string) error {
+
+	name, err := goEnvName(key)
+	if err != nil {
+		return err
+	}
+
+	return updateGoEnvConfig(project, func(env map[string]string) map[string]string {
+		delete(env, name)
+		if len(env) == 0 {
+			return nil
+		}
+		return env
+	})
+}
+
+// GetGoEnvConfig gets the Go environment variables of the go commands run in the project, which is optional, with
+// their value: the one of the environment, of the project or of the CLI configuration
+func GetGoEnvConfig(project common.AppProject) ([]*GoEnvSetting, error) {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+	projectConfig := &util.ProjectConfig{}
+	if project != nil {
+		projectConfig, err = util.LoadProjectConfig(project.Dir())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var settings []*GoEnvSetting
+	for _, key := range util.GoEnvSettings() {
+		setting := &GoEnvSetting{Key: key, Name: util.GoEnvVar(key)}
+		if value, set := os.LookupEnv(setting.Name); set {
+			setting.Value, setting.From = value, goEnvFromEnvironment
+		} else if value, set := projectConfig.GoEnv[setting.Name]; set {
+			setting.Value, setting.From = value, goEnvFromProject
+		} else if value, set := config.GoEnv[setting.Name]; set {
+			setting.Value, setting.From = value, goEnvFromUser
+		}
+		settings = append(settings, setting)
+	}
+
+	return settings, nil
+}
+
+// ListGoEnvConfig prints the Go environment variables of the go commands run in the project, which is optional
+func ListGoEnvConfig(project common.AppProject) error {
+
+	settings, err := GetGoEnvConfig(project)
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range settings {
+		if setting.From == "" {
+			fmt.Printf("%-10s -\n", setting.Key)
+			continue
+		}
+		fmt.Printf("%-10s %s (%s)\n", setting.Key, setting.Value, setting.From)
+	}
+
+	return nil
+}
+
+// updateGoEnvConfig updates the Go environment of the configuration of the project if specified, otherwise of the CLI
+func updateGoEnvConfig(project common.AppProject, update func(env map[string]string) map[string]string) error {
+
+	if project != nil {
+		unlock, err := lockProject(project)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		config, err := util.LoadProjectConfig(project.Dir())
+		if err != nil {
+			return err
+		}
+		config.GoEnv = update(config.GoEnv)
+		return util.SaveProjectConfig(project.Dir(), config)
+	}
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+	config.GoEnv = update(config.GoEnv)
+	return util.SaveCLIConfig(config)
+}
+
+// goEnvName gets the Go environment variable of the configuration key
+func goEnvName(key string) (string, error) {
+
+	name := util.GoEnvVar(key)
+	if name == "" {
+		return "", fmt.Errorf("unknown setting '%s', expected: %s", key, strings.Join(util.GoEnvSettings(), ", "))
+	}
+
+	return name, nil
+}
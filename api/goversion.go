@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+var (
+	goVersionPattern   = regexp.MustCompile(`\bgo(\d+\.\d+(?:\.\d+)?)`)
+	goDirectivePattern = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)`)
+)
+
+// goRequirement is the minimum version of Go required by a module, from the go directive of its go.mod
+type goRequirement struct {
+	Module    string
+	Version   string
+	GoVersion string
+}
+
+// checkGoVersion fails if the installed version of Go is older than the version required by the core or a
+// contribution, which would otherwise surface as syntax or standard library errors in the middle of the compilation
+func checkGoVersion(project common.AppProject) error {
+
+	installed := installedGoVersion()
+	if installed == "" {
+		// a development version of Go can't be compared
+		return nil
+	}
+
+	conflicts := goVersionConflicts(installed, goRequirements(project.SrcDir()))
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	minimum := installed
+	var lines []string
+	for _, req := range conflicts {
+		lines = append(lines, fmt.Sprintf("  %s %s requires go %s", req.Module, req.Version, req.GoVersion))
+		if compareGoVersions(req.GoVersion, minimum) > 0 {
+			minimum = req.GoVersion
+		}
+	}
+
+	return fmt.Errorf("go %s is installed but the application requires go %s or later:\n%s\n\n"+
+		"Install go %s or later (https://golang.org/dl/), or use versions of these modules compatible with go %s using 'flogo update <module>@<version>'",
+		installed, minimum, strings.Join(lines, "\n"), minimum, installed)
+}
+
+// installedGoVersion gets the version of the go tool, ex. 1.12.5, "" if it can't be determined
+func installedGoVersion() string {
+
+	out, err := exec.Command("go", "version").Output()
+	if err != nil {
+		return ""
+	}
+
+	m := goVersionPattern.FindStringSubmatch(string(out))
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+// goRequirements gets the version of Go required by the modules of the project, the modules whose go.mod isn't in the
+// module cache are ignored
+func goRequirements(srcDir string) []*goRequirement {
+
+	modCache := filepath.Join(util.GetGoPath(), "pkg", "mod", "cache", "download")
+
+	var reqs []*goRequirement
+	for mod, ver := range goModRequirements(srcDir) {
+		buf, err := ioutil.ReadFile(filepath.Join(modCache, escapeModulePath(mod), "@v", ver+".mod"))
+		if err != nil {
+			continue
+		}
+
+		m := goDirectivePattern.FindSubmatch(buf)
+		if m == nil {
+			continue
+		}
+		reqs = append(reqs, &goRequirement{Module: mod, Version: ver, GoVersion: string(m[1])})
+	}
+
+	return reqs
+}
+
+// goVersionConflicts gets the requirements which aren't satisfied by the installed version of Go
+func goVersionConflicts(installed string, reqs []*goRequirement) []*goRequirement {
+
+	var conflicts []*goRequirement
+	for _, req := range reqs {
+		if compareGoVersions(req.GoVersion, installed) > 0 {
+			conflicts = append(conflicts, req)
+		}
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Module < conflicts[j].Module
+	})
+
+	return conflicts
+}
+
+// compareGoVersions compares two versions of Go, ex. 1.12 and 1.12.5, a missing patch release is 0
+func compareGoVersions(v1, v2 string) int {
+
+	p1, p2 := strings.Split(v1, "."), strings.Split(v2, ".")
+	for i := 0; i < 3; i++ {
+		n1, n2 := 0, 0
+		if i < len(p1) {
+			n1, _ = strconv.Atoi(p1[i])
+		}
+		if i < len(p2) {
+			n2, _ = strconv.Atoi(p2[i])
+		}
+		if n1 != n2 {
+			if n1 < n2 {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// escapeModulePath escapes the path of a module as in the module cache, the upper case letters are replaced by '!'
+// followed by the lower case letter
+func escapeModulePath(path string) string {
+
+	var b strings.Builder
+	for _, r := range path {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareGoVersions(t *testing.T) {
+
+	assert.Equal(t, 0, compareGoVersions("1.12", "1.12.0"))
+	assert.Equal(t, -1, compareGoVersions("1.12", "1.12.5"))
+	assert.Equal(t, 1, compareGoVersions("1.13", "1.12.5"))
+	assert.Equal(t, -1, compareGoVersions("1.9", "1.12"))
+	assert.Equal(t, 1, compareGoVersions("1.21.3", "1.21"))
+}
+
+func TestGoVersionConflicts(t *testing.T) {
+
+	reqs := []*goRequirement{
+		{Module: "github.com/project-flogo/core", Version: "v1.6.0", GoVersion: "1.18"},
+		{Module: "github.com/project-flogo/contrib/activity/rest", Version: "v0.10.0", GoVersion: "1.12"},
+		{Module: "go.uber.org/zap", Version: "v1.16.0", GoVersion: "1.13"},
+	}
+
+	conflicts := goVersionConflicts("1.12.5", reqs)
+	assert.Len(t, conflicts, 2)
+	assert.Equal(t, "github.com/project-flogo/core", conflicts[0].Module)
+	assert.Equal(t, "go.uber.org/zap", conflicts[1].Module)
+
+	assert.Empty(t, goVersionConflicts("1.18", reqs))
+}
+
+func TestEscapeModulePath(t *testing.T) {
+	assert.Equal(t, "github.com/!burnt!sushi/toml", escapeModulePath("github.com/BurntSushi/toml"))
+	assert.Equal(t, "github.com/project-flogo/core", escapeModulePath("github.com/project-flogo/core"))
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const ideRPCPath = "/rpc"
+
+// IDEServerInfo is written to stdout when the IDE server starts, so that the editor extension can connect to it
+type IDEServerInfo struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// IDEProjectInfo is the result of the project/info method
+type IDEProjectInfo struct {
+	Name       string `json:"name"`
+	Dir        string `json:"dir"`
+	SrcDir     string `json:"srcDir"`
+	BinDir     string `json:"binDir"`
+	Executable string `json:"executable"`
+}
+
+type ideRequest struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params"`
+}
+
+type ideMethod func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// ideParamsError is returned by a method when its params are invalid
+type ideParamsError struct {
+	err error
+}
+
+func (e *ideParamsError) Error() string {
+	return e.err.Error()
+}
+
+type ideServer struct {
+	project common.AppProject
+	token   string
+	methods map[string]ideMethod
+
+	// the methods modify the project, so they are run one at a time
+	mutex sync.Mutex
+}
+
+// ServeIDE serves a JSON-RPC API over HTTP on localhost, for use by editor extensions. A token, which must be sent
+// as a bearer token with every request, is generated and written to stdout along with the URL of the API.
+func ServeIDE(project common.AppProject, port int) error {
+
+	tokenBytes := make([]byte, 16)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return err
+	}
+
+	s := newIDEServer(project, hex.EncodeToString(tokenBytes))
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return err
+	}
+
+	info, err := json.Marshal(&IDEServerInfo{URL: fmt.Sprintf("http://%s%s", listener.Addr(), ideRPCPath), Token: s.token})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, string(info))
+
+	// the output of the methods is meant for humans, keep it out of stdout so that it only contains the server info
+	os.Stdout = os.Stderr
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(ideRPCPath, s.handle)
+
+	return http.Serve(listener, mux)
+}
+
+func newIDEServer(project common.AppProject, token string) *ideServer {
+
+	s := &ideServer{project: project, token: token}
+	s.methods = map[string]ideMethod{
+		"project/info":       s.projectInfo,
+		"project/validate":   s.validate,
+		"project/build":      s.build,
+		"project/builds":     s.builds,
+		"contributions/list": s.listContribs,
+		"imports/add":        s.addImport,
+	}
+
+	return s
+}
+
+func (s *ideServer) handle(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+s.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.writeError(w, nil, rpcErrParse, err.Error())
+		return
+	}
+
+	req := &ideRequest{}
+	err = json.Unmarshal(body, req)
+	if err != nil {
+		s.writeError(w, nil, rpcErrParse, err.Error())
+		return
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		s.writeError(w, req.ID, rpcErrInvalidRequest, "invalid request")
+		return
+	}
+
+	method, exists := s.methods[req.Method]
+	if !exists {
+		s.writeError(w, req.ID, rpcErrMethodNotFound, "method not supported: "+req.Method)
+		return
+	}
+
+	s.mutex.Lock()
+	result, err := method(r.Context(), req.Params)
+	s.mutex.Unlock()
+
+	if err != nil {
+		code := rpcErrServer
+		if _, ok := err.(*ideParamsError); ok {
+			code = rpcErrInvalidParams
+		}
+		s.writeError(w, req.ID, code, err.Error())
+		return
+	}
+
+	s.write(w, &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *ideServer) write(w http.ResponseWriter, v interface{}) {
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = w.Write(buf)
+}
+
+func (s *ideServer) writeError(w http.ResponseWriter, id *json.RawMessage, code int, message string) {
+	s.write(w, &rpcErrorResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}})
+}
+
+// decodeParams decodes the params of a method, which are optional
+func decodeParams(params json.RawMessage, v interface{}) error {
+
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+
+	err := json.Unmarshal(params, v)
+	if err != nil {
+		return &ideParamsError{err: err}
+	}
+
+	return nil
+}
+
+func (s *ideServer) projectInfo(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	return &IDEProjectInfo{Name: s.project.Name(), Dir: s.project.Dir(), SrcDir: s.project.SrcDir(),
+		BinDir: s.project.BinDir(), Executable: s.project.Executable()}, nil
+}
+
+func (s *ideServer) validate(ctx context.Context, params json.RawMessage) (interface{}, error) {
+
+	p := &struct {
+		Probe bool `json:"probe"`
+	}{}
+	err := decodeParams(params, p)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := ValidateProject(s.project, ValidateOptions{Probe: p.Probe})
+	if err != nil {
+		return nil, err
+	}
+	if issues == nil {
+		issues = []*ValidationIssue{}
+	}
+
+	return map[string]interface{}{"issues": issues, "hasErrors": HasErrors(issues)}, nil
+}
+
+func (s *ideServer) build(ctx context.Context, params json.RawMessage) (interface{}, error) {
+
+	p := &buildConfig{}
+	err := decodeParams(params, p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = BuildProject(ctx, s.project, p.options())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"executable": s.project.Executable()}, nil
+}
+
+func (s *ideServer) builds(ctx context.Context, params json.RawMessage) (interface{}, error) {
+
+	history, err := GetBuildHistory(s.project)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []*BuildRecord{}
+	}
+
+	return history, nil
+}
+
+func (s *ideServer) listContribs(ctx context.Context, params json.RawMessage) (interface{}, error) {
+
+	p := &struct {
+		Filter string `json:"filter"`
+	}{}
+	err := decodeParams(params, p)
+	if err != nil {
+		return nil, err
+	}
+
+	specs, err := getContribSpecs(s.project, p.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if specs == nil {
+		specs = []*ContribSpec{}
+	}
+
+	return specs, nil
+}
+
+func (s *ideServer) addImport(ctx context.Context, params json.RawMessage) (interface{}, error) {
+
+	p := &struct {
+		Import string `json:"import"`
+	}{}
+	err := decodeParams(params, p)
+	if err != nil {
+		return nil, err
+	}
+	if p.Import == "" {
+		return nil, &ideParamsError{err: fmt.Errorf("import not specified")}
+	}
+
+	err = InstallPackage(ctx, s.project, p.Import)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"import": p.Import}, nil
+}
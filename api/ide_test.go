@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ideCall calls the method of the IDE server, the status and the body of the response are returned
+func ideCall(s *ideServer, token, body string) (int, map[string]interface{}) {
+
+	req := httptest.NewRequest(http.MethodPost, ideRPCPath, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.handle(w, req)
+
+	var resp map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return w.Code, resp
+}
+
+func ideErrorCode(resp map[string]interface{}) int {
+	rpcErr, _ := resp["error"].(map[string]interface{})
+	code, _ := rpcErr["code"].(float64)
+	return int(code)
+}
+
+func TestIDEServerAuth(t *testing.T) {
+	t.Log("Testing the authentication of the requests to the IDE server")
+
+	s := newIDEServer(NewAppProject(os.TempDir()), "secret")
+
+	code, _ := ideCall(s, "", `{"jsonrpc": "2.0", "id": 1, "method": "project/info"}`)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = ideCall(s, "other", `{"jsonrpc": "2.0", "id": 1, "method": "project/info"}`)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	req := httptest.NewRequest(http.MethodGet, ideRPCPath, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	s.handle(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestIDEServerRequests(t *testing.T) {
+	t.Log("Testing the requests to the IDE server")
+
+	tempDir, err := ioutil.TempDir("", "flogo-ide")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	s := newIDEServer(project, "secret")
+
+	code, resp := ideCall(s, "secret", `{"jsonrpc": "2.0", "id": 1, "method": "project/info"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), resp["id"])
+	info, _ := resp["result"].(map[string]interface{})
+	assert.Equal(t, project.Name(), info["name"])
+	assert.Equal(t, project.Executable(), info["executable"])
+
+	// no builds is an empty list rather than null
+	_, resp = ideCall(s, "secret", `{"jsonrpc": "2.0", "id": "b", "method": "project/builds"}`)
+	assert.Equal(t, "b", resp["id"])
+	assert.Equal(t, []interface{}{}, resp["result"])
+
+	err = os.MkdirAll(filepath.Join(tempDir, dirProjectFlogo), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(tempDir, dirProjectFlogo, fileBuildHistory), []byte(`[{"time": "2026-01-01T10:00:00Z", "duration": "10s", "success": true}]`), 0644)
+	assert.Nil(t, err)
+	_, resp = ideCall(s, "secret", `{"jsonrpc": "2.0", "id": 2, "method": "project/builds"}`)
+	assert.Len(t, resp["result"], 1)
+}
+
+func TestIDEServerErrors(t *testing.T) {
+	t.Log("Testing the errors of the IDE server")
+
+	tempDir, err := ioutil.TempDir("", "flogo-ide")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	s := newIDEServer(NewAppProject(tempDir), "secret")
+
+	errors := map[string]int{
+		`{"jsonrpc": "2.0", "id": 1`:                                                           rpcErrParse,
+		`{"jsonrpc": "1.0", "id": 1, "method": "project/info"}`:                                rpcErrInvalidRequest,
+		`{"jsonrpc": "2.0", "id": 1}`:                                                          rpcErrInvalidRequest,
+		`{"jsonrpc": "2.0", "id": 1, "method": "project/delete"}`:                              rpcErrMethodNotFound,
+		`{"jsonrpc": "2.0", "id": 1, "method": "imports/add", "params": {}}`:                   rpcErrInvalidParams,
+		`{"jsonrpc": "2.0", "id": 1, "method": "contributions/list", "params": {"filter": 1}}`: rpcErrInvalidParams,
+		// the project has no app descriptor
+		`{"jsonrpc": "2.0", "id": 1, "method": "project/validate"}`: rpcErrServer,
+	}
+
+	for body, expected := range errors {
+		code, resp := ideCall(s, "secret", body)
+		assert.Equal(t, http.StatusOK, code, body)
+		assert.Equal(t, expected, ideErrorCode(resp), body)
+		assert.Nil(t, resp["result"], body)
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	t.Log("Testing the decoding of the params of the IDE server methods")
+
+	p := &struct {
+		Filter string `json:"filter"`
+	}{}
+
+	// the params are optional
+	assert.Nil(t, decodeParams(nil, p))
+	assert.Nil(t, decodeParams(json.RawMessage("null"), p))
+
+	assert.Nil(t, decodeParams(json.RawMessage(`{"filter": "rest"}`), p))
+	assert.Equal(t, "rest", p.Filter)
+
+	err := decodeParams(json.RawMessage(`[]`), p)
+	_, isParamsErr := err.(*ideParamsError)
+	assert.True(t, isParamsErr)
+}
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	impactContrib = "contribution"
+	impactFlow    = "flow"
+)
+
+// ImpactReport is the part of the application affected by a change of a contribution or a flow: the elements using it
+// directly, the flows running them, directly or as a subflow, and the handlers and triggers running these flows
+type ImpactReport struct {
+	Target   string   `json:"target"`
+	Kind     string   `json:"kind"`              // contribution or flow
+	Imports  []string `json:"imports,omitempty"` // the imports of the contribution, or of the contributions of a module
+	Uses     []string `json:"uses"`              // the tasks, triggers, handlers, actions and flows using the target
+	Flows    []string `json:"flows"`
+	Actions  []string `json:"actions"`
+	Handlers []string `json:"handlers"`
+	Triggers []string `json:"triggers"`
+}
+
+// ShowImpact reports the flows, handlers and triggers affected by a change of the contribution, identified by its
+// import path, its module or its alias, or of the flow, ex. to scope the tests of an upgrade
+func ShowImpact(project common.AppProject, target string, jsonFormat bool) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	report, err := analyzeImpact(appObj, target)
+	if err != nil {
+		return err
+	}
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(report, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(resp))
+		return nil
+	}
+
+	printImpact(os.Stdout, report)
+	return nil
+}
+
+// analyzeImpact computes the elements of the app descriptor affected by a change of the contribution or the flow
+func analyzeImpact(appObj map[string]interface{}, target string) (*ImpactReport, error) {
+
+	report := &ImpactReport{Target: target}
+	flows := make(map[string]bool)
+
+	resources, _ := appObj["resources"].([]interface{})
+	triggers := impactTriggers(appObj)
+	actions, _ := appObj["actions"].([]interface{})
+
+	// the contribution matches an import path, its alias or a module containing it
+	matches := func(path string) bool {
+		return path != "" && (path == target || strings.HasPrefix(path, target+"/"))
+	}
+	var aliases []string
+	for _, s := range descriptorImports(appObj) {
+		imp, err := util.ParseImport(s)
+		if err != nil {
+			continue
+		}
+		if matches(imp.GoImportPath()) || imp.CanonicalAlias() == strings.TrimPrefix(target, "#") {
+			report.Imports = appendUnique(report.Imports, imp.GoImportPath())
+			aliases = appendUnique(aliases, imp.CanonicalAlias())
+		}
+	}
+
+	// a flow is identified by its id, ex. flow:orders, or its name when no import has it as alias
+	flowId := normalizeFlowId(target)
+	isFlow := false
+	if strings.HasPrefix(target, flowResPrefix) || strings.HasPrefix(target, resURIPrefix) || len(report.Imports) == 0 {
+		for _, res := range resources {
+			resMap, _ := res.(map[string]interface{})
+			if resId, _ := resMap["id"].(string); resId == flowId {
+				isFlow = true
+			}
+		}
+	}
+
+	affectedActions := make(map[string]bool)
+	affectedHandlers := make(map[string]bool)
+	affectedTriggers := make(map[string]bool)
+
+	if isFlow {
+		report.Kind = impactFlow
+		report.Target = flowId
+		report.Imports = nil
+		flows[flowId] = true
+	} else {
+		report.Kind = impactContrib
+
+		uses := func(ref, contribType string) bool {
+			path := refImportPath(appObj, ref, contribType)
+			if matches(path) {
+				return true
+			}
+			for _, imp := range report.Imports {
+				if path == imp {
+					return true
+				}
+			}
+			return false
+		}
+
+		for _, res := range resources {
+			resMap, _ := res.(map[string]interface{})
+			resId, _ := resMap["id"].(string)
+			data, _ := resMap["data"].(map[string]interface{})
+			for _, tasksPath := range []string{"tasks", "errorHandler.tasks"} {
+				for _, task := range flowTasks(data, tasksPath) {
+					taskMap, _ := task.(map[string]interface{})
+					taskId, _ := taskMap["id"].(string)
+					activity, _ := taskMap["activity"].(map[string]interface{})
+					if ref, _ := activity["ref"].(string); uses(ref, "activity") {
+						report.Uses = append(report.Uses, resId+"/"+taskId)
+						flows[resId] = true
+					}
+				}
+			}
+			// the functions are called in the expressions of the flow
+			for _, alias := range aliases {
+				if count := countFunctionCalls(data, alias); count > 0 {
+					report.Uses = append(report.Uses, fmt.Sprintf("%s (%d expressions)", resId, count))
+					flows[resId] = true
+				}
+			}
+		}
+
+		for _, trg := range triggers {
+			if uses(trg.ref, "trigger") {
+				report.Uses = append(report.Uses, "trigger:"+trg.id)
+				affectedTriggers[trg.id] = true
+				for _, handler := range trg.handlers {
+					affectedHandlers[handler.id] = true
+				}
+			}
+			for _, handler := range trg.handlers {
+				if uses(handler.ref, "action") {
+					report.Uses = append(report.Uses, handler.id)
+					affectedHandlers[handler.id] = true
+					// the action runs the flows of the handler
+					for _, flow := range handler.flows {
+						flows[flow] = true
+					}
+				}
+			}
+		}
+
+		for _, act := range actions {
+			actMap, _ := act.(map[string]interface{})
+			actId, _ := actMap["id"].(string)
+			if ref, _ := actMap["ref"].(string); uses(ref, "action") {
+				report.Uses = append(report.Uses, "action:"+actId)
+				affectedActions[actId] = true
+				visitActionFlowURI(actMap, func(settings map[string]interface{}, flowURI string) {
+					flows[strings.TrimPrefix(flowURI, resURIPrefix)] = true
+				})
+			}
+		}
+
+		if len(report.Imports) == 0 && len(report.Uses) == 0 {
+			return nil, fmt.Errorf("no contribution or flow '%s' found in %s", target, fileFlogoJson)
+		}
+	}
+
+	// the flows calling an affected flow as a subflow are affected as well
+	callers := make(map[string][]string)
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		data, _ := resMap["data"].(map[string]interface{})
+		for _, tasksPath := range []string{"tasks", "errorHandler.tasks"} {
+			for _, task := range flowTasks(data, tasksPath) {
+				taskMap, _ := task.(map[string]interface{})
+				activity, _ := taskMap["activity"].(map[string]interface{})
+				settings, _ := activity["settings"].(map[string]interface{})
+				if flowURI, _ := settings["flowURI"].(string); flowURI != "" {
+					subflow := strings.TrimPrefix(flowURI, resURIPrefix)
+					callers[subflow] = appendUnique(callers[subflow], resId)
+				}
+			}
+		}
+	}
+	var pending []string
+	for flow := range flows {
+		pending = append(pending, flow)
+	}
+	for len(pending) > 0 {
+		flow := pending[0]
+		pending = pending[1:]
+		for _, caller := range callers[flow] {
+			if !flows[caller] {
+				flows[caller] = true
+				pending = append(pending, caller)
+			}
+		}
+	}
+
+	// the actions and handlers running an affected flow, and the triggers of these handlers
+	for _, act := range actions {
+		actMap, _ := act.(map[string]interface{})
+		actId, _ := actMap["id"].(string)
+		visitActionFlowURI(actMap, func(settings map[string]interface{}, flowURI string) {
+			if flows[strings.TrimPrefix(flowURI, resURIPrefix)] {
+				affectedActions[actId] = true
+			}
+		})
+	}
+	for _, trg := range triggers {
+		for _, handler := range trg.handlers {
+			for _, flow := range handler.flows {
+				if flows[flow] {
+					affectedHandlers[handler.id] = true
+				}
+			}
+			// a handler referencing an app action by its id
+			if handler.actionId != "" && affectedActions[handler.actionId] {
+				affectedHandlers[handler.id] = true
+			}
+			if affectedHandlers[handler.id] {
+				affectedTriggers[trg.id] = true
+			}
+		}
+	}
+
+	report.Flows = sortedKeys(flows)
+	report.Actions = sortedKeys(affectedActions)
+	report.Handlers = sortedKeys(affectedHandlers)
+	report.Triggers = sortedKeys(affectedTriggers)
+	if report.Uses == nil {
+		report.Uses = []string{}
+	}
+
+	return report, nil
+}
+
+// impactTrigger is a trigger of the application, enabled or not, with its handlers
+type impactTrigger struct {
+	id       string
+	ref      string
+	handlers []*impactHandler
+}
+
+// impactHandler is a handler with the action it runs: the ref of the action, or the id of an app action, and its flows
+type impactHandler struct {
+	id       string
+	ref      string
+	actionId string
+	flows    []string
+}
+
+// impactTriggers gets the triggers of the application, the disabled triggers and handlers included
+func impactTriggers(appObj map[string]interface{}) []*impactTrigger {
+
+	var triggers []interface{}
+	if vals, ok := appObj["triggers"].([]interface{}); ok {
+		triggers = append(triggers, vals...)
+	}
+	if vals, ok := appObj[sectionDisabledTriggers].([]interface{}); ok {
+		triggers = append(triggers, vals...)
+	}
+
+	var result []*impactTrigger
+	for _, trg := range triggers {
+		trgMap, _ := trg.(map[string]interface{})
+		it := &impactTrigger{}
+		it.id, _ = trgMap["id"].(string)
+		it.ref, _ = trgMap["ref"].(string)
+
+		var handlers []interface{}
+		if vals, ok := trgMap["handlers"].([]interface{}); ok {
+			handlers = append(handlers, vals...)
+		}
+		if vals, ok := trgMap[sectionDisabledHandlers].([]interface{}); ok {
+			handlers = append(handlers, vals...)
+		}
+		for i, handler := range handlers {
+			hMap, _ := handler.(map[string]interface{})
+			action, _ := hMap["action"].(map[string]interface{})
+			ih := &impactHandler{id: "trigger:" + it.id + "/" + handlerName(handler, i), flows: handlerFlows(handler)}
+			ih.ref, _ = action["ref"].(string)
+			ih.actionId, _ = action["id"].(string)
+			it.handlers = append(it.handlers, ih)
+		}
+
+		result = append(result, it)
+	}
+
+	return result
+}
+
+func sortedKeys(m map[string]bool) []string {
+
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func printImpact(w io.Writer, report *ImpactReport) {
+
+	fmt.Fprintln(w, util.T("impact.title", report.Kind, report.Target))
+	if len(report.Imports) > 0 {
+		fmt.Fprintln(w, "  "+util.T("impact.imports", strings.Join(report.Imports, ", ")))
+	}
+	if report.Kind == impactContrib {
+		fmt.Fprintln(w, "  "+util.T("impact.usedBy", joinOrDash(report.Uses)))
+	}
+	fmt.Fprintln(w, "  "+util.T("impact.flows", joinOrDash(report.Flows)))
+	if len(report.Actions) > 0 {
+		fmt.Fprintln(w, "  "+util.T("impact.actions", strings.Join(report.Actions, ", ")))
+	}
+	fmt.Fprintln(w, "  "+util.T("impact.handlers", joinOrDash(report.Handlers)))
+	fmt.Fprintln(w, "  "+util.T("impact.triggers", joinOrDash(report.Triggers)))
+
+	if report.Kind == impactContrib && len(report.Uses) == 0 {
+		fmt.Fprintln(w, util.T("impact.unused"))
+	}
+}
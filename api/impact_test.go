@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const impactAppJson = `{
+  "imports": ["github.com/project-flogo/flow", "github.com/project-flogo/contrib/trigger/rest", "github.com/project-flogo/contrib/trigger/timer",
+    "github.com/project-flogo/contrib/activity/rest", "github.com/project-flogo/contrib/activity/log", "github.com/project-flogo/contrib/function/string"],
+  "triggers": [
+    {"id": "api", "ref": "#rest", "handlers": [
+      {"name": "get_order", "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:orders"}}},
+      {"name": "get_status", "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:status"}}}
+    ]},
+    {"id": "nightly", "ref": "#timer", "handlers": [{"action": {"id": "billing_run"}}]}
+  ],
+  "actions": [{"id": "billing_run", "ref": "#flow", "settings": {"flowURI": "res://flow:billing"}}],
+  "resources": [
+    {"id": "flow:orders", "data": {"tasks": [
+      {"id": "billing", "activity": {"ref": "#subflow", "settings": {"flowURI": "res://flow:billing"}}}
+    ]}},
+    {"id": "flow:billing", "data": {"tasks": [
+      {"id": "invoice", "activity": {"ref": "github.com/project-flogo/contrib/activity/rest", "input": {"uri": "=string.concat($property[billing], \"/invoices\")"}}}
+    ]}},
+    {"id": "flow:status", "data": {"tasks": [{"id": "log", "activity": {"ref": "#log"}}]}}
+  ]
+}`
+
+func TestAnalyzeImpact(t *testing.T) {
+
+	var appObj map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(impactAppJson), &appObj))
+
+	report, err := analyzeImpact(appObj, "github.com/project-flogo/contrib/activity/rest")
+	assert.Nil(t, err)
+	assert.Equal(t, impactContrib, report.Kind)
+	assert.Equal(t, []string{"flow:billing/invoice"}, report.Uses)
+	assert.Equal(t, []string{"flow:billing", "flow:orders"}, report.Flows)
+	assert.Equal(t, []string{"billing_run"}, report.Actions)
+	assert.Equal(t, []string{"trigger:api/get_order", "trigger:nightly/0"}, report.Handlers)
+	assert.Equal(t, []string{"api", "nightly"}, report.Triggers)
+
+	// the alias of the rest trigger and activity
+	report, err = analyzeImpact(appObj, "rest")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"flow:billing/invoice", "trigger:api"}, report.Uses)
+	assert.Equal(t, []string{"trigger:api/get_order", "trigger:api/get_status", "trigger:nightly/0"}, report.Handlers)
+
+	report, err = analyzeImpact(appObj, "github.com/project-flogo/contrib/function/string")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"flow:billing (1 expressions)"}, report.Uses)
+	assert.Equal(t, []string{"flow:billing", "flow:orders"}, report.Flows)
+
+	// the module of the contributions
+	report, err = analyzeImpact(appObj, "github.com/project-flogo/contrib")
+	assert.Nil(t, err)
+	assert.Len(t, report.Imports, 5)
+	assert.Equal(t, []string{"flow:billing", "flow:orders", "flow:status"}, report.Flows)
+
+	report, err = analyzeImpact(appObj, "status")
+	assert.Nil(t, err)
+	assert.Equal(t, impactFlow, report.Kind)
+	assert.Equal(t, "flow:status", report.Target)
+	assert.Equal(t, []string{"flow:status"}, report.Flows)
+	assert.Equal(t, []string{"trigger:api/get_status"}, report.Handlers)
+	assert.Equal(t, []string{"api"}, report.Triggers)
+
+	report, err = analyzeImpact(appObj, "flow:billing")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"flow:billing", "flow:orders"}, report.Flows)
+	assert.Equal(t, []string{"api", "nightly"}, report.Triggers)
+
+	// the handlers of a trigger are affected by a change of the trigger
+	report, err = analyzeImpact(appObj, "timer")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"trigger:nightly"}, report.Uses)
+	assert.Equal(t, []string{"trigger:nightly/0"}, report.Handlers)
+	assert.Empty(t, report.Flows)
+
+	_, err = analyzeImpact(appObj, "missing")
+	assert.NotNil(t, err)
+}
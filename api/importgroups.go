@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	sectionImportGroups = "importGroups"
+)
+
+// projectImportGroups gets the import groups of the project, the default ones if the app descriptor doesn't
+// configure them
+func projectImportGroups(project common.AppProject) ([]util.ImportGroup, error) {
+
+	var groups []util.ImportGroup
+	_, err := readAppDescriptorValue(project, "$."+sectionImportGroups, &groups)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", sectionImportGroups, err)
+	}
+
+	for _, group := range groups {
+		if len(group.Prefixes) == 0 {
+			return nil, fmt.Errorf("invalid %s: group '%s' has no prefixes", sectionImportGroups, group.Name)
+		}
+	}
+
+	if len(groups) == 0 {
+		return util.DefaultImportGroups, nil
+	}
+
+	return groups, nil
+}
+
+// writeImportsGoFile prints imports.go with its imports grouped and sorted, and writes it atomically
+func writeImportsGoFile(project common.AppProject, fset *token.FileSet, f *ast.File) error {
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, f); err != nil {
+		return err
+	}
+
+	return writeGroupedImports(project, buf.Bytes())
+}
+
+func writeGroupedImports(project common.AppProject, src []byte) error {
+
+	groups, err := projectImportGroups(project)
+	if err != nil {
+		return err
+	}
+
+	grouped, err := util.GroupImports(src, groups)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(filepath.Join(project.SrcDir(), fileImportsGo), grouped, 0644)
+}
+
+// FormatProjectImports groups and sorts the imports of imports.go according to the import groups of the project
+func FormatProjectImports(project common.AppProject) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	src, err := ioutil.ReadFile(filepath.Join(project.SrcDir(), fileImportsGo))
+	if err != nil {
+		return err
+	}
+
+	return writeGroupedImports(project, src)
+}
@@ -1,14 +1,15 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
-	"github.com/project-flogo/core/app"
 )
 
 func ListProjectImports(project common.AppProject) error {
@@ -26,93 +27,158 @@ func ListProjectImports(project common.AppProject) error {
 	return nil
 }
 
-func SyncProjectImports(project common.AppProject) error {
+// ImportsSyncOptions are the options of the reconciliation of the Go imports and go.mod with the project imports
+type ImportsSyncOptions struct {
+	Prune bool // remove the requirements of go.mod which no import needs, using go mod tidy
+}
 
-	appImports, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), false)
+// ImportsSyncReport describes the changes made to reconcile imports.go and go.mod with the flogo.json
+type ImportsSyncReport struct {
+	Added    []string // the Go imports added to imports.go
+	Removed  []string // the stale Go imports removed from imports.go
+	Requires []string // the changes of the requirements of go.mod, ex. "<module> v1.0.0 -> removed"
+}
+
+// InSync determines if nothing had to be changed
+func (r *ImportsSyncReport) InSync() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Requires) == 0
+}
+
+func (r *ImportsSyncReport) print() {
+
+	if r.InSync() {
+		fmt.Println("Imports are in sync")
+		return
+	}
+
+	for _, imp := range r.Added {
+		fmt.Printf("Added Go import: %s\n", imp)
+	}
+	for _, imp := range r.Removed {
+		fmt.Printf("Removed Go import: %s\n", imp)
+	}
+	for _, change := range r.Requires {
+		fmt.Printf("Updated go.mod: %s\n", change)
+	}
+}
+
+// SyncProjectImports reconciles imports.go and go.mod with the imports of the flogo.json and engine.json, the source
+// of truth: the missing Go imports are installed, the stale ones removed, and the requirements of go.mod no import
+// needs are pruned if requested. The changes are reported.
+func SyncProjectImports(ctx context.Context, project common.AppProject, options ImportsSyncOptions) error {
+
+	unlock, err := lockProject(project)
 	if err != nil {
 		return err
 	}
-	appImportsMap := make(map[string]util.Import)
-	for _, imp := range appImports.GetAllImports() {
-		appImportsMap[imp.GoImportPath()] = imp
-	}
+	defer unlock()
 
-	goImports, err := project.GetGoImports(false)
+	report, err := syncProjectImports(ctx, project, options)
+	if report != nil && (err == nil || !report.InSync()) {
+		report.print()
+	}
 	if err != nil {
 		return err
 	}
-	goImportsMap := make(map[string]util.Import)
-	for _, imp := range goImports {
-		goImportsMap[imp.GoImportPath()] = imp
+
+	if !report.InSync() {
+		updateProjectLock(project)
 	}
 
-	var toAdd []util.Import
-	for goPath, imp := range appImportsMap {
-		if _, ok := goImportsMap[goPath]; !ok {
-			toAdd = append(toAdd, imp)
-			if Verbose() {
-				fmt.Println("Adding missing Go import: ", goPath)
-			}
-		}
+	return nil
+}
+
+func syncProjectImports(ctx context.Context, project common.AppProject, options ImportsSyncOptions) (*ImportsSyncReport, error) {
+
+	appImports, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), false)
+	if err != nil {
+		return nil, err
+	}
+	projectImportsMap := make(map[string]util.Import)
+	for _, imp := range appImports.GetAllImports() {
+		projectImportsMap[imp.GoImportPath()] = imp
 	}
 
 	if util.FileExists(filepath.Join(project.Dir(), fileEngineJson)) {
 		engineImports, err := util.GetEngineImports(filepath.Join(project.Dir(), fileEngineJson), project.DepManager())
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		engImportsMap := make(map[string]util.Import)
 		for _, imp := range engineImports.GetAllImports() {
-			engImportsMap[imp.GoImportPath()] = imp
+			projectImportsMap[imp.GoImportPath()] = imp
 		}
+	}
 
-		for goPath, imp := range engImportsMap {
-			if _, ok := goImportsMap[goPath]; !ok {
-				toAdd = append(toAdd, imp)
-				if Verbose() {
-					fmt.Println("Adding missing Go import: ", goPath)
-				}
-			}
+	goImports, err := project.GetGoImports(false)
+	if err != nil {
+		return nil, err
+	}
+	goImportsMap := make(map[string]util.Import)
+	for _, imp := range goImports {
+		goImportsMap[imp.GoImportPath()] = imp
+	}
+
+	report := &ImportsSyncReport{}
+	requires := goModRequirements(project.SrcDir())
+
+	var toAdd []util.Import
+	for goPath, imp := range projectImportsMap {
+		if _, ok := goImportsMap[goPath]; !ok {
+			toAdd = append(toAdd, imp)
+			report.Added = append(report.Added, goPath)
 		}
 	}
 
-	var toRemove []string
 	for goPath := range goImportsMap {
-		if _, ok := appImportsMap[goPath]; !ok {
-			toRemove = append(toRemove, goPath)
-			if Verbose() {
-				fmt.Println("Removing extraneous Go import: ", goPath)
-			}
+		if _, ok := projectImportsMap[goPath]; !ok {
+			report.Removed = append(report.Removed, goPath)
 		}
 	}
+	sort.Strings(report.Added)
+	sort.Strings(report.Removed)
 
-	err = project.RemoveImports(toRemove...)
+	err = project.RemoveImports(report.Removed...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = project.AddImports(false, false, toAdd...)
+	err = project.AddImports(ctx, false, false, toAdd...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if options.Prune {
+		err = project.DepManager().Tidy(ctx)
+		if err != nil {
+			return report, fmt.Errorf("unable to prune go.mod: %v", err)
+		}
+	}
+	report.Requires = diffModules(requires, goModRequirements(project.SrcDir()))
+
+	return report, nil
 }
 
-func ResolveProjectImports(project common.AppProject) error {
+func ResolveProjectImports(ctx context.Context, project common.AppProject) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if Verbose() {
 		fmt.Fprintln(os.Stdout, "Synchronizing project imports")
 	}
-	err := SyncProjectImports(project)
+	_, err = syncProjectImports(ctx, project, ImportsSyncOptions{})
 	if err != nil {
 		return err
 	}
 
 	if Verbose() {
-		fmt.Fprintln(os.Stdout, "Reading flogo.json")
+		fmt.Fprintln(os.Stdout, "Reading flogo.json imports")
 	}
-	appDescriptor, err := readAppDescriptor(project)
+	var imports []string
+	_, err = readAppDescriptorValue(project, "$.imports", &imports)
 	if err != nil {
 		return err
 	}
@@ -120,7 +186,7 @@ func ResolveProjectImports(project common.AppProject) error {
 	if Verbose() {
 		fmt.Fprintln(os.Stdout, "Updating flogo.json import versions")
 	}
-	err = updateDescriptorImportVersions(project, appDescriptor)
+	imports, err = updateDescriptorImportVersions(project, imports)
 	if err != nil {
 		return err
 	}
@@ -128,7 +194,7 @@ func ResolveProjectImports(project common.AppProject) error {
 	if Verbose() {
 		fmt.Fprintln(os.Stdout, "Saving updated flogo.json")
 	}
-	err = writeAppDescriptor(project, appDescriptor)
+	err = writeAppDescriptorValue(project, "$.imports", imports)
 	if err != nil {
 		return err
 	}
@@ -136,16 +202,16 @@ func ResolveProjectImports(project common.AppProject) error {
 	return nil
 }
 
-func updateDescriptorImportVersions(project common.AppProject, appDescriptor *app.Config) error {
+func updateDescriptorImportVersions(project common.AppProject, imports []string) ([]string, error) {
 
 	goModImports, err := project.DepManager().GetAllImports()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	appImports, err := util.ParseImports(appDescriptor.Imports)
+	appImports, err := util.ParseImports(imports)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var result []string
@@ -166,9 +232,7 @@ func updateDescriptorImportVersions(project common.AppProject, appDescriptor *ap
 		}
 	}
 
-	appDescriptor.Imports = result
-
-	return nil
+	return result, nil
 }
 
 
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncProjectImports(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "imports")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"name": "app", "imports": []}`), 0644))
+	imports := "package main\n\nimport (\n\t_ \"github.com/project-flogo/contrib/activity/log\"\n)\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), fileImportsGo), []byte(imports), 0644))
+
+	report, err := syncProjectImports(context.Background(), project, ImportsSyncOptions{})
+	assert.Nil(t, err)
+	assert.False(t, report.InSync())
+	assert.Empty(t, report.Added)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/log"}, report.Removed)
+
+	goImports, err := project.GetGoImports(false)
+	assert.Nil(t, err)
+	assert.Empty(t, goImports)
+
+	report, err = syncProjectImports(context.Background(), project, ImportsSyncOptions{})
+	assert.Nil(t, err)
+	assert.True(t, report.InSync())
+}
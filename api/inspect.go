@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const embeddedConfigMarker = "FLOGO_EMBEDDED_CONFIG"
+
+// EmbeddedConfig is the app descriptor embedded in an application built using --embed
+type EmbeddedConfig struct {
+	Descriptor []byte // the decompressed app descriptor
+	SHA256     string // the SHA-256 of the app descriptor recorded at build time
+	Verified   bool   // the SHA-256 of the decompressed app descriptor matches the recorded one
+}
+
+// encodeEmbeddedConfig gzip compresses and base64 encodes the app descriptor, as expected by the engine when
+// cfgCompressed is set
+func encodeEmbeddedConfig(descriptor []byte) (string, error) {
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(descriptor)
+	if err != nil {
+		return "", err
+	}
+	err = w.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decodeEmbeddedConfig decodes and decompresses the app descriptor
+func decodeEmbeddedConfig(encoded string) ([]byte, error) {
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// embeddedConfigValue gets the Go string literal embedding the compressed app descriptor and its SHA-256 between
+// markers, ex. "FLOGO_EMBEDDED_CONFIGsha256:<hash>:<compressed>FLOGO_EMBEDDED_CONFIG", along with the bounds of the
+// compressed app descriptor in the string
+func embeddedConfigValue(descriptor []byte) (string, int, int, error) {
+
+	encoded, err := encodeEmbeddedConfig(descriptor)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	hash := sha256.Sum256(descriptor)
+	prefix := embeddedConfigMarker + "sha256:" + hex.EncodeToString(hash[:]) + ":"
+
+	return strconv.Quote(prefix + encoded + embeddedConfigMarker), len(prefix), len(prefix) + len(encoded), nil
+}
+
+// InspectBinary extracts the app descriptor embedded in an application and verifies it against its recorded SHA-256
+func InspectBinary(binary string) (*EmbeddedConfig, error) {
+
+	buf, err := ioutil.ReadFile(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cli itself contains the marker, the last occurrence followed by a valid app descriptor is used
+	marker := []byte(embeddedConfigMarker + "sha256:")
+	end := len(buf)
+	for {
+		start := bytes.LastIndex(buf[:end], marker)
+		if start < 0 {
+			return nil, fmt.Errorf("no embedded app descriptor found in '%s', it wasn't built using --embed or was built using an older version of the cli", binary)
+		}
+		end = start
+
+		start += len(marker)
+		length := bytes.Index(buf[start:], []byte(embeddedConfigMarker))
+		if length < 0 {
+			continue
+		}
+
+		parts := strings.SplitN(string(buf[start:start+length]), ":", 2)
+		if len(parts) != 2 || len(parts[0]) != sha256.Size*2 {
+			continue
+		}
+		descriptor, err := decodeEmbeddedConfig(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("the app descriptor embedded in '%s' is corrupted: %v", binary, err)
+		}
+
+		hash := sha256.Sum256(descriptor)
+		return &EmbeddedConfig{Descriptor: descriptor, SHA256: parts[0], Verified: hex.EncodeToString(hash[:]) == parts[0]}, nil
+	}
+}
+
+// PrintEmbeddedConfig prints the summary of the app descriptor embedded in the application
+func PrintEmbeddedConfig(w io.Writer, binary string, config *EmbeddedConfig) {
+
+	var app struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	_ = json.Unmarshal(config.Descriptor, &app)
+
+	fmt.Fprintf(w, "%s embeds:\n", binary)
+	fmt.Fprintf(w, "  app       : %s %s\n", app.Name, app.Version)
+	fmt.Fprintf(w, "  size      : %d bytes\n", len(config.Descriptor))
+	fmt.Fprintf(w, "  sha256    : %s\n", config.SHA256)
+	if config.Verified {
+		fmt.Fprintln(w, "  integrity : verified")
+	} else {
+		fmt.Fprintln(w, "  integrity : FAILED, the app descriptor doesn't match its SHA-256")
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectBinary(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "inspect")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	descriptor := []byte(`{"name":"a","version":"1.0.0"}`)
+	value, start, end, err := embeddedConfigValue(descriptor)
+	assert.Nil(t, err)
+	value, err = strconv.Unquote(value)
+	assert.Nil(t, err)
+
+	decoded, err := decodeEmbeddedConfig(value[start:end])
+	assert.Nil(t, err)
+	assert.Equal(t, descriptor, decoded)
+
+	binary := filepath.Join(dir, "app")
+	err = ioutil.WriteFile(binary, []byte("junk FLOGO_EMBEDDED_CONFIGsha256: "+value+" junk"), 0644)
+	assert.Nil(t, err)
+
+	config, err := InspectBinary(binary)
+	assert.Nil(t, err)
+	assert.Equal(t, descriptor, config.Descriptor)
+	assert.True(t, config.Verified)
+
+	// a different SHA-256 recorded for the same app descriptor
+	hashStart := len(embeddedConfigMarker + "sha256:")
+	tampered := value[:hashStart] + strings.Repeat("0", 64) + value[hashStart+64:]
+	err = ioutil.WriteFile(binary, []byte("junk "+tampered+" junk"), 0644)
+	assert.Nil(t, err)
+
+	config, err = InspectBinary(binary)
+	assert.Nil(t, err)
+	assert.False(t, config.Verified)
+
+	err = ioutil.WriteFile(binary, []byte("junk FLOGO_EMBEDDED_CONFIG junk"), 0644)
+	assert.Nil(t, err)
+
+	_, err = InspectBinary(binary)
+	assert.NotNil(t, err)
+}
@@ -1,28 +1,56 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
 )
 
-func InstallPackage(project common.AppProject, pkg string) error {
+func InstallPackage(ctx context.Context, project common.AppProject, pkg string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	event := &common.HookEvent{Type: common.HookPreInstall, Project: project, Import: pkg}
+	err = common.DispatchHook(event)
+	if err != nil {
+		return err
+	}
+	pkg = event.Import
 
 	flogoImport, err := util.ParseImport(pkg)
 	if err != nil {
 		return err
 	}
 
-	err = project.AddImports(false, true, flogoImport)
+	contribs, err := applyWorkspaceReplaces(ctx, project)
 	if err != nil {
 		return err
 	}
+	flogoImport = installWorkspaceContrib(project, contribs, flogoImport)
+
+	before := goModRequirements(project.SrcDir())
+
+	err = project.AddImports(ctx, false, true, flogoImport)
+	if err != nil {
+		return suggestImports(err, flogoImport)
+	}
+
+	checkResolvedVersions(project.SrcDir(), before, flogoImport)
 
 	path, err := project.GetPath(flogoImport)
+	if local := workspaceContribPath(contribs, flogoImport); local != "" {
+		path, err = local, nil
+	}
 	if Verbose() {
 		fmt.Println("Installed path", path)
 	}
@@ -43,31 +71,30 @@ func InstallPackage(project common.AppProject, pkg string) error {
 			}
 		}
 
-		fmt.Printf("Installed %s: %s\n", cType, flogoImport)
+		fmt.Println(util.T("install.installed", cType, flogoImport))
+		printContribAdvisories(project, flogoImport)
+
+		err = namespaceImportAlias(project, flogoImport)
+		if err != nil {
+			return err
+		}
 		//instStr := fmt.Sprintf("Installed %s:", cType)
 		//fmt.Printf("%-20s %s\n", instStr, imp)
 	}
 
 	if legacySupportRequired {
-		err := InstallLegacySupport(project)
+		err := InstallLegacySupport(ctx, project)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
-}
-
-func InstallReplacedPackage(project common.AppProject, replacedPath string, pkg string) error {
+	updateProjectLock(project)
 
-	err := project.DepManager().InstallReplacedPkg(pkg, replacedPath)
-	if err != nil {
-		return err
-	}
-	return InstallPackage(project, pkg+"@v0.0.0")
+	return common.DispatchHook(&common.HookEvent{Type: common.HookPostInstall, Project: project, Import: pkg})
 }
 
-func InstallContribBundle(project common.AppProject, path string) error {
+func InstallContribBundle(ctx context.Context, project common.AppProject, path string) error {
 
 	file, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -82,7 +109,7 @@ func InstallContribBundle(project common.AppProject, path string) error {
 	}
 
 	for _, contrib := range contribBundleDescriptor.Contribs {
-		err := InstallPackage(project, contrib)
+		err := InstallPackage(ctx, project, contrib)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error installing contrib '%s': %s", contrib, err.Error())
 		}
@@ -90,3 +117,14 @@ func InstallContribBundle(project common.AppProject, path string) error {
 
 	return nil
 }
+
+// suggestImports adds the official contributions close to the import which failed to install, in case it is mistyped
+func suggestImports(err error, flogoImport util.Import) error {
+
+	suggestions := util.Suggestions(flogoImport.GoImportPath(), util.OfficialContribs, 3)
+	if len(suggestions) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%v\n\nDid you mean this?\n\t%s\n", strings.TrimSpace(err.Error()), strings.Join(suggestions, "\n\t"))
+}
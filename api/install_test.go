@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -107,11 +108,11 @@ func TestInstallLegacyPkg(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	_ = os.Chdir(testEnv.currentDir)
 
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "v0.9.2")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "v0.9.2")
 
 	assert.Nil(t, err)
 
-	err = InstallPackage(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/TIBCOSoftware/flogo-contrib/activity/log")
+	err = InstallPackage(context.Background(), NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/TIBCOSoftware/flogo-contrib/activity/log")
 	assert.Nil(t, err)
 
 	appProject := NewAppProject(filepath.Join(testEnv.currentDir, "myApp"))
@@ -121,7 +122,7 @@ func TestInstallLegacyPkg(t *testing.T) {
 
 	common.SetCurrentProject(appProject)
 
-	err = BuildProject(common.CurrentProject(), common.BuildOptions{})
+	err = BuildProject(context.Background(), common.CurrentProject(), common.BuildOptions{})
 	assert.Nil(t, err)
 
 }
@@ -138,11 +139,11 @@ func TestInstallPkg(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	_ = os.Chdir(testEnv.currentDir)
 
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "")
 
 	assert.Nil(t, err)
 
-	err = InstallPackage(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/project-flogo/contrib/activity/noop")
+	err = InstallPackage(context.Background(), NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/project-flogo/contrib/activity/noop")
 	assert.Nil(t, err)
 
 	appProject := NewAppProject(filepath.Join(testEnv.currentDir, "myApp"))
@@ -152,7 +153,7 @@ func TestInstallPkg(t *testing.T) {
 
 	common.SetCurrentProject(appProject)
 
-	err = BuildProject(common.CurrentProject(), common.BuildOptions{})
+	err = BuildProject(context.Background(), common.CurrentProject(), common.BuildOptions{})
 	assert.Nil(t, err)
 }
 
@@ -168,11 +169,11 @@ func TestInstallPkgWithVersion(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	_ = os.Chdir(testEnv.currentDir)
 
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "")
 
 	assert.Nil(t, err)
 
-	err = InstallPackage(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/project-flogo/contrib/activity/log@v0.9.0")
+	err = InstallPackage(context.Background(), NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), "github.com/project-flogo/contrib/activity/log@v0.9.0")
 	assert.Nil(t, err)
 
 	appProject := NewAppProject(filepath.Join(testEnv.currentDir, "myApp"))
@@ -182,7 +183,7 @@ func TestInstallPkgWithVersion(t *testing.T) {
 
 	common.SetCurrentProject(appProject)
 
-	err = BuildProject(common.CurrentProject(), common.BuildOptions{})
+	err = BuildProject(context.Background(), common.CurrentProject(), common.BuildOptions{})
 	assert.Nil(t, err)
 }
 
@@ -198,11 +199,11 @@ func TestListPkg(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	_ = os.Chdir(testEnv.currentDir)
 
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "")
 
 	assert.Equal(t, nil, err)
 
-	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), true, "")
+	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), ListFormatJson, "")
 	assert.Equal(t, nil, err)
 
 }
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const jsonIndent = "  "
+
+// jsonValue gets the raw value located at the path of the JSON document, ok is false if the document doesn't contain it
+func jsonValue(text, path string) (raw string, ok bool, err error) {
+
+	node, _, _, err := findJSONNode(text, path)
+	if err != nil || node == nil {
+		return "", false, err
+	}
+
+	return text[node.start:node.end], true, nil
+}
+
+// setJSONValue replaces the value located at the path of the JSON document with the JSON encoding of value, the rest
+// of the document is left untouched so the cost of the edit is proportional to the value rather than the document.
+// The member is added if it is missing from an existing object.
+func setJSONValue(text, path string, value interface{}) (string, error) {
+
+	node, parent, lastMember, err := findJSONNode(text, path)
+	if err != nil {
+		return "", err
+	}
+
+	if node != nil {
+		encoded, err := json.MarshalIndent(value, lineIndent(text, node.start), jsonIndent)
+		if err != nil {
+			return "", err
+		}
+		return text[:node.start] + string(encoded) + text[node.end:], nil
+	}
+
+	parentPath := parentJSONPath(path)
+	name, isMember := jsonPathKey(path)
+	if parent == nil || text[parent.start] != '{' || !isMember {
+		return "", fmt.Errorf("unable to set '%s', '%s' isn't an object of the document", path, parentPath)
+	}
+
+	indent := lineIndent(text, parent.start) + jsonIndent
+	key, _ := json.Marshal(name)
+	encoded, err := json.MarshalIndent(value, indent, jsonIndent)
+	if err != nil {
+		return "", err
+	}
+	member := "\n" + indent + string(key) + ": " + string(encoded)
+
+	if lastMember == nil {
+		// the object is empty
+		return text[:parent.start+1] + member + "\n" + lineIndent(text, parent.start) + text[parent.end-1:], nil
+	}
+
+	return text[:lastMember.end] + "," + member + text[lastMember.end:], nil
+}
+
+// removeJSONValue removes the member of an object or the element of an array located at the path of the JSON document,
+// along with its separator, the rest of the document is left untouched
+func removeJSONValue(text, path string) (string, error) {
+
+	node, parent, siblings, err := findJSONSiblings(text, path)
+	if err != nil {
+		return "", err
+	}
+	if node == nil {
+		return "", fmt.Errorf("unable to remove '%s', the document doesn't contain it", path)
+	}
+
+	idx := 0
+	for siblings[idx] != node {
+		idx++
+	}
+
+	switch {
+	case idx > 0:
+		// the separator preceding the value
+		return text[:siblings[idx-1].end] + text[node.end:], nil
+	case len(siblings) > 1:
+		// the first value, up to the key or the start of the next one
+		start := skipJSONSpace(text, parent.start+1)
+		next := skipJSONSpace(text, node.end)
+		next = skipJSONSpace(text, next+1)
+		return text[:start] + text[next:], nil
+	default:
+		return text[:parent.start+1] + text[parent.end-1:parent.end] + text[parent.end:], nil
+	}
+}
+
+// appendJSONValue appends the JSON encoding of value to the array located at the path of the JSON document, the other
+// elements of the array are left untouched. A json.RawMessage keeps the order of the members of the value.
+func appendJSONValue(text, path string, value interface{}) (string, error) {
+
+	node, _, elements, err := findJSONSiblings(text, path+"[0]")
+	if err != nil {
+		return "", err
+	}
+	if node == nil {
+		// the array is empty
+		array, ok, err := jsonValue(text, path)
+		if err != nil {
+			return "", err
+		}
+		if !ok || !strings.HasPrefix(array, "[") {
+			return "", fmt.Errorf("unable to append to '%s', it isn't an array of the document", path)
+		}
+		return setJSONValue(text, path, []interface{}{value})
+	}
+
+	last := elements[len(elements)-1]
+	indent := lineIndent(text, last.start)
+	encoded, err := json.MarshalIndent(value, indent, jsonIndent)
+	if err != nil {
+		return "", err
+	}
+
+	return text[:last.end] + ",\n" + indent + string(encoded) + text[last.end:], nil
+}
+
+// moveJSONValue moves the element at the index of the array located at the from path to the end of the array located
+// at the to path, which is added if missing. The from array is removed once empty if removeEmpty is set.
+func moveJSONValue(text, from, to string, idx int, removeEmpty bool) (string, error) {
+
+	elementPath := fmt.Sprintf("%s[%d]", from, idx)
+	raw, ok, err := jsonValue(text, elementPath)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("unable to move '%s', the document doesn't contain it", elementPath)
+	}
+
+	_, _, elements, err := findJSONSiblings(text, elementPath)
+	if err != nil {
+		return "", err
+	}
+	if len(elements) == 1 && removeEmpty {
+		text, err = removeJSONValue(text, from)
+	} else {
+		text, err = removeJSONValue(text, elementPath)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if _, exists, err := jsonValue(text, to); err != nil || exists {
+		if err != nil {
+			return "", err
+		}
+		return appendJSONValue(text, to, json.RawMessage(raw))
+	}
+
+	return setJSONValue(text, to, []json.RawMessage{json.RawMessage(raw)})
+}
+
+// findJSONSiblings finds the value located at the path, its parent and the values of the parent, in order
+func findJSONSiblings(text, path string) (node, parent *jsonNode, siblings []*jsonNode, err error) {
+
+	parentPath := parentJSONPath(path)
+
+	err = scanJSON(text, func(n *jsonNode) {
+		switch {
+		case n.path == parentPath:
+			parent = n
+		case strings.HasPrefix(n.path, parentPath) && len(n.path) > len(parentPath) && parentJSONPath(n.path) == parentPath:
+			if n.path == path {
+				node = n
+			}
+			siblings = append(siblings, n)
+		}
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to parse %s: %v", fileFlogoJson, err)
+	}
+	sort.Slice(siblings, func(i, j int) bool { return siblings[i].start < siblings[j].start })
+
+	return node, parent, siblings, nil
+}
+
+func skipJSONSpace(text string, offset int) int {
+	for offset < len(text) && strings.ContainsRune(" \t\r\n", rune(text[offset])) {
+		offset++
+	}
+	return offset
+}
+
+// findJSONNode finds the value located at the path, its parent and the last member of the parent if it is an object
+func findJSONNode(text, path string) (node, parent, lastMember *jsonNode, err error) {
+
+	parentPath := parentJSONPath(path)
+
+	err = scanJSON(text, func(n *jsonNode) {
+		switch {
+		case n.path == path:
+			node = n
+		case n.path == parentPath:
+			parent = n
+		case strings.HasPrefix(n.path, parentPath) && len(n.path) > len(parentPath) && parentJSONPath(n.path) == parentPath:
+			if lastMember == nil || n.end > lastMember.end {
+				lastMember = n
+			}
+		}
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to parse %s: %v", fileFlogoJson, err)
+	}
+
+	return node, parent, lastMember, nil
+}
+
+// lineIndent gets the indentation of the line containing the offset
+func lineIndent(text string, offset int) string {
+
+	lineStart := strings.LastIndex(text[:offset], "\n") + 1
+	end := lineStart
+	for end < len(text) && (text[end] == ' ' || text[end] == '\t') {
+		end++
+	}
+
+	return text[lineStart:end]
+}
+
+// readAppDescriptorValue unmarshals the value located at the path of the app descriptor into v, without unmarshalling
+// the rest of the descriptor, ok is false if the descriptor doesn't contain it
+func readAppDescriptorValue(project common.AppProject, path string, v interface{}) (ok bool, err error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return false, err
+	}
+
+	raw, ok, err := jsonValue(string(buf), path)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return true, json.Unmarshal([]byte(raw), v)
+}
+
+// writeAppDescriptorValue sets the value located at the path of the app descriptor, leaving the rest of it untouched
+func writeAppDescriptorValue(project common.AppProject, path string, value interface{}) error {
+	return editAppDescriptor(project, func(text string) (string, error) {
+		return setJSONValue(text, path, value)
+	})
+}
+
+// editAppDescriptor applies the edits of the function to the text of the app descriptor and writes it, the edits are
+// expected to leave the parts of the descriptor they don't change untouched
+func editAppDescriptor(project common.AppProject, edit func(text string) (string, error)) error {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return err
+	}
+
+	updated, err := edit(string(buf))
+	if err != nil {
+		return err
+	}
+
+	return writeAppDescriptorFile(project, []byte(updated))
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetJSONValue(t *testing.T) {
+	t.Log("Testing path-targeted edition of a JSON document")
+
+	text := `{
+  "name": "app",
+  "imports": [
+    "github.com/project-flogo/contrib/activity/log"
+  ],
+  "properties": {},
+  "resources": [{"id": "flow:a"}]
+}`
+
+	updated, err := setJSONValue(text, "$.imports", []string{"github.com/project-flogo/contrib/activity/log", "github.com/project-flogo/flow"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "name": "app",
+  "imports": [
+    "github.com/project-flogo/contrib/activity/log",
+    "github.com/project-flogo/flow"
+  ],
+  "properties": {},
+  "resources": [{"id": "flow:a"}]
+}`, updated)
+
+	raw, ok, err := jsonValue(updated, "$.resources[0].id")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `"flow:a"`, raw)
+
+	// members are added to existing objects
+	updated, err = setJSONValue(text, "$.properties.port", 8080)
+	assert.Nil(t, err)
+	assert.Contains(t, updated, "\"properties\": {\n    \"port\": 8080\n  },")
+
+	updated, err = setJSONValue(text, "$.version", "1.0.0")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, "\"resources\": [{\"id\": \"flow:a\"}],\n  \"version\": \"1.0.0\"\n}")
+
+	_, err = setJSONValue(text, "$.missing.version", "1.0.0")
+	assert.NotNil(t, err)
+
+	_, ok, err = jsonValue(text, "$.version")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestSetJSONValueQuotedKeys(t *testing.T) {
+	t.Log("Testing edition of the members whose key isn't a plain name")
+
+	text := `{
+  "properties": {"a": {"b": 1}, "a.b": 2}
+}`
+
+	updated, err := setJSONValue(text, jsonMemberPath("$.properties", "a.b"), 3)
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"properties": {"a": {"b": 1}, "a.b": 3}`)
+
+	updated, err = setJSONValue(text, jsonMemberPath("$.properties", "x[0]"), 4)
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"a.b": 2,`+"\n    \"x[0]\": 4}")
+
+	raw, ok, err := jsonValue(updated, `$.properties["x[0]"]`)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "4", raw)
+
+	updated, err = removeJSONValue(text, `$.properties["a.b"]`)
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"properties": {"a": {"b": 1}}`)
+
+	// the nested member isn't mistaken for the quoted one
+	updated, err = removeJSONValue(text, "$.properties.a.b")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"properties": {"a": {}, "a.b": 2}`)
+}
+
+func TestRemoveJSONValue(t *testing.T) {
+	t.Log("Testing path-targeted removal of the values of a JSON document")
+
+	text := `{
+  "name": "app",
+  "imports": ["a", "b", "c"],
+  "properties": {"port": 8080}
+}`
+
+	updated, err := removeJSONValue(text, "$.imports[1]")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"imports": ["a", "c"],`)
+
+	updated, err = removeJSONValue(text, "$.imports[0]")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"imports": ["b", "c"],`)
+
+	updated, err = removeJSONValue(text, "$.name")
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"imports\"", updated[:13])
+
+	updated, err = removeJSONValue(text, "$.properties")
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"name\": \"app\",\n  \"imports\": [\"a\", \"b\", \"c\"]\n}", updated)
+
+	updated, err = removeJSONValue(text, "$.properties.port")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, `"properties": {}`)
+
+	_, err = removeJSONValue(text, "$.version")
+	assert.NotNil(t, err)
+}
+
+func TestAppendJSONValue(t *testing.T) {
+	t.Log("Testing appending to the arrays of a JSON document")
+
+	text := `{
+  "triggers": [
+    {"id": "a"}
+  ],
+  "resources": []
+}`
+
+	updated, err := appendJSONValue(text, "$.triggers", json.RawMessage(`{"id": "b", "ref": "#rest"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "triggers": [
+    {"id": "a"},
+    {
+      "id": "b",
+      "ref": "#rest"
+    }
+  ],
+  "resources": []
+}`, updated)
+
+	updated, err = appendJSONValue(text, "$.resources", "flow")
+	assert.Nil(t, err)
+	assert.Contains(t, updated, "\"resources\": [\n    \"flow\"\n  ]")
+
+	_, err = appendJSONValue(text, "$.triggers[0]", "b")
+	assert.NotNil(t, err)
+
+	// the element is moved with the order of its members
+	updated, err = moveJSONValue(text, "$.triggers", "$.disabledTriggers", 0, true)
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "resources": [],
+  "disabledTriggers": [
+    {
+      "id": "a"
+    }
+  ]
+}`, updated)
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonNode is a value located in a JSON document
+type jsonNode struct {
+	path  string // path of the value, ex. $.triggers[0].ref or $.properties["a.b"]
+	start int    // offset of the first byte of the value
+	end   int    // offset following the last byte of the value
+	str   string // the value, if it is a string
+	isStr bool
+}
+
+// jsonScanError is a syntax error in a JSON document, it records the state of the scanner where it occurred
+type jsonScanError struct {
+	offset   int
+	msg      string
+	path     string // path of the value, or object if in a key, being scanned
+	inString bool   // the document ends in a string value
+	inKey    bool   // the document ends in a key
+	strStart int    // offset following the opening quote of the unterminated string
+}
+
+func (e *jsonScanError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.msg, e.offset)
+}
+
+type jsonScanner struct {
+	text  string
+	pos   int
+	visit func(n *jsonNode)
+}
+
+// scanJSON scans a JSON document, calling visit for every value it contains. Scanning stops at the first
+// syntax error, which is returned as a *jsonScanError.
+func scanJSON(text string, visit func(n *jsonNode)) error {
+
+	s := &jsonScanner{text: text, visit: visit}
+
+	err := s.value("$")
+	if err != nil {
+		return err
+	}
+
+	s.skipSpace()
+	if s.pos < len(s.text) {
+		return s.errorf("$", "unexpected content after the document")
+	}
+
+	return nil
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.text) {
+		switch s.text[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonScanner) errorf(path, format string, args ...interface{}) *jsonScanError {
+	return &jsonScanError{offset: s.pos, msg: fmt.Sprintf(format, args...), path: path}
+}
+
+func (s *jsonScanner) value(path string) error {
+
+	s.skipSpace()
+	if s.pos >= len(s.text) {
+		return s.errorf(path, "unexpected end of document")
+	}
+
+	node := &jsonNode{path: path, start: s.pos}
+
+	var err error
+	switch s.text[s.pos] {
+	case '{':
+		err = s.object(path)
+	case '[':
+		err = s.array(path)
+	case '"':
+		node.str, err = s.string(path, false)
+		node.isStr = true
+	default:
+		err = s.literal(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	node.end = s.pos
+	s.visit(node)
+
+	return nil
+}
+
+func (s *jsonScanner) object(path string) error {
+
+	s.pos++
+	s.skipSpace()
+	if s.pos < len(s.text) && s.text[s.pos] == '}' {
+		s.pos++
+		return nil
+	}
+
+	for {
+		s.skipSpace()
+		if s.pos >= len(s.text) || s.text[s.pos] != '"' {
+			return s.errorf(path, "expected key")
+		}
+
+		key, err := s.string(path, true)
+		if err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.pos >= len(s.text) || s.text[s.pos] != ':' {
+			return s.errorf(path, "expected ':' after key '%s'", key)
+		}
+		s.pos++
+
+		err = s.value(jsonMemberPath(path, key))
+		if err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.pos >= len(s.text) {
+			return s.errorf(path, "expected ',' or '}'")
+		}
+
+		switch s.text[s.pos] {
+		case ',':
+			s.pos++
+		case '}':
+			s.pos++
+			return nil
+		default:
+			return s.errorf(path, "expected ',' or '}'")
+		}
+	}
+}
+
+func (s *jsonScanner) array(path string) error {
+
+	s.pos++
+	s.skipSpace()
+	if s.pos < len(s.text) && s.text[s.pos] == ']' {
+		s.pos++
+		return nil
+	}
+
+	for i := 0; ; i++ {
+		err := s.value(fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return err
+		}
+
+		s.skipSpace()
+		if s.pos >= len(s.text) {
+			return s.errorf(path, "expected ',' or ']'")
+		}
+
+		switch s.text[s.pos] {
+		case ',':
+			s.pos++
+		case ']':
+			s.pos++
+			return nil
+		default:
+			return s.errorf(path, "expected ',' or ']'")
+		}
+	}
+}
+
+func (s *jsonScanner) string(path string, isKey bool) (string, error) {
+
+	quote := s.pos
+	s.pos++
+
+	for s.pos < len(s.text) {
+		switch s.text[s.pos] {
+		case '"':
+			s.pos++
+			var str string
+			err := json.Unmarshal([]byte(s.text[quote:s.pos]), &str)
+			if err != nil {
+				return "", &jsonScanError{offset: quote, msg: "invalid string", path: path}
+			}
+			return str, nil
+		case '\\':
+			s.pos += 2
+			continue
+		case '\n':
+			return "", &jsonScanError{offset: s.pos, msg: "unterminated string", path: path}
+		}
+		s.pos++
+	}
+
+	if s.pos > len(s.text) {
+		s.pos = len(s.text)
+	}
+
+	return "", &jsonScanError{offset: s.pos, msg: "unterminated string", path: path, inString: !isKey, inKey: isKey, strStart: quote + 1}
+}
+
+func (s *jsonScanner) literal(path string) error {
+
+	start := s.pos
+	for s.pos < len(s.text) && !strings.ContainsRune(",]} \t\r\n", rune(s.text[s.pos])) {
+		s.pos++
+	}
+
+	lit := s.text[start:s.pos]
+	if lit == "" || !json.Valid([]byte(lit)) {
+		s.pos = start
+		return s.errorf(path, "invalid value '%s'", lit)
+	}
+
+	return nil
+}
+
+// jsonMemberPath gets the path of the member of the object at path, the key is quoted if it isn't a plain name
+func jsonMemberPath(path, key string) string {
+
+	if key == "" || strings.ContainsAny(key, `.[]"\`) {
+		quoted, _ := json.Marshal(key)
+		return path + "[" + string(quoted) + "]"
+	}
+
+	return path + "." + key
+}
+
+// parentJSONPath gets the path of the value containing the value at path, "" for the document
+func parentJSONPath(path string) string {
+
+	idx := -1
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '.':
+			idx = i
+		case '[':
+			idx = i
+			if i+1 < len(path) && path[i+1] == '"' {
+				// skip the quoted key, which may contain any character
+				for i += 2; i < len(path) && path[i] != '"'; i++ {
+					if path[i] == '\\' {
+						i++
+					}
+				}
+			}
+		}
+	}
+	if idx <= 0 {
+		return ""
+	}
+
+	return path[:idx]
+}
+
+// jsonPathKey gets the key of the member at path, ok is false if the value at path isn't a member of an object
+func jsonPathKey(path string) (key string, ok bool) {
+
+	last := path[len(parentJSONPath(path)):]
+	switch {
+	case strings.HasPrefix(last, "."):
+		return last[1:], true
+	case strings.HasPrefix(last, `["`) && strings.HasSuffix(last, `"]`):
+		err := json.Unmarshal([]byte(last[1:len(last)-1]), &key)
+		return key, err == nil
+	}
+
+	return "", false
+}
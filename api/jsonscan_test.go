@@ -0,0 +1,80 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanJSON(t *testing.T) {
+	t.Log("Testing location of values in a JSON document")
+
+	text := `{"name": "app", "triggers": [{"id": "t1", "ref": "#rest", "port": 8080}]}`
+
+	nodes := make(map[string]*jsonNode)
+	err := scanJSON(text, func(n *jsonNode) {
+		nodes[n.path] = n
+	})
+	assert.Nil(t, err)
+
+	ref := nodes["$.triggers[0].ref"]
+	assert.NotNil(t, ref)
+	assert.True(t, ref.isStr)
+	assert.Equal(t, "#rest", ref.str)
+	assert.Equal(t, `"#rest"`, text[ref.start:ref.end])
+
+	port := nodes["$.triggers[0].port"]
+	assert.NotNil(t, port)
+	assert.Equal(t, "8080", text[port.start:port.end])
+
+	assert.Equal(t, "$.triggers[0]", parentJSONPath("$.triggers[0].port"))
+	assert.Equal(t, "$.triggers", parentJSONPath("$.triggers[0]"))
+}
+
+func TestScanJSONQuotedKeys(t *testing.T) {
+	t.Log("Testing paths of the members whose key isn't a plain name")
+
+	text := `{"properties": {"a.b": 1, "a": {"b": 2}, "x[0]": 3, "q\"]": 4, "": 5}}`
+
+	nodes := make(map[string]string)
+	err := scanJSON(text, func(n *jsonNode) {
+		nodes[n.path] = text[n.start:n.end]
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "1", nodes[`$.properties["a.b"]`])
+	assert.Equal(t, "2", nodes["$.properties.a.b"])
+	assert.Equal(t, "3", nodes[`$.properties["x[0]"]`])
+	assert.Equal(t, "4", nodes[`$.properties["q\"]"]`])
+	assert.Equal(t, "5", nodes[`$.properties[""]`])
+
+	assert.Equal(t, "$.properties", parentJSONPath(`$.properties["a.b"]`))
+	assert.Equal(t, "$.properties", parentJSONPath(`$.properties["q\"]"]`))
+	assert.Equal(t, `$.properties["a.b"]`, parentJSONPath(`$.properties["a.b"].ref`))
+	assert.Equal(t, "$.properties.a", parentJSONPath("$.properties.a.b"))
+
+	key, ok := jsonPathKey(`$.properties["q\"]"]`)
+	assert.True(t, ok)
+	assert.Equal(t, `q"]`, key)
+	key, ok = jsonPathKey("$.properties.a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+	_, ok = jsonPathKey("$.imports[0]")
+	assert.False(t, ok)
+}
+
+func TestScanJSONIncomplete(t *testing.T) {
+	t.Log("Testing scanner state at the end of an incomplete JSON document")
+
+	text := `{"triggers": [{"ref": "github.com/pro`
+
+	err := scanJSON(text, func(n *jsonNode) {})
+	scanErr, ok := err.(*jsonScanError)
+	assert.True(t, ok)
+	assert.True(t, scanErr.inString)
+	assert.Equal(t, "$.triggers[0].ref", scanErr.path)
+	assert.Equal(t, "github.com/pro", text[scanErr.strStart:])
+
+	err = scanJSON(`{"a": tru}`, func(n *jsonNode) {})
+	assert.NotNil(t, err)
+}
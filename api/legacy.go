@@ -2,6 +2,8 @@ package api
 
 //Legacy Helper Functions
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -18,13 +20,19 @@ const (
 	pkgLegacySupport = "github.com/project-flogo/legacybridge"
 )
 
-func InstallLegacySupport(project common.AppProject) error {
+func InstallLegacySupport(ctx context.Context, project common.AppProject) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	//todo make sure we only install once
 	pkgLegacySupportImport, err := util.NewFlogoImportFromPath(pkgLegacySupport)
 	if err != nil {
 		return err
 	}
-	err = project.AddImports(false, true, pkgLegacySupportImport)
+	err = project.AddImports(ctx, false, true, pkgLegacySupportImport)
 	if err == nil {
 		fmt.Println("Installed Legacy Support")
 	}
@@ -79,14 +87,10 @@ func CreateLegacyMetadata(path, contribType, contribPkg string) error {
 	}
 	defer os.Chmod(path, 0555)
 
-	f, err := os.Create(mdGoFilePath)
-	if err != nil {
-		return err
-	}
-	RenderTemplate(f, tplMetadata, info)
-	f.Close()
+	var buf bytes.Buffer
+	RenderTemplate(&buf, tplMetadata, info)
 
-	return nil
+	return util.WriteFileAtomic(mdGoFilePath, buf.Bytes(), 0644)
 }
 
 var tplActivityMetadataGoFile = `package {{.Package}}
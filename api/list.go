@@ -3,74 +3,144 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
 )
 
+const (
+	ListFormatText  = "text"
+	ListFormatJson  = "json"
+	ListFormatYaml  = "yaml"
+	ListFormatTable = "table"
+)
+
 type ListFilter int
 
-func ListContribs(project common.AppProject, jsonFormat bool, filter string) error {
+// ListContribs lists the contributions installed in the project in the format: text, json, yaml or table
+func ListContribs(project common.AppProject, format string, filter string) error {
 
-	ai, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), true)
+	err := checkListFormat(format)
 	if err != nil {
 		return err
 	}
 
-	var specs []*ContribSpec
+	specs, err := getContribSpecs(project, filter)
+	if err != nil {
+		return err
+	}
 
-	for _, details := range ai.GetAllImportDetails() {
+	return printContribSpecs(os.Stdout, specs, format)
+}
 
-		if !includeContrib(details, filter) {
-			continue
+func checkListFormat(format string) error {
+	switch format {
+	case ListFormatText, ListFormatJson, ListFormatYaml, ListFormatTable:
+		return nil
+	}
+	return fmt.Errorf("invalid format '%s', expected %s, %s or %s", format, ListFormatJson, ListFormatYaml, ListFormatTable)
+}
+
+func printContribSpecs(w io.Writer, specs []*ContribSpec, format string) error {
+
+	switch format {
+	case ListFormatJson, ListFormatYaml:
+		return printListValue(w, specs, format)
+	case ListFormatTable:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tVERSION\tUSED\tIMPORT")
+		for _, spec := range specs {
+			version := spec.Version
+			if version == "" {
+				version = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\n", spec.Name, spec.ContribType, version, spec.Used, spec.Import)
 		}
+		return tw.Flush()
+	}
+
+	for _, spec := range specs {
+		fmt.Fprintln(w, "Contrib: "+spec.Name)
+		fmt.Fprintln(w, "  Type       : "+spec.Type)
+		if spec.IsLegacy != nil {
+			fmt.Fprintln(w, "  IsLegacy   : true")
+		}
+		fmt.Fprintln(w, "  Homepage   : "+spec.Homepage)
+		fmt.Fprintln(w, "  Ref        : "+spec.Ref)
+		if spec.Version != "" {
+			fmt.Fprintln(w, "  Version    : "+spec.Version)
+		}
+		fmt.Fprintln(w, "  Path       : "+spec.Path)
+		fmt.Fprintln(w, "  Descriptor : "+spec.Path)
+		fmt.Fprintln(w, "  Description: "+spec.Description)
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
 
-		specs = append(specs, getContribSpec(project, details))
+// printListValue prints the value in JSON or, using the same keys, in YAML
+func printListValue(w io.Writer, value interface{}, format string) error {
 
+	buf, err := json.MarshalIndent(value, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+
+	if format == ListFormatYaml {
+		buf, err = util.JSONToYAML(buf)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf)
+		return err
 	}
 
+	_, err = fmt.Fprintln(w, string(buf))
+	return err
+}
+
+// getContribSpecs gets the specs of the contributions installed in the project
+func getContribSpecs(project common.AppProject, filter string) ([]*ContribSpec, error) {
+
+	ai, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := []*ContribSpec{}
+	modules := goModRequirements(project.SrcDir())
+
 	for _, details := range ai.GetAllImportDetails() {
 
-		if details.ContribDesc == nil {
+		if !includeContrib(details, filter) {
 			continue
 		}
 
-		if details.ContribDesc.Type == "flogo:function" {
-			specs = append(specs, getContribSpec(project, details))
+		if spec := getContribSpec(project, details, modules); spec != nil {
+			specs = append(specs, spec)
 		}
 	}
 
-	if len(specs) == 0 {
-		return nil
-	}
+	for _, details := range ai.GetAllImportDetails() {
 
-	if jsonFormat {
-		resp, err := json.MarshalIndent(specs, "", "  ")
-		if err != nil {
-			return err
+		if details.ContribDesc == nil {
+			continue
 		}
 
-		fmt.Fprintf(os.Stdout, "%v \n", string(resp))
-	} else {
-		for _, spec := range specs {
-			fmt.Println("Contrib: " + spec.Name)
-			fmt.Println("  Type       : " + spec.Type)
-			if spec.IsLegacy != nil {
-				fmt.Println("  IsLegacy   : true")
+		if details.ContribDesc.Type == "flogo:function" {
+			if spec := getContribSpec(project, details, modules); spec != nil {
+				specs = append(specs, spec)
 			}
-			fmt.Println("  Homepage   : " + spec.Homepage)
-			fmt.Println("  Ref        : " + spec.Ref)
-			fmt.Println("  Path       : " + spec.Path)
-			fmt.Println("  Descriptor : " + spec.Path)
-			fmt.Println("  Description: " + spec.Description)
-			fmt.Println()
 		}
 	}
 
-	return nil
+	return specs, nil
 }
 
 func includeContrib(details *util.AppImportDetails, filter string) bool {
@@ -97,12 +167,16 @@ type ContribSpec struct {
 	Description string      `json:"description"`
 	Homepage    string      `json:"homepage"`
 	Ref         string      `json:"ref"`
+	Import      string      `json:"import"`            // the Go import path referenced by the app descriptor
+	ContribType string      `json:"contribType"`       // activity, trigger, action or function
+	Version     string      `json:"version,omitempty"` // the version resolved in go.mod
+	Used        bool        `json:"used"`              // referenced by the flows, triggers or expressions of the application
 	Path        string      `json:"path"`
 	Descriptor  string      `json:"descriptor"`
 	IsLegacy    interface{} `json:"isLegacy,omitempty"`
 }
 
-func getContribSpec(project common.AppProject, details *util.AppImportDetails) *ContribSpec {
+func getContribSpec(project common.AppProject, details *util.AppImportDetails, modules map[string]string) *ContribSpec {
 	path, err := project.GetPath(details.Imp)
 	if err != nil {
 		return nil
@@ -120,6 +194,10 @@ func getContribSpec(project common.AppProject, details *util.AppImportDetails) *
 	spec.Description = desc.Description
 	spec.Homepage = desc.Homepage
 	spec.Ref = details.Imp.ModulePath()
+	spec.Import = details.Imp.GoImportPath()
+	spec.ContribType = desc.GetContribType()
+	_, spec.Version = importModule(modules, spec.Import)
+	spec.Used = details.Referenced()
 	spec.Path = path
 
 	if desc.IsLegacy {
@@ -131,7 +209,15 @@ func getContribSpec(project common.AppProject, details *util.AppImportDetails) *
 
 	return spec
 }
-func ListOrphanedRefs(project common.AppProject, jsonFormat bool) error {
+
+// ListOrphanedRefs lists the refs using an import alias which has no corresponding import, in the format: text,
+// json, yaml or table
+func ListOrphanedRefs(project common.AppProject, format string) error {
+
+	err := checkListFormat(format)
+	if err != nil {
+		return err
+	}
 
 	ai, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), true)
 	if err != nil {
@@ -140,17 +226,16 @@ func ListOrphanedRefs(project common.AppProject, jsonFormat bool) error {
 
 	orphaned := ai.GetOrphanedReferences()
 
-	if jsonFormat {
-		resp, err := json.MarshalIndent(orphaned, "", "  ")
-		if err != nil {
-			return err
+	switch format {
+	case ListFormatJson, ListFormatYaml:
+		if orphaned == nil {
+			orphaned = []string{}
 		}
+		return printListValue(os.Stdout, orphaned, format)
+	}
 
-		fmt.Fprintf(os.Stdout, "%v \n", string(resp))
-	} else {
-		for _, ref := range orphaned {
-			fmt.Println(ref)
-		}
+	for _, ref := range orphaned {
+		fmt.Println(ref)
 	}
 
 	return nil
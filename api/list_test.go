@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,11 +23,11 @@ func TestListAllContribs(t *testing.T) {
 	t.Logf("Current dir '%s'", testEnv.currentDir)
 	os.Chdir(testEnv.currentDir)
 
-	_, err := CreateProject(testEnv.currentDir, "myApp", "", "")
+	_, err := CreateProject(context.Background(), testEnv.currentDir, "myApp", "", "")
 
 	assert.Equal(t, nil, err)
 
-	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), true, "all")
+	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "myApp")), ListFormatJson, "all")
 	assert.Equal(t, nil, err)
 
 }
@@ -50,9 +52,37 @@ func TestListWithLegacyPkg(t *testing.T) {
 	}
 	defer file.Close()
 	fmt.Fprintf(file, newJsonString)
-	_, err = CreateProject(testEnv.currentDir, "temp", "flogo.json", "")
+	_, err = CreateProject(context.Background(), testEnv.currentDir, "temp", "flogo.json", "")
 	assert.Equal(t, nil, err)
 
-	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "temp")), true, "")
+	err = ListContribs(NewAppProject(filepath.Join(testEnv.currentDir, "temp")), ListFormatJson, "")
 	assert.Equal(t, nil, err)
 }
+
+func TestPrintContribSpecs(t *testing.T) {
+
+	specs := []*ContribSpec{
+		{Name: "log", Type: "flogo:activity", ContribType: "activity", Import: "github.com/project-flogo/contrib/activity/log", Version: "v0.9.0", Used: true},
+		{Name: "number", Type: "flogo:function", ContribType: "function", Import: "github.com/project-flogo/contrib/function/number"},
+	}
+
+	var buf bytes.Buffer
+	err := printContribSpecs(&buf, specs, ListFormatTable)
+	assert.Nil(t, err)
+	assert.Equal(t, "NAME    TYPE      VERSION  USED   IMPORT\n"+
+		"log     activity  v0.9.0   true   github.com/project-flogo/contrib/activity/log\n"+
+		"number  function  -        false  github.com/project-flogo/contrib/function/number\n", buf.String())
+
+	buf.Reset()
+	err = printContribSpecs(&buf, specs, ListFormatYaml)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "- name: log\n")
+	assert.Contains(t, buf.String(), "  contribType: activity\n  version: v0.9.0\n  used: true\n")
+
+	buf.Reset()
+	err = printContribSpecs(&buf, []*ContribSpec{}, ListFormatJson)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+
+	assert.NotNil(t, checkListFormat("xml"))
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileProjectLock = "project.lock"
+
+	// EnvLockTimeout is the time to wait for the project lock held by another invocation of the cli, ex. 1m, 0 fails fast
+	EnvLockTimeout = "FLOGO_LOCK_TIMEOUT"
+
+	defaultLockTimeout = 30 * time.Second
+	lockPollInterval   = 200 * time.Millisecond
+)
+
+var (
+	lockMutex sync.Mutex
+	lockCount = make(map[string]int)
+)
+
+// lockProject acquires the advisory lock of the project, which prevents concurrent invocations of the cli (ex. an
+// editor extension and a terminal) from interleaving their modifications of flogo.json, src/imports.go and src/go.mod.
+// The lock is reentrant within the process, it is released by calling the returned function.
+func lockProject(project common.AppProject) (func(), error) {
+
+	lockFile := filepath.Join(project.Dir(), dirProjectFlogo, fileProjectLock)
+
+	lockMutex.Lock()
+	defer lockMutex.Unlock()
+
+	if lockCount[lockFile] == 0 {
+		err := acquireLockFile(lockFile, os.Getpid(), lockTimeout())
+		if err != nil {
+			return nil, err
+		}
+	}
+	lockCount[lockFile]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			lockMutex.Lock()
+			defer lockMutex.Unlock()
+
+			lockCount[lockFile]--
+			if lockCount[lockFile] == 0 {
+				delete(lockCount, lockFile)
+				// the lock is only removed if it is still held by the process
+				if lockHolder(lockFile) == os.Getpid() {
+					_ = os.Remove(lockFile)
+				}
+			}
+		})
+	}, nil
+}
+
+// acquireLockFile creates the lock file containing the pid of the process, waiting for the process holding it
+// to release it, a lock file whose process no longer runs is stale and removed
+func acquireLockFile(lockFile string, pid int, timeout time.Duration) error {
+
+	err := os.MkdirAll(filepath.Dir(lockFile), 0755)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	waiting := false
+
+	for {
+		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(pid))
+			f.Close()
+			return err
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+
+		// the pid is written right after the creation of the lock file
+		holder := lockHolder(lockFile)
+		if holder == 0 && isRecentFile(lockFile) {
+			time.Sleep(lockPollInterval)
+			continue
+		}
+		if holder == 0 || holder == pid || !util.IsProcessRunning(holder) {
+			if removeStaleLock(lockFile, holder, pid) && Verbose() {
+				fmt.Printf("Removing stale project lock: %s\n", lockFile)
+			}
+			continue
+		}
+
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("the project is being modified by another flogo command (pid %d), retry once it has completed "+
+				"or remove '%s' if it is no longer running", holder, lockFile)
+		}
+
+		if !waiting {
+			waiting = true
+			fmt.Printf("Waiting for another flogo command (pid %d) to finish modifying the project...\n", holder)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// removeStaleLock removes the lock file held by the stale process. Another process may have removed the same stale
+// lock and acquired the project in the meantime, so the lock file is moved aside to a name unique to the process and
+// only deleted if it is still the stale one, otherwise it is put back. It returns true if the stale lock was removed.
+func removeStaleLock(lockFile string, stale, pid int) bool {
+
+	staleFile := fmt.Sprintf("%s.%d-%d.stale", lockFile, pid, time.Now().UnixNano())
+	if err := os.Rename(lockFile, staleFile); err != nil {
+		// ex. already removed by another process
+		return false
+	}
+
+	if lockHolder(staleFile) == stale && (stale != 0 || !isRecentFile(staleFile)) {
+		_ = os.Remove(staleFile)
+		return true
+	}
+
+	// the lock of a running process, it is put back unless the project has been locked again since
+	if err := os.Link(staleFile, lockFile); err != nil && Verbose() {
+		fmt.Printf("Unable to restore the project lock %s: %v\n", lockFile, err)
+	}
+	_ = os.Remove(staleFile)
+
+	return false
+}
+
+// lockHolder gets the pid of the process holding the lock, 0 if it can't be determined
+func lockHolder(lockFile string) int {
+
+	buf, err := ioutil.ReadFile(lockFile)
+	if err != nil {
+		return 0
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(buf)))
+	if err != nil {
+		return 0
+	}
+
+	return pid
+}
+
+func isRecentFile(file string) bool {
+	info, err := os.Stat(file)
+	return err == nil && time.Since(info.ModTime()) < time.Second
+}
+
+func lockTimeout() time.Duration {
+
+	value := os.Getenv(EnvLockTimeout)
+	if value == "" {
+		return defaultLockTimeout
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultLockTimeout
+	}
+
+	return timeout
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireLockFile(t *testing.T) {
+	t.Log("Testing the advisory lock of the project")
+
+	tempDir, err := ioutil.TempDir("", "flogo-lock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	lockFile := filepath.Join(tempDir, dirProjectFlogo, fileProjectLock)
+
+	// held by another running process
+	err = os.MkdirAll(filepath.Dir(lockFile), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(lockFile, []byte(strconv.Itoa(os.Getppid())), 0644)
+	assert.Nil(t, err)
+
+	err = acquireLockFile(lockFile, os.Getpid(), 0)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "pid "+strconv.Itoa(os.Getppid()))
+
+	// stale, the process no longer runs
+	err = ioutil.WriteFile(lockFile, []byte("999999999"), 0644)
+	assert.Nil(t, err)
+
+	err = acquireLockFile(lockFile, os.Getpid(), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, os.Getpid(), lockHolder(lockFile))
+}
+
+func TestAcquireStaleLockFile(t *testing.T) {
+	t.Log("Testing the removal of a stale lock by concurrent processes")
+
+	tempDir, err := ioutil.TempDir("", "flogo-lock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	lockFile := filepath.Join(tempDir, dirProjectFlogo, fileProjectLock)
+	err = os.MkdirAll(filepath.Dir(lockFile), 0755)
+	assert.Nil(t, err)
+
+	// two running processes, simulated by goroutines locking on behalf of the pids of the test and of its parent
+	pids := []int{os.Getpid(), os.Getppid()}
+
+	for i := 0; i < 50; i++ {
+		err = ioutil.WriteFile(lockFile, []byte("999999999"), 0644)
+		assert.Nil(t, err)
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(pids))
+		for j, pid := range pids {
+			wg.Add(1)
+			go func(j, pid int) {
+				defer wg.Done()
+				errs[j] = acquireLockFile(lockFile, pid, 0)
+			}(j, pid)
+		}
+		wg.Wait()
+
+		// only one of them acquires the lock, the other one doesn't remove it
+		acquired := 0
+		for j, err := range errs {
+			if err == nil {
+				acquired++
+				assert.Equal(t, pids[j], lockHolder(lockFile))
+			}
+		}
+		assert.Equal(t, 1, acquired)
+
+		files, _ := filepath.Glob(lockFile + ".*")
+		assert.Empty(t, files)
+	}
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileFlogoLock = "flogo.lock"
+
+// FlogoLock records the exact versions of the modules providing the imports of the application, so the application
+// can be recreated and rebuilt from the same versions
+type FlogoLock struct {
+	Imports map[string]*LockedImport `json:"imports"`        // keyed by the Go import path
+	Pins    map[string]*LockPin      `json:"pins,omitempty"` // the temporary exceptions to the resolution, keyed by module
+}
+
+// LockedImport is the module providing an import and its version
+type LockedImport struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"` // the hash of the module in go.sum
+}
+
+// resolveProjectLock gets the versions of the modules providing the imports of flogo.json according to go.mod and
+// go.sum, the imports not provided by a required module can't be locked and are omitted
+func resolveProjectLock(project common.AppProject) (*FlogoLock, error) {
+
+	var appImports []string
+	_, err := readAppDescriptorValue(project, "$.imports", &appImports)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := util.ParseImports(appImports)
+	if err != nil {
+		return nil, err
+	}
+
+	requires := goModRequirements(project.SrcDir())
+	sums := goSumHashes(project.SrcDir())
+
+	lock := &FlogoLock{Imports: make(map[string]*LockedImport)}
+	for _, imp := range imports {
+		module, version := requiredModule(requires, imp.GoImportPath())
+		if module == "" {
+			if Verbose() {
+				fmt.Printf("Unable to lock '%s', it isn't provided by a module required by go.mod\n", imp.GoImportPath())
+			}
+			continue
+		}
+		lock.Imports[imp.GoImportPath()] = &LockedImport{Module: module, Version: version, Sum: sums[module+"@"+version]}
+	}
+
+	return lock, nil
+}
+
+// goSumHashes gets the hashes of the modules in the go.sum of the directory, keyed by module@version
+func goSumHashes(srcDir string) map[string]string {
+
+	sums := make(map[string]string)
+
+	buf, err := ioutil.ReadFile(filepath.Join(srcDir, "go.sum"))
+	if err != nil {
+		return sums
+	}
+
+	for _, line := range strings.Split(string(buf), "\n") {
+		fields := strings.Fields(line)
+		// the hashes of the go.mod files are ignored
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+
+	return sums
+}
+
+// readProjectLock reads the flogo.lock of the project, nil if it doesn't have one
+func readProjectLock(project common.AppProject) (*FlogoLock, error) {
+	return readLockFile(filepath.Join(project.Dir(), fileFlogoLock))
+}
+
+func readLockFile(file string) (*FlogoLock, error) {
+
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lock := &FlogoLock{}
+	err = json.Unmarshal(buf, lock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", file, err)
+	}
+	if lock.Imports == nil {
+		lock.Imports = make(map[string]*LockedImport)
+	}
+
+	return lock, nil
+}
+
+// WriteProjectLock writes the flogo.lock of the project from the current resolution of its imports, its pins are kept
+func WriteProjectLock(project common.AppProject) error {
+
+	lock, err := resolveProjectLock(project)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := readProjectLock(project); err == nil && existing != nil {
+		lock.Pins = existing.Pins
+	}
+
+	return writeLockFile(project, lock)
+}
+
+func writeLockFile(project common.AppProject, lock *FlogoLock) error {
+
+	buf, err := json.MarshalIndent(lock, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(filepath.Join(project.Dir(), fileFlogoLock), append(buf, '\n'), 0644)
+}
+
+// updateProjectLock refreshes the flogo.lock of the project once its imports have changed, failing to do so doesn't
+// fail the command
+func updateProjectLock(project common.AppProject) {
+
+	err := WriteProjectLock(project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: unable to update %s: %v\n", fileFlogoLock, err)
+	}
+}
+
+// checkFrozenLock checks that the imports of the project resolve to the versions of its flogo.lock
+func checkFrozenLock(project common.AppProject) error {
+
+	locked, err := readProjectLock(project)
+	if err != nil {
+		return err
+	}
+	if locked == nil {
+		return fmt.Errorf("%s not found, it is written by 'flogo install', 'flogo build' or 'flogo imports lock'", fileFlogoLock)
+	}
+
+	resolved, err := resolveProjectLock(project)
+	if err != nil {
+		return err
+	}
+
+	diffs := diffLocks(locked, resolved)
+	if len(diffs) > 0 {
+		return fmt.Errorf("the imports resolve to versions different from %s:\n  %s", fileFlogoLock, strings.Join(diffs, "\n  "))
+	}
+
+	return nil
+}
+
+// diffLocks describes the differences between the locked and the resolved imports
+func diffLocks(locked, resolved *FlogoLock) []string {
+
+	var diffs []string
+
+	for path, lockedImp := range locked.Imports {
+		resolvedImp, exists := resolved.Imports[path]
+		switch {
+		case !exists:
+			diffs = append(diffs, fmt.Sprintf("%s: locked at %s %s, no longer resolved", path, lockedImp.Module, lockedImp.Version))
+		case resolvedImp.Module != lockedImp.Module || resolvedImp.Version != lockedImp.Version:
+			diffs = append(diffs, fmt.Sprintf("%s: locked at %s %s, resolved to %s %s", path, lockedImp.Module, lockedImp.Version, resolvedImp.Module, resolvedImp.Version))
+		case lockedImp.Sum != "" && resolvedImp.Sum != "" && resolvedImp.Sum != lockedImp.Sum:
+			diffs = append(diffs, fmt.Sprintf("%s: %s %s has the hash %s, %s is locked", path, resolvedImp.Module, resolvedImp.Version, resolvedImp.Sum, lockedImp.Sum))
+		}
+	}
+
+	for path, resolvedImp := range resolved.Imports {
+		if _, exists := locked.Imports[path]; !exists {
+			diffs = append(diffs, fmt.Sprintf("%s: not locked, resolved to %s %s", path, resolvedImp.Module, resolvedImp.Version))
+		}
+	}
+
+	sort.Strings(diffs)
+
+	return diffs
+}
+
+// lockedImports sets the version of the imports without a version to the one locked, so the application is created
+// from the locked versions rather than the latest ones
+func lockedImports(lock *FlogoLock, imports []util.Import) []util.Import {
+
+	if lock == nil {
+		return imports
+	}
+
+	pinned := make([]util.Import, len(imports))
+	for i, imp := range imports {
+		pinned[i] = imp
+		if locked, exists := lock.Imports[imp.GoImportPath()]; exists && (imp.Version() == "" || imp.Version() == "latest") {
+			pinned[i] = util.NewFlogoImportWithVersion(imp, locked.Version)
+		}
+	}
+
+	return pinned
+}
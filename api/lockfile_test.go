@@ -0,0 +1,72 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectLock(t *testing.T) {
+	t.Log("Testing locking of the versions of the imports")
+
+	tempDir, err := ioutil.TempDir("", "flogo-lock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+
+	appJson := `{"name": "myApp", "imports": ["github.com/project-flogo/contrib/activity/log", "github.com/project-flogo/flow", "github.com/unknown/trigger"]}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(appJson), 0644))
+
+	goMod := "module main\n\nrequire (\n\tgithub.com/project-flogo/contrib v0.10.0\n\tgithub.com/project-flogo/flow v0.9.4\n)\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "go.mod"), []byte(goMod), 0644))
+	goSum := "github.com/project-flogo/contrib v0.10.0 h1:contrib=\ngithub.com/project-flogo/contrib v0.10.0/go.mod h1:contribmod=\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "go.sum"), []byte(goSum), 0644))
+
+	err = checkFrozenLock(project)
+	assert.NotNil(t, err)
+
+	err = WriteProjectLock(project)
+	assert.Nil(t, err)
+
+	lock, err := readProjectLock(project)
+	assert.Nil(t, err)
+	assert.Len(t, lock.Imports, 2)
+	assert.Equal(t, &LockedImport{Module: "github.com/project-flogo/contrib", Version: "v0.10.0", Sum: "h1:contrib="}, lock.Imports["github.com/project-flogo/contrib/activity/log"])
+	assert.Equal(t, &LockedImport{Module: "github.com/project-flogo/flow", Version: "v0.9.4"}, lock.Imports["github.com/project-flogo/flow"])
+
+	assert.Nil(t, checkFrozenLock(project))
+
+	goMod = strings.Replace(goMod, "v0.9.4", "v0.10.0", 1)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "go.mod"), []byte(goMod), 0644))
+
+	err = checkFrozenLock(project)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "github.com/project-flogo/flow: locked at github.com/project-flogo/flow v0.9.4, resolved to github.com/project-flogo/flow v0.10.0")
+}
+
+func TestLockedImports(t *testing.T) {
+	t.Log("Testing pinning of the imports to the locked versions")
+
+	lock := &FlogoLock{Imports: map[string]*LockedImport{
+		"github.com/project-flogo/contrib/activity/log": {Module: "github.com/project-flogo/contrib", Version: "v0.10.0"},
+		"github.com/project-flogo/flow":                 {Module: "github.com/project-flogo/flow", Version: "v0.9.4"},
+	}}
+
+	imports, err := util.ParseImports([]string{"github.com/project-flogo/contrib/activity/log", "github.com/project-flogo/flow@v0.9.0", "github.com/project-flogo/stream"})
+	assert.Nil(t, err)
+
+	pinned := lockedImports(lock, imports)
+	assert.Equal(t, "v0.10.0", pinned[0].Version())
+	// an explicit version isn't overridden
+	assert.Equal(t, "v0.9.0", pinned[1].Version())
+	assert.Equal(t, "", pinned[2].Version())
+
+	assert.Equal(t, []util.Import(imports), lockedImports(nil, imports))
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const logPollInterval = 500 * time.Millisecond
+
+var logLevels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+
+// LogOptions are the options for showing the logs of the application
+type LogOptions struct {
+	Follow bool   // keep streaming the output as it is written
+	Level  string // minimum level of the log entries to show
+	Tail   int    // number of lines to show from the end of the log, all if 0
+	Raw    bool   // don't format JSON log entries
+}
+
+// ShowLogs shows the output of the application started by the cli
+func ShowLogs(project common.AppProject, options LogOptions) error {
+
+	minLevel := 0
+	if options.Level != "" {
+		minLevel = logLevelIndex(options.Level)
+		if minLevel < 0 {
+			return fmt.Errorf("invalid log level '%s', expected one of: %s", options.Level, strings.Join(logLevels[:4], ", "))
+		}
+	}
+
+	logFile := appLogFile(project)
+	file, err := os.Open(logFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no log found for '%s', the application hasn't been started by the cli", project.Name())
+		}
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	printer := &logPrinter{minLevel: minLevel, raw: options.Raw, show: true}
+
+	reader := bufio.NewReader(file)
+	var lines []string
+	offset := int64(0)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			// a partial line is kept until the rest of it is written
+			break
+		}
+		if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+		lines = append(lines, line)
+	}
+
+	if options.Tail > 0 && len(lines) > options.Tail {
+		lines = lines[len(lines)-options.Tail:]
+	}
+	for _, line := range lines {
+		printer.print(line)
+	}
+
+	if !options.Follow {
+		return nil
+	}
+
+	pid, err := getAppPid(project)
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		fmt.Fprintf(os.Stderr, "Application '%s' isn't running, waiting for it to be started\n", project.Name())
+	}
+
+	for {
+		info, err := os.Stat(logFile)
+		if err == nil && info.Size() < offset {
+			// the log was truncated, ex. the application was restarted
+			_ = file.Close()
+			file, err = os.Open(logFile)
+			if err != nil {
+				return err
+			}
+			offset = 0
+		}
+
+		_, err = file.Seek(offset, io.SeekStart)
+		if err != nil {
+			return err
+		}
+		reader.Reset(file)
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			offset += int64(len(line))
+			printer.print(line)
+		}
+
+		time.Sleep(logPollInterval)
+	}
+}
+
+type logPrinter struct {
+	minLevel int
+	raw      bool
+
+	// lines without a level, ex. stack traces, are shown if the previous entry was
+	show bool
+}
+
+func (p *logPrinter) print(line string) {
+
+	line = strings.TrimRight(line, "\r\n")
+
+	entry, isJSON := parseJSONLogEntry(line)
+
+	level := -1
+	if isJSON {
+		if lvl, ok := entry["level"].(string); ok {
+			level = logLevelIndex(lvl)
+		}
+	} else {
+		level = consoleLogLevel(line)
+	}
+
+	if level >= 0 {
+		p.show = level >= p.minLevel
+	}
+	if !p.show {
+		return
+	}
+
+	if isJSON && !p.raw {
+		line = formatJSONLogEntry(entry)
+	}
+
+	fmt.Println(line)
+}
+
+func logLevelIndex(level string) int {
+	level = strings.ToLower(level)
+	if level == "warning" {
+		level = "warn"
+	}
+	for i, l := range logLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// consoleLogLevel gets the level of a log entry in the engine's console format, which is tab separated,
+// ex. 2019-03-14T10:00:00.000-0400	INFO	[flogo.engine] -	Starting app
+func consoleLogLevel(line string) int {
+
+	fields := strings.SplitN(line, "\t", 3)
+	if len(fields) < 2 {
+		return -1
+	}
+
+	return logLevelIndex(strings.TrimSpace(fields[1]))
+}
+
+func parseJSONLogEntry(line string) (map[string]interface{}, bool) {
+
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return nil, false
+	}
+
+	var entry map[string]interface{}
+	err := json.Unmarshal([]byte(line), &entry)
+	if err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// formatJSONLogEntry formats a log entry in the engine's JSON format like the console format, with the
+// additional fields appended as key=value pairs
+func formatJSONLogEntry(entry map[string]interface{}) string {
+
+	var parts []string
+
+	switch ts := entry["ts"].(type) {
+	case float64:
+		sec := int64(ts)
+		parts = append(parts, time.Unix(sec, int64((ts-float64(sec))*1e9)).Format("2006-01-02T15:04:05.000Z0700"))
+	case string:
+		parts = append(parts, ts)
+	}
+
+	if level, ok := entry["level"].(string); ok {
+		parts = append(parts, strings.ToUpper(level))
+	}
+	if logger, ok := entry["logger"].(string); ok {
+		parts = append(parts, "["+logger+"]")
+	}
+	if msg, ok := entry["msg"]; ok {
+		parts = append(parts, fmt.Sprint(msg))
+	}
+
+	var keys []string
+	for key := range entry {
+		switch key {
+		case "ts", "level", "logger", "msg":
+		default:
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val, err := json.Marshal(entry[key])
+		if err != nil {
+			continue
+		}
+		parts = append(parts, key+"="+string(val))
+	}
+
+	return strings.Join(parts, "\t")
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAppLog = "2026-01-01T10:00:00.000Z\tDEBUG\t[flogo.engine] -\tLoading app\n" +
+	"2026-01-01T10:00:00.001Z\tINFO\t[flogo.engine] -\tStarting app\n" +
+	`{"level":"error","ts":1767261600.5,"logger":"flogo.flow","msg":"Task failed","flow":"main"}` + "\n" +
+	"goroutine 1 [running]:\n" +
+	"2026-01-01T10:00:01.000Z\tWARN\t[flogo.engine] -\tSlow handler\n" +
+	"partial"
+
+func TestShowLogs(t *testing.T) {
+	t.Log("Testing the logs of the application")
+
+	tempDir, err := ioutil.TempDir("", "flogo-logs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+
+	err = ShowLogs(project, LogOptions{})
+	assert.EqualError(t, err, "no log found for '"+project.Name()+"', the application hasn't been started by the cli")
+
+	err = os.MkdirAll(filepath.Join(tempDir, dirProjectFlogo), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(appLogFile(project), []byte(testAppLog), 0644)
+	assert.Nil(t, err)
+
+	// the partial line isn't shown, the JSON entries are formatted
+	var showErr error
+	out := captureStdout(t, func() {
+		showErr = ShowLogs(project, LogOptions{})
+	})
+	assert.Nil(t, showErr)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 5)
+	assert.True(t, strings.HasSuffix(lines[0], "Loading app"))
+	// the timestamps are in the local time zone
+	assert.True(t, strings.HasPrefix(lines[2], time.Unix(1767261600, 5e8).Format("2006-01-02T15:04:05.000")))
+	assert.True(t, strings.HasSuffix(lines[2], "\tERROR\t[flogo.flow]\tTask failed\tflow=\"main\""))
+	assert.NotContains(t, out, "partial")
+
+	// the lines without level follow the entry they belong to
+	out = captureStdout(t, func() {
+		showErr = ShowLogs(project, LogOptions{Level: "warning"})
+	})
+	assert.Nil(t, showErr)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "Task failed")
+	assert.Equal(t, "goroutine 1 [running]:", lines[1])
+	assert.Contains(t, lines[2], "Slow handler")
+
+	out = captureStdout(t, func() {
+		showErr = ShowLogs(project, LogOptions{Tail: 2, Raw: true})
+	})
+	assert.Nil(t, showErr)
+	assert.Equal(t, "goroutine 1 [running]:\n2026-01-01T10:00:01.000Z\tWARN\t[flogo.engine] -\tSlow handler\n", out)
+
+	out = captureStdout(t, func() {
+		showErr = ShowLogs(project, LogOptions{Level: "error", Raw: true})
+	})
+	assert.Nil(t, showErr)
+	assert.True(t, strings.HasPrefix(out, `{"level":"error"`))
+
+	err = ShowLogs(project, LogOptions{Level: "verbose"})
+	assert.EqualError(t, err, "invalid log level 'verbose', expected one of: debug, info, warn, error")
+}
+
+func TestLogLevels(t *testing.T) {
+	t.Log("Testing the levels of the log entries")
+
+	assert.Equal(t, 0, logLevelIndex("DEBUG"))
+	assert.Equal(t, 2, logLevelIndex("warning"))
+	assert.Equal(t, -1, logLevelIndex("verbose"))
+
+	assert.Equal(t, 1, consoleLogLevel("2026-01-01T10:00:00.000Z\tINFO\t[flogo] -\tStarted"))
+	assert.Equal(t, -1, consoleLogLevel("goroutine 1 [running]:"))
+	assert.Equal(t, -1, consoleLogLevel("a\tb\tc"))
+}
+
+func TestFormatJSONLogEntry(t *testing.T) {
+	t.Log("Testing the formatting of JSON log entries")
+
+	entry, ok := parseJSONLogEntry(`{"level":"info","ts":"2026-01-01T10:00:00Z","msg":"Started","port":8080,"tags":["a"]}`)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-01-01T10:00:00Z\tINFO\tStarted\tport=8080\ttags=[\"a\"]", formatJSONLogEntry(entry))
+
+	_, ok = parseJSONLogEntry("{not json")
+	assert.False(t, ok)
+	_, ok = parseJSONLogEntry(`"string"`)
+	assert.False(t, ok)
+}
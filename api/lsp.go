@@ -0,0 +1,529 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+
+	rpcErrParse          = -32700
+	rpcErrInvalidRequest = -32600
+	rpcErrMethodNotFound = -32601
+	rpcErrInvalidParams  = -32602
+	rpcErrServer         = -32000
+)
+
+type lspMessage struct {
+	ID     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+}
+
+type rpcErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   *rpcError        `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextDocumentParams struct {
+	TextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Position lspPosition `json:"position"`
+}
+
+type lspCompletionItem struct {
+	Label         string      `json:"label"`
+	Kind          int         `json:"kind"`
+	Detail        string      `json:"detail,omitempty"`
+	Documentation interface{} `json:"documentation,omitempty"`
+	FilterText    string      `json:"filterText"`
+	TextEdit      interface{} `json:"textEdit"`
+}
+
+type lspMarkup struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspServer struct {
+	project  common.AppProject
+	in       *bufio.Reader
+	out      io.Writer
+	docs     map[string]string
+	contribs *contribResolver
+}
+
+// ServeLSP serves the language server protocol for the project's app descriptor, using the specified streams
+func ServeLSP(project common.AppProject, in io.Reader, out io.Writer) error {
+
+	s := &lspServer{project: project, in: bufio.NewReader(in), out: out, docs: make(map[string]string)}
+	s.loadContribs()
+
+	return s.serve()
+}
+
+func (s *lspServer) serve() error {
+
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.Method {
+		case "initialize":
+			err = s.reply(msg.ID, map[string]interface{}{
+				"capabilities": map[string]interface{}{
+					"textDocumentSync":   1, // full
+					"completionProvider": map[string]interface{}{"triggerCharacters": []string{"\"", "#", "/"}},
+					"hoverProvider":      true,
+				},
+				"serverInfo": map[string]string{"name": "flogo"},
+			})
+		case "initialized", "$/cancelRequest", "$/setTrace":
+		case "shutdown":
+			err = s.reply(msg.ID, nil)
+		case "exit":
+			return nil
+		case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+			err = s.documentChanged(msg)
+		case "textDocument/completion":
+			err = s.completion(msg)
+		case "textDocument/hover":
+			err = s.hover(msg)
+		default:
+			if msg.ID != nil {
+				err = s.replyError(msg.ID, rpcErrMethodNotFound, "method not supported: "+msg.Method)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *lspServer) loadContribs() {
+	contribs, err := newContribResolver(s.project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load installed contributions: %v\n", err)
+		return
+	}
+	s.contribs = contribs
+}
+
+func (s *lspServer) read() (*lspMessage, error) {
+
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[len("content-length:"):]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid header '%s'", line)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	buf := make([]byte, length)
+	_, err := io.ReadFull(s.in, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &lspMessage{}
+	err = json.Unmarshal(buf, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (s *lspServer) write(v interface{}) error {
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(buf), buf)
+	return err
+}
+
+func (s *lspServer) reply(id *json.RawMessage, result interface{}) error {
+	return s.write(&rpcResponse{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *lspServer) replyError(id *json.RawMessage, code int, message string) error {
+	return s.write(&rpcErrorResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}})
+}
+
+func (s *lspServer) documentChanged(msg *lspMessage) error {
+
+	params := &lspTextDocumentParams{}
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		return nil
+	}
+
+	uri := params.TextDocument.URI
+
+	switch msg.Method {
+	case "textDocument/didOpen":
+		s.docs[uri] = params.TextDocument.Text
+	case "textDocument/didChange":
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uri] = params.ContentChanges[n-1].Text
+		}
+	case "textDocument/didSave":
+		// the imports may have changed
+		s.loadContribs()
+	case "textDocument/didClose":
+		delete(s.docs, uri)
+		return s.publishDiagnostics(uri, []lspDiagnostic{})
+	}
+
+	if !isAppDescriptorURI(uri) {
+		return nil
+	}
+
+	return s.publishDiagnostics(uri, s.diagnostics(s.docs[uri]))
+}
+
+func (s *lspServer) publishDiagnostics(uri string, diagnostics []lspDiagnostic) error {
+	return s.write(&lspNotification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics",
+		Params: map[string]interface{}{"uri": uri, "diagnostics": diagnostics}})
+}
+
+// diagnostics gets the syntax errors and validation issues of the app descriptor
+func (s *lspServer) diagnostics(text string) []lspDiagnostic {
+
+	diagnostics := []lspDiagnostic{}
+
+	nodes := make(map[string]*jsonNode)
+	err := scanJSON(text, func(n *jsonNode) {
+		nodes[n.path] = n
+	})
+	if err != nil {
+		offset := 0
+		if scanErr, ok := err.(*jsonScanError); ok {
+			offset = scanErr.offset
+		}
+		return append(diagnostics, lspDiagnostic{Range: lspRangeOf(text, offset, offset), Severity: lspSeverityError, Source: "flogo", Message: err.Error()})
+	}
+
+	var appObj map[string]interface{}
+	err = json.Unmarshal([]byte(text), &appObj)
+	if err != nil {
+		return append(diagnostics, lspDiagnostic{Range: lspRangeOf(text, 0, 0), Severity: lspSeverityError, Source: "flogo", Message: err.Error()})
+	}
+
+	if s.contribs == nil {
+		return diagnostics
+	}
+
+	issues, err := validateAppObj(s.project, ValidateOptions{}, appObj, s.contribs)
+	if err != nil {
+		return append(diagnostics, lspDiagnostic{Range: lspRangeOf(text, 0, 0), Severity: lspSeverityError, Source: "flogo", Message: err.Error()})
+	}
+
+	for _, issue := range issues {
+		// issues about missing values are reported on the closest value that exists
+		path := issue.Path
+		node := nodes[path]
+		for node == nil && path != "" {
+			path = parentJSONPath(path)
+			node = nodes[path]
+		}
+
+		r := lspRangeOf(text, 0, 0)
+		if node != nil {
+			r = lspRangeOf(text, node.start, node.end)
+		}
+
+		severity := lspSeverityError
+		if issue.Severity == SeverityWarning {
+			severity = lspSeverityWarning
+		}
+
+		diagnostics = append(diagnostics, lspDiagnostic{Range: r, Severity: severity, Source: "flogo", Message: issue.Message})
+	}
+
+	return diagnostics
+}
+
+// completion completes the ref being edited with the refs and aliases of the installed contributions
+func (s *lspServer) completion(msg *lspMessage) error {
+
+	params := &lspTextDocumentParams{}
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		return s.replyError(msg.ID, rpcErrInvalidParams, err.Error())
+	}
+
+	items := []*lspCompletionItem{}
+
+	text := s.docs[params.TextDocument.URI]
+	offset := lspOffsetOf(text, params.Position)
+
+	err = scanJSON(text[:offset], func(n *jsonNode) {})
+	scanErr, ok := err.(*jsonScanError)
+	if !ok || !scanErr.inString || !strings.HasSuffix(scanErr.path, ".ref") || s.contribs == nil {
+		return s.reply(msg.ID, items)
+	}
+
+	contribType := refContribType(scanErr.path)
+	editRange := lspRangeOf(text, scanErr.strStart, offset)
+
+	addItem := func(label string, desc *util.FlogoContribDescriptor) {
+		items = append(items, &lspCompletionItem{Label: label, Kind: 9, // module
+			Detail: desc.Type, Documentation: &lspMarkup{Kind: "markdown", Value: contribDoc(desc)},
+			FilterText: label, TextEdit: map[string]interface{}{"range": editRange, "newText": label}})
+	}
+
+	for ct, aliases := range s.contribs.byAlias {
+		if contribType != "" && ct != contribType {
+			continue
+		}
+		for alias, desc := range aliases {
+			addItem("#"+alias, desc)
+		}
+	}
+
+	for path, desc := range s.contribs.byPath {
+		if contribType != "" && desc.GetContribType() != contribType {
+			continue
+		}
+		addItem(path, desc)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+
+	return s.reply(msg.ID, items)
+}
+
+// hover shows the documentation of the contribution referenced by the ref under the cursor
+func (s *lspServer) hover(msg *lspMessage) error {
+
+	params := &lspTextDocumentParams{}
+	err := json.Unmarshal(msg.Params, params)
+	if err != nil {
+		return s.replyError(msg.ID, rpcErrInvalidParams, err.Error())
+	}
+
+	text := s.docs[params.TextDocument.URI]
+	offset := lspOffsetOf(text, params.Position)
+
+	var ref *jsonNode
+	_ = scanJSON(text, func(n *jsonNode) {
+		if n.isStr && n.start <= offset && offset < n.end && strings.HasSuffix(n.path, ".ref") {
+			ref = n
+		}
+	})
+
+	if ref == nil || s.contribs == nil {
+		return s.reply(msg.ID, nil)
+	}
+
+	var desc *util.FlogoContribDescriptor
+	if contribType := refContribType(ref.path); contribType != "" {
+		desc = s.contribs.Resolve(contribType, ref.str)
+	} else {
+		for ct := range s.contribs.byAlias {
+			if desc = s.contribs.Resolve(ct, ref.str); desc != nil {
+				break
+			}
+		}
+	}
+
+	if desc == nil {
+		return s.reply(msg.ID, nil)
+	}
+
+	return s.reply(msg.ID, map[string]interface{}{
+		"contents": &lspMarkup{Kind: "markdown", Value: contribDoc(desc)},
+		"range":    lspRangeOf(text, ref.start, ref.end),
+	})
+}
+
+// refContribType gets the type of contribution the ref at path references, "" if it can't be determined
+func refContribType(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".activity.ref"):
+		return "activity"
+	case strings.HasSuffix(path, ".action.ref"), strings.HasPrefix(path, "$.actions["):
+		return "action"
+	case strings.HasPrefix(path, "$.triggers[") && strings.Count(path, ".") == 2:
+		return "trigger"
+	case strings.HasPrefix(path, "$.connections."):
+		return "connection"
+	}
+	return ""
+}
+
+// contribDoc gets the markdown documentation of a contribution
+func contribDoc(desc *util.FlogoContribDescriptor) string {
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** (%s)", desc.Name, desc.Type)
+	if desc.Version != "" {
+		fmt.Fprintf(&b, " %s", desc.Version)
+	}
+	b.WriteString("\n\n")
+	if desc.Description != "" {
+		b.WriteString(desc.Description + "\n\n")
+	}
+
+	writeSettings := func(title string, settings []*util.FlogoSettingDescriptor) {
+		if len(settings) == 0 {
+			return
+		}
+		b.WriteString(title + ":\n")
+		for _, setting := range settings {
+			required := ""
+			if setting.Required {
+				required = ", required"
+			}
+			fmt.Fprintf(&b, "- `%s` (%s%s)\n", setting.Name, setting.Type, required)
+		}
+		b.WriteString("\n")
+	}
+
+	writeSettings("Settings", desc.Settings)
+	if desc.Handler != nil {
+		writeSettings("Handler Settings", desc.Handler.Settings)
+	}
+	writeSettings("Input", desc.Input)
+	writeSettings("Output", desc.Output)
+
+	return strings.TrimSpace(b.String())
+}
+
+func isAppDescriptorURI(uri string) bool {
+	return strings.HasSuffix(uri, "/"+fileFlogoJson)
+}
+
+// lspRangeOf converts a range of byte offsets to an LSP range
+func lspRangeOf(text string, start, end int) lspRange {
+	return lspRange{Start: lspPositionOf(text, start), End: lspPositionOf(text, end)}
+}
+
+// lspPositionOf converts a byte offset to an LSP position, whose character is in UTF-16 code units
+func lspPositionOf(text string, offset int) lspPosition {
+
+	if offset > len(text) {
+		offset = len(text)
+	}
+
+	pos := lspPosition{}
+	for _, r := range text[:offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Character = 0
+			continue
+		}
+		pos.Character += utf16Len(r)
+	}
+
+	return pos
+}
+
+// lspOffsetOf converts an LSP position to a byte offset
+func lspOffsetOf(text string, pos lspPosition) int {
+
+	line, char := 0, 0
+	for i, r := range text {
+		if line == pos.Line && char >= pos.Character {
+			return i
+		}
+		if r == '\n' {
+			if line == pos.Line {
+				return i
+			}
+			line++
+			char = 0
+			continue
+		}
+		if line == pos.Line {
+			char += utf16Len(r)
+		}
+	}
+
+	return len(text)
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
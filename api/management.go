@@ -0,0 +1,596 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	fileManagementGo = "management.go"
+
+	// DefaultManagementAddr is the address the management API of the application listens on, unless
+	// overridden using the FLOGO_MANAGEMENT_ADDR environment variable
+	DefaultManagementAddr = "127.0.0.1:7779"
+
+	// EnvManagementToken is the environment variable containing the bearer token required by the management API
+	EnvManagementToken = "FLOGO_MANAGEMENT_TOKEN"
+)
+
+// createManagementGoFile generates the management service, which is started along with the engine
+func createManagementGoFile(project common.AppProject) error {
+
+	if Verbose() {
+		fmt.Println("Enabling management API in application...")
+	}
+
+	return writeTemplateFile(filepath.Join(project.SrcDir(), fileManagementGo), tplManagementGoFile, &struct{ Addr string }{DefaultManagementAddr})
+}
+
+func cleanupManagementGoFile(project common.AppProject) error {
+
+	managementSrcPath := filepath.Join(project.SrcDir(), fileManagementGo)
+
+	if _, err := os.Stat(managementSrcPath); err == nil {
+		if Verbose() {
+			fmt.Println("Removing management API")
+		}
+		return os.Remove(managementSrcPath)
+	}
+
+	return nil
+}
+
+var tplManagementGoFile = `// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-flogo/core/action"
+	"github.com/project-flogo/core/app"
+	"github.com/project-flogo/core/data"
+	"github.com/project-flogo/core/data/coerce"
+	"github.com/project-flogo/core/data/property"
+	"github.com/project-flogo/core/engine"
+	"github.com/project-flogo/core/engine/event"
+	"github.com/project-flogo/core/support/log"
+)
+
+func init() {
+	instrumentActions()
+	engine.LifeCycle(&managementService{})
+}
+
+// managementService serves the management API used by 'flogo remote'
+type managementService struct {
+	server *http.Server
+	mutex  sync.Mutex
+
+	started   time.Time
+	appStatus string
+	logLevel  string
+	flows     []*managementFlow
+}
+
+type managementFlow struct {
+	ID       string   ` + "`json:\"id\"`" + `
+	Name     string   ` + "`json:\"name,omitempty\"`" + `
+	Triggers []string ` + "`json:\"triggers\"`" + `
+}
+
+// managementError is an error caused by the request
+type managementError struct {
+	msg string
+}
+
+func (e *managementError) Error() string {
+	return e.msg
+}
+
+func (s *managementService) Start() error {
+
+	addr := os.Getenv("FLOGO_MANAGEMENT_ADDR")
+	if addr == "" {
+		addr = "{{.Addr}}"
+	}
+
+	s.started = time.Now()
+	s.appStatus = app.STARTING
+	s.logLevel = strings.ToUpper(os.Getenv(log.EnvKeyLogLevel))
+	if s.logLevel == "" {
+		s.logLevel = "INFO"
+	}
+
+	flows, err := loadManagementFlows()
+	if err != nil {
+		log.RootLogger().Warnf("Management API unable to determine the flows of the application: %v", err)
+	}
+	s.flows = flows
+
+	err = event.RegisterListener("flogo-cli-management", s, []string{app.AppEventType})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("unable to start management API: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/flows", s.handleFlows)
+	mux.HandleFunc("/props", s.handleProps)
+	mux.HandleFunc("/config", s.handleConfig)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	s.server = &http.Server{Handler: s.authorize(mux)}
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+
+	log.RootLogger().Infof("Management API listening on %s", listener.Addr())
+
+	return nil
+}
+
+func (s *managementService) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	return s.server.Close()
+}
+
+// HandleEvent keeps track of the status of the application
+func (s *managementService) HandleEvent(ctx *event.Context) error {
+	if ae, ok := ctx.GetEvent().(app.AppEvent); ok {
+		s.mutex.Lock()
+		s.appStatus = string(ae.AppStatus())
+		s.mutex.Unlock()
+	}
+	return nil
+}
+
+func (s *managementService) authorize(handler http.Handler) http.Handler {
+
+	token := os.Getenv("FLOGO_MANAGEMENT_TOKEN")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (s *managementService) handleStatus(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	writeManagementResult(w, map[string]interface{}{
+		"name":       engine.GetAppName(),
+		"version":    engine.GetAppVersion(),
+		"commit":     flogoBuildCommit,
+		"built":      flogoBuildTime,
+		"status":     s.appStatus,
+		"started":    s.started,
+		"uptime":     time.Since(s.started).Round(time.Second).String(),
+		"logLevel":   s.logLevel,
+		"goroutines": runtime.NumGoroutine(),
+		"memory":     mem.Alloc,
+	})
+}
+
+func (s *managementService) handleFlows(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flows := s.flows
+	if flows == nil {
+		flows = []*managementFlow{}
+	}
+
+	writeManagementResult(w, flows)
+}
+
+// handleProps lists the names of the app properties or updates their values, the values aren't listed since
+// they may contain secrets
+func (s *managementService) handleProps(w http.ResponseWriter, r *http.Request) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names := []string{}
+
+	switch r.Method {
+	case http.MethodGet:
+		for name := range currentProperties() {
+			names = append(names, name)
+		}
+	case http.MethodPatch:
+		var updates map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&updates)
+		if err != nil {
+			http.Error(w, "invalid properties: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		names, err = updateProperties(updates)
+		if err != nil {
+			writeManagementError(w, err)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sort.Strings(names)
+	writeManagementResult(w, map[string]interface{}{"properties": names})
+}
+
+// handleConfig reconfigures the running application
+func (s *managementService) handleConfig(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPatch {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cfg := &struct {
+		LogLevel   string                 ` + "`json:\"logLevel\"`" + `
+		Properties map[string]interface{} ` + "`json:\"properties\"`" + `
+	}{}
+	err := json.NewDecoder(r.Body).Decode(cfg)
+	if err != nil {
+		http.Error(w, "invalid configuration: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logLevel := strings.ToUpper(cfg.LogLevel)
+	switch logLevel {
+	case "", "DEBUG", "INFO", "WARN", "ERROR":
+	default:
+		http.Error(w, fmt.Sprintf("invalid log level '%s'", cfg.LogLevel), http.StatusBadRequest)
+		return
+	}
+
+	names := []string{}
+	if len(cfg.Properties) > 0 {
+		names, err = updateProperties(cfg.Properties)
+		if err != nil {
+			writeManagementError(w, err)
+			return
+		}
+	}
+
+	if logLevel != "" {
+		log.SetLogLevel(log.RootLogger(), log.ToLogLevel(logLevel))
+		s.logLevel = logLevel
+		log.RootLogger().Infof("Log level set to %s", logLevel)
+	}
+
+	sort.Strings(names)
+	writeManagementResult(w, map[string]interface{}{"logLevel": s.logLevel, "properties": names})
+}
+
+// handleMetrics writes the metrics of the application in the Prometheus text format
+func (s *managementService) handleMetrics(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	writeMetric(w, "flogo_app_uptime_seconds", "gauge", "Time since the application was started.")
+	fmt.Fprintf(w, "flogo_app_uptime_seconds %g\n", time.Since(s.started).Seconds())
+	writeMetric(w, "go_goroutines", "gauge", "Number of goroutines that currently exist.")
+	fmt.Fprintf(w, "go_goroutines %d\n", runtime.NumGoroutine())
+	writeMetric(w, "go_memstats_alloc_bytes", "gauge", "Number of bytes allocated and still in use.")
+	fmt.Fprintf(w, "go_memstats_alloc_bytes %d\n", mem.Alloc)
+
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+
+	var names []string
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeMetric(w, "flogo_flow_executions_total", "counter", "Number of executions of the flow.")
+	for _, name := range names {
+		fmt.Fprintf(w, "flogo_flow_executions_total{flow=%s} %d\n", strconv.Quote(name), metrics[name].executions)
+	}
+	writeMetric(w, "flogo_flow_errors_total", "counter", "Number of executions of the flow that failed.")
+	for _, name := range names {
+		fmt.Fprintf(w, "flogo_flow_errors_total{flow=%s} %d\n", strconv.Quote(name), metrics[name].errors)
+	}
+	writeMetric(w, "flogo_flow_duration_seconds_sum", "counter", "Total duration of the executions of the flow.")
+	for _, name := range names {
+		fmt.Fprintf(w, "flogo_flow_duration_seconds_sum{flow=%s} %g\n", strconv.Quote(name), metrics[name].durationSum)
+	}
+	writeMetric(w, "flogo_flow_duration_seconds_max", "gauge", "Longest duration of an execution of the flow.")
+	for _, name := range names {
+		fmt.Fprintf(w, "flogo_flow_duration_seconds_max{flow=%s} %g\n", strconv.Quote(name), metrics[name].durationMax)
+	}
+}
+
+func writeMetric(w http.ResponseWriter, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// currentProperties gets a copy of the current app properties
+func currentProperties() map[string]interface{} {
+
+	props := make(map[string]interface{})
+	_ = property.DefaultManager().Finalize(func(current map[string]interface{}) error {
+		for name, val := range current {
+			props[name] = val
+		}
+		return nil
+	})
+
+	return props
+}
+
+// updateProperties updates the values of app properties, the values are converted to the type of the property
+func updateProperties(updates map[string]interface{}) ([]string, error) {
+
+	props := currentProperties()
+	var names []string
+
+	for name, val := range updates {
+		current, exists := props[name]
+		if !exists {
+			return nil, &managementError{msg: fmt.Sprintf("property '%s' isn't defined by the application", name)}
+		}
+		if t, err := data.GetType(current); err == nil && current != nil {
+			val, err = coerce.ToType(val, t)
+			if err != nil {
+				return nil, &managementError{msg: fmt.Sprintf("invalid value for property '%s': %v", name, err)}
+			}
+		}
+		props[name] = val
+		names = append(names, name)
+	}
+
+	// the manager is replaced rather than modified, so that the properties aren't modified while being resolved
+	property.SetDefaultManager(property.NewManager(props))
+	log.RootLogger().Infof("Updated app properties: %v", names)
+
+	return names, nil
+}
+
+// loadManagementFlows gets the flows of the application and the triggers that start them
+func loadManagementFlows() ([]*managementFlow, error) {
+
+	cfg, err := engine.LoadAppConfig(cfgJson, cfgCompressed)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []*managementFlow
+	byURI := make(map[string]*managementFlow)
+
+	for _, res := range cfg.Resources {
+		if !strings.HasPrefix(res.ID, "flow:") {
+			continue
+		}
+		flow := &managementFlow{ID: res.ID, Triggers: []string{}}
+		flowData := &struct {
+			Name string ` + "`json:\"name\"`" + `
+		}{}
+		if json.Unmarshal(res.Data, flowData) == nil {
+			flow.Name = flowData.Name
+		}
+		flows = append(flows, flow)
+		byURI["res://"+res.ID] = flow
+	}
+
+	actionSettings := make(map[string]map[string]interface{})
+	for _, act := range cfg.Actions {
+		actionSettings[act.Id] = act.Settings
+	}
+
+	for _, trg := range cfg.Triggers {
+		for _, handler := range trg.Handlers {
+			for _, act := range handler.Actions {
+				if act == nil || act.Config == nil {
+					continue
+				}
+				settings := act.Settings
+				if act.Id != "" {
+					settings = actionSettings[act.Id]
+				}
+				if flowURI, ok := settings["flowURI"].(string); ok {
+					if flow, exists := byURI[flowURI]; exists {
+						flow.Triggers = append(flow.Triggers, trg.Id)
+					}
+				}
+			}
+		}
+	}
+
+	return flows, nil
+}
+
+// flowMetrics are the metrics of the executions of a flow, or of another action
+type flowMetrics struct {
+	executions  uint64
+	errors      uint64
+	durationSum float64
+	durationMax float64
+}
+
+var (
+	metrics      = make(map[string]*flowMetrics)
+	metricsMutex sync.Mutex
+)
+
+func recordExecution(name string, start time.Time, err error) {
+
+	duration := time.Since(start).Seconds()
+
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+
+	m, exists := metrics[name]
+	if !exists {
+		m = &flowMetrics{}
+		metrics[name] = m
+	}
+
+	m.executions++
+	if err != nil {
+		m.errors++
+	}
+	m.durationSum += duration
+	if duration > m.durationMax {
+		m.durationMax = duration
+	}
+}
+
+// instrumentActions wraps the registered action factories, so that the executions of the actions they create are measured
+func instrumentActions() {
+	factories := action.Factories()
+	for ref, f := range factories {
+		factories[ref] = &meteredFactory{Factory: f}
+	}
+}
+
+type meteredFactory struct {
+	action.Factory
+}
+
+func (f *meteredFactory) New(config *action.Config) (action.Action, error) {
+
+	act, err := f.Factory.New(config)
+	if err != nil || act == nil {
+		return act, err
+	}
+
+	name := config.Id
+	if flowURI, ok := config.Settings["flowURI"].(string); ok {
+		name = strings.TrimPrefix(flowURI, "res://")
+	}
+	if name == "" {
+		name = config.Ref
+	}
+
+	switch a := act.(type) {
+	case action.SyncAction:
+		return &meteredSyncAction{SyncAction: a, name: name}, nil
+	case action.AsyncAction:
+		return &meteredAsyncAction{AsyncAction: a, name: name}, nil
+	}
+
+	return act, nil
+}
+
+type meteredSyncAction struct {
+	action.SyncAction
+	name string
+}
+
+func (a *meteredSyncAction) Run(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	results, err := a.SyncAction.Run(ctx, input)
+	recordExecution(a.name, start, err)
+	return results, err
+}
+
+type meteredAsyncAction struct {
+	action.AsyncAction
+	name string
+}
+
+func (a *meteredAsyncAction) Run(ctx context.Context, input map[string]interface{}, handler action.ResultHandler) error {
+
+	mh := &meteredResultHandler{ResultHandler: handler, name: a.name, start: time.Now()}
+
+	err := a.AsyncAction.Run(ctx, input, mh)
+	if err != nil {
+		mh.record(err)
+	}
+
+	return err
+}
+
+// meteredResultHandler records the execution of an asynchronous action once it is done
+type meteredResultHandler struct {
+	action.ResultHandler
+	name  string
+	start time.Time
+	err   error
+	once  sync.Once
+}
+
+func (h *meteredResultHandler) HandleResult(results map[string]interface{}, err error) {
+	if err != nil {
+		h.err = err
+	}
+	h.ResultHandler.HandleResult(results, err)
+}
+
+func (h *meteredResultHandler) Done() {
+	h.record(h.err)
+	h.ResultHandler.Done()
+}
+
+func (h *meteredResultHandler) record(err error) {
+	h.once.Do(func() {
+		recordExecution(h.name, h.start, err)
+	})
+}
+
+func writeManagementResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func writeManagementError(w http.ResponseWriter, err error) {
+	if _, ok := err.(*managementError); ok {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+`
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// the variables of the other generated files
+const testManagementMainGo = `package main
+
+var (
+	cfgJson          string
+	cfgCompressed    bool
+	flogoBuildCommit = "abc123"
+	flogoBuildTime   string
+)
+
+func main() {
+}
+`
+
+// the tests of the generated management service, they are run in the package of the generated file
+const testManagementTestGo = `package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/core/data/property"
+)
+
+func serve(s *managementService, method, path, token, body string) *httptest.ResponseRecorder {
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/flows", s.handleFlows)
+	mux.HandleFunc("/props", s.handleProps)
+	mux.HandleFunc("/config", s.handleConfig)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.authorize(mux).ServeHTTP(w, req)
+
+	return w
+}
+
+func expect(t *testing.T, w *httptest.ResponseRecorder, code int, body string) {
+	t.Helper()
+	if w.Code != code || !strings.Contains(w.Body.String(), body) {
+		t.Errorf("expected %d containing %q, got %d: %s", code, body, w.Code, w.Body.String())
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+
+	s := &managementService{}
+
+	expect(t, serve(s, http.MethodGet, "/status", "", ""), http.StatusOK, ` + "`\"commit\":\"abc123\"`" + `)
+
+	t.Setenv("FLOGO_MANAGEMENT_TOKEN", "secret")
+	expect(t, serve(s, http.MethodGet, "/status", "", ""), http.StatusUnauthorized, "unauthorized")
+	expect(t, serve(s, http.MethodGet, "/status", "other", ""), http.StatusUnauthorized, "unauthorized")
+	expect(t, serve(s, http.MethodGet, "/status", "secret", ""), http.StatusOK, ` + "`\"goroutines\"`" + `)
+	expect(t, serve(s, http.MethodPost, "/status", "secret", ""), http.StatusMethodNotAllowed, "")
+}
+
+func TestProps(t *testing.T) {
+
+	property.SetDefaultManager(property.NewManager(map[string]interface{}{"port": 8080, "host": "localhost", "password": "secret"}))
+	s := &managementService{logLevel: "INFO"}
+
+	// the values aren't listed
+	w := serve(s, http.MethodGet, "/props", "", "")
+	expect(t, w, http.StatusOK, ` + "`{\"properties\":[\"host\",\"password\",\"port\"]}`" + `)
+
+	// the values are converted to the type of the property
+	expect(t, serve(s, http.MethodPatch, "/props", "", ` + "`{\"port\": \"9090\"}`" + `), http.StatusOK, ` + "`[\"port\"]`" + `)
+	if port := fmt.Sprint(currentProperties()["port"]); port != "9090" {
+		t.Errorf("expected port 9090, got %v", port)
+	}
+
+	expect(t, serve(s, http.MethodPatch, "/props", "", ` + "`{\"port\": \"abc\"}`" + `), http.StatusBadRequest, "invalid value for property 'port'")
+	expect(t, serve(s, http.MethodPatch, "/props", "", ` + "`{\"other\": 1}`" + `), http.StatusBadRequest, "property 'other' isn't defined by the application")
+	expect(t, serve(s, http.MethodPatch, "/props", "", "{"), http.StatusBadRequest, "invalid properties")
+	expect(t, serve(s, http.MethodDelete, "/props", "", ""), http.StatusMethodNotAllowed, "")
+
+	// the invalid updates aren't applied
+	if port := fmt.Sprint(currentProperties()["port"]); port != "9090" {
+		t.Errorf("expected port 9090, got %v", port)
+	}
+}
+
+func TestConfig(t *testing.T) {
+
+	property.SetDefaultManager(property.NewManager(map[string]interface{}{"host": "localhost"}))
+	s := &managementService{logLevel: "INFO"}
+
+	expect(t, serve(s, http.MethodPatch, "/config", "", ` + "`{\"logLevel\": \"debug\", \"properties\": {\"host\": \"example.com\"}}`" + `), http.StatusOK,
+		` + "`{\"logLevel\":\"DEBUG\",\"properties\":[\"host\"]}`" + `)
+	if host := currentProperties()["host"]; host != "example.com" {
+		t.Errorf("expected host example.com, got %v", host)
+	}
+
+	expect(t, serve(s, http.MethodPatch, "/config", "", ` + "`{\"logLevel\": \"fatal\"}`" + `), http.StatusBadRequest, "invalid log level 'fatal'")
+	expect(t, serve(s, http.MethodGet, "/config", "", ""), http.StatusMethodNotAllowed, "")
+}
+
+func TestFlows(t *testing.T) {
+
+	cfgJson = ` + "`" + `{
+  "name": "myApp", "type": "flogo:app", "version": "1.0.0", "appModel": "1.1.0",
+  "triggers": [
+    {"id": "rest", "ref": "#rest", "handlers": [{"action": {"ref": "#flow", "settings": {"flowURI": "res://flow:main"}}}]},
+    {"id": "timer", "ref": "#timer", "handlers": [{"action": {"id": "shared"}}]}
+  ],
+  "actions": [{"id": "shared", "ref": "#flow", "settings": {"flowURI": "res://flow:main"}}],
+  "resources": [{"id": "flow:main", "data": {"name": "Main"}}, {"id": "flow:aux", "data": {}}, {"id": "schema:data", "data": {}}]
+}` + "`" + `
+
+	flows, err := loadManagementFlows()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &managementService{flows: flows}
+	expect(t, serve(s, http.MethodGet, "/flows", "", ""), http.StatusOK,
+		` + "`[{\"id\":\"flow:main\",\"name\":\"Main\",\"triggers\":[\"rest\",\"timer\"]},{\"id\":\"flow:aux\",\"triggers\":[]}]`" + `)
+
+	// no flows is an empty list
+	expect(t, serve(&managementService{}, http.MethodGet, "/flows", "", ""), http.StatusOK, "[]")
+}
+
+func TestMetrics(t *testing.T) {
+
+	recordExecution("flow:main", time.Now(), nil)
+	recordExecution("flow:main", time.Now(), errors.New("failed"))
+
+	w := serve(&managementService{}, http.MethodGet, "/metrics", "", "")
+	expect(t, w, http.StatusOK, "flogo_flow_executions_total{flow=\"flow:main\"} 2\n")
+	expect(t, w, http.StatusOK, "flogo_flow_errors_total{flow=\"flow:main\"} 1\n")
+	expect(t, w, http.StatusOK, "# TYPE go_goroutines gauge\n")
+}
+`
+
+func TestManagementService(t *testing.T) {
+	t.Log("Testing the generated management service")
+
+	if testing.Short() {
+		t.Skip("the generated service is built and tested using the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command isn't available")
+	}
+
+	// the generated service is built in a package of the module, ignored by ./... since its name starts with _
+	_, file, _, _ := runtime.Caller(0)
+	tempDir, err := ioutil.TempDir(filepath.Dir(file), "_management")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+
+	err = createManagementGoFile(project)
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.SrcDir(), fileManagementGo))
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), `addr = "`+DefaultManagementAddr+`"`)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "main.go"), []byte(testManagementMainGo), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "management_test.go"), []byte(testManagementTestGo), 0644))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = project.SrcDir()
+	out, err := cmd.CombinedOutput()
+	assert.Nil(t, err, string(out))
+
+	err = cleanupManagementGoFile(project)
+	assert.Nil(t, err)
+	assert.False(t, util.FileExists(filepath.Join(project.SrcDir(), fileManagementGo)))
+
+	// there is nothing to clean up without management service
+	err = cleanupManagementGoFile(project)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AppMetrics is a summary of the metrics exposed by the management API of a running application
+type AppMetrics struct {
+	Uptime     float64        `json:"uptime"`
+	Goroutines int            `json:"goroutines"`
+	Memory     uint64         `json:"memory"`
+	Flows      []*FlowMetrics `json:"flows"`
+}
+
+// FlowMetrics are the metrics of the executions of a flow, the durations are in seconds
+type FlowMetrics struct {
+	Flow        string  `json:"flow"`
+	Executions  uint64  `json:"executions"`
+	Errors      uint64  `json:"errors"`
+	DurationAvg float64 `json:"durationAvg"`
+	DurationMax float64 `json:"durationMax"`
+}
+
+// ShowMetrics gets the metrics of a running application and prints a summary of them
+func ShowMetrics(remote RemoteOptions, jsonFormat bool) error {
+
+	var raw []byte
+	err := remoteRequest(remote, http.MethodGet, "/metrics", nil, &raw)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := parseMetrics(raw)
+	if err != nil {
+		return err
+	}
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(metrics, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(resp))
+		return nil
+	}
+
+	fmt.Printf("Uptime    : %s\n", (time.Duration(metrics.Uptime) * time.Second).String())
+	fmt.Printf("Goroutines: %d\n", metrics.Goroutines)
+	fmt.Printf("Memory    : %.1f MB\n", float64(metrics.Memory)/(1024*1024))
+	fmt.Println()
+
+	if len(metrics.Flows) == 0 {
+		fmt.Println("No flows have been executed")
+		return nil
+	}
+
+	fmt.Printf("%-30s %10s %8s %10s %10s\n", "FLOW", "EXECUTIONS", "ERRORS", "AVG", "MAX")
+	for _, flow := range metrics.Flows {
+		fmt.Printf("%-30s %10d %8d %10s %10s\n", flow.Flow, flow.Executions, flow.Errors,
+			formatSeconds(flow.DurationAvg), formatSeconds(flow.DurationMax))
+	}
+
+	return nil
+}
+
+// parseMetrics parses the metrics in the Prometheus text format exposed by the management API
+func parseMetrics(raw []byte) (*AppMetrics, error) {
+
+	metrics := &AppMetrics{Flows: []*FlowMetrics{}}
+	flows := make(map[string]*FlowMetrics)
+	durationSums := make(map[string]float64)
+
+	flowMetrics := func(name string) *FlowMetrics {
+		fm, exists := flows[name]
+		if !exists {
+			fm = &FlowMetrics{Flow: name}
+			flows[name] = fm
+		}
+		return fm
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		idx := strings.LastIndex(line, " ")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid metric '%s'", line)
+		}
+		name, valStr := line[:idx], line[idx+1:]
+		val, err := strconv.ParseFloat(valStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of metric '%s': %v", name, err)
+		}
+
+		flow := ""
+		if start := strings.Index(name, "{"); start > 0 && strings.HasSuffix(name, "}") {
+			flow = metricLabel(name[start+1:len(name)-1], "flow")
+			name = name[:start]
+		}
+
+		switch name {
+		case "flogo_app_uptime_seconds":
+			metrics.Uptime = val
+		case "go_goroutines":
+			metrics.Goroutines = int(val)
+		case "go_memstats_alloc_bytes":
+			metrics.Memory = uint64(val)
+		case "flogo_flow_executions_total":
+			flowMetrics(flow).Executions = uint64(val)
+		case "flogo_flow_errors_total":
+			flowMetrics(flow).Errors = uint64(val)
+		case "flogo_flow_duration_seconds_sum":
+			durationSums[flow] = val
+		case "flogo_flow_duration_seconds_max":
+			flowMetrics(flow).DurationMax = val
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for name, fm := range flows {
+		if fm.Executions > 0 {
+			fm.DurationAvg = durationSums[name] / float64(fm.Executions)
+		}
+		metrics.Flows = append(metrics.Flows, fm)
+	}
+	sort.Slice(metrics.Flows, func(i, j int) bool {
+		return metrics.Flows[i].Flow < metrics.Flows[j].Flow
+	})
+
+	return metrics, nil
+}
+
+// metricLabel gets the value of a label from the labels of a metric, ex. flow="flow:main"
+func metricLabel(labels, label string) string {
+
+	for _, pair := range strings.Split(labels, ",") {
+		idx := strings.Index(pair, "=")
+		if idx < 0 || strings.TrimSpace(pair[:idx]) != label {
+			continue
+		}
+		val, err := strconv.Unquote(strings.TrimSpace(pair[idx+1:]))
+		if err != nil {
+			return ""
+		}
+		return val
+	}
+
+	return ""
+}
+
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetrics(t *testing.T) {
+	t.Log("Testing parsing of the metrics exposed by the management API")
+
+	raw := `# HELP flogo_app_uptime_seconds Time since the application was started.
+# TYPE flogo_app_uptime_seconds gauge
+flogo_app_uptime_seconds 90.5
+go_goroutines 12
+go_memstats_alloc_bytes 1048576
+flogo_flow_executions_total{flow="flow:main"} 4
+flogo_flow_executions_total{flow="flow:other"} 0
+flogo_flow_errors_total{flow="flow:main"} 1
+flogo_flow_duration_seconds_sum{flow="flow:main"} 2
+flogo_flow_duration_seconds_max{flow="flow:main"} 1.25
+`
+
+	metrics, err := parseMetrics([]byte(raw))
+	assert.Nil(t, err)
+	assert.Equal(t, 90.5, metrics.Uptime)
+	assert.Equal(t, 12, metrics.Goroutines)
+	assert.Equal(t, uint64(1048576), metrics.Memory)
+
+	assert.Len(t, metrics.Flows, 2)
+	main := metrics.Flows[0]
+	assert.Equal(t, "flow:main", main.Flow)
+	assert.Equal(t, uint64(4), main.Executions)
+	assert.Equal(t, uint64(1), main.Errors)
+	assert.Equal(t, 0.5, main.DurationAvg)
+	assert.Equal(t, 1.25, main.DurationMax)
+
+	other := metrics.Flows[1]
+	assert.Equal(t, "flow:other", other.Flow)
+	assert.Equal(t, 0.0, other.DurationAvg)
+
+	_, err = parseMetrics([]byte("go_goroutines abc"))
+	assert.NotNil(t, err)
+}
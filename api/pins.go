@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	pinDateLayout  = "2006-01-02"
+	defaultPinDays = 30
+	pinExpiresSoon = 7 * 24 * time.Hour
+)
+
+// LockPin is a temporary exception to the resolution of a module, ex. a downgrade working around a broken release,
+// recorded in flogo.lock until it expires
+type LockPin struct {
+	Version string `json:"version"`
+	Reason  string `json:"reason"`
+	Pinned  string `json:"pinned"`  // the date the pin was recorded, as YYYY-MM-DD
+	Expires string `json:"expires"` // the date the pin expires, as YYYY-MM-DD
+}
+
+// PinOptions are the options used to pin a module
+type PinOptions struct {
+	Reason  string // why the module is pinned, required
+	Expires string // the date the pin expires, as YYYY-MM-DD, in 30 days by default
+}
+
+// expired determines if the pin is expired at the time, a pin is valid until the end of its expiry date
+func (p *LockPin) expired(now time.Time) bool {
+	expires, err := time.Parse(pinDateLayout, p.Expires)
+	return err != nil || !now.UTC().Before(expires.AddDate(0, 0, 1))
+}
+
+// PinModule requires the version of the module, ex. to downgrade a broken contribution, and records the pin with its
+// reason and expiry in flogo.lock, so 'flogo upgrade' keeps the version and 'flogo outdated' and 'flogo doctor' nag
+// once the pin expires
+func PinModule(ctx context.Context, project common.AppProject, modVer string, options PinOptions) error {
+
+	module, version := splitModuleVersion(modVer)
+	if module == "" || version == "" {
+		return fmt.Errorf("invalid module '%s', expected <module>@<version>", modVer)
+	}
+	if options.Reason == "" {
+		return fmt.Errorf("the reason of the pin must be specified")
+	}
+
+	now := time.Now().UTC()
+	pin := &LockPin{Version: version, Reason: options.Reason, Pinned: now.Format(pinDateLayout), Expires: options.Expires}
+	if pin.Expires == "" {
+		pin.Expires = now.AddDate(0, 0, defaultPinDays).Format(pinDateLayout)
+	}
+	if _, err := time.Parse(pinDateLayout, pin.Expires); err != nil {
+		return fmt.Errorf("invalid expiry date '%s', expected YYYY-MM-DD", pin.Expires)
+	}
+	if pin.expired(now) {
+		return fmt.Errorf("the expiry date %s is in the past", pin.Expires)
+	}
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = project.DepManager().Get(ctx, module+"@"+version)
+	if err != nil {
+		return fmt.Errorf("unable to require %s@%s: %v", module, version, err)
+	}
+
+	lock, err := resolveProjectLock(project)
+	if err != nil {
+		return err
+	}
+	lock.Pins, err = projectPins(project)
+	if err != nil {
+		return err
+	}
+	lock.Pins[module] = pin
+
+	err = writeLockFile(project, lock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pinned %s at %s until %s\n", module, version, pin.Expires)
+
+	return nil
+}
+
+// UnpinModule removes the pin of the module from flogo.lock, the version required by go.mod is kept
+func UnpinModule(project common.AppProject, module string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	module, _ = splitModuleVersion(module)
+
+	lock, err := readProjectLock(project)
+	if err != nil {
+		return err
+	}
+	if lock == nil || lock.Pins[module] == nil {
+		return fmt.Errorf("%s isn't pinned", module)
+	}
+	delete(lock.Pins, module)
+
+	err = writeLockFile(project, lock)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Unpinned %s, run 'flogo upgrade' to upgrade it\n", module)
+
+	return nil
+}
+
+// ListPins lists the pins of flogo.lock
+func ListPins(project common.AppProject) error {
+
+	pins, err := projectPins(project)
+	if err != nil {
+		return err
+	}
+
+	if len(pins) == 0 {
+		fmt.Println("No module is pinned")
+		return nil
+	}
+
+	now := time.Now()
+	fmt.Printf("%-60s %-20s %-20s %s\n", "MODULE", "VERSION", "EXPIRES", "REASON")
+	for _, module := range sortedPinModules(pins) {
+		pin := pins[module]
+		expires := pin.Expires
+		if pin.expired(now) {
+			expires += " (expired)"
+		}
+		fmt.Printf("%-60s %-20s %-20s %s\n", module, pin.Version, expires, pin.Reason)
+	}
+
+	return nil
+}
+
+// projectPins gets the pins of the flogo.lock of the project, keyed by module
+func projectPins(project common.AppProject) (map[string]*LockPin, error) {
+
+	lock, err := readProjectLock(project)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil || lock.Pins == nil {
+		return make(map[string]*LockPin), nil
+	}
+
+	return lock.Pins, nil
+}
+
+// pinWarnings nags about the pins which are expired, expire within a week or no longer match the version required
+// by go.mod
+func pinWarnings(pins map[string]*LockPin, requires map[string]string, now time.Time) []string {
+
+	var warnings []string
+	for _, module := range sortedPinModules(pins) {
+		pin := pins[module]
+		switch {
+		case pin.expired(now):
+			warnings = append(warnings, fmt.Sprintf("the pin of %s at %s expired on %s (%s), upgrade it and run 'flogo imports unpin %s', or extend the pin", module, pin.Version, pin.Expires, pin.Reason, module))
+		case pin.expired(now.Add(pinExpiresSoon)):
+			warnings = append(warnings, fmt.Sprintf("the pin of %s at %s expires on %s (%s)", module, pin.Version, pin.Expires, pin.Reason))
+		}
+		if version, required := requires[module]; required && version != pin.Version {
+			warnings = append(warnings, fmt.Sprintf("%s is pinned at %s but go.mod requires %s", module, pin.Version, version))
+		}
+	}
+
+	return warnings
+}
+
+func sortedPinModules(pins map[string]*LockPin) []string {
+
+	var modules []string
+	for module := range pins {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	return modules
+}
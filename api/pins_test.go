@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinWarnings(t *testing.T) {
+	t.Log("Testing the warnings of the pins of flogo.lock")
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	pins := map[string]*LockPin{
+		"github.com/project-flogo/contrib": {Version: "v0.9.0", Reason: "v0.10.0 breaks the log activity", Expires: "2026-03-09"},
+		"github.com/project-flogo/flow":    {Version: "v0.9.4", Reason: "issue #12", Expires: "2026-03-10"},
+		"github.com/project-flogo/stream":  {Version: "v0.3.0", Reason: "issue #7", Expires: "2026-03-14"},
+		"github.com/project-flogo/edge":    {Version: "v0.1.0", Reason: "issue #3", Expires: "2026-06-01"},
+	}
+	requires := map[string]string{
+		"github.com/project-flogo/contrib": "v0.9.0",
+		"github.com/project-flogo/flow":    "v0.9.4",
+		"github.com/project-flogo/edge":    "v0.2.0",
+	}
+
+	// a pin is valid until the end of its expiry date
+	assert.True(t, pins["github.com/project-flogo/contrib"].expired(now))
+	assert.False(t, pins["github.com/project-flogo/flow"].expired(now))
+
+	assert.Equal(t, []string{
+		"the pin of github.com/project-flogo/contrib at v0.9.0 expired on 2026-03-09 (v0.10.0 breaks the log activity), upgrade it and run 'flogo imports unpin github.com/project-flogo/contrib', or extend the pin",
+		"github.com/project-flogo/edge is pinned at v0.1.0 but go.mod requires v0.2.0",
+		"the pin of github.com/project-flogo/flow at v0.9.4 expires on 2026-03-10 (issue #12)",
+		"the pin of github.com/project-flogo/stream at v0.3.0 expires on 2026-03-14 (issue #7)",
+	}, pinWarnings(pins, requires, now))
+
+	upgrades := []*contribUpgrade{
+		{Module: "github.com/project-flogo/contrib", From: "v0.9.0", To: "v0.10.1", ToModule: "github.com/project-flogo/contrib"},
+		{Module: "github.com/project-flogo/flow", From: "v0.9.4", To: "v0.10.0", ToModule: "github.com/project-flogo/flow"},
+	}
+	kept := skipPinnedUpgrades(upgrades, pins, now)
+	assert.Len(t, kept, 1)
+	assert.Equal(t, "github.com/project-flogo/contrib", kept[0].Module)
+}
+
+func TestProjectLockKeepsPins(t *testing.T) {
+	t.Log("Testing that the pins are kept when flogo.lock is written")
+
+	tempDir, err := ioutil.TempDir("", "flogo-pins")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(`{"name": "myApp", "imports": ["github.com/project-flogo/flow"]}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "go.mod"), []byte("module main\n\nrequire github.com/project-flogo/flow v0.9.4\n"), 0644))
+
+	pin := &LockPin{Version: "v0.9.4", Reason: "issue #12", Pinned: "2026-03-01", Expires: "2026-04-01"}
+	err = writeLockFile(project, &FlogoLock{Imports: map[string]*LockedImport{}, Pins: map[string]*LockPin{"github.com/project-flogo/flow": pin}})
+	assert.Nil(t, err)
+
+	err = WriteProjectLock(project)
+	assert.Nil(t, err)
+
+	lock, err := readProjectLock(project)
+	assert.Nil(t, err)
+	assert.Len(t, lock.Imports, 1)
+	assert.Equal(t, pin, lock.Pins["github.com/project-flogo/flow"])
+
+	err = UnpinModule(project, "github.com/project-flogo/flow")
+	assert.Nil(t, err)
+	assert.NotNil(t, UnpinModule(project, "github.com/project-flogo/flow"))
+
+	lock, err = readProjectLock(project)
+	assert.Nil(t, err)
+	assert.Len(t, lock.Pins, 0)
+
+	assert.NotNil(t, PinModule(context.Background(), project, "github.com/project-flogo/flow", PinOptions{Reason: "issue #12"}))
+	assert.NotNil(t, PinModule(context.Background(), project, "github.com/project-flogo/flow@v0.9.4", PinOptions{}))
+	assert.NotNil(t, PinModule(context.Background(), project, "github.com/project-flogo/flow@v0.9.4", PinOptions{Reason: "issue #12", Expires: "2020-01-01"}))
+}
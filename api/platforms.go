@@ -0,0 +1,198 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const platformVariantMusl = "musl"
+
+var platformPartPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// BuildPlatform is a platform to build for, as os/arch, with an optional variant selecting a preset, ex. linux/amd64-musl
+type BuildPlatform struct {
+	OS      string
+	Arch    string
+	Variant string
+}
+
+func (p *BuildPlatform) String() string {
+	if p.Variant != "" {
+		return p.OS + "/" + p.Arch + "-" + p.Variant
+	}
+	return p.OS + "/" + p.Arch
+}
+
+// platformPreset is the configuration of the build of a variant of a platform
+type platformPreset struct {
+	OS   string   // the operating system the variant applies to
+	Env  []string // the environment of the build
+	Tags []string // the build tags
+}
+
+var platformPresets = map[string]*platformPreset{
+	// a static executable, which doesn't depend on the C library, so it runs on musl based distributions such as Alpine
+	platformVariantMusl: {OS: "linux", Env: []string{"CGO_ENABLED=0"}, Tags: []string{"netgo", "osusergo"}},
+}
+
+// the first versions of Go supporting the platforms added after the support of modules
+var platformMinGoVersions = map[string]string{
+	"darwin/arm64":  "1.16",
+	"windows/arm64": "1.17",
+}
+
+// ParsePlatforms parses the platforms to build for, as os/arch or os/arch-variant, the duplicates are removed
+func ParsePlatforms(platforms []string) ([]*BuildPlatform, error) {
+
+	var parsed []*BuildPlatform
+	seen := make(map[string]bool)
+	for _, platform := range platforms {
+		platform = strings.TrimSpace(platform)
+		if seen[platform] {
+			continue
+		}
+		seen[platform] = true
+
+		parts := strings.Split(platform, "/")
+		if len(parts) != 2 || !platformPartPattern.MatchString(parts[0]) {
+			return nil, fmt.Errorf("invalid platform '%s', expected os/arch (ex. linux/amd64), see 'go tool dist list'", platform)
+		}
+
+		p := &BuildPlatform{OS: parts[0], Arch: parts[1]}
+		if idx := strings.Index(p.Arch, "-"); idx >= 0 {
+			p.Arch, p.Variant = p.Arch[:idx], p.Arch[idx+1:]
+		}
+		if !platformPartPattern.MatchString(p.Arch) {
+			return nil, fmt.Errorf("invalid platform '%s', expected os/arch (ex. linux/amd64), see 'go tool dist list'", platform)
+		}
+
+		if p.Variant != "" {
+			preset, exists := platformPresets[p.Variant]
+			if !exists {
+				return nil, fmt.Errorf("invalid platform '%s', unknown variant '%s', expected: %s", platform, p.Variant, strings.Join(platformVariants(), ", "))
+			}
+			if preset.OS != p.OS {
+				return nil, fmt.Errorf("invalid platform '%s', the %s variant only applies to %s", platform, p.Variant, preset.OS)
+			}
+		}
+
+		parsed = append(parsed, p)
+	}
+
+	return parsed, nil
+}
+
+func platformVariants() []string {
+	var variants []string
+	for variant := range platformPresets {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+	return variants
+}
+
+// platformBuild gets the arguments and the environment of the go build of the executable for the platform
+func platformBuild(platform *BuildPlatform, exe string, tags []string, ldflags string) ([]string, []string) {
+
+	env := []string{"GOOS=" + platform.OS, "GOARCH=" + platform.Arch}
+	if preset := platformPresets[platform.Variant]; preset != nil {
+		env = append(env, preset.Env...)
+		tags = append(append([]string{}, tags...), preset.Tags...)
+	}
+
+	args := []string{"build", "-o", exe}
+	if len(tags) > 0 {
+		args = append(args, "-tags", strings.Join(tags, ","))
+	}
+	if ldflags != "" {
+		args = append(args, "-ldflags", ldflags)
+	}
+
+	return args, env
+}
+
+// checkPlatformSupport checks that the installed version of Go supports the platform
+func checkPlatformSupport(platform *BuildPlatform, installedGo string) error {
+
+	minVersion, exists := platformMinGoVersions[platform.OS+"/"+platform.Arch]
+	if !exists || installedGo == "" || compareGoVersions(installedGo, minVersion) >= 0 {
+		return nil
+	}
+
+	return fmt.Errorf("go %s or later is required to build for %s/%s, go %s is installed", minVersion, platform.OS, platform.Arch, installedGo)
+}
+
+// isCgoDisabled determines if cgo is disabled when building for the platform: by its preset, or because Go disables
+// it when cross-compiling unless it is enabled explicitly
+func isCgoDisabled(platform *BuildPlatform) bool {
+
+	if preset := platformPresets[platform.Variant]; preset != nil {
+		for _, env := range preset.Env {
+			if env == "CGO_ENABLED=0" {
+				return true
+			}
+		}
+	}
+
+	switch os.Getenv("CGO_ENABLED") {
+	case "0":
+		return true
+	case "1":
+		return false
+	}
+
+	return platform.OS != runtime.GOOS || platform.Arch != runtime.GOARCH
+}
+
+// platformWarnings warns about the modules of the application which use cgo, when it is disabled for the platform:
+// their build fails or they lack the features implemented in C
+func platformWarnings(project common.AppProject, platform *BuildPlatform) []string {
+
+	if !isCgoDisabled(platform) {
+		return nil
+	}
+
+	cmd := exec.Command("go", "list", "-deps", "-f", "{{if and .CgoFiles (not .Standard)}}{{.ImportPath}}{{end}}", ".")
+	cmd.Dir = project.SrcDir()
+	cmd.Env = append(util.GoCmdEnv(project.Dir()), "GOOS="+platform.OS, "GOARCH="+platform.Arch, "CGO_ENABLED=1")
+	out, err := cmd.Output()
+	if err != nil {
+		// the build reports the errors
+		return nil
+	}
+
+	return cgoWarnings(platform, strings.Fields(string(out)), goModRequirements(project.SrcDir()))
+}
+
+// cgoWarnings warns about the modules providing the packages using cgo
+func cgoWarnings(platform *BuildPlatform, cgoPkgs []string, requires map[string]string) []string {
+
+	var modules []string
+	seen := make(map[string]bool)
+	for _, pkg := range cgoPkgs {
+		module, _ := requiredModule(requires, pkg)
+		if module == "" {
+			module = pkg
+		}
+		if !seen[module] {
+			seen[module] = true
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+
+	var warnings []string
+	for _, module := range modules {
+		warnings = append(warnings, fmt.Sprintf("%s uses cgo, which is disabled for %s, its build may fail or it may lack features", module, platform))
+	}
+
+	return warnings
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	t.Log("Testing parsing of the platforms to build for")
+
+	platforms, err := ParsePlatforms([]string{"linux/amd64", " darwin/arm64", "linux/amd64", "windows/arm64", "linux/amd64-musl"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*BuildPlatform{{OS: "linux", Arch: "amd64"}, {OS: "darwin", Arch: "arm64"}, {OS: "windows", Arch: "arm64"},
+		{OS: "linux", Arch: "amd64", Variant: "musl"}}, platforms)
+	assert.Equal(t, "linux/amd64-musl", platforms[3].String())
+
+	for _, platform := range []string{"linux", "linux/amd64/v2", "Linux/AMD64", "linux/-musl", "linux/amd64-glibc", "windows/amd64-musl"} {
+		_, err = ParsePlatforms([]string{platform})
+		assert.NotNil(t, err, platform)
+	}
+}
+
+func TestPlatformExecutable(t *testing.T) {
+	t.Log("Testing naming of the executables built for a platform")
+
+	project := NewAppProject(filepath.Join("apps", "myApp"))
+
+	assert.Equal(t, filepath.Join("apps", "myApp", "bin", "myApp-linux-arm64"), PlatformExecutable(project, &BuildPlatform{OS: "linux", Arch: "arm64"}))
+	assert.Equal(t, filepath.Join("apps", "myApp", "bin", "myApp-windows-amd64.exe"), PlatformExecutable(project, &BuildPlatform{OS: "windows", Arch: "amd64"}))
+	assert.Equal(t, filepath.Join("apps", "myApp", "bin", "myApp-windows-arm64.exe"), PlatformExecutable(project, &BuildPlatform{OS: "windows", Arch: "arm64"}))
+	assert.Equal(t, filepath.Join("apps", "myApp", "bin", "myApp-linux-amd64-musl"), PlatformExecutable(project, &BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"}))
+}
+
+func TestPlatformBuild(t *testing.T) {
+	t.Log("Testing the build of the presets of the platforms")
+
+	args, env := platformBuild(&BuildPlatform{OS: "windows", Arch: "arm64"}, "myApp.exe", nil, "")
+	assert.Equal(t, []string{"build", "-o", "myApp.exe"}, args)
+	assert.Equal(t, []string{"GOOS=windows", "GOARCH=arm64"}, env)
+
+	tags := []string{"kafka"}
+	args, env = platformBuild(&BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"}, "myApp", tags, "-X main.v=1")
+	assert.Equal(t, []string{"build", "-o", "myApp", "-tags", "kafka,netgo,osusergo", "-ldflags", "-X main.v=1"}, args)
+	assert.Equal(t, []string{"GOOS=linux", "GOARCH=amd64", "CGO_ENABLED=0"}, env)
+	assert.Equal(t, []string{"kafka"}, tags)
+
+	assert.True(t, isCgoDisabled(&BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"}))
+}
+
+func TestCheckPlatformSupport(t *testing.T) {
+	t.Log("Testing the versions of Go required by the platforms")
+
+	windowsArm := &BuildPlatform{OS: "windows", Arch: "arm64"}
+	assert.NotNil(t, checkPlatformSupport(windowsArm, "1.16.5"))
+	assert.Nil(t, checkPlatformSupport(windowsArm, "1.17"))
+	assert.Nil(t, checkPlatformSupport(windowsArm, ""))
+	assert.Nil(t, checkPlatformSupport(&BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"}, "1.12"))
+}
+
+func TestCgoWarnings(t *testing.T) {
+	t.Log("Testing the warnings about the modules using cgo")
+
+	requires := map[string]string{"github.com/mattn/go-sqlite3": "v1.14.0", "github.com/project-flogo/contrib": "v0.10.0"}
+	warnings := cgoWarnings(&BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"},
+		[]string{"github.com/mattn/go-sqlite3", "github.com/project-flogo/contrib/activity/sqlite", "github.com/project-flogo/contrib/activity/sqlite/internal"}, requires)
+
+	assert.Equal(t, []string{
+		"github.com/mattn/go-sqlite3 uses cgo, which is disabled for linux/amd64-musl, its build may fail or it may lack features",
+		"github.com/project-flogo/contrib uses cgo, which is disabled for linux/amd64-musl, its build may fail or it may lack features",
+	}, warnings)
+}
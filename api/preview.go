@@ -0,0 +1,377 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	previewTaskWidth  = 180
+	previewTaskHeight = 50
+	previewColWidth   = 240
+	previewRowHeight  = 80
+	previewMargin     = 20
+)
+
+type previewApp struct {
+	Name        string
+	Version     string
+	Description string
+	Triggers    []*previewTrigger
+	Flows       []*previewFlow
+}
+
+type previewTrigger struct {
+	Id       string
+	Ref      string
+	Enabled  bool
+	Settings string
+	Handlers []*previewHandler
+}
+
+type previewHandler struct {
+	Name     string
+	Settings string
+	Flow     string
+	Anchor   string
+}
+
+type previewFlow struct {
+	Id           string
+	Anchor       string
+	Name         string
+	Graph        *previewGraph
+	ErrorHandler *previewGraph
+}
+
+type previewGraph struct {
+	Width  int
+	Height int
+	Tasks  []*previewTask
+	Links  []*previewLink
+}
+
+type previewTask struct {
+	Id    string
+	Name  string
+	Ref   string
+	X, Y  int
+	level int
+	row   int
+}
+
+type previewLink struct {
+	X1, Y1, X2, Y2 int
+	LX, LY         int
+	Label          string
+}
+
+// ServePreview serves a read-only web page on localhost rendering the app's triggers and flows, the
+// app descriptor is read on every request so the page reflects its current state
+func ServePreview(project common.AppProject, port int) error {
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving preview of '%s' at http://%s/\n", project.Name(), listener.Addr())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		appObj, err := readAppDescriptorObj(project)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = previewTemplate.Execute(w, newPreviewApp(appObj))
+		if err != nil && Verbose() {
+			fmt.Printf("Error rendering preview: %v\n", err)
+		}
+	})
+
+	return http.Serve(listener, mux)
+}
+
+func newPreviewApp(appObj map[string]interface{}) *previewApp {
+
+	app := &previewApp{}
+	app.Name, _ = appObj["name"].(string)
+	app.Version, _ = appObj["version"].(string)
+	app.Description, _ = appObj["description"].(string)
+
+	addTriggers := func(section string, enabled bool) {
+		triggers, _ := appObj[section].([]interface{})
+		for _, trg := range triggers {
+			trgMap, ok := trg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			pt := &previewTrigger{Enabled: enabled, Settings: previewJSON(trgMap["settings"])}
+			pt.Id, _ = trgMap["id"].(string)
+			pt.Ref, _ = trgMap["ref"].(string)
+
+			handlers, _ := trgMap["handlers"].([]interface{})
+			for i, h := range handlers {
+				hMap, ok := h.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ph := &previewHandler{Name: handlerName(h, i), Settings: previewJSON(hMap["settings"])}
+
+				setFlow := func(settings map[string]interface{}, flowURI string) {
+					ph.Flow = strings.TrimPrefix(flowURI, resURIPrefix)
+					ph.Anchor = previewAnchor(ph.Flow)
+				}
+				visitActionFlowURI(hMap["action"], setFlow)
+				actions, _ := hMap["actions"].([]interface{})
+				for _, action := range actions {
+					visitActionFlowURI(action, setFlow)
+				}
+
+				pt.Handlers = append(pt.Handlers, ph)
+			}
+
+			app.Triggers = append(app.Triggers, pt)
+		}
+	}
+	addTriggers("triggers", true)
+	addTriggers(sectionDisabledTriggers, false)
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, ok := res.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := resMap["id"].(string)
+		if !strings.HasPrefix(id, flowResPrefix) {
+			continue
+		}
+
+		data, _ := resMap["data"].(map[string]interface{})
+		flow := &previewFlow{Id: id, Anchor: previewAnchor(id), Graph: newPreviewGraph(data)}
+		flow.Name, _ = data["name"].(string)
+		if flow.Name == "" {
+			flow.Name = strings.TrimPrefix(id, flowResPrefix)
+		}
+
+		if errorHandler, ok := data["errorHandler"].(map[string]interface{}); ok {
+			flow.ErrorHandler = newPreviewGraph(errorHandler)
+		}
+
+		app.Flows = append(app.Flows, flow)
+	}
+
+	sort.Slice(app.Flows, func(i, j int) bool {
+		return app.Flows[i].Id < app.Flows[j].Id
+	})
+
+	return app
+}
+
+// newPreviewGraph lays out the tasks of a flow in columns, each task is placed in the column following
+// the columns of the tasks linking to it
+func newPreviewGraph(data map[string]interface{}) *previewGraph {
+
+	graph := &previewGraph{}
+
+	tasksById := make(map[string]*previewTask)
+	tasks, _ := data["tasks"].([]interface{})
+	for _, task := range tasks {
+		taskMap, ok := task.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pt := &previewTask{}
+		pt.Id, _ = taskMap["id"].(string)
+		pt.Name, _ = taskMap["name"].(string)
+		if pt.Name == "" {
+			pt.Name = pt.Id
+		}
+		if activity, ok := taskMap["activity"].(map[string]interface{}); ok {
+			pt.Ref, _ = activity["ref"].(string)
+		}
+		graph.Tasks = append(graph.Tasks, pt)
+		tasksById[pt.Id] = pt
+	}
+
+	type link struct {
+		from, to *previewTask
+		label    string
+	}
+
+	var links []*link
+	linkObjs, _ := data["links"].([]interface{})
+	for _, l := range linkObjs {
+		lMap, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		from, _ := lMap["from"].(string)
+		to, _ := lMap["to"].(string)
+		if tasksById[from] == nil || tasksById[to] == nil {
+			continue
+		}
+		label, _ := lMap["value"].(string)
+		if lType, _ := lMap["type"].(string); lType == "exprOtherwise" {
+			label = "otherwise"
+		}
+		links = append(links, &link{from: tasksById[from], to: tasksById[to], label: label})
+	}
+
+	// relax the levels, bounded by the number of tasks in case the links contain a cycle
+	for i := 0; i < len(graph.Tasks); i++ {
+		changed := false
+		for _, l := range links {
+			if l.to.level < l.from.level+1 {
+				l.to.level = l.from.level + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	rows := make(map[int]int)
+	maxLevel, maxRow := 0, 0
+	for _, task := range graph.Tasks {
+		task.row = rows[task.level]
+		rows[task.level]++
+		task.X = previewMargin + task.level*previewColWidth
+		task.Y = previewMargin + task.row*previewRowHeight
+		if task.level > maxLevel {
+			maxLevel = task.level
+		}
+		if task.row > maxRow {
+			maxRow = task.row
+		}
+	}
+
+	for _, l := range links {
+		pl := &previewLink{
+			X1: l.from.X + previewTaskWidth, Y1: l.from.Y + previewTaskHeight/2,
+			X2: l.to.X, Y2: l.to.Y + previewTaskHeight/2,
+			Label: l.label,
+		}
+		pl.LX, pl.LY = (pl.X1+pl.X2)/2, (pl.Y1+pl.Y2)/2
+		graph.Links = append(graph.Links, pl)
+	}
+
+	graph.Width = 2*previewMargin + maxLevel*previewColWidth + previewTaskWidth
+	graph.Height = 2*previewMargin + maxRow*previewRowHeight + previewTaskHeight
+
+	return graph
+}
+
+// previewAnchor gets the id of the element of a flow
+func previewAnchor(flowId string) string {
+	return strings.Replace(flowId, ":", "-", -1)
+}
+
+func previewJSON(val interface{}) string {
+
+	if val == nil {
+		return ""
+	}
+	if m, ok := val.(map[string]interface{}); ok && len(m) == 0 {
+		return ""
+	}
+
+	buf, err := json.MarshalIndent(val, "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(buf)
+}
+
+var previewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} - flogo preview</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 small { color: #888; font-weight: normal; }
+.trigger, .flow { border: 1px solid #ccc; border-radius: 4px; padding: 1em; margin-bottom: 1em; }
+.disabled { opacity: 0.5; }
+.ref { color: #666; font-family: monospace; }
+pre { background: #f6f6f6; padding: 0.5em; overflow: auto; }
+svg rect { fill: #eef4fb; stroke: #4a7ab5; }
+svg text { font-size: 12px; }
+svg line { stroke: #4a7ab5; }
+svg .label { fill: #a05a00; font-size: 10px; }
+</style>
+</head>
+<body>
+<h1>{{.Name}} <small>{{.Version}}</small></h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+
+<h2>Triggers</h2>
+{{range .Triggers}}
+<div class="trigger{{if not .Enabled}} disabled{{end}}">
+<h3>{{.Id}}{{if not .Enabled}} (disabled){{end}}</h3>
+<div class="ref">{{.Ref}}</div>
+{{if .Settings}}<pre>{{.Settings}}</pre>{{end}}
+{{range .Handlers}}
+<h4>Handler: {{.Name}}{{if .Flow}} &rarr; <a href="#{{.Anchor}}">{{.Flow}}</a>{{end}}</h4>
+{{if .Settings}}<pre>{{.Settings}}</pre>{{end}}
+{{end}}
+</div>
+{{else}}
+<p>No triggers</p>
+{{end}}
+
+<h2>Flows</h2>
+{{range .Flows}}
+<div class="flow" id="{{.Anchor}}">
+<h3>{{.Name}} <span class="ref">{{.Id}}</span></h3>
+{{template "graph" .Graph}}
+{{if .ErrorHandler}}{{if .ErrorHandler.Tasks}}<h4>Error Handler</h4>{{template "graph" .ErrorHandler}}{{end}}{{end}}
+</div>
+{{else}}
+<p>No flows</p>
+{{end}}
+</body>
+</html>
+
+{{define "graph"}}
+{{if .Tasks}}
+<svg width="{{.Width}}" height="{{.Height}}">
+<defs><marker id="arrow" markerWidth="10" markerHeight="10" refX="9" refY="3" orient="auto"><path d="M0,0 L0,6 L9,3 z" fill="#4a7ab5"/></marker></defs>
+{{range .Links}}
+<line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" marker-end="url(#arrow)"/>
+{{if .Label}}<text class="label" x="{{.LX}}" y="{{.LY}}" dy="-4">{{.Label}}</text>{{end}}
+{{end}}
+{{range .Tasks}}
+<g>
+<title>{{.Ref}}</title>
+<rect x="{{.X}}" y="{{.Y}}" width="180" height="50" rx="4"/>
+<text x="{{.X}}" y="{{.Y}}" dx="8" dy="20">{{.Name}}</text>
+<text class="ref" x="{{.X}}" y="{{.Y}}" dx="8" dy="38">{{.Ref}}</text>
+</g>
+{{end}}
+</svg>
+{{else}}
+<p>No tasks</p>
+{{end}}
+{{end}}
+`))
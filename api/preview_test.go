@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const previewAppJson = `{
+  "name": "myApp",
+  "version": "1.0.0",
+  "description": "<script>alert(1)</script>",
+  "triggers": [{"id": "rest", "ref": "#rest", "settings": {"port": 8080},
+    "handlers": [{"name": "get", "settings": {"method": "GET"}, "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:main"}}}, {}]}],
+  "disabledTriggers": [{"id": "timer", "ref": "#timer", "settings": {}}],
+  "resources": [
+    {"id": "flow:main", "data": {"name": "Main",
+      "tasks": [{"id": "log", "activity": {"ref": "#log"}}, {"id": "ok", "name": "Ok"}, {"id": "ko", "name": "Ko"}, {"id": "end"}],
+      "links": [{"from": "log", "to": "ok", "type": "expression", "value": "$.ok"}, {"from": "log", "to": "ko", "type": "exprOtherwise"},
+        {"from": "ok", "to": "end"}, {"from": "ko", "to": "end"}, {"from": "log", "to": "unknown"}],
+      "errorHandler": {"tasks": [{"id": "error"}]}}},
+    {"id": "flow:aux", "data": {}},
+    {"id": "schema:data", "data": {}}
+  ]
+}`
+
+func TestNewPreviewApp(t *testing.T) {
+	t.Log("Testing the preview of the triggers and flows of the app")
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(previewAppJson), &appObj)
+	assert.Nil(t, err)
+
+	app := newPreviewApp(appObj)
+	assert.Equal(t, "myApp", app.Name)
+	assert.Equal(t, "1.0.0", app.Version)
+
+	assert.Len(t, app.Triggers, 2)
+	rest := app.Triggers[0]
+	assert.True(t, rest.Enabled)
+	assert.Equal(t, "#rest", rest.Ref)
+	assert.Equal(t, "{\n  \"port\": 8080\n}", rest.Settings)
+	assert.Len(t, rest.Handlers, 2)
+	assert.Equal(t, "get", rest.Handlers[0].Name)
+	assert.Equal(t, "flow:main", rest.Handlers[0].Flow)
+	assert.Equal(t, "flow-main", rest.Handlers[0].Anchor)
+	// handlers without name are named by their index
+	assert.Equal(t, "1", rest.Handlers[1].Name)
+	assert.Equal(t, "", rest.Handlers[1].Flow)
+
+	timer := app.Triggers[1]
+	assert.False(t, timer.Enabled)
+	assert.Equal(t, "", timer.Settings)
+
+	// only the flows are previewed, sorted by id
+	assert.Len(t, app.Flows, 2)
+	assert.Equal(t, "flow:aux", app.Flows[0].Id)
+	assert.Equal(t, "aux", app.Flows[0].Name)
+	assert.Nil(t, app.Flows[0].ErrorHandler)
+	assert.Equal(t, "Main", app.Flows[1].Name)
+	assert.NotNil(t, app.Flows[1].ErrorHandler)
+	assert.Len(t, app.Flows[1].ErrorHandler.Tasks, 1)
+}
+
+func TestNewPreviewGraph(t *testing.T) {
+	t.Log("Testing the layout of the tasks of a flow")
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(previewAppJson), &appObj)
+	assert.Nil(t, err)
+
+	data := appObj["resources"].([]interface{})[0].(map[string]interface{})["data"].(map[string]interface{})
+	graph := newPreviewGraph(data)
+
+	// each task is in the column following the tasks linking to it, the tasks of a column are in rows
+	positions := make(map[string][2]int)
+	for _, task := range graph.Tasks {
+		positions[task.Id] = [2]int{task.X, task.Y}
+	}
+	assert.Equal(t, [2]int{previewMargin, previewMargin}, positions["log"])
+	assert.Equal(t, [2]int{previewMargin + previewColWidth, previewMargin}, positions["ok"])
+	assert.Equal(t, [2]int{previewMargin + previewColWidth, previewMargin + previewRowHeight}, positions["ko"])
+	assert.Equal(t, [2]int{previewMargin + 2*previewColWidth, previewMargin}, positions["end"])
+
+	assert.Equal(t, 2*previewMargin+2*previewColWidth+previewTaskWidth, graph.Width)
+	assert.Equal(t, 2*previewMargin+previewRowHeight+previewTaskHeight, graph.Height)
+
+	// the links to unknown tasks are ignored
+	assert.Len(t, graph.Links, 4)
+	assert.Equal(t, "$.ok", graph.Links[0].Label)
+	assert.Equal(t, "otherwise", graph.Links[1].Label)
+	assert.Equal(t, previewMargin+previewTaskWidth, graph.Links[0].X1)
+	assert.Equal(t, previewMargin+previewColWidth, graph.Links[0].X2)
+	assert.Equal(t, "#log", graph.Tasks[0].Ref)
+	assert.Equal(t, "log", graph.Tasks[0].Name)
+}
+
+func TestNewPreviewGraphCycle(t *testing.T) {
+	t.Log("Testing the layout of the tasks of a flow whose links contain a cycle")
+
+	var data map[string]interface{}
+	err := json.Unmarshal([]byte(`{"tasks": [{"id": "a"}, {"id": "b"}], "links": [{"from": "a", "to": "b"}, {"from": "b", "to": "a"}]}`), &data)
+	assert.Nil(t, err)
+
+	graph := newPreviewGraph(data)
+	assert.Len(t, graph.Tasks, 2)
+	assert.Len(t, graph.Links, 2)
+}
+
+func TestPreviewTemplate(t *testing.T) {
+	t.Log("Testing the rendering of the preview page")
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(previewAppJson), &appObj)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	err = previewTemplate.Execute(&buf, newPreviewApp(appObj))
+	assert.Nil(t, err)
+	page := buf.String()
+
+	assert.Contains(t, page, "<title>myApp - flogo preview</title>")
+	assert.Contains(t, page, `<a href="#flow-main">flow:main</a>`)
+	assert.Contains(t, page, `<div class="flow" id="flow-main">`)
+	assert.Contains(t, page, "<h3>timer (disabled)</h3>")
+	assert.Contains(t, page, "<h4>Error Handler</h4>")
+	assert.Contains(t, page, "<p>No tasks</p>")
+
+	// the content of the app descriptor is escaped
+	assert.NotContains(t, page, "<script>")
+	assert.Contains(t, page, "&lt;script&gt;")
+}
@@ -0,0 +1,347 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileAppPid   = "app.pid"
+	fileAppLog   = "app.log"
+	fileAppStart = "app.json"
+
+	envKeyAppPropsJson = "FLOGO_APP_PROPS_JSON"
+	envKeyLogLevel     = "FLOGO_LOG_LEVEL"
+
+	startCheckDelay  = time.Second
+	stopPollInterval = 100 * time.Millisecond
+)
+
+// StartOptions are the options used to start the application
+type StartOptions struct {
+	Env      []string `json:"env,omitempty"`      // environment variables, as KEY=VALUE
+	Props    []string `json:"props,omitempty"`    // JSON files containing app property values
+	LogLevel string   `json:"logLevel,omitempty"` // log level of the engine
+}
+
+// AppStatus is the status of the application started by the cli
+type AppStatus struct {
+	Name       string    `json:"name"`
+	Running    bool      `json:"running"`
+	Pid        int       `json:"pid,omitempty"`
+	Started    time.Time `json:"started,omitempty"`
+	Executable string    `json:"executable"`
+	Log        string    `json:"log"`
+}
+
+// appPidInfo is the content of the pid file, the identity of the process is verified before it is signalled
+type appPidInfo struct {
+	Pid int `json:"pid"`
+	*util.ProcessIdentity
+}
+
+// StartApp starts the built application as a background process, its output is written to the app log
+func StartApp(project common.AppProject, options StartOptions) error {
+
+	pid, err := getAppPid(project)
+	if err != nil {
+		return err
+	}
+	if pid != 0 {
+		return fmt.Errorf("application '%s' is already running (pid %d)", project.Name(), pid)
+	}
+
+	exe := project.Executable()
+	if _, err := os.Stat(exe); err != nil {
+		return fmt.Errorf("executable '%s' not found, build the application with 'flogo build'", exe)
+	}
+
+	env, err := appEnv(options)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Join(project.Dir(), dirProjectFlogo), 0755)
+	if err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(appLogFile(project), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	// the app descriptor is read from the working directory if it isn't embedded
+	cmd := exec.Command(exe)
+	cmd.Dir = project.Dir()
+	cmd.Env = env
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// the application is detached from the terminal, it isn't interrupted along with the cli
+	detachProcess(cmd)
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	err = writeAppPidFile(project, cmd.Process.Pid)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return err
+	}
+
+	// check that the application doesn't fail on startup
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		_ = os.Remove(appPidFile(project))
+		if err == nil {
+			err = fmt.Errorf("exited")
+		}
+		return fmt.Errorf("application '%s' failed to start: %v, see 'flogo logs'", project.Name(), err)
+	case <-time.After(startCheckDelay):
+	}
+
+	buf, err := json.MarshalIndent(options, "", "  ")
+	if err == nil {
+		_ = util.WriteFileAtomic(filepath.Join(project.Dir(), dirProjectFlogo, fileAppStart), buf, 0644)
+	}
+
+	fmt.Printf("Started application '%s' (pid %d)\n", project.Name(), cmd.Process.Pid)
+
+	return nil
+}
+
+// appEnv gets the environment of the application: the one of the cli, the environment variables, the property files,
+// followed by the ones given, and the log level of the options
+func appEnv(options StartOptions, propFiles ...string) ([]string, error) {
+
+	env := os.Environ()
+	for _, e := range options.Env {
+		if !strings.Contains(e, "=") {
+			return nil, fmt.Errorf("invalid environment variable '%s', expected KEY=VALUE", e)
+		}
+		env = append(env, e)
+	}
+
+	var files []string
+	for _, props := range options.Props {
+		path, err := filepath.Abs(props)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("properties file '%s' not found", props)
+		}
+		files = append(files, path)
+	}
+	// the values of the last files override the ones of the first
+	files = append(files, propFiles...)
+	if len(files) > 0 {
+		env = append(env, envKeyAppPropsJson+"="+strings.Join(files, ","))
+	}
+
+	if options.LogLevel != "" {
+		env = append(env, envKeyLogLevel+"="+strings.ToUpper(options.LogLevel))
+	}
+
+	return env, nil
+}
+
+// StopApp stops the application started by the cli, it is killed if it doesn't stop within the timeout
+func StopApp(project common.AppProject, timeout time.Duration) error {
+
+	pid, err := getAppPid(project)
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		_ = os.Remove(appPidFile(project))
+		return fmt.Errorf("application '%s' isn't running", project.Name())
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+
+	// interrupting a process isn't supported on windows
+	if runtime.GOOS == "windows" {
+		err = process.Kill()
+	} else {
+		err = process.Signal(os.Interrupt)
+	}
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for util.IsProcessRunning(pid) {
+		if time.Now().After(deadline) {
+			fmt.Printf("Application '%s' didn't stop within %s, killing it\n", project.Name(), timeout)
+			err = process.Kill()
+			if err != nil {
+				return err
+			}
+			break
+		}
+		time.Sleep(stopPollInterval)
+	}
+
+	_ = os.Remove(appPidFile(project))
+
+	fmt.Printf("Stopped application '%s'\n", project.Name())
+
+	return nil
+}
+
+// RestartApp restarts the application, the options it was last started with are used if none are specified
+func RestartApp(project common.AppProject, options *StartOptions, timeout time.Duration) error {
+
+	pid, err := getAppPid(project)
+	if err != nil {
+		return err
+	}
+	if pid != 0 {
+		err = StopApp(project, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options == nil {
+		options = &StartOptions{}
+		buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), dirProjectFlogo, fileAppStart))
+		if err == nil {
+			err = json.Unmarshal(buf, options)
+			if err != nil {
+				return fmt.Errorf("unable to read the previous start options: %v", err)
+			}
+		}
+	}
+
+	return StartApp(project, *options)
+}
+
+// GetAppStatus gets the status of the application started by the cli
+func GetAppStatus(project common.AppProject) (*AppStatus, error) {
+
+	status := &AppStatus{Name: project.Name(), Executable: project.Executable(), Log: appLogFile(project)}
+
+	pid, err := getAppPid(project)
+	if err != nil {
+		return nil, err
+	}
+
+	if pid != 0 {
+		status.Running = true
+		status.Pid = pid
+		if info, err := os.Stat(appPidFile(project)); err == nil {
+			status.Started = info.ModTime()
+		}
+	}
+
+	return status, nil
+}
+
+// PrintAppStatus prints the status of the application started by the cli
+func PrintAppStatus(project common.AppProject) error {
+
+	status, err := GetAppStatus(project)
+	if err != nil {
+		return err
+	}
+
+	if !status.Running {
+		fmt.Printf("Application '%s' isn't running\n", status.Name)
+		return nil
+	}
+
+	fmt.Printf("Application '%s' is running\n", status.Name)
+	fmt.Printf("  Pid       : %d\n", status.Pid)
+	fmt.Printf("  Started   : %s (%s ago)\n", util.FormatDateTime(status.Started), time.Since(status.Started).Round(time.Second))
+	fmt.Printf("  Executable: %s\n", status.Executable)
+	fmt.Printf("  Log       : %s\n", status.Log)
+
+	return nil
+}
+
+// appPidFile gets the file recording the pid of the application started by the cli
+func appPidFile(project common.AppProject) string {
+	return filepath.Join(project.Dir(), dirProjectFlogo, fileAppPid)
+}
+
+// appLogFile gets the file the output of the application started by the cli is written to
+func appLogFile(project common.AppProject) string {
+	return filepath.Join(project.Dir(), dirProjectFlogo, fileAppLog)
+}
+
+// writeAppPidFile records the pid of the application started by the cli, along with the identity of its process
+func writeAppPidFile(project common.AppProject, pid int) error {
+
+	info := &appPidInfo{Pid: pid}
+	// the identity isn't available on every os, the pid is then trusted as is
+	if identity, err := util.GetProcessIdentity(pid); err == nil {
+		info.ProcessIdentity = identity
+	}
+
+	buf, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(appPidFile(project), buf, 0644)
+}
+
+// getAppPid gets the pid of the application started by the cli, 0 if it isn't running. A process which doesn't have
+// the executable and start time recorded when the application was started isn't the application, the pid was reused.
+func getAppPid(project common.AppProject) (int, error) {
+
+	buf, err := ioutil.ReadFile(appPidFile(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	info := &appPidInfo{}
+	err = json.Unmarshal(buf, info)
+	if err != nil {
+		// the pid files written by previous versions only contain the pid
+		info.Pid, err = strconv.Atoi(strings.TrimSpace(string(buf)))
+		if err != nil {
+			return 0, fmt.Errorf("invalid pid file '%s'", appPidFile(project))
+		}
+	}
+
+	if !util.IsProcessRunning(info.Pid) {
+		return 0, nil
+	}
+
+	if info.ProcessIdentity != nil {
+		identity, err := util.GetProcessIdentity(info.Pid)
+		if err != nil || !identity.Matches(info.ProcessIdentity) {
+			return 0, nil
+		}
+	}
+
+	return info.Pid, nil
+}
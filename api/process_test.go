@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartStopApp(t *testing.T) {
+	t.Log("Testing start and stop of the application")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the application is simulated by a shell script")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-process")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	err = os.MkdirAll(project.BinDir(), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(project.Executable(), []byte("#!/bin/sh\ntrap 'exit 0' INT\nwhile true; do sleep 1; done\n"), 0755)
+	assert.Nil(t, err)
+
+	err = StartApp(project, StartOptions{})
+	assert.Nil(t, err)
+
+	status, err := GetAppStatus(project)
+	assert.Nil(t, err)
+	assert.True(t, status.Running)
+
+	// the identity of the process is recorded along with its pid
+	buf, err := ioutil.ReadFile(appPidFile(project))
+	assert.Nil(t, err)
+	info := &appPidInfo{}
+	err = json.Unmarshal(buf, info)
+	assert.Nil(t, err)
+	assert.Equal(t, status.Pid, info.Pid)
+	assert.NotNil(t, info.ProcessIdentity)
+
+	err = StartApp(project, StartOptions{})
+	assert.NotNil(t, err)
+
+	err = StopApp(project, 5*time.Second)
+	assert.Nil(t, err)
+	assert.False(t, util.IsProcessRunning(status.Pid))
+	assert.False(t, util.FileExists(appPidFile(project)))
+
+	err = StopApp(project, 5*time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestStopAppPidReused(t *testing.T) {
+	t.Log("Testing stop of the application when its pid was reused by another process")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the identity of processes isn't supported on windows")
+	}
+
+	tempDir, err := ioutil.TempDir("", "flogo-process")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	err = os.MkdirAll(filepath.Join(tempDir, dirProjectFlogo), 0755)
+	assert.Nil(t, err)
+
+	identity, err := util.GetProcessIdentity(os.Getpid())
+	assert.Nil(t, err)
+
+	writePidFile := func(info *appPidInfo) {
+		buf, err := json.Marshal(info)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(appPidFile(project), buf, 0644)
+		assert.Nil(t, err)
+	}
+
+	// the process of the test is the application as long as its identity matches
+	writePidFile(&appPidInfo{Pid: os.Getpid(), ProcessIdentity: identity})
+	pid, err := getAppPid(project)
+	assert.Nil(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// another start time, the test process isn't signalled
+	writePidFile(&appPidInfo{Pid: os.Getpid(), ProcessIdentity: &util.ProcessIdentity{Executable: identity.Executable, Started: "0"}})
+	err = StopApp(project, time.Second)
+	assert.EqualError(t, err, "application '"+project.Name()+"' isn't running")
+	assert.False(t, util.FileExists(appPidFile(project)))
+
+	// another executable
+	writePidFile(&appPidInfo{Pid: os.Getpid(), ProcessIdentity: &util.ProcessIdentity{Executable: "/usr/bin/other", Started: identity.Started}})
+	pid, err = getAppPid(project)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pid)
+
+	// the pid files of previous versions only contain the pid
+	err = ioutil.WriteFile(appPidFile(project), []byte("999999999"), 0644)
+	assert.Nil(t, err)
+	pid, err = getAppPid(project)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, pid)
+}
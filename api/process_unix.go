@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package api
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the process in a new session
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
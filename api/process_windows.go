@@ -0,0 +1,11 @@
+package api
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the process in a new process group
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	dirProfiles = "profiles"
+
+	envKeyAppPropsEnv = "FLOGO_APP_PROPS_ENV"
+
+	propSourceDefault = "default"
+)
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// AppPropertyInfo is a property declared in the app descriptor, with the values the profiles override it with
+type AppPropertyInfo struct {
+	Name     string                 `json:"name"`
+	Type     string                 `json:"type,omitempty"`
+	Value    interface{}            `json:"value"`              // the default value, or the value of the profile listed
+	Source   string                 `json:"source,omitempty"`   // default or the profile listed
+	Profiles map[string]interface{} `json:"profiles,omitempty"` // the values of the profiles overriding the property
+}
+
+// ListAppProperties prints the properties declared in the app descriptor with their default value and the profiles
+// overriding them, or their value in the profile if specified
+func ListAppProperties(project common.AppProject, profile string, jsonFormat bool) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	names := Profiles(project)
+	if profile != "" {
+		names = []string{profile}
+	}
+	profiles := make(map[string]map[string]interface{})
+	for _, name := range names {
+		profiles[name], err = loadProfile(project, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	var infos []*AppPropertyInfo
+	for _, prop := range getAppProperties(appObj) {
+		info := &AppPropertyInfo{Name: prop.Name, Type: prop.Type, Value: prop.Value}
+		if profile != "" {
+			info.Source = propSourceDefault
+			if value, ok := profiles[profile][prop.Name]; ok {
+				info.Value, info.Source = value, profile
+			}
+		} else {
+			for _, name := range names {
+				if value, ok := profiles[name][prop.Name]; ok {
+					if info.Profiles == nil {
+						info.Profiles = make(map[string]interface{})
+					}
+					info.Profiles[name] = value
+				}
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	if jsonFormat {
+		if infos == nil {
+			infos = []*AppPropertyInfo{}
+		}
+		resp, err := json.MarshalIndent(infos, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(resp))
+		return nil
+	}
+
+	if len(infos) == 0 {
+		fmt.Println(util.T("props.none", fileFlogoJson))
+		return nil
+	}
+	printAppProperties(os.Stdout, infos, profile != "")
+
+	return nil
+}
+
+func printAppProperties(w io.Writer, infos []*AppPropertyInfo, withSource bool) {
+
+	if withSource {
+		fmt.Fprintf(w, "%-30s %-8s %-30s %s\n", "NAME", "TYPE", "VALUE", "SOURCE")
+	} else {
+		fmt.Fprintf(w, "%-30s %-8s %-30s %s\n", "NAME", "TYPE", "DEFAULT", "PROFILES")
+	}
+
+	for _, info := range infos {
+		value, _ := json.Marshal(info.Value)
+		if withSource {
+			fmt.Fprintf(w, "%-30s %-8s %-30s %s\n", info.Name, info.Type, value, info.Source)
+			continue
+		}
+		var names []string
+		for name := range info.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "%-30s %-8s %-30s %s\n", info.Name, info.Type, value, joinOrDash(names))
+	}
+}
+
+// Profiles gets the names of the property profiles of the project
+func Profiles(project common.AppProject) []string {
+
+	files, _ := filepath.Glob(filepath.Join(project.Dir(), dirProfiles, "*.json"))
+
+	var names []string
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ListProfiles prints the property profiles of the project with the number of properties they override
+func ListProfiles(project common.AppProject) error {
+
+	names := Profiles(project)
+	if len(names) == 0 {
+		fmt.Println(util.T("props.noProfiles"))
+		return nil
+	}
+
+	for _, name := range names {
+		values, err := loadProfile(project, name)
+		if err != nil {
+			return err
+		}
+		fmt.Println(util.T("props.profile", name, len(values)))
+	}
+
+	return nil
+}
+
+// CreateProfile creates a property profile, with the values of another profile if specified, overridden by the values
+// given as name=value
+func CreateProfile(project common.AppProject, name, from string, values []string) error {
+
+	err := validateProfileName(name)
+	if err != nil {
+		return err
+	}
+	if util.FileExists(profileFile(project, name)) {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+
+	profile := make(map[string]interface{})
+	if from != "" {
+		profile, err = loadProfile(project, from)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = setProfileValues(project, profile, values)
+	if err != nil {
+		return err
+	}
+
+	err = saveProfile(project, name, profile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(util.T("props.profileCreated", name, relProjectPath(project, profileFile(project, name)), len(profile)))
+
+	return nil
+}
+
+// SetProfileValues sets the values of app properties, given as name=value, in the property profile
+func SetProfileValues(project common.AppProject, name string, values []string) error {
+
+	profile, err := loadProfile(project, name)
+	if err != nil {
+		return err
+	}
+
+	err = setProfileValues(project, profile, values)
+	if err != nil {
+		return err
+	}
+
+	return saveProfile(project, name, profile)
+}
+
+// UnsetProfileValues removes app properties from the property profile, they get their default value
+func UnsetProfileValues(project common.AppProject, name string, props []string) error {
+
+	profile, err := loadProfile(project, name)
+	if err != nil {
+		return err
+	}
+
+	for _, prop := range props {
+		if _, ok := profile[prop]; !ok {
+			return fmt.Errorf("profile '%s' doesn't set property '%s'", name, prop)
+		}
+		delete(profile, prop)
+	}
+
+	return saveProfile(project, name, profile)
+}
+
+// setProfileValues sets the values given as name=value in the profile, the properties have to be declared in the app
+// descriptor and the values are converted to their type
+func setProfileValues(project common.AppProject, profile map[string]interface{}, values []string) error {
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool)
+	for _, prop := range getAppProperties(appObj) {
+		declared[prop.Name] = true
+	}
+	for _, value := range values {
+		if name := strings.SplitN(value, "=", 2)[0]; name != "" && !declared[name] {
+			return fmt.Errorf("'%s' isn't a property of the application, see 'flogo props list'", name)
+		}
+	}
+
+	parsed, err := parsePropValues(appObj, values)
+	if err != nil {
+		return err
+	}
+	for name, value := range parsed {
+		profile[name] = value
+	}
+
+	return nil
+}
+
+// applyProfile sets the values of the app properties of the app descriptor to the ones of the profile
+func applyProfile(appObj map[string]interface{}, profile map[string]interface{}) {
+
+	props, _ := appObj["properties"].([]interface{})
+	for _, prop := range props {
+		propMap, ok := prop.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := propMap["name"].(string)
+		if value, ok := profile[name]; ok {
+			propMap["value"] = value
+		}
+	}
+}
+
+// writeProfileEnvFile writes the values of the app properties in the profile, or their default value, to an env file
+// next to the executable, which sets FLOGO_APP_PROPS_ENV=auto so the application resolves them, the file is returned
+func writeProfileEnvFile(project common.AppProject, name string) (string, error) {
+
+	profile, err := loadProfile(project, name)
+	if err != nil {
+		return "", err
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return "", err
+	}
+	props := getAppProperties(appObj)
+
+	values := make(map[string]interface{})
+	for _, prop := range props {
+		value, ok := profile[prop.Name]
+		if !ok {
+			value = prop.Value
+		}
+		values[envPropName(prop.Name)] = value
+	}
+	values[envKeyAppPropsEnv] = "auto"
+
+	err = os.MkdirAll(project.BinDir(), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(project.BinDir(), project.Name()+"-"+name+".env")
+
+	return file, util.WriteFileAtomic(file, formatEnvPropsFile(project.Name(), name, props, values), 0644)
+}
+
+// loadProfile loads the values of the property profile
+func loadProfile(project common.AppProject, name string) (map[string]interface{}, error) {
+
+	err := validateProfileName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := readPropsFile(profileFile(project, name))
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		profiles := Profiles(project)
+		if len(profiles) == 0 {
+			return nil, fmt.Errorf("profile '%s' not found, create it using 'flogo props profile create %s'", name, name)
+		}
+		return nil, fmt.Errorf("profile '%s' not found, available: %s", name, strings.Join(profiles, ", "))
+	}
+
+	return values, nil
+}
+
+func saveProfile(project common.AppProject, name string, profile map[string]interface{}) error {
+
+	buf, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Join(project.Dir(), dirProfiles), 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(profileFile(project, name), append(buf, '\n'), 0644)
+}
+
+func profileFile(project common.AppProject, name string) string {
+	return filepath.Join(project.Dir(), dirProfiles, name+".json")
+}
+
+func validateProfileName(name string) error {
+
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s', expected letters, digits, '.', '_' or '-'", name)
+	}
+
+	return nil
+}
+
+// validateProfiles validates that the property profiles only set app properties, to values of their type
+func validateProfiles(ctx *validationContext) error {
+
+	types := make(map[string]string)
+	for _, prop := range getAppProperties(ctx.appObj) {
+		types[prop.Name] = prop.Type
+	}
+
+	for _, name := range Profiles(ctx.project) {
+		path := relProjectPath(ctx.project, profileFile(ctx.project, name))
+		values, err := readPropsFile(profileFile(ctx.project, name))
+		if err != nil {
+			ctx.addError(path, "%v", err)
+			continue
+		}
+
+		var keys []string
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			propType, declared := types[key]
+			if !declared {
+				ctx.addWarning(path, "unknown app property '%s'", key)
+				continue
+			}
+			if _, err := coercePropertyValue(propType, values[key]); err != nil {
+				ctx.addError(path, "invalid value at %s: %v", propsFileValuePath(profileFile(ctx.project, name), key), err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProfile(t *testing.T) {
+	t.Log("Testing baking of the values of a profile into the app descriptor")
+
+	appObj := map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"name": "db.url", "type": "string", "value": "localhost:5432"},
+			map[string]interface{}{"name": "port", "type": "int", "value": 8080.0},
+		},
+	}
+
+	applyProfile(appObj, map[string]interface{}{"port": 80.0, "timeout": 10.0})
+
+	props := getAppProperties(appObj)
+	assert.Len(t, props, 2)
+	assert.Equal(t, "localhost:5432", props[0].Value)
+	assert.Equal(t, 80.0, props[1].Value)
+}
+
+func TestValidateProfileName(t *testing.T) {
+
+	assert.Nil(t, validateProfileName("prod"))
+	assert.Nil(t, validateProfileName("eu-west_1.stage"))
+	assert.NotNil(t, validateProfileName(""))
+	assert.NotNil(t, validateProfileName("../prod"))
+	assert.NotNil(t, validateProfileName(".hidden"))
+	assert.NotNil(t, validateProfileName("my profile"))
+}
+
+func TestProfiles(t *testing.T) {
+	t.Log("Testing profiles stored in the profiles directory")
+
+	tempDir, err := ioutil.TempDir("", "flogo-profiles")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"name": "myApp", "properties": [
+		{"name": "db.url", "type": "string", "value": "localhost:5432"},
+		{"name": "port", "type": "int", "value": 8080}
+	]}`), 0644)
+	assert.Nil(t, err)
+
+	project := NewAppProject(tempDir)
+
+	assert.Nil(t, CreateProfile(project, "staging", "", []string{"port=9090", "db.url=staging-db:5432"}))
+	assert.Nil(t, CreateProfile(project, "prod", "staging", []string{"db.url=prod-db:5432"}))
+	assert.NotNil(t, CreateProfile(project, "prod", "", nil))
+	assert.NotNil(t, CreateProfile(project, "dev", "", []string{"timeout=10"}))
+
+	assert.True(t, util.FileExists(filepath.Join(tempDir, dirProfiles, "staging.json")))
+	assert.Equal(t, []string{"prod", "staging"}, Profiles(project))
+
+	profile, err := loadProfile(project, "prod")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"db.url": "prod-db:5432", "port": 9090.0}, profile)
+
+	// the properties a profile doesn't set keep their default value
+	assert.Nil(t, UnsetProfileValues(project, "prod", []string{"port"}))
+	assert.NotNil(t, UnsetProfileValues(project, "prod", []string{"port"}))
+	assert.Nil(t, SetProfileValues(project, "staging", []string{"port=9091"}))
+
+	profile, err = loadProfile(project, "prod")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"db.url": "prod-db:5432"}, profile)
+
+	profile, err = loadProfile(project, "staging")
+	assert.Nil(t, err)
+	assert.Equal(t, 9091.0, profile["port"])
+
+	_, err = loadProfile(project, "dev")
+	assert.NotNil(t, err)
+}
@@ -1,13 +1,14 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"go/parser"
-	"go/printer"
 	"go/token"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
@@ -21,6 +22,9 @@ const (
 	fileImportsGo  = "imports.go"
 	dirSrc         = "src"
 	dirBin         = "bin"
+
+	dirProjectCache  = "cache"
+	fileContribCache = "contribs.json"
 )
 
 var GOOSENV = os.Getenv("GOOS")
@@ -31,21 +35,29 @@ type appProjectImpl struct {
 	srcDir  string
 	binDir  string
 	dm      util.DepManager
+
+	descriptorErr error
 }
 
 func NewAppProject(appDir string) common.AppProject {
 	project := &appProjectImpl{appDir: appDir}
 	project.srcDir = filepath.Join(appDir, dirSrc)
 	project.binDir = filepath.Join(appDir, dirBin)
-	project.dm = util.NewDepManager(project.srcDir)
+	cache := util.NewContribCache(filepath.Join(appDir, dirProjectFlogo, dirProjectCache, fileContribCache), filepath.Join(project.srcDir, "go.mod"))
+	project.dm = util.NewCachedDepManager(util.NewDepManager(project.srcDir), cache)
 	project.appName = filepath.Base(appDir)
+	project.descriptorErr = syncYamlDescriptor(appDir)
 	return project
 }
 
 func (p *appProjectImpl) Validate() error {
+	if p.descriptorErr != nil {
+		return p.descriptorErr
+	}
+
 	_, err := os.Stat(filepath.Join(p.appDir, fileFlogoJson))
 	if os.IsNotExist(err) {
-		return fmt.Errorf("not a valid flogo app project directory, missing flogo.json")
+		return fmt.Errorf("not a valid flogo app project directory, missing flogo.json or flogo.yaml")
 	}
 
 	_, err = os.Stat(p.srcDir)
@@ -100,6 +112,21 @@ func (p *appProjectImpl) Executable() string {
 	return execPath
 }
 
+// PlatformExecutable gets the path of the executable built for the platform, its name is suffixed with the platform,
+// ex. bin/myApp-linux-arm64
+func PlatformExecutable(project common.AppProject, platform *BuildPlatform) string {
+
+	name := project.Name() + "-" + platform.OS + "-" + platform.Arch
+	if platform.Variant != "" {
+		name += "-" + platform.Variant
+	}
+	if platform.OS == "windows" {
+		name += ".exe"
+	}
+
+	return filepath.Join(project.BinDir(), name)
+}
+
 func (p *appProjectImpl) GetPath(flogoImport util.Import) (string, error) {
 	return p.dm.GetPath(flogoImport)
 }
@@ -122,7 +149,12 @@ func (p *appProjectImpl) GetGoImports(withVersion bool) ([]util.Import, error) {
 	var imports []util.Import
 	for _, is := range file.Imports {
 
-		imp, err := util.ParseImport(is.Path.Value)
+		path, err := strconv.Unquote(is.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		imp, err := util.ParseImport(path)
 		if err != nil {
 			return nil, err
 		}
@@ -133,7 +165,7 @@ func (p *appProjectImpl) GetGoImports(withVersion bool) ([]util.Import, error) {
 	return imports, nil
 }
 
-func (p *appProjectImpl) addImportsInGo(ignoreError bool, imports ...util.Import) error {
+func (p *appProjectImpl) addImportsInGo(ctx context.Context, ignoreError bool, imports ...util.Import) error {
 	importsFile := filepath.Join(p.SrcDir(), fileImportsGo)
 
 	fset := token.NewFileSet()
@@ -143,7 +175,7 @@ func (p *appProjectImpl) addImportsInGo(ignoreError bool, imports ...util.Import
 	}
 
 	for _, i := range imports {
-		err := p.DepManager().AddDependency(i)
+		err := p.DepManager().AddDependency(ctx, i)
 		if err != nil {
 			if ignoreError {
 				fmt.Printf("Warning: unable to install '%s'\n", i)
@@ -157,9 +189,8 @@ func (p *appProjectImpl) addImportsInGo(ignoreError bool, imports ...util.Import
 		util.AddImport(fset, file, i.GoImportPath())
 	}
 
-	f, err := os.Create(importsFile)
-	defer f.Close()
-	if err := printer.Fprint(f, fset, file); err != nil {
+	err = writeImportsGoFile(p, fset, file)
+	if err != nil {
 		return err
 	}
 
@@ -170,14 +201,20 @@ func (p *appProjectImpl) addImportsInGo(ignoreError bool, imports ...util.Import
 
 func (p *appProjectImpl) addImportsInJson(ignoreError bool, imports ...util.Import) error {
 
-	appDescriptor, err := readAppDescriptor(p)
+	// only the imports are read and written, which keeps the cost of the update low for large descriptors
+	var appImports []string
+	_, err := readAppDescriptorValue(p, "$.imports", &appImports)
 	if err != nil {
 		return err
 	}
 
 	// list existing imports in JSON to avoid duplicates
 	existingImports := make(map[string]util.Import)
-	jsonImports, _ := util.ParseImports(appDescriptor.Imports)
+	// the imports are rewritten, ignoring one which can't be parsed would drop all of them
+	jsonImports, err := util.ParseImports(appImports)
+	if err != nil {
+		return fmt.Errorf("invalid import in %s: %v", fileFlogoJson, err)
+	}
 	for _, e := range jsonImports {
 		existingImports[e.GoImportPath()] = e
 	}
@@ -203,9 +240,7 @@ func (p *appProjectImpl) addImportsInJson(ignoreError bool, imports ...util.Impo
 	for _, val := range existingImports {
 		newImport = append(newImport, val.CanonicalImport())
 	}
-	appDescriptor.Imports = newImport
-
-	err = writeAppDescriptor(p, appDescriptor)
+	err = writeAppDescriptorValue(p, "$.imports", newImport)
 	if err != nil {
 		return err
 	}
@@ -213,8 +248,8 @@ func (p *appProjectImpl) addImportsInJson(ignoreError bool, imports ...util.Impo
 	return nil
 }
 
-func (p *appProjectImpl) AddImports(ignoreError bool, addToJson bool, imports ...util.Import) error {
-	err := p.addImportsInGo(ignoreError, imports...) // begin with Go imports as they are more likely to fail
+func (p *appProjectImpl) AddImports(ctx context.Context, ignoreError bool, addToJson bool, imports ...util.Import) error {
+	err := p.addImportsInGo(ctx, ignoreError, imports...) // begin with Go imports as they are more likely to fail
 	if err != nil {
 		return err
 	}
@@ -240,9 +275,8 @@ func (p *appProjectImpl) RemoveImports(imports ...string) error {
 		util.DeleteImport(fset, file, impPath)
 	}
 
-	f, err := os.Create(importsFile)
-	defer f.Close()
-	if err := printer.Fprint(f, fset, file); err != nil {
+	err = writeImportsGoFile(p, fset, file)
+	if err != nil {
 		return err
 	}
 
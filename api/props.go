@@ -0,0 +1,488 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	dirProps = "props"
+
+	PropsFormatJson = "json" // JSON file used with FLOGO_APP_PROPS_JSON
+	PropsFormatEnv  = "env"  // environment variables used with FLOGO_APP_PROPS_ENV=auto
+)
+
+// PropsGenOptions are the options used to generate the property files of the environments
+type PropsGenOptions struct {
+	Envs   []string // the environments, ex. dev, staging, prod
+	Format string   // the format of the files, json or env
+	Dir    string   // the directory of the files, relative to the project
+	Check  bool     // only check that the existing files are in sync with the app properties
+}
+
+// appProperty is a property declared in the app descriptor
+type appProperty struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// propsFileStatus is the result of comparing a property file with the app properties
+type propsFileStatus struct {
+	File    string
+	Missing []string // the app properties missing from the file
+	Unknown []string // the properties of the file which aren't app properties
+	Invalid []string // the values of the file which aren't of the type of their app property
+}
+
+// GeneratePropsFiles generates a property override file per environment, containing the app properties and their
+// default values. The values of existing files are preserved, the properties added to the app since are added.
+func GeneratePropsFiles(project common.AppProject, options PropsGenOptions) error {
+
+	if len(options.Envs) == 0 {
+		return fmt.Errorf("no environment specified")
+	}
+	if options.Format == "" {
+		options.Format = PropsFormatJson
+	}
+	if options.Format != PropsFormatJson && options.Format != PropsFormatEnv {
+		return fmt.Errorf("invalid format '%s', expected %s or %s", options.Format, PropsFormatJson, PropsFormatEnv)
+	}
+	if options.Dir == "" {
+		options.Dir = dirProps
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+	props := getAppProperties(appObj)
+
+	dir := options.Dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(project.Dir(), dir)
+	}
+
+	outOfSync := 0
+	for _, env := range options.Envs {
+		env = strings.TrimSpace(env)
+		if env == "" {
+			continue
+		}
+		file := filepath.Join(dir, env+"."+options.Format)
+
+		if options.Check {
+			status, err := checkPropsFile(file, props)
+			if err != nil {
+				return err
+			}
+			if printPropsFileStatus(project, status) {
+				outOfSync++
+			}
+			continue
+		}
+
+		err = writePropsFile(project, file, env, props)
+		if err != nil {
+			return err
+		}
+	}
+
+	if outOfSync > 0 {
+		return fmt.Errorf("%d property file(s) out of sync with the app properties, run 'flogo props gen' to add the missing properties", outOfSync)
+	}
+
+	return nil
+}
+
+// getAppProperties gets the properties declared in the app descriptor
+func getAppProperties(appObj map[string]interface{}) []*appProperty {
+
+	var props []*appProperty
+
+	vals, _ := appObj["properties"].([]interface{})
+	for _, val := range vals {
+		propMap, ok := val.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := propMap["name"].(string)
+		if name == "" {
+			continue
+		}
+		propType, _ := propMap["type"].(string)
+		props = append(props, &appProperty{Name: name, Type: propType, Value: propMap["value"]})
+	}
+
+	return props
+}
+
+// coercePropertyValue converts a value to the declared type of its property, so that a value the engine would fail to
+// start with is rejected when it is set: a number given on the command line for a string property is converted to a
+// string, and the strings of env property files to numbers, booleans, objects or arrays. Expressions and values of
+// unknown types are left as is.
+func coercePropertyValue(propType string, value interface{}) (interface{}, error) {
+
+	if value == nil || isExpression(value) {
+		return value, nil
+	}
+
+	var coerced interface{}
+	switch strings.ToLower(propType) {
+	case "string":
+		if _, ok := value.(string); !ok {
+			buf, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			return string(buf), nil
+		}
+		return value, nil
+	case "int", "integer", "int32", "int64", "long":
+		if num, ok := propertyNumber(value); ok && num == math.Trunc(num) {
+			coerced = num
+		}
+	case "float", "float32", "float64", "double", "number":
+		if num, ok := propertyNumber(value); ok {
+			coerced = num
+		}
+	case "bool", "boolean":
+		switch v := value.(type) {
+		case bool:
+			coerced = v
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				coerced = b
+			}
+		}
+	case "object", "params", "map":
+		switch v := value.(type) {
+		case map[string]interface{}:
+			coerced = v
+		case string:
+			var obj map[string]interface{}
+			if err := json.Unmarshal([]byte(v), &obj); err == nil && obj != nil {
+				coerced = obj
+			}
+		}
+	case "array":
+		switch v := value.(type) {
+		case []interface{}:
+			coerced = v
+		case string:
+			var arr []interface{}
+			if err := json.Unmarshal([]byte(v), &arr); err == nil && arr != nil {
+				coerced = arr
+			}
+		}
+	default:
+		return value, nil
+	}
+
+	if coerced == nil {
+		buf, _ := json.Marshal(value)
+		return nil, fmt.Errorf("expected value of type '%s', got %s", propType, buf)
+	}
+
+	return coerced, nil
+}
+
+// propertyNumber gets the number of a value, which can be given as a string
+func propertyNumber(value interface{}) (float64, bool) {
+
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		num, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return num, err == nil
+	}
+
+	return 0, false
+}
+
+func writePropsFile(project common.AppProject, file, env string, props []*appProperty) error {
+
+	existing, err := readPropsFile(file)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{})
+	for name, value := range existing {
+		values[name] = value
+	}
+
+	var added []string
+	for _, prop := range props {
+		name := propsFileKey(file, prop.Name)
+		if _, exists := values[name]; !exists {
+			values[name] = prop.Value
+			added = append(added, prop.Name)
+		}
+	}
+
+	var content []byte
+	if filepath.Ext(file) == "."+PropsFormatEnv {
+		content = formatEnvPropsFile(project.Name(), env, props, values)
+	} else {
+		content, err = json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return err
+		}
+		content = append(content, '\n')
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = util.WriteFileAtomic(file, content, 0644)
+	if err != nil {
+		return err
+	}
+
+	relFile := relProjectPath(project, file)
+	switch {
+	case existing == nil:
+		fmt.Printf("Generated %s (%d properties)\n", relFile, len(props))
+	case len(added) > 0:
+		fmt.Printf("Updated %s, added: %s\n", relFile, strings.Join(added, ", "))
+	default:
+		fmt.Printf("%s is up to date\n", relFile)
+	}
+
+	return nil
+}
+
+// formatEnvPropsFile formats the properties as environment variables, named as expected by FLOGO_APP_PROPS_ENV=auto
+func formatEnvPropsFile(appName, env string, props []*appProperty, values map[string]interface{}) []byte {
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# app properties of %s for the %s environment, used with FLOGO_APP_PROPS_ENV=auto\n", appName, env)
+
+	written := make(map[string]bool)
+	for _, prop := range props {
+		key := envPropName(prop.Name)
+		fmt.Fprintf(&b, "%s=%s\n", key, formatEnvPropValue(values[key]))
+		written[key] = true
+	}
+
+	// the values which aren't app properties are preserved
+	var others []string
+	for key := range values {
+		if !written[key] {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	for _, key := range others {
+		fmt.Fprintf(&b, "%s=%s\n", key, formatEnvPropValue(values[key]))
+	}
+
+	return b.Bytes()
+}
+
+func formatEnvPropValue(value interface{}) string {
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	buf, _ := json.Marshal(value)
+	return string(buf)
+}
+
+// envPropName gets the name of the environment variable of the property, ex. db.url is DB_URL
+func envPropName(name string) string {
+	return strings.ToUpper(strings.Replace(name, ".", "_", -1))
+}
+
+// propsFileKey gets the key of the property in the property file
+func propsFileKey(file, name string) string {
+	if filepath.Ext(file) == "."+PropsFormatEnv {
+		return envPropName(name)
+	}
+	return name
+}
+
+// readPropsFile reads the values of a JSON or env property file, nil is returned if it doesn't exist
+func readPropsFile(file string) (map[string]interface{}, error) {
+
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+
+	if filepath.Ext(file) != "."+PropsFormatEnv {
+		err = json.Unmarshal(buf, &values)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property file '%s': %v", file, err)
+		}
+		return values, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid line in property file '%s': %s", file, line)
+		}
+		values[strings.TrimSpace(line[:idx])] = line[idx+1:]
+	}
+
+	return values, scanner.Err()
+}
+
+// checkPropsFile compares the property file with the app properties
+func checkPropsFile(file string, props []*appProperty) (*propsFileStatus, error) {
+
+	values, err := readPropsFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		return nil, fmt.Errorf("property file '%s' doesn't exist", file)
+	}
+
+	status := &propsFileStatus{File: file}
+
+	known := make(map[string]bool)
+	for _, prop := range props {
+		key := propsFileKey(file, prop.Name)
+		known[key] = true
+		value, exists := values[key]
+		if !exists {
+			status.Missing = append(status.Missing, prop.Name)
+			continue
+		}
+		if _, err := coercePropertyValue(prop.Type, value); err != nil {
+			status.Invalid = append(status.Invalid, fmt.Sprintf("%s: %v", propsFileValuePath(file, key), err))
+		}
+	}
+
+	for key := range values {
+		if !known[key] {
+			status.Unknown = append(status.Unknown, key)
+		}
+	}
+	sort.Strings(status.Unknown)
+
+	return status, nil
+}
+
+// propsFileValuePath locates the value of a property in the property file: the name of its environment variable, or
+// its JSON path
+func propsFileValuePath(file, key string) string {
+	if filepath.Ext(file) == "."+PropsFormatEnv {
+		return key
+	}
+	return fmt.Sprintf("$['%s']", key)
+}
+
+// printPropsFileStatus prints the differences of the property file, returns true if it is out of sync
+func printPropsFileStatus(project common.AppProject, status *propsFileStatus) bool {
+
+	relFile := relProjectPath(project, status.File)
+
+	if len(status.Missing) == 0 && len(status.Unknown) == 0 && len(status.Invalid) == 0 {
+		fmt.Printf("%s is up to date\n", relFile)
+		return false
+	}
+
+	if len(status.Missing) > 0 {
+		fmt.Printf("%s is missing: %s\n", relFile, strings.Join(status.Missing, ", "))
+	}
+	if len(status.Unknown) > 0 {
+		fmt.Printf("%s contains unknown properties: %s\n", relFile, strings.Join(status.Unknown, ", "))
+	}
+	for _, invalid := range status.Invalid {
+		fmt.Printf("%s contains an invalid value at %s\n", relFile, invalid)
+	}
+
+	return len(status.Missing) > 0
+}
+
+// validatePropsFiles validates that the property files of the environments, in the props directory, contain the app properties
+func validatePropsFiles(ctx *validationContext) error {
+
+	props := getAppProperties(ctx.appObj)
+
+	for _, format := range []string{PropsFormatJson, PropsFormatEnv} {
+		files, _ := filepath.Glob(filepath.Join(ctx.project.Dir(), dirProps, "*."+format))
+		for _, file := range files {
+			status, err := checkPropsFile(file, props)
+			if err != nil {
+				ctx.addError(relProjectPath(ctx.project, file), "%v", err)
+				continue
+			}
+			path := relProjectPath(ctx.project, file)
+			if len(status.Missing) > 0 {
+				env := strings.TrimSuffix(filepath.Base(file), "."+format)
+				ctx.addWarning(path, "missing app properties: %s, run 'flogo props gen -e %s --format %s' to add them", strings.Join(status.Missing, ", "), env, format)
+			}
+			if len(status.Unknown) > 0 {
+				ctx.addWarning(path, "unknown app properties: %s", strings.Join(status.Unknown, ", "))
+			}
+			for _, invalid := range status.Invalid {
+				ctx.addError(path, "invalid value at %s", invalid)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateAppProperties validates that the values of the app properties are of their declared type
+func validateAppProperties(ctx *validationContext) error {
+
+	vals, _ := ctx.appObj["properties"].([]interface{})
+	for i, val := range vals {
+		propMap, ok := val.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		propType, _ := propMap["type"].(string)
+		if _, err := coercePropertyValue(propType, propMap["value"]); err != nil {
+			ctx.addError(fmt.Sprintf("$.properties[%d].value", i), "%v", err)
+		}
+	}
+
+	return nil
+}
+
+func relProjectPath(project common.AppProject, file string) string {
+	if rel, err := filepath.Rel(project.Dir(), file); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return file
+}
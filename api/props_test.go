@@ -0,0 +1,132 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPropsFile(t *testing.T) {
+	t.Log("Testing comparison of property files with the app properties")
+
+	tempDir, err := ioutil.TempDir("", "flogo-props")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	props := getAppProperties(map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"name": "db.url", "type": "string", "value": "localhost:5432"},
+			map[string]interface{}{"name": "port", "type": "int", "value": 8080.0},
+		},
+	})
+	assert.Len(t, props, 2)
+
+	jsonFile := filepath.Join(tempDir, "dev.json")
+	err = ioutil.WriteFile(jsonFile, []byte(`{"db.url": "db:5432", "timeout": 10}`), 0644)
+	assert.Nil(t, err)
+
+	status, err := checkPropsFile(jsonFile, props)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"port"}, status.Missing)
+	assert.Equal(t, []string{"timeout"}, status.Unknown)
+
+	envFile := filepath.Join(tempDir, "dev.env")
+	err = ioutil.WriteFile(envFile, formatEnvPropsFile("myApp", "dev", props, map[string]interface{}{"DB_URL": "db:5432", "PORT": 8080.0}), 0644)
+	assert.Nil(t, err)
+
+	values, err := readPropsFile(envFile)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"DB_URL": "db:5432", "PORT": "8080"}, values)
+
+	status, err = checkPropsFile(envFile, props)
+	assert.Nil(t, err)
+	assert.Empty(t, status.Missing)
+	assert.Empty(t, status.Unknown)
+}
+
+func TestCoercePropertyValue(t *testing.T) {
+	t.Log("Testing conversion of property values to their declared type")
+
+	value, err := coercePropertyValue("string", 8080.0)
+	assert.Nil(t, err)
+	assert.Equal(t, "8080", value)
+
+	value, err = coercePropertyValue("int", "8080")
+	assert.Nil(t, err)
+	assert.Equal(t, 8080.0, value)
+
+	_, err = coercePropertyValue("int", 1.5)
+	assert.EqualError(t, err, "expected value of type 'int', got 1.5")
+
+	_, err = coercePropertyValue("integer", "abc")
+	assert.EqualError(t, err, `expected value of type 'integer', got "abc"`)
+
+	value, err = coercePropertyValue("float64", " 0.5")
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, value)
+
+	value, err = coercePropertyValue("boolean", "true")
+	assert.Nil(t, err)
+	assert.Equal(t, true, value)
+
+	_, err = coercePropertyValue("bool", "yes")
+	assert.EqualError(t, err, `expected value of type 'bool', got "yes"`)
+
+	value, err = coercePropertyValue("object", `{"a": 1}`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, value)
+
+	_, err = coercePropertyValue("object", []interface{}{1.0})
+	assert.EqualError(t, err, "expected value of type 'object', got [1]")
+
+	value, err = coercePropertyValue("array", "[1, 2]")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1.0, 2.0}, value)
+
+	// expressions, missing values and unknown types are left as is
+	value, err = coercePropertyValue("int", "=$env[PORT]")
+	assert.Nil(t, err)
+	assert.Equal(t, "=$env[PORT]", value)
+
+	value, err = coercePropertyValue("int", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	value, err = coercePropertyValue("any", "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", value)
+}
+
+func TestCheckPropsFileInvalidValues(t *testing.T) {
+	t.Log("Testing detection of property file values of the wrong type")
+
+	tempDir, err := ioutil.TempDir("", "flogo-props")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	props := getAppProperties(map[string]interface{}{
+		"properties": []interface{}{
+			map[string]interface{}{"name": "db.port", "type": "int", "value": 5432.0},
+			map[string]interface{}{"name": "debug", "type": "bool", "value": false},
+		},
+	})
+
+	jsonFile := filepath.Join(tempDir, "prod.json")
+	err = ioutil.WriteFile(jsonFile, []byte(`{"db.port": "abc", "debug": "true"}`), 0644)
+	assert.Nil(t, err)
+
+	status, err := checkPropsFile(jsonFile, props)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`$['db.port']: expected value of type 'int', got "abc"`}, status.Invalid)
+
+	envFile := filepath.Join(tempDir, "prod.env")
+	err = ioutil.WriteFile(envFile, []byte("DB_PORT=5432\nDEBUG=maybe\n"), 0644)
+	assert.Nil(t, err)
+
+	status, err = checkPropsFile(envFile, props)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{`DEBUG: expected value of type 'bool', got "maybe"`}, status.Invalid)
+}
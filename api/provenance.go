@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	provenanceStatementType = "https://in-toto.io/Statement/v0.1"
+	provenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	provenanceBuildType     = "https://github.com/project-flogo/cli/build@v1"
+	provenanceBuilderID     = "https://github.com/project-flogo/cli"
+	provenancePayloadType   = "application/vnd.in-toto+json"
+
+	fileProvenanceSuffix = ".intoto.jsonl"
+)
+
+// ProvenanceStatement is an in-toto statement carrying the SLSA provenance of the executables of a build
+type ProvenanceStatement struct {
+	Type          string               `json:"_type"`
+	Subject       []*ProvenanceSubject `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     *ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenanceSubject is an artifact produced by the build
+type ProvenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// ProvenancePredicate describes how the executables were built and from what
+type ProvenancePredicate struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string `json:"buildType"`
+	Invocation struct {
+		ConfigSource *ProvenanceMaterial    `json:"configSource"`
+		Parameters   map[string]interface{} `json:"parameters,omitempty"`
+		Environment  map[string]string      `json:"environment"`
+	} `json:"invocation"`
+	Metadata struct {
+		BuildStartedOn  time.Time `json:"buildStartedOn"`
+		BuildFinishedOn time.Time `json:"buildFinishedOn"`
+		Reproducible    bool      `json:"reproducible"`
+	} `json:"metadata"`
+	Materials []*ProvenanceMaterial `json:"materials"`
+}
+
+// ProvenanceMaterial is an input of the build, the modules are identified by their package URL
+type ProvenanceMaterial struct {
+	URI        string            `json:"uri"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// provenanceEnvelope is the DSSE envelope of the statement, its signatures are empty if it isn't signed
+type provenanceEnvelope struct {
+	PayloadType string                 `json:"payloadType"`
+	Payload     string                 `json:"payload"`
+	Signatures  []*provenanceSignature `json:"signatures"`
+}
+
+type provenanceSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// writeProvenance writes the provenance of the executables built, as a DSSE envelope in bin/<app>.intoto.jsonl by
+// default, signed if a key is specified
+func writeProvenance(ctx context.Context, project common.AppProject, options common.BuildOptions, started, finished time.Time) error {
+
+	statement, err := buildProvenanceStatement(project, options, started, finished)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	envelope := &provenanceEnvelope{PayloadType: provenancePayloadType, Payload: base64.StdEncoding.EncodeToString(payload), Signatures: []*provenanceSignature{}}
+	if options.Provenance.SignKey != "" {
+		signature, err := signProvenance(ctx, options.Provenance.SignKey, payload)
+		if err != nil {
+			return err
+		}
+		envelope.Signatures = append(envelope.Signatures, signature)
+	}
+
+	buf, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	file := provenanceFile(project, options)
+	err = ioutil.WriteFile(file, append(buf, '\n'), 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote provenance of %d executable(s) to %s\n", len(statement.Subject), file)
+	return nil
+}
+
+// provenanceFile gets the file the provenance of the executables is written to
+func provenanceFile(project common.AppProject, options common.BuildOptions) string {
+	if options.Provenance.File != "" {
+		return options.Provenance.File
+	}
+	return filepath.Join(project.BinDir(), project.Name()+fileProvenanceSuffix)
+}
+
+// buildProvenanceStatement describes the executables built, the app descriptor, go.mod and go.sum, the modules and
+// the toolchain they were built from
+func buildProvenanceStatement(project common.AppProject, options common.BuildOptions, started, finished time.Time) (*ProvenanceStatement, error) {
+
+	var executables []string
+	if len(options.Platforms) > 0 {
+		platforms, err := ParsePlatforms(options.Platforms)
+		if err != nil {
+			return nil, err
+		}
+		for _, platform := range platforms {
+			executables = append(executables, PlatformExecutable(project, platform))
+		}
+	} else {
+		executables = append(executables, project.Executable())
+	}
+
+	statement := &ProvenanceStatement{Type: provenanceStatementType, PredicateType: provenancePredicateType, Predicate: &ProvenancePredicate{}}
+	for _, executable := range executables {
+		hash := util.FileHash(executable)
+		if hash == "" {
+			// ex. a platform whose build failed
+			continue
+		}
+		statement.Subject = append(statement.Subject, &ProvenanceSubject{Name: filepath.Base(executable), Digest: map[string]string{"sha256": hash}})
+	}
+	if len(statement.Subject) == 0 {
+		return nil, fmt.Errorf("no executable found in %s to describe the provenance of", project.BinDir())
+	}
+
+	predicate := statement.Predicate
+	predicate.Builder.ID = provenanceBuilderID
+	if options.Provenance.BuilderVersion != "" {
+		predicate.Builder.ID += "@" + options.Provenance.BuilderVersion
+	}
+	predicate.BuildType = provenanceBuildType
+
+	descriptorHash := util.FileHash(filepath.Join(project.Dir(), fileFlogoJson))
+	if descriptorHash == "" {
+		return nil, fmt.Errorf("unable to read %s", fileFlogoJson)
+	}
+	predicate.Invocation.ConfigSource = &ProvenanceMaterial{URI: fileFlogoJson, Digest: map[string]string{"sha256": descriptorHash}, EntryPoint: fileFlogoJson}
+	predicate.Invocation.Parameters = provenanceParameters(options)
+
+	goos, goarch := buildTargetPlatform()
+	predicate.Invocation.Environment = map[string]string{"GOOS": goos, "GOARCH": goarch, "go": installedGoVersion()}
+	if cli := CLIBuildInfo(); cli != nil {
+		predicate.Invocation.Environment["cliChannel"] = cli.Channel
+		if cli.Commit != "" {
+			predicate.Invocation.Environment["cliCommit"] = cli.Commit
+		}
+	}
+
+	predicate.Metadata.BuildStartedOn = started.UTC().Truncate(time.Second)
+	predicate.Metadata.BuildFinishedOn = finished.UTC().Truncate(time.Second)
+
+	predicate.Materials = append(predicate.Materials, predicate.Invocation.ConfigSource)
+	for _, name := range []string{"go.mod", "go.sum"} {
+		if hash := util.FileHash(filepath.Join(project.SrcDir(), name)); hash != "" {
+			predicate.Materials = append(predicate.Materials, &ProvenanceMaterial{URI: dirSrc + "/" + name, Digest: map[string]string{"sha256": hash}})
+		}
+	}
+	if hash := util.FileHash(filepath.Join(project.Dir(), fileFlogoLock)); hash != "" {
+		predicate.Materials = append(predicate.Materials, &ProvenanceMaterial{URI: fileFlogoLock, Digest: map[string]string{"sha256": hash}})
+	}
+
+	requires := goModRequirements(project.SrcDir())
+	var modules []string
+	for module := range requires {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		predicate.Materials = append(predicate.Materials, &ProvenanceMaterial{URI: "pkg:golang/" + module + "@" + requires[module]})
+	}
+
+	return statement, nil
+}
+
+// provenanceParameters gets the options of the build which affect the executables
+func provenanceParameters(options common.BuildOptions) map[string]interface{} {
+
+	params := make(map[string]interface{})
+	if options.OptimizeImports {
+		params["optimize"] = true
+	}
+	if options.EmbedConfig {
+		params["embed"] = true
+	}
+	if options.Shim != "" {
+		params["shim"] = options.Shim
+	}
+	if options.Variant != "" {
+		params["variant"] = options.Variant
+	}
+	if options.Profile != "" {
+		params["profile"] = options.Profile
+	}
+	if len(options.Tags) > 0 {
+		params["tags"] = options.Tags
+	}
+	if options.Management {
+		params["management"] = true
+	}
+	if options.Trace != "" {
+		params["trace"] = options.Trace
+	}
+	if len(options.Platforms) > 0 {
+		params["platforms"] = options.Platforms
+	}
+
+	return params
+}
+
+// signProvenance signs the payload of the envelope using the ECDSA or RSA private key of the PEM file
+func signProvenance(ctx context.Context, keyFile string, payload []byte) (*provenanceSignature, error) {
+
+	buf, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found in '%s'", keyFile)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse key '%s': %v", keyFile, err)
+	}
+
+	var signer crypto.Signer
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		signer = k
+	case *rsa.PrivateKey:
+		signer = k
+	default:
+		return nil, fmt.Errorf("unsupported key '%s', use an ECDSA or RSA private key", keyFile)
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	keyID := sha256.Sum256(pub)
+
+	digest := sha256.Sum256(dssePreAuthEncoding(provenancePayloadType, payload))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provenanceSignature{KeyID: hex.EncodeToString(keyID[:]), Sig: base64.StdEncoding.EncodeToString(sig)}, nil
+}
+
+// dssePreAuthEncoding encodes the payload and its type as signed by DSSE
+func dssePreAuthEncoding(payloadType string, payload []byte) []byte {
+	return append([]byte(fmt.Sprintf("DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))), payload...)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteProvenance(t *testing.T) {
+	t.Log("Testing writing of the signed provenance of an application")
+
+	tempDir, err := ioutil.TempDir("", "flogo-provenance")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(filepath.Join(tempDir, "myApp"))
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+	assert.Nil(t, os.MkdirAll(project.BinDir(), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(`{"name": "myApp"}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.SrcDir(), "go.mod"), []byte("module main\n\nrequire github.com/project-flogo/core v0.9.5\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(PlatformExecutable(project, &BuildPlatform{OS: "linux", Arch: "arm64"}), []byte("executable"), 0755))
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	keyFile := filepath.Join(tempDir, "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600)
+	assert.Nil(t, err)
+
+	options := common.BuildOptions{Tags: []string{"kafka"}, Platforms: []string{"linux/arm64", "windows/amd64"},
+		Provenance: &common.ProvenanceOptions{SignKey: keyFile, BuilderVersion: "v1.2.0"}}
+	err = writeProvenance(context.Background(), project, options, time.Now(), time.Now())
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.BinDir(), "myApp"+fileProvenanceSuffix))
+	assert.Nil(t, err)
+
+	envelope := &provenanceEnvelope{}
+	assert.Nil(t, json.Unmarshal(buf, envelope))
+	assert.Equal(t, provenancePayloadType, envelope.PayloadType)
+	assert.Len(t, envelope.Signatures, 1)
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	assert.Nil(t, err)
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	assert.Nil(t, err)
+	digest := sha256.Sum256(dssePreAuthEncoding(provenancePayloadType, payload))
+	assert.Nil(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+
+	statement := &ProvenanceStatement{}
+	assert.Nil(t, json.Unmarshal(payload, statement))
+	assert.Equal(t, provenancePredicateType, statement.PredicateType)
+
+	// the platform whose executable is missing isn't a subject
+	assert.Len(t, statement.Subject, 1)
+	assert.Equal(t, "myApp-linux-arm64", statement.Subject[0].Name)
+	assert.Equal(t, util.FileHash(PlatformExecutable(project, &BuildPlatform{OS: "linux", Arch: "arm64"})), statement.Subject[0].Digest["sha256"])
+
+	predicate := statement.Predicate
+	assert.Equal(t, provenanceBuilderID+"@v1.2.0", predicate.Builder.ID)
+	assert.Equal(t, util.FileHash(filepath.Join(project.Dir(), fileFlogoJson)), predicate.Invocation.ConfigSource.Digest["sha256"])
+	assert.Equal(t, []interface{}{"kafka"}, predicate.Invocation.Parameters["tags"])
+	assert.Len(t, predicate.Materials, 3)
+	assert.Equal(t, "src/go.mod", predicate.Materials[1].URI)
+	assert.Equal(t, "pkg:golang/github.com/project-flogo/core@v0.9.5", predicate.Materials[2].URI)
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	filePublishJson = "publish.json"
+
+	fileReleaseManifestSuffix = "-release.json"
+)
+
+// the files of the bin directory which are published as SBOMs, as produced by syft or cyclonedx-gomod
+var sbomPatterns = []string{"*.spdx.json", "*.cdx.json", "*.sbom.json"}
+
+type publishConfig struct {
+	Targets map[string]*publishTargetConfig `json:"targets"`
+}
+
+// publishTargetConfig is the definition of a publish target in publish.json
+type publishTargetConfig struct {
+	Publisher string                 `json:"publisher"`
+	Platforms []string               `json:"platforms,omitempty"`
+	Build     *buildConfig           `json:"build,omitempty"`
+	Settings  map[string]interface{} `json:"settings,omitempty"`
+}
+
+// ReleaseOptions are the options used to release the application
+type ReleaseOptions struct {
+	SkipBuild bool // publish the existing artifacts without building the application
+}
+
+// ReleaseApp builds the application for the platforms of the target defined in the project's publish.json and
+// publishes its artifacts to it
+func ReleaseApp(ctx context.Context, project common.AppProject, targetName string, options ReleaseOptions) error {
+
+	cfg, err := loadPublishTarget(project, targetName)
+	if err != nil {
+		return err
+	}
+
+	buildOptions := cfg.Build.options()
+	buildOptions.Platforms = cfg.Platforms
+
+	if !options.SkipBuild {
+		err = BuildProject(ctx, project, buildOptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	return PublishArtifacts(project, targetName, buildOptions.Platforms)
+}
+
+// PublishArtifacts publishes the executables of the application built for the platforms, or for the current one if
+// none is specified, along with the SBOMs and the provenance found in the bin directory and the manifest of the release
+func PublishArtifacts(project common.AppProject, targetName string, platforms []string) error {
+
+	cfg, err := loadPublishTarget(project, targetName)
+	if err != nil {
+		return err
+	}
+
+	publisher := common.GetPublisher(cfg.Publisher)
+	if publisher == nil {
+		return fmt.Errorf("unknown publisher '%s' for target '%s', available publishers: %s", cfg.Publisher, targetName, strings.Join(common.Publishers(), ", "))
+	}
+
+	release, err := prepareRelease(project, platforms)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Publishing %d artifact(s) of '%s' %s to target '%s' using publisher '%s'...\n", len(release.Artifacts), release.App, release.Version, targetName, cfg.Publisher)
+
+	target := &common.PublishTarget{Name: targetName, Publisher: cfg.Publisher, Settings: cfg.Settings}
+	if target.Settings == nil {
+		target.Settings = make(map[string]interface{})
+	}
+
+	err = publisher.Publish(project, target, release)
+	if err != nil {
+		return fmt.Errorf("publishing to target '%s' failed: %v", targetName, err)
+	}
+
+	fmt.Printf("Published '%s' %s to target '%s'\n", release.App, release.Version, targetName)
+
+	return nil
+}
+
+// ListPublishTargets lists the publish targets defined in the project's publish.json
+func ListPublishTargets(project common.AppProject) error {
+
+	targets, err := loadPublishTargets(project)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cfg := targets[name]
+		platforms := ""
+		if len(cfg.Platforms) > 0 {
+			platforms = " (" + strings.Join(cfg.Platforms, ", ") + ")"
+		}
+		status := ""
+		if common.GetPublisher(cfg.Publisher) == nil {
+			status = " [unknown publisher]"
+		}
+		fmt.Printf("%-20s %s%s%s\n", name, cfg.Publisher, platforms, status)
+	}
+
+	return nil
+}
+
+func loadPublishTargets(project common.AppProject) (map[string]*publishTargetConfig, error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), filePublishJson))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no publish targets defined, %s not found in the project directory", filePublishJson)
+		}
+		return nil, err
+	}
+
+	cfg := &publishConfig{}
+	err = json.Unmarshal(buf, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", filePublishJson, err)
+	}
+
+	for name, target := range cfg.Targets {
+		if target == nil || target.Publisher == "" {
+			return nil, fmt.Errorf("publisher not specified for target '%s'", name)
+		}
+	}
+
+	return cfg.Targets, nil
+}
+
+func loadPublishTarget(project common.AppProject, targetName string) (*publishTargetConfig, error) {
+
+	targets, err := loadPublishTargets(project)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, exists := targets[targetName]
+	if !exists {
+		var names []string
+		for name := range targets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("target '%s' not defined in %s, defined targets: %s", targetName, filePublishJson, strings.Join(names, ", "))
+	}
+
+	return cfg, nil
+}
+
+// prepareRelease collects the artifacts of the release and writes its manifest, bin/<app>-release.json, which is
+// published last
+func prepareRelease(project common.AppProject, platforms []string) (*common.Release, error) {
+
+	release := &common.Release{App: project.Name(), Version: "latest"}
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := appObj["name"].(string); ok && name != "" {
+		release.App = name
+	}
+	if version, ok := appObj["version"].(string); ok && version != "" {
+		release.Version = version
+	}
+
+	var executables []string
+	if len(platforms) > 0 {
+		parsed, err := ParsePlatforms(platforms)
+		if err != nil {
+			return nil, err
+		}
+		for _, platform := range parsed {
+			executables = append(executables, PlatformExecutable(project, platform))
+		}
+	} else {
+		executables = append(executables, project.Executable())
+	}
+
+	for _, executable := range executables {
+		artifact, err := newArtifact(executable, common.ArtifactExecutable)
+		if err != nil {
+			return nil, fmt.Errorf("executable '%s' not found, build the application with 'flogo build'", executable)
+		}
+		release.Artifacts = append(release.Artifacts, artifact)
+	}
+
+	var sboms []string
+	for _, pattern := range sbomPatterns {
+		files, _ := filepath.Glob(filepath.Join(project.BinDir(), pattern))
+		sboms = append(sboms, files...)
+	}
+	sort.Strings(sboms)
+	for _, sbom := range sboms {
+		artifact, err := newArtifact(sbom, common.ArtifactSBOM)
+		if err != nil {
+			return nil, err
+		}
+		release.Artifacts = append(release.Artifacts, artifact)
+	}
+
+	provenance := filepath.Join(project.BinDir(), project.Name()+fileProvenanceSuffix)
+	if util.FileExists(provenance) {
+		artifact, err := newArtifact(provenance, common.ArtifactProvenance)
+		if err != nil {
+			return nil, err
+		}
+		release.Artifacts = append(release.Artifacts, artifact)
+	}
+
+	// the signatures of the executables and the provenance, if signed by the build
+	for _, signed := range append(executables, provenance) {
+		for _, signature := range SignatureFiles(signed) {
+			artifact, err := newArtifact(signature, common.ArtifactSignature)
+			if err != nil {
+				return nil, err
+			}
+			release.Artifacts = append(release.Artifacts, artifact)
+		}
+	}
+
+	buf, err := json.MarshalIndent(release, "", jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	manifest := filepath.Join(project.BinDir(), project.Name()+fileReleaseManifestSuffix)
+	err = ioutil.WriteFile(manifest, append(buf, '\n'), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := newArtifact(manifest, common.ArtifactManifest)
+	if err != nil {
+		return nil, err
+	}
+	release.Artifacts = append(release.Artifacts, artifact)
+
+	return release, nil
+}
+
+func newArtifact(file, artifactType string) (*common.Artifact, error) {
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Artifact{Name: filepath.Base(file), Type: artifactType, Size: info.Size(), SHA256: util.FileHash(file), Path: file}, nil
+}
+
+// PublishSetting gets a string setting of a publish target, an error is returned if it is required and not set
+func PublishSetting(target *common.PublishTarget, name string, required bool) (string, error) {
+
+	val, exists := target.Settings[name]
+	if !exists || val == nil || val == "" {
+		if required {
+			return "", fmt.Errorf("setting '%s' not specified for target '%s'", name, target.Name)
+		}
+		return "", nil
+	}
+
+	return fmt.Sprint(val), nil
+}
+
+// artifactPath gets the path an artifact is published to: <prefix>/<app>/<version>/<name>
+func artifactPath(prefix string, release *common.Release, artifact *common.Artifact) string {
+	return strings.TrimPrefix(path.Join(prefix, release.App, release.Version, artifact.Name), "/")
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishArtifacts(t *testing.T) {
+	t.Log("Testing publishing of the artifacts of the application to Artifactory")
+
+	var mu sync.Mutex
+	uploads := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		buf, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		uploads[r.URL.Path] = r.Header.Get("X-Checksum-Sha256")
+		mu.Unlock()
+		assert.NotEmpty(t, buf)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "flogo-publish")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	appDir := filepath.Join(dir, "myApp")
+	assert.Nil(t, os.MkdirAll(filepath.Join(appDir, "bin"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, "flogo.json"), []byte(`{"name": "myApp", "version": "1.2.0"}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(appDir, filePublishJson), []byte(`{
+  "targets": {
+    "releases": {"publisher": "artifactory", "settings": {"url": "`+server.URL+`/artifactory/generic-local/", "prefix": "flogo", "tokenEnv": "TEST_PUBLISH_TOKEN"}}
+  }
+}`), 0644))
+
+	project := NewAppProject(appDir)
+	assert.Nil(t, ioutil.WriteFile(project.Executable(), []byte("binary"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.BinDir(), "myApp.spdx.json"), []byte(`{"spdxVersion": "SPDX-2.3"}`), 0644))
+
+	err = PublishArtifacts(project, "releases", nil)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "TEST_PUBLISH_TOKEN")
+	}
+
+	defer os.Unsetenv("TEST_PUBLISH_TOKEN")
+	os.Setenv("TEST_PUBLISH_TOKEN", "secret")
+
+	err = PublishArtifacts(project, "releases", nil)
+	assert.Nil(t, err)
+
+	exeName := filepath.Base(project.Executable())
+	assert.Equal(t, map[string]string{
+		"/artifactory/generic-local/flogo/myApp/1.2.0/" + exeName:         util.FileHash(project.Executable()),
+		"/artifactory/generic-local/flogo/myApp/1.2.0/myApp.spdx.json":    util.FileHash(filepath.Join(project.BinDir(), "myApp.spdx.json")),
+		"/artifactory/generic-local/flogo/myApp/1.2.0/myApp-release.json": util.FileHash(filepath.Join(project.BinDir(), "myApp-release.json")),
+	}, uploads)
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.BinDir(), "myApp-release.json"))
+	assert.Nil(t, err)
+	release := &common.Release{}
+	assert.Nil(t, json.Unmarshal(buf, release))
+	assert.Equal(t, "myApp", release.App)
+	assert.Equal(t, "1.2.0", release.Version)
+	if assert.Len(t, release.Artifacts, 2) {
+		assert.Equal(t, common.ArtifactExecutable, release.Artifacts[0].Type)
+		assert.Equal(t, int64(6), release.Artifacts[0].Size)
+		assert.Equal(t, common.ArtifactSBOM, release.Artifacts[1].Type)
+	}
+
+	err = PublishArtifacts(project, "nightly", nil)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "defined targets: releases")
+	}
+}
+
+func TestArtifactMediaType(t *testing.T) {
+	t.Log("Testing media types of the artifacts pushed to an OCI registry")
+
+	assert.Equal(t, "application/vnd.flogo.executable", artifactMediaType(&common.Artifact{Name: "myApp", Type: common.ArtifactExecutable}))
+	assert.Equal(t, "application/spdx+json", artifactMediaType(&common.Artifact{Name: "myApp.spdx.json", Type: common.ArtifactSBOM}))
+	assert.Equal(t, "application/vnd.cyclonedx+json", artifactMediaType(&common.Artifact{Name: "myApp.cdx.json", Type: common.ArtifactSBOM}))
+	assert.Equal(t, "application/vnd.in-toto+json", artifactMediaType(&common.Artifact{Name: "myApp.intoto.jsonl", Type: common.ArtifactProvenance}))
+}
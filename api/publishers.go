@@ -0,0 +1,204 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	defaultArtifactoryTokenEnv = "ARTIFACTORY_TOKEN"
+
+	ociArtifactType = "application/vnd.flogo.release.v1"
+)
+
+func init() {
+	common.RegisterPublisher("s3", &s3Publisher{})
+	common.RegisterPublisher("gcs", &gcsPublisher{})
+	common.RegisterPublisher("artifactory", &artifactoryPublisher{})
+	common.RegisterPublisher("oci", &ociPublisher{})
+}
+
+// s3Publisher copies the artifacts to an S3 bucket, under <prefix>/<app>/<version>
+//
+// settings: bucket (required), prefix, region, profile
+type s3Publisher struct {
+}
+
+func (p *s3Publisher) Publish(project common.AppProject, target *common.PublishTarget, release *common.Release) error {
+
+	bucket, err := PublishSetting(target, "bucket", true)
+	if err != nil {
+		return err
+	}
+	prefix, _ := PublishSetting(target, "prefix", false)
+
+	var awsArgs []string
+	if region, _ := PublishSetting(target, "region", false); region != "" {
+		awsArgs = append(awsArgs, "--region", region)
+	}
+	if profile, _ := PublishSetting(target, "profile", false); profile != "" {
+		awsArgs = append(awsArgs, "--profile", profile)
+	}
+
+	for _, artifact := range release.Artifacts {
+		dest := "s3://" + bucket + "/" + artifactPath(prefix, release, artifact)
+		fmt.Printf("Uploading %s to %s\n", artifact.Name, dest)
+		err = runDeployCmd("", "aws", append([]string{"s3", "cp", artifact.Path, dest, "--only-show-errors"}, awsArgs...)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gcsPublisher copies the artifacts to a Google Cloud Storage bucket, under <prefix>/<app>/<version>
+//
+// settings: bucket (required), prefix
+type gcsPublisher struct {
+}
+
+func (p *gcsPublisher) Publish(project common.AppProject, target *common.PublishTarget, release *common.Release) error {
+
+	bucket, err := PublishSetting(target, "bucket", true)
+	if err != nil {
+		return err
+	}
+	prefix, _ := PublishSetting(target, "prefix", false)
+
+	for _, artifact := range release.Artifacts {
+		dest := "gs://" + bucket + "/" + artifactPath(prefix, release, artifact)
+		fmt.Printf("Uploading %s to %s\n", artifact.Name, dest)
+		err = runDeployCmd("", "gsutil", "-q", "cp", artifact.Path, dest)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// artifactoryPublisher uploads the artifacts to a generic repository of Artifactory, under <prefix>/<app>/<version>,
+// the access token is read from an environment variable so it isn't stored in the project
+//
+// settings: url (required, the URL of the repository), prefix, user, tokenEnv (default ARTIFACTORY_TOKEN)
+type artifactoryPublisher struct {
+}
+
+func (p *artifactoryPublisher) Publish(project common.AppProject, target *common.PublishTarget, release *common.Release) error {
+
+	repoURL, err := PublishSetting(target, "url", true)
+	if err != nil {
+		return err
+	}
+	prefix, _ := PublishSetting(target, "prefix", false)
+	user, _ := PublishSetting(target, "user", false)
+
+	tokenEnv, _ := PublishSetting(target, "tokenEnv", false)
+	if tokenEnv == "" {
+		tokenEnv = defaultArtifactoryTokenEnv
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return fmt.Errorf("the access token of target '%s' isn't set, set it using the %s environment variable", target.Name, tokenEnv)
+	}
+
+	for _, artifact := range release.Artifacts {
+		dest := strings.TrimSuffix(repoURL, "/") + "/" + artifactPath(prefix, release, artifact)
+		fmt.Printf("Uploading %s to %s\n", artifact.Name, dest)
+		err = uploadArtifact(dest, artifact, user, token)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uploadArtifact uploads the artifact using a PUT request, along with its checksum which the server verifies
+func uploadArtifact(url string, artifact *common.Artifact, user, token string) error {
+
+	f, err := os.Open(artifact.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	req, err := http.NewRequest(http.MethodPut, url, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = artifact.Size
+	req.Header.Set("X-Checksum-Sha256", artifact.SHA256)
+	if user != "" {
+		req.SetBasicAuth(user, token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unable to upload %s: %s %s", artifact.Name, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// ociPublisher pushes the artifacts to an OCI registry as an artifact tagged with the version of the application,
+// using oras
+//
+// settings: repository (required, ex. registry.example.com/acme/orders)
+type ociPublisher struct {
+}
+
+func (p *ociPublisher) Publish(project common.AppProject, target *common.PublishTarget, release *common.Release) error {
+
+	repository, err := PublishSetting(target, "repository", true)
+	if err != nil {
+		return err
+	}
+
+	reference := repository + ":" + release.Version
+	args := []string{"push", reference, "--artifact-type", ociArtifactType}
+	for _, artifact := range release.Artifacts {
+		// the files are pushed from the bin directory, so they are named after their base name
+		args = append(args, artifact.Name+":"+artifactMediaType(artifact))
+	}
+
+	fmt.Printf("Pushing %s\n", reference)
+	return runDeployCmd(project.BinDir(), "oras", args...)
+}
+
+// artifactMediaType gets the media type of the layer of an artifact pushed to an OCI registry
+func artifactMediaType(artifact *common.Artifact) string {
+
+	switch artifact.Type {
+	case common.ArtifactSBOM:
+		if strings.HasSuffix(artifact.Name, ".spdx.json") {
+			return "application/spdx+json"
+		}
+		return "application/vnd.cyclonedx+json"
+	case common.ArtifactProvenance:
+		return "application/vnd.in-toto+json"
+	case common.ArtifactManifest:
+		return "application/vnd.flogo.release.manifest.v1+json"
+	case common.ArtifactSignature:
+		if strings.HasSuffix(artifact.Name, ".pem") {
+			return "application/x-pem-file"
+		}
+		return "application/octet-stream"
+	}
+
+	return "application/vnd.flogo.executable"
+}
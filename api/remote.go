@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+)
+
+const remoteTimeout = 10 * time.Second
+
+// RemoteOptions are the options for connecting to the management API of a running application
+type RemoteOptions struct {
+	Addr  string // host:port or URL of the management API
+	Token string // bearer token required by the management API, defaults to FLOGO_MANAGEMENT_TOKEN
+}
+
+// RemoteStatus is the status of a running application reported by its management API
+type RemoteStatus struct {
+	Name       string    `json:"name"`
+	Version    string    `json:"version"`
+	Commit     string    `json:"commit,omitempty"` // the git commit the application was built from
+	Built      string    `json:"built,omitempty"`
+	Status     string    `json:"status"`
+	Started    time.Time `json:"started"`
+	Uptime     string    `json:"uptime"`
+	LogLevel   string    `json:"logLevel"`
+	Goroutines int       `json:"goroutines"`
+	Memory     uint64    `json:"memory"`
+}
+
+// RemoteFlow is a flow of a running application and the triggers that start it
+type RemoteFlow struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Triggers []string `json:"triggers"`
+}
+
+// PrintRemoteStatus prints the status of a running application
+func PrintRemoteStatus(remote RemoteOptions) error {
+
+	status := &RemoteStatus{}
+	err := remoteRequest(remote, http.MethodGet, "/status", nil, status)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Application '%s' is %s\n", status.Name, strings.ToLower(status.Status))
+	fmt.Printf("  Version   : %s\n", status.Version)
+	if status.Commit != "" {
+		fmt.Printf("  Commit    : %s\n", status.Commit)
+	}
+	if status.Built != "" {
+		fmt.Printf("  Built     : %s\n", status.Built)
+	}
+	fmt.Printf("  Started   : %s (%s ago)\n", util.FormatDateTime(status.Started.Local()), status.Uptime)
+	fmt.Printf("  Log level : %s\n", status.LogLevel)
+	fmt.Printf("  Goroutines: %d\n", status.Goroutines)
+	fmt.Printf("  Memory    : %.1f MB\n", float64(status.Memory)/(1024*1024))
+
+	return nil
+}
+
+// ListRemoteFlows lists the flows of a running application
+func ListRemoteFlows(remote RemoteOptions) error {
+
+	var flows []*RemoteFlow
+	err := remoteRequest(remote, http.MethodGet, "/flows", nil, &flows)
+	if err != nil {
+		return err
+	}
+
+	for _, flow := range flows {
+		triggers := "no triggers"
+		if len(flow.Triggers) > 0 {
+			triggers = "triggers: " + strings.Join(flow.Triggers, ", ")
+		}
+		fmt.Printf("%-30s %-30s %s\n", flow.ID, flow.Name, triggers)
+	}
+
+	return nil
+}
+
+// PushRemoteProps updates the app properties of a running application using its management API, the properties
+// are read from a JSON file, if specified, and then set from the name=value pairs
+func PushRemoteProps(remote RemoteOptions, propsFile string, values []string) error {
+
+	props, err := remotePropValues(propsFile, values)
+	if err != nil {
+		return err
+	}
+	if len(props) == 0 {
+		return fmt.Errorf("no properties specified, use a properties file or name=value pairs")
+	}
+
+	result := &struct {
+		Properties []string `json:"properties"`
+	}{}
+	err = remoteRequest(remote, http.MethodPatch, "/props", props, result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated properties: %s\n", strings.Join(result.Properties, ", "))
+
+	return nil
+}
+
+// ReconfigureRemote changes the log level and/or app properties of a running application
+func ReconfigureRemote(remote RemoteOptions, logLevel string, propsFile string, values []string) error {
+
+	props, err := remotePropValues(propsFile, values)
+	if err != nil {
+		return err
+	}
+	if logLevel == "" && len(props) == 0 {
+		return fmt.Errorf("nothing to reconfigure, specify a log level or properties")
+	}
+	if idx := logLevelIndex(logLevel); logLevel != "" && (idx < 0 || idx > 3) {
+		return fmt.Errorf("invalid log level '%s', expected one of: %s", logLevel, strings.Join(logLevels[:4], ", "))
+	}
+
+	cfg := map[string]interface{}{"properties": props}
+	if logLevel != "" {
+		cfg["logLevel"] = strings.ToUpper(logLevel)
+	}
+
+	result := &struct {
+		LogLevel   string   `json:"logLevel"`
+		Properties []string `json:"properties"`
+	}{}
+	err = remoteRequest(remote, http.MethodPatch, "/config", cfg, result)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Log level: %s\n", result.LogLevel)
+	if len(result.Properties) > 0 {
+		fmt.Printf("Updated properties: %s\n", strings.Join(result.Properties, ", "))
+	}
+
+	return nil
+}
+
+// remotePropValues gets the property values read from the JSON file, if specified, and then set from the name=value pairs
+func remotePropValues(propsFile string, values []string) (map[string]interface{}, error) {
+
+	props := make(map[string]interface{})
+
+	if propsFile != "" {
+		buf, err := ioutil.ReadFile(propsFile)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(buf, &props)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse properties file '%s': %v", propsFile, err)
+		}
+	}
+
+	for _, value := range values {
+		idx := strings.Index(value, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid property '%s', expected name=value", value)
+		}
+		// the engine converts the value to the type of the property
+		props[value[:idx]] = value[idx+1:]
+	}
+
+	return props, nil
+}
+
+// remoteRequest sends a request to the management API, the body and the result are JSON encoded, unless the
+// result is a *[]byte which is set to the raw response
+func remoteRequest(remote RemoteOptions, method, path string, body interface{}, result interface{}) error {
+
+	if remote.Addr == "" {
+		remote.Addr = DefaultManagementAddr
+	}
+	url := strings.TrimSuffix(remote.Addr, "/")
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	url += path
+
+	token := remote.Token
+	if token == "" {
+		token = os.Getenv(EnvManagementToken)
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if Verbose() {
+		fmt.Printf("%s %s\n", method, url)
+	}
+
+	client := &http.Client{Timeout: remoteTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to connect to the management API at '%s', the application must be built with 'flogo build --management': %v", remote.Addr, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("unauthorized, specify the token of the management API using --token or %s", EnvManagementToken)
+	case resp.StatusCode >= 300:
+		msg := strings.TrimSpace(string(respBody))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return fmt.Errorf("management API: %s", msg)
+	}
+
+	switch r := result.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*r = respBody
+		return nil
+	}
+
+	return json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// remoteTestRequest is a request received by the test management API
+type remoteTestRequest struct {
+	method      string
+	path        string
+	auth        string
+	contentType string
+	body        map[string]interface{}
+}
+
+// newRemoteTestServer starts a management API recording the requests it receives and responding with the status
+// and body of the path
+func newRemoteTestServer(responses map[string]string) (*httptest.Server, *[]*remoteTestRequest) {
+
+	var requests []*remoteTestRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		req := &remoteTestRequest{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization"), contentType: r.Header.Get("Content-Type")}
+		buf, _ := ioutil.ReadAll(r.Body)
+		if len(buf) > 0 {
+			_ = json.Unmarshal(buf, &req.body)
+		}
+		requests = append(requests, req)
+
+		switch r.URL.Path {
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/error":
+			http.Error(w, "flow not found", http.StatusNotFound)
+		case "/empty-error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		}
+	}))
+
+	return server, &requests
+}
+
+func TestRemoteRequest(t *testing.T) {
+	t.Log("Testing the requests to the management API")
+
+	server, requests := newRemoteTestServer(map[string]string{"/status": `{"name": "myApp", "status": "STARTED"}`})
+	defer server.Close()
+
+	defer os.Setenv(EnvManagementToken, os.Getenv(EnvManagementToken))
+	os.Setenv(EnvManagementToken, "envToken")
+
+	// the address may be a URL, or host:port
+	status := &RemoteStatus{}
+	err := remoteRequest(RemoteOptions{Addr: server.URL + "/"}, http.MethodGet, "/status", nil, status)
+	assert.Nil(t, err)
+	assert.Equal(t, "myApp", status.Name)
+
+	var raw []byte
+	err = remoteRequest(RemoteOptions{Addr: strings.TrimPrefix(server.URL, "http://"), Token: "myToken"}, http.MethodGet, "/status", nil, &raw)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "myApp", "status": "STARTED"}`, string(raw))
+
+	err = remoteRequest(RemoteOptions{Addr: server.URL}, http.MethodPatch, "/config", map[string]interface{}{"logLevel": "DEBUG"}, nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, *requests, 3)
+	first, second, third := (*requests)[0], (*requests)[1], (*requests)[2]
+
+	// the token defaults to the one of the environment
+	assert.Equal(t, "Bearer envToken", first.auth)
+	assert.Equal(t, "Bearer myToken", second.auth)
+
+	// only requests with a body are JSON
+	assert.Equal(t, http.MethodGet, first.method)
+	assert.Equal(t, "/status", first.path)
+	assert.Equal(t, "", first.contentType)
+	assert.Nil(t, first.body)
+	assert.Equal(t, http.MethodPatch, third.method)
+	assert.Equal(t, "application/json", third.contentType)
+	assert.Equal(t, map[string]interface{}{"logLevel": "DEBUG"}, third.body)
+}
+
+func TestRemoteRequestErrors(t *testing.T) {
+	t.Log("Testing the errors of the management API")
+
+	server, _ := newRemoteTestServer(nil)
+	defer server.Close()
+
+	remote := RemoteOptions{Addr: server.URL}
+
+	err := remoteRequest(remote, http.MethodGet, "/unauthorized", nil, nil)
+	assert.EqualError(t, err, "unauthorized, specify the token of the management API using --token or "+EnvManagementToken)
+
+	err = remoteRequest(remote, http.MethodGet, "/error", nil, nil)
+	assert.EqualError(t, err, "management API: flow not found")
+
+	err = remoteRequest(remote, http.MethodGet, "/empty-error", nil, nil)
+	assert.EqualError(t, err, "management API: 500 Internal Server Error")
+
+	err = remoteRequest(remote, http.MethodGet, "/invalid", nil, &RemoteStatus{})
+	assert.NotNil(t, err)
+
+	server.Close()
+	err = remoteRequest(remote, http.MethodGet, "/status", nil, nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the application must be built with 'flogo build --management'")
+}
+
+func TestRemotePropValues(t *testing.T) {
+	t.Log("Testing the property values pushed to the management API")
+
+	tempDir, err := ioutil.TempDir("", "flogo-remote")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	propsFile := filepath.Join(tempDir, "props.json")
+	err = ioutil.WriteFile(propsFile, []byte(`{"port": 8080, "host": "localhost"}`), 0644)
+	assert.Nil(t, err)
+
+	// the pairs override the values of the file
+	props, err := remotePropValues(propsFile, []string{"host=example.com", "url=http://a?b=c"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"port": float64(8080), "host": "example.com", "url": "http://a?b=c"}, props)
+
+	_, err = remotePropValues("", []string{"=value"})
+	assert.EqualError(t, err, "invalid property '=value', expected name=value")
+
+	_, err = remotePropValues(filepath.Join(tempDir, "missing.json"), nil)
+	assert.NotNil(t, err)
+
+	err = ioutil.WriteFile(propsFile, []byte(`[1]`), 0644)
+	assert.Nil(t, err)
+	_, err = remotePropValues(propsFile, nil)
+	assert.NotNil(t, err)
+}
+
+func TestReconfigureRemote(t *testing.T) {
+	t.Log("Testing the reconfiguration of a running application")
+
+	server, requests := newRemoteTestServer(map[string]string{
+		"/config": `{"logLevel": "DEBUG", "properties": ["host"]}`,
+		"/props":  `{"properties": ["host", "port"]}`,
+	})
+	defer server.Close()
+
+	remote := RemoteOptions{Addr: server.URL}
+
+	err := ReconfigureRemote(remote, "", "", nil)
+	assert.EqualError(t, err, "nothing to reconfigure, specify a log level or properties")
+	err = ReconfigureRemote(remote, "fatal", "", nil)
+	assert.EqualError(t, err, "invalid log level 'fatal', expected one of: debug, info, warn, error")
+	err = PushRemoteProps(remote, "", nil)
+	assert.NotNil(t, err)
+	assert.Len(t, *requests, 0)
+
+	out := captureStdout(t, func() {
+		err = ReconfigureRemote(remote, "debug", "", []string{"host=example.com"})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Log level: DEBUG\nUpdated properties: host\n", out)
+
+	out = captureStdout(t, func() {
+		err = PushRemoteProps(remote, "", []string{"host=example.com", "port=80"})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Updated properties: host, port\n", out)
+
+	assert.Len(t, *requests, 2)
+	assert.Equal(t, "/config", (*requests)[0].path)
+	assert.Equal(t, map[string]interface{}{"logLevel": "DEBUG", "properties": map[string]interface{}{"host": "example.com"}}, (*requests)[0].body)
+	assert.Equal(t, http.MethodPatch, (*requests)[1].method)
+	assert.Equal(t, "/props", (*requests)[1].path)
+	assert.Equal(t, map[string]interface{}{"host": "example.com", "port": "80"}, (*requests)[1].body)
+}
+
+func TestPrintRemoteStatus(t *testing.T) {
+	t.Log("Testing the status and flows of a running application")
+
+	server, _ := newRemoteTestServer(map[string]string{
+		"/status": `{"name": "myApp", "version": "1.0.0", "commit": "abc123", "status": "STARTED", "started": "2026-01-01T10:00:00Z", "uptime": "1h0m0s", "logLevel": "INFO", "goroutines": 12, "memory": 3145728}`,
+		"/flows":  `[{"id": "flow:main", "name": "Main", "triggers": ["rest", "timer"]}, {"id": "flow:aux", "name": "Aux"}]`,
+	})
+	defer server.Close()
+
+	remote := RemoteOptions{Addr: server.URL}
+
+	var err error
+	out := captureStdout(t, func() {
+		err = PrintRemoteStatus(remote)
+	})
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(out, "Application 'myApp' is started\n  Version   : 1.0.0\n  Commit    : abc123\n"))
+	assert.NotContains(t, out, "Built")
+	assert.Contains(t, out, "  Goroutines: 12\n  Memory    : 3.0 MB\n")
+
+	out = captureStdout(t, func() {
+		err = ListRemoteFlows(remote)
+	})
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], "triggers: rest, timer"))
+	assert.True(t, strings.HasSuffix(lines[1], "no triggers"))
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const sectionImportReplaces = "importReplaces"
+
+// ImportReplace is the replacement of the module of a contribution by a local directory or a fork, recorded in the
+// importReplaces section of flogo.json so it is applied again when the project is created from its descriptor
+type ImportReplace struct {
+	Ref     string // the replaced contribution, its module or one of its packages
+	Module  string // the replaced module
+	Path    string // the local directory, relative to the project directory, or the module of the fork
+	Version string // the version of the fork, empty for a local directory
+}
+
+// ParseImportReplace parses a replacement: ref=>local/path or ref=>fork@version, a local path starts with ./, ../ or /
+// unless it is an existing directory
+func ParseImportReplace(spec string) (*ImportReplace, error) {
+
+	parts := strings.SplitN(spec, "=>", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return nil, fmt.Errorf("invalid replacement '%s', expected <ref>=><local path> or <ref>=><fork>@<version>", spec)
+	}
+
+	target := strings.TrimSpace(parts[1])
+	if !isLocalReplacePath(target) && util.DirExists(target) {
+		target = "./" + target
+	}
+
+	return newImportReplace(strings.TrimSpace(parts[0]), target)
+}
+
+func newImportReplace(ref, target string) (*ImportReplace, error) {
+
+	ref, _ = splitModuleVersion(ref)
+	replace := &ImportReplace{Ref: ref, Module: ref, Path: target}
+
+	if isLocalReplacePath(target) {
+		return replace, nil
+	}
+
+	replace.Path, replace.Version = splitModuleVersion(target)
+	if replace.Version == "" {
+		return nil, fmt.Errorf("the version of fork '%s' must be specified, ex. %s@master", target, target)
+	}
+
+	return replace, nil
+}
+
+// String gets the replacement as recorded in flogo.json: the local path or fork@version
+func (r *ImportReplace) String() string {
+	if r.Version == "" {
+		return r.Path
+	}
+	return r.Path + "@" + r.Version
+}
+
+// goModTarget gets the replacement used by the go.mod of the src directory, the local directory relative to it
+func (r *ImportReplace) goModTarget(baseDir, srcDir string) string {
+	if r.Version != "" {
+		return r.Path + "@" + r.Version
+	}
+	dir := filepath.FromSlash(r.Path)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	return localReplacePath(srcDir, dir)
+}
+
+// InstallReplacedPackage installs a contribution replaced by a local directory or a fork, the replacement is either
+// ref=>target or target alone, in which case pkg is the replaced contribution
+func InstallReplacedPackage(ctx context.Context, project common.AppProject, replacement string, pkg string) error {
+
+	if !strings.Contains(replacement, "=>") {
+		replacement = pkg + "=>" + replacement
+	}
+	replace, err := ParseImportReplace(replacement)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+
+	pkg, err = addImportReplace(ctx, project, replace)
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	return InstallPackage(ctx, project, pkg)
+}
+
+// addImportReplace replaces the module in go.mod and records the replacement in flogo.json, the import of the replaced
+// contribution is returned
+func addImportReplace(ctx context.Context, project common.AppProject, replace *ImportReplace) (string, error) {
+
+	if replace.Version == "" {
+		dir := filepath.FromSlash(replace.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(project.Dir(), dir)
+		}
+		if !util.DirExists(dir) {
+			return "", fmt.Errorf("replacement directory '%s' not found", replace.Path)
+		}
+		// a directory replacing a module must declare it
+		if module := goModModulePath(dir); module != "" {
+			if replace.Ref != module && !strings.HasPrefix(replace.Ref, module+"/") {
+				return "", fmt.Errorf("'%s' isn't provided by module %s of directory '%s'", replace.Ref, module, replace.Path)
+			}
+			replace.Module = module
+		}
+	}
+
+	target := replace.goModTarget(project.Dir(), project.SrcDir())
+	err := project.DepManager().InstallReplacedPkg(ctx, replace.Module, target)
+	if err != nil {
+		return "", err
+	}
+
+	replaces, err := readImportReplaces(project)
+	if err != nil {
+		return "", err
+	}
+	replaces[replace.Module] = replace.String()
+	err = writeAppDescriptorValue(project, "$."+sectionImportReplaces, replaces)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Replaced %s with %s\n", replace.Module, replace)
+
+	// the replaced module may not be published, it is required at v0.0.0 unless it is already required
+	version := "v0.0.0"
+	if required, exists := goModRequirements(project.SrcDir())[replace.Module]; exists {
+		version = required
+	}
+	imp := replace.Module + "@" + version
+	if replace.Ref != replace.Module {
+		imp += ":" + strings.TrimPrefix(replace.Ref, replace.Module)
+	}
+
+	return imp, nil
+}
+
+// applyImportReplaces replaces the modules of the importReplaces section of flogo.json in go.mod, the local
+// directories are relative to baseDir, the directory of the descriptor the project is created from
+func applyImportReplaces(ctx context.Context, project common.AppProject, baseDir string) error {
+
+	replaces, err := readImportReplaces(project)
+	if err != nil {
+		return err
+	}
+
+	var modules []string
+	for module := range replaces {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		replace, err := newImportReplace(module, replaces[module])
+		if err != nil {
+			return fmt.Errorf("invalid replacement of '%s' in %s: %v", module, sectionImportReplaces, err)
+		}
+		target := replace.goModTarget(baseDir, project.SrcDir())
+		err = project.DepManager().Edit(ctx, "-replace", module+"="+target)
+		if err != nil {
+			return err
+		}
+		if Verbose() {
+			fmt.Printf("Replaced %s with %s\n", module, target)
+		}
+	}
+
+	return nil
+}
+
+// readImportReplaces gets the importReplaces section of flogo.json, keyed by module
+func readImportReplaces(project common.AppProject) (map[string]string, error) {
+
+	replaces := make(map[string]string)
+	_, err := readAppDescriptorValue(project, "$."+sectionImportReplaces, &replaces)
+	if err != nil {
+		return nil, err
+	}
+
+	return replaces, nil
+}
+
+func isLocalReplacePath(target string) bool {
+	return strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") || filepath.IsAbs(target) ||
+		target == "." || target == ".."
+}
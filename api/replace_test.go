@@ -0,0 +1,48 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportReplace(t *testing.T) {
+
+	replace, err := ParseImportReplace("github.com/acme/flogo/activity/log=>../log")
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/acme/flogo/activity/log", replace.Ref)
+	assert.Equal(t, "../log", replace.Path)
+	assert.Equal(t, "", replace.Version)
+	assert.Equal(t, "../log", replace.String())
+
+	replace, err = ParseImportReplace("github.com/acme/activity@v1.0.0 => github.com/fork/activity@master")
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/acme/activity", replace.Module)
+	assert.Equal(t, "github.com/fork/activity", replace.Path)
+	assert.Equal(t, "master", replace.Version)
+	assert.Equal(t, "github.com/fork/activity@master", replace.String())
+	assert.Equal(t, "github.com/fork/activity@master", replace.goModTarget("/tmp", "/tmp/app/src"))
+
+	_, err = ParseImportReplace("github.com/acme/activity=>github.com/fork/activity")
+	assert.NotNil(t, err)
+	_, err = ParseImportReplace("github.com/acme/activity")
+	assert.NotNil(t, err)
+	_, err = ParseImportReplace("=>../log")
+	assert.NotNil(t, err)
+}
+
+func TestImportReplaceGoModTarget(t *testing.T) {
+
+	root := filepath.FromSlash("/work")
+
+	replace, err := newImportReplace("github.com/acme/activity", "../activity")
+	assert.Nil(t, err)
+	assert.Equal(t, "../../activity", replace.goModTarget(filepath.Join(root, "app"), filepath.Join(root, "app", "src")))
+	// the project is created from a descriptor of another directory
+	assert.Equal(t, "../../../activity", replace.goModTarget(filepath.Join(root, "app"), filepath.Join(root, "apps", "copy", "src")))
+
+	replace, err = newImportReplace("github.com/acme/activity", "./contrib/activity")
+	assert.Nil(t, err)
+	assert.Equal(t, "../contrib/activity", replace.goModTarget(filepath.Join(root, "app"), filepath.Join(root, "app", "src")))
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	ReportFormatText = "text"
+	ReportFormatJson = "json"
+	ReportFormatHtml = "html"
+
+	dirTests = "tests"
+
+	reportSectionValidation = "validation"
+	reportSectionOutdated   = "outdated"
+	reportSectionAudit      = "audit"
+	reportSectionUnused     = "unused"
+	reportSectionTests      = "tests"
+)
+
+// ReportOptions are the options used to report the health of the project
+type ReportOptions struct {
+	Offline bool // skip the checks querying the module proxy
+}
+
+// HealthReport is the health of the project, scored out of 100
+type HealthReport struct {
+	App       string           `json:"app"`
+	Version   string           `json:"version,omitempty"`
+	Generated time.Time        `json:"generated"`
+	Score     int              `json:"score"`
+	Grade     string           `json:"grade"`
+	Sections  []*ReportSection `json:"sections"`
+}
+
+// ReportSection is an aspect of the health of the project, its findings cost up to MaxPenalty points
+type ReportSection struct {
+	Name       string           `json:"name"`
+	Title      string           `json:"title"`
+	Penalty    int              `json:"penalty"`
+	MaxPenalty int              `json:"maxPenalty"`
+	Skipped    string           `json:"skipped,omitempty"` // the reason the section wasn't checked
+	Findings   []*ReportFinding `json:"findings"`
+}
+
+// ReportFinding is a problem found in the project
+type ReportFinding struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+}
+
+// the points of the score each section can cost, adding up to 100
+var reportMaxPenalties = map[string]int{
+	reportSectionValidation: 30,
+	reportSectionAudit:      25,
+	reportSectionTests:      20,
+	reportSectionOutdated:   15,
+	reportSectionUnused:     10,
+}
+
+// BuildHealthReport aggregates the validation issues, the outdated contributions, the plaintext secrets, the unused
+// imports and the flows without tests into a scored report
+func BuildHealthReport(ctx context.Context, project common.AppProject, options ReportOptions) (*HealthReport, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HealthReport{App: project.Name(), Generated: time.Now().UTC().Truncate(time.Second)}
+	if name, ok := appObj["name"].(string); ok && name != "" {
+		report.App = name
+	}
+	report.Version, _ = appObj["version"].(string)
+
+	validation := newReportSection(reportSectionValidation, "Validation")
+	issues, err := ValidateProject(project, ValidateOptions{})
+	if err != nil {
+		validation.addFinding(SeverityError, fileFlogoJson, "unable to validate the application: %v", err)
+	}
+	for _, issue := range issues {
+		validation.addFinding(issue.Severity, issue.Path, "%s", issue.Message)
+	}
+
+	outdated := newReportSection(reportSectionOutdated, "Outdated contributions")
+	if options.Offline {
+		outdated.Skipped = "offline"
+	} else {
+		imports, err := util.ParseImports(descriptorImports(appObj))
+		if err != nil {
+			return nil, err
+		}
+		mods := importedModules(imports, goModRequirements(project.SrcDir()))
+		if len(mods) > 0 {
+			modules, err := findOutdatedModules(ctx, project.SrcDir(), mods)
+			if err != nil {
+				outdated.Skipped = err.Error()
+			}
+			for _, mod := range modules {
+				outdated.addFinding(SeverityWarning, mod.Path, "%s is installed, %s is available", mod.Version, mod.Latest)
+			}
+		}
+	}
+
+	audit := newReportSection(reportSectionAudit, "Audit")
+	secrets, err := ScanSecrets(project)
+	if err != nil {
+		return nil, err
+	}
+	for _, finding := range secrets {
+		audit.addFinding(SeverityError, finding.Path, "%s, use an encrypted SECRET: value", finding.Reason)
+	}
+
+	unused := newReportSection(reportSectionUnused, "Unused imports")
+	for _, usage := range getContribUsages(appObj) {
+		if usage.Unused {
+			unused.addFinding(SeverityWarning, usage.Import, "imported but not used by the application")
+		}
+	}
+
+	tests := newReportSection(reportSectionTests, "Flow tests")
+	tested := testedFlows(project)
+	var flows []string
+	for flow := range getFlowVersions(appObj) {
+		flows = append(flows, flow)
+	}
+	sort.Strings(flows)
+	for _, flow := range flows {
+		if !tested[flow] {
+			tests.addFinding(SeverityWarning, flow, "no test found in the %s directory", dirTests)
+		}
+	}
+
+	scoreSection(validation, func(f *ReportFinding) int {
+		if f.Severity == SeverityError {
+			return 10
+		}
+		return 2
+	})
+	scoreSection(outdated, func(f *ReportFinding) int { return 3 })
+	scoreSection(audit, func(f *ReportFinding) int { return 10 })
+	scoreSection(unused, func(f *ReportFinding) int { return 2 })
+	// the untested flows cost their share of the points of the section
+	if len(flows) > 0 {
+		tests.Penalty = tests.MaxPenalty * len(tests.Findings) / len(flows)
+	}
+
+	report.Sections = []*ReportSection{validation, audit, tests, outdated, unused}
+	report.Score = 100
+	for _, section := range report.Sections {
+		report.Score -= section.Penalty
+	}
+	report.Grade = reportGrade(report.Score)
+
+	return report, nil
+}
+
+func newReportSection(name, title string) *ReportSection {
+	return &ReportSection{Name: name, Title: title, MaxPenalty: reportMaxPenalties[name], Findings: []*ReportFinding{}}
+}
+
+func (s *ReportSection) addFinding(severity, path, format string, args ...interface{}) {
+	s.Findings = append(s.Findings, &ReportFinding{Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// scoreSection sets the penalty of the section to the cost of its findings, up to its maximum
+func scoreSection(section *ReportSection, cost func(f *ReportFinding) int) {
+
+	penalty := 0
+	for _, finding := range section.Findings {
+		penalty += cost(finding)
+	}
+	if penalty > section.MaxPenalty {
+		penalty = section.MaxPenalty
+	}
+
+	section.Penalty = penalty
+}
+
+func reportGrade(score int) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	}
+	return "F"
+}
+
+// descriptorImports gets the imports of the app descriptor
+func descriptorImports(appObj map[string]interface{}) []string {
+
+	var imports []string
+	vals, _ := appObj["imports"].([]interface{})
+	for _, val := range vals {
+		if s, ok := val.(string); ok {
+			imports = append(imports, s)
+		}
+	}
+
+	return imports
+}
+
+// testedFlows gets the flows tested by the JSON files of the tests directory, each of them names the flow it tests,
+// ex. {"flow": "flow:orders", ...}
+func testedFlows(project common.AppProject) map[string]bool {
+
+	tested := make(map[string]bool)
+
+	files, _ := filepath.Glob(filepath.Join(project.Dir(), dirTests, "*.json"))
+	for _, file := range files {
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var test struct {
+			Flow string `json:"flow"`
+		}
+		if json.Unmarshal(buf, &test) == nil && test.Flow != "" {
+			base, _ := splitFlowVersion(normalizeFlowId(test.Flow))
+			tested[base] = true
+		}
+	}
+
+	return tested
+}
+
+// PrintHealthReport prints the report in the format: text, json or html
+func PrintHealthReport(w io.Writer, report *HealthReport, format string) error {
+
+	switch format {
+	case "", ReportFormatText:
+		printHealthReportText(w, report)
+		return nil
+	case ReportFormatJson:
+		buf, err := json.MarshalIndent(report, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(buf))
+		return err
+	case ReportFormatHtml:
+		return reportTemplate.Execute(w, report)
+	}
+
+	return fmt.Errorf("invalid format '%s', expected %s, %s or %s", format, ReportFormatText, ReportFormatJson, ReportFormatHtml)
+}
+
+func printHealthReportText(w io.Writer, report *HealthReport) {
+
+	app := report.App
+	if report.Version != "" {
+		app += " " + report.Version
+	}
+	fmt.Fprintf(w, "Health of %s: %d/100 (%s)\n", app, report.Score, report.Grade)
+
+	for _, section := range report.Sections {
+		fmt.Fprintln(w)
+		summary := fmt.Sprintf("%d finding(s)", len(section.Findings))
+		if section.Skipped != "" {
+			summary = "skipped: " + section.Skipped
+		}
+		fmt.Fprintf(w, "%-24s -%d/%d  %s\n", section.Title, section.Penalty, section.MaxPenalty, summary)
+		for _, finding := range section.Findings {
+			fmt.Fprintf(w, "  %s: %s: %s\n", finding.Severity, finding.Path, finding.Message)
+		}
+	}
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.App}} - flogo report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 small { color: #888; font-weight: normal; }
+.score { font-size: 2em; }
+.grade-A, .grade-B { color: #2a7a2a; }
+.grade-C, .grade-D { color: #a05a00; }
+.grade-F { color: #b52a2a; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+td, th { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+.error { color: #b52a2a; }
+.warning { color: #a05a00; }
+.path { font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>{{.App}} <small>{{.Version}}</small></h1>
+<p class="score grade-{{.Grade}}">{{.Score}}/100 ({{.Grade}})</p>
+<p>Generated {{.Generated.Format "2006-01-02 15:04:05 MST"}}</p>
+{{range .Sections}}
+<h2>{{.Title}} <small>-{{.Penalty}}/{{.MaxPenalty}}</small></h2>
+{{if .Skipped}}<p>Skipped: {{.Skipped}}</p>{{end}}
+{{if .Findings}}
+<table>
+<tr><th>Severity</th><th>Path</th><th>Finding</th></tr>
+{{range .Findings}}<tr><td class="{{.Severity}}">{{.Severity}}</td><td class="path">{{.Path}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{else if not .Skipped}}<p>No findings</p>{{end}}
+{{end}}
+</body>
+</html>
+`))
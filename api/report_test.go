@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHealthReport(t *testing.T) {
+	t.Log("Testing scoring of the health of a project")
+
+	tempDir, err := ioutil.TempDir("", "flogo-report")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	assert.Nil(t, os.MkdirAll(project.SrcDir(), 0755))
+
+	appJson := `{
+  "name": "myApp",
+  "version": "1.0.0",
+  "imports": ["github.com/project-flogo/flow", "github.com/project-flogo/contrib/activity/log"],
+  "properties": [{"name": "db.password", "type": "string", "value": "admin"}],
+  "triggers": [{"id": "rest", "handlers": [{"action": {"ref": "#flow", "settings": {"flowURI": "res://flow:orders"}}}]}],
+  "resources": [{"id": "flow:orders", "data": {}}, {"id": "flow:billing", "data": {}}, {"id": "flow:billing@v2", "data": {}}]
+}`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(appJson), 0644))
+	assert.Nil(t, os.MkdirAll(filepath.Join(project.Dir(), dirTests), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(project.Dir(), dirTests, "orders.json"), []byte(`{"flow": "orders"}`), 0644))
+
+	report, err := BuildHealthReport(context.Background(), project, ReportOptions{Offline: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", report.Version)
+
+	sections := make(map[string]*ReportSection)
+	for _, section := range report.Sections {
+		sections[section.Name] = section
+	}
+	assert.Equal(t, "offline", sections[reportSectionOutdated].Skipped)
+	assert.Len(t, sections[reportSectionAudit].Findings, 1)
+	assert.Equal(t, "$.properties[0].value", sections[reportSectionAudit].Findings[0].Path)
+	assert.Equal(t, 10, sections[reportSectionAudit].Penalty)
+	assert.Len(t, sections[reportSectionUnused].Findings, 1)
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/log", sections[reportSectionUnused].Findings[0].Path)
+	assert.Len(t, sections[reportSectionTests].Findings, 1)
+	assert.Equal(t, "flow:billing", sections[reportSectionTests].Findings[0].Path)
+	assert.Equal(t, 10, sections[reportSectionTests].Penalty)
+
+	penalties := 0
+	for _, section := range report.Sections {
+		assert.True(t, section.Penalty <= section.MaxPenalty)
+		penalties += section.Penalty
+	}
+	assert.Equal(t, 100-penalties, report.Score)
+	assert.Equal(t, reportGrade(report.Score), report.Grade)
+
+	var buf bytes.Buffer
+	assert.Nil(t, PrintHealthReport(&buf, report, ReportFormatText))
+	assert.True(t, strings.HasPrefix(buf.String(), "Health of myApp 1.0.0: "))
+
+	buf.Reset()
+	assert.Nil(t, PrintHealthReport(&buf, report, ReportFormatHtml))
+	assert.Contains(t, buf.String(), "<td class=\"path\">flow:billing</td>")
+
+	assert.NotNil(t, PrintHealthReport(&buf, report, "xml"))
+}
+
+func TestReportGrade(t *testing.T) {
+	t.Log("Testing grading of the health score")
+
+	assert.Equal(t, "A", reportGrade(100))
+	assert.Equal(t, "B", reportGrade(85))
+	assert.Equal(t, "D", reportGrade(60))
+	assert.Equal(t, "F", reportGrade(12))
+}
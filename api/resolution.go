@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/project-flogo/cli/util"
+)
+
+// versionConflict is a module resolved by the minimal version selection to a version other than the one expected
+type versionConflict struct {
+	Module     string
+	Expected   string
+	Selected   string
+	RequiredBy []string // the modules requiring the selected version, as path@version
+}
+
+// goModRequirements gets the versions of the modules required in the go.mod of the directory
+func goModRequirements(srcDir string) map[string]string {
+
+	reqs := make(map[string]string)
+
+	buf, err := ioutil.ReadFile(filepath.Join(srcDir, "go.mod"))
+	if err != nil {
+		return reqs
+	}
+
+	inRequire := false
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		switch {
+		case line == "require (":
+			inRequire = true
+			continue
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inRequire:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			reqs[fields[0]] = fields[1]
+		}
+	}
+
+	return reqs
+}
+
+// checkResolvedVersions explains the modules whose resolved version differs from the one requested or previously
+// required by the application, before is the requirements of the go.mod prior to the installation of the import
+func checkResolvedVersions(srcDir string, before map[string]string, flogoImport util.Import) {
+
+	expected := make(map[string]string)
+	for mod, ver := range before {
+		expected[mod] = ver
+	}
+	delete(expected, flogoImport.ModulePath())
+
+	// a replaced import is installed using a placeholder version
+	if ver := flogoImport.Version(); strings.HasPrefix(ver, "v") && ver != "v0.0.0" {
+		expected[flogoImport.ModulePath()] = ver
+	}
+	if len(expected) == 0 {
+		return
+	}
+
+	cmd := exec.Command("go", "mod", "graph")
+	cmd.Dir = srcDir
+	cmd.Env = util.GoCmdEnv(filepath.Dir(srcDir))
+	out, err := cmd.Output()
+	if err != nil {
+		if Verbose() {
+			fmt.Printf("Unable to check resolved versions: %v\n", err)
+		}
+		return
+	}
+
+	for _, conflict := range findVersionConflicts(string(out), expected) {
+		printVersionConflict(conflict)
+	}
+}
+
+// findVersionConflicts determines the version of the modules selected from the output of 'go mod graph', which is
+// the highest version required in the graph, and the modules requiring it
+func findVersionConflicts(graph string, expected map[string]string) []*versionConflict {
+
+	selected := make(map[string]string)
+	requiredBy := make(map[string][]string)
+
+	for _, line := range strings.Split(graph, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		mod, ver := splitModuleVersion(fields[1])
+		if ver == "" {
+			continue
+		}
+		if cur, ok := selected[mod]; !ok || compareModuleVersions(ver, cur) > 0 {
+			selected[mod] = ver
+		}
+		requiredBy[fields[1]] = append(requiredBy[fields[1]], fields[0])
+	}
+
+	var conflicts []*versionConflict
+	for mod, ver := range expected {
+		sel, ok := selected[mod]
+		if !ok || sel == ver {
+			continue
+		}
+
+		conflict := &versionConflict{Module: mod, Expected: ver, Selected: sel}
+		for _, req := range requiredBy[mod+"@"+sel] {
+			// the main module has no version
+			if strings.Contains(req, "@") {
+				conflict.RequiredBy = append(conflict.RequiredBy, req)
+			}
+		}
+		sort.Strings(conflict.RequiredBy)
+		conflicts = append(conflicts, conflict)
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Module < conflicts[j].Module
+	})
+
+	return conflicts
+}
+
+func splitModuleVersion(modVer string) (string, string) {
+	idx := strings.LastIndex(modVer, "@")
+	if idx < 0 {
+		return modVer, ""
+	}
+	return modVer[:idx], modVer[idx+1:]
+}
+
+// compareModuleVersions compares two module versions, versions which aren't semantic versions are compared as strings
+func compareModuleVersions(v1, v2 string) int {
+
+	sv1, err1 := semver.NewVersion(strings.TrimPrefix(v1, "v"))
+	sv2, err2 := semver.NewVersion(strings.TrimPrefix(v2, "v"))
+	if err1 != nil || err2 != nil {
+		return strings.Compare(v1, v2)
+	}
+
+	return sv1.Compare(*sv2)
+}
+
+func printVersionConflict(conflict *versionConflict) {
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "Warning: '%s' resolved to %s instead of %s\n", conflict.Module, conflict.Selected, conflict.Expected)
+	if len(conflict.RequiredBy) > 0 {
+		fmt.Fprintf(&b, "  %s is required by:\n", conflict.Selected)
+		for _, req := range conflict.RequiredBy {
+			fmt.Fprintf(&b, "    %s\n", req)
+		}
+	} else {
+		fmt.Fprintf(&b, "  %s is required by the application\n", conflict.Selected)
+	}
+
+	fmt.Fprintf(&b, "  Options:\n")
+	fmt.Fprintf(&b, "    upgrade: keep %s, it is the version the application will be built with\n", conflict.Selected)
+	fmt.Fprintf(&b, "    pin:     force %s, regardless of the requirements, using 'go mod edit -replace %s=%s@%s' in src\n",
+		conflict.Expected, conflict.Module, conflict.Module, conflict.Expected)
+	if len(conflict.RequiredBy) > 0 {
+		req, _ := splitModuleVersion(conflict.RequiredBy[0])
+		fmt.Fprintf(&b, "    exclude: use another version of '%s' using 'go mod edit -exclude %s' in src, or install one "+
+			"compatible with %s using 'flogo install %s@<version>'\n", req, conflict.RequiredBy[0], conflict.Expected, req)
+	}
+
+	fmt.Print(b.String())
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindVersionConflicts(t *testing.T) {
+	t.Log("Testing detection of modules resolved to an unexpected version")
+
+	graph := `main github.com/project-flogo/core@v0.9.5
+main github.com/project-flogo/contrib/activity/log@v0.9.0
+main github.com/myuser/activity@v1.0.0
+github.com/project-flogo/contrib/activity/log@v0.9.0 github.com/project-flogo/core@v0.9.0
+github.com/myuser/activity@v1.0.0 github.com/project-flogo/core@v1.2.0
+github.com/project-flogo/core@v1.2.0 github.com/stretchr/testify@v1.4.0
+`
+
+	conflicts := findVersionConflicts(graph, map[string]string{
+		"github.com/project-flogo/core":                 "v0.9.5",
+		"github.com/project-flogo/contrib/activity/log": "v0.9.0",
+	})
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, "github.com/project-flogo/core", conflicts[0].Module)
+	assert.Equal(t, "v0.9.5", conflicts[0].Expected)
+	assert.Equal(t, "v1.2.0", conflicts[0].Selected)
+	assert.Equal(t, []string{"github.com/myuser/activity@v1.0.0"}, conflicts[0].RequiredBy)
+
+	assert.Empty(t, findVersionConflicts(graph, map[string]string{"github.com/myuser/activity": "v1.0.0"}))
+}
+
+func TestCompareModuleVersions(t *testing.T) {
+	assert.True(t, compareModuleVersions("v0.10.0", "v0.9.5") > 0)
+	assert.True(t, compareModuleVersions("v0.9.5-beta.1", "v0.9.5") < 0)
+	assert.Equal(t, 0, compareModuleVersions("v1.0.0", "v1.0.0"))
+}
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileRunProps = "run-props.json"
+
+	envKeyEngineConfigPath = "FLOGO_ENG_CONFIG_PATH"
+
+	runStopTimeout = 10 * time.Second
+)
+
+// RunOptions are the options used to run the application in the foreground
+type RunOptions struct {
+	Start         StartOptions        // the environment variables, property files and log level of the application
+	Build         common.BuildOptions // options the application is built with if it isn't up to date
+	NoBuild       bool                // run the executable as is, without building it
+	Profile       string              // the property profile whose values override the ones of the property files
+	PropValues    []string            // app property values, as name=value, overriding the ones of the profile
+	EngineProfile string              // the engine configuration to run with, engine.<profile>.json of the project
+	Pretty        bool                // format the JSON log entries like the console format of the engine
+	Args          []string            // arguments passed to the application
+}
+
+// RunApp builds the application if it isn't up to date and runs it in the foreground, streaming its output, until it
+// exits or is interrupted. The warnings the engine logs while starting are reported with their remediation.
+func RunApp(ctx context.Context, project common.AppProject, options RunOptions) error {
+
+	if !options.NoBuild {
+		err := BuildProject(ctx, project, options.Build)
+		if err != nil {
+			return err
+		}
+	}
+
+	exe := project.Executable()
+	if _, err := os.Stat(exe); err != nil {
+		return fmt.Errorf("executable '%s' not found, build the application with 'flogo build'", exe)
+	}
+
+	if status, err := GetAppStatus(project); err == nil && status.Running {
+		fmt.Fprintf(os.Stderr, "Warning: application '%s' is also running in the background (pid %d), stop it using 'flogo stop'\n", project.Name(), status.Pid)
+	}
+
+	var propFiles []string
+	if options.Profile != "" {
+		if _, err := loadProfile(project, options.Profile); err != nil {
+			return err
+		}
+		propFiles = append(propFiles, profileFile(project, options.Profile))
+	}
+	if len(options.PropValues) > 0 {
+		propsFile, err := writeRunPropsFile(project, options.PropValues)
+		if err != nil {
+			return err
+		}
+		propFiles = append(propFiles, propsFile)
+	}
+
+	env, err := appEnv(options.Start, propFiles...)
+	if err != nil {
+		return err
+	}
+
+	if options.EngineProfile != "" {
+		engineFile := filepath.Join(project.Dir(), "engine."+options.EngineProfile+".json")
+		if _, err := os.Stat(engineFile); err != nil {
+			profiles := engineProfiles(project)
+			if len(profiles) == 0 {
+				return fmt.Errorf("engine profile '%s' not found, no engine.<profile>.json in the project directory", options.EngineProfile)
+			}
+			return fmt.Errorf("engine profile '%s' not found, available: %s", options.EngineProfile, strings.Join(profiles, ", "))
+		}
+		env = append(env, envKeyEngineConfigPath+"="+engineFile)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	// the app descriptor is read from the working directory if it isn't embedded
+	cmd := exec.Command(exe, options.Args...)
+	cmd.Dir = project.Dir()
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	// the interruption is forwarded to the application, which is given time to stop
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		r.Close()
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer r.Close()
+
+		printer := &logPrinter{raw: !options.Pretty, show: true}
+		// the warnings of the engine are reported once it started rather than lost in its output
+		var warnings engineWarnings
+		started := false
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			printer.print(line)
+			if !started {
+				warnings.add(line)
+				if strings.Contains(line, smokeReadyMessage) {
+					started = true
+					warnings.report(os.Stderr)
+				}
+			}
+		}
+		if !started {
+			warnings.report(os.Stderr)
+		}
+	}()
+
+	exited := make(chan error, 1)
+	go func() {
+		<-done
+		exited <- cmd.Wait()
+	}()
+
+	select {
+	case err = <-exited:
+	case <-interrupted:
+		stopRunApp(cmd, exited)
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("application '%s' exited: %v", project.Name(), err)
+	}
+
+	return nil
+}
+
+// stopRunApp stops the application, it is killed if it doesn't stop in time
+func stopRunApp(cmd *exec.Cmd, exited chan error) {
+
+	// interrupting a process isn't supported on windows
+	if runtime.GOOS == "windows" {
+		_ = cmd.Process.Kill()
+	} else {
+		_ = cmd.Process.Signal(os.Interrupt)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(runStopTimeout):
+		fmt.Fprintf(os.Stderr, "Application didn't stop within %s, killing it\n", runStopTimeout)
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// writeRunPropsFile writes the app property values given as name=value to a JSON property file of the project, the
+// values are converted to the type of their property
+func writeRunPropsFile(project common.AppProject, values []string) (string, error) {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return "", err
+	}
+
+	props, err := parsePropValues(appObj, values)
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := json.MarshalIndent(props, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Join(project.Dir(), dirProjectFlogo), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(project.Dir(), dirProjectFlogo, fileRunProps)
+
+	return file, util.WriteFileAtomic(file, buf, 0644)
+}
+
+// parsePropValues parses the app property values given as name=value, converting them to the type of their property
+func parsePropValues(appObj map[string]interface{}, values []string) (map[string]interface{}, error) {
+
+	types := make(map[string]string)
+	for _, prop := range getAppProperties(appObj) {
+		types[prop.Name] = prop.Type
+	}
+
+	props := make(map[string]interface{})
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid property value '%s', expected name=value", value)
+		}
+
+		propType, declared := types[parts[0]]
+		if !declared {
+			fmt.Fprintf(os.Stderr, "Warning: '%s' isn't a property of the application\n", parts[0])
+		}
+
+		coerced, err := coercePropertyValue(propType, parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of property '%s': %v", parts[0], err)
+		}
+		props[parts[0]] = coerced
+	}
+
+	return props, nil
+}
+
+// engineProfiles gets the engine profiles of the project, the <profile> of its engine.<profile>.json files
+func engineProfiles(project common.AppProject) []string {
+
+	files, _ := filepath.Glob(filepath.Join(project.Dir(), "engine.*.json"))
+
+	var profiles []string
+	for _, file := range files {
+		profiles = append(profiles, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "engine."), ".json"))
+	}
+	sort.Strings(profiles)
+
+	return profiles
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePropValues(t *testing.T) {
+
+	appObj := map[string]interface{}{"properties": []interface{}{
+		map[string]interface{}{"name": "port", "type": "int", "value": 8080},
+		map[string]interface{}{"name": "db.url", "type": "string", "value": "postgres://localhost"},
+		map[string]interface{}{"name": "debug", "type": "boolean", "value": false},
+	}}
+
+	props, err := parsePropValues(appObj, []string{"port=9090", "db.url=postgres://db:5432/orders?ssl=on", "debug=true"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"port": float64(9090), "db.url": "postgres://db:5432/orders?ssl=on", "debug": true}, props)
+
+	_, err = parsePropValues(appObj, []string{"port=http"})
+	assert.NotNil(t, err)
+
+	_, err = parsePropValues(appObj, []string{"port"})
+	assert.NotNil(t, err)
+
+	_, err = parsePropValues(appObj, []string{"=9090"})
+	assert.NotNil(t, err)
+}
+
+func TestAppEnv(t *testing.T) {
+
+	env, err := appEnv(StartOptions{Env: []string{"A=1"}, LogLevel: "debug"}, "/tmp/run-props.json")
+	assert.Nil(t, err)
+	assert.Contains(t, env, "A=1")
+	assert.Contains(t, env, envKeyLogLevel+"=DEBUG")
+	assert.Contains(t, env, envKeyAppPropsJson+"=/tmp/run-props.json")
+
+	_, err = appEnv(StartOptions{Env: []string{"A"}})
+	assert.NotNil(t, err)
+
+	_, err = appEnv(StartOptions{Props: []string{"missing-props.json"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,726 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	ContribActivity = "activity"
+	ContribTrigger  = "trigger"
+	ContribAction   = "action"
+	ContribFunction = "function"
+)
+
+var contribPackageInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ContribOptions are the options used to scaffold a contribution
+type ContribOptions struct {
+	Type        string // activity, trigger, action or function
+	Name        string
+	Module      string // the Go module of the contribution, its name by default
+	CoreVersion string // the version of github.com/project-flogo/core, the latest by default
+}
+
+// the templates of the files of each type of contribution, by file name
+var contribTemplates = map[string]map[string]string{
+	ContribActivity: {
+		"descriptor.json":  tplActivityDescriptor,
+		"metadata.go":      tplActivityMetadata,
+		"activity.go":      tplActivity,
+		"activity_test.go": tplActivityTest,
+	},
+	ContribTrigger: {
+		"descriptor.json": tplTriggerDescriptor,
+		"metadata.go":     tplTriggerMetadata,
+		"trigger.go":      tplTrigger,
+		"trigger_test.go": tplTriggerTest,
+	},
+	ContribAction: {
+		"descriptor.json": tplActionDescriptor,
+		"metadata.go":     tplActionMetadata,
+		"action.go":       tplAction,
+		"action_test.go":  tplActionTest,
+	},
+	ContribFunction: {
+		"descriptor.json":  tplFunctionDescriptor,
+		"function.go":      tplFunction,
+		"function_test.go": tplFunctionTest,
+	},
+}
+
+// CreateContrib scaffolds a contribution project in a new directory named after the contribution: its descriptor,
+// its metadata structs, a skeleton of its implementation and a test which runs as is
+func CreateContrib(ctx context.Context, basePath string, options ContribOptions) (string, error) {
+
+	templates, exists := contribTemplates[options.Type]
+	if !exists {
+		return "", fmt.Errorf("invalid contribution type '%s', expected %s, %s, %s or %s", options.Type, ContribActivity, ContribTrigger, ContribAction, ContribFunction)
+	}
+
+	pkg := contribPackageName(options.Name)
+	if pkg == "" {
+		return "", fmt.Errorf("invalid contribution name '%s'", options.Name)
+	}
+	if options.Module == "" {
+		options.Module = options.Name
+	}
+
+	dir := filepath.Join(basePath, options.Name)
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("cannot create contribution: directory '%s' already exists", dir)
+	}
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	data := &struct {
+		Name    string
+		Package string
+		Module  string
+	}{
+		Name:    options.Name,
+		Package: pkg,
+		Module:  options.Module,
+	}
+
+	for name, tpl := range templates {
+		var buf bytes.Buffer
+		RenderTemplate(&buf, tpl, data)
+		err = util.WriteFileAtomic(filepath.Join(dir, name), buf.Bytes(), 0644)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var buf bytes.Buffer
+	RenderTemplate(&buf, tplContribGoMod, data)
+	err = util.WriteFileAtomic(filepath.Join(dir, "go.mod"), buf.Bytes(), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	err = resolveContribDependencies(ctx, dir, defaultCoreVersion(options.CoreVersion))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: unable to resolve the dependencies of the %s, run 'go mod tidy' in %s: %v\n", options.Type, dir, err)
+	}
+
+	fmt.Printf("Created %s '%s' in %s, run 'go test ./...' in it to test it\n", options.Type, options.Name, dir)
+
+	return dir, nil
+}
+
+// contribPackageName gets the Go package name of the contribution, ex. mylog for my-log
+func contribPackageName(name string) string {
+	return strings.TrimLeft(contribPackageInvalidChars.ReplaceAllString(strings.ToLower(name), ""), "0123456789")
+}
+
+// resolveContribDependencies requires the version of the core library, then the other dependencies of the
+// contribution
+func resolveContribDependencies(ctx context.Context, dir, coreVersion string) error {
+
+	dm := util.NewDepManager(dir)
+
+	if coreVersion != "" {
+		err := dm.Get(ctx, flogoCoreRepo+"@"+coreVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	return dm.Tidy(ctx)
+}
+
+var tplContribGoMod = `module {{.Module}}
+`
+
+var tplActivityDescriptor = `{
+  "name": "{{.Name}}",
+  "type": "flogo:activity",
+  "version": "0.0.1",
+  "title": "{{.Name}}",
+  "description": "{{.Name}} activity",
+  "settings": [
+    {
+      "name": "aSetting",
+      "type": "string",
+      "required": true
+    }
+  ],
+  "input": [
+    {
+      "name": "anInput",
+      "type": "string",
+      "required": true
+    }
+  ],
+  "output": [
+    {
+      "name": "anOutput",
+      "type": "string"
+    }
+  ]
+}
+`
+
+var tplActivityMetadata = `package {{.Package}}
+
+import "github.com/project-flogo/core/data/coerce"
+
+type Settings struct {
+	ASetting string ` + "`md:\"aSetting,required\"`" + `
+}
+
+type Input struct {
+	AnInput string ` + "`md:\"anInput,required\"`" + `
+}
+
+func (r *Input) FromMap(values map[string]interface{}) error {
+	strVal, _ := coerce.ToString(values["anInput"])
+	r.AnInput = strVal
+	return nil
+}
+
+func (r *Input) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"anInput": r.AnInput,
+	}
+}
+
+type Output struct {
+	AnOutput string ` + "`md:\"anOutput\"`" + `
+}
+
+func (o *Output) FromMap(values map[string]interface{}) error {
+	strVal, _ := coerce.ToString(values["anOutput"])
+	o.AnOutput = strVal
+	return nil
+}
+
+func (o *Output) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"anOutput": o.AnOutput,
+	}
+}
+`
+
+var tplActivity = `package {{.Package}}
+
+import (
+	"github.com/project-flogo/core/activity"
+	"github.com/project-flogo/core/data/metadata"
+)
+
+func init() {
+	_ = activity.Register(&Activity{}, New)
+}
+
+var activityMd = activity.ToMetadata(&Settings{}, &Input{}, &Output{})
+
+// New creates an instance of the activity for its settings
+func New(ctx activity.InitContext) (activity.Activity, error) {
+
+	s := &Settings{}
+	err := metadata.MapToStruct(ctx.Settings(), s, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Logger().Debugf("Setting: %s", s.ASetting)
+
+	return &Activity{settings: s}, nil
+}
+
+// Activity is the {{.Name}} activity
+type Activity struct {
+	settings *Settings
+}
+
+// Metadata returns the metadata of the activity
+func (a *Activity) Metadata() *activity.Metadata {
+	return activityMd
+}
+
+// Eval implements the logic of the activity
+func (a *Activity) Eval(ctx activity.Context) (done bool, err error) {
+
+	input := &Input{}
+	err = ctx.GetInputObject(input)
+	if err != nil {
+		return true, err
+	}
+
+	ctx.Logger().Debugf("Input: %s", input.AnInput)
+
+	output := &Output{AnOutput: input.AnInput}
+	err = ctx.SetOutputObject(output)
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+`
+
+var tplActivityTest = `package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/project-flogo/core/activity"
+	"github.com/project-flogo/core/support/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+
+	ref := activity.GetRef(&Activity{})
+	act := activity.Get(ref)
+
+	assert.NotNil(t, act)
+}
+
+func TestEval(t *testing.T) {
+
+	settings := &Settings{ASetting: "test"}
+	iCtx := test.NewActivityInitContext(settings, nil)
+	act, err := New(iCtx)
+	assert.Nil(t, err)
+
+	tc := test.NewActivityContext(act.Metadata())
+	input := &Input{AnInput: "test"}
+	err = tc.SetInputObject(input)
+	assert.Nil(t, err)
+
+	done, err := act.Eval(tc)
+	assert.True(t, done)
+	assert.Nil(t, err)
+
+	output := &Output{}
+	err = tc.GetOutputObject(output)
+	assert.Nil(t, err)
+	assert.Equal(t, "test", output.AnOutput)
+}
+`
+
+var tplTriggerDescriptor = `{
+  "name": "{{.Name}}",
+  "type": "flogo:trigger",
+  "version": "0.0.1",
+  "title": "{{.Name}}",
+  "description": "{{.Name}} trigger",
+  "settings": [
+    {
+      "name": "aSetting",
+      "type": "string"
+    }
+  ],
+  "handler": {
+    "settings": [
+      {
+        "name": "aSetting",
+        "type": "string",
+        "required": true
+      }
+    ]
+  },
+  "output": [
+    {
+      "name": "anOutput",
+      "type": "string"
+    }
+  ],
+  "reply": [
+    {
+      "name": "aReply",
+      "type": "any"
+    }
+  ]
+}
+`
+
+var tplTriggerMetadata = `package {{.Package}}
+
+import "github.com/project-flogo/core/data/coerce"
+
+type Settings struct {
+	ASetting string ` + "`md:\"aSetting\"`" + `
+}
+
+type HandlerSettings struct {
+	ASetting string ` + "`md:\"aSetting,required\"`" + `
+}
+
+type Output struct {
+	AnOutput string ` + "`md:\"anOutput\"`" + `
+}
+
+func (o *Output) FromMap(values map[string]interface{}) error {
+	strVal, _ := coerce.ToString(values["anOutput"])
+	o.AnOutput = strVal
+	return nil
+}
+
+func (o *Output) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"anOutput": o.AnOutput,
+	}
+}
+
+type Reply struct {
+	AReply interface{} ` + "`md:\"aReply\"`" + `
+}
+
+func (r *Reply) FromMap(values map[string]interface{}) error {
+	r.AReply = values["aReply"]
+	return nil
+}
+
+func (r *Reply) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"aReply": r.AReply,
+	}
+}
+`
+
+var tplTrigger = `package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/project-flogo/core/data/metadata"
+	"github.com/project-flogo/core/support/log"
+	"github.com/project-flogo/core/trigger"
+)
+
+var triggerMd = trigger.NewMetadata(&Settings{}, &HandlerSettings{}, &Output{}, &Reply{})
+
+func init() {
+	_ = trigger.Register(&Trigger{}, &Factory{})
+}
+
+// Factory creates the instances of the trigger
+type Factory struct {
+}
+
+// New creates an instance of the trigger for its configuration
+func (*Factory) New(config *trigger.Config) (trigger.Trigger, error) {
+
+	s := &Settings{}
+	err := metadata.MapToStruct(config.Settings, s, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Trigger{id: config.Id, settings: s}, nil
+}
+
+// Metadata returns the metadata of the trigger
+func (f *Factory) Metadata() *trigger.Metadata {
+	return triggerMd
+}
+
+// Trigger is the {{.Name}} trigger
+type Trigger struct {
+	id       string
+	settings *Settings
+	handlers []trigger.Handler
+	logger   log.Logger
+}
+
+// Initialize initializes the trigger and its handlers
+func (t *Trigger) Initialize(ctx trigger.InitContext) error {
+
+	t.logger = ctx.Logger()
+
+	for _, handler := range ctx.GetHandlers() {
+		s := &HandlerSettings{}
+		err := metadata.MapToStruct(handler.Settings(), s, true)
+		if err != nil {
+			return err
+		}
+		t.handlers = append(t.handlers, handler)
+	}
+
+	return nil
+}
+
+// Start starts the trigger, ex. listens for the events which fire its handlers
+func (t *Trigger) Start() error {
+	return nil
+}
+
+// Stop stops the trigger
+func (t *Trigger) Stop() error {
+	return nil
+}
+
+// fire runs the actions of the handlers of the trigger for an event
+func (t *Trigger) fire(data string) {
+
+	for _, handler := range t.handlers {
+		results, err := handler.Handle(context.Background(), &Output{AnOutput: data})
+		if err != nil {
+			t.logger.Errorf("Error running handler '%s': %v", handler.Name(), err)
+			continue
+		}
+
+		reply := &Reply{}
+		err = reply.FromMap(results)
+		if err != nil {
+			t.logger.Errorf("Error reading the reply of handler '%s': %v", handler.Name(), err)
+			continue
+		}
+		t.logger.Debugf("Reply of handler '%s': %v", handler.Name(), reply.AReply)
+	}
+}
+`
+
+var tplTriggerTest = `package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/project-flogo/core/support"
+	"github.com/project-flogo/core/trigger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+
+	ref := support.GetRef(&Trigger{})
+	f := trigger.GetFactory(ref)
+
+	assert.NotNil(t, f)
+}
+
+func TestNew(t *testing.T) {
+
+	f := &Factory{}
+	trg, err := f.New(&trigger.Config{Id: "test", Settings: map[string]interface{}{"aSetting": "test"}})
+	assert.Nil(t, err)
+	assert.NotNil(t, trg)
+
+	assert.Nil(t, trg.Start())
+	assert.Nil(t, trg.Stop())
+}
+`
+
+var tplActionDescriptor = `{
+  "name": "{{.Name}}",
+  "type": "flogo:action",
+  "version": "0.0.1",
+  "title": "{{.Name}}",
+  "description": "{{.Name}} action",
+  "settings": [
+    {
+      "name": "aSetting",
+      "type": "string"
+    }
+  ],
+  "input": [
+    {
+      "name": "anInput",
+      "type": "string"
+    }
+  ],
+  "output": [
+    {
+      "name": "anOutput",
+      "type": "string"
+    }
+  ]
+}
+`
+
+var tplActionMetadata = `package {{.Package}}
+
+type Settings struct {
+	ASetting string ` + "`md:\"aSetting\"`" + `
+}
+
+type Input struct {
+	AnInput string ` + "`md:\"anInput\"`" + `
+}
+
+type Output struct {
+	AnOutput string ` + "`md:\"anOutput\"`" + `
+}
+`
+
+var tplAction = `package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/project-flogo/core/action"
+	"github.com/project-flogo/core/data/coerce"
+	"github.com/project-flogo/core/data/metadata"
+)
+
+var actionMd = action.ToMetadata(&Settings{}, &Input{}, &Output{})
+
+func init() {
+	_ = action.Register(&Action{}, &Factory{})
+}
+
+// Factory creates the instances of the action
+type Factory struct {
+}
+
+// Initialize initializes the factory, ex. registers the resource loaders of the action
+func (f *Factory) Initialize(ctx action.InitContext) error {
+	return nil
+}
+
+// New creates an instance of the action for its configuration
+func (f *Factory) New(config *action.Config) (action.Action, error) {
+
+	s := &Settings{}
+	err := metadata.MapToStruct(config.Settings, s, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Action{settings: s}, nil
+}
+
+// Action is the {{.Name}} action
+type Action struct {
+	settings *Settings
+}
+
+// Metadata returns the metadata of the action
+func (a *Action) Metadata() *action.Metadata {
+	return actionMd
+}
+
+// IOMetadata returns the input and output of the action
+func (a *Action) IOMetadata() *metadata.IOMetadata {
+	return actionMd.IOMetadata
+}
+
+// Run runs the action synchronously
+func (a *Action) Run(ctx context.Context, inputs map[string]interface{}) (map[string]interface{}, error) {
+
+	anInput, err := coerce.ToString(inputs["anInput"])
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"anOutput": anInput}, nil
+}
+`
+
+var tplActionTest = `package {{.Package}}
+
+import (
+	"context"
+	"testing"
+
+	"github.com/project-flogo/core/action"
+	"github.com/project-flogo/core/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+
+	ref := support.GetRef(&Action{})
+	f := action.GetFactory(ref)
+
+	assert.NotNil(t, f)
+}
+
+func TestRun(t *testing.T) {
+
+	f := &Factory{}
+	act, err := f.New(&action.Config{Settings: map[string]interface{}{"aSetting": "test"}})
+	assert.Nil(t, err)
+
+	results, err := act.(action.SyncAction).Run(context.Background(), map[string]interface{}{"anInput": "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test", results["anOutput"])
+}
+`
+
+var tplFunctionDescriptor = `{
+  "name": "{{.Package}}",
+  "type": "flogo:function",
+  "version": "0.0.1",
+  "title": "{{.Name}}",
+  "description": "{{.Name}} functions",
+  "functions": [
+    {
+      "name": "echo",
+      "description": "returns its argument",
+      "args": [
+        {
+          "name": "str",
+          "type": "string"
+        }
+      ],
+      "return": {
+        "type": "string"
+      }
+    }
+  ]
+}
+`
+
+var tplFunction = `package {{.Package}}
+
+import (
+	"github.com/project-flogo/core/data"
+	"github.com/project-flogo/core/data/coerce"
+	"github.com/project-flogo/core/data/expression/function"
+)
+
+func init() {
+	_ = function.Register(&fnEcho{})
+}
+
+// fnEcho is the {{.Package}}.echo function
+type fnEcho struct {
+}
+
+// Name returns the name of the function
+func (fnEcho) Name() string {
+	return "echo"
+}
+
+// Sig returns the types of the arguments of the function
+func (fnEcho) Sig() (paramTypes []data.Type, isVariadic bool) {
+	return []data.Type{data.TypeString}, false
+}
+
+// Eval evaluates the function
+func (fnEcho) Eval(params ...interface{}) (interface{}, error) {
+	return coerce.ToString(params[0])
+}
+`
+
+var tplFunctionTest = `package {{.Package}}
+
+import (
+	"testing"
+
+	"github.com/project-flogo/core/data/expression/function"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcho(t *testing.T) {
+
+	result, err := function.Eval(&fnEcho{}, "test")
+	assert.Nil(t, err)
+	assert.Equal(t, "test", result)
+}
+`
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContribPackageName(t *testing.T) {
+	t.Log("Testing package name of a contribution")
+
+	assert.Equal(t, "mylog", contribPackageName("my-log"))
+	assert.Equal(t, "mylog", contribPackageName("MyLog"))
+	assert.Equal(t, "log", contribPackageName("2log"))
+	assert.Equal(t, "", contribPackageName("--"))
+}
+
+func TestCreateContrib(t *testing.T) {
+	t.Log("Testing scaffolding of an activity")
+
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	// the resolution of the dependencies fails without the module proxy, which is only reported as a warning
+	defer os.Setenv("GOPROXY", os.Getenv("GOPROXY"))
+	os.Setenv("GOPROXY", "off")
+
+	dir, err := CreateContrib(context.Background(), tempDir, ContribOptions{Type: ContribActivity, Name: "my-log", Module: "github.com/myorg/activity/mylog"})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(tempDir, "my-log"), dir)
+
+	for _, name := range []string{"descriptor.json", "metadata.go", "activity.go", "activity_test.go", "go.mod"} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "activity.go"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), "package mylog")
+
+	buf, err = ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), "module github.com/myorg/activity/mylog")
+
+	_, err = CreateContrib(context.Background(), tempDir, ContribOptions{Type: ContribActivity, Name: "my-log"})
+	assert.NotNil(t, err)
+
+	_, err = CreateContrib(context.Background(), tempDir, ContribOptions{Type: "handler", Name: "other"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// coreAppSchema is the JSON Schema of the core app model, extended with the sections managed by the cli
+const coreAppSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["name", "type", "version"],
+  "properties": {
+    "name": {"type": "string"},
+    "type": {"type": "string"},
+    "version": {"type": "string"},
+    "description": {"type": "string"},
+    "appModel": {"type": "string"},
+    "imports": {"type": "array", "items": {"type": "string"}},
+    "channels": {"type": "array", "items": {"type": "string"}},
+    "properties": {"type": "array", "items": {"$ref": "#/definitions/data.Attribute"}},
+    "schemas": {"type": "object"},
+    "triggers": {"type": "array", "items": {"$ref": "#/definitions/trigger.Config"}},
+    "disabledTriggers": {"type": "array", "items": {"$ref": "#/definitions/trigger.Config"}},
+    "resources": {"type": "array", "items": {"$ref": "#/definitions/resource.Config"}},
+    "actions": {"type": "array", "items": {"$ref": "#/definitions/action.Config"}},
+    "connections": {"type": "object", "additionalProperties": {"$ref": "#/definitions/connection.Config"}},
+    "importConstraints": {"type": "object", "additionalProperties": {"type": "string"}},
+    "importReplaces": {"type": "object", "additionalProperties": {"type": "string"}},
+    "importGroups": {"type": "array", "items": {"$ref": "#/definitions/importGroup"}}
+  },
+  "definitions": {
+    "importGroup": {
+      "type": "object",
+      "required": ["prefixes"],
+      "properties": {
+        "name": {"type": "string"},
+        "prefixes": {"type": "array", "items": {"type": "string"}}
+      },
+      "additionalProperties": false
+    },
+    "data.Attribute": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {
+        "name": {"type": "string"},
+        "type": {"type": "string"},
+        "value": {}
+      }
+    },
+    "action.Config": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "ref": {"type": "string"},
+        "type": {"type": "string"},
+        "settings": {"type": "object"},
+        "data": {"type": "object"}
+      },
+      "additionalProperties": false
+    },
+    "resource.Config": {
+      "type": "object",
+      "required": ["id", "data"],
+      "properties": {
+        "id": {"type": "string"},
+        "data": {
+          "type": "object",
+          "properties": {
+            "tasks": {"$ref": "#/definitions/flow.Tasks"},
+            "errorHandler": {"type": "object", "properties": {"tasks": {"$ref": "#/definitions/flow.Tasks"}}}
+          }
+        },
+        "variants": {"type": "object", "additionalProperties": {"type": "string"}}
+      },
+      "additionalProperties": false
+    },
+    "flow.Tasks": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "activity": {"$ref": "#/definitions/activity.Config"}
+        }
+      }
+    },
+    "activity.Config": {
+      "type": "object",
+      "required": ["ref"],
+      "properties": {
+        "ref": {"type": "string"},
+        "settings": {"type": "object"},
+        "input": {"type": "object"},
+        "output": {"type": "object"},
+        "schemas": {"type": "object"}
+      }
+    },
+    "connection.Config": {
+      "type": "object",
+      "required": ["ref"],
+      "properties": {
+        "ref": {"type": "string"},
+        "settings": {"type": "object"}
+      }
+    },
+    "trigger.Config": {
+      "type": "object",
+      "required": ["id", "handlers"],
+      "properties": {
+        "id": {"type": "string"},
+        "ref": {"type": "string"},
+        "type": {"type": "string"},
+        "settings": {"type": "object"},
+        "handlers": {"type": "array", "items": {"$ref": "#/definitions/trigger.HandlerConfig"}},
+        "disabledHandlers": {"type": "array", "items": {"$ref": "#/definitions/trigger.HandlerConfig"}}
+      },
+      "additionalProperties": false
+    },
+    "trigger.HandlerConfig": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "settings": {"type": "object"},
+        "action": {"$ref": "#/definitions/trigger.ActionConfig"},
+        "actions": {"type": "array", "items": {"$ref": "#/definitions/trigger.ActionConfig"}}
+      },
+      "oneOf": [
+        {"required": ["action"]},
+        {"required": ["actions"]}
+      ]
+    },
+    "trigger.ActionConfig": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "ref": {"type": "string"},
+        "type": {"type": "string"},
+        "if": {"type": "string"},
+        "settings": {"type": "object"},
+        "input": {"type": "object"},
+        "output": {"type": "object"},
+        "data": {"type": "object"}
+      },
+      "additionalProperties": false
+    }
+  }
+}`
+
+// GenerateDescriptorSchema generates a JSON Schema for the project's app descriptor, composed of the
+// core app schema and the settings of the project's installed contributions
+func GenerateDescriptorSchema(project common.AppProject) ([]byte, error) {
+
+	contribs, err := newContribResolver(project)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := composeDescriptorSchema(contribs)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func composeDescriptorSchema(contribs *contribResolver) (map[string]interface{}, error) {
+
+	var schema map[string]interface{}
+	err := json.Unmarshal([]byte(coreAppSchema), &schema)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+
+	// a contribution can be referenced by its import path or any of its aliases
+	refs := make(map[*util.FlogoContribDescriptor][]string)
+	for path, desc := range contribs.byPath {
+		refs[desc] = append(refs[desc], path)
+	}
+	for _, aliases := range contribs.byAlias {
+		for alias, desc := range aliases {
+			if _, installed := refs[desc]; installed {
+				refs[desc] = append(refs[desc], "#"+alias)
+			}
+		}
+	}
+
+	var descs []*util.FlogoContribDescriptor
+	for desc := range refs {
+		sort.Strings(refs[desc])
+		descs = append(descs, desc)
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return refs[descs[i]][0] < refs[descs[j]][0]
+	})
+
+	for _, desc := range descs {
+		switch desc.GetContribType() {
+		case "trigger":
+			then := map[string]interface{}{"settings": settingsSchema(desc.Settings)}
+			if desc.Handler != nil {
+				then["handlers"] = map[string]interface{}{"items": map[string]interface{}{
+					"properties": map[string]interface{}{"settings": settingsSchema(desc.Handler.Settings)},
+				}}
+			}
+			addContribSchema(definitions["trigger.Config"], refs[desc], then)
+		case "activity":
+			addContribSchema(definitions["activity.Config"], refs[desc], map[string]interface{}{
+				"settings": settingsSchema(desc.Settings),
+				"input":    settingsSchema(desc.Input),
+			})
+		case "action":
+			then := map[string]interface{}{"settings": settingsSchema(desc.Settings)}
+			addContribSchema(definitions["action.Config"], refs[desc], then)
+			addContribSchema(definitions["trigger.ActionConfig"], refs[desc], then)
+		case "connection":
+			addContribSchema(definitions["connection.Config"], refs[desc], map[string]interface{}{
+				"settings": settingsSchema(desc.Settings),
+			})
+		}
+	}
+
+	return schema, nil
+}
+
+// addContribSchema adds the refs of a contribution to the allowed refs of the definition, along with
+// the schema of the properties of configurations that use one of them
+func addContribSchema(definition interface{}, refs []string, properties map[string]interface{}) {
+
+	defMap := definition.(map[string]interface{})
+	defProps := defMap["properties"].(map[string]interface{})
+
+	refSchema := defProps["ref"].(map[string]interface{})
+	enum, _ := refSchema["enum"].([]interface{})
+	for _, ref := range refs {
+		enum = append(enum, ref)
+	}
+	refSchema["enum"] = enum
+
+	var refValues []interface{}
+	for _, ref := range refs {
+		refValues = append(refValues, ref)
+	}
+
+	allOf, _ := defMap["allOf"].([]interface{})
+	defMap["allOf"] = append(allOf, map[string]interface{}{
+		"if": map[string]interface{}{
+			"required":   []string{"ref"},
+			"properties": map[string]interface{}{"ref": map[string]interface{}{"enum": refValues}},
+		},
+		"then": map[string]interface{}{"properties": properties},
+	})
+}
+
+// settingsSchema gets the schema of an object containing the specified settings
+func settingsSchema(settings []*util.FlogoSettingDescriptor) map[string]interface{} {
+
+	properties := make(map[string]interface{})
+	var required []string
+
+	for _, setting := range settings {
+		properties[setting.Name] = settingSchema(setting)
+		if setting.Required && setting.Value == nil {
+			required = append(required, setting.Name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// settingSchema gets the schema of a setting's value, strings are always allowed since the value can be an expression
+func settingSchema(setting *util.FlogoSettingDescriptor) map[string]interface{} {
+
+	schema := make(map[string]interface{})
+
+	switch strings.ToLower(setting.Type) {
+	case "string":
+		schema["type"] = "string"
+	case "int", "integer", "long":
+		schema["type"] = []string{"integer", "string"}
+	case "number", "float", "double":
+		schema["type"] = []string{"number", "string"}
+	case "bool", "boolean":
+		schema["type"] = []string{"boolean", "string"}
+	case "object", "params", "map", settingTypeConnection:
+		schema["type"] = []string{"object", "string"}
+	case "array":
+		schema["type"] = []string{"array", "string"}
+	}
+
+	if setting.Value != nil {
+		schema["default"] = setting.Value
+	}
+
+	return schema
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeDescriptorSchema(t *testing.T) {
+	t.Log("Testing composition of the app descriptor schema")
+
+	rest := &util.FlogoContribDescriptor{Name: "rest", Type: "flogo:trigger",
+		Settings: []*util.FlogoSettingDescriptor{{Name: "port", Type: "int", Required: true}},
+		Handler:  &util.FlogoHandlerDescriptor{Settings: []*util.FlogoSettingDescriptor{{Name: "method", Type: "string", Required: true, Value: "GET"}}}}
+
+	contribs := &contribResolver{
+		byAlias: map[string]map[string]*util.FlogoContribDescriptor{"trigger": {"rest": rest}},
+		byPath:  map[string]*util.FlogoContribDescriptor{"github.com/project-flogo/contrib/trigger/rest": rest},
+	}
+
+	schema, err := composeDescriptorSchema(contribs)
+	assert.Nil(t, err)
+
+	trgDef := schema["definitions"].(map[string]interface{})["trigger.Config"].(map[string]interface{})
+	ref := trgDef["properties"].(map[string]interface{})["ref"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"#rest", "github.com/project-flogo/contrib/trigger/rest"}, ref["enum"])
+
+	allOf := trgDef["allOf"].([]interface{})
+	assert.Len(t, allOf, 1)
+
+	then := allOf[0].(map[string]interface{})["then"].(map[string]interface{})["properties"].(map[string]interface{})
+	settings := then["settings"].(map[string]interface{})
+	assert.Equal(t, []string{"port"}, settings["required"])
+
+	// handler settings with a default value aren't required
+	handlers := then["handlers"].(map[string]interface{})["items"].(map[string]interface{})["properties"].(map[string]interface{})
+	_, hasRequired := handlers["settings"].(map[string]interface{})["required"]
+	assert.False(t, hasRequired)
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+// descriptorSchemaError reports the values of flogo.json which don't match the app descriptor schema
+type descriptorSchemaError struct {
+	issues []*ValidationIssue
+}
+
+func (e *descriptorSchemaError) Error() string {
+	var lines []string
+	for _, issue := range e.issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return fmt.Sprintf("%s doesn't match the app descriptor schema, run 'flogo schema descriptor' to get it:\n%s", fileFlogoJson, strings.Join(lines, "\n"))
+}
+
+// IsDescriptorSchemaError determines if the error reports that flogo.json doesn't match the app descriptor schema
+func IsDescriptorSchemaError(err error) bool {
+	_, ok := err.(*descriptorSchemaError)
+	return ok
+}
+
+// schemaViolation is a value which doesn't match its schema
+type schemaViolation struct {
+	path    string // path of the value, ex. $.triggers[0].handlers
+	pointer string // JSON pointer of the value, ex. /triggers/0/handlers
+	message string
+}
+
+// CheckDescriptorSchema checks the flogo.json of the project against the core app schema, the commands other than
+// validate report a mismatch as a warning since the engine may well run the app anyway
+func CheckDescriptorSchema(project common.AppProject) error {
+	return checkDescriptorFile(project.Dir())
+}
+
+// checkDescriptorFile checks the flogo.json of the app directory against the core app schema, a *descriptorSchemaError
+// is returned if it doesn't match
+func checkDescriptorFile(appDir string) error {
+
+	buf, err := ioutil.ReadFile(filepath.Join(appDir, fileFlogoJson))
+	if err != nil {
+		return err
+	}
+
+	issues, err := checkDescriptorSchema(string(buf))
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return &descriptorSchemaError{issues: issues}
+	}
+
+	return nil
+}
+
+// checkDescriptorSchema checks the app descriptor against the core app schema, the issues are located by the JSON
+// pointer of the value and its line in the document
+func checkDescriptorSchema(text string) ([]*ValidationIssue, error) {
+
+	var appObj interface{}
+	err := json.Unmarshal([]byte(text), &appObj)
+	if err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line, col := offsetPosition(text, int(syntaxErr.Offset))
+			return nil, fmt.Errorf("unable to parse %s: %v at line %d, column %d", fileFlogoJson, err, line, col)
+		}
+		return nil, fmt.Errorf("unable to parse %s: %v", fileFlogoJson, err)
+	}
+
+	var schema map[string]interface{}
+	err = json.Unmarshal([]byte(coreAppSchema), &schema)
+	if err != nil {
+		return nil, err
+	}
+	definitions, _ := schema["definitions"].(map[string]interface{})
+
+	var violations []*schemaViolation
+	checkSchema(schema, definitions, appObj, "$", "", &violations)
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	offsets := make(map[string]int)
+	_ = scanJSON(text, func(n *jsonNode) {
+		offsets[n.path] = n.start
+	})
+
+	var issues []*ValidationIssue
+	for _, v := range violations {
+		pointer := v.pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		issue := &ValidationIssue{Severity: SeverityError, Path: pointer, Message: v.message}
+		if offset, found := offsets[v.path]; found {
+			issue.Line, _ = offsetPosition(text, offset)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// checkSchema checks the value against the schema, supporting the keywords used by the core app schema
+func checkSchema(schema, definitions map[string]interface{}, value interface{}, path, pointer string, violations *[]*schemaViolation) {
+
+	addViolation := func(format string, args ...interface{}) {
+		*violations = append(*violations, &schemaViolation{path: path, pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		definition, _ := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+		if definition != nil {
+			checkSchema(definition, definitions, value, path, pointer, violations)
+		}
+		return
+	}
+
+	if expected, ok := schema["type"].(string); ok && !matchesSchemaType(expected, value) {
+		addViolation("expected a value of type '%s', got %s", expected, jsonTypeName(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addViolation("value isn't one of the allowed values")
+		}
+	}
+
+	switch t := value.(type) {
+	case map[string]interface{}:
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				if _, exists := t[s]; !exists {
+					addViolation("missing property '%s'", s)
+				}
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		var names []string
+		for name := range t {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propPath, propPointer := jsonMemberPath(path, name), pointer+"/"+escapeJSONPointer(name)
+			if propSchema, ok := properties[name].(map[string]interface{}); ok {
+				checkSchema(propSchema, definitions, t[name], propPath, propPointer, violations)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					*violations = append(*violations, &schemaViolation{path: propPath, pointer: propPointer, message: fmt.Sprintf("unknown property '%s'", name)})
+				}
+			case map[string]interface{}:
+				checkSchema(additional, definitions, t[name], propPath, propPointer, violations)
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range t {
+				checkSchema(items, definitions, item, fmt.Sprintf("%s[%d]", path, i), pointer+"/"+strconv.Itoa(i), violations)
+			}
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			if subSchema, ok := sub.(map[string]interface{}); ok {
+				checkSchema(subSchema, definitions, value, path, pointer, violations)
+			}
+		}
+	}
+
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		alternatives, ok := schema[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		matches := 0
+		var reasons []string
+		for _, sub := range alternatives {
+			subSchema, ok := sub.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var subViolations []*schemaViolation
+			checkSchema(subSchema, definitions, value, path, pointer, &subViolations)
+			if len(subViolations) == 0 {
+				matches++
+			} else {
+				reasons = append(reasons, subViolations[0].message)
+			}
+		}
+		switch {
+		case matches == 0:
+			addViolation("%s", strings.Join(reasons, " or "))
+		case matches > 1 && keyword == "oneOf":
+			addViolation("matches %d of the alternatives of the schema, expected only one", matches)
+		}
+	}
+}
+
+func matchesSchemaType(expected string, value interface{}) bool {
+
+	switch expected {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+
+	return jsonTypeName(value) == expected
+}
+
+// jsonTypeName gets the JSON Schema type of a decoded JSON value
+func jsonTypeName(value interface{}) string {
+
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+// escapeJSONPointer escapes a key as a token of a JSON pointer
+func escapeJSONPointer(key string) string {
+	return strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1)
+}
+
+// offsetPosition gets the line and the column, starting at 1, of the offset in the text
+func offsetPosition(text string, offset int) (int, int) {
+
+	if offset > len(text) {
+		offset = len(text)
+	}
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	col := offset - strings.LastIndex(before, "\n")
+
+	return line, col
+}
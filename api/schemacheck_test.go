@@ -0,0 +1,88 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDescriptorSchema(t *testing.T) {
+	t.Log("Testing checking of the app descriptor against its schema")
+
+	app := `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "appModel": "1.1.0",
+  "imports": ["github.com/project-flogo/contrib/trigger/rest", 5],
+  "triggers": [
+    {
+      "id": "rest",
+      "ref": "#rest",
+      "handlers": [
+        {"name": "get", "settings": {"method": "GET"}}
+      ]
+    },
+    {"id": "timer", "extra/info": true}
+  ],
+  "resources": [],
+  "metadata": {"owner": "me"}
+}`
+
+	issues, err := checkDescriptorSchema(app)
+	assert.Nil(t, err)
+
+	var found []string
+	for _, issue := range issues {
+		assert.Equal(t, SeverityError, issue.Severity)
+		found = append(found, issue.String())
+	}
+	assert.Equal(t, []string{
+		"error: /imports/1 (line 6): expected a value of type 'string', got number",
+		"error: /triggers/0/handlers/0 (line 12): missing property 'action' or missing property 'actions'",
+		"error: /triggers/1 (line 15): missing property 'handlers'",
+		"error: /triggers/1/extra~1info (line 15): unknown property 'extra/info'",
+	}, found)
+
+	issues, err = checkDescriptorSchema(`{"name": "myApp", "type": "flogo:app", "version": "1.0.0", "appModel": "1.1.0"}`)
+	assert.Nil(t, err)
+	assert.Empty(t, issues)
+
+	// the app model isn't required by the core, and the descriptor may have keys of its own
+	issues, err = checkDescriptorSchema(`{"name": "myApp", "type": "flogo:app", "version": "1.0.0", "metadata": {"owner": "me"}}`)
+	assert.Nil(t, err)
+	assert.Empty(t, issues)
+
+	_, err = checkDescriptorSchema("{\n  \"name\": \"myApp\",\n  \"type\" \"flogo:app\"\n}")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "line 3, column")
+	}
+}
+
+func TestCheckDescriptorSchemaProject(t *testing.T) {
+	t.Log("Testing that a project whose descriptor doesn't match the schema is still valid")
+
+	tempDir, err := ioutil.TempDir("", "flogo-schema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"name": "myApp", "type": "flogo:app", "version": "1.0.0", "triggers": [{"id": "timer"}]}`), 0644)
+	assert.Nil(t, err)
+	err = os.MkdirAll(filepath.Join(tempDir, dirSrc), 0755)
+	assert.Nil(t, err)
+	for _, file := range []string{fileImportsGo, "go.mod"} {
+		err = ioutil.WriteFile(filepath.Join(tempDir, dirSrc, file), nil, 0644)
+		assert.Nil(t, err)
+	}
+	project := NewAppProject(tempDir)
+
+	assert.Nil(t, project.Validate())
+
+	err = CheckDescriptorSchema(project)
+	assert.True(t, IsDescriptorSchemaError(err))
+	assert.Contains(t, err.Error(), "run 'flogo schema descriptor' to get it")
+	assert.Contains(t, err.Error(), "missing property 'handlers'")
+}
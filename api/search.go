@@ -0,0 +1,258 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	// EnvSearchIndex is the URL or file of the contribution index, it overrides the index of the CLI configuration
+	EnvSearchIndex = "FLOGO_SEARCH_INDEX"
+
+	// DefaultSearchIndex is the search API of the Flogo hub, the showcase of the contributions
+	DefaultSearchIndex = "https://www.flogo.io/api/v1/contributions"
+
+	searchClientHub   = "hub"
+	searchClientIndex = "index"
+)
+
+func init() {
+	common.RegisterRegistryClient(searchClientHub, &hubRegistryClient{})
+	common.RegisterRegistryClient(searchClientIndex, &indexRegistryClient{})
+}
+
+// SearchOptions are the options of the search of the contributions
+type SearchOptions struct {
+	Index  string // the URL or file of the contribution index, the one of the CLI configuration by default
+	Client string // the registry client querying the index, the one of the CLI configuration, hub for a URL or index for a file by default
+	Type   string // the type of the contributions, ex. activity, all of them if empty
+}
+
+// searchIndexContent is the content of a contribution index, the response of the hub
+type searchIndexContent struct {
+	Contributions []*common.RegistryEntry `json:"contributions"`
+}
+
+// SearchContributions queries the contribution index for the contributions matching the keyword
+func SearchContributions(query string, options SearchOptions) ([]*common.RegistryEntry, error) {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	index := options.Index
+	if index == "" {
+		index = os.Getenv(EnvSearchIndex)
+	}
+	if index == "" {
+		index = orDefault(config.SearchIndex, DefaultSearchIndex)
+	}
+
+	name := options.Client
+	if name == "" {
+		name = config.SearchClient
+	}
+	if name == "" {
+		// a file can only be a static index
+		name = searchClientHub
+		if !util.IsRemote(index) {
+			name = searchClientIndex
+		}
+	}
+	client := common.GetRegistryClient(name)
+	if client == nil {
+		return nil, fmt.Errorf("unknown registry client '%s', available: %s", name, strings.Join(common.RegistryClients(), ", "))
+	}
+
+	entries, err := client.Search(index, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search '%s': %v", index, err)
+	}
+
+	var found []*common.RegistryEntry
+	for _, entry := range entries {
+		if options.Type == "" || strings.EqualFold(entry.Type, options.Type) {
+			found = append(found, entry)
+		}
+	}
+
+	return found, nil
+}
+
+// PrintSearchResults prints the contributions found along with the command installing them, and the deprecations
+// of the registry index
+func PrintSearchResults(w io.Writer, entries []*common.RegistryEntry) {
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, util.T("search.none"))
+		return
+	}
+
+	registry, _ := util.LoadRegistryIndex()
+
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintf(w, "%s", entry.Ref)
+		if entry.Type != "" {
+			fmt.Fprintf(w, " (%s)", entry.Type)
+		}
+		if entry.Version != "" {
+			fmt.Fprintf(w, " %s", entry.Version)
+		}
+		fmt.Fprintln(w)
+
+		if entry.Description != "" {
+			fmt.Fprintf(w, "  %s\n", entry.Description)
+		}
+		for _, warning := range registry.Find(entry.Ref).Warnings("") {
+			fmt.Fprintln(w, "  "+util.T("search.warning", warning))
+		}
+
+		install := entry.Ref
+		if entry.Version != "" {
+			install += "@" + entry.Version
+		}
+		fmt.Fprintf(w, "  flogo install %s\n", install)
+	}
+}
+
+// hubRegistryClient queries the search API of the Flogo hub, or of an index implementing it: GET <index>?q=<query>
+// responds with the contributions found
+type hubRegistryClient struct {
+}
+
+func (c *hubRegistryClient) Search(index string, query string) ([]*common.RegistryEntry, error) {
+
+	if !util.IsRemote(index) {
+		return nil, fmt.Errorf("the hub is queried using a URL, use the index client for a file")
+	}
+
+	sep := "?"
+	if strings.Contains(index, "?") {
+		sep = "&"
+	}
+	content, err := util.LoadRemoteFile(index + sep + "q=" + url.QueryEscape(query))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSearchIndex([]byte(content))
+}
+
+// indexRegistryClient searches a static contribution index, a file or the URL of one, ex. a private index published
+// on a web server
+type indexRegistryClient struct {
+}
+
+func (c *indexRegistryClient) Search(index string, query string) ([]*common.RegistryEntry, error) {
+
+	var content string
+	var err error
+	if util.IsRemote(index) {
+		content, err = util.LoadRemoteFile(index)
+	} else {
+		content, err = util.LoadLocalFile(index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseSearchIndex([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+
+	return matchRegistryEntries(entries, query), nil
+}
+
+func parseSearchIndex(buf []byte) ([]*common.RegistryEntry, error) {
+
+	content := &searchIndexContent{}
+	err := json.Unmarshal(buf, content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse contribution index: %v", err)
+	}
+
+	return content.Contributions, nil
+}
+
+// matchRegistryEntries gets the entries matching all the keywords of the query, the ones whose name or keywords match
+// first, then the ones whose package or description contain them, then the ones whose name is close to them
+func matchRegistryEntries(entries []*common.RegistryEntry, query string) []*common.RegistryEntry {
+
+	rankWord := func(entry *common.RegistryEntry, word string) int {
+		if strings.ToLower(entry.Name) == word {
+			return 0
+		}
+		for _, keyword := range entry.Keywords {
+			if strings.ToLower(keyword) == word {
+				return 1
+			}
+		}
+		if strings.Contains(strings.ToLower(entry.Name), word) {
+			return 1
+		}
+		// the package rather than the import path, whose host and owner would match most of the queries
+		if strings.Contains(strings.ToLower(path.Base(entry.Ref)), word) || strings.Contains(strings.ToLower(entry.Description), word) {
+			return 2
+		}
+		// a misspelled name or package, ex. "timr"
+		for _, name := range []string{entry.Name, path.Base(entry.Ref)} {
+			if name != "" && util.EditDistance(strings.ToLower(name), word) <= maxSearchTypos(word) {
+				return 3
+			}
+		}
+		return -1
+	}
+
+	words := strings.Fields(strings.ToLower(query))
+
+	var found []*common.RegistryEntry
+	ranks := make(map[*common.RegistryEntry]int)
+	for _, entry := range entries {
+		rank := 0
+		for _, word := range words {
+			r := rankWord(entry, word)
+			if r < 0 || r > rank {
+				rank = r
+			}
+			if r < 0 {
+				break
+			}
+		}
+		if rank >= 0 {
+			ranks[entry] = rank
+			found = append(found, entry)
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if ranks[found[i]] != ranks[found[j]] {
+			return ranks[found[i]] < ranks[found[j]]
+		}
+		return found[i].Ref < found[j].Ref
+	})
+
+	return found
+}
+
+// maxSearchTypos gets the edit distance up to which a name matches a keyword, one typo per four letters
+func maxSearchTypos(word string) int {
+	if len(word) < 4 {
+		return 0
+	}
+	return len(word) / 4
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSearchIndex = `{"contributions": [
+	{"ref": "github.com/project-flogo/contrib/activity/rest", "type": "activity", "name": "rest", "description": "Invokes a REST service", "version": "v0.10.0"},
+	{"ref": "github.com/project-flogo/contrib/trigger/rest", "type": "trigger", "name": "rest", "description": "Simple REST trigger", "version": "v0.10.0"},
+	{"ref": "github.com/project-flogo/contrib/activity/log", "type": "activity", "name": "log", "description": "Logs a message", "keywords": ["print"]},
+	{"ref": "github.com/example/flogo/activity/restlogger", "type": "activity", "name": "restlogger", "description": "Logs the requests"}
+]}`
+
+func TestSearchContributions(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "search")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(util.EnvKeyFlogoHome, os.Getenv(util.EnvKeyFlogoHome))
+	os.Setenv(util.EnvKeyFlogoHome, tempDir)
+
+	indexFile := filepath.Join(tempDir, "index.json")
+	assert.Nil(t, ioutil.WriteFile(indexFile, []byte(testSearchIndex), 0644))
+
+	refs := func(entries []*common.RegistryEntry) []string {
+		var refs []string
+		for _, entry := range entries {
+			refs = append(refs, entry.Ref)
+		}
+		return refs
+	}
+
+	// a file is searched by the index client
+	entries, err := SearchContributions("rest", SearchOptions{Index: indexFile})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/rest", "github.com/project-flogo/contrib/trigger/rest", "github.com/example/flogo/activity/restlogger"}, refs(entries))
+
+	entries, err = SearchContributions("rest", SearchOptions{Index: indexFile, Type: "trigger"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/trigger/rest"}, refs(entries))
+
+	entries, err = SearchContributions("print", SearchOptions{Index: indexFile})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/log"}, refs(entries))
+
+	entries, err = SearchContributions("logs requests", SearchOptions{Index: indexFile})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/example/flogo/activity/restlogger"}, refs(entries))
+
+	// a misspelled name matches
+	entries, err = SearchContributions("resst", SearchOptions{Index: indexFile})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/rest", "github.com/project-flogo/contrib/trigger/rest"}, refs(entries))
+
+	_, err = SearchContributions("rest", SearchOptions{Index: indexFile, Client: "unknown"})
+	assert.NotNil(t, err)
+
+	// the hub searches the contributions
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "log", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte(`{"contributions": [{"ref": "github.com/project-flogo/contrib/activity/log", "type": "activity", "version": "v0.10.0"}]}`))
+	}))
+	defer server.Close()
+
+	entries, err = SearchContributions("log", SearchOptions{Index: server.URL})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/log"}, refs(entries))
+
+	var out bytes.Buffer
+	PrintSearchResults(&out, entries)
+	assert.Contains(t, out.String(), "flogo install github.com/project-flogo/contrib/activity/log@v0.10.0")
+}
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/project-flogo/core/engine/secret"
+)
+
+const (
+	secretPrefix = "SECRET:"
+
+	fileSecretsCheck = "secrets.json"
+)
+
+// same pattern used by the engine to locate secret values in the app json
+var secretValuePattern = regexp.MustCompile(`SECRET:[^\\"]*`)
+
+// the values of env property files aren't quoted, a secret value ends with the line
+var envSecretValuePattern = regexp.MustCompile(`SECRET:[^\s"']*`)
+
+// rotatedFile is the content of a configuration file with its secret values re-encrypted
+type rotatedFile struct {
+	file    string
+	content []byte
+	count   int
+}
+
+// RotateSecrets re-encrypts all the secret values in the project's configuration files using the new key, the app
+// descriptor, engine.json and the property files of the environments and profiles. The values of all the files are
+// re-encrypted before any file is written, so a value which can't be rotated leaves every file as is.
+func RotateSecrets(project common.AppProject, oldKey, newKey string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if oldKey == "" || newKey == "" {
+		return fmt.Errorf("both the old and new key must be specified")
+	}
+
+	macs, err := loadSecretMACs(project)
+	if err != nil {
+		return err
+	}
+
+	var files []*rotatedFile
+	var replaced, encodedValues []string
+
+	for _, cfgFile := range secretConfigFiles(project) {
+
+		buf, err := ioutil.ReadFile(cfgFile)
+		if err != nil {
+			return err
+		}
+
+		pattern := secretValuePattern
+		if filepath.Ext(cfgFile) == "."+PropsFormatEnv {
+			pattern = envSecretValuePattern
+		}
+
+		cfg := string(buf)
+		count := 0
+
+		for _, match := range uniqueMatches(pattern.FindAllString(cfg, -1)) {
+
+			encoded, err := rotateSecretValue(oldKey, newKey, match, macs[match[len(secretPrefix):]])
+			if err != nil {
+				return fmt.Errorf("unable to rotate secret in '%s': %s", relProjectPath(project, cfgFile), err.Error())
+			}
+
+			cfg = strings.Replace(cfg, match, secretPrefix+encoded, -1)
+			replaced = append(replaced, match[len(secretPrefix):])
+			encodedValues = append(encodedValues, encoded)
+			count++
+		}
+
+		if count > 0 {
+			files = append(files, &rotatedFile{file: cfgFile, content: []byte(cfg), count: count})
+		}
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	// the new macs are saved first and the old ones are kept until every file is written, so that the values of
+	// the files are authenticated whichever key they are encrypted with if a write fails
+	for _, encoded := range encodedValues {
+		macs[encoded] = secretMAC(newKey, encoded)
+	}
+	err = saveSecretMACs(project, macs)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.file == filepath.Join(project.Dir(), fileFlogoJson) {
+			err = writeAppDescriptorFile(project, f.content)
+		} else {
+			err = util.WriteFileAtomic(f.file, f.content, 0644)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Rotated %d secret value(s) in %s\n", f.count, relProjectPath(project, f.file))
+	}
+
+	for _, old := range replaced {
+		delete(macs, old)
+	}
+
+	return saveSecretMACs(project, macs)
+}
+
+// secretConfigFiles gets the existing configuration files of the project which may contain secret values
+func secretConfigFiles(project common.AppProject) []string {
+
+	var files []string
+	for _, fileName := range []string{fileFlogoJson, fileEngineJson} {
+		files = append(files, filepath.Join(project.Dir(), fileName))
+	}
+
+	for _, pattern := range []string{
+		filepath.Join(dirProps, "*."+PropsFormatJson),
+		filepath.Join(dirProps, "*."+PropsFormatEnv),
+		filepath.Join(dirProfiles, "*.json"),
+	} {
+		matches, _ := filepath.Glob(filepath.Join(project.Dir(), pattern))
+		files = append(files, matches...)
+	}
+
+	var existing []string
+	for _, file := range files {
+		if util.FileExists(file) {
+			existing = append(existing, file)
+		}
+	}
+
+	return existing
+}
+
+// rotateSecretValue re-encrypts the secret value with the new key, the value is authenticated using its
+// mac if it has one, values encrypted elsewhere don't have one and must decrypt to printable text
+func rotateSecretValue(oldKey, newKey, secretValue, mac string) (string, error) {
+
+	value := secretValue[len(secretPrefix):]
+	if mac != "" && !hmac.Equal([]byte(mac), []byte(secretMAC(oldKey, value))) {
+		return "", fmt.Errorf("value was not encrypted using the old key")
+	}
+
+	oldHandler := &secret.KeyBasedSecretValueHandler{Key: oldKey}
+	newHandler := &secret.KeyBasedSecretValueHandler{Key: newKey}
+
+	plain, err := oldHandler.DecodeValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	// decrypting with the wrong key doesn't fail, it just yields garbage
+	if mac == "" && !isPrintable(plain) {
+		return "", fmt.Errorf("value could not be decrypted using the old key")
+	}
+
+	encoded, err := newHandler.EncodeValue(plain)
+	if err != nil {
+		return "", err
+	}
+
+	verify, err := newHandler.DecodeValue(encoded)
+	if err != nil {
+		return "", err
+	}
+	if verify != plain {
+		return "", fmt.Errorf("verification of re-encrypted value failed")
+	}
+
+	return encoded, nil
+}
+
+func isPrintable(val string) bool {
+	if !utf8.ValidString(val) {
+		return false
+	}
+	for _, r := range val {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// secretMAC authenticates an encrypted value with the key it was encrypted with, the encryption
+// used by the engine isn't authenticated so it can't tell that a key is wrong by itself
+func secretMAC(key, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// loadSecretMACs loads the macs of the encrypted values of the project, keyed by value
+func loadSecretMACs(project common.AppProject) (map[string]string, error) {
+
+	macs := make(map[string]string)
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), dirProjectFlogo, fileSecretsCheck))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return macs, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(buf, &macs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", fileSecretsCheck, err)
+	}
+
+	return macs, nil
+}
+
+func saveSecretMACs(project common.AppProject, macs map[string]string) error {
+
+	buf, err := json.MarshalIndent(macs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Join(project.Dir(), dirProjectFlogo), 0755)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(filepath.Join(project.Dir(), dirProjectFlogo, fileSecretsCheck), buf, 0644)
+}
+
+func uniqueMatches(matches []string) []string {
+
+	seen := make(map[string]struct{}, len(matches))
+	var result []string
+
+	for _, match := range matches {
+		if _, exists := seen[match]; exists {
+			continue
+		}
+		seen[match] = struct{}{}
+		result = append(result, match)
+	}
+
+	return result
+}
+
+var secretKeyPattern = regexp.MustCompile(`(?i)(passw(or)?d|pwd|secret|token|api[_-]?key|access[_-]?key|private[_-]?key|credential)`)
+var connStrCredsPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://[^/:@\s]+:[^/@\s]+@`)
+
+// SecretFinding is a likely plaintext credential found in the app descriptor
+type SecretFinding struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (f *SecretFinding) String() string {
+	return f.Path + ": " + f.Reason
+}
+
+// ScanSecrets scans the app descriptor for values that look like plaintext credentials
+func ScanSecrets(project common.AppProject) ([]*SecretFinding, error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return nil, err
+	}
+
+	var appObj interface{}
+	err = json.Unmarshal(buf, &appObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []*SecretFinding
+	scanForSecrets("$", "", appObj, &findings)
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings, nil
+}
+
+func scanForSecrets(path, key string, item interface{}, findings *[]*SecretFinding) {
+	switch t := item.(type) {
+	case map[string]interface{}:
+		// named values (ex. properties) are flagged based on their name
+		name, _ := t["name"].(string)
+
+		for k, v := range t {
+			vKey := k
+			if k == "value" && name != "" {
+				vKey = name
+			}
+			scanForSecrets(path+"."+k, vKey, v, findings)
+		}
+	case []interface{}:
+		for i, v := range t {
+			scanForSecrets(fmt.Sprintf("%s[%d]", path, i), key, v, findings)
+		}
+	case string:
+		if isResolvedValue(t) {
+			return
+		}
+
+		if connStrCredsPattern.MatchString(t) {
+			*findings = append(*findings, &SecretFinding{Path: path, Reason: "connection string contains credentials"})
+		} else if secretKeyPattern.MatchString(key) {
+			*findings = append(*findings, &SecretFinding{Path: path, Reason: fmt.Sprintf("plaintext value for '%s'", key)})
+		}
+	}
+}
+
+// isResolvedValue determines if the value is empty, encrypted or resolved at runtime
+func isResolvedValue(val string) bool {
+	val = strings.TrimSpace(val)
+	if val == "" || strings.HasPrefix(val, secretPrefix) {
+		return true
+	}
+
+	// expressions, property and env references
+	return strings.HasPrefix(val, "=") || strings.HasPrefix(val, "$")
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+)
+
+const setupTimeout = 10 * time.Second
+
+// the completion scripts which can be installed, with the rc file of the shell sourcing them
+var completionRcFiles = map[string]string{
+	"bash": ".bashrc",
+	"zsh":  ".zshrc",
+}
+
+// SetupOptions are the options of the guided setup of the CLI
+type SetupOptions struct {
+	FirstRun   bool                                  // the setup is offered on the first run of the CLI, and can be declined
+	Completion func(shell string, w io.Writer) error // generates the completion script of a shell
+}
+
+// RunSetup guides the user through the setup of the CLI: the check of the Go module proxy, the version of the core
+// used by the created projects, the installation of the shell completion and the registry index, then saves the CLI
+// configuration
+func RunSetup(options SetupOptions) error {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	if options.FirstRun {
+		fmt.Println(util.T("setup.welcome"))
+		if !util.Confirm(util.T("setup.confirm")) {
+			// the setup isn't offered again once the configuration is saved
+			fmt.Println(util.T("setup.later"))
+			return util.SaveCLIConfig(config)
+		}
+	}
+
+	fmt.Println("\n" + util.T("setup.proxy"))
+	coreVersions := checkModuleProxy()
+
+	fmt.Println("\n" + util.T("setup.coreVersion"))
+	if len(coreVersions) > 0 {
+		fmt.Println("  " + util.T("setup.latestCore", flogoCoreRepo, coreVersions[len(coreVersions)-1]))
+	}
+	version, err := util.Prompt("  "+util.T("setup.coreVersionPrompt"), orDefault(config.CoreVersion, "latest"))
+	if err != nil {
+		return err
+	}
+	if version == "latest" {
+		version = ""
+	}
+	if version != "" && len(coreVersions) > 0 && !isKnownVersion(coreVersions, version) {
+		fmt.Println("  " + util.T("setup.unknownCore", version))
+	}
+	config.CoreVersion = version
+
+	fmt.Println("\n" + util.T("setup.completion"))
+	err = setupCompletion(options.Completion)
+	if err != nil {
+		fmt.Println("  " + util.T("setup.completionFailed", err))
+	}
+
+	fmt.Println("\n" + util.T("setup.registry"))
+	fmt.Println("  " + util.T("setup.registryHelp"))
+	registry, err := util.Prompt("  "+util.T("setup.registryPrompt"), orDefault(config.Registry, "none"))
+	if err != nil {
+		return err
+	}
+	if registry == "none" {
+		registry = ""
+	}
+	if registry != "" && !util.IsRemote(registry) {
+		registry, _ = filepath.Abs(registry)
+	}
+	if registry != "" {
+		if err := checkRegistry(registry); err != nil {
+			fmt.Println("  " + util.T("setup.registryUnavailable", err))
+		}
+	}
+	config.Registry = registry
+
+	err = util.SaveCLIConfig(config)
+	if err != nil {
+		return err
+	}
+
+	home, _ := util.GetFlogoHome()
+	fmt.Println("\n" + util.T("setup.saved", home))
+
+	return nil
+}
+
+// InstallCompletion writes the completion script of the shell in the flogo home and sources it from the rc file of
+// the shell, the script is returned
+func InstallCompletion(shell string, generate func(shell string, w io.Writer) error) (string, error) {
+
+	rcFile, supported := completionRcFiles[shell]
+	if !supported {
+		return "", fmt.Errorf("unsupported shell '%s', expected bash or zsh", shell)
+	}
+
+	home, err := util.GetFlogoHome()
+	if err != nil {
+		return "", err
+	}
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = generate(shell, &buf)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(home, 0755)
+	if err != nil {
+		return "", err
+	}
+	script := filepath.Join(home, "completion."+shell)
+	err = util.WriteFileAtomic(script, buf.Bytes(), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	rc := filepath.Join(userHome, rcFile)
+	content, err := ioutil.ReadFile(rc)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if bytes.Contains(content, []byte(script)) {
+		return script, nil
+	}
+
+	f, err := os.OpenFile(rc, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	_, err = fmt.Fprintf(f, "\n# flogo completion\n[ -f \"%s\" ] && source \"%s\"\n", script, script)
+	if err != nil {
+		f.Close()
+		return "", err
+	}
+
+	return script, f.Close()
+}
+
+func setupCompletion(generate func(shell string, w io.Writer) error) error {
+
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if _, supported := completionRcFiles[shell]; !supported || generate == nil {
+		fmt.Println("  " + util.T("setup.completionShells"))
+		return nil
+	}
+
+	if !util.Confirm("  " + util.T("setup.completionConfirm", shell)) {
+		return nil
+	}
+
+	script, err := InstallCompletion(shell, generate)
+	if err != nil {
+		return err
+	}
+	fmt.Println("  " + util.T("setup.completionInstalled", script))
+
+	return nil
+}
+
+// checkModuleProxy checks that the modules can be downloaded from the Go module proxy, the versions of the core are
+// returned, sorted from the lowest to the highest
+func checkModuleProxy() []string {
+
+	cmd := exec.Command("go", "env", "GOPROXY")
+	cmd.Env = util.GoCmdEnv("")
+	out, err := cmd.Output()
+	if err != nil {
+		fmt.Println("  " + util.T("setup.noGo", err))
+		return nil
+	}
+	goproxy := strings.TrimSpace(string(out))
+
+	for _, proxy := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		if proxy == "direct" || proxy == "off" {
+			continue
+		}
+
+		versions, err := listProxyVersions(proxy, flogoCoreRepo)
+		if err != nil {
+			fmt.Println("  " + util.T("setup.proxyUnreachable", proxy, err))
+			fmt.Println("  " + util.T("setup.proxyHelp"))
+			return nil
+		}
+		fmt.Println("  " + util.T("setup.proxyUsed", proxy))
+		return versions
+	}
+
+	fmt.Println("  " + util.T("setup.proxyDirect", goproxy))
+
+	return nil
+}
+
+func listProxyVersions(proxy, module string) ([]string, error) {
+
+	client := &http.Client{Timeout: setupTimeout}
+	resp, err := client.Get(strings.TrimSuffix(proxy, "/") + "/" + escapeModulePath(module) + "/@v/list")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := strings.Fields(string(body))
+	sort.Slice(versions, func(i, j int) bool {
+		return compareModuleVersions(versions[i], versions[j]) < 0
+	})
+
+	return versions, nil
+}
+
+func checkRegistry(registry string) error {
+
+	if !util.IsRemote(registry) {
+		if !util.FileExists(registry) {
+			return fmt.Errorf("file '%s' not found", registry)
+		}
+		return nil
+	}
+
+	client := &http.Client{Timeout: setupTimeout}
+	resp, err := client.Get(registry)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", resp.Status)
+	}
+
+	return nil
+}
+
+// defaultCoreVersion gets the version of the core used by the created projects when none is specified, the one of
+// the CLI configuration
+func defaultCoreVersion(version string) string {
+
+	if version != "" {
+		return version
+	}
+	if config, err := util.LoadCLIConfig(); err == nil {
+		return config.CoreVersion
+	}
+
+	return ""
+}
+
+func isKnownVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallCompletion(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "setup")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(util.EnvKeyFlogoHome, os.Getenv(util.EnvKeyFlogoHome))
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv(util.EnvKeyFlogoHome, filepath.Join(tempDir, "flogo"))
+	os.Setenv("HOME", tempDir)
+
+	generate := func(shell string, w io.Writer) error {
+		_, err := fmt.Fprintf(w, "# %s completion\n", shell)
+		return err
+	}
+
+	_, err = InstallCompletion("fish", generate)
+	assert.NotNil(t, err)
+
+	for i := 0; i < 2; i++ {
+		script, err := InstallCompletion("bash", generate)
+		assert.Nil(t, err)
+		assert.Equal(t, filepath.Join(tempDir, "flogo", "completion.bash"), script)
+	}
+
+	rc, err := ioutil.ReadFile(filepath.Join(tempDir, ".bashrc"))
+	assert.Nil(t, err)
+	// the script is sourced once
+	assert.Equal(t, 1, strings.Count(string(rc), "source"))
+
+	assert.False(t, util.CLIConfigExists())
+	assert.Equal(t, "", defaultCoreVersion(""))
+	err = util.SaveCLIConfig(&util.CLIConfig{CoreVersion: "v1.6.0"})
+	assert.Nil(t, err)
+	assert.True(t, util.CLIConfigExists())
+	assert.Equal(t, "v1.6.0", defaultCoreVersion(""))
+	assert.Equal(t, "v1.2.0", defaultCoreVersion("v1.2.0"))
+}
+
+func TestListProxyVersions(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github.com/project-flogo/core/@v/list" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "v1.10.0\nv1.2.0\nv0.9.2\n")
+	}))
+	defer server.Close()
+
+	versions, err := listProxyVersions(server.URL+"/", flogoCoreRepo)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"v0.9.2", "v1.2.0", "v1.10.0"}, versions)
+	assert.True(t, isKnownVersion(versions, "v1.2.0"))
+	assert.False(t, isKnownVersion(versions, "v1.3.0"))
+
+	_, err = listProxyVersions(server.URL, "github.com/project-flogo/flow")
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -29,10 +30,11 @@ var flogoImportPattern = regexp.MustCompile(`^(([^ ]*)[ ]+)?([^@:]*)@?([^:]*)?:?
 
 type ShimBuilder struct {
 	appBuilder common.Builder
-	shim string
+	shim       string
+	options    common.BuildOptions
 }
 
-func (sb *ShimBuilder) Build(project common.AppProject) error {
+func (sb *ShimBuilder) Build(ctx context.Context, project common.AppProject) error {
 
 	err := backupMain(project)
 	if err != nil {
@@ -49,7 +51,7 @@ func (sb *ShimBuilder) Build(project common.AppProject) error {
 	if Verbose() {
 		fmt.Println("Preparing shim...")
 	}
-	built, err := prepareShim(project, sb.shim)
+	built, err := prepareShim(ctx, project, sb.shim)
 	if err != nil {
 		return err
 	}
@@ -57,7 +59,7 @@ func (sb *ShimBuilder) Build(project common.AppProject) error {
 	if !built {
 		fmt.Println("Using go build to build shim...")
 
-		err := simpleGoBuild(project)
+		err := simpleGoBuild(ctx, project, sb.options)
 		if err != nil {
 			return err
 		}
@@ -66,7 +68,7 @@ func (sb *ShimBuilder) Build(project common.AppProject) error {
 	return nil
 }
 
-func prepareShim(project common.AppProject, shim string) (bool, error) {
+func prepareShim(ctx context.Context, project common.AppProject, shim string) (bool, error) {
 
 	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
 	if err != nil {
@@ -134,7 +136,7 @@ func prepareShim(project common.AppProject, shim string) (bool, error) {
 					}
 
 					// Execute go run gobuild.go
-					err = util.ExecCmd(exec.Command("go", "run", fileBuildGo), project.SrcDir())
+					err = util.ExecCmd(exec.CommandContext(ctx, "go", "run", fileBuildGo), project.SrcDir())
 					if err != nil {
 						return false, err
 					}
@@ -220,7 +222,7 @@ func createShimSupportGoFile(project common.AppProject) error {
 		return err
 	}
 
-	err = ioutil.WriteFile(shimSrcPath, bytes, 0644)
+	err = util.WriteFileAtomic(shimSrcPath, bytes, 0644)
 	if err != nil {
 		return err
 	}
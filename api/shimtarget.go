@@ -0,0 +1,184 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	ShimTargetLambda = "lambda"
+	ShimTargetAzure  = "azure"
+	ShimTargetGCF    = "gcf"
+
+	fileAzureHostJson     = "host.json"
+	fileAzureFunctionJson = "function.json"
+	fileProcfile          = "Procfile"
+)
+
+// shimTarget is a serverless platform a shim is packaged for, the package is a zip uploaded to the platform
+type shimTarget struct {
+	handler string // the name of the executable in the package
+	portEnv string // the environment variable the port of the trigger is bound to, when the trigger serves the requests forwarded by the platform
+	files   func(project common.AppProject, shim, handler string) (map[string][]byte, error)
+}
+
+var shimTargets = map[string]*shimTarget{
+	// the lambda shim of the trigger is the entrypoint, the function uses the provided.al2 runtime
+	ShimTargetLambda: {handler: "bootstrap"},
+	// the trigger is an Azure Functions custom handler, the requests are forwarded to it
+	ShimTargetAzure: {handler: "handler", portEnv: "FUNCTIONS_CUSTOMHANDLER_PORT", files: azureFunctionFiles},
+	// the trigger serves the requests of the Cloud Run service of a Cloud Functions Gen2 function
+	ShimTargetGCF: {handler: "server", portEnv: "PORT", files: gcfFunctionFiles},
+}
+
+func getShimTarget(name string) (*shimTarget, error) {
+
+	target, exists := shimTargets[name]
+	if !exists {
+		var names []string
+		for n := range shimTargets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown shim target '%s', expected %s", name, strings.Join(names, ", "))
+	}
+
+	return target, nil
+}
+
+// shimPortEnv gets the environment variable the port of the shim trigger is bound to, empty when the shim of the
+// trigger is the entrypoint of the application
+func shimPortEnv(options common.BuildOptions) string {
+	if target := shimTargets[options.ShimTarget]; target != nil {
+		return target.portEnv
+	}
+	return ""
+}
+
+// setShimTargetPlatform sets the platform the shim is built for, linux/amd64 unless GOOS or GOARCH are set, ex. to
+// build a lambda for arm64, the returned function restores the previous one
+func setShimTargetPlatform(name string) (func(), error) {
+
+	if _, err := getShimTarget(name); err != nil {
+		return nil, err
+	}
+
+	goos, goarch := os.Getenv("GOOS"), os.Getenv("GOARCH")
+	if goos == "" {
+		goos = "linux"
+	}
+	if goarch == "" {
+		goarch = "amd64"
+	}
+
+	return setBuildPlatform(goos, goarch, true), nil
+}
+
+// bindShimTriggerPort binds the port of the shim trigger to the environment variable of the target, so it listens on
+// the port the platform forwards the requests to
+func bindShimTriggerPort(appObj map[string]interface{}, shim, portEnv string) error {
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		trgMap, ok := trg.(map[string]interface{})
+		if !ok || trgMap["id"] != shim {
+			continue
+		}
+		settings, _ := trgMap["settings"].(map[string]interface{})
+		if _, hasPort := settings["port"]; !hasPort {
+			return fmt.Errorf("trigger '%s' has no port setting, the requests are forwarded to an HTTP trigger", shim)
+		}
+		settings["port"] = "=$env[" + portEnv + "]"
+		return nil
+	}
+
+	return fmt.Errorf("unable to to find shim trigger: %s", shim)
+}
+
+// packageShim creates bin/<app>-<target>.zip containing the executable, named after the handler of the target, and
+// the files the target requires
+func packageShim(project common.AppProject, options common.BuildOptions) (string, error) {
+
+	target, err := getShimTarget(options.ShimTarget)
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string]string{target.handler: project.Executable()}
+
+	if target.files != nil {
+		generated, err := target.files(project, options.Shim, target.handler)
+		if err != nil {
+			return "", err
+		}
+
+		tmpDir, err := ioutil.TempDir("", "flogo-shim")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		for name, content := range generated {
+			file := filepath.Join(tmpDir, strings.Replace(name, "/", "_", -1))
+			err = ioutil.WriteFile(file, content, 0644)
+			if err != nil {
+				return "", err
+			}
+			files[name] = file
+		}
+	}
+
+	zipFile := filepath.Join(project.BinDir(), project.Name()+"-"+options.ShimTarget+".zip")
+	err = zipFiles(zipFile, files)
+	if err != nil {
+		return "", err
+	}
+
+	return zipFile, nil
+}
+
+// azureFunctionFiles gets the host.json configuring the executable as the custom handler, with the HTTP requests
+// forwarded to it, and the function.json of a function, named after the trigger, receiving the requests of any route
+func azureFunctionFiles(project common.AppProject, shim, handler string) (map[string][]byte, error) {
+
+	host := map[string]interface{}{
+		"version": "2.0",
+		"customHandler": map[string]interface{}{
+			"description":                 map[string]interface{}{"defaultExecutablePath": handler, "workingDirectory": "", "arguments": []string{}},
+			"enableForwardingHttpRequest": true,
+		},
+		"extensions":      map[string]interface{}{"http": map[string]interface{}{"routePrefix": ""}},
+		"extensionBundle": map[string]interface{}{"id": "Microsoft.Azure.Functions.ExtensionBundle", "version": "[4.*, 5.0.0)"},
+	}
+
+	function := map[string]interface{}{
+		"bindings": []interface{}{
+			map[string]interface{}{"type": "httpTrigger", "direction": "in", "name": "req", "authLevel": "anonymous",
+				"methods": []string{"get", "post", "put", "patch", "delete", "head", "options"}, "route": "{*route}"},
+			map[string]interface{}{"type": "http", "direction": "out", "name": "res"},
+		},
+	}
+
+	files := make(map[string][]byte)
+	for name, value := range map[string]interface{}{fileAzureHostJson: host, shim + "/" + fileAzureFunctionJson: function} {
+		buf, err := json.MarshalIndent(value, "", jsonIndent)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = append(buf, '\n')
+	}
+
+	return files, nil
+}
+
+// gcfFunctionFiles gets the Procfile starting the executable, which serves the requests on $PORT
+func gcfFunctionFiles(project common.AppProject, shim, handler string) (map[string][]byte, error) {
+	return map[string][]byte{fileProcfile: []byte("web: ./" + handler + "\n")}, nil
+}
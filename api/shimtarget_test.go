@@ -0,0 +1,92 @@
+package api
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShimTargetDescriptor(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "shimtarget")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "flogo.json"), []byte(`{"name": "myApp", "triggers": [
+		{"id": "rest", "settings": {"port": 9999}},
+		{"id": "timer", "settings": {}}
+	]}`), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(dir)
+
+	flogoJSON, err := getBuildAppDescriptor(project, common.BuildOptions{Shim: "rest", ShimTarget: ShimTargetAzure})
+	assert.Nil(t, err)
+	assert.Contains(t, flogoJSON, `"port": "=$env[FUNCTIONS_CUSTOMHANDLER_PORT]"`)
+
+	flogoJSON, err = getBuildAppDescriptor(project, common.BuildOptions{Shim: "rest", ShimTarget: ShimTargetGCF})
+	assert.Nil(t, err)
+	assert.Contains(t, flogoJSON, `"port": "=$env[PORT]"`)
+
+	// the lambda shim of the trigger is the entrypoint
+	flogoJSON, err = getBuildAppDescriptor(project, common.BuildOptions{Shim: "rest", ShimTarget: ShimTargetLambda})
+	assert.Nil(t, err)
+	assert.Contains(t, flogoJSON, `"port": 9999`)
+
+	_, err = getBuildAppDescriptor(project, common.BuildOptions{Shim: "timer", ShimTarget: ShimTargetAzure})
+	assert.NotNil(t, err)
+	_, err = getBuildAppDescriptor(project, common.BuildOptions{Shim: "missing", ShimTarget: ShimTargetGCF})
+	assert.NotNil(t, err)
+
+	_, err = getShimTarget("openwhisk")
+	assert.NotNil(t, err)
+}
+
+func TestPackageShim(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "shimtarget")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	project := NewAppProject(filepath.Join(dir, "myApp"))
+	err = os.MkdirAll(project.BinDir(), 0755)
+	assert.Nil(t, err)
+
+	restore, err := setShimTargetPlatform(ShimTargetAzure)
+	assert.Nil(t, err)
+	defer restore()
+	assert.Equal(t, "linux", os.Getenv("GOOS"))
+
+	err = ioutil.WriteFile(project.Executable(), []byte("binary"), 0755)
+	assert.Nil(t, err)
+
+	zipFile, err := packageShim(project, common.BuildOptions{Shim: "rest", ShimTarget: ShimTargetAzure})
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(project.BinDir(), "myApp-azure.zip"), zipFile)
+
+	zr, err := zip.OpenReader(zipFile)
+	assert.Nil(t, err)
+	defer zr.Close()
+
+	var names []string
+	content := make(map[string]string)
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		r, err := f.Open()
+		assert.Nil(t, err)
+		buf, _ := ioutil.ReadAll(r)
+		r.Close()
+		content[f.Name] = string(buf)
+	}
+	sort.Strings(names)
+
+	assert.Equal(t, []string{"handler", "host.json", "rest/function.json"}, names)
+	assert.Contains(t, content["host.json"], `"defaultExecutablePath": "handler"`)
+	assert.Contains(t, content["host.json"], `"enableForwardingHttpRequest": true`)
+	assert.Contains(t, content["rest/function.json"], `"route": "{*route}"`)
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	// EnvSigningKey is the key signing the artifacts, when not specified by the build
+	EnvSigningKey = "FLOGO_SIGNING_KEY"
+
+	signerCosign   = "cosign"
+	signerMinisign = "minisign"
+)
+
+// the suffixes of the files of the signatures of an artifact
+var signatureSuffixes = []string{".sig", ".pem", ".minisig"}
+
+func init() {
+	common.RegisterArtifactSigner(signerCosign, &cosignSigner{})
+	common.RegisterArtifactSigner(signerMinisign, &minisignSigner{})
+}
+
+// signArtifacts signs the executables built and their provenance, the signatures are written next to them
+func signArtifacts(ctx context.Context, project common.AppProject, options common.BuildOptions) error {
+
+	signer := common.GetArtifactSigner(options.Signing.Signer)
+	if signer == nil {
+		return fmt.Errorf("unknown artifact signer '%s', available: %s", options.Signing.Signer, strings.Join(common.ArtifactSigners(), ", "))
+	}
+
+	key := options.Signing.Key
+	if key == "" {
+		key = os.Getenv(EnvSigningKey)
+	}
+
+	artifacts, err := buildExecutables(project, options)
+	if err != nil {
+		return err
+	}
+	if options.Provenance != nil {
+		artifacts = append(artifacts, provenanceFile(project, options))
+	}
+
+	for _, artifact := range artifacts {
+		if !util.FileExists(artifact) {
+			// ex. a platform whose build failed
+			continue
+		}
+		signatures, err := signer.Sign(ctx, artifact, key)
+		if err != nil {
+			return fmt.Errorf("unable to sign '%s': %v", artifact, err)
+		}
+		fmt.Printf("Signed %s: %s\n", artifact, strings.Join(signatures, ", "))
+	}
+
+	return nil
+}
+
+// SignatureFiles gets the files of the signatures of the artifact
+func SignatureFiles(artifact string) []string {
+
+	var files []string
+	for _, suffix := range signatureSuffixes {
+		if util.FileExists(artifact + suffix) {
+			files = append(files, artifact+suffix)
+		}
+	}
+
+	return files
+}
+
+// runSigner runs the signing tool in the terminal, which prompts for the password of the key if it is encrypted
+func runSigner(ctx context.Context, name string, args ...string) error {
+
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("'%s' not found, it must be installed to sign the artifacts", name)
+	}
+
+	if Verbose() {
+		fmt.Printf("Running: %s %s\n", name, strings.Join(args, " "))
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v", name, err)
+	}
+
+	return nil
+}
+
+// cosignSigner signs the artifacts using cosign sign-blob, with a key file, a KMS URI or keyless (the certificate of
+// the identity signing them is written along with the signature)
+type cosignSigner struct {
+}
+
+func (s *cosignSigner) Sign(ctx context.Context, artifact string, key string) ([]string, error) {
+
+	signatures := []string{artifact + ".sig"}
+	args := []string{"sign-blob", "--yes", "--output-signature", artifact + ".sig"}
+	if key != "" {
+		args = append(args, "--key", key)
+	} else {
+		signatures = append(signatures, artifact+".pem")
+		args = append(args, "--output-certificate", artifact+".pem")
+	}
+	args = append(args, artifact)
+
+	return signatures, runSigner(ctx, "cosign", args...)
+}
+
+// minisignSigner signs the artifacts using minisign, with the secret key file
+type minisignSigner struct {
+}
+
+func (s *minisignSigner) Sign(ctx context.Context, artifact string, key string) ([]string, error) {
+
+	args := []string{"-S", "-m", artifact, "-x", artifact + ".minisig"}
+	if key != "" {
+		args = append(args, "-s", key)
+	}
+
+	return []string{artifact + ".minisig"}, runSigner(ctx, "minisign", args...)
+}
+
+// validateSigningOptions validates that the artifacts of the build can be signed by the artifact signer
+func validateSigningOptions(options common.BuildOptions) error {
+
+	if options.Signing == nil {
+		return nil
+	}
+	if options.Docker != nil {
+		return fmt.Errorf("the artifacts of an image can't be signed, sign the image once pushed instead")
+	}
+	if common.GetArtifactSigner(options.Signing.Signer) == nil {
+		return fmt.Errorf("unknown artifact signer '%s', available: %s", options.Signing.Signer, strings.Join(common.ArtifactSigners(), ", "))
+	}
+	if options.Provenance != nil && options.Provenance.SignKey != "" {
+		return fmt.Errorf("the provenance is signed by the artifact signer, its envelope can't be signed using a key as well")
+	}
+
+	return nil
+}
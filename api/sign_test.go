@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSigner writes the key as the signature of the artifacts
+type testSigner struct {
+}
+
+func (s *testSigner) Sign(ctx context.Context, artifact string, key string) ([]string, error) {
+	return []string{artifact + ".sig"}, ioutil.WriteFile(artifact+".sig", []byte(key), 0644)
+}
+
+func TestSignArtifacts(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "sign")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	common.RegisterArtifactSigner("test", &testSigner{})
+
+	project := NewAppProject(filepath.Join(tempDir, "myApp"))
+	assert.Nil(t, os.MkdirAll(project.BinDir(), 0755))
+	assert.Nil(t, ioutil.WriteFile(project.Executable(), []byte("app"), 0755))
+
+	os.Setenv(EnvSigningKey, "env.key")
+	defer os.Unsetenv(EnvSigningKey)
+
+	err = signArtifacts(context.Background(), project, common.BuildOptions{Signing: &common.SigningOptions{Signer: "test"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{project.Executable() + ".sig"}, SignatureFiles(project.Executable()))
+	buf, err := ioutil.ReadFile(project.Executable() + ".sig")
+	assert.Nil(t, err)
+	assert.Equal(t, "env.key", string(buf))
+
+	err = signArtifacts(context.Background(), project, common.BuildOptions{Signing: &common.SigningOptions{Signer: "test", Key: "flag.key"}})
+	assert.Nil(t, err)
+	buf, err = ioutil.ReadFile(project.Executable() + ".sig")
+	assert.Nil(t, err)
+	assert.Equal(t, "flag.key", string(buf))
+
+	err = signArtifacts(context.Background(), project, common.BuildOptions{Signing: &common.SigningOptions{Signer: "unknown"}})
+	assert.NotNil(t, err)
+
+	assert.Contains(t, common.ArtifactSigners(), signerCosign)
+	assert.Contains(t, common.ArtifactSigners(), signerMinisign)
+}
+
+func TestValidateSigningOptions(t *testing.T) {
+
+	common.RegisterArtifactSigner("test", &testSigner{})
+
+	assert.Nil(t, validateSigningOptions(common.BuildOptions{}))
+	assert.Nil(t, validateSigningOptions(common.BuildOptions{Signing: &common.SigningOptions{Signer: "test"}, Provenance: &common.ProvenanceOptions{}}))
+	assert.NotNil(t, validateSigningOptions(common.BuildOptions{Signing: &common.SigningOptions{Signer: "unknown"}}))
+	assert.NotNil(t, validateSigningOptions(common.BuildOptions{Signing: &common.SigningOptions{Signer: "test"}, Docker: &common.DockerOptions{}}))
+
+	// the provenance is signed either by the artifact signer or using the key of its envelope
+	err := validateSigningOptions(common.BuildOptions{Signing: &common.SigningOptions{Signer: "test"},
+		Provenance: &common.ProvenanceOptions{SignKey: "release.pem"}})
+	assert.NotNil(t, err)
+}
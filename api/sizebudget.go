@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileSizeBudget = "size-budget.json"
+
+	sizeBudgetFail = "fail"
+	sizeBudgetWarn = "warn"
+)
+
+// sizeBudget is the maximum size of the executables of the application declared in size-budget.json, the budgets of
+// the platforms are os/arch patterns whose os or arch can be *, ex. linux/arm or linux/*, or os/arch-variant
+type sizeBudget struct {
+	MaxSize   string            `json:"maxSize,omitempty"`   // the budget of the platforms without one, ex. 30MB
+	Platforms map[string]string `json:"platforms,omitempty"` // the budgets by platform, ex. "linux/arm": "12MB"
+	OnExceed  string            `json:"onExceed,omitempty"`  // fail, the default, or warn
+
+	maxSize   int64
+	platforms map[string]int64
+}
+
+// sizeBudgetViolation is an executable over the budget of its platform
+type sizeBudgetViolation struct {
+	Executable string
+	Platform   string
+	Size       int64
+	Budget     int64
+}
+
+func (v *sizeBudgetViolation) String() string {
+	return fmt.Sprintf("%s is %s, over the budget of %s for %s by %s", v.Executable, util.FormatByteSize(v.Size),
+		util.FormatByteSize(v.Budget), v.Platform, util.FormatByteSize(v.Size-v.Budget))
+}
+
+// loadSizeBudget loads the size budget of the project, nil if it doesn't declare one
+func loadSizeBudget(project common.AppProject) (*sizeBudget, error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileSizeBudget))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	budget := &sizeBudget{}
+	err = json.Unmarshal(buf, budget)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", fileSizeBudget, err)
+	}
+
+	return budget, budget.validate()
+}
+
+// validate validates the budget and parses its sizes
+func (b *sizeBudget) validate() error {
+
+	switch b.OnExceed {
+	case "":
+		b.OnExceed = sizeBudgetFail
+	case sizeBudgetFail, sizeBudgetWarn:
+	default:
+		return fmt.Errorf("invalid onExceed '%s' in %s, expected %s or %s", b.OnExceed, fileSizeBudget, sizeBudgetFail, sizeBudgetWarn)
+	}
+
+	if b.MaxSize == "" && len(b.Platforms) == 0 {
+		return fmt.Errorf("no maxSize or platforms defined in %s", fileSizeBudget)
+	}
+
+	var err error
+	if b.MaxSize != "" {
+		b.maxSize, err = util.ParseByteSize(b.MaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid maxSize in %s: %v", fileSizeBudget, err)
+		}
+	}
+
+	b.platforms = make(map[string]int64)
+	for pattern, size := range b.Platforms {
+		parts := strings.Split(strings.SplitN(pattern, "-", 2)[0], "/")
+		if len(parts) != 2 || parts[0] != "*" && !platformPartPattern.MatchString(parts[0]) || parts[1] != "*" && !platformPartPattern.MatchString(parts[1]) ||
+			strings.Contains(pattern, "*") && strings.Contains(pattern, "-") {
+			return fmt.Errorf("invalid platform '%s' in %s, expected os/arch or os/arch-variant, the os or the arch can be *", pattern, fileSizeBudget)
+		}
+		b.platforms[pattern], err = util.ParseByteSize(size)
+		if err != nil {
+			return fmt.Errorf("invalid budget of platform '%s' in %s: %v", pattern, fileSizeBudget, err)
+		}
+	}
+
+	return nil
+}
+
+// limit gets the budget of the platform, the most specific one applies: the one of the variant, of the os/arch, of
+// the os, of the arch, then maxSize, 0 if the platform has no budget
+func (b *sizeBudget) limit(platform *BuildPlatform) int64 {
+
+	for _, pattern := range []string{platform.String(), platform.OS + "/" + platform.Arch, platform.OS + "/*", "*/" + platform.Arch} {
+		if size, ok := b.platforms[pattern]; ok {
+			return size
+		}
+	}
+
+	return b.maxSize
+}
+
+// checkSizeBudget checks the size of the executables built against the budget of the project, the build fails if one
+// is over its budget unless the budget only warns
+func checkSizeBudget(project common.AppProject, options common.BuildOptions) error {
+
+	if options.Docker != nil {
+		// the executable is built in the image
+		return nil
+	}
+
+	budget, err := loadSizeBudget(project)
+	if err != nil || budget == nil {
+		return err
+	}
+
+	violations, err := sizeBudgetViolations(project, options, budget)
+	if err != nil || len(violations) == 0 {
+		return err
+	}
+
+	hint := fmt.Sprintf("run 'flogo size %s' to see what the size is made of", violations[0].Executable)
+	if budget.OnExceed == sizeBudgetWarn {
+		for _, violation := range violations {
+			fmt.Fprintln(os.Stderr, util.T("warning", violation))
+		}
+		fmt.Fprintln(os.Stderr, util.T("warning", hint))
+		return nil
+	}
+
+	var msgs []string
+	for _, violation := range violations {
+		msgs = append(msgs, violation.String())
+	}
+
+	return fmt.Errorf("size budget exceeded: %s, %s", strings.Join(msgs, "; "), hint)
+}
+
+// sizeBudgetViolations gets the executables built which are over the budget of their platform
+func sizeBudgetViolations(project common.AppProject, options common.BuildOptions, budget *sizeBudget) ([]*sizeBudgetViolation, error) {
+
+	var platforms []*BuildPlatform
+	var executables []string
+	if len(options.Platforms) > 0 {
+		var err error
+		platforms, err = ParsePlatforms(options.Platforms)
+		if err != nil {
+			return nil, err
+		}
+		for _, platform := range platforms {
+			executables = append(executables, PlatformExecutable(project, platform))
+		}
+	} else {
+		goos, goarch := buildTargetPlatform()
+		platforms = append(platforms, &BuildPlatform{OS: goos, Arch: goarch})
+		executables = append(executables, project.Executable())
+	}
+
+	var violations []*sizeBudgetViolation
+	for i, platform := range platforms {
+		limit := budget.limit(platform)
+		info, err := os.Stat(executables[i])
+		if limit == 0 || err != nil {
+			// ex. a platform whose build failed
+			continue
+		}
+		if Verbose() {
+			fmt.Println(util.T("size.budgetUsage", executables[i], util.FormatByteSize(info.Size()), util.FormatByteSize(limit)))
+		}
+		if info.Size() > limit {
+			violations = append(violations, &sizeBudgetViolation{Executable: executables[i], Platform: platform.String(), Size: info.Size(), Budget: limit})
+		}
+	}
+
+	return violations, nil
+}
+
+// sizeBudgetOf gets the budget of the executables of the platform declared by the project, 0 if none
+func sizeBudgetOf(project common.AppProject, platform *BuildPlatform) (int64, error) {
+
+	budget, err := loadSizeBudget(project)
+	if err != nil || budget == nil {
+		return 0, err
+	}
+
+	return budget.limit(platform), nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeBudgetLimit(t *testing.T) {
+
+	budget := &sizeBudget{MaxSize: "30MB", Platforms: map[string]string{"linux/arm": "12MB", "linux/*": "20MB", "linux/amd64-musl": "10MB", "*/arm64": "25MB"}}
+	assert.Nil(t, budget.validate())
+	assert.Equal(t, sizeBudgetFail, budget.OnExceed)
+
+	assert.Equal(t, int64(12<<20), budget.limit(&BuildPlatform{OS: "linux", Arch: "arm"}))
+	assert.Equal(t, int64(20<<20), budget.limit(&BuildPlatform{OS: "linux", Arch: "amd64"}))
+	assert.Equal(t, int64(10<<20), budget.limit(&BuildPlatform{OS: "linux", Arch: "amd64", Variant: "musl"}))
+	assert.Equal(t, int64(20<<20), budget.limit(&BuildPlatform{OS: "linux", Arch: "arm64"}))
+	assert.Equal(t, int64(25<<20), budget.limit(&BuildPlatform{OS: "darwin", Arch: "arm64"}))
+	assert.Equal(t, int64(30<<20), budget.limit(&BuildPlatform{OS: "windows", Arch: "amd64"}))
+
+	budget = &sizeBudget{Platforms: map[string]string{"linux/arm": "12MB"}}
+	assert.Nil(t, budget.validate())
+	assert.Equal(t, int64(0), budget.limit(&BuildPlatform{OS: "windows", Arch: "amd64"}))
+
+	assert.NotNil(t, (&sizeBudget{}).validate())
+	assert.NotNil(t, (&sizeBudget{MaxSize: "large"}).validate())
+	assert.NotNil(t, (&sizeBudget{MaxSize: "10MB", OnExceed: "ignore"}).validate())
+	assert.NotNil(t, (&sizeBudget{Platforms: map[string]string{"linux": "10MB"}}).validate())
+	assert.NotNil(t, (&sizeBudget{Platforms: map[string]string{"linux/*-musl": "10MB"}}).validate())
+}
+
+func TestAttributeSymbolSizes(t *testing.T) {
+
+	nm := `  4a1b20       120 T github.com/project-flogo/core/engine.(*engineImpl).Start
+  4a1c00        80 t github.com/project-flogo/core/data/coerce.ToString
+  4a2000        64 T github.com/project-flogo/contrib/activity/rest.(*Activity).Eval
+  4b0000        40 R gopkg.in/yaml%2ev2.(*TypeError).Error
+  4b1000       300 T runtime.mallocgc
+  4b2000       200 T net/http.(*Client).Do
+  4c0000      1000 r go:func.*
+  4c1000        16 R type:*github.com/project-flogo/core/engine.engineImpl
+  4d0000     50000 B runtime.mheap_
+  4e0000        10 T main.main
+                     U _cgo_panic
+`
+	modules := map[string]string{"main": "", "github.com/project-flogo/core": "v1.6.0", "github.com/project-flogo/contrib": "v1.0.0",
+		"github.com/project-flogo/contrib/activity/rest": "v0.10.0", "gopkg.in/yaml.v2": "v2.2.2"}
+
+	sizes := attributeSymbolSizes(strings.NewReader(nm), modules)
+	assert.Equal(t, map[string]int64{
+		"github.com/project-flogo/core":                  216,
+		"github.com/project-flogo/contrib/activity/rest": 64,
+		"gopkg.in/yaml.v2":                               40,
+		sizeGroupStd:                                     500,
+		sizeGroupOther:                                   1000,
+		"main":                                           10,
+	}, sizes)
+}
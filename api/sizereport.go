@@ -0,0 +1,224 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	sizeGroupStd   = "std"   // the packages of the standard library
+	sizeGroupOther = "other" // the type descriptors, strings, tables and debug information of the executable
+)
+
+// a symbol printed by go tool nm -size: its address, size, type and name, which can contain spaces
+var nmSymbolPattern = regexp.MustCompile(`^\s*[0-9a-f]*\s+(\d+)\s+(\S)\s+(.+)$`)
+
+// SizeReport attributes the size of an executable to the modules it is built from
+type SizeReport struct {
+	Binary   string        `json:"binary"`
+	Platform string        `json:"platform,omitempty"`
+	Size     int64         `json:"size"`
+	Budget   int64         `json:"budget,omitempty"` // the budget of the platform declared in size-budget.json
+	Modules  []*ModuleSize `json:"modules"`          // the modules, the standard library and the rest, largest first
+}
+
+// ModuleSize is the size of the code and data of a module in an executable
+type ModuleSize struct {
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	Size    int64  `json:"size"`
+}
+
+// ShowSizeReport prints what the size of the executable is made of, by module, along with the budget of its platform
+// when the project, which is optional, declares one
+func ShowSizeReport(project common.AppProject, binary string, jsonFormat bool) error {
+
+	report, err := BuildSizeReport(binary)
+	if err != nil {
+		return err
+	}
+
+	if project != nil && report.Platform != "" {
+		parts := strings.SplitN(report.Platform, "/", 2)
+		report.Budget, err = sizeBudgetOf(project, &BuildPlatform{OS: parts[0], Arch: parts[1]})
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(report, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(resp))
+		return nil
+	}
+
+	printSizeReport(os.Stdout, report)
+	return nil
+}
+
+// BuildSizeReport attributes the size of the executable to its modules using the symbol table of the executable
+func BuildSizeReport(binary string) (*SizeReport, error) {
+
+	info, err := os.Stat(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	goInfo, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the Go build information of '%s': %v", binary, err)
+	}
+
+	report := &SizeReport{Binary: binary, Size: info.Size()}
+
+	modules := map[string]string{goInfo.Main.Path: goInfo.Main.Version}
+	for _, dep := range goInfo.Deps {
+		modules[dep.Path] = dep.Version
+		if dep.Replace != nil {
+			modules[dep.Path] = dep.Replace.Version
+		}
+	}
+	// ex. built outside of a module
+	delete(modules, "")
+	var goos, goarch string
+	for _, setting := range goInfo.Settings {
+		switch setting.Key {
+		case "GOOS":
+			goos = setting.Value
+		case "GOARCH":
+			goarch = setting.Value
+		}
+	}
+	if goos != "" && goarch != "" {
+		report.Platform = goos + "/" + goarch
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command("go", "tool", "nm", "-size", binary)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("unable to read the symbols of '%s', it may have been built without a symbol table (ex. -ldflags=-s): %s", binary, strings.TrimSpace(out.String()))
+	}
+
+	sizes := attributeSymbolSizes(&out, modules)
+
+	// the rest of the executable: its headers, symbol table and debug information
+	attributed := int64(0)
+	for _, size := range sizes {
+		attributed += size
+	}
+	if report.Size > attributed {
+		sizes[sizeGroupOther] += report.Size - attributed
+	}
+
+	for module, size := range sizes {
+		report.Modules = append(report.Modules, &ModuleSize{Module: module, Version: modules[module], Size: size})
+	}
+	sort.Slice(report.Modules, func(i, j int) bool {
+		if report.Modules[i].Size != report.Modules[j].Size {
+			return report.Modules[i].Size > report.Modules[j].Size
+		}
+		return report.Modules[i].Module < report.Modules[j].Module
+	})
+
+	return report, nil
+}
+
+// attributeSymbolSizes sums the sizes of the symbols listed by go tool nm -size by module, the uninitialized data,
+// which takes no space in the executable, is ignored
+func attributeSymbolSizes(r io.Reader, modules map[string]string) map[string]int64 {
+
+	var paths []string
+	for module := range modules {
+		paths = append(paths, module)
+	}
+	// the longest module containing the package of the symbol is the one it belongs to
+	sort.Slice(paths, func(i, j int) bool { return len(paths[i]) > len(paths[j]) })
+
+	sizes := make(map[string]int64)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		match := nmSymbolPattern.FindStringSubmatch(scanner.Text())
+		if match == nil || strings.ContainsAny(match[2], "BbU") {
+			continue
+		}
+		size, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || size == 0 {
+			continue
+		}
+		sizes[symbolModule(match[3], paths)] += size
+	}
+
+	return sizes
+}
+
+// symbolModule gets the module of the package of a symbol, ex. github.com/project-flogo/core for
+// github.com/project-flogo/core/engine.(*engineImpl).Start, std for the standard library, other if unknown
+func symbolModule(name string, modules []string) string {
+
+	// the dots of the last element of the package path are escaped, ex. gopkg.in/yaml%2ev2.Marshal
+	name = strings.Replace(strings.Replace(name, "%252e", ".", -1), "%2e", ".", -1)
+	name = strings.TrimLeft(strings.TrimPrefix(name, "type:"), "*")
+
+	for _, module := range modules {
+		if strings.HasPrefix(name, module+".") || strings.HasPrefix(name, module+"/") {
+			return module
+		}
+	}
+
+	// the first element of the packages of the standard library has no dot, ex. runtime or net/http
+	pkg := name
+	if end := strings.IndexAny(pkg, "([ "); end >= 0 {
+		pkg = pkg[:end]
+	}
+	dot := strings.Index(pkg, ".")
+	slash := strings.Index(pkg, "/")
+	if dot > 0 && (slash < 0 || slash < dot) && !strings.HasPrefix(pkg, "go:") {
+		return sizeGroupStd
+	}
+
+	return sizeGroupOther
+}
+
+func printSizeReport(w io.Writer, report *SizeReport) {
+
+	fmt.Fprint(w, util.T("size.title", report.Binary, util.FormatByteSize(report.Size)))
+	if report.Platform != "" {
+		fmt.Fprintf(w, " (%s)", report.Platform)
+	}
+	fmt.Fprintln(w)
+	if report.Budget > 0 {
+		key := "size.budgetWithin"
+		if report.Size > report.Budget {
+			key = "size.budgetOver"
+		}
+		fmt.Fprintln(w, util.T(key, util.FormatByteSize(report.Budget), report.Size*100/report.Budget))
+	}
+
+	fmt.Fprintln(w)
+	for _, module := range report.Modules {
+		name := module.Module
+		if module.Version != "" && module.Version != "(devel)" {
+			name += " " + module.Version
+		}
+		fmt.Fprintf(w, "  %-60s %10s %5.1f%%\n", name, util.FormatByteSize(module.Size), float64(module.Size)*100/float64(report.Size))
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	// DefaultSmokeTimeout is the time given to the application to start during the smoke test
+	DefaultSmokeTimeout = 10 * time.Second
+
+	smokeReadyMessage = "Engine Started"
+	smokeStopTimeout  = 5 * time.Second
+	smokeOutputLines  = 20
+)
+
+var (
+	smokeTriggerStartedPattern = regexp.MustCompile(`Trigger \[ ?([^\]]*?) ?\]: Started`)
+	smokeFailurePatterns       = []*regexp.Regexp{
+		regexp.MustCompile(`cannot register import`),
+		regexp.MustCompile(`failed to start due to error`),
+		regexp.MustCompile(`: Error Starting`),
+		regexp.MustCompile(`^panic: `),
+	}
+)
+
+// SmokeTestApp starts the built application and checks that the engine starts and all the triggers of the app
+// descriptor are started, which catches the contribution registration and initialization errors at build time.
+// The application is stopped once started, the warnings it logged while starting are reported.
+func SmokeTestApp(project common.AppProject, timeout time.Duration) error {
+
+	if timeout <= 0 {
+		timeout = DefaultSmokeTimeout
+	}
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	var expected []string
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		trgMap, _ := trg.(map[string]interface{})
+		if id, _ := trgMap["id"].(string); id != "" {
+			expected = append(expected, id)
+		}
+	}
+
+	exe := project.Executable()
+	if _, err := os.Stat(exe); err != nil {
+		return fmt.Errorf("executable '%s' not found", exe)
+	}
+
+	fmt.Printf("Smoke testing application '%s'...\n", project.Name())
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	// the app descriptor is read from the working directory if it isn't embedded
+	cmd := exec.Command(exe)
+	cmd.Dir = project.Dir()
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	start := time.Now()
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		r.Close()
+		return err
+	}
+
+	lineCh := make(chan string)
+	go func() {
+		defer r.Close()
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lineCh <- scanner.Text()
+		}
+		close(lineCh)
+	}()
+	// the output of the application is drained until it exits
+	defer func() {
+		go func() {
+			for range lineCh {
+			}
+		}()
+	}()
+	lines := lineCh
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	var output []string
+	// the warnings of the engine are reported with their remediation rather than lost in its output
+	var warnings engineWarnings
+	defer warnings.report(os.Stderr)
+	failed := false
+	started := make(map[string]bool)
+	ready := false
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for !ready && !failed {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				// the application exited, its status is waited for below
+				lines = nil
+				continue
+			}
+			output = appendOutputLine(output, line)
+			warnings.add(line)
+			if m := smokeTriggerStartedPattern.FindStringSubmatch(line); m != nil {
+				started[m[1]] = true
+			}
+			for _, pattern := range smokeFailurePatterns {
+				if pattern.MatchString(line) {
+					failed = true
+				}
+			}
+			if strings.Contains(line, smokeReadyMessage) {
+				ready = true
+			}
+		case err := <-exited:
+			if lines != nil {
+				for line := range lines {
+					output = appendOutputLine(output, line)
+					warnings.add(line)
+				}
+			}
+			if err == nil {
+				err = fmt.Errorf("exited")
+			}
+			return fmt.Errorf("smoke test failed, the application %v before starting:\n%s", err, strings.Join(output, "\n"))
+		case <-timer.C:
+			stopSmokeTestApp(cmd, exited)
+			return fmt.Errorf("smoke test failed, the engine didn't start within %s:\n%s", timeout, strings.Join(output, "\n"))
+		}
+	}
+
+	elapsed := time.Since(start)
+	stopSmokeTestApp(cmd, exited)
+
+	var notStarted []string
+	for _, id := range expected {
+		if !started[id] {
+			notStarted = append(notStarted, id)
+		}
+	}
+	sort.Strings(notStarted)
+
+	if failed || len(notStarted) > 0 {
+		msg := "smoke test failed"
+		if len(notStarted) > 0 {
+			msg += fmt.Sprintf(", triggers not started: %s", strings.Join(notStarted, ", "))
+		}
+		return fmt.Errorf("%s:\n%s", msg, strings.Join(output, "\n"))
+	}
+
+	fmt.Printf("Smoke test passed: the engine started in %s with %d trigger(s)\n", elapsed.Round(10*time.Millisecond), len(expected))
+
+	return nil
+}
+
+// stopSmokeTestApp stops the application, it is killed if it doesn't stop in time
+func stopSmokeTestApp(cmd *exec.Cmd, exited chan error) {
+
+	// interrupting a process isn't supported on windows
+	if runtime.GOOS == "windows" {
+		_ = cmd.Process.Kill()
+	} else {
+		_ = cmd.Process.Signal(os.Interrupt)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(smokeStopTimeout):
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+}
+
+// appendOutputLine keeps the last lines of the output of the application
+func appendOutputLine(output []string, line string) []string {
+
+	output = append(output, line)
+	if len(output) > smokeOutputLines {
+		output = output[len(output)-smokeOutputLines:]
+	}
+
+	return output
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmokeTestApp(t *testing.T) {
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the application is simulated by a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "smoke")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	appDir := filepath.Join(dir, "myApp")
+	err = os.MkdirAll(filepath.Join(appDir, "bin"), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "flogo.json"), []byte(`{"triggers": [{"id": "rest"}, {"id": "timer"}]}`), 0644)
+	assert.Nil(t, err)
+
+	project := NewAppProject(appDir)
+
+	writeApp := func(script string) {
+		err := ioutil.WriteFile(project.Executable(), []byte("#!/bin/sh\n"+script), 0755)
+		assert.Nil(t, err)
+	}
+
+	writeApp(`echo "Trigger [ rest ]: Started"
+echo "Trigger [ timer ]: Started"
+echo "Engine Started"
+exec sleep 10
+`)
+	err = SmokeTestApp(project, 5*time.Second)
+	assert.Nil(t, err)
+
+	writeApp(`echo "Trigger [timer] failed to start due to error [bad schedule]"
+echo "Trigger [ rest ]: Started"
+echo "Engine Started"
+exec sleep 10
+`)
+	err = SmokeTestApp(project, 5*time.Second)
+	assert.NotNil(t, err)
+
+	writeApp(`echo "cannot start: invalid config"
+exit 1
+`)
+	err = SmokeTestApp(project, 5*time.Second)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "invalid config"))
+
+	writeApp(`exec sleep 10
+`)
+	err = SmokeTestApp(project, 200*time.Millisecond)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	dirProjectSnapshots = "snapshots"
+	dirSnapshotFiles    = "files"
+	fileSnapshotJson    = "snapshot.json"
+)
+
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// the files of .flogo which are state rather than configuration of the project, they aren't part of the snapshots
+var snapshotStateFiles = map[string]bool{
+	dirProjectCache:     true,
+	dirProjectSnapshots: true,
+	fileProjectLock:     true,
+	fileBuildHistory:    true,
+	fileAppPid:          true,
+	fileAppLog:          true,
+	fileAppStart:        true,
+}
+
+// Snapshot is a named copy of the descriptor, the lock, the dependencies and the .flogo configuration of the project,
+// which the project can be restored to, ex. after a failed upgrade of the core
+type Snapshot struct {
+	Name        string    `json:"name"`
+	Created     time.Time `json:"created"`
+	Message     string    `json:"message,omitempty"`
+	CoreVersion string    `json:"coreVersion,omitempty"`
+	Files       []string  `json:"files"` // the files of the snapshot, relative to the project directory
+}
+
+// CreateSnapshot copies the files of the project to a snapshot, named after the current time if no name is specified
+func CreateSnapshot(project common.AppProject, name, message string, overwrite bool) (*Snapshot, error) {
+
+	if name == "" {
+		name = time.Now().Format("20060102-150405")
+	}
+	if !snapshotNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name '%s', letters, digits, '.', '_' and '-' are allowed", name)
+	}
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	dir := snapshotDir(project, name)
+	if util.DirExists(dir) {
+		if !overwrite {
+			return nil, fmt.Errorf("snapshot '%s' already exists", name)
+		}
+		err = os.RemoveAll(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := snapshotFiles(project)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{Name: name, Created: time.Now().UTC().Truncate(time.Second), Message: message}
+	snapshot.CoreVersion = goModRequirements(project.SrcDir())[flogoCoreRepo]
+
+	for _, rel := range files {
+		buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), rel))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		file := filepath.Join(dir, dirSnapshotFiles, rel)
+		err = os.MkdirAll(filepath.Dir(file), 0755)
+		if err != nil {
+			return nil, err
+		}
+		err = util.WriteFileAtomic(file, buf, 0644)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Files = append(snapshot.Files, filepath.ToSlash(rel))
+	}
+
+	buf, err := json.MarshalIndent(snapshot, "", jsonIndent)
+	if err != nil {
+		return nil, err
+	}
+	// the snapshot is only listed once all its files are copied
+	err = util.WriteFileAtomic(filepath.Join(dir, fileSnapshotJson), buf, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot restores the files of the project to the ones of the snapshot, the files which didn't exist when
+// the snapshot was created are removed
+func RestoreSnapshot(project common.AppProject, name string) (*Snapshot, error) {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	snapshot, err := readSnapshot(project, name)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := snapshotFiles(project)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := make(map[string]bool)
+	for _, rel := range snapshot.Files {
+		restored[filepath.FromSlash(rel)] = true
+	}
+	for _, rel := range current {
+		if restored[rel] {
+			continue
+		}
+		err = os.Remove(filepath.Join(project.Dir(), rel))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if Verbose() {
+			fmt.Println(util.T("snapshot.removedFile", rel))
+		}
+	}
+
+	// flogo.json is restored last, so it isn't converted again from a YAML app descriptor restored after it
+	files := append([]string{}, snapshot.Files...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i] != fileFlogoJson && files[j] == fileFlogoJson
+	})
+
+	for _, rel := range files {
+		buf, err := ioutil.ReadFile(filepath.Join(snapshotDir(project, name), dirSnapshotFiles, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+
+		file := filepath.Join(project.Dir(), filepath.FromSlash(rel))
+		err = os.MkdirAll(filepath.Dir(file), 0755)
+		if err != nil {
+			return nil, err
+		}
+		err = util.WriteFileAtomic(file, buf, 0644)
+		if err != nil {
+			return nil, err
+		}
+		if Verbose() {
+			fmt.Println(util.T("snapshot.restoredFile", rel))
+		}
+	}
+
+	return snapshot, nil
+}
+
+// ListSnapshots gets the snapshots of the project, most recent first
+func ListSnapshots(project common.AppProject) ([]*Snapshot, error) {
+
+	entries, err := ioutil.ReadDir(filepath.Join(project.Dir(), dirProjectFlogo, dirProjectSnapshots))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		snapshot, err := readSnapshot(project, entry.Name())
+		if err != nil {
+			// an incomplete snapshot, ex. interrupted while created
+			if Verbose() {
+				fmt.Fprintln(os.Stderr, util.T("warning", err))
+			}
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.After(snapshots[j].Created)
+	})
+
+	return snapshots, nil
+}
+
+func readSnapshot(project common.AppProject, name string) (*Snapshot, error) {
+
+	if !snapshotNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name '%s'", name)
+	}
+
+	buf, err := ioutil.ReadFile(filepath.Join(snapshotDir(project, name), fileSnapshotJson))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot '%s' not found", name)
+		}
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	err = json.Unmarshal(buf, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse snapshot '%s': %v", name, err)
+	}
+
+	return snapshot, nil
+}
+
+func snapshotDir(project common.AppProject, name string) string {
+	return filepath.Join(project.Dir(), dirProjectFlogo, dirProjectSnapshots, name)
+}
+
+// snapshotFiles gets the files of the project which are part of a snapshot, relative to the project directory: the app
+// descriptors, engine.json, flogo.lock, the imports and dependencies of src and the configuration files of .flogo
+func snapshotFiles(project common.AppProject) ([]string, error) {
+
+	files := []string{fileFlogoJson, fileFlogoYaml, fileFlogoYml, fileEngineJson, fileFlogoLock}
+	for _, file := range []string{fileImportsGo, "go.mod", "go.sum"} {
+		rel, err := filepath.Rel(project.Dir(), filepath.Join(project.SrcDir(), file))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rel)
+	}
+
+	flogoDir := filepath.Join(project.Dir(), dirProjectFlogo)
+	err := filepath.Walk(flogoDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if path == flogoDir {
+			return nil
+		}
+		rel, _ := filepath.Rel(flogoDir, path)
+		if snapshotStateFiles[strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			files = append(files, filepath.Join(dirProjectFlogo, rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []string
+	for _, rel := range files {
+		if util.FileExists(filepath.Join(project.Dir(), rel)) {
+			existing = append(existing, rel)
+		}
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshots(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(tempDir, dirSrc), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(tempDir, dirProjectFlogo, dirProjectCache), 0755))
+	files := map[string]string{
+		fileFlogoJson:                                                   `{"name": "app"}`,
+		filepath.Join(dirSrc, "go.mod"):                                 "module main\n\nrequire github.com/project-flogo/core v1.0.0\n",
+		filepath.Join(dirSrc, fileImportsGo):                            "package main\n",
+		filepath.Join(dirProjectFlogo, "config.json"):                   "{}",
+		filepath.Join(dirProjectFlogo, dirProjectCache, fileBuildCache): "{}",
+		filepath.Join(dirProjectFlogo, fileBuildHistory):                "[]",
+	}
+	for file, content := range files {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, file), []byte(content), 0644))
+	}
+
+	project := NewAppProject(tempDir)
+
+	_, err = CreateSnapshot(project, "../escape", "", false)
+	assert.NotNil(t, err)
+
+	snapshot, err := CreateSnapshot(project, "before", "upgrade", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "v1.0.0", snapshot.CoreVersion)
+	assert.ElementsMatch(t, []string{fileFlogoJson, "src/go.mod", "src/imports.go", ".flogo/config.json"}, snapshot.Files)
+
+	_, err = CreateSnapshot(project, "before", "", false)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(`{"name": "changed"}`), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, fileEngineJson), []byte(`{}`), 0644))
+	assert.Nil(t, os.Remove(filepath.Join(tempDir, dirProjectFlogo, "config.json")))
+
+	_, err = CreateSnapshot(project, "after", "", false)
+	assert.Nil(t, err)
+
+	_, err = RestoreSnapshot(project, "before")
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(filepath.Join(tempDir, fileFlogoJson))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "app"}`, string(buf))
+	assert.False(t, util.FileExists(filepath.Join(tempDir, fileEngineJson)))
+	assert.True(t, util.FileExists(filepath.Join(tempDir, dirProjectFlogo, "config.json")))
+	// the state of .flogo isn't restored
+	assert.True(t, util.FileExists(filepath.Join(tempDir, dirProjectFlogo, fileBuildHistory)))
+
+	_, err = RestoreSnapshot(project, "unknown")
+	assert.NotNil(t, err)
+
+	snapshots, err := ListSnapshots(project)
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 2)
+}
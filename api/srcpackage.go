@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileSrcPackagesGo = "packages.go"
+
+var srcPackageImportPattern = regexp.MustCompile(`(?m)^\s*_ ("[^"]+")`)
+
+// AddSrcPackage adds a Go package of the user to the src module of the application, so custom code is built along
+// with the generated files. A local directory is copied into src/<name>, when it contains a go.mod its module is
+// replaced with the copy, otherwise it becomes a package of the src module. A module path, optionally with a version,
+// is required using 'go get'. The packages are imported by src/packages.go, which the commands generating the files of
+// src don't change.
+func AddSrcPackage(ctx context.Context, project common.AppProject, pkg string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var importPath string
+
+	if info, err := os.Stat(pkg); err == nil && info.IsDir() {
+		importPath, err = vendorSrcPackage(ctx, project, pkg)
+		if err != nil {
+			return err
+		}
+	} else if strings.HasPrefix(pkg, ".") || filepath.IsAbs(pkg) {
+		return fmt.Errorf("directory '%s' not found", pkg)
+	} else {
+		importPath = strings.SplitN(pkg, "@", 2)[0]
+		if !strings.Contains(pkg, "@") {
+			pkg += "@latest"
+		}
+		err := project.DepManager().Get(ctx, pkg)
+		if err != nil {
+			return fmt.Errorf("unable to get package '%s': %v", pkg, err)
+		}
+	}
+
+	imports := srcPackageImports(project)
+	if isSrcPackage(imports, importPath) {
+		fmt.Printf("Updated package '%s'\n", importPath)
+		return nil
+	}
+
+	err = writeSrcPackagesGoFile(project, append(imports, importPath))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added package '%s' to the application\n", importPath)
+
+	return nil
+}
+
+// vendorSrcPackage copies the directory into src and wires it in the src module, the import path of the package is
+// returned
+func vendorSrcPackage(ctx context.Context, project common.AppProject, dir string) (importPath string, err error) {
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(dir)
+	dest := filepath.Join(project.SrcDir(), name)
+	if rel, err := filepath.Rel(project.SrcDir(), dir); err == nil && !strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("directory '%s' is already part of the src module", dir)
+	}
+
+	srcModule := goModModulePath(project.SrcDir())
+	if srcModule == "" {
+		return "", fmt.Errorf("unable to determine the module of %s", filepath.Join(project.SrcDir(), "go.mod"))
+	}
+
+	importPath = srcModule + "/" + name
+	modulePath := goModModulePath(dir)
+	if modulePath != "" {
+		importPath = modulePath
+	}
+
+	update := util.DirExists(dest)
+	if update {
+		// the package is updated from the directory, a directory of src which isn't one of the added packages is kept
+		if !isSrcPackage(srcPackageImports(project), importPath) {
+			return "", fmt.Errorf("'%s' already exists in the src directory", name)
+		}
+		err = os.RemoveAll(dest)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = util.Copy(dir, dest, true)
+	defer func() {
+		// a package which fails to be added is removed
+		if err != nil && !update {
+			_ = os.RemoveAll(dest)
+			if modulePath != "" {
+				_ = project.DepManager().Edit(ctx, "-dropreplace", modulePath)
+			}
+		}
+	}()
+	if err != nil {
+		return "", err
+	}
+	_ = os.RemoveAll(filepath.Join(dest, ".git"))
+
+	// requires the modules imported by the package
+	pattern := "./" + name + "/..."
+	if modulePath != "" {
+		err = project.DepManager().Edit(ctx, "-replace", modulePath+"=./"+name)
+		if err != nil {
+			return "", err
+		}
+		pattern = modulePath + "@v0.0.0"
+	}
+
+	err = project.DepManager().Get(ctx, pattern)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the dependencies of package '%s': %v", name, err)
+	}
+
+	return importPath, nil
+}
+
+func isSrcPackage(imports []string, importPath string) bool {
+	for _, imp := range imports {
+		if imp == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// srcPackageImports gets the packages imported by src/packages.go
+func srcPackageImports(project common.AppProject) []string {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.SrcDir(), fileSrcPackagesGo))
+	if err != nil {
+		return nil
+	}
+
+	var imports []string
+	for _, m := range srcPackageImportPattern.FindAllStringSubmatch(string(buf), -1) {
+		if imp, err := strconv.Unquote(m[1]); err == nil {
+			imports = append(imports, imp)
+		}
+	}
+
+	return imports
+}
+
+func writeSrcPackagesGoFile(project common.AppProject, imports []string) error {
+
+	sort.Strings(imports)
+
+	return writeTemplateFile(filepath.Join(project.SrcDir(), fileSrcPackagesGo), tplSrcPackagesGoFile, &struct{ Imports []string }{imports})
+}
+
+// goModModulePath gets the path of the module declared by the go.mod of the directory, empty if there is none
+func goModModulePath(dir string) string {
+
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(buf), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+
+	return ""
+}
+
+var tplSrcPackagesGoFile = `// This file has been generated using 'flogo src add-package', it imports the packages added to the application
+// It isn't changed when the other files are generated, add packages using 'flogo src add-package'
+package main
+
+import (
+{{- range .Imports}}
+	_ "{{.}}"
+{{- end}}
+)
+`
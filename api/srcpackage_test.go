@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSrcPackage(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "srcpkg")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	appDir := filepath.Join(dir, "myApp")
+	err = os.MkdirAll(filepath.Join(appDir, "src"), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "src", "go.mod"), []byte("module main\n\ngo 1.12\n"), 0644)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(appDir, "src", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	assert.Nil(t, err)
+
+	pkgDir := filepath.Join(dir, "helpers")
+	err = os.MkdirAll(pkgDir, 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(pkgDir, "helpers.go"), []byte("package helpers\n\nvar Version = \"1.0.0\"\n"), 0644)
+	assert.Nil(t, err)
+
+	project := NewAppProject(appDir)
+
+	err = AddSrcPackage(context.Background(), project, pkgDir)
+	assert.Nil(t, err)
+	assert.True(t, util.FileExists(filepath.Join(appDir, "src", "helpers", "helpers.go")))
+	assert.Equal(t, []string{"main/helpers"}, srcPackageImports(project))
+
+	// adding the package again updates it
+	err = ioutil.WriteFile(filepath.Join(pkgDir, "helpers.go"), []byte("package helpers\n\nvar Version = \"1.1.0\"\n"), 0644)
+	assert.Nil(t, err)
+	err = AddSrcPackage(context.Background(), project, pkgDir)
+	assert.Nil(t, err)
+	buf, _ := ioutil.ReadFile(filepath.Join(appDir, "src", "helpers", "helpers.go"))
+	assert.Contains(t, string(buf), "1.1.0")
+	assert.Equal(t, []string{"main/helpers"}, srcPackageImports(project))
+
+	// a directory of src which wasn't added isn't replaced
+	err = os.MkdirAll(filepath.Join(dir, "other", "shim"), 0755)
+	assert.Nil(t, err)
+	err = os.MkdirAll(filepath.Join(appDir, "src", "shim"), 0755)
+	assert.Nil(t, err)
+	err = AddSrcPackage(context.Background(), project, filepath.Join(dir, "other", "shim"))
+	assert.NotNil(t, err)
+
+	assert.NotNil(t, AddSrcPackage(context.Background(), project, filepath.Join(dir, "missing")))
+}
+
+func TestGoModModulePath(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "srcpkg")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Equal(t, "", goModModulePath(dir))
+
+	err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("// helpers\nmodule \"example.com/helpers\"\n\ngo 1.12\n"), 0644)
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com/helpers", goModModulePath(dir))
+}
@@ -0,0 +1,461 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	fileTraceGo = "trace.go"
+
+	// DefaultTraceFile is the file the traces are written to when tracing is enabled without specifying the output,
+	// relative to the working directory of the application
+	DefaultTraceFile = "trace.jsonl"
+
+	// EnvTrace is the environment variable overriding the trace output of a traced application, a file, stdout or off
+	EnvTrace = "FLOGO_TRACE"
+
+	traceValueLength = 100
+)
+
+// TraceViewOptions are the options used to view the traces of an application
+type TraceViewOptions struct {
+	File       string // the trace file, the default trace file of the project if not specified
+	Flow       string // only show the executions of the flow
+	ErrorsOnly bool   // only show the failed executions
+	Full       bool   // show the complete inputs and outputs
+	Last       int    // only show the last executions
+}
+
+// traceRecord is a record of the trace file, written by the traced application when a flow or task is done
+type traceRecord struct {
+	Type         string          `json:"type"` // flow or task
+	Time         time.Time       `json:"time"`
+	Flow         string          `json:"flow"`
+	FlowID       string          `json:"flowId"`
+	ParentFlowID string          `json:"parentFlowId,omitempty"`
+	Task         string          `json:"task,omitempty"`
+	Ref          string          `json:"ref,omitempty"`
+	Status       string          `json:"status"`
+	DurationMs   *float64        `json:"durationMs,omitempty"`
+	Inputs       json.RawMessage `json:"inputs,omitempty"`
+	Outputs      json.RawMessage `json:"outputs,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// traceExecution is the execution of a flow, with the records of its tasks
+type traceExecution struct {
+	flowID string
+	flow   *traceRecord
+	tasks  []*traceRecord
+}
+
+func (e *traceExecution) failed() bool {
+	if e.flow != nil && e.flow.Status == "Failed" {
+		return true
+	}
+	for _, task := range e.tasks {
+		if task.Status == "Failed" {
+			return true
+		}
+	}
+	return false
+}
+
+// createTraceGoFile generates the trace service, which writes the execution of the flows and their tasks to the output
+func createTraceGoFile(project common.AppProject, output string) error {
+
+	if Verbose() {
+		fmt.Println("Enabling execution traces in application...")
+	}
+
+	return writeTemplateFile(filepath.Join(project.SrcDir(), fileTraceGo), tplTraceGoFile, &struct{ Output string }{strconv.Quote(output)})
+}
+
+func cleanupTraceGoFile(project common.AppProject) error {
+
+	traceSrcPath := filepath.Join(project.SrcDir(), fileTraceGo)
+
+	if _, err := os.Stat(traceSrcPath); err == nil {
+		if Verbose() {
+			fmt.Println("Removing execution traces")
+		}
+		return os.Remove(traceSrcPath)
+	}
+
+	return nil
+}
+
+// ViewTrace renders the traces written by a traced application, grouped by flow execution
+func ViewTrace(project common.AppProject, options TraceViewOptions) error {
+
+	file := options.File
+	if file == "" {
+		if project == nil {
+			return fmt.Errorf("trace file not specified")
+		}
+		file = filepath.Join(project.Dir(), DefaultTraceFile)
+	}
+
+	executions, err := readTraceFile(file)
+	if err != nil {
+		return err
+	}
+
+	var selected []*traceExecution
+	for _, execution := range executions {
+		if options.Flow != "" && traceFlowName(execution) != options.Flow {
+			continue
+		}
+		if options.ErrorsOnly && !execution.failed() {
+			continue
+		}
+		selected = append(selected, execution)
+	}
+	if options.Last > 0 && len(selected) > options.Last {
+		selected = selected[len(selected)-options.Last:]
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("No flow executions traced")
+		return nil
+	}
+
+	failed := 0
+	for _, execution := range selected {
+		if execution.failed() {
+			failed++
+		}
+		printTraceExecution(execution, options.Full)
+		fmt.Println()
+	}
+
+	fmt.Printf("%d flow execution(s), %d failed\n", len(selected), failed)
+
+	return nil
+}
+
+// readTraceFile reads the flow executions of the trace file in the order they started, the lines which aren't trace
+// records are skipped so the traces can be read from the output of an application tracing to stdout
+func readTraceFile(file string) ([]*traceExecution, error) {
+
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("trace file '%s' not found, build the application with --trace to trace its executions", file)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var executions []*traceExecution
+	byID := make(map[string]*traceExecution)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		record := &traceRecord{}
+		if json.Unmarshal([]byte(line), record) != nil || (record.Type != "flow" && record.Type != "task") || record.FlowID == "" {
+			continue
+		}
+
+		execution, exists := byID[record.FlowID]
+		if !exists {
+			execution = &traceExecution{flowID: record.FlowID}
+			byID[record.FlowID] = execution
+			executions = append(executions, execution)
+		}
+
+		if record.Type == "flow" {
+			execution.flow = record
+		} else {
+			execution.tasks = append(execution.tasks, record)
+		}
+	}
+
+	return executions, scanner.Err()
+}
+
+func traceFlowName(execution *traceExecution) string {
+	if execution.flow != nil {
+		return execution.flow.Flow
+	}
+	if len(execution.tasks) > 0 {
+		return execution.tasks[0].Flow
+	}
+	return ""
+}
+
+func printTraceExecution(execution *traceExecution, full bool) {
+
+	header := fmt.Sprintf("flow '%s' (%s)", traceFlowName(execution), execution.flowID)
+	if flow := execution.flow; flow != nil {
+		header += " " + flow.Status
+		if flow.DurationMs != nil {
+			header += " in " + formatTraceDuration(*flow.DurationMs)
+		}
+		header += " at " + flow.Time.Local().Format("2006-01-02 15:04:05.000")
+		if flow.ParentFlowID != "" {
+			header += ", subflow of " + flow.ParentFlowID
+		}
+	} else {
+		header += " running"
+	}
+	fmt.Println(header)
+
+	for _, task := range execution.tasks {
+		duration := "-"
+		if task.DurationMs != nil {
+			duration = formatTraceDuration(*task.DurationMs)
+		}
+		fmt.Printf("  %-10s %10s  %s\n", task.Status, duration, task.Task)
+		if len(task.Inputs) > 0 && string(task.Inputs) != "{}" {
+			fmt.Printf("      inputs : %s\n", formatTraceValue(task.Inputs, full))
+		}
+		if len(task.Outputs) > 0 && string(task.Outputs) != "{}" {
+			fmt.Printf("      outputs: %s\n", formatTraceValue(task.Outputs, full))
+		}
+		if task.Error != "" {
+			fmt.Printf("      error  : %s\n", task.Error)
+		}
+	}
+
+	if flow := execution.flow; flow != nil && flow.Error != "" {
+		fmt.Printf("  error: %s\n", flow.Error)
+	}
+}
+
+func formatTraceDuration(ms float64) string {
+	return (time.Duration(ms * float64(time.Millisecond))).Round(10 * time.Microsecond).String()
+}
+
+func formatTraceValue(value json.RawMessage, full bool) string {
+	s := string(value)
+	if !full && len(s) > traceValueLength {
+		s = s[:traceValueLength] + "..."
+	}
+	return s
+}
+
+var tplTraceGoFile = `// Do not change this file, it has been generated using flogo-cli
+// If you change it and rebuild the application your changes might get lost
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/project-flogo/core/engine"
+	"github.com/project-flogo/core/engine/event"
+	"github.com/project-flogo/core/support/log"
+)
+
+const (
+	traceListener  = "flogo-cli-trace"
+	traceFlowEvent = "flowevent"
+	traceTaskEvent = "taskevent"
+)
+
+func init() {
+	engine.LifeCycle(&traceService{})
+}
+
+// flowTaskEvent is the event posted by the flow action when the status of a task changes
+type flowTaskEvent interface {
+	ActivityRef() string
+	FlowName() string
+	FlowID() string
+	TaskName() string
+	TaskInstanceId() string
+	Time() time.Time
+	TaskInput() map[string]interface{}
+	TaskOutput() map[string]interface{}
+	TaskError() error
+}
+
+// flowInstanceEvent is the event posted by the flow action when the status of a flow instance changes
+type flowInstanceEvent interface {
+	FlowName() string
+	FlowID() string
+	ParentFlowID() string
+	Time() time.Time
+	FlowError() error
+}
+
+// traceService writes the executions of the flows and their tasks in JSONL, from the events of the flow action
+type traceService struct {
+	mutex   sync.Mutex
+	out     io.Writer
+	file    *os.File
+	started map[string]time.Time
+}
+
+func (s *traceService) Start() error {
+
+	output := os.Getenv("FLOGO_TRACE")
+	if output == "" {
+		output = {{.Output}}
+	}
+	if output == "off" {
+		return nil
+	}
+
+	s.started = make(map[string]time.Time)
+
+	if output == "stdout" {
+		s.out = os.Stdout
+	} else {
+		f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("unable to open trace file: %v", err)
+		}
+		s.file = f
+		s.out = f
+	}
+
+	err := event.RegisterListener(traceListener, s, []string{traceFlowEvent, traceTaskEvent})
+	if err != nil {
+		return err
+	}
+
+	log.RootLogger().Infof("Writing execution traces to %s", output)
+
+	return nil
+}
+
+func (s *traceService) Stop() error {
+
+	if s.out == nil {
+		return nil
+	}
+
+	event.UnRegisterListener(traceListener, []string{traceFlowEvent, traceTaskEvent})
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.out = nil
+	if s.file != nil {
+		return s.file.Close()
+	}
+
+	return nil
+}
+
+func (s *traceService) HandleEvent(ctx *event.Context) error {
+
+	switch evt := ctx.GetEvent().(type) {
+	case flowTaskEvent:
+		key := evt.FlowID() + "/" + evt.TaskInstanceId()
+		record := map[string]interface{}{
+			"type":   "task",
+			"flow":   evt.FlowName(),
+			"flowId": evt.FlowID(),
+			"task":   evt.TaskName(),
+			"ref":    evt.ActivityRef(),
+		}
+		switch status := eventStatus(evt, "TaskStatus"); status {
+		case "Started":
+			s.start(key, evt.Time())
+		case "Completed", "Failed", "Skipped":
+			record["inputs"] = evt.TaskInput()
+			if status == "Completed" {
+				record["outputs"] = evt.TaskOutput()
+			}
+			if err := evt.TaskError(); err != nil {
+				record["error"] = err.Error()
+			}
+			s.write(key, status, evt.Time(), record)
+		}
+	case flowInstanceEvent:
+		key := evt.FlowID()
+		record := map[string]interface{}{
+			"type":   "flow",
+			"flow":   evt.FlowName(),
+			"flowId": evt.FlowID(),
+		}
+		if parent := evt.ParentFlowID(); parent != "" {
+			record["parentFlowId"] = parent
+		}
+		switch status := eventStatus(evt, "FlowStatus"); status {
+		case "Started":
+			s.start(key, evt.Time())
+		case "Completed", "Failed", "Cancelled":
+			if err := evt.FlowError(); err != nil {
+				record["error"] = err.Error()
+			}
+			s.write(key, status, evt.Time(), record)
+		}
+	}
+
+	return nil
+}
+
+func (s *traceService) start(key string, t time.Time) {
+	s.mutex.Lock()
+	s.started[key] = t
+	s.mutex.Unlock()
+}
+
+func (s *traceService) write(key, status string, t time.Time, record map[string]interface{}) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.out == nil {
+		return
+	}
+
+	record["time"] = t
+	record["status"] = status
+	if started, ok := s.started[key]; ok {
+		record["durationMs"] = float64(t.Sub(started)) / float64(time.Millisecond)
+		delete(s.started, key)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		// the inputs or outputs can't be encoded, ex. they contain a channel
+		for _, name := range []string{"inputs", "outputs"} {
+			if val, ok := record[name]; ok {
+				record[name] = fmt.Sprintf("%v", val)
+			}
+		}
+		line, err = json.Marshal(record)
+		if err != nil {
+			return
+		}
+	}
+
+	_, _ = s.out.Write(append(line, '\n'))
+}
+
+// eventStatus gets the status of the event, the status type is declared by the flow action
+func eventStatus(evt interface{}, method string) string {
+
+	m := reflect.ValueOf(evt).MethodByName(method)
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return ""
+	}
+
+	return fmt.Sprint(m.Call(nil)[0].Interface())
+}
+`
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTraceLog = `2026-10-15T10:00:01.000Z	INFO	[flogo.engine] -	Engine Started
+{"type":"task","time":"2026-10-15T10:00:01.010Z","flow":"orders","flowId":"a1","task":"log","ref":"#log","status":"Completed","durationMs":1.5,"inputs":{"message":"order received"},"outputs":{}}
+{"type":"task","time":"2026-10-15T10:00:01.020Z","flow":"orders","flowId":"a1","task":"call","ref":"#rest","status":"Failed","durationMs":8,"inputs":{"uri":"http://localhost:9999"},"error":"connection refused"}
+{"type":"flow","time":"2026-10-15T10:00:01.021Z","flow":"orders","flowId":"a1","status":"Failed","durationMs":11,"error":"connection refused"}
+{"type":"task","time":"2026-10-15T10:00:02.010Z","flow":"health","flowId":"b2","task":"reply","ref":"#actreturn","status":"Completed","durationMs":0.2,"inputs":{"code":200},"outputs":{}}
+{"type":"flow","time":"2026-10-15T10:00:02.011Z","flow":"health","flowId":"b2","status":"Completed","durationMs":0.5}
+{"type":"metric"}
+`
+
+func TestReadTraceFile(t *testing.T) {
+	t.Log("Testing reading of the flow executions of a trace file")
+
+	tempDir, err := ioutil.TempDir("", "test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, DefaultTraceFile)
+	assert.Nil(t, ioutil.WriteFile(file, []byte(testTraceLog), 0644))
+
+	executions, err := readTraceFile(file)
+	assert.Nil(t, err)
+	assert.Len(t, executions, 2)
+
+	assert.Equal(t, "a1", executions[0].flowID)
+	assert.Equal(t, "orders", traceFlowName(executions[0]))
+	assert.Len(t, executions[0].tasks, 2)
+	assert.Equal(t, "connection refused", executions[0].tasks[1].Error)
+	assert.True(t, executions[0].failed())
+
+	assert.Equal(t, "health", traceFlowName(executions[1]))
+	assert.False(t, executions[1].failed())
+
+	_, err = readTraceFile(filepath.Join(tempDir, "missing.jsonl"))
+	assert.NotNil(t, err)
+}
+
+func TestFormatTraceValue(t *testing.T) {
+	t.Log("Testing formatting of the inputs and outputs of a trace")
+
+	value := []byte(`{"message":"` + strings.Repeat("x", 200) + `"}`)
+	assert.Len(t, formatTraceValue(value, false), traceValueLength+3)
+	assert.Equal(t, string(value), formatTraceValue(value, true))
+	assert.Equal(t, "1.5ms", formatTraceDuration(1.5))
+}
+
+func TestTraceGoFileTemplate(t *testing.T) {
+	t.Log("Testing rendering of the trace service of the application")
+
+	var buf bytes.Buffer
+	RenderTemplate(&buf, tplTraceGoFile, &struct{ Output string }{`"trace.jsonl"`})
+
+	assert.Contains(t, buf.String(), `output = "trace.jsonl"`)
+	assert.Contains(t, buf.String(), "engine.LifeCycle(&traceService{})")
+}
+
+func TestCreateTraceGoFile(t *testing.T) {
+	t.Log("Testing generation of the trace service of the application")
+
+	tempDir, err := ioutil.TempDir("", "flogo-trace")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	project := NewAppProject(tempDir)
+	err = os.MkdirAll(project.SrcDir(), 0755)
+	assert.Nil(t, err)
+
+	// a source truncated by a previous build is replaced, no temporary file is left in the sources
+	err = ioutil.WriteFile(filepath.Join(project.SrcDir(), fileTraceGo), []byte("package main\n\nimport ("), 0644)
+	assert.Nil(t, err)
+
+	err = createTraceGoFile(project, "trace.jsonl")
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.SrcDir(), fileTraceGo))
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), `output = "trace.jsonl"`)
+
+	files, err := ioutil.ReadDir(project.SrcDir())
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	// the engine has no notion of a disabled trigger or handler, so they are
+	// moved to sections which it ignores
+	sectionDisabledTriggers = "disabledTriggers"
+	sectionDisabledHandlers = "disabledHandlers"
+)
+
+// SetTriggerEnabled enables or disables a trigger, or one of its handlers if handler is specified
+func SetTriggerEnabled(project common.AppProject, triggerId, handler string, enabled bool) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	disabled, _ := appObj[sectionDisabledTriggers].([]interface{})
+
+	// the trigger or handler is moved in place, leaving the rest of the descriptor untouched
+	var edit func(text string) (string, error)
+
+	if handler != "" {
+		trgPath := "$.triggers"
+		trg, idx := findById(triggers, triggerId)
+		if trg == nil {
+			trgPath = "$." + sectionDisabledTriggers
+			trg, idx = findById(disabled, triggerId)
+		}
+		if trg == nil {
+			return fmt.Errorf("trigger '%s' not found", triggerId)
+		}
+
+		edit, err = setHandlerEnabled(fmt.Sprintf("%s[%d]", trgPath, idx), trg, handler, enabled)
+		if err != nil {
+			return err
+		}
+	} else {
+		from, to := triggers, disabled
+		fromPath, toPath := "$.triggers", "$."+sectionDisabledTriggers
+		if enabled {
+			from, to = disabled, triggers
+			fromPath, toPath = toPath, fromPath
+		}
+
+		trg, idx := findById(from, triggerId)
+		if trg == nil {
+			if t, _ := findById(to, triggerId); t != nil {
+				return fmt.Errorf("trigger '%s' is already %s", triggerId, enabledStr(enabled))
+			}
+			return fmt.Errorf("trigger '%s' not found", triggerId)
+		}
+
+		edit = func(text string) (string, error) {
+			return moveJSONValue(text, fromPath, toPath, idx, enabled)
+		}
+	}
+
+	err = editAppDescriptor(project, edit)
+	if err != nil {
+		return err
+	}
+
+	if handler != "" {
+		fmt.Printf("Handler '%s' of trigger '%s' %s\n", handler, triggerId, enabledStr(enabled))
+	} else {
+		fmt.Printf("Trigger '%s' %s\n", triggerId, enabledStr(enabled))
+	}
+
+	return nil
+}
+
+// ListTriggers lists the triggers and handlers of the application and whether they are enabled
+func ListTriggers(project common.AppProject) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	printTrigger := func(item interface{}, enabled bool) {
+		trg, ok := item.(map[string]interface{})
+		if !ok {
+			return
+		}
+		id, _ := trg["id"].(string)
+		ref, _ := trg["ref"].(string)
+		fmt.Printf("Trigger: %s (%s)\n", id, enabledStr(enabled))
+		fmt.Println("  Ref      : " + ref)
+
+		handlers, _ := trg["handlers"].([]interface{})
+		disabledHandlers, _ := trg[sectionDisabledHandlers].([]interface{})
+		for i, h := range handlers {
+			fmt.Printf("  Handler  : %s (%s)\n", handlerName(h, i), enabledStr(enabled))
+		}
+		for i, h := range disabledHandlers {
+			fmt.Printf("  Handler  : %s (%s)\n", handlerName(h, i), enabledStr(false))
+		}
+	}
+
+	triggers, _ := appObj["triggers"].([]interface{})
+	for _, trg := range triggers {
+		printTrigger(trg, true)
+	}
+
+	disabled, _ := appObj[sectionDisabledTriggers].([]interface{})
+	for _, trg := range disabled {
+		printTrigger(trg, false)
+	}
+
+	return nil
+}
+
+// setHandlerEnabled gets the edit of the app descriptor enabling or disabling the handler of the trigger located at
+// the path, which moves it between the handlers and the disabled handlers of the trigger
+func setHandlerEnabled(trgPath string, trg map[string]interface{}, handler string, enabled bool) (func(text string) (string, error), error) {
+
+	handlers, _ := trg["handlers"].([]interface{})
+	disabled, _ := trg[sectionDisabledHandlers].([]interface{})
+
+	from, to := handlers, disabled
+	fromPath, toPath := trgPath+".handlers", trgPath+"."+sectionDisabledHandlers
+	if enabled {
+		from, to = disabled, handlers
+		fromPath, toPath = toPath, fromPath
+	}
+
+	idx := findHandler(from, handler)
+	if idx < 0 {
+		if findHandler(to, handler) >= 0 {
+			return nil, fmt.Errorf("handler '%s' is already %s", handler, enabledStr(enabled))
+		}
+		return nil, fmt.Errorf("handler '%s' not found", handler)
+	}
+
+	return func(text string) (string, error) {
+		// the disabled handlers are removed once they are all enabled
+		return moveJSONValue(text, fromPath, toPath, idx, enabled)
+	}, nil
+}
+
+// findHandler finds a handler by name, or by its index in the list of handlers
+func findHandler(handlers []interface{}, handler string) int {
+
+	for i, h := range handlers {
+		if hMap, ok := h.(map[string]interface{}); ok && hMap["name"] == handler {
+			return i
+		}
+	}
+
+	if idx, err := strconv.Atoi(handler); err == nil && idx >= 0 && idx < len(handlers) {
+		return idx
+	}
+
+	return -1
+}
+
+func handlerName(handler interface{}, idx int) string {
+	if hMap, ok := handler.(map[string]interface{}); ok {
+		if name, ok := hMap["name"].(string); ok && name != "" {
+			return name
+		}
+	}
+	return strconv.Itoa(idx)
+}
+
+func findById(items []interface{}, id string) (map[string]interface{}, int) {
+	for i, item := range items {
+		if itemMap, ok := item.(map[string]interface{}); ok && itemMap["id"] == id {
+			return itemMap, i
+		}
+	}
+	return nil, -1
+}
+
+func enabledStr(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+var propertyRefPattern = regexp.MustCompile(`^=?\$property\[([^\]]+)\]$`)
+
+// validateTriggerConflicts detects the settings of the enabled triggers which conflict at startup: duplicate trigger
+// ids and handler names, triggers listening on the same port and handlers with overlapping paths
+func validateTriggerConflicts(ctx *validationContext) error {
+
+	triggers, _ := ctx.appObj["triggers"].([]interface{})
+
+	props := make(map[string]interface{})
+	for _, prop := range getAppProperties(ctx.appObj) {
+		props[prop.Name] = prop.Value
+	}
+
+	ids := make(map[string]int)
+	ports := make(map[int]string)
+
+	for i, trg := range triggers {
+		trgMap, ok := trg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("$.triggers[%d]", i)
+		id, _ := trgMap["id"].(string)
+
+		if other, exists := ids[id]; exists && id != "" {
+			ctx.addError(path+".id", "duplicate trigger id '%s', also used by $.triggers[%d]", id, other)
+		} else {
+			ids[id] = i
+		}
+
+		settings, _ := trgMap["settings"].(map[string]interface{})
+		if port, ok := triggerPort(settings["port"], props); ok {
+			if other, exists := ports[port]; exists {
+				ctx.addError(path+".settings.port", "port %d is also used by trigger '%s', the trigger will fail to listen on it", port, other)
+			} else {
+				ports[port] = id
+			}
+		}
+
+		handlers, _ := trgMap["handlers"].([]interface{})
+		validateHandlerConflicts(ctx, path, handlers)
+	}
+
+	return nil
+}
+
+// validateHandlerConflicts detects the handlers of a trigger with the same name or overlapping paths
+func validateHandlerConflicts(ctx *validationContext, trgPath string, handlers []interface{}) {
+
+	names := make(map[string]int)
+	routes := make(map[string][]int) // the handlers by method
+
+	for i, handler := range handlers {
+		hMap, ok := handler.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("%s.handlers[%d]", trgPath, i)
+
+		if name, _ := hMap["name"].(string); name != "" {
+			if other, exists := names[name]; exists {
+				ctx.addError(path+".name", "duplicate handler name '%s', also used by handlers[%d]", name, other)
+			} else {
+				names[name] = i
+			}
+		}
+
+		settings, _ := hMap["settings"].(map[string]interface{})
+		method, _ := settings["method"].(string)
+		route, _ := settings["path"].(string)
+		if method == "" || route == "" || isExpression(route) {
+			continue
+		}
+		method = strings.ToUpper(method)
+
+		for _, other := range routes[method] {
+			otherSettings, _ := handlers[other].(map[string]interface{})["settings"].(map[string]interface{})
+			otherRoute, _ := otherSettings["path"].(string)
+
+			switch routesOverlap(route, otherRoute) {
+			case routeIdentical:
+				ctx.addError(path+".settings.path", "%s %s is also handled by handlers[%d]", method, route, other)
+			case routeAmbiguous:
+				ctx.addWarning(path+".settings.path", "%s %s overlaps with %s of handlers[%d], a path parameter conflicts with a "+
+					"static segment at the same position, which the router may reject", method, route, otherRoute, other)
+			}
+		}
+		routes[method] = append(routes[method], i)
+	}
+}
+
+const (
+	routeDistinct = iota
+	routeIdentical
+	routeAmbiguous
+)
+
+// routesOverlap compares the paths of two handlers, the paths are identical if they only differ by the names of their
+// parameters, ex. /users/:id and /users/{name}, and ambiguous if a parameter and a static segment are at the same
+// position, ex. /users/:id and /users/me
+func routesOverlap(route1, route2 string) int {
+
+	segs1 := strings.Split(strings.Trim(route1, "/"), "/")
+	segs2 := strings.Split(strings.Trim(route2, "/"), "/")
+	if len(segs1) != len(segs2) {
+		return routeDistinct
+	}
+
+	result := routeIdentical
+	for i := range segs1 {
+		param1, param2 := isRouteParam(segs1[i]), isRouteParam(segs2[i])
+		switch {
+		case param1 && param2:
+		case param1 || param2:
+			result = routeAmbiguous
+		case segs1[i] != segs2[i]:
+			return routeDistinct
+		}
+	}
+
+	return result
+}
+
+func isRouteParam(segment string) bool {
+	return strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") ||
+		(strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"))
+}
+
+// triggerPort gets the port of a trigger, resolving a reference to an app property
+func triggerPort(val interface{}, props map[string]interface{}) (int, bool) {
+
+	if s, ok := val.(string); ok {
+		if m := propertyRefPattern.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+			val = props[m[1]]
+		}
+	}
+
+	switch t := val.(type) {
+	case float64:
+		return int(t), t > 0
+	case string:
+		port, err := strconv.Atoi(strings.TrimSpace(t))
+		return port, err == nil && port > 0
+	}
+
+	return 0, false
+}
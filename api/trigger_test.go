@@ -0,0 +1,78 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const triggersTestDescriptor = `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "1.0.0",
+  "triggers": [
+    {
+      "id": "rest",
+      "ref": "#rest",
+      "settings": {
+        "port": 8080
+      },
+      "handlers": [
+        {
+          "name": "orders",
+          "settings": {
+            "method": "GET",
+            "path": "/orders"
+          },
+          "action": {
+            "ref": "#flow",
+            "settings": {
+              "flowURI": "res://flow:orders"
+            }
+          }
+        }
+      ]
+    }
+  ],
+  "resources": []
+}`
+
+func TestSetTriggerEnabled(t *testing.T) {
+	t.Log("Testing that disabling then enabling a handler or a trigger restores the app descriptor")
+
+	tempDir, err := ioutil.TempDir("", "flogo-trigger")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	descriptorFile := filepath.Join(tempDir, fileFlogoJson)
+	err = ioutil.WriteFile(descriptorFile, []byte(triggersTestDescriptor), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(tempDir)
+
+	readDescriptor := func() string {
+		buf, err := ioutil.ReadFile(descriptorFile)
+		assert.Nil(t, err)
+		return string(buf)
+	}
+
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "orders", false))
+	assert.Contains(t, readDescriptor(), "\"handlers\": [],\n      \"disabledHandlers\": [\n        {\n          \"name\": \"orders\",")
+	assert.NotNil(t, SetTriggerEnabled(project, "rest", "orders", false))
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "orders", true))
+	assert.Equal(t, triggersTestDescriptor, readDescriptor())
+
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "", false))
+	assert.Contains(t, readDescriptor(), "\"triggers\": [],")
+	// the handlers of a disabled trigger can be disabled as well
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "0", false))
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "orders", true))
+	assert.Nil(t, SetTriggerEnabled(project, "rest", "", true))
+	assert.Equal(t, triggersTestDescriptor, readDescriptor())
+
+	assert.NotNil(t, SetTriggerEnabled(project, "rest", "", true))
+	assert.NotNil(t, SetTriggerEnabled(project, "timer", "", false))
+	assert.NotNil(t, SetTriggerEnabled(project, "rest", "payments", false))
+}
@@ -0,0 +1,325 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	uiSectionTriggers = iota
+	uiSectionFlows
+	uiSectionImports
+	uiSectionBuilds
+)
+
+const (
+	ansiClear   = "\x1b[H\x1b[2J"
+	ansiReverse = "\x1b[7m"
+	ansiBold    = "\x1b[1m"
+	ansiReset   = "\x1b[0m"
+)
+
+var uiSectionTitles = []string{"Triggers", "Flows", "Imports", "Builds"}
+
+type projectUI struct {
+	project  common.AppProject
+	input    *bufio.Reader
+	items    [][]string
+	current  int
+	selected []int
+	status   string
+}
+
+// RunUI runs a terminal UI showing the project's triggers, flows, imports and recent builds
+func RunUI(ctx context.Context, project common.AppProject) error {
+
+	ui := &projectUI{project: project, input: bufio.NewReader(os.Stdin), selected: make([]int, len(uiSectionTitles))}
+
+	err := ui.load()
+	if err != nil {
+		return err
+	}
+
+	restore, err := util.MakeRawTerminal()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		restore()
+		fmt.Print(ansiClear)
+	}()
+
+	for {
+		ui.render()
+
+		key, err := ui.readKey()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "q", "\x03":
+			return nil
+		case "\t", "right", "l":
+			ui.current = (ui.current + 1) % len(uiSectionTitles)
+		case "left", "h":
+			ui.current = (ui.current + len(uiSectionTitles) - 1) % len(uiSectionTitles)
+		case "1", "2", "3", "4":
+			ui.current, _ = strconv.Atoi(key)
+			ui.current--
+		case "up", "k":
+			if ui.selected[ui.current] > 0 {
+				ui.selected[ui.current]--
+			}
+		case "down", "j":
+			if ui.selected[ui.current] < len(ui.items[ui.current])-1 {
+				ui.selected[ui.current]++
+			}
+		case "r":
+			ui.status = ""
+			err = ui.load()
+			if err != nil {
+				ui.status = err.Error()
+			}
+		case "i":
+			ui.runAction(restore, "Install", func() error {
+				fmt.Print("Contribution/dependency to install: ")
+				pkg, _ := ui.input.ReadString('\n')
+				pkg = strings.TrimSpace(pkg)
+				if pkg == "" {
+					return nil
+				}
+				return InstallPackage(ctx, project, pkg)
+			})
+		case "u":
+			pkg := ui.selectedImport()
+			if pkg == "" {
+				ui.status = "select an import to upgrade in the Imports section"
+				continue
+			}
+			ui.runAction(restore, "Upgrade "+pkg, func() error {
+				return UpdatePkg(ctx, project, pkg)
+			})
+		case "b":
+			ui.runAction(restore, "Build", func() error {
+				return BuildProject(ctx, project, common.BuildOptions{})
+			})
+		case "v":
+			ui.runAction(restore, "Validate", func() error {
+				issues, err := ValidateProject(project, ValidateOptions{})
+				if err != nil {
+					return err
+				}
+				if len(issues) == 0 {
+					fmt.Println("No issues found")
+				}
+				return PrintValidationIssues(issues, false)
+			})
+		}
+	}
+}
+
+// load loads the items of all the sections from the project
+func (ui *projectUI) load() error {
+
+	appObj, err := readAppDescriptorObj(ui.project)
+	if err != nil {
+		return err
+	}
+
+	items := make([][]string, len(uiSectionTitles))
+
+	addTriggers := func(section string, enabled bool) {
+		triggers, _ := appObj[section].([]interface{})
+		for _, trg := range triggers {
+			trgMap, ok := trg.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			handlers, _ := trgMap["handlers"].([]interface{})
+			items[uiSectionTriggers] = append(items[uiSectionTriggers], fmt.Sprintf("%-25v %-9s %d handler(s)  %v",
+				trgMap["id"], enabledStr(enabled), len(handlers), trgMap["ref"]))
+		}
+	}
+	addTriggers("triggers", true)
+	addTriggers(sectionDisabledTriggers, false)
+
+	flows := getFlowVersions(appObj)
+	var flowNames []string
+	for name := range flows {
+		flowNames = append(flowNames, name)
+	}
+	sort.Strings(flowNames)
+	for _, name := range flowNames {
+		var versions []string
+		for _, v := range flows[name].Versions {
+			vStr := "v" + strconv.Itoa(v)
+			if containsInt(flows[name].Active, v) {
+				vStr += "*"
+			}
+			versions = append(versions, vStr)
+		}
+		items[uiSectionFlows] = append(items[uiSectionFlows], fmt.Sprintf("%-35s %s", name, strings.Join(versions, " ")))
+	}
+
+	imports, _ := appObj["imports"].([]interface{})
+	for _, imp := range imports {
+		if strImp, ok := imp.(string); ok {
+			items[uiSectionImports] = append(items[uiSectionImports], strImp)
+		}
+	}
+
+	history, err := GetBuildHistory(ui.project)
+	if err != nil {
+		return err
+	}
+	for _, record := range history {
+		result := "ok"
+		if !record.Success {
+			result = "FAILED: " + record.Error
+		}
+		items[uiSectionBuilds] = append(items[uiSectionBuilds], fmt.Sprintf("%s  %-8s %s",
+			util.FormatDateTime(record.Time), record.Duration, result))
+	}
+
+	ui.items = items
+	for i := range ui.selected {
+		if ui.selected[i] >= len(items[i]) {
+			ui.selected[i] = 0
+		}
+	}
+
+	return nil
+}
+
+func (ui *projectUI) render() {
+
+	rows, cols := util.GetTerminalSize()
+
+	var b strings.Builder
+	b.WriteString(ansiClear)
+	b.WriteString(ansiBold + "flogo ui - " + ui.project.Name() + ansiReset + "\r\n\r\n")
+
+	for i, title := range uiSectionTitles {
+		label := fmt.Sprintf(" %d %s (%d) ", i+1, title, len(ui.items[i]))
+		if i == ui.current {
+			label = ansiReverse + label + ansiReset
+		}
+		b.WriteString(label + " ")
+	}
+	b.WriteString("\r\n\r\n")
+
+	// keep the selected item visible
+	items := ui.items[ui.current]
+	visible := rows - 8
+	if visible < 1 {
+		visible = 1
+	}
+	first := 0
+	if ui.selected[ui.current] >= visible {
+		first = ui.selected[ui.current] - visible + 1
+	}
+
+	if len(items) == 0 {
+		b.WriteString("  (none)\r\n")
+	}
+	for i := first; i < len(items) && i < first+visible; i++ {
+		line := "  " + items[i]
+		if len(line) > cols {
+			line = line[:cols]
+		}
+		if i == ui.selected[ui.current] {
+			line = ansiReverse + line + ansiReset
+		}
+		b.WriteString(line + "\r\n")
+	}
+
+	b.WriteString("\r\n")
+	if ui.status != "" {
+		b.WriteString(ui.status + "\r\n")
+	}
+	b.WriteString("[tab] section  [j/k] move  [i]nstall  [u]pgrade  [b]uild  [v]alidate  [r]efresh  [q]uit")
+
+	fmt.Print(b.String())
+}
+
+// readKey reads a key press, arrow keys are returned as up, down, left and right
+func (ui *projectUI) readKey() (string, error) {
+
+	c, err := ui.input.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	if c == 0x1b && ui.input.Buffered() >= 2 {
+		seq := make([]byte, 2)
+		_, _ = ui.input.Read(seq)
+		if seq[0] == '[' {
+			switch seq[1] {
+			case 'A':
+				return "up", nil
+			case 'B':
+				return "down", nil
+			case 'C':
+				return "right", nil
+			case 'D':
+				return "left", nil
+			}
+		}
+		return "", nil
+	}
+
+	return string(c), nil
+}
+
+// runAction runs the action with the terminal restored, so that its output can be seen
+func (ui *projectUI) runAction(restore func(), name string, action func() error) {
+
+	restore()
+	fmt.Print(ansiClear)
+	fmt.Println(ansiBold + name + ansiReset)
+	fmt.Println()
+
+	err := action()
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		ui.status = name + " failed"
+	} else {
+		ui.status = name + " completed"
+	}
+
+	fmt.Print("\nPress enter to return")
+	_, _ = ui.input.ReadString('\n')
+
+	if loadErr := ui.load(); loadErr != nil {
+		ui.status = loadErr.Error()
+	}
+
+	// the original restore function is still valid, it restores the terminal to the state it had before the ui
+	_, rawErr := util.MakeRawTerminal()
+	if rawErr != nil {
+		ui.status = rawErr.Error()
+	}
+}
+
+func (ui *projectUI) selectedImport() string {
+
+	if ui.current != uiSectionImports || len(ui.items[uiSectionImports]) == 0 {
+		return ""
+	}
+
+	imp, err := util.ParseImport(ui.items[uiSectionImports][ui.selected[uiSectionImports]])
+	if err != nil {
+		return ""
+	}
+
+	return imp.GoImportPath()
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureStdout gets what the function prints to stdout
+func captureStdout(t *testing.T, f func()) string {
+
+	r, w, err := os.Pipe()
+	assert.Nil(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	out := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		out <- buf.String()
+	}()
+
+	f()
+	_ = w.Close()
+
+	return <-out
+}
+
+func newTestProjectUI(t *testing.T, dir string) *projectUI {
+
+	err := ioutil.WriteFile(filepath.Join(dir, fileFlogoJson), []byte(`{
+  "name": "myApp",
+  "imports": ["github.com/project-flogo/contrib/trigger/rest", "timer github.com/project-flogo/contrib/trigger/timer"],
+  "triggers": [{"id": "rest", "ref": "#rest", "handlers": [{"action": {"ref": "#flow", "settings": {"flowURI": "res://flow:main@v2"}}}]}],
+  "disabledTriggers": [{"id": "timer", "ref": "#timer", "handlers": []}],
+  "resources": [{"id": "flow:main", "data": {}}, {"id": "flow:main@v2", "data": {}}]
+}`), 0644)
+	assert.Nil(t, err)
+
+	err = os.MkdirAll(filepath.Join(dir, dirProjectFlogo), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, dirProjectFlogo, fileBuildHistory), []byte(`[
+  {"time": "2026-01-02T10:00:00Z", "duration": "12s", "success": false, "error": "compilation failed"},
+  {"time": "2026-01-01T10:00:00Z", "duration": "10s", "success": true}
+]`), 0644)
+	assert.Nil(t, err)
+
+	return &projectUI{project: NewAppProject(dir), selected: make([]int, len(uiSectionTitles))}
+}
+
+func TestUILoad(t *testing.T) {
+	t.Log("Testing the sections of the terminal UI")
+
+	tempDir, err := ioutil.TempDir("", "flogo-ui")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ui := newTestProjectUI(t, tempDir)
+	ui.selected[uiSectionImports] = 5
+
+	err = ui.load()
+	assert.Nil(t, err)
+
+	triggers := ui.items[uiSectionTriggers]
+	assert.Len(t, triggers, 2)
+	assert.True(t, strings.HasPrefix(triggers[0], "rest "))
+	assert.Contains(t, triggers[0], "1 handler(s)")
+	assert.True(t, strings.HasPrefix(triggers[1], "timer "))
+	assert.Contains(t, triggers[1], enabledStr(false))
+
+	// the active versions of the flows are marked
+	assert.Len(t, ui.items[uiSectionFlows], 1)
+	assert.True(t, strings.HasSuffix(ui.items[uiSectionFlows][0], "v1 v2*"))
+
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/trigger/rest", "timer github.com/project-flogo/contrib/trigger/timer"}, ui.items[uiSectionImports])
+
+	builds := ui.items[uiSectionBuilds]
+	assert.Len(t, builds, 2)
+	assert.Contains(t, builds[0], "FAILED: compilation failed")
+	assert.True(t, strings.HasSuffix(builds[1], " ok"))
+
+	// a selection out of range is reset
+	assert.Equal(t, 0, ui.selected[uiSectionImports])
+}
+
+func TestUISelectedImport(t *testing.T) {
+	t.Log("Testing the import selected in the terminal UI")
+
+	tempDir, err := ioutil.TempDir("", "flogo-ui")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ui := newTestProjectUI(t, tempDir)
+	err = ui.load()
+	assert.Nil(t, err)
+
+	// only an import of the imports section can be upgraded
+	assert.Equal(t, "", ui.selectedImport())
+
+	ui.current = uiSectionImports
+	ui.selected[uiSectionImports] = 1
+	assert.Equal(t, "github.com/project-flogo/contrib/trigger/timer", ui.selectedImport())
+}
+
+func TestUIReadKey(t *testing.T) {
+	t.Log("Testing the keys read by the terminal UI")
+
+	ui := &projectUI{input: bufio.NewReader(strings.NewReader("q\x1b[A\x1b[B\x1b[C\x1b[D\t"))}
+
+	var keys []string
+	for {
+		key, err := ui.readKey()
+		if err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		keys = append(keys, key)
+	}
+
+	assert.Equal(t, []string{"q", "up", "down", "right", "left", "\t"}, keys)
+}
+
+func TestUIRender(t *testing.T) {
+	t.Log("Testing the rendering of the terminal UI")
+
+	tempDir, err := ioutil.TempDir("", "flogo-ui")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ui := newTestProjectUI(t, tempDir)
+	err = ui.load()
+	assert.Nil(t, err)
+
+	ui.current = uiSectionBuilds
+	ui.selected[uiSectionBuilds] = 1
+	ui.status = "Build completed"
+
+	out := captureStdout(t, ui.render)
+
+	assert.True(t, strings.HasPrefix(out, ansiClear))
+	assert.Contains(t, out, ansiReverse+" 4 Builds (2) "+ansiReset)
+	assert.Contains(t, out, " 1 Triggers (2) ")
+	assert.Contains(t, out, ansiReverse+"  "+ui.items[uiSectionBuilds][1])
+	assert.Contains(t, out, "Build completed\r\n")
+
+	// an empty section
+	err = ioutil.WriteFile(filepath.Join(tempDir, dirProjectFlogo, fileBuildHistory), []byte(`[]`), 0644)
+	assert.Nil(t, err)
+	err = ui.load()
+	assert.Nil(t, err)
+
+	out = captureStdout(t, ui.render)
+	assert.Contains(t, out, "  (none)\r\n")
+}
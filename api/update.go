@@ -1,19 +1,24 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 
 	"github.com/project-flogo/cli/common"
-	"github.com/project-flogo/cli/util"
 )
 
-func UpdatePkg(project common.AppProject, pkg string) error {
+func UpdatePkg(ctx context.Context, project common.AppProject, pkg string) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if Verbose() {
 		fmt.Printf("Updating Package: %s \n", pkg)
 	}
 
-	err := util.ExecCmd(exec.Command("go", "get", "-u", pkg), project.SrcDir())
+	err = project.DepManager().Get(ctx, "-u", pkg)
 	return err
 }
@@ -0,0 +1,368 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+var majorVersionSuffix = regexp.MustCompile(`/v([0-9]+)$`)
+
+// UpgradeOptions are the options used to upgrade the imported contributions
+type UpgradeOptions struct {
+	Imports []string // the imports to upgrade, by import path, module or alias, all of them by default
+	Major   bool     // also upgrade to a new major version, whose module path differs
+	DryRun  bool     // only print the upgrades
+}
+
+// contribUpgrade is the upgrade of a module providing imports of the application
+type contribUpgrade struct {
+	Module   string
+	From     string
+	ToModule string // differs from the module for a new major version
+	To       string
+	Imports  []util.Import
+}
+
+// UpgradeContribs upgrades the modules of the imported contributions to their latest version available from the module
+// proxy, the imports of flogo.json and src/imports.go are updated using the same machinery as 'flogo install'
+func UpgradeContribs(ctx context.Context, project common.AppProject, options UpgradeOptions) error {
+
+	unlock, err := lockProject(project)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var appImports []string
+	_, err = readAppDescriptorValue(project, "$.imports", &appImports)
+	if err != nil {
+		return err
+	}
+	imports, err := util.ParseImports(appImports)
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectUpgradedImports(imports, options.Imports)
+	if err != nil {
+		return err
+	}
+
+	upgrades, err := findContribUpgrades(ctx, project, selected, options.Major)
+	if err != nil {
+		return err
+	}
+
+	pins, err := projectPins(project)
+	if err != nil {
+		return err
+	}
+	upgrades = skipPinnedUpgrades(upgrades, pins, time.Now())
+
+	if len(upgrades) == 0 {
+		fmt.Println("All contributions are up to date")
+		return nil
+	}
+
+	fmt.Printf("%-60s %-20s %s\n", "CONTRIBUTION", "INSTALLED", "UPGRADE")
+	for _, upgrade := range upgrades {
+		to := upgrade.To
+		if upgrade.ToModule != upgrade.Module {
+			to = upgrade.ToModule + " " + upgrade.To
+		}
+		fmt.Printf("%-60s %-20s %s\n", upgrade.Module, upgrade.From, to)
+	}
+
+	if options.DryRun {
+		return nil
+	}
+
+	for _, upgrade := range upgrades {
+		err = applyContribUpgrade(ctx, project, upgrade)
+		if err != nil {
+			return fmt.Errorf("unable to upgrade %s to %s: %v", upgrade.Module, upgrade.To, err)
+		}
+		if upgrade.ToModule != upgrade.Module {
+			fmt.Printf("Upgraded %s %s to %s %s, review its breaking changes\n", upgrade.Module, upgrade.From, upgrade.ToModule, upgrade.To)
+		} else {
+			fmt.Printf("Upgraded %s %s to %s\n", upgrade.Module, upgrade.From, upgrade.To)
+		}
+	}
+
+	updateProjectLock(project)
+
+	return nil
+}
+
+// skipPinnedUpgrades removes the upgrades of the modules pinned in flogo.lock, until their pin expires
+func skipPinnedUpgrades(upgrades []*contribUpgrade, pins map[string]*LockPin, now time.Time) []*contribUpgrade {
+
+	var kept []*contribUpgrade
+	for _, upgrade := range upgrades {
+		if pin := pins[upgrade.Module]; pin != nil && !pin.expired(now) {
+			fmt.Printf("Skipping %s, pinned at %s until %s: %s\n", upgrade.Module, pin.Version, pin.Expires, pin.Reason)
+			continue
+		}
+		kept = append(kept, upgrade)
+	}
+
+	return kept
+}
+
+// selectUpgradedImports selects the imports matching the import paths, modules or aliases, all of them if none is
+// specified
+func selectUpgradedImports(imports []util.Import, selectors []string) ([]util.Import, error) {
+
+	if len(selectors) == 0 {
+		return imports, nil
+	}
+
+	var selected []util.Import
+	seen := make(map[string]bool)
+	for _, selector := range selectors {
+		selector, _ = splitModuleVersion(strings.TrimSpace(selector))
+		found := false
+		for _, imp := range imports {
+			if selector != imp.GoImportPath() && selector != imp.ModulePath() && selector != imp.CanonicalAlias() {
+				continue
+			}
+			found = true
+			if !seen[imp.GoImportPath()] {
+				seen[imp.GoImportPath()] = true
+				selected = append(selected, imp)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("'%s' isn't imported by the application", selector)
+		}
+	}
+
+	return selected, nil
+}
+
+// findContribUpgrades finds the latest versions of the modules providing the imports
+func findContribUpgrades(ctx context.Context, project common.AppProject, imports []util.Import, major bool) ([]*contribUpgrade, error) {
+
+	requires := goModRequirements(project.SrcDir())
+
+	byModule := make(map[string]*contribUpgrade)
+	var modules []string
+	for _, imp := range imports {
+		module, version := requiredModule(requires, imp.GoImportPath())
+		if module == "" {
+			if Verbose() {
+				fmt.Printf("Unable to upgrade '%s', it isn't provided by a module required by go.mod\n", imp.GoImportPath())
+			}
+			continue
+		}
+		upgrade, exists := byModule[module]
+		if !exists {
+			upgrade = &contribUpgrade{Module: module, From: version}
+			byModule[module] = upgrade
+			modules = append(modules, module)
+		}
+		upgrade.Imports = append(upgrade.Imports, imp)
+	}
+	sort.Strings(modules)
+
+	var upgrades []*contribUpgrade
+	for _, module := range modules {
+		upgrade := byModule[module]
+
+		versions, err := moduleVersions(ctx, project.SrcDir(), module)
+		if err != nil {
+			return nil, err
+		}
+		upgrade.ToModule, upgrade.To = module, latestModuleVersion(upgrade.From, versions)
+
+		if major {
+			for next := nextMajorModule(upgrade.ToModule); ; next = nextMajorModule(next) {
+				versions, err := moduleVersions(ctx, project.SrcDir(), next)
+				if err != nil {
+					// the module of the next major version doesn't exist
+					if Verbose() {
+						fmt.Printf("No version of %s found: %v\n", next, err)
+					}
+					break
+				}
+				latest := latestModuleVersion("", versions)
+				if latest == "" {
+					break
+				}
+				upgrade.ToModule, upgrade.To = next, latest
+			}
+		}
+
+		if upgrade.To != "" && (upgrade.ToModule != module || compareModuleVersions(upgrade.To, upgrade.From) > 0) {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
+
+	return upgrades, nil
+}
+
+// moduleVersions lists the versions of the module available from the module proxy
+func moduleVersions(ctx context.Context, srcDir, module string) ([]string, error) {
+
+	cmd := exec.CommandContext(ctx, "go", "list", "-m", "-versions", module)
+	cmd.Dir = srcDir
+	cmd.Env = util.GoCmdEnv(filepath.Dir(srcDir))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the versions of %s: %s", module, strings.TrimSpace(string(out)))
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return fields[1:], nil
+}
+
+// latestModuleVersion gets the latest of the versions with the same major version as the current one, prereleases are
+// only considered if the current version is one, an empty string is returned if there is none
+func latestModuleVersion(current string, versions []string) string {
+
+	var cur *semver.Version
+	if current != "" {
+		var err error
+		cur, err = semver.NewVersion(strings.TrimPrefix(current, "v"))
+		if err != nil {
+			return ""
+		}
+	}
+
+	var latest string
+	for _, version := range versions {
+		if strings.HasSuffix(version, "+incompatible") {
+			continue
+		}
+		v, err := semver.NewVersion(strings.TrimPrefix(version, "v"))
+		if err != nil {
+			continue
+		}
+		if v.PreRelease != "" && (cur == nil || cur.PreRelease == "") {
+			continue
+		}
+		if cur != nil && v.Major != cur.Major && (v.Major > 1 || cur.Major > 1) {
+			continue
+		}
+		if latest == "" || compareModuleVersions(version, latest) > 0 {
+			latest = version
+		}
+	}
+
+	return latest
+}
+
+// nextMajorModule gets the path of the module of the next major version, ex. github.com/org/repo/v2 for
+// github.com/org/repo
+func nextMajorModule(module string) string {
+
+	if m := majorVersionSuffix.FindStringSubmatch(module); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		return strings.TrimSuffix(module, m[0]) + "/v" + strconv.Itoa(major+1)
+	}
+
+	return module + "/v2"
+}
+
+// upgradedImport gets the import of the upgraded version, for a new major version the module path is replaced in the
+// import path and the alias preserved, so the references of the application to the contribution are still resolved
+func upgradedImport(imp util.Import, upgrade *contribUpgrade) util.Import {
+
+	if upgrade.ToModule == upgrade.Module {
+		return util.NewFlogoImportWithVersion(imp, upgrade.To)
+	}
+
+	alias := imp.Alias()
+	var upgraded util.Import
+	if imp.IsClassic() {
+		upgraded = util.NewFlogoImport(upgrade.ToModule+strings.TrimPrefix(imp.GoImportPath(), upgrade.Module), "", upgrade.To, alias)
+	} else {
+		upgraded = util.NewFlogoImport(upgrade.ToModule, imp.RelativeImportPath(), upgrade.To, alias)
+	}
+	if alias == "" && path.Base(upgraded.GoImportPath()) != imp.CanonicalAlias() {
+		upgraded = util.NewFlogoImport(upgraded.ModulePath(), upgraded.RelativeImportPath(), upgrade.To, imp.CanonicalAlias())
+	}
+
+	return upgraded
+}
+
+// applyContribUpgrade updates the imports provided by the module to its new version, the imports of a new major version
+// replace the previous ones
+func applyContribUpgrade(ctx context.Context, project common.AppProject, upgrade *contribUpgrade) error {
+
+	var upgraded []util.Import
+	for _, imp := range upgrade.Imports {
+		upgraded = append(upgraded, upgradedImport(imp, upgrade))
+	}
+
+	if upgrade.ToModule != upgrade.Module {
+		err := removeDescriptorImports(project, upgrade.Imports)
+		if err != nil {
+			return err
+		}
+
+		var paths []string
+		for _, imp := range upgrade.Imports {
+			paths = append(paths, imp.GoImportPath())
+		}
+		err = project.RemoveImports(paths...)
+		if err != nil {
+			return err
+		}
+	}
+
+	before := goModRequirements(project.SrcDir())
+
+	err := project.AddImports(ctx, false, true, upgraded...)
+	if err != nil {
+		return err
+	}
+
+	for _, imp := range upgraded {
+		checkResolvedVersions(project.SrcDir(), before, imp)
+	}
+
+	return nil
+}
+
+// removeDescriptorImports removes the imports from flogo.json
+func removeDescriptorImports(project common.AppProject, imports []util.Import) error {
+
+	removed := make(map[string]bool)
+	for _, imp := range imports {
+		removed[imp.GoImportPath()] = true
+	}
+
+	var appImports []string
+	_, err := readAppDescriptorValue(project, "$.imports", &appImports)
+	if err != nil {
+		return err
+	}
+
+	remaining := []string{}
+	for _, appImport := range appImports {
+		imp, err := util.ParseImport(appImport)
+		if err == nil && removed[imp.GoImportPath()] {
+			continue
+		}
+		remaining = append(remaining, appImport)
+	}
+
+	return writeAppDescriptorValue(project, "$.imports", remaining)
+}
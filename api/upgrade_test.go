@@ -0,0 +1,70 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestModuleVersion(t *testing.T) {
+	t.Log("Testing selection of the version to upgrade to")
+
+	versions := []string{"v0.9.0", "v0.10.0", "v0.10.1-rc.1", "v1.0.0", "v1.1.0", "v2.0.0+incompatible"}
+
+	assert.Equal(t, "v1.1.0", latestModuleVersion("v0.9.0", versions))
+	assert.Equal(t, "v1.1.0", latestModuleVersion("v0.10.1-rc.0", []string{"v0.10.0", "v1.1.0"}))
+	assert.Equal(t, "v0.10.1-rc.1", latestModuleVersion("v0.10.1-rc.0", []string{"v0.10.0", "v0.10.1-rc.1"}))
+	assert.Equal(t, "v2.3.0", latestModuleVersion("v2.0.0", []string{"v2.1.0", "v2.3.0", "v3.0.0"}))
+	assert.Equal(t, "", latestModuleVersion("v1.0.0", nil))
+}
+
+func TestNextMajorModule(t *testing.T) {
+	t.Log("Testing path of the module of the next major version")
+
+	assert.Equal(t, "github.com/project-flogo/contrib/v2", nextMajorModule("github.com/project-flogo/contrib"))
+	assert.Equal(t, "github.com/project-flogo/contrib/v3", nextMajorModule("github.com/project-flogo/contrib/v2"))
+}
+
+func TestSelectUpgradedImports(t *testing.T) {
+	t.Log("Testing selection of the imports to upgrade")
+
+	imports, err := util.ParseImports([]string{
+		"github.com/project-flogo/contrib/activity/rest@v0.9.0",
+		"github.com/project-flogo/contrib@v0.9.0:/activity/log",
+		"myTrigger github.com/project-flogo/contrib@v0.9.0:/trigger/rest",
+	})
+	assert.Nil(t, err)
+
+	selected, err := selectUpgradedImports(imports, nil)
+	assert.Nil(t, err)
+	assert.Len(t, selected, 3)
+
+	selected, err = selectUpgradedImports(imports, []string{"myTrigger", "github.com/project-flogo/contrib/activity/rest@latest"})
+	assert.Nil(t, err)
+	assert.Equal(t, "github.com/project-flogo/contrib/trigger/rest", selected[0].GoImportPath())
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", selected[1].GoImportPath())
+
+	_, err = selectUpgradedImports(imports, []string{"github.com/project-flogo/flow"})
+	assert.EqualError(t, err, "'github.com/project-flogo/flow' isn't imported by the application")
+}
+
+func TestUpgradedImport(t *testing.T) {
+	t.Log("Testing imports of the upgraded versions")
+
+	classic, _ := util.ParseImport("github.com/project-flogo/contrib/activity/rest@v0.9.0")
+	relative, _ := util.ParseImport("github.com/project-flogo/contrib@v0.9.0:/activity/log")
+	module, _ := util.ParseImport("github.com/org/rest@v1.2.0")
+
+	upgrade := &contribUpgrade{Module: "github.com/project-flogo/contrib", ToModule: "github.com/project-flogo/contrib", To: "v0.10.0"}
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest@v0.10.0", upgradedImport(classic, upgrade).CanonicalImport())
+	assert.Equal(t, "github.com/project-flogo/contrib@v0.10.0:/activity/log", upgradedImport(relative, upgrade).CanonicalImport())
+
+	upgrade = &contribUpgrade{Module: "github.com/project-flogo/contrib", ToModule: "github.com/project-flogo/contrib/v2", To: "v2.0.0"}
+	assert.Equal(t, "github.com/project-flogo/contrib/v2/activity/rest@v2.0.0", upgradedImport(classic, upgrade).CanonicalImport())
+	assert.Equal(t, "github.com/project-flogo/contrib/v2@v2.0.0:/activity/log", upgradedImport(relative, upgrade).CanonicalImport())
+
+	// the alias is preserved when the last element of the import path changes
+	upgrade = &contribUpgrade{Module: "github.com/org/rest", ToModule: "github.com/org/rest/v2", To: "v2.1.0"}
+	assert.Equal(t, "rest github.com/org/rest/v2@v2.1.0", upgradedImport(module, upgrade).CanonicalImport())
+}
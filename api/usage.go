@@ -0,0 +1,286 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// ContribUsage is the usage of an imported contribution by the application
+type ContribUsage struct {
+	Import      string   `json:"import"`
+	Uses        int      `json:"uses"`
+	Flows       []string `json:"flows"`
+	Handlers    []string `json:"handlers"`
+	Where       []string `json:"where"`
+	Unused      bool     `json:"unused,omitempty"`
+	HeavilyUsed bool     `json:"heavilyUsed,omitempty"`
+}
+
+// a contribution is heavily used when it has at least minHeavyUses and accounts for at least a quarter of the uses
+const minHeavyUses = 3
+
+// ShowContribUsage reports, per imported contribution, the flows and handlers using it, highlighting the unused and
+// heavily used contributions
+func ShowContribUsage(project common.AppProject, jsonFormat bool) error {
+
+	appObj, err := readAppDescriptorObj(project)
+	if err != nil {
+		return err
+	}
+
+	usages := getContribUsages(appObj)
+
+	if jsonFormat {
+		resp, err := json.MarshalIndent(usages, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%v \n", string(resp))
+		return nil
+	}
+
+	var unused []string
+	for _, usage := range usages {
+		switch {
+		case usage.Unused:
+			fmt.Printf("Contrib: %s (unused)\n", usage.Import)
+			unused = append(unused, usage.Import)
+		case usage.HeavilyUsed:
+			fmt.Printf("Contrib: %s (heavily used)\n", usage.Import)
+		default:
+			fmt.Printf("Contrib: %s\n", usage.Import)
+		}
+		fmt.Printf("  Uses     : %d\n", usage.Uses)
+		fmt.Printf("  Flows    : %s\n", joinOrDash(usage.Flows))
+		fmt.Printf("  Handlers : %s\n", joinOrDash(usage.Handlers))
+		if len(usage.Where) > 0 {
+			fmt.Printf("  Where    : %s\n", strings.Join(usage.Where, ", "))
+		}
+		fmt.Println()
+	}
+
+	if len(unused) > 0 {
+		fmt.Printf("%d of %d imports are unused, remove them from the imports of %s and run 'flogo imports sync' to prune them\n",
+			len(unused), len(usages), fileFlogoJson)
+	}
+
+	return nil
+}
+
+// getContribUsages gets the usage of the contributions imported or referenced by the app descriptor, sorted by uses
+func getContribUsages(appObj map[string]interface{}) []*ContribUsage {
+
+	usages := make(map[string]*ContribUsage)
+	aliases := make(map[string][]string)
+
+	var imports []string
+	if vals, ok := appObj["imports"].([]interface{}); ok {
+		for _, val := range vals {
+			if s, ok := val.(string); ok {
+				imports = append(imports, s)
+			}
+		}
+	}
+	for _, s := range imports {
+		imp, err := util.ParseImport(s)
+		if err != nil {
+			continue
+		}
+		usages[imp.GoImportPath()] = &ContribUsage{Import: imp.GoImportPath()}
+		aliases[imp.CanonicalAlias()] = append(aliases[imp.CanonicalAlias()], imp.GoImportPath())
+	}
+
+	addUse := func(ref, contribType, flow, handler, where string) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return
+		}
+
+		var path string
+		if strings.HasPrefix(ref, "#") {
+			path = resolveAlias(aliases[ref[1:]], contribType)
+		} else if imp, err := util.ParseImport(ref); err == nil {
+			path = imp.GoImportPath()
+		}
+		if path == "" {
+			return
+		}
+
+		usage, exists := usages[path]
+		if !exists {
+			usage = &ContribUsage{Import: path}
+			usages[path] = usage
+		}
+
+		usage.Uses++
+		usage.Flows = appendUnique(usage.Flows, flow)
+		usage.Handlers = appendUnique(usage.Handlers, handler)
+		usage.Where = append(usage.Where, where)
+	}
+
+	var triggers []interface{}
+	if vals, ok := appObj["triggers"].([]interface{}); ok {
+		triggers = append(triggers, vals...)
+	}
+	if vals, ok := appObj[sectionDisabledTriggers].([]interface{}); ok {
+		triggers = append(triggers, vals...)
+	}
+	for _, trg := range triggers {
+		trgMap, _ := trg.(map[string]interface{})
+		trgId, _ := trgMap["id"].(string)
+		ref, _ := trgMap["ref"].(string)
+		addUse(ref, "trigger", "", "", "trigger:"+trgId)
+
+		var handlers []interface{}
+		if vals, ok := trgMap["handlers"].([]interface{}); ok {
+			handlers = append(handlers, vals...)
+		}
+		if vals, ok := trgMap[sectionDisabledHandlers].([]interface{}); ok {
+			handlers = append(handlers, vals...)
+		}
+		for i, handler := range handlers {
+			hMap, _ := handler.(map[string]interface{})
+			action, _ := hMap["action"].(map[string]interface{})
+			ref, _ := action["ref"].(string)
+			handlerId := "trigger:" + trgId + "/" + handlerName(handler, i)
+			addUse(ref, "action", "", handlerId, handlerId)
+		}
+	}
+
+	actions, _ := appObj["actions"].([]interface{})
+	for _, act := range actions {
+		actMap, _ := act.(map[string]interface{})
+		actId, _ := actMap["id"].(string)
+		ref, _ := actMap["ref"].(string)
+		addUse(ref, "action", "", "", "action:"+actId)
+	}
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, _ := res.(map[string]interface{})
+		resId, _ := resMap["id"].(string)
+		data, _ := resMap["data"].(map[string]interface{})
+
+		var tasks []interface{}
+		if t, ok := data["tasks"].([]interface{}); ok {
+			tasks = append(tasks, t...)
+		}
+		if eh, ok := data["errorHandler"].(map[string]interface{}); ok {
+			if t, ok := eh["tasks"].([]interface{}); ok {
+				tasks = append(tasks, t...)
+			}
+		}
+
+		for _, task := range tasks {
+			taskMap, _ := task.(map[string]interface{})
+			taskId, _ := taskMap["id"].(string)
+			activity, _ := taskMap["activity"].(map[string]interface{})
+			ref, _ := activity["ref"].(string)
+			addUse(ref, "activity", resId, "", resId+"/"+taskId)
+		}
+
+		// the functions are used in the expressions of the resource
+		for alias, paths := range aliases {
+			if count := countFunctionCalls(data, alias); count > 0 {
+				usage := usages[resolveAlias(paths, "function")]
+				usage.Uses += count
+				usage.Flows = appendUnique(usage.Flows, resId)
+				usage.Where = append(usage.Where, fmt.Sprintf("%s (%d expressions)", resId, count))
+			}
+		}
+	}
+
+	total := 0
+	for _, usage := range usages {
+		total += usage.Uses
+	}
+
+	var result []*ContribUsage
+	for _, usage := range usages {
+		usage.Unused = usage.Uses == 0
+		usage.HeavilyUsed = usage.Uses >= minHeavyUses && usage.Uses*4 >= total
+		result = append(result, usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Uses != result[j].Uses {
+			return result[i].Uses > result[j].Uses
+		}
+		return result[i].Import < result[j].Import
+	})
+
+	return result
+}
+
+// resolveAlias resolves an alias shared by several imports, ex. the rest trigger and activity, using the type of
+// contribution expected by the reference, the contributions are usually in a directory named after their type
+func resolveAlias(paths []string, contribType string) string {
+
+	if len(paths) == 0 {
+		return ""
+	}
+	for _, path := range paths {
+		if strings.Contains(path, "/"+contribType+"/") {
+			return path
+		}
+	}
+
+	return paths[0]
+}
+
+// countFunctionCalls counts the expressions of the item calling a function of the alias, ex. string.concat(...)
+func countFunctionCalls(item interface{}, alias string) int {
+
+	pattern := regexp.MustCompile(`(?:^|[^\w.$\]])` + regexp.QuoteMeta(alias) + `\.\w+\s*\(`)
+
+	count := 0
+	var visit func(item interface{})
+	visit = func(item interface{}) {
+		switch t := item.(type) {
+		case string:
+			if strings.HasPrefix(t, "=") && pattern.MatchString(t) {
+				count++
+			}
+		case map[string]interface{}:
+			for _, val := range t {
+				visit(val)
+			}
+		case []interface{}:
+			for _, val := range t {
+				visit(val)
+			}
+		}
+	}
+	visit(item)
+
+	return count
+}
+
+func appendUnique(values []string, value string) []string {
+
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
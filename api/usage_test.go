@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const usageTestApp = `{
+  "imports": [
+    "github.com/project-flogo/contrib/trigger/rest",
+    "github.com/project-flogo/contrib/activity/rest",
+    "github.com/project-flogo/contrib/activity/log",
+    "github.com/project-flogo/contrib/function/string",
+    "github.com/project-flogo/flow"
+  ],
+  "triggers": [
+    {
+      "id": "receive",
+      "ref": "#rest",
+      "handlers": [
+        {"name": "get", "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:main"}}},
+        {"name": "post", "action": {"ref": "#flow", "settings": {"flowURI": "res://flow:other"}}}
+      ]
+    }
+  ],
+  "resources": [
+    {
+      "id": "flow:main",
+      "data": {
+        "tasks": [
+          {"id": "call", "activity": {"ref": "#rest", "input": {"uri": "=string.concat($.host, \"/api\")"}}},
+          {"id": "call2", "activity": {"ref": "#rest"}}
+        ]
+      }
+    },
+    {
+      "id": "flow:other",
+      "data": {
+        "tasks": [
+          {"id": "call", "activity": {"ref": "#rest"}}
+        ],
+        "errorHandler": {
+          "tasks": [
+            {"id": "notify", "activity": {"ref": "github.com/project-flogo/contrib/activity/rest"}}
+          ]
+        }
+      }
+    }
+  ]
+}`
+
+func TestGetContribUsages(t *testing.T) {
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(usageTestApp), &appObj)
+	assert.Nil(t, err)
+
+	usages := getContribUsages(appObj)
+	assert.Len(t, usages, 5)
+
+	rest := usages[0]
+	assert.Equal(t, "github.com/project-flogo/contrib/activity/rest", rest.Import)
+	assert.Equal(t, 4, rest.Uses)
+	assert.Equal(t, []string{"flow:main", "flow:other"}, rest.Flows)
+	assert.Equal(t, []string{"flow:main/call", "flow:main/call2", "flow:other/call", "flow:other/notify"}, rest.Where)
+	assert.True(t, rest.HeavilyUsed)
+
+	flow := usages[1]
+	assert.Equal(t, "github.com/project-flogo/flow", flow.Import)
+	assert.Equal(t, []string{"trigger:receive/get", "trigger:receive/post"}, flow.Handlers)
+	assert.False(t, flow.HeavilyUsed)
+
+	byImport := make(map[string]*ContribUsage)
+	for _, usage := range usages {
+		byImport[usage.Import] = usage
+	}
+	assert.Equal(t, 1, byImport["github.com/project-flogo/contrib/trigger/rest"].Uses)
+	assert.Equal(t, 1, byImport["github.com/project-flogo/contrib/function/string"].Uses)
+	assert.True(t, byImport["github.com/project-flogo/contrib/activity/log"].Unused)
+}
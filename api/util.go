@@ -1,53 +1,42 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/project-flogo/cli/common"
-	"github.com/project-flogo/core/app"
+	"github.com/project-flogo/cli/util"
 )
 
-func readAppDescriptor(project common.AppProject) (*app.Config, error) {
+// writeTemplateFile renders the template to a generated source of the project, it is written atomically so that an
+// interrupted build doesn't leave a truncated source
+func writeTemplateFile(file, text string, data interface{}) error {
+	var buf bytes.Buffer
+	RenderTemplate(&buf, text, data)
 
-	appDescriptorFile, err := os.Open(filepath.Join(project.Dir(), fileFlogoJson))
-	if err != nil {
-		return nil, err
-	}
-	defer appDescriptorFile.Close()
-
-	appDescriptorData, err := ioutil.ReadAll(appDescriptorFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var appDescriptor app.Config
-	err = json.Unmarshal([]byte(appDescriptorData), &appDescriptor)
-	if err != nil {
-		return nil, err
-	}
-
-	return &appDescriptor, nil
+	return util.WriteFileAtomic(file, buf.Bytes(), 0644)
 }
 
-func writeAppDescriptor(project common.AppProject, appDescriptor *app.Config)  error {
+// writeAppDescriptorFile writes the app descriptor, giving the registered hooks a chance to veto or alter it
+func writeAppDescriptorFile(project common.AppProject, appDescriptor []byte) error {
 
-	appDescriptorUpdated, err := json.MarshalIndent(appDescriptor, "", "  ")
+	event := &common.HookEvent{Type: common.HookDescriptorModified, Project: project, Descriptor: appDescriptor}
+	err := common.DispatchHook(event)
 	if err != nil {
 		return err
 	}
 
-	appDescriptorUpdatedJson := string(appDescriptorUpdated)
-
-	err = ioutil.WriteFile(filepath.Join(project.Dir(), fileFlogoJson), []byte(appDescriptorUpdatedJson), 0644)
+	err = util.WriteFileAtomic(filepath.Join(project.Dir(), fileFlogoJson), event.Descriptor, 0644)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return writeYamlDescriptor(project.Dir(), event.Descriptor)
 }
 
 func backupMain(project common.AppProject) error {
@@ -101,3 +90,58 @@ func restoreMain(project common.AppProject) error {
 
 	return nil
 }
+
+// readAppDescriptorObj reads the app descriptor as a generic object, preserving sections unknown to app.Config
+func readAppDescriptorObj(project common.AppProject) (map[string]interface{}, error) {
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return nil, err
+	}
+
+	var appObj map[string]interface{}
+	err = json.Unmarshal(buf, &appObj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", fileFlogoJson, err.Error())
+	}
+
+	return appObj, nil
+}
+
+func writeAppDescriptorObj(project common.AppProject, appObj map[string]interface{}) error {
+
+	appDescriptorUpdated, err := json.MarshalIndent(appObj, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeAppDescriptorFile(project, appDescriptorUpdated)
+}
+
+// parseKeyValues parses 'key=value' pairs, values that are valid json are unmarshalled
+func parseKeyValues(kvs []string) (map[string]interface{}, error) {
+
+	result := make(map[string]interface{}, len(kvs))
+
+	for _, kv := range kvs {
+		idx := strings.Index(kv, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid key/value pair '%s', expected 'key=value'", kv)
+		}
+
+		result[kv[:idx]] = parseValue(kv[idx+1:])
+	}
+
+	return result, nil
+}
+
+// parseValue parses a value given on the command line, as JSON if it's valid JSON, as a string otherwise
+func parseValue(strVal string) interface{} {
+
+	var val interface{}
+	if err := json.Unmarshal([]byte(strVal), &val); err != nil {
+		return strVal
+	}
+
+	return val
+}
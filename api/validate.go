@@ -0,0 +1,348 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ValidateOptions are the options used when validating an application
+type ValidateOptions struct {
+	Probe   bool                         // perform live connectivity tests of connections
+	OnIssue func(issue *ValidationIssue) // called as soon as an issue is found, by one goroutine at a time
+}
+
+// ValidationIssue is a problem found while validating the application
+type ValidationIssue struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"` // the line of flogo.json, for the issues of its schema
+}
+
+func (i *ValidationIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s: %s (line %d): %s", i.Severity, i.Path, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Path, i.Message)
+}
+
+type validationContext struct {
+	project  common.AppProject
+	options  ValidateOptions
+	appObj   map[string]interface{}
+	contribs *contribResolver
+	issues   []*ValidationIssue
+	report   func(issue *ValidationIssue) // streams the issues as they are found
+}
+
+func (ctx *validationContext) addError(path, format string, args ...interface{}) {
+	ctx.addIssue(&ValidationIssue{Severity: SeverityError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (ctx *validationContext) addWarning(path, format string, args ...interface{}) {
+	ctx.addIssue(&ValidationIssue{Severity: SeverityWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (ctx *validationContext) addIssue(issue *ValidationIssue) {
+	ctx.issues = append(ctx.issues, issue)
+	if ctx.report != nil {
+		ctx.report(issue)
+	}
+}
+
+type validator func(ctx *validationContext) error
+
+// resourceValidator validates a resource of the application, the resources are validated concurrently so it must only
+// read the app descriptor
+type resourceValidator func(ctx *validationContext, path string, resource map[string]interface{})
+
+var validators = []validator{
+	validateConnections,
+	validateAppProperties,
+	validatePropsFiles,
+	validateProfiles,
+	validateTriggerConflicts,
+	validateAliasCollisions,
+	validateAdvisories,
+}
+
+var resourceValidators = []resourceValidator{
+	validateResourceConnections,
+}
+
+// ValidateProject validates the application descriptor against the installed contributions
+func ValidateProject(project common.AppProject, options ValidateOptions) ([]*ValidationIssue, error) {
+
+	err := project.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// the issues of the schema are reported along with the other ones
+	var schemaIssues []*ValidationIssue
+	err = checkDescriptorFile(project.Dir())
+	if schemaErr, ok := err.(*descriptorSchemaError); ok {
+		schemaIssues = schemaErr.issues
+	} else if err != nil {
+		return nil, err
+	}
+
+	buf, err := ioutil.ReadFile(filepath.Join(project.Dir(), fileFlogoJson))
+	if err != nil {
+		return nil, err
+	}
+
+	var appObj map[string]interface{}
+	err = json.Unmarshal(buf, &appObj)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", fileFlogoJson, err.Error())
+	}
+
+	contribs, err := newContribResolver(project)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := validateAppObj(project, options, appObj, contribs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(schemaIssues, issues...), nil
+}
+
+// validateAppObj validates an app descriptor, which may not have been saved yet
+func validateAppObj(project common.AppProject, options ValidateOptions, appObj map[string]interface{}, contribs *contribResolver) ([]*ValidationIssue, error) {
+
+	ctx := &validationContext{project: project, options: options, appObj: appObj, contribs: contribs}
+	if options.OnIssue != nil {
+		var mu sync.Mutex
+		ctx.report = func(issue *ValidationIssue) {
+			mu.Lock()
+			defer mu.Unlock()
+			options.OnIssue(issue)
+		}
+	}
+
+	for _, v := range validators {
+		err := v(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	validateResources(ctx)
+
+	sort.SliceStable(ctx.issues, func(i, j int) bool {
+		return ctx.issues[i].Path < ctx.issues[j].Path
+	})
+
+	return ctx.issues, nil
+}
+
+// validateResources validates the resources concurrently, their issues are added in the order of the resources so the
+// report is the same from one validation to the next
+func validateResources(ctx *validationContext) {
+
+	resources, _ := ctx.appObj["resources"].([]interface{})
+	if len(resources) == 0 {
+		return
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(resources) {
+		workers = len(resources)
+	}
+
+	results := make([][]*ValidationIssue, len(resources))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				resource, ok := resources[i].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				resCtx := &validationContext{project: ctx.project, options: ctx.options, appObj: ctx.appObj, contribs: ctx.contribs, report: ctx.report}
+				for _, v := range resourceValidators {
+					v(resCtx, fmt.Sprintf("$.resources[%d]", i), resource)
+				}
+				results[i] = resCtx.issues
+			}
+		}()
+	}
+
+	for i := range resources {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, issues := range results {
+		ctx.issues = append(ctx.issues, issues...)
+	}
+}
+
+// HasErrors determines if any of the issues is an error
+func HasErrors(issues []*ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// contribResolver resolves contribution refs to their descriptors
+type contribResolver struct {
+	byAlias      map[string]map[string]*util.FlogoContribDescriptor
+	byPath       map[string]*util.FlogoContribDescriptor
+	aliasImports map[string]map[string][]string // the import paths by contribution type and alias
+}
+
+func newContribResolver(project common.AppProject) (*contribResolver, error) {
+
+	ai, err := util.GetAppImports(filepath.Join(project.Dir(), fileFlogoJson), project.DepManager(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &contribResolver{byAlias: make(map[string]map[string]*util.FlogoContribDescriptor), byPath: make(map[string]*util.FlogoContribDescriptor),
+		aliasImports: make(map[string]map[string][]string)}
+
+	for _, details := range ai.GetAllImportDetails() {
+		if details.ContribDesc == nil {
+			continue
+		}
+
+		r.byPath[details.Imp.GoImportPath()] = details.ContribDesc
+
+		if details.TopLevel {
+			ct := details.ContribDesc.GetContribType()
+			aliasMap, exists := r.byAlias[ct]
+			if !exists {
+				aliasMap = make(map[string]*util.FlogoContribDescriptor)
+				r.byAlias[ct] = aliasMap
+			}
+			aliasMap[details.Imp.CanonicalAlias()] = details.ContribDesc
+
+			if r.aliasImports[ct] == nil {
+				r.aliasImports[ct] = make(map[string][]string)
+			}
+			r.aliasImports[ct][details.Imp.CanonicalAlias()] = append(r.aliasImports[ct][details.Imp.CanonicalAlias()], details.Imp.GoImportPath())
+		}
+	}
+
+	return r, nil
+}
+
+// Resolve gets the descriptor of the contribution of the specified type referenced by ref
+func (r *contribResolver) Resolve(contribType, ref string) *util.FlogoContribDescriptor {
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+
+	if ref[0] == '#' {
+		return r.byAlias[contribType][ref[1:]]
+	}
+
+	imp, err := util.ParseImport(ref)
+	if err != nil {
+		return nil
+	}
+
+	return r.byPath[imp.GoImportPath()]
+}
+
+// isExpression determines if the value is resolved at runtime
+func isExpression(val interface{}) bool {
+	strVal, ok := val.(string)
+	if !ok {
+		return false
+	}
+
+	strVal = strings.TrimSpace(strVal)
+	return strings.HasPrefix(strVal, "=") || strings.HasPrefix(strVal, "$")
+}
+
+// checkSettingType determines if the value is compatible with the declared setting type
+func checkSettingType(settingType string, val interface{}) bool {
+
+	if val == nil || isExpression(val) {
+		return true
+	}
+
+	switch strings.ToLower(settingType) {
+	case "string":
+		_, ok := val.(string)
+		return ok
+	case "int", "integer", "long", "number", "float", "double":
+		switch t := val.(type) {
+		case float64:
+			return true
+		case string:
+			_, err := json.Number(t).Float64()
+			return err == nil
+		}
+		return false
+	case "bool", "boolean":
+		switch t := val.(type) {
+		case bool:
+			return true
+		case string:
+			return t == "true" || t == "false"
+		}
+		return false
+	case "object", "params", "map":
+		_, ok := val.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := val.([]interface{})
+		return ok
+	}
+
+	return true
+}
+
+// PrintValidationIssues prints the validation issues
+func PrintValidationIssues(issues []*ValidationIssue, jsonFormat bool) error {
+
+	if jsonFormat {
+		if issues == nil {
+			issues = []*ValidationIssue{}
+		}
+		resp, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stdout, "%v \n", string(resp))
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSettingType(t *testing.T) {
+	t.Log("Testing checking of setting types")
+
+	assert.True(t, checkSettingType("string", "abc"))
+	assert.True(t, checkSettingType("int", 8080.0))
+	assert.True(t, checkSettingType("int", "8080"))
+	assert.True(t, checkSettingType("int", "=$property[port]"))
+	assert.False(t, checkSettingType("int", "abc"))
+	assert.True(t, checkSettingType("boolean", "true"))
+	assert.False(t, checkSettingType("boolean", 1.0))
+	assert.False(t, checkSettingType("object", "abc"))
+}
+
+func TestConnectionAddress(t *testing.T) {
+	t.Log("Testing determining the address of a connection")
+
+	assert.Equal(t, "localhost:5432", connectionAddress(map[string]interface{}{"url": "postgres://localhost/mydb"}))
+	assert.Equal(t, "broker:1884", connectionAddress(map[string]interface{}{"brokerUrl": "tcp://broker:1884"}))
+	assert.Equal(t, "db:3306", connectionAddress(map[string]interface{}{"host": "db", "port": 3306.0}))
+	assert.Equal(t, "", connectionAddress(map[string]interface{}{"host": "=$property[host]", "port": 3306.0}))
+	assert.Equal(t, "", connectionAddress(map[string]interface{}{"name": "abc"}))
+}
+
+func TestValidateTriggerConflicts(t *testing.T) {
+	t.Log("Testing detection of conflicting trigger settings")
+
+	app := `{
+  "properties": [{"name": "port", "type": "int", "value": 8080}],
+  "triggers": [
+    {
+      "id": "rest",
+      "settings": {"port": 8080},
+      "handlers": [
+        {"name": "get", "settings": {"method": "GET", "path": "/users/:id"}},
+        {"name": "get", "settings": {"method": "GET", "path": "/users/{name}"}},
+        {"name": "me", "settings": {"method": "GET", "path": "/users/me"}},
+        {"name": "post", "settings": {"method": "POST", "path": "/users/me"}}
+      ]
+    },
+    {"id": "rest", "settings": {"port": "=$property[port]"}},
+    {"id": "timer", "handlers": [{"name": "tick"}, {"name": "tick"}]}
+  ]
+}`
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(app), &appObj)
+	assert.Nil(t, err)
+
+	ctx := &validationContext{appObj: appObj}
+	err = validateTriggerConflicts(ctx)
+	assert.Nil(t, err)
+
+	var found []string
+	for _, issue := range ctx.issues {
+		found = append(found, issue.Severity+" "+issue.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"error $.triggers[0].handlers[1].name",
+		"error $.triggers[0].handlers[1].settings.path",
+		"warning $.triggers[0].handlers[2].settings.path",
+		"warning $.triggers[0].handlers[2].settings.path",
+		"error $.triggers[1].id",
+		"error $.triggers[1].settings.port",
+		"error $.triggers[2].handlers[1].name",
+	}, found)
+}
+
+func TestRoutesOverlap(t *testing.T) {
+	t.Log("Testing comparison of handler paths")
+
+	assert.Equal(t, routeIdentical, routesOverlap("/users/:id", "/users/{name}/"))
+	assert.Equal(t, routeAmbiguous, routesOverlap("/users/:id", "/users/me"))
+	assert.Equal(t, routeDistinct, routesOverlap("/users/:id", "/orders/:id"))
+	assert.Equal(t, routeDistinct, routesOverlap("/users/:id", "/users/:id/orders"))
+}
+
+func TestValidateResources(t *testing.T) {
+	t.Log("Testing concurrent validation of the resources")
+
+	sql := &util.FlogoContribDescriptor{Name: "sql", Type: "flogo:activity",
+		Settings: []*util.FlogoSettingDescriptor{{Name: "connection", Type: settingTypeConnection, Required: true}}}
+	contribs := &contribResolver{byAlias: map[string]map[string]*util.FlogoContribDescriptor{"activity": {"sql": sql}}}
+
+	var resources []interface{}
+	var expected []string
+	for i := 0; i < 50; i++ {
+		task := func(settings string) interface{} {
+			var task map[string]interface{}
+			err := json.Unmarshal([]byte(`{"activity": {"ref": "#sql", "settings": `+settings+`}}`), &task)
+			assert.Nil(t, err)
+			return task
+		}
+		tasks := []interface{}{task(`{"connection": "conn://db"}`), task(`{}`), task(`{"connection": "conn://missing"}`)}
+		resources = append(resources, map[string]interface{}{"id": fmt.Sprintf("flow:f%d", i), "data": map[string]interface{}{"tasks": tasks}})
+		expected = append(expected, fmt.Sprintf("$.resources[%d].data.tasks[1].activity.settings.connection", i),
+			fmt.Sprintf("$.resources[%d].data.tasks[2].activity.settings.connection", i))
+	}
+	appObj := map[string]interface{}{"resources": resources, "connections": map[string]interface{}{"db": map[string]interface{}{}}}
+
+	var mu sync.Mutex
+	streamed := 0
+	ctx := &validationContext{appObj: appObj, contribs: contribs, report: func(issue *ValidationIssue) {
+		mu.Lock()
+		defer mu.Unlock()
+		streamed++
+	}}
+	validateResources(ctx)
+
+	// the issues are in the order of the resources, whichever finished first
+	var found []string
+	for _, issue := range ctx.issues {
+		assert.Equal(t, SeverityError, issue.Severity)
+		found = append(found, issue.Path)
+	}
+	assert.Equal(t, expected, found)
+	assert.Equal(t, len(expected), streamed)
+}
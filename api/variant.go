@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	sectionVariants = "variants"
+)
+
+// applyVariant replaces the references to resources that declare the variant with references to the variant resource
+func applyVariant(appObj map[string]interface{}, variant string) error {
+
+	replacements := make(map[string]string)
+
+	resources, _ := appObj["resources"].([]interface{})
+	for _, res := range resources {
+		resMap, ok := res.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		variants, _ := resMap[sectionVariants].(map[string]interface{})
+		variantId, ok := variants[variant].(string)
+		if !ok {
+			continue
+		}
+
+		id, _ := resMap["id"].(string)
+		variantId = strings.TrimPrefix(variantId, resURIPrefix)
+		if v, _ := findById(resources, variantId); v == nil {
+			return fmt.Errorf("variant '%s' of resource '%s' refers to unknown resource '%s'", variant, id, variantId)
+		}
+
+		replacements[resURIPrefix+id] = resURIPrefix + variantId
+	}
+
+	if len(replacements) == 0 {
+		return fmt.Errorf("no resource declares variant '%s'", variant)
+	}
+
+	for from, to := range replacements {
+		if Verbose() {
+			fmt.Printf("Using variant '%s': %s => %s\n", variant, from, to)
+		}
+	}
+
+	for key, val := range appObj {
+		if key != "resources" {
+			appObj[key] = replaceResourceRefs(val, replacements)
+		}
+	}
+
+	// resources can reference other resources (ex. subflows), but not themselves
+	for _, res := range resources {
+		if resMap, ok := res.(map[string]interface{}); ok {
+			resMap["data"] = replaceResourceRefs(resMap["data"], replacements)
+		}
+	}
+
+	return nil
+}
+
+func replaceResourceRefs(item interface{}, replacements map[string]string) interface{} {
+	switch t := item.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			t[k] = replaceResourceRefs(v, replacements)
+		}
+	case []interface{}:
+		for i, v := range t {
+			t[i] = replaceResourceRefs(v, replacements)
+		}
+	case string:
+		if to, ok := replacements[t]; ok {
+			return to
+		}
+	}
+
+	return item
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const variantAppJson = `{
+  "name": "myApp",
+  "triggers": [{"id": "rest", "handlers": [{"action": {"ref": "#flow", "settings": {"flowURI": "res://flow:main"}}}]}],
+  "resources": [
+    {"id": "flow:main", "variants": {"mock": "res://flow:main_mock"}, "data": {"tasks": [{"settings": {"flowURI": "res://flow:sub"}}]}},
+    {"id": "flow:main_mock", "data": {}},
+    {"id": "flow:sub", "variants": {"mock": "flow:sub_mock"}, "data": {}},
+    {"id": "flow:sub_mock", "data": {}}
+  ]
+}`
+
+func TestApplyVariant(t *testing.T) {
+	t.Log("Testing the selection of the resource variants")
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(variantAppJson), &appObj)
+	assert.Nil(t, err)
+
+	err = applyVariant(appObj, "mock")
+	assert.Nil(t, err)
+
+	// the references of the app and of the resources are replaced, with or without the res:// prefix in the variants
+	handler := appObj["triggers"].([]interface{})[0].(map[string]interface{})["handlers"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "res://flow:main_mock", handler["action"].(map[string]interface{})["settings"].(map[string]interface{})["flowURI"])
+
+	resources := appObj["resources"].([]interface{})
+	main := resources[0].(map[string]interface{})
+	task := main["data"].(map[string]interface{})["tasks"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "res://flow:sub_mock", task["settings"].(map[string]interface{})["flowURI"])
+
+	// the resources themselves are kept
+	assert.Equal(t, "flow:main", main["id"])
+	assert.Len(t, resources, 4)
+}
+
+func TestApplyVariantErrors(t *testing.T) {
+	t.Log("Testing the selection of unknown resource variants")
+
+	var appObj map[string]interface{}
+	err := json.Unmarshal([]byte(variantAppJson), &appObj)
+	assert.Nil(t, err)
+
+	err = applyVariant(appObj, "test")
+	assert.EqualError(t, err, "no resource declares variant 'test'")
+
+	err = json.Unmarshal([]byte(`{"resources": [{"id": "flow:main", "variants": {"mock": "res://flow:missing"}}]}`), &appObj)
+	assert.Nil(t, err)
+
+	err = applyVariant(appObj, "mock")
+	assert.EqualError(t, err, "variant 'mock' of resource 'flow:main' refers to unknown resource 'flow:missing'")
+}
+
+func TestBuildAppDescriptorVariant(t *testing.T) {
+	t.Log("Testing the app descriptor built with a variant")
+
+	tempDir, err := ioutil.TempDir("", "flogo-variant")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, fileFlogoJson), []byte(variantAppJson), 0644)
+	assert.Nil(t, err)
+	project := NewAppProject(tempDir)
+
+	// the descriptor is used as is without variant
+	descriptor, err := getBuildAppDescriptor(project, common.BuildOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, variantAppJson, descriptor)
+
+	descriptor, err = getBuildAppDescriptor(project, common.BuildOptions{Variant: "mock"})
+	assert.Nil(t, err)
+	assert.Contains(t, descriptor, `"flowURI": "res://flow:main_mock"`)
+	assert.NotContains(t, descriptor, `"flowURI": "res://flow:main"`)
+
+	_, err = getBuildAppDescriptor(project, common.BuildOptions{Variant: "test"})
+	assert.NotNil(t, err)
+
+	// the descriptor of the project isn't changed
+	buf, err := ioutil.ReadFile(filepath.Join(tempDir, fileFlogoJson))
+	assert.Nil(t, err)
+	assert.Equal(t, variantAppJson, string(buf))
+}
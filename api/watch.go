@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+)
+
+// DefaultWatchInterval is the interval at which the project is checked for changes
+const DefaultWatchInterval = time.Second
+
+const (
+	watchStopTimeout  = 10 * time.Second
+	watchChangesShown = 5
+)
+
+// WatchOptions are the options used to watch the project
+type WatchOptions struct {
+	Interval    time.Duration       // interval at which the project is checked for changes
+	Build       common.BuildOptions // options the application is built with
+	Start       StartOptions        // options the application is started with
+	StopTimeout time.Duration       // time to wait for the application to stop before killing it
+}
+
+// WatchProject builds and starts the application, then rebuilds and restarts it each time the app descriptor or the
+// sources of the project change, until interrupted. The application is stopped when the watch is interrupted.
+func WatchProject(ctx context.Context, project common.AppProject, options WatchOptions) error {
+
+	if options.Interval <= 0 {
+		options.Interval = DefaultWatchInterval
+	}
+	if options.StopTimeout <= 0 {
+		options.StopTimeout = watchStopTimeout
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	snapshot := rebuildWatchedApp(ctx, project, options)
+
+	fmt.Printf("Watching %s for changes, press Ctrl+C to stop\n", project.Name())
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-interrupted:
+			fmt.Println()
+			if status, err := GetAppStatus(project); err == nil && status.Running {
+				return StopApp(project, options.StopTimeout)
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		current := watchSnapshot(project, options)
+		changed := changedFiles(snapshot, current)
+		if len(changed) == 0 {
+			continue
+		}
+
+		// the files are given time to settle, ex. an editor saving several files or a go mod command
+		for {
+			time.Sleep(options.Interval)
+			next := watchSnapshot(project, options)
+			if len(changedFiles(current, next)) == 0 {
+				break
+			}
+			current = next
+		}
+		changed = changedFiles(snapshot, current)
+
+		shown := changed
+		if len(shown) > watchChangesShown {
+			shown = shown[:watchChangesShown]
+		}
+		more := ""
+		if len(changed) > len(shown) {
+			more = fmt.Sprintf(" (+%d more)", len(changed)-len(shown))
+		}
+		fmt.Printf("\nChanged: %s%s\n", strings.Join(shown, ", "), more)
+
+		snapshot = rebuildWatchedApp(ctx, project, options)
+	}
+}
+
+// rebuildWatchedApp builds and restarts the application, the errors are reported and the project watched for the
+// changes fixing them. The snapshot of the project is taken once built, as the build updates the sources.
+func rebuildWatchedApp(ctx context.Context, project common.AppProject, options WatchOptions) map[string]string {
+
+	start := time.Now()
+	err := BuildProject(ctx, project, options.Build)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Build failed: %v\n", err)
+		return watchSnapshot(project, options)
+	}
+	fmt.Printf("Built %s in %s\n", project.Name(), time.Since(start).Round(10*time.Millisecond))
+
+	startOptions := options.Start
+	err = RestartApp(project, &startOptions, options.StopTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Restart failed: %v\n", err)
+	}
+
+	return watchSnapshot(project, options)
+}
+
+// watchSnapshot gets the modification time and size of the watched files, by path relative to the project: the app
+// descriptor, the properties files the application is started with and the sources of the project
+func watchSnapshot(project common.AppProject, options WatchOptions) map[string]string {
+
+	snapshot := make(map[string]string)
+
+	add := func(path string, info os.FileInfo) {
+		rel, err := filepath.Rel(project.Dir(), path)
+		if err != nil {
+			rel = path
+		}
+		snapshot[filepath.ToSlash(rel)] = fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+	}
+
+	files := []string{filepath.Join(project.Dir(), fileFlogoJson)}
+	if yamlFile := yamlDescriptorFile(project.Dir()); yamlFile != "" {
+		files = append(files, yamlFile)
+	}
+	for _, props := range options.Start.Props {
+		if path, err := filepath.Abs(props); err == nil {
+			files = append(files, path)
+		}
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			add(file, info)
+		}
+	}
+
+	_ = filepath.Walk(project.SrcDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != project.SrcDir() && strings.HasPrefix(name, ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isWatchIgnored(name) {
+			return nil
+		}
+		add(path, info)
+		return nil
+	})
+
+	return snapshot
+}
+
+// isWatchIgnored checks if a file is a temporary file, ex. the swap file of an editor or a backup made by the build
+func isWatchIgnored(name string) bool {
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "#") || strings.HasSuffix(name, "~") {
+		return true
+	}
+	switch filepath.Ext(name) {
+	case ".swp", ".swx", ".tmp", ".bak":
+		return true
+	}
+	return false
+}
+
+// changedFiles gets the files added, modified or removed between two snapshots
+func changedFiles(before, after map[string]string) []string {
+
+	var changed []string
+	for path, state := range after {
+		if before[path] != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, exists := after[path]; !exists {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
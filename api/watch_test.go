@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t.Log("Testing detection of the files changed between two snapshots")
+
+	before := map[string]string{"flogo.json": "1:10", "src/main.go": "1:20", "src/old.go": "1:5"}
+	after := map[string]string{"flogo.json": "2:12", "src/main.go": "1:20", "src/new.go": "3:7"}
+
+	assert.Equal(t, []string{"flogo.json", "src/new.go", "src/old.go"}, changedFiles(before, after))
+	assert.Empty(t, changedFiles(after, after))
+}
+
+func TestIsWatchIgnored(t *testing.T) {
+	t.Log("Testing the temporary files ignored by the watch")
+
+	assert.False(t, isWatchIgnored("main.go"))
+	assert.False(t, isWatchIgnored("go.mod"))
+	assert.True(t, isWatchIgnored("main.go.bak"))
+	assert.True(t, isWatchIgnored(".main.go.swp"))
+	assert.True(t, isWatchIgnored("imports.go~"))
+	assert.True(t, isWatchIgnored("#flogo.json#"))
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// PrefetchOptions are the options used to prefetch the dependencies of a workspace
+type PrefetchOptions struct {
+	Dir  string // the root directory of the workspace
+	Jobs int    // the number of concurrent downloads
+}
+
+// wsModule is a module required by the applications of a workspace
+type wsModule struct {
+	Path    string
+	Version string
+	SrcDir  string // the src directory of an application requiring it, the download is run from it
+}
+
+func (m *wsModule) String() string {
+	return m.Path + "@" + m.Version
+}
+
+// FindWorkspaceApps finds the application projects of the workspace, the directories below the root containing a
+// flogo.json and a src/go.mod, or the applications listed by the flogo.workspace.json of the root
+func FindWorkspaceApps(root string) ([]common.AppProject, error) {
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var apps []common.AppProject
+
+	cfg, err := loadWorkspaceConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Apps) > 0 {
+		for _, app := range cfg.Apps {
+			dir := filepath.Join(root, filepath.FromSlash(app))
+			if !util.FileExists(filepath.Join(dir, dirSrc, "go.mod")) {
+				return nil, fmt.Errorf("application '%s' of %s not found", app, fileWorkspaceJson)
+			}
+			apps = append(apps, NewAppProject(dir))
+		}
+		return apps, nil
+	}
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+
+		name := info.Name()
+		if path != root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+			return filepath.SkipDir
+		}
+
+		hasDescriptor := util.FileExists(filepath.Join(path, fileFlogoJson)) || yamlDescriptorFile(path) != ""
+		if hasDescriptor && util.FileExists(filepath.Join(path, dirSrc, "go.mod")) {
+			apps = append(apps, NewAppProject(path))
+			// the bin and src directories of the application don't contain other applications
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apps) == 0 {
+		return nil, fmt.Errorf("no application found in workspace '%s'", root)
+	}
+
+	return apps, nil
+}
+
+// PrefetchWorkspace downloads the union of the modules required by the applications of the workspace into the module
+// cache, concurrently and once per module, so the following commands of the applications don't download them
+func PrefetchWorkspace(ctx context.Context, options PrefetchOptions) error {
+
+	if options.Dir == "" {
+		options.Dir = "."
+	}
+	if options.Jobs <= 0 {
+		options.Jobs = runtime.NumCPU()
+	}
+
+	apps, err := FindWorkspaceApps(options.Dir)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, app := range apps {
+		names = append(names, relWorkspacePath(options.Dir, app.Dir()))
+	}
+	fmt.Printf("Found %d application(s): %s\n", len(apps), strings.Join(names, ", "))
+
+	modules := workspaceModules(apps)
+
+	modCache := filepath.Join(util.GetGoPath(), "pkg", "mod", "cache", "download")
+
+	var toDownload []*wsModule
+	for _, mod := range modules {
+		if !util.FileExists(filepath.Join(modCache, escapeModulePath(mod.Path), "@v", mod.Version+".zip")) {
+			toDownload = append(toDownload, mod)
+		}
+	}
+
+	if len(toDownload) == 0 {
+		fmt.Printf("All %d modules are already in the module cache\n", len(modules))
+		return nil
+	}
+
+	jobs := options.Jobs
+	if jobs > len(toDownload) {
+		jobs = len(toDownload)
+	}
+	fmt.Printf("Downloading %d modules (%d already cached) using %d workers\n", len(toDownload), len(modules)-len(toDownload), jobs)
+
+	start := time.Now()
+	failures := downloadModules(ctx, toDownload, jobs)
+
+	if len(failures) > 0 {
+		var lines []string
+		for _, mod := range toDownload {
+			if err, failed := failures[mod]; failed {
+				lines = append(lines, fmt.Sprintf("  %s: %s", mod, strings.TrimSpace(err.Error())))
+			}
+		}
+		return fmt.Errorf("unable to download %d of %d modules:\n%s", len(failures), len(toDownload), strings.Join(lines, "\n"))
+	}
+
+	fmt.Printf("Downloaded %d modules in %s\n", len(toDownload), time.Since(start).Round(100*time.Millisecond))
+
+	return nil
+}
+
+// workspaceModules gets the union of the modules required by the applications, sorted by path and version
+func workspaceModules(apps []common.AppProject) []*wsModule {
+
+	union := make(map[string]*wsModule)
+	for _, app := range apps {
+		for path, version := range goModRequirements(app.SrcDir()) {
+			mod := &wsModule{Path: path, Version: version, SrcDir: app.SrcDir()}
+			if _, exists := union[mod.String()]; !exists {
+				union[mod.String()] = mod
+			}
+		}
+	}
+
+	modules := make([]*wsModule, 0, len(union))
+	for _, mod := range union {
+		modules = append(modules, mod)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if modules[i].Path != modules[j].Path {
+			return modules[i].Path < modules[j].Path
+		}
+		return modules[i].Version < modules[j].Version
+	})
+
+	return modules
+}
+
+// downloadModules downloads the modules using jobs concurrent 'go mod download', the failures are returned
+func downloadModules(ctx context.Context, modules []*wsModule, jobs int) map[*wsModule]error {
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		done     int
+		failures = make(map[*wsModule]error)
+	)
+
+	queue := make(chan *wsModule)
+
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for mod := range queue {
+				err := util.NewDepManager(mod.SrcDir).Download(ctx, mod.String())
+
+				mu.Lock()
+				done++
+				if err != nil {
+					failures[mod] = err
+					fmt.Printf("  [%d/%d] failed %s\n", done, len(modules), mod)
+				} else {
+					fmt.Printf("  [%d/%d] %s\n", done, len(modules), mod)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, mod := range modules {
+		queue <- mod
+	}
+	close(queue)
+
+	wg.Wait()
+
+	return failures
+}
+
+func relWorkspacePath(root, dir string) string {
+	if rootAbs, err := filepath.Abs(root); err == nil {
+		if rel, err := filepath.Rel(rootAbs, dir); err == nil {
+			return rel
+		}
+	}
+	return dir
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWorkspaceApps(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	writeApp := func(dir, goMod string) {
+		err := os.MkdirAll(filepath.Join(root, dir, "src"), 0755)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "flogo.json"), []byte("{}"), 0644)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "src", "go.mod"), []byte(goMod), 0644)
+		assert.Nil(t, err)
+	}
+
+	writeApp("apps/a", "module main\n\nrequire (\n\tgithub.com/project-flogo/core v0.9.2\n\tgithub.com/project-flogo/flow v0.9.0\n)\n")
+	writeApp("apps/b", "module main\n\nrequire github.com/project-flogo/core v0.9.2\n")
+	writeApp(".cache/c", "module main\n")
+
+	apps, err := FindWorkspaceApps(root)
+	assert.Nil(t, err)
+	assert.Len(t, apps, 2)
+
+	modules := workspaceModules(apps)
+	assert.Len(t, modules, 2)
+	assert.Equal(t, "github.com/project-flogo/core@v0.9.2", modules[0].String())
+	assert.Equal(t, "github.com/project-flogo/flow@v0.9.0", modules[1].String())
+
+	_, err = FindWorkspaceApps(filepath.Join(root, ".cache", "c", "src"))
+	assert.NotNil(t, err)
+}
+
+func TestWorkspaceDrifts(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	writeApp := func(dir, goMod string) {
+		err := os.MkdirAll(filepath.Join(root, dir, "src"), 0755)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "flogo.json"), []byte("{}"), 0644)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "src", "go.mod"), []byte(goMod), 0644)
+		assert.Nil(t, err)
+	}
+
+	writeApp("a", "module main\n\nrequire (\n\tgithub.com/project-flogo/core v0.9.2\n\tgithub.com/project-flogo/contrib v0.10.0\n)\n")
+	writeApp("b", "module main\n\nrequire (\n\tgithub.com/project-flogo/core v0.9.2\n\tgithub.com/project-flogo/contrib v0.9.0\n)\n")
+	writeApp("c", "module main\n\nrequire github.com/project-flogo/contrib v0.9.0\n")
+
+	apps, err := FindWorkspaceApps(root)
+	assert.Nil(t, err)
+
+	drifts := workspaceDrifts(root, apps)
+	assert.Len(t, drifts, 1)
+	assert.Equal(t, "github.com/project-flogo/contrib", drifts[0].Module)
+	assert.Equal(t, []string{"v0.9.0", "v0.10.0"}, drifts[0].Versions)
+	assert.Equal(t, []string{"b", "c"}, drifts[0].Apps["v0.9.0"])
+
+	assert.Equal(t, "v0.10.0", highestRequiredVersion(apps, "github.com/project-flogo/contrib"))
+	assert.Equal(t, "", highestRequiredVersion(apps, "github.com/project-flogo/flow"))
+}
+
+func TestAlignDescriptorImports(t *testing.T) {
+
+	imports := []string{
+		"github.com/project-flogo/contrib/activity/log@v0.9.0",
+		"github.com/project-flogo/contrib/trigger/rest",
+		"github.com/project-flogo/flow@v0.9.0",
+	}
+
+	aligned, changed := alignDescriptorImports(imports, "github.com/project-flogo/contrib", "v0.10.0")
+	assert.True(t, changed)
+	assert.Equal(t, []string{
+		"github.com/project-flogo/contrib/activity/log@v0.10.0",
+		"github.com/project-flogo/contrib/trigger/rest",
+		"github.com/project-flogo/flow@v0.9.0",
+	}, aligned)
+
+	_, changed = alignDescriptorImports(aligned, "github.com/project-flogo/contrib", "v0.10.0")
+	assert.False(t, changed)
+}
+
+func TestWorkspaceContribs(t *testing.T) {
+
+	root, err := ioutil.TempDir("", "ws")
+	assert.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	for _, dir := range []string{"apps/a", "apps/b", "apps/c"} {
+		err := os.MkdirAll(filepath.Join(root, dir, "src"), 0755)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "flogo.json"), []byte("{}"), 0644)
+		assert.Nil(t, err)
+		err = ioutil.WriteFile(filepath.Join(root, dir, "src", "go.mod"), []byte("module main\n"), 0644)
+		assert.Nil(t, err)
+	}
+	err = os.MkdirAll(filepath.Join(root, "contrib", "myactivity"), 0755)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(filepath.Join(root, "contrib", "myactivity", "go.mod"), []byte("module example.com/myactivity\n"), 0644)
+	assert.Nil(t, err)
+
+	// without flogo.workspace.json, the workspace has no contribution
+	assert.Equal(t, "", findWorkspaceRoot(filepath.Join(root, "apps", "a")))
+
+	cfg := &workspaceConfig{Apps: []string{"apps/a", "apps/b"}, Contribs: []string{"contrib/myactivity"}}
+	err = writeWorkspaceConfig(root, cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, root, findWorkspaceRoot(filepath.Join(root, "apps", "a", "src")))
+
+	apps, err := FindWorkspaceApps(root)
+	assert.Nil(t, err)
+	assert.Len(t, apps, 2)
+
+	contribs, err := workspaceContribs(root, cfg)
+	assert.Nil(t, err)
+	assert.Len(t, contribs, 1)
+	assert.Equal(t, "example.com/myactivity", contribs[0].Module)
+
+	srcDir := filepath.Join(root, "apps", "a", "src")
+	assert.Equal(t, "../../../contrib/myactivity", localReplacePath(srcDir, contribs[0].Dir))
+	assert.Equal(t, "./pkg", localReplacePath(srcDir, filepath.Join(srcDir, "pkg")))
+
+	imp, err := util.ParseImport("example.com/myactivity/sub")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(contribs[0].Dir, "sub"), workspaceContribPath(contribs, imp))
+	assert.Equal(t, "v0.0.0", installWorkspaceContrib(apps[0], contribs, imp).Version())
+
+	imp, err = util.ParseImport("github.com/project-flogo/contrib/activity/log")
+	assert.Nil(t, err)
+	assert.Equal(t, "", workspaceContribPath(contribs, imp))
+	assert.Equal(t, "", installWorkspaceContrib(apps[0], contribs, imp).Version())
+
+	cfg.Apps = []string{"apps/d"}
+	err = writeWorkspaceConfig(root, cfg)
+	assert.Nil(t, err)
+	_, err = FindWorkspaceApps(root)
+	assert.NotNil(t, err)
+}
+
+func TestGoModReplaces(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "gomod")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	goMod := "module main\n\nreplace example.com/a => ../../a\n\nreplace (\n\texample.com/b v1.0.0 => example.com/c v1.1.0\n)\n"
+	err = ioutil.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goMod), 0644)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]string{"example.com/a": "../../a", "example.com/b": "example.com/c"}, goModReplaces(tempDir))
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+// AlignOptions are the options used to align the versions of the modules required by the applications of a workspace
+type AlignOptions struct {
+	Dir     string   // the root directory of the workspace
+	Modules []string // the modules to align, as module or module@version, to the highest version used by default
+	All     bool     // align all the modules required at different versions to the highest one
+}
+
+// wsDrift is a module required at different versions by the applications of a workspace
+type wsDrift struct {
+	Module   string
+	Versions []string            // sorted from the lowest to the highest
+	Apps     map[string][]string // the applications requiring each version
+}
+
+// AlignWorkspace reports the modules required at different versions by the applications of the workspace, and aligns
+// the requested ones: the applications requiring them are updated to require the same version, in go.mod and in the
+// imports of their flogo.json
+func AlignWorkspace(ctx context.Context, options AlignOptions) error {
+
+	if options.Dir == "" {
+		options.Dir = "."
+	}
+
+	apps, err := FindWorkspaceApps(options.Dir)
+	if err != nil {
+		return err
+	}
+
+	drifts := workspaceDrifts(options.Dir, apps)
+
+	if len(options.Modules) == 0 && !options.All {
+		if len(drifts) == 0 {
+			fmt.Printf("The %d application(s) require the same version of each module\n", len(apps))
+			return nil
+		}
+
+		for _, drift := range drifts {
+			width := 0
+			for _, version := range drift.Versions {
+				if len(version) > width {
+					width = len(version)
+				}
+			}
+			fmt.Println(drift.Module)
+			for _, version := range drift.Versions {
+				fmt.Printf("  %-*s  %s\n", width, version, strings.Join(drift.Apps[version], ", "))
+			}
+		}
+		fmt.Printf("\n%d module(s) required at different versions, align them using 'flogo ws align <module>[@<version>]' or 'flogo ws align --all'\n", len(drifts))
+		return nil
+	}
+
+	targets := make(map[string]string)
+	if options.All {
+		for _, drift := range drifts {
+			targets[drift.Module] = drift.Versions[len(drift.Versions)-1]
+		}
+	}
+	for _, mod := range options.Modules {
+		module, version := mod, ""
+		if idx := strings.LastIndex(mod, "@"); idx > 0 {
+			module, version = mod[:idx], mod[idx+1:]
+		}
+		if version == "" {
+			version = highestRequiredVersion(apps, module)
+			if version == "" {
+				return fmt.Errorf("module '%s' isn't required by any application of the workspace", module)
+			}
+		}
+		targets[module] = version
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No module to align")
+		return nil
+	}
+
+	var modules []string
+	for module := range targets {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	var failures []string
+	for _, module := range modules {
+		version := targets[module]
+		fmt.Printf("Aligning %s to %s\n", module, version)
+
+		for _, app := range apps {
+			current, required := goModRequirements(app.SrcDir())[module]
+			if !required || current == version {
+				continue
+			}
+
+			name := relWorkspacePath(options.Dir, app.Dir())
+			resolved, err := alignAppModule(ctx, app, module, version)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("  %s: %s", name, strings.TrimSpace(err.Error())))
+				fmt.Printf("  %s: failed\n", name)
+				continue
+			}
+			if resolved != version {
+				// another module of the application requires a higher version
+				fmt.Printf("  %s: %s -> %s (%s is required by another dependency)\n", name, current, resolved, resolved)
+			} else {
+				fmt.Printf("  %s: %s -> %s\n", name, current, resolved)
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to align %d application(s):\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+// workspaceDrifts gets the modules required at different versions by the applications, sorted by path
+func workspaceDrifts(root string, apps []common.AppProject) []*wsDrift {
+
+	byModule := make(map[string]*wsDrift)
+	for _, app := range apps {
+		name := relWorkspacePath(root, app.Dir())
+		for module, version := range goModRequirements(app.SrcDir()) {
+			drift, exists := byModule[module]
+			if !exists {
+				drift = &wsDrift{Module: module, Apps: make(map[string][]string)}
+				byModule[module] = drift
+			}
+			if _, exists := drift.Apps[version]; !exists {
+				drift.Versions = append(drift.Versions, version)
+			}
+			drift.Apps[version] = append(drift.Apps[version], name)
+		}
+	}
+
+	var drifts []*wsDrift
+	for _, drift := range byModule {
+		if len(drift.Versions) < 2 {
+			continue
+		}
+		sort.Slice(drift.Versions, func(i, j int) bool {
+			return compareModuleVersions(drift.Versions[i], drift.Versions[j]) < 0
+		})
+		for _, names := range drift.Apps {
+			sort.Strings(names)
+		}
+		drifts = append(drifts, drift)
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Module < drifts[j].Module
+	})
+
+	return drifts
+}
+
+// highestRequiredVersion gets the highest version of the module required by the applications
+func highestRequiredVersion(apps []common.AppProject, module string) string {
+
+	highest := ""
+	for _, app := range apps {
+		version, required := goModRequirements(app.SrcDir())[module]
+		if required && (highest == "" || compareModuleVersions(version, highest) > 0) {
+			highest = version
+		}
+	}
+
+	return highest
+}
+
+// alignAppModule requires the version of the module in the application and updates the version of the imports of
+// flogo.json provided by the module, the version resolved by Go is returned
+func alignAppModule(ctx context.Context, app common.AppProject, module, version string) (string, error) {
+
+	unlock, err := lockProject(app)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	err = app.DepManager().Get(ctx, module+"@"+version)
+	if err != nil {
+		return "", err
+	}
+
+	resolved := goModRequirements(app.SrcDir())[module]
+
+	var imports []string
+	_, err = readAppDescriptorValue(app, "$.imports", &imports)
+	if err != nil {
+		return "", err
+	}
+
+	aligned, changed := alignDescriptorImports(imports, module, resolved)
+	if changed {
+		err = writeAppDescriptorValue(app, "$.imports", aligned)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return resolved, nil
+}
+
+// alignDescriptorImports sets the version of the imports provided by the module, the imports without a version are
+// left unchanged as they use the version required by go.mod
+func alignDescriptorImports(imports []string, module, version string) ([]string, bool) {
+
+	changed := false
+	aligned := make([]string, len(imports))
+	for i, strImport := range imports {
+		aligned[i] = strImport
+
+		imp, err := util.ParseImport(strImport)
+		if err != nil || imp.Version() == "" || imp.Version() == version {
+			continue
+		}
+		if provider, _ := requiredModule(map[string]string{module: version}, imp.GoImportPath()); provider == "" {
+			continue
+		}
+
+		aligned[i] = util.NewFlogoImportWithVersion(imp, version).CanonicalImport()
+		changed = true
+	}
+
+	return aligned, changed
+}
@@ -0,0 +1,375 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+)
+
+const fileWorkspaceJson = "flogo.workspace.json"
+
+// workspaceConfig is the definition of a workspace in the flogo.workspace.json of its root, the directories are
+// relative to the root
+type workspaceConfig struct {
+	Apps     []string `json:"apps,omitempty"`     // the applications of the workspace, all the applications below the root by default
+	Contribs []string `json:"contribs,omitempty"` // the local contributions replacing their module in the applications
+}
+
+// wsContrib is a local contribution of a workspace, a directory containing a go.mod
+type wsContrib struct {
+	Dir    string
+	Module string
+}
+
+// AddWorkspaceContribs adds the local contribution directories to the flogo.workspace.json of the workspace and
+// replaces their module with the directory in the go.mod of every application of the workspace, so the applications
+// are built with the local code of the contributions
+func AddWorkspaceContribs(ctx context.Context, root string, dirs []string) error {
+
+	cfg, err := loadWorkspaceConfig(root)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		contrib, err := newWorkspaceContrib(dir)
+		if err != nil {
+			return err
+		}
+		rel := filepath.ToSlash(relWorkspacePath(root, contrib.Dir))
+		if isWorkspaceContrib(cfg, rel) {
+			continue
+		}
+		cfg.Contribs = append(cfg.Contribs, rel)
+		fmt.Printf("Added contribution '%s' (%s) to the workspace\n", rel, contrib.Module)
+	}
+
+	err = writeWorkspaceConfig(root, cfg)
+	if err != nil {
+		return err
+	}
+
+	return SyncWorkspace(ctx, root)
+}
+
+// RemoveWorkspaceContribs removes the local contribution directories from the flogo.workspace.json of the workspace
+// and drops the replaces of their module by the applications of the workspace
+func RemoveWorkspaceContribs(ctx context.Context, root string, dirs []string) error {
+
+	cfg, err := loadWorkspaceConfig(root)
+	if err != nil {
+		return err
+	}
+
+	apps, err := FindWorkspaceApps(root)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		rel := filepath.ToSlash(relWorkspacePath(root, dir))
+
+		if !isWorkspaceContrib(cfg, rel) {
+			return fmt.Errorf("'%s' isn't a contribution of the workspace", rel)
+		}
+		var contribs []string
+		for _, c := range cfg.Contribs {
+			if c != rel {
+				contribs = append(contribs, c)
+			}
+		}
+		cfg.Contribs = contribs
+
+		module := goModModulePath(dir)
+		if module == "" {
+			continue
+		}
+
+		for _, app := range apps {
+			target, replaced := goModReplaces(app.SrcDir())[module]
+			if !replaced || target != localReplacePath(app.SrcDir(), dir) {
+				// the module isn't replaced with the contribution, ex. the replace was changed by hand
+				continue
+			}
+			err = app.DepManager().Edit(ctx, "-dropreplace", module)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("  %s: dropped %s => %s\n", relWorkspacePath(root, app.Dir()), module, target)
+		}
+
+		fmt.Printf("Removed contribution '%s' (%s) from the workspace\n", rel, module)
+	}
+
+	return writeWorkspaceConfig(root, cfg)
+}
+
+// SyncWorkspace replaces the modules of the local contributions of the workspace with their directory in the go.mod of
+// every application of the workspace, ex. after an application is added to the workspace
+func SyncWorkspace(ctx context.Context, root string) error {
+
+	cfg, err := loadWorkspaceConfig(root)
+	if err != nil {
+		return err
+	}
+
+	contribs, err := workspaceContribs(root, cfg)
+	if err != nil {
+		return err
+	}
+	if len(contribs) == 0 {
+		fmt.Printf("No local contribution in the workspace, add them using 'flogo ws add-contrib <dir>'\n")
+		return nil
+	}
+
+	apps, err := FindWorkspaceApps(root)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, app := range apps {
+		replaced, err := replaceWorkspaceContribs(ctx, app, contribs)
+		if err != nil {
+			return fmt.Errorf("unable to update %s: %v", relWorkspacePath(root, app.Dir()), err)
+		}
+		for _, replace := range replaced {
+			fmt.Printf("  %s: %s\n", relWorkspacePath(root, app.Dir()), replace)
+		}
+		changed += len(replaced)
+	}
+
+	if changed == 0 {
+		fmt.Printf("The %d application(s) already use the %d local contribution(s)\n", len(apps), len(contribs))
+	}
+
+	return nil
+}
+
+// replaceWorkspaceContribs replaces the modules of the contributions with their directory in the go.mod of the
+// application, the replaces which changed are returned
+func replaceWorkspaceContribs(ctx context.Context, app common.AppProject, contribs []*wsContrib) ([]string, error) {
+
+	var replaced []string
+
+	current := goModReplaces(app.SrcDir())
+	for _, contrib := range contribs {
+		target := localReplacePath(app.SrcDir(), contrib.Dir)
+		if current[contrib.Module] == target {
+			continue
+		}
+		err := app.DepManager().Edit(ctx, "-replace", contrib.Module+"="+target)
+		if err != nil {
+			return replaced, err
+		}
+		replaced = append(replaced, contrib.Module+" => "+target)
+	}
+
+	return replaced, nil
+}
+
+// applyWorkspaceReplaces replaces the modules of the local contributions of the workspace containing the project, if
+// any, so installing a contribution developed in the workspace uses its local directory, the contributions are returned
+func applyWorkspaceReplaces(ctx context.Context, project common.AppProject) ([]*wsContrib, error) {
+
+	root := findWorkspaceRoot(project.Dir())
+	if root == "" {
+		return nil, nil
+	}
+
+	cfg, err := loadWorkspaceConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Contribs) == 0 {
+		return nil, nil
+	}
+
+	apps, err := FindWorkspaceApps(root)
+	if err != nil {
+		return nil, err
+	}
+	dir, _ := filepath.Abs(project.Dir())
+	member := false
+	for _, app := range apps {
+		if app.Dir() == dir {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return nil, nil
+	}
+
+	contribs, err := workspaceContribs(root, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced, err := replaceWorkspaceContribs(ctx, project, contribs)
+	if Verbose() {
+		for _, replace := range replaced {
+			fmt.Printf("Replaced %s using workspace '%s'\n", replace, root)
+		}
+	}
+
+	return contribs, err
+}
+
+// installWorkspaceContrib sets the version of an import provided by a local contribution to the version required by
+// the application, v0.0.0 when it isn't required yet, as the module of the contribution may not be published its latest
+// version can't be resolved
+func installWorkspaceContrib(project common.AppProject, contribs []*wsContrib, imp util.Import) util.Import {
+
+	if imp.Version() != "" {
+		return imp
+	}
+
+	modules := make(map[string]string)
+	for _, contrib := range contribs {
+		modules[contrib.Module] = "v0.0.0"
+	}
+	module, version := requiredModule(modules, imp.GoImportPath())
+	if module == "" {
+		return imp
+	}
+	if required, exists := goModRequirements(project.SrcDir())[module]; exists {
+		version = required
+	}
+
+	return util.NewFlogoImportWithVersion(imp, version)
+}
+
+// workspaceContribPath gets the local directory of an import provided by a local contribution, empty if none provides
+// it
+func workspaceContribPath(contribs []*wsContrib, imp util.Import) string {
+
+	pkg := imp.GoImportPath()
+	for _, contrib := range contribs {
+		if pkg == contrib.Module || strings.HasPrefix(pkg, contrib.Module+"/") {
+			return filepath.Join(contrib.Dir, filepath.FromSlash(strings.TrimPrefix(pkg, contrib.Module)))
+		}
+	}
+
+	return ""
+}
+
+// findWorkspaceRoot gets the closest directory containing a flogo.workspace.json, the directory or one of its parents,
+// empty if there is none
+func findWorkspaceRoot(dir string) string {
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		if util.FileExists(filepath.Join(dir, fileWorkspaceJson)) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func newWorkspaceContrib(dir string) (*wsContrib, error) {
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.DirExists(dir) {
+		return nil, fmt.Errorf("contribution directory '%s' not found", dir)
+	}
+	module := goModModulePath(dir)
+	if module == "" {
+		return nil, fmt.Errorf("contribution directory '%s' has no go.mod", dir)
+	}
+
+	return &wsContrib{Dir: dir, Module: module}, nil
+}
+
+func isWorkspaceContrib(cfg *workspaceConfig, rel string) bool {
+	for _, c := range cfg.Contribs {
+		if c == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceContribs gets the local contributions of the workspace
+func workspaceContribs(root string, cfg *workspaceConfig) ([]*wsContrib, error) {
+
+	var contribs []*wsContrib
+	for _, dir := range cfg.Contribs {
+		contrib, err := newWorkspaceContrib(filepath.Join(root, filepath.FromSlash(dir)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid contribution of %s: %v", fileWorkspaceJson, err)
+		}
+		contribs = append(contribs, contrib)
+	}
+
+	return contribs, nil
+}
+
+// localReplacePath gets the path of the directory used by a replace of the go.mod of srcDir, relative paths must
+// start with ./ or ../
+func localReplacePath(srcDir, dir string) string {
+
+	rel, err := filepath.Rel(srcDir, dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") && rel != ".." {
+		rel = "./" + rel
+	}
+
+	return rel
+}
+
+func loadWorkspaceConfig(root string) (*workspaceConfig, error) {
+
+	cfg := &workspaceConfig{}
+
+	buf, err := ioutil.ReadFile(filepath.Join(root, fileWorkspaceJson))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(buf, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", fileWorkspaceJson, err)
+	}
+
+	return cfg, nil
+}
+
+func writeWorkspaceConfig(root string, cfg *workspaceConfig) error {
+
+	buf, err := json.MarshalIndent(cfg, "", jsonIndent)
+	if err != nil {
+		return err
+	}
+
+	return util.WriteFileAtomic(filepath.Join(root, fileWorkspaceJson), append(buf, '\n'), 0644)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	fileFlogoYaml = "flogo.yaml"
+	fileFlogoYml  = "flogo.yml"
+)
+
+// yamlDescriptorFile gets the YAML app descriptor of the application, empty if the app descriptor is a JSON one
+func yamlDescriptorFile(appDir string) string {
+	for _, name := range []string{fileFlogoYaml, fileFlogoYml} {
+		file := filepath.Join(appDir, name)
+		if util.FileExists(file) {
+			return file
+		}
+	}
+	return ""
+}
+
+// syncYamlDescriptor converts the YAML app descriptor of the application to the flogo.json used by the commands
+// and the build, when the flogo.json is missing or older than the YAML app descriptor
+func syncYamlDescriptor(appDir string) error {
+
+	yamlFile := yamlDescriptorFile(appDir)
+	if yamlFile == "" {
+		return nil
+	}
+
+	yamlInfo, err := os.Stat(yamlFile)
+	if err != nil {
+		return err
+	}
+	jsonFile := filepath.Join(appDir, fileFlogoJson)
+	if jsonInfo, err := os.Stat(jsonFile); err == nil && !jsonInfo.ModTime().Before(yamlInfo.ModTime()) {
+		return nil
+	}
+
+	buf, err := ioutil.ReadFile(yamlFile)
+	if err != nil {
+		return err
+	}
+
+	appJson, err := util.YAMLToJSON(buf, jsonIndent)
+	if err != nil {
+		return fmt.Errorf("unable to parse %s: %v", filepath.Base(yamlFile), err)
+	}
+
+	if Verbose() {
+		fmt.Printf("Converting %s to %s\n", filepath.Base(yamlFile), fileFlogoJson)
+	}
+
+	return util.WriteFileAtomic(jsonFile, appJson, 0644)
+}
+
+// writeYamlDescriptor converts the app descriptor back to the YAML app descriptor of the application, if it has one,
+// so the modifications made by the commands aren't lost the next time the YAML app descriptor is converted
+func writeYamlDescriptor(appDir string, appDescriptor []byte) error {
+
+	yamlFile := yamlDescriptorFile(appDir)
+	if yamlFile == "" {
+		return nil
+	}
+
+	return writeYamlFile(appDir, yamlFile, appDescriptor)
+}
+
+// writeYamlFile converts the app descriptor to the YAML file
+func writeYamlFile(appDir, yamlFile string, appDescriptor []byte) error {
+
+	buf, err := util.JSONToYAML(appDescriptor)
+	if err != nil {
+		return err
+	}
+
+	err = util.WriteFileAtomic(yamlFile, buf, 0644)
+	if err != nil {
+		return err
+	}
+
+	// the flogo.json is already up to date, so it's kept from being converted again
+	now := time.Now()
+	return os.Chtimes(filepath.Join(appDir, fileFlogoJson), now, now)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncYamlDescriptor(t *testing.T) {
+	t.Log("Testing conversion of the YAML app descriptor to the flogo.json")
+
+	tempDir, err := ioutil.TempDir("", "flogo-yaml")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	jsonFile := filepath.Join(tempDir, fileFlogoJson)
+	yamlFile := filepath.Join(tempDir, fileFlogoYml)
+
+	// no YAML app descriptor
+	assert.Nil(t, syncYamlDescriptor(tempDir))
+	assert.False(t, util.FileExists(jsonFile))
+
+	err = ioutil.WriteFile(yamlFile, []byte("name: myApp\ntype: flogo:app\nimports: []\n"), 0644)
+	assert.Nil(t, err)
+	assert.Equal(t, yamlFile, yamlDescriptorFile(tempDir))
+
+	assert.Nil(t, syncYamlDescriptor(tempDir))
+	buf, err := ioutil.ReadFile(jsonFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"name\": \"myApp\",\n  \"type\": \"flogo:app\",\n  \"imports\": []\n}\n", string(buf))
+
+	// a modification of the flogo.json is written back to the YAML app descriptor, which isn't converted again
+	project := NewAppProject(tempDir)
+	assert.Nil(t, writeAppDescriptorValue(project, "$.imports", []string{"github.com/project-flogo/contrib/activity/log"}))
+	buf, err = ioutil.ReadFile(yamlFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "name: myApp\ntype: flogo:app\nimports:\n- github.com/project-flogo/contrib/activity/log\n", string(buf))
+
+	jsonInfo, err := os.Stat(jsonFile)
+	assert.Nil(t, err)
+	assert.Nil(t, syncYamlDescriptor(tempDir))
+	info, err := os.Stat(jsonFile)
+	assert.Nil(t, err)
+	assert.Equal(t, jsonInfo.ModTime(), info.ModTime())
+
+	// a modification of the YAML app descriptor is converted
+	later := time.Now().Add(time.Minute)
+	err = ioutil.WriteFile(yamlFile, []byte("name: myApp\ntype: [flogo:app\n"), 0644)
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chtimes(yamlFile, later, later))
+	assert.NotNil(t, syncYamlDescriptor(tempDir))
+	assert.NotNil(t, NewAppProject(tempDir).Validate())
+}
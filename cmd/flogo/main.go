@@ -12,6 +12,10 @@ import (
 // This latter file is generated with a "go generate" command.
 var Version string = ""
 
+// The release channel and commit of the CLI, set when it is released using -ldflags, ex.
+// -X main.Channel=stable -X main.Commit=$(git rev-parse HEAD)
+var Channel, Commit string
+
 //go:generate go run gen/version.go
 func main() {
 
@@ -22,6 +26,6 @@ func main() {
 
 	//Initialize the commands
 	_ = os.Setenv("GO111MODULE", "on")
-	commands.Initialize(Version)
+	commands.Initialize(Version, Channel, Commit)
 	commands.Execute()
 }
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "manage command aliases",
+	Long:  "Manage command aliases",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.SetVerbose(verbose)
+	},
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <alias> <expansion>",
+	Short: "define an alias",
+	Long:  "Defines an alias, $1..$n in the expansion are replaced by the arguments of the alias and $@ by all of them",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := setAlias(args[0], args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.setAlias", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list aliases",
+	Long:  "Lists the defined aliases",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		config, err := util.LoadCLIConfig()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listAliases", err))
+			os.Exit(1)
+		}
+
+		var names []string
+		for name := range config.Aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fmt.Printf("%-15s %s\n", name, config.Aliases[name])
+		}
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <alias>",
+	Short: "remove an alias",
+	Long:  "Removes an alias",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		config, err := util.LoadCLIConfig()
+		if err == nil {
+			if _, exists := config.Aliases[args[0]]; !exists {
+				err = fmt.Errorf("alias '%s' not found", args[0])
+			} else {
+				delete(config.Aliases, args[0])
+				err = util.SaveCLIConfig(config)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.removeAlias", err))
+			os.Exit(1)
+		}
+
+		fmt.Println(util.T("alias.removed", args[0]))
+	},
+}
+
+func setAlias(name, expansion string) error {
+
+	if name == "" || strings.ContainsAny(name, " \t") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid alias '%s'", name)
+	}
+
+	if isCommand(name) {
+		return fmt.Errorf("'%s' is a flogo command", name)
+	}
+
+	_, err := util.SplitArgs(expansion)
+	if err != nil {
+		return err
+	}
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.Aliases == nil {
+		config.Aliases = make(map[string]string)
+	}
+	config.Aliases[name] = expansion
+
+	err = util.SaveCLIConfig(config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(util.T("alias.set", name, expansion))
+
+	return nil
+}
+
+// expandAliases expands the alias used as command, if any
+func expandAliases(args []string) ([]string, error) {
+
+	// the alias is the first argument that isn't a flag
+	idx := -1
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			idx = i
+			break
+		}
+	}
+
+	if idx < 0 || isCommand(args[idx]) {
+		return args, nil
+	}
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	expansion, exists := config.Aliases[args[idx]]
+	if !exists {
+		return args, nil
+	}
+
+	expanded, err := util.ExpandAlias(expansion, args[idx+1:])
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand alias '%s': %v", args[idx], err)
+	}
+
+	return append(append([]string{}, args[:idx]...), expanded...), nil
+}
+
+func isCommand(name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var applyOptions api.ApplyOptions
+
+func init() {
+	applyCmd.Flags().BoolVarP(&applyOptions.DryRun, "dry-run", "", false, "print the changes of the script without keeping them")
+	rootCmd.AddCommand(applyCmd)
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <script>",
+	Short: "apply a script of operations to the project",
+	Long: `Applies a script of operations (install, remove, property and version) to the project as a single transaction.
+If an operation fails the project is rolled back to its state before the script, otherwise the changes are printed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ApplyScript(commandContext(), common.CurrentProject(), args[0], applyOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.applyScript", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var blueprintPublishOptions api.BlueprintPublishOptions
+var blueprintUseOptions api.BlueprintUseOptions
+
+func init() {
+	blueprintPublishCmd.Flags().StringVarP(&blueprintPublishOptions.Name, "name", "n", "", "name of the blueprint, defaults to the app name")
+	blueprintPublishCmd.Flags().StringVarP(&blueprintPublishOptions.Output, "output", "o", "", "write the blueprint to a file instead of the catalog")
+	blueprintUseCmd.Flags().StringArrayVarP(&blueprintUseOptions.Vars, "var", "", nil, "value of a variable of the blueprint (ex. --var log.level=DEBUG)")
+	blueprintUseCmd.Flags().BoolVarP(&blueprintUseOptions.Interactive, "interactive", "i", false, "prompt for the values of the variables which aren't set")
+	blueprintUseCmd.Flags().BoolVarP(&blueprintUseOptions.Yes, "yes", "y", false, "run the hooks of the blueprint without confirmation")
+	blueprintUseCmd.Flags().BoolVarP(&blueprintUseOptions.NoHooks, "no-hooks", "", false, "don't run the hooks of the blueprint")
+	blueprintCmd.AddCommand(blueprintPublishCmd)
+	blueprintCmd.AddCommand(blueprintUseCmd)
+	blueprintCmd.AddCommand(blueprintListCmd)
+	rootCmd.AddCommand(blueprintCmd)
+}
+
+var blueprintCmd = &cobra.Command{
+	Use:   "blueprint",
+	Short: "manage application blueprints",
+	Long:  `Manage blueprints, shareable starting points created from applications.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var blueprintPublishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "publish the application as a blueprint",
+	Long: `Packages the app descriptor, with its secret values stripped, its app properties as variables and the README of the project into a blueprint.
+The variables and the hooks declared in the blueprint.json of the project are added to the blueprint.
+The blueprint is added to the local catalog, unless an output file is specified.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PublishBlueprint(common.CurrentProject(), blueprintPublishOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.publishBlueprint", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var blueprintUseCmd = &cobra.Command{
+	Use:   "use [flags] <blueprint> [appName]",
+	Short: "create an application from a blueprint",
+	Long: `Creates an application project from a blueprint of the catalog, or a blueprint file or URL, setting the app properties to the values of the variables.
+The hooks of the blueprint, such as the installation of contributions depending on the values of the variables, are then run once confirmed.`,
+	Args: cobra.RangeArgs(1, 2),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) > 1 {
+			blueprintUseOptions.AppName = args[1]
+		}
+
+		currentDir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.workingDir", err))
+			os.Exit(1)
+		}
+
+		_, err = api.UseBlueprint(commandContext(), currentDir, args[0], blueprintUseOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.createFromBlueprint", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var blueprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the published blueprints",
+	Long:  `Lists the blueprints of the local catalog.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListBlueprints()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listBlueprints", err))
+			os.Exit(1)
+		}
+	},
+}
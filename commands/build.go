@@ -5,117 +5,327 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/project-flogo/cli/api"
 	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 )
 
 var buildShim string
+var buildShimTarget string
 var buildOptimize bool
 var buildEmbed bool
+var buildFailOnSecrets bool
+var buildVariant string
+var buildProfile string
+var buildProfileEnv bool
+var buildTags []string
+var buildManagement bool
+var buildTrace string
+var buildPlatforms []string
+var buildDocker bool
+var buildDockerOptions common.DockerOptions
+var buildProvenance bool
+var buildProvenanceKey string
+var buildSigning common.SigningOptions
+var buildFrozen bool
+var buildOffline bool
+var buildBundle string
+var buildPublish string
+var buildSmoke bool
+var buildSmokeTimeout time.Duration
+var buildCompose bool
+var buildForce bool
 var syncImport bool
 var flogoJsonFile string
 
 func init() {
 	buildCmd.Flags().StringVarP(&buildShim, "shim", "", "", "use shim trigger")
+	buildCmd.Flags().StringVarP(&buildShimTarget, "shim-target", "", "", "package the shim in a zip for a serverless platform [lambda, azure, gcf]")
 	buildCmd.Flags().BoolVarP(&buildOptimize, "optimize", "o", false, "optimize build")
 	buildCmd.Flags().BoolVarP(&buildEmbed, "embed", "e", false, "embed configuration in binary")
-	buildCmd.Flags().StringVarP(&flogoJsonFile, "file", "f", "", "specify a flogo.json to build")
+	buildCmd.Flags().StringVarP(&flogoJsonFile, "file", "f", "", "specify a flogo.json or flogo.yaml to build")
+	buildCmd.Flags().BoolVarP(&buildCompose, "compose", "", false, "build the specified flogo.json files into a single application")
 	buildCmd.Flags().BoolVarP(&syncImport, "sync", "s", false, "sync imports during build")
+	buildCmd.Flags().BoolVarP(&buildForce, "force", "", false, "run go build even if the application is up to date")
+	buildCmd.Flags().StringVarP(&buildVariant, "variant", "", "", "build using the specified resource variant")
+	buildCmd.Flags().StringVarP(&buildProfile, "profile", "", "", "bake the app property values of the profile into the application")
+	buildCmd.Flags().BoolVarP(&buildProfileEnv, "profile-env", "", false, "write the values of the profile to bin/<app name>-<profile>.env instead of baking them in")
+	buildCmd.Flags().StringSliceVarP(&buildTags, "tags", "", nil, "build tags, enables the imports conditional on these tags")
+	buildCmd.Flags().BoolVarP(&buildManagement, "management", "", false, "enable the management API used by 'flogo remote'")
+	buildCmd.Flags().StringVarP(&buildTrace, "trace", "", "", "write the execution traces of the flows and their tasks in JSONL to a file or stdout")
+	buildCmd.Flags().Lookup("trace").NoOptDefVal = api.DefaultTraceFile
+	buildCmd.Flags().StringSliceVarP(&buildPlatforms, "platforms", "", nil, "build one executable per platform, as os/arch or os/arch-variant (ex. linux/amd64,windows/arm64,linux/amd64-musl)")
+	buildCmd.Flags().BoolVarP(&buildDocker, "docker", "", false, "build a container image of the application instead of an executable")
+	buildCmd.Flags().StringVarP(&buildDockerOptions.Image, "image", "", "", "name and tag of the image, <app name>:<app version> by default")
+	buildCmd.Flags().StringVarP(&buildDockerOptions.Base, "base-image", "", "", "base image the executable is copied to, ex. scratch (default \"gcr.io/distroless/static\")")
+	buildCmd.Flags().BoolVarP(&buildDockerOptions.Push, "push", "", false, "push the image once built")
+	buildCmd.Flags().BoolVarP(&buildProvenance, "provenance", "", false, "write the SLSA provenance of the executables to bin/<app name>.intoto.jsonl")
+	buildCmd.Flags().StringVarP(&buildProvenanceKey, "provenance-key", "", "", "PEM file of the ECDSA or RSA private key signing the envelope of the provenance")
+	buildCmd.Flags().StringVarP(&buildSigning.Signer, "sign", "", "", "sign the executables and their provenance [cosign, minisign]")
+	buildCmd.Flags().StringVarP(&buildSigning.Key, "signing-key", "", "", "key of the signer, a file or a KMS URI for cosign (default $"+api.EnvSigningKey+" or the key of the signer)")
+	buildCmd.Flags().BoolVarP(&buildFrozen, "frozen", "", false, "fail if the imports don't resolve to the versions of flogo.lock")
+	buildCmd.Flags().BoolVarP(&buildOffline, "offline", "", false, "build without downloading modules, from the module cache or the bundle")
+	buildCmd.Flags().StringVarP(&buildBundle, "bundle", "", "", "bundle of modules created by 'flogo bundle' to build from, implies --offline")
+	buildCmd.Flags().StringVarP(&buildPublish, "publish", "", "", "publish the artifacts of the build to a target defined in publish.json")
+	buildCmd.Flags().BoolVarP(&buildFailOnSecrets, "fail-on-secrets", "", false, "fail the build if plaintext secrets are found")
+	buildCmd.Flags().BoolVarP(&buildSmoke, "smoke", "", false, "start the built application to check that the engine and its triggers start")
+	buildCmd.Flags().DurationVarP(&buildSmokeTimeout, "smoke-timeout", "", api.DefaultSmokeTimeout, "time given to the application to start during the smoke test")
 	rootCmd.AddCommand(buildCmd)
 }
 
 //Build the project.
 var buildCmd = &cobra.Command{
-	Use:              "build",
+	Use:              "build [flags] [--compose <flogo.json>...]",
 	Short:            "build the flogo application",
 	Long:             `Build the flogo application.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
+		if _, err = api.ParsePlatforms(buildPlatforms); err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.build", err))
+			os.Exit(1)
+		}
+		if !buildDocker && (buildDockerOptions.Image != "" || buildDockerOptions.Base != "" || buildDockerOptions.Push) {
+			fmt.Fprintln(os.Stderr, util.T("error.build.dockerRequired"))
+			os.Exit(1)
+		}
+		if (buildOffline || buildBundle != "") && buildDocker {
+			fmt.Fprintln(os.Stderr, util.T("error.build.offlineDocker"))
+			os.Exit(1)
+		}
+		if buildBundle != "" {
+			// the build of a specified file runs in a temporary project
+			buildBundle, err = filepath.Abs(buildBundle)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.build", err))
+				os.Exit(1)
+			}
+		}
+		if buildPublish != "" && (buildDocker || flogoJsonFile != "") {
+			fmt.Fprintln(os.Stderr, util.T("error.build.publishDocker"))
+			os.Exit(1)
+		}
+		if buildProvenanceKey != "" && !buildProvenance {
+			fmt.Fprintln(os.Stderr, util.T("error.build.provenanceRequired"))
+			os.Exit(1)
+		}
+		if buildProvenanceKey != "" && buildSigning.Signer != "" {
+			fmt.Fprintln(os.Stderr, util.T("error.build.provenanceKeySign"))
+			os.Exit(1)
+		}
+		if buildSigning.Key != "" && buildSigning.Signer == "" {
+			fmt.Fprintln(os.Stderr, util.T("error.build.signRequired"))
+			os.Exit(1)
+		}
+		if buildCompose {
+			flogoJsonFile = composeDescriptors(args)
+		}
 		if flogoJsonFile == "" {
 			preRun(cmd, args, verbose)
-			options := common.BuildOptions{Shim: buildShim, OptimizeImports: buildOptimize, EmbedConfig: buildEmbed}
+			options := common.BuildOptions{Shim: buildShim, ShimTarget: buildShimTarget, OptimizeImports: buildOptimize, EmbedConfig: buildEmbed, FailOnSecrets: buildFailOnSecrets, Variant: buildVariant, Profile: buildProfile, ProfileEnv: buildProfileEnv, Tags: buildTags, Management: buildManagement, Trace: buildTrace, Platforms: buildPlatforms, Docker: dockerOptions(), Provenance: provenanceOptions(), Frozen: buildFrozen, Offline: buildOffline, Bundle: buildBundle, Force: buildForce, Signing: signingOptions()}
 
 			if syncImport {
-				err = api.SyncProjectImports(common.CurrentProject())
+				err = api.SyncProjectImports(commandContext(), common.CurrentProject(), api.ImportsSyncOptions{})
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error synchronzing imports: %v\n", err)
+					fmt.Fprintln(os.Stderr, util.T("error.syncImports", err))
 					os.Exit(1)
 				}
 			}
 
-			err = api.BuildProject(common.CurrentProject(), options)
+			err = api.BuildProject(commandContext(), common.CurrentProject(), options)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error building project: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.build", err))
 				os.Exit(1)
 			}
+
+			smokeTest(common.CurrentProject())
+			publish(common.CurrentProject())
 		} else {
 			//If a jsonFile is specified in the build.
 			//Create a new project in the temp folder and copy the bin.
 
 			tempDir, err := api.GetTempDir()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting temp dir: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.tempDir", err))
 				os.Exit(1)
 			}
 
 			api.SetVerbose(verbose)
-			tempProject, err := api.CreateProject(tempDir, "", flogoJsonFile, "latest")
+			tempProject, err := api.CreateProject(commandContext(), tempDir, "", flogoJsonFile, "latest")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error creating temp project: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.createTempProject", err))
 				os.Exit(1)
 			}
 
 			common.SetCurrentProject(tempProject)
 
-			options := common.BuildOptions{Shim: buildShim, OptimizeImports: buildOptimize, EmbedConfig: buildEmbed}
+			provenance := provenanceOptions()
+			if provenance != nil {
+				// the temp project is removed once built, the provenance is written next to the executable
+				provenance.File = tempProject.Name() + ".intoto.jsonl"
+			}
+
+			options := common.BuildOptions{Shim: buildShim, ShimTarget: buildShimTarget, OptimizeImports: buildOptimize, EmbedConfig: buildEmbed, FailOnSecrets: buildFailOnSecrets, Variant: buildVariant, Profile: buildProfile, ProfileEnv: buildProfileEnv, Tags: buildTags, Management: buildManagement, Trace: buildTrace, Platforms: buildPlatforms, Docker: dockerOptions(), Provenance: provenance, Frozen: buildFrozen, Offline: buildOffline, Bundle: buildBundle, Force: buildForce, Signing: signingOptions()}
 
-			err = api.BuildProject(common.CurrentProject(), options)
+			err = api.BuildProject(commandContext(), common.CurrentProject(), options)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error building temp project: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.buildTempProject", err))
 				os.Exit(1)
 			}
 
+			smokeTest(tempProject)
+
+			if buildDocker {
+				// the image is the output of the build
+				err = os.RemoveAll(tempProject.Dir())
+				if err != nil {
+					fmt.Fprintln(os.Stderr, util.T("error.removeTempDir", err))
+					os.Exit(1)
+				}
+				return
+			}
+
 			copyBin(verbose, tempProject)
 		}
 	},
 }
 
+// composeDescriptors merges the descriptors into a temporary flogo.json, which is built as the one of a specified file
+func composeDescriptors(files []string) string {
+
+	if flogoJsonFile != "" {
+		fmt.Fprintln(os.Stderr, util.T("error.compose.file"))
+		os.Exit(1)
+	}
+
+	tempDir, err := api.GetTempDir()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error.tempDir", err))
+		os.Exit(1)
+	}
+
+	api.SetVerbose(verbose)
+	composedFile := filepath.Join(tempDir, "flogo.json")
+	err = api.ComposeAppDescriptors(files, composedFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error.compose", err))
+		os.Exit(1)
+	}
+
+	fmt.Println(util.T("build.composed", len(files)))
+
+	return composedFile
+}
+
+// dockerOptions gets the options of the image to build, nil if the build doesn't build an image
+func dockerOptions() *common.DockerOptions {
+	if !buildDocker {
+		return nil
+	}
+	return &buildDockerOptions
+}
+
+// provenanceOptions gets the options of the provenance of the executables, nil if it isn't written
+func provenanceOptions() *common.ProvenanceOptions {
+	if !buildProvenance {
+		return nil
+	}
+	return &common.ProvenanceOptions{SignKey: buildProvenanceKey, BuilderVersion: rootCmd.Version}
+}
+
+// signingOptions gets the options of the signatures of the artifacts, nil if they aren't signed
+func signingOptions() *common.SigningOptions {
+	if buildSigning.Signer == "" {
+		return nil
+	}
+	return &buildSigning
+}
+
+func smokeTest(project common.AppProject) {
+
+	if !buildSmoke {
+		return
+	}
+
+	goarch := os.Getenv("GOARCH")
+	if buildShim != "" || len(buildPlatforms) > 0 || buildDocker || api.GOOSENV != "" && api.GOOSENV != runtime.GOOS || goarch != "" && goarch != runtime.GOARCH {
+		fmt.Println(util.T("build.smokeTestSkipped"))
+		return
+	}
+
+	err := api.SmokeTestApp(project, buildSmokeTimeout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error.smokeTest", err))
+		os.Exit(1)
+	}
+}
+
+func publish(project common.AppProject) {
+
+	if buildPublish == "" {
+		return
+	}
+
+	err := api.PublishArtifacts(project, buildPublish, buildPlatforms)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error.publish", err))
+		os.Exit(1)
+	}
+}
+
 func copyBin(verbose bool, tempProject common.AppProject) {
 
 	currDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error determining working directory: %v\n", err)
+		fmt.Fprintln(os.Stderr, util.T("error.workingDir", err))
 		os.Exit(1)
 	}
 
 	if verbose {
-		fmt.Printf("Copying the binary from  %s to %s \n", tempProject.BinDir(), currDir)
+		fmt.Println(util.T("build.copyingBinary", tempProject.BinDir(), currDir))
 	}
 
-	if runtime.GOOS == "windows" || api.GOOSENV == "windows" {
-		err = os.Rename(tempProject.Executable(), filepath.Join(currDir, "main.exe"))
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error renaming executable: %v\n", err)
-			os.Exit(1)
+	if len(buildPlatforms) > 0 {
+		platforms, _ := api.ParsePlatforms(buildPlatforms)
+		for _, platform := range platforms {
+			exe := api.PlatformExecutable(tempProject, platform)
+			renameExecutable(exe, filepath.Join(currDir, filepath.Base(exe)))
 		}
+	} else if runtime.GOOS == "windows" || api.GOOSENV == "windows" {
+		renameExecutable(tempProject.Executable(), filepath.Join(currDir, "main.exe"))
 	} else {
-		err = os.Rename(tempProject.Executable(), filepath.Join(currDir, tempProject.Name()))
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error renaming executable: %v\n", err)
-			os.Exit(1)
-		}
+		renameExecutable(tempProject.Executable(), filepath.Join(currDir, tempProject.Name()))
 	}
 
 	if verbose {
-		fmt.Printf("Removing the temp dir: %s\n ", tempProject.Dir())
+		fmt.Println(util.T("build.removingTempDir", tempProject.Dir()))
 	}
 
 	err = os.RemoveAll(tempProject.Dir())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error removing temp dir: %v\n", err)
+		fmt.Fprintln(os.Stderr, util.T("error.removeTempDir", err))
+		os.Exit(1)
+	}
+}
+
+// renameExecutable moves the executable and its signatures, which are named after it
+func renameExecutable(exe, dest string) {
+
+	for _, signature := range api.SignatureFiles(exe) {
+		err := os.Rename(signature, dest+strings.TrimPrefix(signature, exe))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.renameSignature", err))
+			os.Exit(1)
+		}
+	}
+
+	err := os.Rename(exe, dest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error.renameExecutable", err))
 		os.Exit(1)
 	}
 }
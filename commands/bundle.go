@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var bundleOutput string
+
+func init() {
+	bundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "file the bundle is written to, <app name>-modules.tar.gz by default")
+	rootCmd.AddCommand(bundleCmd)
+}
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle [flags]",
+	Short: "bundle the modules required by the application",
+	Long: `Writes a tarball of the modules required to build the application, the subset of the module cache it uses.
+Build from it where the module proxy can't be reached using 'flogo build --offline --bundle <file>'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := api.BundleModules(commandContext(), common.CurrentProject(), api.BundleOptions{Output: bundleOutput})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.bundle", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var cachePruneOptions api.CachePruneOptions
+var cacheMaxSize string
+
+func init() {
+	cachePruneCmd.Flags().DurationVar(&cachePruneOptions.MaxAge, "max-age", 30*24*time.Hour, "remove the entries not modified for longer, 0 to keep them whatever their age")
+	cachePruneCmd.Flags().StringVar(&cacheMaxSize, "max-size", "", "remove the oldest entries until the caches fit in this size (ex. 500MB, 2GB)")
+	cachePruneCmd.Flags().BoolVarP(&cachePruneOptions.All, "all", "a", false, "remove all the entries")
+	cachePruneCmd.Flags().BoolVarP(&cachePruneOptions.DryRun, "dry-run", "n", false, "print the entries which would be removed, without removing them")
+	cacheCmd.AddCommand(cachePruneCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "manage the caches of the CLI",
+	Long: `Manage the caches of the CLI: the descriptor cache of the project, and the registry index, templates, toolchains
+and offline bundles cached in the flogo home ($FLOGO_HOME or ~/.flogo).`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional, its descriptor cache is only pruned when run in the project directory
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune [flags] [cache...]",
+	Short: "remove the old entries of the caches",
+	Long: `Removes the entries of the caches not modified for longer than the maximum age, then the oldest ones until the caches fit in the maximum size.
+The caches are descriptors (the project's), registry, templates, toolchains and bundles, all of them are pruned by default.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if cacheMaxSize != "" {
+			maxSize, err := util.ParseByteSize(cacheMaxSize)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.pruneCaches", err))
+				os.Exit(1)
+			}
+			cachePruneOptions.MaxSize = maxSize
+		}
+
+		cachePruneOptions.Caches = args
+		err := api.PruneCaches(common.CurrentProject(), cachePruneOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.pruneCaches", err))
+			os.Exit(1)
+		}
+	},
+}
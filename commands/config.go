@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var configProject bool
+
+func init() {
+	configSetCmd.Flags().BoolVarP(&configProject, "project", "p", false, "set it in the configuration of the project rather than of the cli")
+	configUnsetCmd.Flags().BoolVarP(&configProject, "project", "p", false, "remove it from the configuration of the project rather than of the cli")
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "manage the configuration of the cli",
+	Long: `Manages the Go environment of the go commands run by the cli: ` + strings.Join(util.GoEnvSettings(), ", ") + `.
+The settings are saved in the configuration of the cli, shared by all projects, or of a project using --project, which overrides it. The variables set in the environment take precedence.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional unless the setting is for the project
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "set a setting",
+	Long:  "Sets a setting of the configuration, ex. goproxy https://proxy.example.com,direct",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project, err := configTarget()
+		if err == nil {
+			err = api.SetGoEnvConfig(project, args[0], args[1])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.setSetting", args[0], err))
+			os.Exit(1)
+		}
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <setting>",
+	Short: "remove a setting",
+	Long:  "Removes a setting from the configuration",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project, err := configTarget()
+		if err == nil {
+			err = api.UnsetGoEnvConfig(project, args[0])
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.removeSetting", args[0], err))
+			os.Exit(1)
+		}
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the settings",
+	Long:  "Lists the settings used in the current directory, with where their value comes from: the environment, the project or the cli configuration",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListGoEnvConfig(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listSettings", err))
+			os.Exit(1)
+		}
+	},
+}
+
+// configTarget gets the project whose configuration is changed, nil for the configuration of the cli
+func configTarget() (common.AppProject, error) {
+
+	if !configProject {
+		return nil, nil
+	}
+	project := common.CurrentProject()
+	if project == nil {
+		return nil, fmt.Errorf("not in a project, --project requires a project")
+	}
+
+	return project, nil
+}
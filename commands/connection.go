@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var connRef string
+var connSettings []string
+var connInline bool
+var connJson bool
+
+func init() {
+	connectionAddCmd.Flags().StringVarP(&connRef, "ref", "r", "", "ref of the connection contribution")
+	connectionAddCmd.Flags().StringArrayVarP(&connSettings, "setting", "s", nil, "connection setting (ex. host=localhost)")
+	connectionRemoveCmd.Flags().BoolVarP(&connInline, "inline", "", false, "replace references with an inline copy of the connection")
+	connectionListCmd.Flags().BoolVarP(&connJson, "json", "j", false, "print in json format")
+	connectionCmd.AddCommand(connectionAddCmd)
+	connectionCmd.AddCommand(connectionListCmd)
+	connectionCmd.AddCommand(connectionRemoveCmd)
+	rootCmd.AddCommand(connectionCmd)
+}
+
+var connectionCmd = &cobra.Command{
+	Use:   "connection",
+	Short: "manage shared connections",
+	Long:  `Manage the shared connections of the application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var connectionAddCmd = &cobra.Command{
+	Use:   "add [flags] <id>",
+	Short: "add a shared connection",
+	Long:  `Adds a shared connection, identical inline connections are replaced by a reference to it.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.AddConnection(common.CurrentProject(), args[0], connRef, connSettings)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.addConnection", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var connectionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list shared connections",
+	Long:  `Lists the shared connections and where they are referenced.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListConnections(common.CurrentProject(), connJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listConnections", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var connectionRemoveCmd = &cobra.Command{
+	Use:   "remove [flags] <id>",
+	Short: "remove a shared connection",
+	Long:  `Removes a shared connection.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RemoveConnection(common.CurrentProject(), args[0], connInline)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.removeConnection", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(analyzeCrashCmd)
+}
+
+var analyzeCrashCmd = &cobra.Command{
+	Use:   "analyze-crash [log]",
+	Short: "analyze the panic of an application",
+	Long: `Analyzes the panic in the log of an application, by default the log of the application started with 'flogo start'.
+The frames of the stack trace are mapped to the contributions and tasks of the project, when run in the project directory.`,
+	Args: cobra.MaximumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional, the log may be analyzed outside of it
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		logFile := ""
+		if len(args) > 0 {
+			logFile = args[0]
+		}
+
+		err := api.AnalyzeCrash(common.CurrentProject(), logFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.analyzeCrash", err))
+			os.Exit(1)
+		}
+	},
+}
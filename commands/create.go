@@ -5,15 +5,22 @@ import (
 	"os"
 
 	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 )
 
 var flogoJsonPath string
 var coreVersion string
+var contribModule string
+var createInteractive bool
 
 func init() {
-	CreateCmd.Flags().StringVarP(&flogoJsonPath, "file", "f", "", "specify a flogo.json to create project from")
+	CreateCmd.Flags().StringVarP(&flogoJsonPath, "file", "f", "", "specify a flogo.json or flogo.yaml to create project from, a file, a URL or <git repository>#[<ref>:]<path>")
 	CreateCmd.Flags().StringVarP(&coreVersion, "cv", "", "", "specify core library version (ex. master)")
+	CreateCmd.Flags().BoolVarP(&createInteractive, "interactive", "i", false, "guide through the name, core version, contributions, module path and license of the application")
+	for _, contribType := range []string{api.ContribActivity, api.ContribTrigger, api.ContribAction, api.ContribFunction} {
+		CreateCmd.AddCommand(newCreateContribCmd(contribType))
+	}
 	rootCmd.AddCommand(CreateCmd)
 }
 
@@ -33,13 +40,51 @@ var CreateCmd = &cobra.Command{
 
 		currentDir, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error determining working directory: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.workingDir", err))
 			os.Exit(1)
 		}
-		_, err = api.CreateProject(currentDir, appName, flogoJsonPath, coreVersion)
+		if createInteractive {
+			if flogoJsonPath != "" {
+				fmt.Fprintln(os.Stderr, util.T("error.create.interactiveFile"))
+				os.Exit(1)
+			}
+			_, err = api.CreateProjectInteractive(commandContext(), currentDir, appName, coreVersion)
+		} else {
+			_, err = api.CreateProject(commandContext(), currentDir, appName, flogoJsonPath, coreVersion)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating project: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.create", err))
 			os.Exit(1)
 		}
 	},
 }
+
+// newCreateContribCmd creates the command scaffolding a type of contribution
+func newCreateContribCmd(contribType string) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:   contribType + " [flags] <name>",
+		Short: "create a flogo " + contribType + " project",
+		Long:  `Creates a project for a new ` + contribType + `: its descriptor, metadata, implementation skeleton and a test, in a directory named after it.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+
+			api.SetVerbose(verbose)
+
+			currentDir, err := os.Getwd()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.workingDir", err))
+				os.Exit(1)
+			}
+			_, err = api.CreateContrib(commandContext(), currentDir, api.ContribOptions{Type: contribType, Name: args[0], Module: contribModule, CoreVersion: coreVersion})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.createApp", contribType, err))
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&contribModule, "module", "m", "", "Go module of the "+contribType+", its name by default (ex. github.com/myorg/"+contribType+"/mycontrib)")
+	cmd.Flags().StringVarP(&coreVersion, "cv", "", "", "specify core library version (ex. master)")
+
+	return cmd
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var debugFlowOptions api.DebugFlowOptions
+
+func init() {
+	debugFlowCmd.Flags().StringVarP(&debugFlowOptions.Input, "input", "i", "", "JSON file containing the input of the flow")
+	debugFlowCmd.Flags().StringArrayVarP(&debugFlowOptions.Data, "data", "d", nil, "input value of the flow (ex. --data name=bob)")
+	debugFlowCmd.Flags().StringSliceVarP(&debugFlowOptions.Breaks, "break", "b", nil, "tasks to pause at, all the tasks by default")
+	rootCmd.AddCommand(debugFlowCmd)
+}
+
+var debugFlowCmd = &cobra.Command{
+	Use:   "debug-flow [flags] <flow-id>",
+	Short: "debug a flow step by step",
+	Long: `Runs a flow of the application against the input, pausing before each task to show the data of the flow.
+At each pause the task can be executed, skipped or skipped using outputs provided instead, the application is run without its triggers.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.DebugFlow(commandContext(), common.CurrentProject(), args[0], debugFlowOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.debugFlow", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var deployTarget string
+var deploySkipBuild bool
+var deployList bool
+
+func init() {
+	deployCmd.Flags().StringVarP(&deployTarget, "target", "t", "", "target to deploy to, as defined in deploy.json")
+	deployCmd.Flags().BoolVarP(&deploySkipBuild, "skip-build", "", false, "deploy the existing executable without building it")
+	deployCmd.Flags().BoolVarP(&deployList, "list", "l", false, "list the deploy targets")
+	rootCmd.AddCommand(deployCmd)
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy [flags]",
+	Short: "deploy the application",
+	Long:  "Builds the application and deploys it to a target defined in the project's deploy.json",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if deployList {
+			err := api.ListDeployTargets(common.CurrentProject())
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.listDeployTargets", err))
+				os.Exit(1)
+			}
+			return
+		}
+
+		if deployTarget == "" {
+			fmt.Fprintln(os.Stderr, util.T("error.deploy.noTarget"))
+			os.Exit(1)
+		}
+
+		err := api.DeployApp(commandContext(), common.CurrentProject(), deployTarget, deploySkipBuild)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.deploy", err))
+			os.Exit(1)
+		}
+	},
+}
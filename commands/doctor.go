@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var doctorJson bool
+
+func init() {
+	doctorCmd.Flags().BoolVarP(&doctorJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [flags]",
+	Short: "diagnose the toolchain and the project",
+	Long: `Diagnoses the Go toolchain (version, GOFLAGS, reachability of the module proxies) and, when run in a project
+directory, the layout of the project and the consistency of its flogo.json, imports.go and go.mod, printing the fix of each problem found.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional and diagnosed even if it isn't valid
+		if currentDir, err := os.Getwd(); err == nil {
+			if appProject := api.DoctorProject(currentDir); appProject != nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		report := api.RunDoctor(common.CurrentProject())
+
+		err := api.PrintDoctorReport(report, doctorJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.diagnostics", err))
+			os.Exit(1)
+		}
+
+		if report.HasErrors() {
+			os.Exit(1)
+		}
+	},
+}
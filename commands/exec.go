@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var execOptions api.ExecOptions
+
+func init() {
+	execCmd.Flags().StringVarP(&execOptions.Input, "input", "i", "", "JSON file containing the input of the flow or action")
+	execCmd.Flags().StringArrayVarP(&execOptions.Data, "data", "d", nil, "input value of the flow or action (ex. --data name=bob)")
+	rootCmd.AddCommand(execCmd)
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] <flow-id|action-id>",
+	Short: "run a flow or action once",
+	Long: `Runs a flow or a shared action of the application once against the input and prints its output as JSON.
+The application is run without its triggers, the logs of the engine are written to stderr.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ExecAction(commandContext(), common.CurrentProject(), args[0], execOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.exec", args[0], err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var explainJson bool
+
+func init() {
+	explainCmd.Flags().BoolVarP(&explainJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(explainCmd)
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [flags] <json-path|id>",
+	Short: "explain an element of the application",
+	Long: `Explains a trigger, handler, flow, task or action of the application: what it is, the contribution implementing it, its settings with the app properties resolved and the flows related to it.
+The element is identified by its id (ex. rest, rest/get_order, flow:orders, flow:orders/log) or a JSON path of the flogo.json (ex. $.triggers[0].handlers[1]).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ExplainElement(common.CurrentProject(), args[0], explainJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.explain", args[0], err))
+			os.Exit(1)
+		}
+	},
+}
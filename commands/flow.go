@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	flowCmd.AddCommand(flowListCmd)
+	flowCmd.AddCommand(flowNewVersionCmd)
+	flowCmd.AddCommand(flowPromoteCmd)
+	flowCmd.AddCommand(flowRollbackCmd)
+	rootCmd.AddCommand(flowCmd)
+}
+
+var flowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "manage application flows",
+	Long:  `Manage the flows of the application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var flowListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list flows and their versions",
+	Long:  `Lists the flows and their versions, versions used by handlers are marked with a '*'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListFlowVersions(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listFlows", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var flowNewVersionCmd = &cobra.Command{
+	Use:   "new-version <flow>",
+	Short: "create a new version of a flow",
+	Long:  `Creates a new version of a flow, copied from the version currently used by handlers.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.NewFlowVersion(common.CurrentProject(), args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.createFlowVersion", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var flowPromoteCmd = &cobra.Command{
+	Use:   "promote <flow> <version>",
+	Short: "promote a version of a flow",
+	Long:  `Points all the handlers using the flow at the specified version.`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PromoteFlowVersion(common.CurrentProject(), args[0], args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.promoteFlowVersion", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var flowRollbackCmd = &cobra.Command{
+	Use:   "rollback <flow>",
+	Short: "rollback a flow to its previous version",
+	Long:  `Points all the handlers using the flow at the version preceding the one currently used.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RollbackFlowVersion(common.CurrentProject(), args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.rollbackFlowVersion", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var idePort int
+
+func init() {
+	ideServeCmd.Flags().IntVarP(&idePort, "port", "p", 0, "port to listen on, a free port is chosen if not specified")
+	ideCmd.AddCommand(ideServeCmd)
+	rootCmd.AddCommand(ideCmd)
+}
+
+var ideCmd = &cobra.Command{
+	Use:   "ide",
+	Short: "backend for editor extensions",
+	Long:  `Backend for editor extensions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var ideServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "serve the IDE API",
+	Long:  `Serves a JSON-RPC API on localhost providing project introspection and operations for editor extensions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ServeIDE(common.CurrentProject(), idePort)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.ide", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var impactJson bool
+
+func init() {
+	impactCmd.Flags().BoolVarP(&impactJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(impactCmd)
+}
+
+var impactCmd = &cobra.Command{
+	Use:   "impact [flags] <import|flow-id>",
+	Short: "show the flows, handlers and triggers affected by a change",
+	Long: `Shows the flows, handlers and triggers of the application affected by a change of a contribution or a flow, to scope the tests of an upgrade.
+The contribution is identified by its import path, its alias or its module (ex. github.com/project-flogo/contrib/activity/rest, rest or github.com/project-flogo/contrib), the flow by its id (ex. flow:orders or orders).
+The flows calling an affected flow as a subflow are affected as well.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ShowImpact(common.CurrentProject(), args[0], impactJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.impact", args[0], err))
+			os.Exit(1)
+		}
+	},
+}
@@ -6,14 +6,25 @@ import (
 
 	"github.com/project-flogo/cli/api"
 	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 )
 
+var pinOptions api.PinOptions
+var importsSyncNoPrune bool
+
 func init() {
+	importsSyncCmd.Flags().BoolVarP(&importsSyncNoPrune, "no-prune", "", false, "keep the requirements of go.mod which no import needs")
+	importsPinCmd.Flags().StringVarP(&pinOptions.Reason, "reason", "r", "", "why the module is pinned, ex. the issue of the version it works around")
+	importsPinCmd.Flags().StringVarP(&pinOptions.Expires, "expires", "", "", "date the pin expires, as YYYY-MM-DD (default in 30 days)")
 	rootCmd.AddCommand(importsCmd)
 	importsCmd.AddCommand(importsSyncCmd)
 	importsCmd.AddCommand(importsResolveCmd)
 	importsCmd.AddCommand(importsListCmd)
+	importsCmd.AddCommand(importsFormatCmd)
+	importsCmd.AddCommand(importsLockCmd)
+	importsCmd.AddCommand(importsPinCmd)
+	importsCmd.AddCommand(importsUnpinCmd)
 }
 
 var importsCmd = &cobra.Command{
@@ -28,13 +39,14 @@ var importsCmd = &cobra.Command{
 var importsSyncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "sync Go imports to project imports",
-	Long:  `Synchronize Go imports to project imports.`,
+	Long: `Reconciles imports.go and go.mod with the imports of the flogo.json and engine.json, the source of truth: the missing Go imports are installed,
+the stale ones are removed and the requirements of go.mod which no import needs are pruned. The changes are reported.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		err := api.SyncProjectImports(common.CurrentProject())
+		err := api.SyncProjectImports(commandContext(), common.CurrentProject(), api.ImportsSyncOptions{Prune: !importsSyncNoPrune})
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error synchronzing imports: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.syncImports", err))
 			os.Exit(1)
 		}
 	},
@@ -46,10 +58,10 @@ var importsResolveCmd = &cobra.Command{
 	Long:  `Resolves all project imports to current installed version.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		err := api.ResolveProjectImports(common.CurrentProject())
+		err := api.ResolveProjectImports(commandContext(), common.CurrentProject())
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error resolving import versions: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.resolveImports", err))
 			os.Exit(1)
 		}
 	},
@@ -64,7 +76,75 @@ var importsListCmd = &cobra.Command{
 		err := api.ListProjectImports(common.CurrentProject())
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error listing imports: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.listImports", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var importsFormatCmd = &cobra.Command{
+	Use:   "format",
+	Short: "group and sort the Go imports",
+	Long:  `Groups and sorts the Go imports of imports.go according to the import groups of the project.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.FormatProjectImports(common.CurrentProject())
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.formatImports", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var importsLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "lock the versions of the project imports",
+	Long:  `Writes the flogo.lock of the project, which records the exact versions of the modules providing the imports resolved by go.mod and go.sum.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.WriteProjectLock(common.CurrentProject())
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.lockImports", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var importsPinCmd = &cobra.Command{
+	Use:   "pin [<module>@<version>]",
+	Short: "pin a module temporarily",
+	Long: `Requires the version of a module, ex. to downgrade a broken contribution, and records the pin with its reason and expiry in flogo.lock.
+'flogo upgrade' keeps the pinned version, 'flogo outdated' and 'flogo doctor' warn once the pin expires. Without a module, the pins are listed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		var err error
+		if len(args) == 0 {
+			err = api.ListPins(common.CurrentProject())
+		} else {
+			err = api.PinModule(commandContext(), common.CurrentProject(), args[0], pinOptions)
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.pinModule", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var importsUnpinCmd = &cobra.Command{
+	Use:   "unpin <module>",
+	Short: "remove the pin of a module",
+	Long:  `Removes the pin of a module from flogo.lock, the version required by go.mod is kept until the module is upgraded.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.UnpinModule(common.CurrentProject(), args[0])
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.unpinModule", err))
 			os.Exit(1)
 		}
 	},
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var inspectOutput string
+
+func init() {
+	inspectCmd.Flags().StringVarP(&inspectOutput, "output", "o", "", "file to extract the app descriptor to, '-' for stdout")
+	rootCmd.AddCommand(inspectCmd)
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect [flags] <binary>",
+	Short: "inspect the app descriptor embedded in an application",
+	Long: `Extracts the app descriptor embedded in an application built using --embed and verifies it against the SHA-256 recorded at build time.
+Use it to audit the configuration an executable actually ships with.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// the application is inspected on its own, outside of a project
+		api.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		config, err := api.InspectBinary(args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.inspect", err))
+			os.Exit(1)
+		}
+
+		switch inspectOutput {
+		case "":
+			api.PrintEmbeddedConfig(os.Stdout, args[0], config)
+		case "-":
+			os.Stdout.Write(config.Descriptor)
+		default:
+			err = ioutil.WriteFile(inspectOutput, config.Descriptor, 0644)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.writeDescriptor", err))
+				os.Exit(1)
+			}
+			api.PrintEmbeddedConfig(os.Stdout, args[0], config)
+			fmt.Println(util.T("inspect.extracted", inspectOutput))
+		}
+
+		if !config.Verified {
+			fmt.Fprintln(os.Stderr, util.T("error.inspect.checksum", args[0]))
+			os.Exit(1)
+		}
+	},
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/project-flogo/cli/api"
 	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 )
 
@@ -14,7 +15,7 @@ var replaceContrib string
 var contribBundleFile string
 
 func init() {
-	installCmd.Flags().StringVarP(&replaceContrib, "replace", "r", "", "specify path to replacement contribution/dependency")
+	installCmd.Flags().StringVarP(&replaceContrib, "replace", "r", "", "specify path to replacement contribution/dependency, a local directory or <fork>@<version>")
 	installCmd.Flags().StringVarP(&contribBundleFile, "file", "f", "", "specify contribution bundle")
 	rootCmd.AddCommand(installCmd)
 }
@@ -22,29 +23,38 @@ func init() {
 var installCmd = &cobra.Command{
 	Use:   "install [flags] <contribution|dependency>",
 	Short: "install a flogo contribution/dependency",
-	Long:  "Installs a flogo contribution or dependency",
+	Long: `Installs a flogo contribution or dependency.
+A contribution replaced by a local directory or a fork is installed using <ref>=><local path> or <ref>=><fork>@<version>, the replacement is recorded in flogo.json.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if contribBundleFile != "" {
-			err := api.InstallContribBundle(common.CurrentProject(), contribBundleFile)
+			err := api.InstallContribBundle(commandContext(), common.CurrentProject(), contribBundleFile)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error installing contribution bundle: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.installBundle", err))
 				os.Exit(1)
 			}
 		}
 
 		if replaceContrib != "" {
-			replaceContrib = strings.Replace(replaceContrib, "@", " ", -1)
-			err := api.InstallReplacedPackage(common.CurrentProject(), replaceContrib, args[0])
+			if len(args) != 1 {
+				fmt.Fprintln(os.Stderr, util.T("error.install.noReplaced"))
+				os.Exit(1)
+			}
+			err := api.InstallReplacedPackage(commandContext(), common.CurrentProject(), replaceContrib, args[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error installing contribution/dependency: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.install", err))
 				os.Exit(1)
 			}
 		} else {
 			for _, pkg := range args {
-				err := api.InstallPackage(common.CurrentProject(), pkg)
+				var err error
+				if strings.Contains(pkg, "=>") {
+					err = api.InstallReplacedPackage(commandContext(), common.CurrentProject(), pkg, "")
+				} else {
+					err = api.InstallPackage(commandContext(), common.CurrentProject(), pkg)
+				}
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error installing contribution/dependency: %v\n", err)
+					fmt.Fprintln(os.Stderr, util.T("error.install", err))
 					os.Exit(1)
 				}
 			}
@@ -6,15 +6,18 @@ import (
 
 	"github.com/project-flogo/cli/api"
 	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 )
 
 var json bool
 var orphaned bool
 var listFilter string
+var listOutput string
 
 func init() {
 	listCmd.Flags().BoolVarP(&json, "json", "j", true, "print in json format")
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "", "output format [json, yaml, table], overrides --json")
 	listCmd.Flags().BoolVarP(&orphaned, "orphaned", "", false, "list orphaned refs")
 	listCmd.Flags().StringVarP(&listFilter, "filter", "", "", "apply list filter [used, unused]")
 	rootCmd.AddCommand(listCmd)
@@ -26,19 +29,27 @@ var listCmd = &cobra.Command{
 	Long:  "List installed flogo contributions",
 	Run: func(cmd *cobra.Command, args []string) {
 
+		format := listOutput
+		if format == "" {
+			format = api.ListFormatText
+			if json {
+				format = api.ListFormatJson
+			}
+		}
+
 		if orphaned {
-			err := api.ListOrphanedRefs(common.CurrentProject(), json)
+			err := api.ListOrphanedRefs(common.CurrentProject(), format)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting orphaned refs: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.orphanedRefs", err))
 				os.Exit(1)
 			}
 
 			return
 		}
 
-		err := api.ListContribs(common.CurrentProject(), json, listFilter)
+		err := api.ListContribs(common.CurrentProject(), format, listFilter)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting list of contributions: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.listContribs", err))
 			os.Exit(1)
 		}
 	},
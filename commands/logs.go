@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var logOptions api.LogOptions
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logOptions.Follow, "follow", "f", false, "keep streaming the output as it is written")
+	logsCmd.Flags().StringVarP(&logOptions.Level, "level", "l", "", "minimum level of the log entries to show [debug, info, warn, error]")
+	logsCmd.Flags().IntVarP(&logOptions.Tail, "tail", "n", 0, "number of lines to show from the end of the log")
+	logsCmd.Flags().BoolVarP(&logOptions.Raw, "raw", "", false, "don't format JSON log entries")
+	rootCmd.AddCommand(logsCmd)
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "show the output of the running application",
+	Long:  "Shows the output of the application started by the cli, optionally filtered by log level",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ShowLogs(common.CurrentProject(), logOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.logs", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "language server for flogo.json",
+	Long:  "Runs a language server over stdio providing completion of contribution refs, hover documentation and diagnostics for the project's flogo.json",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ServeLSP(common.CurrentProject(), os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.lsp", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var metricsOptions api.RemoteOptions
+var metricsJSON bool
+
+func init() {
+	metricsCmd.Flags().StringVarP(&metricsOptions.Addr, "addr", "a", api.DefaultManagementAddr, "address of the application's management API")
+	metricsCmd.Flags().StringVarP(&metricsOptions.Token, "token", "", "", "token of the management API, defaults to "+api.EnvManagementToken)
+	metricsCmd.Flags().BoolVarP(&metricsJSON, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(metricsCmd)
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "show the metrics of a running application",
+	Long:  `Shows a summary of the metrics of a running application, such as the executions, errors and durations of its flows. The metrics are exposed by the management API, which is enabled using 'flogo build --management'.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ShowMetrics(metricsOptions, metricsJSON)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.metrics", err))
+			os.Exit(1)
+		}
+	},
+}
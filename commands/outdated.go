@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "list outdated contributions",
+	Long:  `Lists the contributions for which a newer version is available, along with the deprecations and advisories of the registry affecting them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListOutdatedContribs(commandContext(), common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.outdated", err))
+			os.Exit(1)
+		}
+	},
+}
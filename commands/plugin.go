@@ -3,13 +3,28 @@ package commands
 import (
 	"fmt"
 	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 	"os"
 )
 
+var updateAllPlugins bool
+var pluginOptions PluginOptions
+var binaryPlugin bool
+var doctorAssumeYes bool
+
 func init() {
+	pluginDoctorCmd.Flags().BoolVarP(&doctorAssumeYes, "yes", "y", false, "rebuild or reinstall broken plugins without asking")
+	pluginInstallCmd.Flags().BoolVarP(&binaryPlugin, "binary", "b", false, "install a prebuilt plugin from a file or URL")
+	pluginInstallCmd.Flags().BoolVarP(&pluginOptions.Private, "private", "p", false, "plugin is hosted on a private module host")
+	pluginInstallCmd.Flags().BoolVarP(&pluginOptions.SSH, "ssh", "", false, "access the plugin's host using ssh")
+	pluginInstallCmd.Flags().BoolVarP(&pluginOptions.AssumeYes, "yes", "y", false, "grant the capabilities requested by the plugin without asking")
+	pluginUpdateCmd.Flags().BoolVarP(&updateAllPlugins, "all", "a", false, "update all installed plugins")
+	pluginUpdateCmd.Flags().BoolVarP(&pluginOptions.AssumeYes, "yes", "y", false, "grant the capabilities requested by the plugins without asking")
+	pluginCmd.AddCommand(pluginDoctorCmd)
 	pluginCmd.AddCommand(pluginInstallCmd)
 	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginOutdatedCmd)
 	pluginCmd.AddCommand(pluginUpdateCmd)
 	pluginCmd.AddCommand(pluginRemoveCmd)
 	rootCmd.AddCommand(pluginCmd)
@@ -25,23 +40,28 @@ var pluginCmd = &cobra.Command{
 }
 
 var pluginInstallCmd = &cobra.Command{
-	Use:   "install <plugin>",
+	Use:   "install <plugin>[@version]",
 	Short: "install CLI plugin",
-	Long:  "Installs a CLI plugin",
+	Long:  "Installs a CLI plugin, optionally at a specific version or commit",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 
 		pluginPkg := args[0]
 
-		fmt.Printf("Installing plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.installing", pluginPkg))
 
-		err := UpdateCLI(pluginPkg, UpdateOptAdd)
+		var err error
+		if binaryPlugin {
+			err = installBinaryPlugin(pluginPkg, rootCmd.Version, pluginOptions)
+		} else {
+			err = UpdateCLIWithOptions(pluginPkg, UpdateOptAdd, pluginOptions)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error adding plugin: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.addPlugin", err))
 			os.Exit(1)
 		}
 
-		fmt.Printf("Installed plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.installed", pluginPkg))
 	},
 }
 
@@ -51,8 +71,10 @@ var pluginListCmd = &cobra.Command{
 	Long:  "Lists installed CLI plugins",
 	Run: func(cmd *cobra.Command, args []string) {
 
-		for _, pluginPkg := range common.GetPluginPkgs() {
-			fmt.Println(pluginPkg)
+		err := listPlugins()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listPlugins", err))
+			os.Exit(1)
 		}
 	},
 }
@@ -66,35 +88,93 @@ var pluginRemoveCmd = &cobra.Command{
 
 		pluginPkg := args[0]
 
-		fmt.Printf("Removing plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.removing", pluginPkg))
 
-		err := UpdateCLI(pluginPkg, UpdateOptRemove)
+		removed, err := removeBinaryPlugin(pluginPkg)
+		if !removed && err == nil {
+			err = UpdateCLI(pluginPkg, UpdateOptRemove)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error adding plugin: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.addPlugin", err))
 			os.Exit(1)
 		}
 
-		fmt.Printf("Removed plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.removed", pluginPkg))
+	},
+}
+
+var pluginDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "fix broken plugins",
+	Long:  "Detects plugins which can no longer be loaded, ex. after a CLI upgrade, and offers to rebuild or remove them",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := doctorPlugins(doctorAssumeYes)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.fixPlugins", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var pluginOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "list outdated plugins",
+	Long:  "Lists the installed CLI plugins for which a newer version is available",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := listOutdatedPlugins()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.outdatedPlugins", err))
+			os.Exit(1)
+		}
 	},
 }
 
 var pluginUpdateCmd = &cobra.Command{
-	Use:   "update <plugin>",
+	Use:   "update [plugin|--all]",
 	Short: "update plugin",
-	Long:  "Updates the specified installed CLI plugin",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Updates the specified installed CLI plugin, or all of them",
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 
+		if updateAllPlugins {
+			fmt.Println(util.T("plugin.updatingAll"))
+
+			err := UpdateCLIWithOptions("", UpdateOptUpdateAll, pluginOptions)
+			if err == nil {
+				err = updateBinaryPlugins(rootCmd.Version, pluginOptions)
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.updatePlugins", err))
+				os.Exit(1)
+			}
+
+			fmt.Println(util.T("plugin.updatedAll"))
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, util.T("error.updatePlugin.noPlugin"))
+			os.Exit(1)
+		}
+
 		pluginPkg := args[0]
 
-		fmt.Printf("Updating plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.updating", pluginPkg))
 
-		err := UpdateCLI(pluginPkg, UpdateOptUpdate)
+		var err error
+		if pc := getBinaryPlugin(pluginPkg); pc != nil {
+			// prebuilt plugins are updated by installing them again from their source
+			err = installBinaryPlugin(pc.Source, rootCmd.Version, pluginOptions)
+		} else {
+			err = UpdateCLIWithOptions(pluginPkg, UpdateOptUpdate, pluginOptions)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating plugin: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.updatePlugin", err))
 			os.Exit(1)
 		}
 
-		fmt.Printf("Updated plugin: %s\n", pluginPkg)
+		fmt.Println(util.T("plugin.updated", pluginPkg))
 	},
 }
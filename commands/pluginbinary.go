@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/internal/pluginhost"
+	"github.com/project-flogo/cli/rpcplugin"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+// installBinaryPlugin installs a prebuilt plugin from a local file or URL, no rebuild of the CLI is required
+func installBinaryPlugin(source, cliVersion string, options PluginOptions) error {
+
+	tmpFile, err := ioutil.TempFile("", "flogo-plugin")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	_ = tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	if util.IsRemote(source) {
+		content, err := util.LoadRemoteFile(source)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(tmpPath, []byte(content), 0755)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = util.CopyFile(source, tmpPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Chmod(tmpPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	client, err := rpcplugin.Start(tmpPath)
+	if err != nil {
+		return err
+	}
+	info, err := client.Info()
+	_ = client.Close()
+	if err != nil {
+		return err
+	}
+
+	if info.Name == "" || strings.ContainsAny(info.Name, "/\\") {
+		return fmt.Errorf("plugin has an invalid name '%s'", info.Name)
+	}
+
+	err = validateCapabilities(info.Name, info.Capabilities)
+	if err != nil {
+		return err
+	}
+
+	if compatible, err := util.IsCompatibleVersion(cliVersion, info.CLIVersion); err == nil && !compatible {
+		return fmt.Errorf("plugin '%s' requires CLI version %s, current version is %s", info.Name, info.CLIVersion, cliVersion)
+	}
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	var granted []string
+	if pc, exists := config.Plugins[info.Name]; exists {
+		if pc.Binary == "" {
+			return fmt.Errorf("a plugin named '%s' is already installed", info.Name)
+		}
+		granted = pc.Capabilities
+	}
+
+	err = confirmCapabilities(info.Name, info.Capabilities, granted, newConfirm(options.AssumeYes))
+	if err != nil {
+		return err
+	}
+
+	home, err := util.GetFlogoHome()
+	if err != nil {
+		return err
+	}
+
+	binary := filepath.Join(home, "plugins", info.Name)
+	if runtime.GOOS == "windows" {
+		binary = binary + ".exe"
+	}
+
+	err = os.MkdirAll(filepath.Dir(binary), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = util.CopyFile(tmpPath, binary)
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(binary, 0755)
+	if err != nil {
+		return err
+	}
+
+	pc := &util.PluginConfig{Version: info.Version, CLIVersion: info.CLIVersion, Binary: binary, Source: source,
+		Capabilities: info.Capabilities, Hooks: info.Hooks}
+	for _, c := range info.Commands {
+		pc.Commands = append(pc.Commands, &util.PluginCommandConfig{Use: c.Use, Short: c.Short, Long: c.Long})
+	}
+
+	if config.Plugins == nil {
+		config.Plugins = make(map[string]*util.PluginConfig)
+	}
+	config.Plugins[info.Name] = pc
+
+	if len(info.Capabilities) == 0 {
+		fmt.Println(util.T("plugin.noCapabilities", info.Name))
+	}
+
+	return util.SaveCLIConfig(config)
+}
+
+// removeBinaryPlugin removes a prebuilt plugin, false is returned if the plugin isn't a prebuilt plugin
+func removeBinaryPlugin(name string) (bool, error) {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return false, err
+	}
+
+	pc, exists := config.Plugins[name]
+	if !exists || pc.Binary == "" {
+		return false, nil
+	}
+
+	err = util.DeleteFile(pc.Binary)
+	if err != nil && !os.IsNotExist(err) {
+		return true, err
+	}
+
+	delete(config.Plugins, name)
+
+	return true, util.SaveCLIConfig(config)
+}
+
+// getBinaryPlugin gets the configuration of a prebuilt plugin, nil if the plugin isn't a prebuilt plugin
+func getBinaryPlugin(name string) *util.PluginConfig {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil
+	}
+
+	if pc, exists := config.Plugins[name]; exists && pc.Binary != "" {
+		return pc
+	}
+
+	return nil
+}
+
+// updateBinaryPlugins updates all the prebuilt plugins by installing them again from their source
+func updateBinaryPlugins(cliVersion string, options PluginOptions) error {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range getBinaryPluginNames(config) {
+		err = installBinaryPlugin(config.Plugins[name].Source, cliVersion, options)
+		if err != nil {
+			return fmt.Errorf("unable to update plugin '%s': %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func getBinaryPluginNames(config *util.CLIConfig) []string {
+
+	var names []string
+	for name, pc := range config.Plugins {
+		if pc.Binary != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// loadBinaryPlugins adds the commands and hooks of the installed prebuilt plugins which are compatible with the CLI
+func loadBinaryPlugins(config *util.CLIConfig, cliVersion string) {
+
+	for _, name := range getBinaryPluginNames(config) {
+		pc := config.Plugins[name]
+
+		if compatible, err := util.IsCompatibleVersion(cliVersion, pc.CLIVersion); err == nil && !compatible {
+			reason := fmt.Sprintf("requires CLI version %s", pc.CLIVersion)
+			fmt.Fprintln(os.Stderr, util.T("warning.pluginDisabled", name, reason))
+			common.DisablePlugin(name, reason)
+			continue
+		}
+
+		err := pluginhost.GrantCapabilities(name, pc.Capabilities)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("warning.pluginDisabled", name, err))
+			common.DisablePlugin(name, err.Error())
+			continue
+		}
+
+		for _, c := range pc.Commands {
+			// a plugin can't replace the commands of the CLI or of another plugin
+			cmd := newBinaryPluginCommand(pc.Binary, c)
+			if existing := findRootCommand(cmd.Name()); existing != nil {
+				fmt.Fprintln(os.Stderr, util.T("warning.pluginCommandConflict", cmd.Name(), name, existing.Name()))
+				continue
+			}
+			rootCmd.AddCommand(cmd)
+		}
+
+		for _, eventType := range pc.Hooks {
+			_ = pluginhost.RegisterHook(name, eventType, newBinaryPluginHook(pc.Binary))
+		}
+	}
+}
+
+// findRootCommand finds the command of the CLI with the name or alias, nil if there is none
+func findRootCommand(name string) *cobra.Command {
+
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+func newBinaryPluginCommand(binary string, c *util.PluginCommandConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:                c.Use,
+		Short:              c.Short,
+		Long:               c.Long,
+		DisableFlagParsing: true, // the plugin parses its own flags
+		Run: func(cmd *cobra.Command, args []string) {
+
+			client, err := rpcplugin.Start(binary)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.startPlugin", err))
+				os.Exit(1)
+			}
+
+			err = client.Execute(cmd.Name(), args)
+			_ = client.Close()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error", err))
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func newBinaryPluginHook(binary string) common.Hook {
+	return func(event *common.HookEvent) error {
+
+		client, err := rpcplugin.Start(binary)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		rpcEvent := &rpcplugin.HookEvent{Type: event.Type, Import: event.Import, Options: event.Options, Descriptor: event.Descriptor}
+		if event.Project != nil {
+			rpcEvent.ProjectDir = event.Project.Dir()
+		}
+
+		err = client.Hook(rpcEvent)
+		if err != nil {
+			return err
+		}
+
+		event.Import = rpcEvent.Import
+		event.Descriptor = rpcEvent.Descriptor
+		if event.Options != nil && rpcEvent.Options != nil {
+			*event.Options = *rpcEvent.Options
+		}
+
+		return nil
+	}
+}
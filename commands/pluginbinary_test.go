@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBinaryPluginsConflicts(t *testing.T) {
+	t.Log("Testing that the commands of prebuilt plugins don't replace the ones of the CLI")
+
+	config := &util.CLIConfig{Plugins: map[string]*util.PluginConfig{
+		"myplugin": {Binary: "flogo-myplugin", Commands: []*util.PluginCommandConfig{
+			{Use: "build [flags]", Short: "build differently"},
+			{Use: "deploy-edge-x [flags]", Short: "deploy to the edge"},
+		}},
+		"other": {Binary: "flogo-other", Commands: []*util.PluginCommandConfig{
+			{Use: "deploy-edge-x", Short: "deploy to the edge as well"},
+		}},
+	}}
+
+	loadBinaryPlugins(config, "v1.0.0")
+
+	var builds, deploys int
+	for _, cmd := range rootCmd.Commands() {
+		switch cmd.Name() {
+		case "build":
+			builds++
+			assert.Equal(t, "build the flogo application", cmd.Short)
+		case "deploy-edge-x":
+			deploys++
+			assert.Equal(t, "deploy to the edge", cmd.Short)
+			defer rootCmd.RemoveCommand(cmd)
+		}
+	}
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, 1, deploys)
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"go/parser"
@@ -10,6 +11,9 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 	"time"
 
@@ -24,15 +28,38 @@ const (
 	UpdateOptAdd
 	UpdateOptRemove
 	UpdateOptUpdate
+	UpdateOptUpdateAll
 )
 
+const (
+	EnvKeyGitToken = "FLOGO_GIT_TOKEN"
+)
+
+// PluginOptions are the options used to get a plugin
+type PluginOptions struct {
+	Private   bool // the plugin is hosted on a private module host, access is authenticated using FLOGO_GIT_TOKEN if set
+	SSH       bool // access the plugin's host using ssh, implies private
+	AssumeYes bool // grant the capabilities requested by the plugin without asking
+}
+
 func UpdateCLI(pluginPkg string, updateOption int) error {
+	return UpdateCLIWithOptions(pluginPkg, updateOption, PluginOptions{})
+}
+
+// UpdateCLIWithOptions rebuilds the CLI with the installed plugins, after applying the update to the specified
+// plugin. The plugin can be suffixed with @<version> or @<commit> to get a specific version.
+func UpdateCLIWithOptions(pluginPkg string, updateOption int, options PluginOptions) error {
 
 	exPath, err := os.Executable()
 	if err != nil {
 		return err
 	}
 
+	version := ""
+	if idx := strings.LastIndex(pluginPkg, "@"); idx > 0 {
+		pluginPkg, version = pluginPkg[:idx], pluginPkg[idx+1:]
+	}
+
 	pluginSet := make(map[string]struct{})
 
 	//add installed plugins
@@ -42,6 +69,17 @@ func UpdateCLI(pluginPkg string, updateOption int) error {
 		fmt.Println(aPluginPkg)
 	}
 
+	// the configuration records the installed plugins, even if they aren't part of this build of the CLI
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+	for plugin, pc := range config.Plugins {
+		if pc.Binary == "" {
+			pluginSet[plugin] = struct{}{}
+		}
+	}
+
 	if updateOption == UpdateOptAdd {
 		// add new plugin
 		pluginSet[pluginPkg] = struct{}{}
@@ -57,7 +95,7 @@ func UpdateCLI(pluginPkg string, updateOption int) error {
 
 	err = os.RemoveAll(basePath)
 	if err != nil {
-		fmt.Println("del err:", err)
+		fmt.Println(util.T("error", err))
 	}
 
 	err = util.Copy(path, basePath, false)
@@ -70,33 +108,99 @@ func UpdateCLI(pluginPkg string, updateOption int) error {
 		return err
 	}
 
-	err = createPluginListFile(basePath, pluginSet)
-	if err != nil {
-		return err
+	sources := make(map[string]*util.PluginConfig)
+	for plugin := range pluginSet {
+		if pc, exists := config.Plugins[plugin]; exists {
+			sources[plugin] = pc
+		} else {
+			sources[plugin] = &util.PluginConfig{}
+		}
+	}
+	if src, exists := sources[pluginPkg]; exists && updateOption != UpdateOptRemove {
+		src.Private = src.Private || options.Private
+		src.SSH = src.SSH || options.SSH
+	}
+
+	env := privatePluginEnv(sources)
+
+	installed := make(map[string]*util.PluginConfig)
+	installedSet := make(map[string]struct{})
+	pluginCaps := make(map[string][]string)
+
+	// prebuilt plugins aren't part of the CLI build
+	for name, pc := range config.Plugins {
+		if pc.Binary != "" {
+			installed[name] = pc
+		}
 	}
 
 	for plugin := range pluginSet {
-		_, err := addPlugin(cliCmdPath, plugin)
+		update := updateOption == UpdateOptUpdateAll || (updateOption == UpdateOptUpdate && plugin == pluginPkg)
+
+		// the other plugins are kept at their installed version
+		query := sources[plugin].Version
+		if update {
+			query = ""
+		}
+		if plugin == pluginPkg && version != "" {
+			query = version
+		}
+
+		info, err := getPlugin(cliCmdPath, plugin, query, update, ver, env)
 		if err != nil {
-			fmt.Println("error:", err)
+			if plugin == pluginPkg {
+				return err
+			}
+			fmt.Println(util.T("error", err))
+			continue
 		}
-	}
 
-	if updateOption == UpdateOptUpdate {
-		err = util.ExecCmd(exec.Command("go", "get", "-u", pluginPkg), cliCmdPath)
+		_, err = addPluginImport(cliCmdPath, plugin)
 		if err != nil {
 			return err
 		}
+
+		// the capabilities already granted to the plugin aren't asked again, ex. when the CLI is rebuilt
+		granted := sources[plugin].Capabilities
+		if granted == nil {
+			granted = common.GetPluginCapabilities(plugin)
+		}
+		err = confirmCapabilities(plugin, info.Capabilities, granted, newConfirm(options.AssumeYes))
+		if err != nil {
+			return err
+		}
+
+		installed[plugin] = &util.PluginConfig{Module: info.Module, Version: info.Version, CLIVersion: info.CLIVersion,
+			Private: sources[plugin].Private, SSH: sources[plugin].SSH, Capabilities: info.Capabilities}
+		installedSet[plugin] = struct{}{}
+		pluginCaps[plugin] = info.Capabilities
+
+		if prev, exists := config.Plugins[plugin]; update && exists && prev.Version != info.Version {
+			fmt.Println(util.T("plugin.updatedVersion", plugin, prev.Version, info.Version))
+		}
+
+		if plugin == pluginPkg && updateOption != UpdateOptRemove && len(info.Capabilities) == 0 {
+			fmt.Println(util.T("plugin.noCapabilities", plugin))
+		}
 	}
 
-	err = util.ExecCmd(exec.Command("go", "mod", "download"), basePath)
+	err = createPluginListFile(basePath, installedSet, pluginCaps)
 	if err != nil {
 		return err
 	}
 
-	err = util.ExecCmd(exec.Command("go", "build"), cliCmdPath)
+	downloadCmd := exec.Command("go", "mod", "download")
+	downloadCmd.Env = env
+	err = util.ExecCmd(downloadCmd, basePath)
 	if err != nil {
-		//fmt.Fprintf(os.Stderr, "Error: %v\n", osErr)
+		return err
+	}
+
+	buildCmd := exec.Command("go", "build")
+	buildCmd.Env = env
+	err = util.ExecCmd(buildCmd, cliCmdPath)
+	if err != nil {
+		//fmt.Fprintln(os.Stderr, util.T("error", osErr))
 		return err
 	}
 
@@ -112,26 +216,264 @@ func UpdateCLI(pluginPkg string, updateOption int) error {
 
 	err = util.Copy(filepath.Join(cliCmdPath, cliExe), exPath, false)
 	if err != nil {
-		//fmt.Fprintf(os.Stderr, "Error: %v\n", osErr)
+		//fmt.Fprintln(os.Stderr, util.T("error", osErr))
 		return err
 	}
 
+	config.Plugins = installed
+
+	return util.SaveCLIConfig(config)
+}
+
+type pluginInfo struct {
+	Module       string
+	Version      string
+	CLIVersion   string
+	Capabilities []string
+}
+
+// getPlugin gets the plugin's module at the version matching the query (latest if empty) and verifies that
+// the plugin is compatible with the CLI
+func getPlugin(cliCmdPath, pluginPkg, query string, update bool, cliVersion string, env []string) (*pluginInfo, error) {
+
+	target := pluginPkg
+	if query != "" {
+		target = pluginPkg + "@" + query
+	}
+
+	getCmd := exec.Command("go", "get", target)
+	if update {
+		getCmd = exec.Command("go", "get", "-u", target)
+	}
+	getCmd.Env = env
+
+	err := util.ExecCmd(getCmd, cliCmdPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("go", "list", "-f", "{{.Dir}}|{{with .Module}}{{.Path}}|{{.Version}}{{end}}", pluginPkg)
+	cmd.Dir = cliCmdPath
+	cmd.Env = env
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate plugin '%s': %v", pluginPkg, err)
+	}
+
+	parts := strings.Split(strings.TrimSpace(string(out)), "|")
+	info := &pluginInfo{}
+	if len(parts) == 3 {
+		info.Module, info.Version = parts[1], parts[2]
+	}
+
+	desc, err := util.GetPluginDescriptor(parts[0])
+	if err != nil || desc == nil {
+		return info, err
+	}
+
+	err = validateCapabilities(pluginPkg, desc.Capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(desc.Capabilities)
+	info.Capabilities = desc.Capabilities
+	info.CLIVersion = desc.CLIVersion
+
+	if compatible, err := util.IsCompatibleVersion(cliVersion, info.CLIVersion); err == nil && !compatible {
+		return nil, fmt.Errorf("plugin '%s' requires CLI version %s, current version is %s", pluginPkg, info.CLIVersion, cliVersion)
+	}
+
+	return info, nil
+}
+
+func validateCapabilities(plugin string, capabilities []string) error {
+	for _, c := range capabilities {
+		switch c {
+		case common.CapDescriptorWrite, common.CapImportsWrite, common.CapOptionsWrite:
+		default:
+			return fmt.Errorf("plugin '%s' requests unknown capability '%s'", plugin, c)
+		}
+	}
+	return nil
+}
+
+// confirmCapabilities asks the user to grant the capabilities requested by the plugin which haven't been granted
+// to it yet, an error is returned if they are declined
+func confirmCapabilities(plugin string, requested, granted []string, confirm func(question string) bool) error {
+
+	var added []string
+	for _, c := range requested {
+		if !containsString(granted, c) {
+			added = append(added, c)
+		}
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	if !confirm(fmt.Sprintf("Plugin '%s' requests capabilities: %s, grant them?", plugin, strings.Join(added, ", "))) {
+		return fmt.Errorf("capabilities requested by plugin '%s' were not granted", plugin)
+	}
+
 	return nil
 }
 
-func addPlugin(cliCmdPath, pluginPkg string) (bool, error) {
+// newConfirm gets a function asking the user to confirm a question, unless the answer is assumed to be yes
+func newConfirm(assumeYes bool) func(question string) bool {
+	return func(question string) bool {
+		if assumeYes {
+			fmt.Println(question + " yes")
+			return true
+		}
+		return util.Confirm(question)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// privatePluginEnv gets the environment to use to access the plugins, nil if none of them are private
+func privatePluginEnv(sources map[string]*util.PluginConfig) []string {
+
+	var patterns []string
+	rewrites := make(map[string]string)
+	token := os.Getenv(EnvKeyGitToken)
+
+	for plugin, src := range sources {
+		if !src.Private && !src.SSH {
+			continue
+		}
+
+		parts := strings.Split(plugin, "/")
+		host := parts[0]
+
+		// the owner is used rather than the package, since the package can be nested in the module
+		pattern := host
+		if len(parts) > 1 {
+			pattern = host + "/" + parts[1]
+		}
+		patterns = append(patterns, pattern)
+
+		if src.SSH {
+			rewrites["https://"+host+"/"] = "ssh://git@" + host + "/"
+		} else if src.Private && token != "" {
+			rewrites["https://"+host+"/"] = "https://oauth2:" + token + "@" + host + "/"
+		}
+	}
+
+	if len(patterns) == 0 && len(rewrites) == 0 {
+		return nil
+	}
+
+	env := os.Environ()
+
+	if len(patterns) > 0 {
+		if goPrivate := os.Getenv("GOPRIVATE"); goPrivate != "" {
+			patterns = append(patterns, goPrivate)
+		}
+		sort.Strings(patterns)
+		env = append(env, "GOPRIVATE="+strings.Join(patterns, ","), "GIT_TERMINAL_PROMPT=0")
+	}
+
+	// the rewrites are passed to git using the environment, so that credentials aren't persisted
+	var urls []string
+	for url := range rewrites {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
 
-	err := util.ExecCmd(exec.Command("go", "get", pluginPkg), cliCmdPath)
+	if len(urls) > 0 {
+		env = append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(len(urls)))
+		for i, url := range urls {
+			env = append(env, fmt.Sprintf("GIT_CONFIG_KEY_%d=url.%s.insteadOf", i, rewrites[url]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, url))
+		}
+	}
+
+	return env
+}
+
+// loadPlugins disables the installed plugins which aren't compatible with the CLI version and loads the prebuilt plugins
+func loadPlugins(cliVersion string) {
+
+	config, err := util.LoadCLIConfig()
 	if err != nil {
-		return false, err
+		fmt.Fprintln(os.Stderr, util.T("warning.loadPlugins", err))
+		return
+	}
+
+	loadBinaryPlugins(config, cliVersion)
+
+	for _, pluginPkg := range common.GetPluginPkgs() {
+		pc, exists := config.Plugins[pluginPkg]
+		if !exists || pc.CLIVersion == "" {
+			continue
+		}
+
+		// development versions of the CLI can't be verified
+		if compatible, err := util.IsCompatibleVersion(cliVersion, pc.CLIVersion); err == nil && !compatible {
+			reason := fmt.Sprintf("requires CLI version %s", pc.CLIVersion)
+			fmt.Fprintln(os.Stderr, util.T("warning.pluginDisabled", pluginPkg, reason))
+			common.DisablePlugin(pluginPkg, reason)
+		}
 	}
+}
+
+// listOutdatedPlugins lists the installed plugins for which a newer version is available
+func listOutdatedPlugins() error {
 
-	added, err := addPluginImport(cliCmdPath, pluginPkg)
+	config, err := util.LoadCLIConfig()
 	if err != nil {
-		return added, err
+		return err
+	}
+
+	var plugins []string
+	for plugin := range config.Plugins {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+
+	// the private plugins are accessed as when they are installed
+	env := privatePluginEnv(config.Plugins)
+
+	outdated := 0
+	for _, plugin := range plugins {
+		pc := config.Plugins[plugin]
+		if pc.Module == "" {
+			continue
+		}
+
+		cmd := exec.Command("go", "list", "-m", "-f", "{{.Version}}", pc.Module+"@latest")
+		cmd.Dir = os.TempDir()
+		cmd.Env = env
+		out, err := cmd.Output()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.latestPluginVersion", plugin, err))
+			continue
+		}
+
+		latest := strings.TrimSpace(string(out))
+		if latest != pc.Version {
+			if outdated == 0 {
+				fmt.Printf("%-50s %-20s %s\n", "PLUGIN", "INSTALLED", "LATEST")
+			}
+			fmt.Printf("%-50s %-20s %s\n", plugin, pc.Version, latest)
+			outdated++
+		}
+	}
+
+	if outdated == 0 {
+		fmt.Println(util.T("plugin.upToDate"))
 	}
 
-	return added, nil
+	return nil
 }
 
 func addPluginImport(cliCmdPath, pkg string) (bool, error) {
@@ -147,12 +489,11 @@ func addPluginImport(cliCmdPath, pkg string) (bool, error) {
 	successful := util.AddImport(fset, file, pkg)
 
 	if successful {
-		f, err := os.Create(importsFile)
-		if err != nil {
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, file); err != nil {
 			return false, err
 		}
-		defer f.Close()
-		if err := printer.Fprint(f, fset, file); err != nil {
+		if err := util.WriteFileAtomic(importsFile, buf.Bytes(), 0644); err != nil {
 			return false, err
 		}
 	}
@@ -160,23 +501,23 @@ func addPluginImport(cliCmdPath, pkg string) (bool, error) {
 	return successful, nil
 }
 
-func createPluginListFile(basePath string, plugins map[string]struct{}) error {
+func createPluginListFile(basePath string, plugins map[string]struct{}, capabilities map[string][]string) error {
 
-	f, err := os.Create(filepath.Join(basePath, "common", "pluginlist.go"))
+	var buf bytes.Buffer
+	err := pluginListTemplate.Execute(&buf, struct {
+		Timestamp    time.Time
+		PluginList   map[string]struct{}
+		Capabilities map[string][]string
+	}{
+		Timestamp:    time.Now(),
+		PluginList:   plugins,
+		Capabilities: capabilities,
+	})
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
-	err = pluginListTemplate.Execute(f, struct {
-		Timestamp  time.Time
-		PluginList map[string]struct{}
-	}{
-		Timestamp:  time.Now(),
-		PluginList: plugins,
-	})
-
-	return err
+	return util.WriteFileAtomic(filepath.Join(basePath, "common", "pluginlist.go"), buf.Bytes(), 0644)
 }
 
 var pluginListTemplate = template.Must(template.New("").Parse(`// Code generated by go generate; DO NOT EDIT.
@@ -188,5 +529,8 @@ func init() {
 	{{range $k, $v := .PluginList}}
 	pluginPkgs = append(pluginPkgs, "{{$k}}")
 	{{end}}
+	{{range $k, $v := .Capabilities}}
+	pluginCapabilities["{{$k}}"] = []string{ {{range $v}}"{{.}}", {{end}} }
+	{{end}}
 }
 `))
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/rpcplugin"
+	"github.com/project-flogo/cli/util"
+)
+
+const (
+	pluginStatusLoaded   = "loaded"
+	pluginStatusDisabled = "disabled"
+	pluginStatusMissing  = "missing"
+	pluginStatusBroken   = "broken"
+)
+
+// pluginStatus describes an installed plugin and whether it could be loaded
+type pluginStatus struct {
+	Name     string
+	Version  string
+	Module   string
+	Commands []string
+	Status   string
+	Reason   string
+	config   *util.PluginConfig
+}
+
+func (s *pluginStatus) binary() bool {
+	return s.config != nil && s.config.Binary != ""
+}
+
+// getPluginStatuses gets the status of all the installed plugins, prebuilt plugins are started to verify them if probe is set
+func getPluginStatuses(probe bool) ([]*pluginStatus, error) {
+
+	config, err := util.LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make(map[string]bool)
+	for _, pluginPkg := range common.GetPluginPkgs() {
+		compiled[pluginPkg] = true
+	}
+
+	var names []string
+	for name := range compiled {
+		names = append(names, name)
+	}
+	for name := range config.Plugins {
+		if !compiled[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var statuses []*pluginStatus
+
+	for _, name := range names {
+		s := &pluginStatus{Name: name, Status: pluginStatusLoaded, config: config.Plugins[name]}
+		if s.config != nil {
+			s.Version = s.config.Version
+			s.Module = s.config.Module
+		}
+
+		if s.binary() {
+			for _, c := range s.config.Commands {
+				if fields := strings.Fields(c.Use); len(fields) > 0 {
+					s.Commands = append(s.Commands, fields[0])
+				}
+			}
+		} else {
+			for _, c := range common.GetPluginCommands(name) {
+				s.Commands = append(s.Commands, c.Name())
+			}
+		}
+
+		switch {
+		case common.IsPluginDisabled(name):
+			s.Status, s.Reason = pluginStatusDisabled, common.GetDisabledReason(name)
+		case s.binary() && !util.FileExists(s.config.Binary):
+			s.Status, s.Reason = pluginStatusBroken, "plugin binary not found"
+		case s.binary() && probe:
+			if err := probeBinaryPlugin(name, s.config.Binary); err != nil {
+				s.Status, s.Reason = pluginStatusBroken, err.Error()
+			}
+		case !s.binary() && !compiled[name]:
+			s.Status, s.Reason = pluginStatusMissing, "not part of this build of the CLI"
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
+
+// probeBinaryPlugin verifies that the prebuilt plugin can be started
+func probeBinaryPlugin(name, binary string) error {
+
+	client, err := rpcplugin.Start(binary)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	info, err := client.Info()
+	if err != nil {
+		return err
+	}
+
+	if info.Name != name {
+		return fmt.Errorf("plugin binary is plugin '%s'", info.Name)
+	}
+
+	return nil
+}
+
+func listPlugins() error {
+
+	statuses, err := getPluginStatuses(false)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		status := s.Status
+		if s.Reason != "" {
+			status += " (" + s.Reason + ")"
+		}
+
+		fmt.Println(util.T("plugin.info.name", s.Name))
+		if s.Version != "" {
+			fmt.Println(util.T("plugin.info.version", s.Version))
+		}
+		if s.Module != "" {
+			fmt.Println(util.T("plugin.info.module", s.Module))
+		}
+		if s.binary() {
+			fmt.Println(util.T("plugin.info.binary", s.config.Binary))
+		}
+		fmt.Println(util.T("plugin.info.commands", strings.Join(s.Commands, ", ")))
+		fmt.Println(util.T("plugin.info.status", status))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// doctorPlugins detects the plugins which can no longer be loaded, typically after a CLI upgrade, and
+// offers to rebuild/reinstall or remove them
+func doctorPlugins(assumeYes bool) error {
+
+	statuses, err := getPluginStatuses(true)
+	if err != nil {
+		return err
+	}
+
+	options := PluginOptions{AssumeYes: assumeYes}
+	confirm := newConfirm(assumeYes)
+
+	problems := 0
+	for _, s := range statuses {
+		if s.Status != pluginStatusLoaded {
+			problems++
+		}
+	}
+
+	missing, _ := fixPlugins(statuses, confirm, func(s *pluginStatus, action string) error {
+		return applyPluginFix(s, action, options)
+	})
+
+	if len(missing) > 0 {
+		if confirm("Rebuild the CLI with the missing plugins?") {
+			err = UpdateCLIWithOptions("", UpdateOptRebuild, options)
+			if err != nil {
+				return err
+			}
+		} else {
+			config, err := util.LoadCLIConfig()
+			if err != nil {
+				return err
+			}
+			for _, s := range missing {
+				if confirm(fmt.Sprintf("Remove plugin '%s'?", s.Name)) {
+					delete(config.Plugins, s.Name)
+				}
+			}
+			err = util.SaveCLIConfig(config)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if problems == 0 {
+		fmt.Println(util.T("plugin.healthy"))
+	}
+
+	return nil
+}
+
+const (
+	pluginFixReinstall = "reinstall"
+	pluginFixUpdate    = "update"
+	pluginFixRemove    = "remove"
+)
+
+// fixPlugins offers to fix each plugin which can't be loaded using the fix confirmed: a binary plugin is reinstalled
+// from its source or removed, a plugin built into the CLI is updated or removed. The missing plugins, which are all
+// restored by a single rebuild, are returned along with the plugins whose fix failed.
+func fixPlugins(statuses []*pluginStatus, confirm func(question string) bool, fix func(s *pluginStatus, action string) error) ([]*pluginStatus, []string) {
+
+	var missing []*pluginStatus
+	var failed []string
+
+	for _, s := range statuses {
+		if s.Status == pluginStatusLoaded {
+			continue
+		}
+		fmt.Println(util.T("plugin.broken", s.Name, s.Status, s.Reason))
+
+		if s.Status == pluginStatusMissing {
+			missing = append(missing, s)
+			continue
+		}
+
+		var err error
+		if s.binary() {
+			if s.config.Source != "" && confirm(fmt.Sprintf("Reinstall plugin '%s' from %s?", s.Name, s.config.Source)) {
+				err = fix(s, pluginFixReinstall)
+			} else if confirm(fmt.Sprintf("Remove plugin '%s'?", s.Name)) {
+				err = fix(s, pluginFixRemove)
+			}
+		} else {
+			if confirm(fmt.Sprintf("Update plugin '%s'?", s.Name)) {
+				err = fix(s, pluginFixUpdate)
+			} else if confirm(fmt.Sprintf("Remove plugin '%s'?", s.Name)) {
+				err = fix(s, pluginFixRemove)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.fixPlugin", s.Name, err))
+			failed = append(failed, s.Name)
+		}
+	}
+
+	return missing, failed
+}
+
+// applyPluginFix reinstalls, updates or removes the plugin
+func applyPluginFix(s *pluginStatus, action string, options PluginOptions) error {
+
+	var err error
+	switch {
+	case action == pluginFixReinstall:
+		err = installBinaryPlugin(s.config.Source, rootCmd.Version, options)
+	case action == pluginFixRemove && s.binary():
+		_, err = removeBinaryPlugin(s.Name)
+	case action == pluginFixUpdate:
+		err = UpdateCLIWithOptions(s.Name, UpdateOptUpdate, options)
+	case action == pluginFixRemove:
+		err = UpdateCLI(s.Name, UpdateOptRemove)
+	}
+
+	return err
+}
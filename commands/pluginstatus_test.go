@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixPlugins(t *testing.T) {
+	t.Log("Testing the fixes offered by plugin doctor")
+
+	statuses := []*pluginStatus{
+		{Name: "github.com/myuser/loaded", Status: pluginStatusLoaded},
+		{Name: "github.com/myuser/broken", Status: pluginStatusBroken, Reason: "incompatible"},
+		{Name: "github.com/myuser/declined", Status: pluginStatusDisabled, Reason: "incompatible"},
+		{Name: "binary", Status: pluginStatusBroken, Reason: "handshake failed", config: &util.PluginConfig{Binary: "flogo-binary", Source: "https://example.com/flogo-binary"}},
+		{Name: "github.com/myuser/missing", Status: pluginStatusMissing},
+	}
+
+	// the update of the first plugin fails, the second one is declined, the binary plugin is removed
+	answers := map[string]bool{
+		"Update plugin 'github.com/myuser/broken'?":                        true,
+		"Reinstall plugin 'binary' from https://example.com/flogo-binary?": false,
+		"Remove plugin 'binary'?":                                          true,
+	}
+	confirm := func(question string) bool {
+		return answers[question]
+	}
+
+	var fixes []string
+	fix := func(s *pluginStatus, action string) error {
+		fixes = append(fixes, action+" "+s.Name)
+		if s.Name == "github.com/myuser/broken" {
+			return errors.New("build failed")
+		}
+		return nil
+	}
+
+	missing, failed := fixPlugins(statuses, confirm, fix)
+
+	assert.Equal(t, []string{"update github.com/myuser/broken", "remove binary"}, fixes)
+	// a declined plugin isn't reported as failed by the error of a previous one
+	assert.Equal(t, []string{"github.com/myuser/broken"}, failed)
+	assert.Len(t, missing, 1)
+	assert.Equal(t, "github.com/myuser/missing", missing[0].Name)
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var previewPort int
+
+func init() {
+	previewCmd.Flags().IntVarP(&previewPort, "port", "p", 8090, "port to serve the preview on")
+	rootCmd.AddCommand(previewCmd)
+}
+
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "preview the application in a browser",
+	Long:  "Serves a read-only web page on localhost rendering the application's triggers and flows",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ServePreview(common.CurrentProject(), previewPort)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.preview", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var startOptions api.StartOptions
+var stopTimeout time.Duration
+
+func init() {
+	for _, cmd := range []*cobra.Command{startCmd, restartCmd} {
+		cmd.Flags().StringArrayVarP(&startOptions.Env, "env", "e", nil, "environment variable to set, as KEY=VALUE")
+		cmd.Flags().StringArrayVarP(&startOptions.Props, "props", "p", nil, "JSON file containing app property values")
+		cmd.Flags().StringVarP(&startOptions.LogLevel, "log-level", "l", "", "log level of the engine [debug, info, warn, error]")
+	}
+	for _, cmd := range []*cobra.Command{stopCmd, restartCmd} {
+		cmd.Flags().DurationVarP(&stopTimeout, "timeout", "t", 10*time.Second, "time to wait for the application to stop before killing it")
+	}
+
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(restartCmd)
+}
+
+var startCmd = &cobra.Command{
+	Use:   "start [flags]",
+	Short: "start the application in the background",
+	Long:  "Starts the built application as a background process, its output is written to .flogo/app.log",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.StartApp(common.CurrentProject(), startOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.start", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [flags]",
+	Short: "stop the application",
+	Long:  "Stops the application started in the background",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.StopApp(common.CurrentProject(), stopTimeout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.stop", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the status of the application",
+	Long:  "Shows whether the application started in the background is running",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PrintAppStatus(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.status", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var restartCmd = &cobra.Command{
+	Use:   "restart [flags]",
+	Short: "restart the application",
+	Long:  "Restarts the application in the background, using the options it was last started with unless new ones are specified",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		var options *api.StartOptions
+		if cmd.Flags().Changed("env") || cmd.Flags().Changed("props") || cmd.Flags().Changed("log-level") {
+			options = &startOptions
+		}
+
+		err := api.RestartApp(common.CurrentProject(), options, stopTimeout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.restart", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var propsGenOptions api.PropsGenOptions
+var propsListProfile string
+var propsListJson bool
+var profileFrom string
+
+func init() {
+	propsGenCmd.Flags().StringSliceVarP(&propsGenOptions.Envs, "env", "e", nil, "environments to generate property files for, ex. dev,staging,prod")
+	propsGenCmd.Flags().StringVarP(&propsGenOptions.Format, "format", "", api.PropsFormatJson, "format of the property files [json, env]")
+	propsGenCmd.Flags().StringVarP(&propsGenOptions.Dir, "dir", "d", "props", "directory of the property files")
+	propsGenCmd.Flags().BoolVarP(&propsGenOptions.Check, "check", "", false, "only check that the property files contain the app properties")
+	propsListCmd.Flags().StringVarP(&propsListProfile, "profile", "", "", "show the values of the app properties in the profile")
+	propsListCmd.Flags().BoolVarP(&propsListJson, "json", "j", false, "print in json format")
+	profileCreateCmd.Flags().StringVarP(&profileFrom, "from", "", "", "profile to copy the values of")
+	propsCmd.AddCommand(propsGenCmd)
+	propsCmd.AddCommand(propsListCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileSetCmd)
+	profileCmd.AddCommand(profileUnsetCmd)
+	profileCmd.AddCommand(profileListCmd)
+	propsCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(propsCmd)
+}
+
+var propsCmd = &cobra.Command{
+	Use:   "props",
+	Short: "manage the app properties",
+	Long:  `Manage the app properties of the application and their values in each environment.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var propsGenCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "generate the property files of environments",
+	Long:  `Generates a property override file per environment, containing the app properties and their default values. Existing files keep their values, the properties added to the app since are added.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.GeneratePropsFiles(common.CurrentProject(), propsGenOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.generateProps", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var propsListCmd = &cobra.Command{
+	Use:   "list [flags]",
+	Short: "list the app properties",
+	Long:  `Lists the app properties declared in flogo.json with their type, their default value and the profiles overriding them, or their value in the profile specified.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListAppProperties(common.CurrentProject(), propsListProfile, propsListJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listProps", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "manage the property profiles",
+	Long: `Manage the property profiles of the project, named sets of app property values, ex. dev, stage or prod, stored in profiles/<name>.json.
+The values of a profile are baked into the application using 'flogo build --profile <name>', or written to an env file using --profile-env, and used by 'flogo run --profile <name>'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create [flags] <name> [<property>=<value>...]",
+	Short: "create a property profile",
+	Long:  `Creates a property profile setting the values of the app properties given, the other properties keep their default value.`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.CreateProfile(common.CurrentProject(), args[0], profileFrom, args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.createProfile", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var profileSetCmd = &cobra.Command{
+	Use:   "set <name> <property>=<value>...",
+	Short: "set app property values in a profile",
+	Long:  `Sets the values of app properties in the property profile, the values are converted to the type of their property.`,
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.SetProfileValues(common.CurrentProject(), args[0], args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.setProfile", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var profileUnsetCmd = &cobra.Command{
+	Use:   "unset <name> <property>...",
+	Short: "remove app properties from a profile",
+	Long:  `Removes app properties from the property profile, they keep their default value.`,
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.UnsetProfileValues(common.CurrentProject(), args[0], args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.unsetProfile", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the property profiles",
+	Long:  `Lists the property profiles of the project with the number of app properties they set.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListProfiles(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listProfiles", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var releaseTarget string
+var releaseSkipBuild bool
+var releaseList bool
+
+func init() {
+	releaseCmd.Flags().StringVarP(&releaseTarget, "target", "t", "", "target to publish to, as defined in publish.json")
+	releaseCmd.Flags().BoolVarP(&releaseSkipBuild, "skip-build", "", false, "publish the existing artifacts without building the application")
+	releaseCmd.Flags().BoolVarP(&releaseList, "list", "l", false, "list the publish targets")
+	rootCmd.AddCommand(releaseCmd)
+}
+
+var releaseCmd = &cobra.Command{
+	Use:   "release [flags]",
+	Short: "publish the artifacts of the application",
+	Long:  "Builds the application and publishes its executables, SBOMs, provenance and release manifest to a target defined in the project's publish.json",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if releaseList {
+			err := api.ListPublishTargets(common.CurrentProject())
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.listPublishTargets", err))
+				os.Exit(1)
+			}
+			return
+		}
+
+		if releaseTarget == "" {
+			fmt.Fprintln(os.Stderr, util.T("error.release.noTarget"))
+			os.Exit(1)
+		}
+
+		err := api.ReleaseApp(commandContext(), common.CurrentProject(), releaseTarget, api.ReleaseOptions{SkipBuild: releaseSkipBuild})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.release", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var remoteOptions api.RemoteOptions
+var remotePropsFile string
+var remoteLogLevel string
+
+func init() {
+	remoteCmd.PersistentFlags().StringVarP(&remoteOptions.Addr, "addr", "a", api.DefaultManagementAddr, "address of the application's management API")
+	remoteCmd.PersistentFlags().StringVarP(&remoteOptions.Token, "token", "", "", "token of the management API, defaults to "+api.EnvManagementToken)
+	remotePropsPushCmd.Flags().StringVarP(&remotePropsFile, "file", "f", "", "JSON file containing the properties to push")
+	remoteReconfigureCmd.Flags().StringVarP(&remoteLogLevel, "log-level", "l", "", "log level of the engine [debug, info, warn, error]")
+	remoteReconfigureCmd.Flags().StringVarP(&remotePropsFile, "file", "f", "", "JSON file containing the properties to update")
+	remotePropsCmd.AddCommand(remotePropsPushCmd)
+	remoteCmd.AddCommand(remoteStatusCmd)
+	remoteCmd.AddCommand(remoteFlowsCmd)
+	remoteCmd.AddCommand(remoteReconfigureCmd)
+	remoteCmd.AddCommand(remotePropsCmd)
+	rootCmd.AddCommand(remoteCmd)
+}
+
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "manage a running application",
+	Long:  `Manage a running application using its management API, which is enabled using 'flogo build --management'.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var remoteStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the status of a running application",
+	Long:  `Shows the status of a running application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PrintRemoteStatus(remoteOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.status", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var remoteFlowsCmd = &cobra.Command{
+	Use:   "flows",
+	Short: "list the flows of a running application",
+	Long:  `Lists the flows of a running application and the triggers that start them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListRemoteFlows(remoteOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listFlows", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var remoteReconfigureCmd = &cobra.Command{
+	Use:   "reconfigure [name=value...]",
+	Short: "reconfigure a running application",
+	Long:  `Changes the log level and/or the app properties of a running application, without rebuilding or restarting it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ReconfigureRemote(remoteOptions, remoteLogLevel, remotePropsFile, args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.reconfigure", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var remotePropsCmd = &cobra.Command{
+	Use:   "props",
+	Short: "manage the app properties of a running application",
+	Long:  `Manage the app properties of a running application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var remotePropsPushCmd = &cobra.Command{
+	Use:   "push [name=value...]",
+	Short: "update the app properties of a running application",
+	Long:  `Updates the app properties of a running application, without rebuilding or restarting it.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PushRemoteProps(remoteOptions, remotePropsFile, args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.pushProps", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var reportOptions api.ReportOptions
+var reportFormat string
+var reportOutput string
+var reportMinScore int
+
+func init() {
+	reportCmd.Flags().StringVar(&reportFormat, "format", api.ReportFormatText, "format of the report [text, json, html]")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "file to write the report to")
+	reportCmd.Flags().BoolVar(&reportOptions.Offline, "offline", false, "skip the checks querying the module proxy")
+	reportCmd.Flags().IntVar(&reportMinScore, "min-score", 0, "fail if the score is lower")
+	rootCmd.AddCommand(reportCmd)
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "report the health of the project",
+	Long: `Reports the health of the project, scored out of 100: the validation issues, the outdated contributions, the plaintext
+secrets, the unused imports and the flows without tests in the tests directory.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		report, err := api.BuildHealthReport(commandContext(), common.CurrentProject(), reportOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.report", err))
+			os.Exit(1)
+		}
+
+		out := os.Stdout
+		if reportOutput != "" {
+			out, err = os.Create(reportOutput)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.report", err))
+				os.Exit(1)
+			}
+			defer out.Close()
+		}
+
+		err = api.PrintHealthReport(out, report, reportFormat)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.report", err))
+			os.Exit(1)
+		}
+
+		if report.Score < reportMinScore {
+			fmt.Fprintln(os.Stderr, util.T("error.report.minScore", report.Score, reportMinScore))
+			os.Exit(1)
+		}
+	},
+}
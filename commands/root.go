@@ -1,17 +1,21 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"github.com/project-flogo/cli/api"
 	"github.com/project-flogo/cli/common"
 	"github.com/project-flogo/cli/util"
 	"github.com/spf13/cobra"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 )
 
 const (
-	VersionTpl = `{{with .Name}}{{printf "%s " .}}{{end}}{{printf "cli version %s" .Version}}
-`
+	VersionTpl = `{{cliVersion}}`
 )
 
 var verbose bool
@@ -27,7 +31,8 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-func Initialize(version string) {
+// Initialize adds the commands of the CLI, the channel and commit are the ones set when the CLI was released, if any
+func Initialize(version, channel, commit string) {
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "verbose output")
 
 	if len(version) > 0 {
@@ -36,8 +41,19 @@ func Initialize(version string) {
 		_, rootCmd.Version, _ = util.GetCLIInfo() // guess version from sources in $GOPATH/src
 	}
 
+	api.SetCLIBuildInfo(util.NewCLIBuildInfo(rootCmd.Version, channel, commit))
+
+	cobra.AddTemplateFunc("cliVersion", func() string {
+		var buf strings.Builder
+		_ = api.PrintCLIBuildInfo(&buf, false)
+		return buf.String()
+	})
 	rootCmd.SetVersionTemplate(VersionTpl)
 
+	enableSuggestions(rootCmd)
+
+	loadPlugins(rootCmd.Version)
+
 	//Get the list of commands from the registry of commands and add.
 	commandList := common.GetPlugins()
 
@@ -45,12 +61,48 @@ func Initialize(version string) {
 
 		rootCmd.AddCommand(command)
 	}
+
+	localizeCommands(rootCmd)
+}
+
+var (
+	cmdContextOnce sync.Once
+	cmdContext     context.Context
+)
+
+// commandContext gets the context of the command being run, it is canceled when the CLI is interrupted (ex. Ctrl+C)
+// so that the go commands run by the API are killed, interrupting it again terminates the CLI right away
+func commandContext() context.Context {
+
+	cmdContextOnce.Do(func() {
+		var cancel context.CancelFunc
+		cmdContext, cancel = context.WithCancel(context.Background())
+
+		interrupted := make(chan os.Signal, 1)
+		signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-interrupted
+			signal.Stop(interrupted)
+			cancel()
+		}()
+	})
+
+	return cmdContext
 }
 
 func Execute() {
 
+	args, err := expandAliases(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("error", err))
+		os.Exit(1)
+	}
+	rootCmd.SetArgs(args)
+
+	offerFirstRunSetup(args)
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintln(os.Stderr, util.T("error", err))
 		os.Exit(1)
 	}
 }
@@ -64,17 +116,64 @@ func preRun(cmd *cobra.Command, args []string, verbose bool) {
 	if len(os.Args) > 1 && !builtIn {
 		currentDir, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error determining working directory: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.workingDir", err))
 			os.Exit(1)
 		}
 		appProject := api.NewAppProject(currentDir)
 
 		err = appProject.Validate()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error validating project: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.validate", err))
 			os.Exit(1)
 		}
 
+		// validate reports the issues of the schema along with the other ones
+		if cmd != validateCmd {
+			if err := api.CheckDescriptorSchema(appProject); api.IsDescriptorSchemaError(err) {
+				fmt.Fprintln(os.Stderr, util.T("warning.schema", err))
+			}
+		}
+
 		common.SetCurrentProject(appProject)
 	}
 }
+
+// the headings of the usage template, with the key of their message
+var usageHeadings = [][2]string{
+	{"Usage:", "usage.usage"},
+	{"Aliases:", "usage.aliases"},
+	{"Examples:", "usage.examples"},
+	{"Available Commands:", "usage.commands"},
+	{"Global Flags:", "usage.globalFlags"},
+	{"\nFlags:", "usage.flags"},
+	{"Additional help topics:", "usage.helpTopics"},
+}
+
+// localizeCommands translates the usage template and the short help of the commands using the message catalog of the
+// locale, the commands without a translation keep their help
+func localizeCommands(root *cobra.Command) {
+
+	if util.Locale() == util.DefaultLocale {
+		return
+	}
+
+	tpl := root.UsageTemplate()
+	for _, heading := range usageHeadings {
+		tpl = strings.Replace(tpl, heading[0], strings.Replace(heading[0], strings.TrimSpace(heading[0]), util.T(heading[1]), 1), 1)
+	}
+	more := `Use "{{.CommandPath}} [command] --help" for more information about a command.`
+	tpl = strings.Replace(tpl, more, util.T("usage.moreHelp", "{{.CommandPath}}"), 1)
+	root.SetUsageTemplate(tpl)
+
+	var localize func(cmd *cobra.Command, path []string)
+	localize = func(cmd *cobra.Command, path []string) {
+		for _, sub := range cmd.Commands() {
+			subPath := append(append([]string{}, path...), sub.Name())
+			if short, exists := util.LookupMessage("command." + strings.Join(subPath, ".") + ".short"); exists {
+				sub.Short = short
+			}
+			localize(sub, subPath)
+		}
+	}
+	localize(root, nil)
+}
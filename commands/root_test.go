@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandContext(t *testing.T) {
+	t.Log("Testing the cancellation of the command context when the CLI is interrupted")
+
+	ctx := commandContext()
+	assert.Nil(t, ctx.Err())
+	assert.Equal(t, ctx, commandContext())
+
+	process, err := os.FindProcess(os.Getpid())
+	assert.Nil(t, err)
+	err = process.Signal(os.Interrupt)
+	assert.Nil(t, err)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the context isn't canceled")
+	}
+}
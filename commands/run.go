@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var runOptions api.RunOptions
+
+func init() {
+	runCmd.Flags().StringArrayVarP(&runOptions.Start.Env, "env", "e", nil, "environment variable to set, as KEY=VALUE")
+	runCmd.Flags().StringArrayVarP(&runOptions.Start.Props, "props", "p", nil, "JSON file containing app property values")
+	runCmd.Flags().StringVarP(&runOptions.Start.LogLevel, "log-level", "l", "", "log level of the engine [debug, info, warn, error]")
+	runCmd.Flags().StringVarP(&runOptions.Profile, "profile", "", "", "run with the app property values of the profile, overriding the property files")
+	runCmd.Flags().StringArrayVarP(&runOptions.PropValues, "prop", "", nil, "app property value, as name=value, overriding the profile")
+	runCmd.Flags().StringVarP(&runOptions.EngineProfile, "engine-profile", "", "", "run with the engine configuration engine.<profile>.json of the project")
+	runCmd.Flags().BoolVarP(&runOptions.Pretty, "pretty", "", false, "format the JSON log entries like the console format of the engine")
+	runCmd.Flags().BoolVarP(&runOptions.NoBuild, "no-build", "", false, "run the executable as is, without building it")
+	runCmd.Flags().StringVarP(&runOptions.Build.Variant, "variant", "", "", "build using the specified resource variant")
+	runCmd.Flags().StringSliceVarP(&runOptions.Build.Tags, "tags", "", nil, "build tags, enables the imports conditional on these tags")
+	rootCmd.AddCommand(runCmd)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] [-- <args>...]",
+	Short: "build and run the application in the foreground",
+	Long: `Builds the application if it isn't up to date and runs it in the foreground, streaming its output, until it exits or is interrupted.
+The arguments after -- are passed to the application. The warnings the engine logs while starting are reported with their remediation.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		runOptions.Args = args
+		err := api.RunApp(commandContext(), common.CurrentProject(), runOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.run", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	scanCmd.AddCommand(scanSecretsCmd)
+	rootCmd.AddCommand(scanCmd)
+}
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "scan the project",
+	Long:  `Scan the project for potential problems.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var scanSecretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "scan for plaintext secrets",
+	Long:  `Scans the application descriptor for likely plaintext credentials.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		findings, err := api.ScanSecrets(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.scan", err))
+			os.Exit(1)
+		}
+
+		for _, finding := range findings {
+			fmt.Println(finding)
+		}
+
+		if len(findings) > 0 {
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+func init() {
+	schemaDescriptorCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "file to write the schema to")
+	schemaCmd.AddCommand(schemaDescriptorCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "generate JSON schemas for the project",
+	Long:  `Generate JSON schemas for the project's files.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var schemaDescriptorCmd = &cobra.Command{
+	Use:   "descriptor",
+	Short: "generate the JSON schema of flogo.json",
+	Long:  `Generates a JSON schema for the project's flogo.json, composed of the core app schema and the settings of the installed contributions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		schema, err := api.GenerateDescriptorSchema(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.generateSchema", err))
+			os.Exit(1)
+		}
+
+		if schemaOutput == "" {
+			fmt.Fprintln(os.Stdout, string(schema))
+			return
+		}
+
+		err = ioutil.WriteFile(schemaOutput, schema, 0644)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.writeSchema", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var searchOptions api.SearchOptions
+
+func init() {
+	searchCmd.Flags().StringVarP(&searchOptions.Type, "type", "t", "", "type of the contributions [activity, trigger, action, function]")
+	searchCmd.Flags().StringVarP(&searchOptions.Index, "index", "", "", "URL or file of the contribution index, the one of the CLI configuration or the Flogo hub by default")
+	searchCmd.Flags().StringVarP(&searchOptions.Client, "client", "", "", "registry client querying the index [hub, index] or one of a plugin, hub for a URL and index for a file by default")
+	rootCmd.AddCommand(searchCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search [flags] <keyword>...",
+	Short: "search the contributions",
+	Long: `Searches a contribution index for the activities, triggers, actions and functions matching the keywords, and shows
+their description, latest version and the command installing them.
+The index is the Flogo hub by default, $FLOGO_SEARCH_INDEX or the searchIndex and searchClient of the CLI configuration
+point at a private index.`,
+	Args: cobra.MinimumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		entries, err := api.SearchContributions(strings.Join(args, " "), searchOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.search", err))
+			os.Exit(1)
+		}
+
+		api.PrintSearchResults(os.Stdout, entries)
+	},
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var oldSecretKey string
+var newSecretKey string
+
+func init() {
+	secretsRotateCmd.Flags().StringVarP(&oldSecretKey, "old-key", "", "", "key currently used to encrypt secret values")
+	secretsRotateCmd.Flags().StringVarP(&newSecretKey, "new-key", "", "", "key to re-encrypt secret values with")
+	secretsCmd.AddCommand(secretsRotateCmd)
+	rootCmd.AddCommand(secretsCmd)
+}
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "manage project secrets",
+	Long:  `Manage the encrypted secret values of the project.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var secretsRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "rotate the secrets encryption key",
+	Long:  `Re-encrypts all the secret values in the project using a new key.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RotateSecrets(common.CurrentProject(), oldSecretKey, newSecretKey)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.rotateSecrets", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var completionInstall bool
+
+func init() {
+	completionCmd.Flags().BoolVar(&completionInstall, "install", false, "install the script in the flogo home and source it from the rc file of the shell")
+	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(completionCmd)
+}
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "set up the CLI",
+	Long: `Guides through the setup of the CLI: the check of the Go module proxy, the version of the core used by the created projects, the installation of the shell completion and the registry index.
+The setup is offered on the first run of the CLI in a terminal.`,
+	Args: cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RunSetup(api.SetupOptions{Completion: generateCompletion})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.setup", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh>",
+	Short: "generate the completion script of a shell",
+	Long: `Generates the completion script of the flogo commands for bash or zsh.
+With --install, the script is written in the flogo home and sourced from ~/.bashrc or ~/.zshrc.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh"},
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if completionInstall {
+			script, err := api.InstallCompletion(args[0], generateCompletion)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, util.T("error.installCompletion", err))
+				os.Exit(1)
+			}
+			fmt.Println(util.T("setup.completionInstalled", script))
+			return
+		}
+
+		err := generateCompletion(args[0], os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.generateCompletion", err))
+			os.Exit(1)
+		}
+	},
+}
+
+func generateCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletion(w)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	}
+	return fmt.Errorf("unsupported shell '%s', expected bash or zsh", shell)
+}
+
+// offerFirstRunSetup offers the setup on the first run of the CLI, when it is used in a terminal, unless the command
+// sets up the CLI or only prints help
+func offerFirstRunSetup(args []string) {
+
+	if util.CLIConfigExists() || !util.IsInteractive() {
+		return
+	}
+
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" || arg == "--version" {
+			return
+		}
+	}
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil || cmd == rootCmd {
+		return
+	}
+	switch cmd.Name() {
+	case "setup", "completion", "help", "version":
+		return
+	}
+
+	err = api.RunSetup(api.SetupOptions{FirstRun: true, Completion: generateCompletion})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, util.T("warning.setup", err))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var sizeJson bool
+
+func init() {
+	sizeCmd.Flags().BoolVarP(&sizeJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(sizeCmd)
+}
+
+var sizeCmd = &cobra.Command{
+	Use:   "size [flags] [binary]",
+	Short: "show what the size of an application is made of",
+	Long: `Shows the size of the code and data of each module of an application, the standard library and the rest, ex. the symbol table and the debug information, largest first.
+The binary defaults to the application built in the project. When the project declares a budget in size-budget.json, the budget of the platform of the application is shown as well.`,
+	Args: cobra.MaximumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional when the binary is specified
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project := common.CurrentProject()
+
+		binary := ""
+		if len(args) > 0 {
+			binary = args[0]
+		} else if project != nil {
+			binary = project.Executable()
+		} else {
+			fmt.Fprintln(os.Stderr, util.T("error.size.noBinary"))
+			os.Exit(1)
+		}
+
+		err := api.ShowSizeReport(project, binary, sizeJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.size", binary, err))
+			os.Exit(1)
+		}
+	},
+}
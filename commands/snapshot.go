@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var snapshotMessage string
+var snapshotForce bool
+
+func init() {
+	snapshotCreateCmd.Flags().StringVarP(&snapshotMessage, "message", "m", "", "description of the snapshot, ex. the operation it precedes")
+	snapshotCreateCmd.Flags().BoolVarP(&snapshotForce, "force", "", false, "overwrite the snapshot if it already exists")
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "manage project snapshots",
+	Long: `Manage the snapshots of the project, named copies of flogo.json, flogo.lock, engine.json, src/imports.go, src/go.mod,
+src/go.sum and the configuration of .flogo, stored in .flogo/snapshots. A snapshot taken before a risky operation,
+ex. an upgrade of the core, reverts it in one command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create [flags] [name]",
+	Short: "take a snapshot of the project",
+	Long:  `Takes a snapshot of the project, named after the current time if no name is specified.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		snapshot, err := api.CreateSnapshot(common.CurrentProject(), name, snapshotMessage, snapshotForce)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.createSnapshot", err))
+			os.Exit(1)
+		}
+
+		fmt.Println(util.T("snapshot.created", snapshot.Name, len(snapshot.Files)))
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "restore the project to a snapshot",
+	Long: `Restores the files of the project to the ones of the snapshot, the files created since the snapshot are removed.
+Take a snapshot of the current state first to be able to come back to it.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		snapshot, err := api.RestoreSnapshot(common.CurrentProject(), args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.restoreSnapshot", err))
+			os.Exit(1)
+		}
+
+		fmt.Println(util.T("snapshot.restored", snapshot.Name, util.FormatDateTime(snapshot.Created.Local())))
+	},
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the snapshots of the project",
+	Long:  `Lists the snapshots of the project, most recent first, along with the version of the core they use.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		snapshots, err := api.ListSnapshots(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listSnapshots", err))
+			os.Exit(1)
+		}
+
+		if len(snapshots) == 0 {
+			fmt.Println(util.T("snapshot.none"))
+			return
+		}
+
+		for _, snapshot := range snapshots {
+			fmt.Printf("%-24s %-20s %-10s %s\n", snapshot.Name, util.FormatDateTime(snapshot.Created.Local()), snapshot.CoreVersion, snapshot.Message)
+		}
+	},
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(srcCmd)
+	srcCmd.AddCommand(srcAddPackageCmd)
+}
+
+var srcCmd = &cobra.Command{
+	Use:   "src",
+	Short: "manage the Go source of the application",
+	Long:  `Manage the Go source of the application, the src module generated by the CLI.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var srcAddPackageCmd = &cobra.Command{
+	Use:   "add-package <path|module>",
+	Short: "add a Go package to the application",
+	Long: `Adds a Go package of your own to the src module of the application, so it is built along with the generated files.
+A local directory is copied into src, a module path (optionally with @version) is required using 'go get'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.AddSrcPackage(commandContext(), common.CurrentProject(), args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.addPackage", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var unknownFlagPattern = regexp.MustCompile(`^unknown (?:shorthand )?flag: (?:--([^\s=]+)|'.' in -([^\s=]+))`)
+
+// enableSuggestions suggests the closest subcommands of the commands grouping other commands, which otherwise ignore
+// mistyped subcommands, and the closest flags of all commands
+func enableSuggestions(cmd *cobra.Command) {
+
+	cmd.SetFlagErrorFunc(suggestFlags)
+
+	for _, c := range cmd.Commands() {
+		if c.HasSubCommands() && c.Args == nil {
+			c.Args = suggestSubcommands
+			if c.SuggestionsMinimumDistance <= 0 {
+				c.SuggestionsMinimumDistance = 2
+			}
+		}
+		enableSuggestions(c)
+	}
+}
+
+func suggestSubcommands(cmd *cobra.Command, args []string) error {
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		msg += didYouMean(suggestions)
+	} else {
+		msg += fmt.Sprintf("\nRun '%s --help' for usage.", cmd.CommandPath())
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
+func suggestFlags(cmd *cobra.Command, err error) error {
+
+	m := unknownFlagPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	var names []string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			names = append(names, flag.Name)
+		}
+	})
+
+	// a shorthand flag is typically a long flag used with a single dash, ex. -verbose
+	word := m[1]
+	if word == "" {
+		word = m[2]
+	}
+
+	var suggestions []string
+	if flag := cmd.Flags().Lookup(word); flag != nil && m[1] == "" {
+		suggestions = append(suggestions, "--"+flag.Name)
+	} else {
+		for _, name := range util.Suggestions(word, names, 2) {
+			suggestions = append(suggestions, "--"+name)
+		}
+	}
+
+	if len(suggestions) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%v%s", err, didYouMean(suggestions))
+}
+
+func didYouMean(suggestions []string) string {
+	return "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t") + "\n"
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var testOptions api.TestOptions
+
+func init() {
+	testCmd.Flags().BoolVarP(&testOptions.Flows, "flows", "", false, "also run the flow tests of the tests directory")
+	testCmd.Flags().BoolVarP(&testOptions.Contribs, "contribs", "", false, "also run the tests of the imported contributions")
+	testCmd.Flags().StringVarP(&testOptions.Run, "run", "r", "", "only run the flow tests whose name contains the value")
+	rootCmd.AddCommand(testCmd)
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test [flags]",
+	Short: "run the tests of the application",
+	Long: `Runs 'go test' in the src directory of the application and, with --flows, the flow tests of the tests directory.
+A flow test is a JSON file naming the flow to run, its input and the values expected in its output.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.TestProject(commandContext(), common.CurrentProject(), testOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.test", err))
+			os.Exit(1)
+		}
+	},
+}
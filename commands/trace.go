@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var traceViewOptions api.TraceViewOptions
+
+func init() {
+	traceViewCmd.Flags().StringVarP(&traceViewOptions.Flow, "flow", "", "", "only show the executions of the flow")
+	traceViewCmd.Flags().BoolVarP(&traceViewOptions.ErrorsOnly, "errors", "", false, "only show the failed executions")
+	traceViewCmd.Flags().BoolVarP(&traceViewOptions.Full, "full", "", false, "show the complete inputs and outputs of the tasks")
+	traceViewCmd.Flags().IntVarP(&traceViewOptions.Last, "last", "n", 0, "only show the last n executions")
+	traceCmd.AddCommand(traceViewCmd)
+	rootCmd.AddCommand(traceCmd)
+}
+
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "view the execution traces of an application",
+	Long:  `View the execution traces of an application built with --trace.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var traceViewCmd = &cobra.Command{
+	Use:   "view [file]",
+	Short: "view the execution traces",
+	Long: `Renders the execution traces of an application, grouped by flow execution, by default the traces in the trace.jsonl file of the project.
+The output of an application tracing to stdout can also be viewed, the lines which aren't traces are skipped.`,
+	Args: cobra.MaximumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional, a trace file may be viewed outside of it
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) > 0 {
+			traceViewOptions.File = args[0]
+		}
+
+		err := api.ViewTrace(common.CurrentProject(), traceViewOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.trace", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var triggerHandler string
+
+func init() {
+	triggerEnableCmd.Flags().StringVarP(&triggerHandler, "handler", "", "", "name or index of the handler")
+	triggerDisableCmd.Flags().StringVarP(&triggerHandler, "handler", "", "", "name or index of the handler")
+	triggerCmd.AddCommand(triggerListCmd)
+	triggerCmd.AddCommand(triggerEnableCmd)
+	triggerCmd.AddCommand(triggerDisableCmd)
+	rootCmd.AddCommand(triggerCmd)
+}
+
+var triggerCmd = &cobra.Command{
+	Use:   "trigger",
+	Short: "manage application triggers",
+	Long:  `Manage the triggers of the application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var triggerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list triggers",
+	Long:  `Lists the triggers and handlers of the application.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ListTriggers(common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.listTriggers", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var triggerEnableCmd = &cobra.Command{
+	Use:   "enable [flags] <id>",
+	Short: "enable a trigger or handler",
+	Long:  `Enables a disabled trigger or handler.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.SetTriggerEnabled(common.CurrentProject(), args[0], triggerHandler, true)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.enableTrigger", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var triggerDisableCmd = &cobra.Command{
+	Use:   "disable [flags] <id>",
+	Short: "disable a trigger or handler",
+	Long:  `Disables a trigger or handler, disabled triggers and handlers are not started by the engine.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.SetTriggerEnabled(common.CurrentProject(), args[0], triggerHandler, false)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.disableTrigger", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(uiCmd)
+}
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "terminal UI for the project",
+	Long:  "Launches a terminal UI showing the project's triggers, flows, imports and recent builds, with actions to install, upgrade, build and validate",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RunUI(commandContext(), common.CurrentProject())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.ui", err))
+			os.Exit(1)
+		}
+	},
+}
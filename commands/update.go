@@ -37,13 +37,13 @@ func updatePackage(project common.AppProject, args []string, all bool) {
 
 	if !all {
 		if len(args) < 1 {
-			fmt.Fprintf(os.Stderr, "Contribution not specified")
+			fmt.Fprintln(os.Stderr, util.T("error.update.noContrib"))
 			os.Exit(1)
 		}
-		err := api.UpdatePkg(project, args[0])
+		err := api.UpdatePkg(commandContext(), project, args[0])
 
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating contribution/dependency: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.update", err))
 			os.Exit(1)
 		}
 
@@ -51,16 +51,16 @@ func updatePackage(project common.AppProject, args []string, all bool) {
 		//Get all imports
 		imports, err := util.GetAppImports(filepath.Join(project.Dir(), fJsonFile), project.DepManager(), true)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error updating all contributions: %v\n", err)
+			fmt.Fprintln(os.Stderr, util.T("error.updateAll", err))
 			os.Exit(1)
 		}
 		//Update each package in imports
 		for _, imp := range imports.GetAllImports() {
 
-			err = api.UpdatePkg(project, imp.GoGetImportPath())
+			err = api.UpdatePkg(commandContext(), project, imp.GoGetImportPath())
 
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error updating contribution/dependency: %v\n", err)
+				fmt.Fprintln(os.Stderr, util.T("error.update", err))
 				os.Exit(1)
 			}
 		}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var upgradeOptions api.UpgradeOptions
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeOptions.Major, "major", false, "also upgrade to new major versions, which may have breaking changes")
+	upgradeCmd.Flags().BoolVarP(&upgradeOptions.DryRun, "dry-run", "n", false, "print the upgrades, without applying them")
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [flags] [import...]",
+	Short: "upgrade the contributions to their latest version",
+	Long: `Upgrades the modules of the imported contributions to the latest version available from the module proxy, within their major version unless --major is set.
+The imports to upgrade are selected by import path, module or alias, all of them are upgraded by default.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		upgradeOptions.Imports = args
+		err := api.UpgradeContribs(commandContext(), common.CurrentProject(), upgradeOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.upgrade", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var usageJson bool
+
+func init() {
+	usageCmd.Flags().BoolVarP(&usageJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(usageCmd)
+}
+
+var usageCmd = &cobra.Command{
+	Use:   "usage [flags]",
+	Short: "show the usage of the contributions",
+	Long:  `Shows, per imported contribution, the flows and handlers using it, highlighting the unused and heavily used contributions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.ShowContribUsage(common.CurrentProject(), usageJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.usage", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var validateProbe bool
+var validateJson bool
+var validateStream bool
+
+func init() {
+	validateCmd.Flags().BoolVarP(&validateProbe, "probe", "", false, "perform live connectivity tests of connections")
+	validateCmd.Flags().BoolVarP(&validateJson, "json", "j", false, "print in json format")
+	validateCmd.Flags().BoolVarP(&validateStream, "stream", "", false, "print the issues to stderr as soon as they are found, before the report")
+	rootCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [flags]",
+	Short: "validate the flogo application",
+	Long:  `Validates the flogo application descriptor against the installed contributions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		options := api.ValidateOptions{Probe: validateProbe}
+		if validateStream {
+			options.OnIssue = func(issue *api.ValidationIssue) {
+				fmt.Fprintln(os.Stderr, issue)
+			}
+		}
+
+		issues, err := api.ValidateProject(common.CurrentProject(), options)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.validate", err))
+			os.Exit(1)
+		}
+
+		err = api.PrintValidationIssues(issues, validateJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.printValidation", err))
+			os.Exit(1)
+		}
+
+		if api.HasErrors(issues) {
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var verifyDescriptor string
+
+func init() {
+	verifyCmd.Flags().StringVarP(&verifyDescriptor, "file", "f", "", "app descriptor to compare the application with, defaults to the flogo.json of the project")
+	rootCmd.AddCommand(verifyCmd)
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [binary]",
+	Short: "verify that an application matches the project",
+	Long: `Verifies that a built application matches the project, by comparing the app descriptor and the modules it was built from with the current ones.
+The binary defaults to the application built in the project.`,
+	Args: cobra.MaximumNArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+
+		// the project is optional when the app descriptor is specified
+		if currentDir, err := os.Getwd(); err == nil {
+			appProject := api.NewAppProject(currentDir)
+			if appProject.Validate() == nil {
+				common.SetCurrentProject(appProject)
+			}
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		project := common.CurrentProject()
+
+		binary := ""
+		if len(args) > 0 {
+			binary = args[0]
+		} else if project != nil {
+			binary = project.Executable()
+		} else {
+			fmt.Fprintln(os.Stderr, util.T("error.verify.noBinary"))
+			os.Exit(1)
+		}
+
+		err := api.VerifyBinary(project, binary, verifyDescriptor)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.verify", err))
+			os.Exit(1)
+		}
+	},
+}
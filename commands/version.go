@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var versionJson bool
+
+func init() {
+	versionCmd.Flags().BoolVarP(&versionJson, "json", "j", false, "print in json format")
+	rootCmd.AddCommand(versionCmd)
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version [flags]",
+	Short: "show the version of the cli",
+	Long: `Shows the version of the cli, its release channel, the commit it was built from and the versions of the core and Go
+it was built with, as recorded in the applications it builds.`,
+	Args:             cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.PrintCLIBuildInfo(os.Stdout, versionJson)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.version", err))
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var watchOptions api.WatchOptions
+
+func init() {
+	watchCmd.Flags().DurationVarP(&watchOptions.Interval, "interval", "", api.DefaultWatchInterval, "interval at which the project is checked for changes")
+	watchCmd.Flags().StringVarP(&watchOptions.Build.Variant, "variant", "", "", "build using the specified resource variant")
+	watchCmd.Flags().StringSliceVarP(&watchOptions.Build.Tags, "tags", "", nil, "build tags, enables the imports conditional on these tags")
+	watchCmd.Flags().StringArrayVarP(&watchOptions.Start.Env, "env", "e", nil, "environment variable to set, as KEY=VALUE")
+	watchCmd.Flags().StringArrayVarP(&watchOptions.Start.Props, "props", "p", nil, "JSON file containing app property values")
+	watchCmd.Flags().StringVarP(&watchOptions.Start.LogLevel, "log-level", "l", "", "log level of the engine [debug, info, warn, error]")
+	watchCmd.Flags().DurationVarP(&watchOptions.StopTimeout, "timeout", "t", 10*time.Second, "time to wait for the application to stop before killing it")
+	rootCmd.AddCommand(watchCmd)
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [flags]",
+	Short: "rebuild and restart the application on change",
+	Long: `Builds and starts the application in the background, then rebuilds and restarts it each time the flogo.json, the sources of the project or the properties files change.
+The application is stopped when the watch is interrupted, its output is written to .flogo/app.log.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.WatchProject(commandContext(), common.CurrentProject(), watchOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.watch", err))
+			os.Exit(1)
+		}
+	},
+}
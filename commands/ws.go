@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-flogo/cli/api"
+	"github.com/project-flogo/cli/common"
+	"github.com/project-flogo/cli/util"
+	"github.com/spf13/cobra"
+)
+
+var wsDir string
+var prefetchOptions api.PrefetchOptions
+var alignOptions api.AlignOptions
+
+func init() {
+	wsCmd.PersistentFlags().StringVarP(&wsDir, "dir", "d", ".", "root directory of the workspace")
+	wsPrefetchCmd.Flags().IntVarP(&prefetchOptions.Jobs, "jobs", "j", 0, "number of concurrent downloads, defaults to the number of CPUs")
+	wsAlignCmd.Flags().BoolVarP(&alignOptions.All, "all", "a", false, "align all the modules required at different versions to the highest one")
+	wsCmd.AddCommand(wsPrefetchCmd)
+	wsCmd.AddCommand(wsAlignCmd)
+	wsCmd.AddCommand(wsAddContribCmd)
+	wsCmd.AddCommand(wsRemoveContribCmd)
+	wsCmd.AddCommand(wsSyncCmd)
+	rootCmd.AddCommand(wsCmd)
+}
+
+var wsCmd = &cobra.Command{
+	Use:   "ws",
+	Short: "manage a workspace of applications",
+	Long:  `Manage a workspace, a directory containing several flogo application projects, ex. a monorepo.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		api.SetVerbose(verbose)
+		common.SetVerbose(verbose)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+
+	},
+}
+
+var wsPrefetchCmd = &cobra.Command{
+	Use:   "prefetch",
+	Short: "download the dependencies of the workspace applications",
+	Long:  `Downloads the union of the modules required by the applications of the workspace into the module cache, concurrently and once per module.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		prefetchOptions.Dir = wsDir
+		err := api.PrefetchWorkspace(commandContext(), prefetchOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.wsPrefetch", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var wsAlignCmd = &cobra.Command{
+	Use:   "align [flags] [module[@version]...]",
+	Short: "align the versions of the modules of the workspace applications",
+	Long: `Reports the modules required at different versions by the applications of the workspace.
+When modules are specified, the applications requiring them are updated to require the specified version, or the highest one used in the workspace.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		alignOptions.Dir = wsDir
+		alignOptions.Modules = args
+		err := api.AlignWorkspace(commandContext(), alignOptions)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.wsAlign", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var wsAddContribCmd = &cobra.Command{
+	Use:   "add-contrib <dir>...",
+	Short: "use local contributions in the workspace applications",
+	Long: `Adds local contribution directories, containing a go.mod, to the flogo.workspace.json of the workspace.
+Their module is replaced with the directory in the go.mod of every application of the workspace.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.AddWorkspaceContribs(commandContext(), wsDir, args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.wsAdd", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var wsRemoveContribCmd = &cobra.Command{
+	Use:   "remove-contrib <dir>...",
+	Short: "stop using local contributions in the workspace applications",
+	Long:  `Removes local contribution directories from the flogo.workspace.json of the workspace and drops the replaces of their module by the applications of the workspace.`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.RemoveWorkspaceContribs(commandContext(), wsDir, args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.wsRemove", err))
+			os.Exit(1)
+		}
+	},
+}
+
+var wsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "replace the local contributions in the workspace applications",
+	Long:  `Replaces the modules of the local contributions of the workspace with their directory in the go.mod of every application of the workspace, ex. after an application is added.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		err := api.SyncWorkspace(commandContext(), wsDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, util.T("error.wsSync", err))
+			os.Exit(1)
+		}
+	},
+}
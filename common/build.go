@@ -1,13 +1,53 @@
 package common
 
+import "context"
+
 type BuildOptions struct {
 	OptimizeImports bool
 	EmbedConfig     bool
 	Shim            string
+	ShimTarget      string // the serverless platform the shim is packaged for: lambda, azure or gcf
+	FailOnSecrets   bool
+	Variant         string
+	Profile         string // the property profile whose values are baked into the application
+	ProfileEnv      bool   // write the values of the profile to bin/<app name>-<profile>.env instead of baking them in
+	Tags            []string
+	Management      bool
+	Trace           string
+	Debug           bool
+	Platforms       []string           // the platforms to build for, as os/arch, instead of the current one
+	Docker          *DockerOptions     // build a container image of the application instead of an executable
+	Provenance      *ProvenanceOptions // write the SLSA provenance of the executables built
+	Frozen          bool               // fail if the imports don't resolve to the versions of flogo.lock
+	Offline         bool               // build without downloading modules, from the module cache or the bundle
+	Bundle          string             // the bundle of modules, created by 'flogo bundle', an offline build uses
+	Force           bool               // run go build even if the executables are up to date
+	Signing         *SigningOptions    // sign the executables and the provenance built
+	LDFlags         string             // the -ldflags of go build, the build adds the ones embedding its metadata
+}
+
+// DockerOptions are the options of the container image of the application
+type DockerOptions struct {
+	Image string // the name and tag of the image, <app name>:<app version> by default
+	Base  string // the base image the executable is copied to
+	Push  bool   // push the image once built
+}
+
+// ProvenanceOptions are the options of the provenance statement of the executables
+type ProvenanceOptions struct {
+	File           string // the file the statement is written to, bin/<app name>.intoto.jsonl by default
+	SignKey        string // the PEM file of the private key signing the statement, unsigned if not set
+	BuilderVersion string // the version of the CLI building the application
+}
+
+// SigningOptions are the options of the signatures of the artifacts of the build
+type SigningOptions struct {
+	Signer string // the artifact signer, ex. cosign or minisign
+	Key    string // the key of the signer, its default key if not set
 }
 
 type Builder interface {
-	Build(project AppProject) error
+	Build(ctx context.Context, project AppProject) error
 }
 
 type BuildPreProcessor interface {
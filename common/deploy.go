@@ -0,0 +1,62 @@
+package common
+
+import (
+	"sort"
+)
+
+// DeployTarget is a destination the application is deployed to, as defined by the project
+type DeployTarget struct {
+	Name     string
+	Provider string
+	Settings map[string]interface{} // provider specific settings
+}
+
+// DeployProvider deploys the application to the targets that use it
+type DeployProvider interface {
+	// Deploy deploys the built application executable to the target
+	Deploy(project AppProject, target *DeployTarget, executable string) error
+}
+
+// DeployPlatform is implemented by providers which require the application to be built for a specific platform
+type DeployPlatform interface {
+	Platform() (goos string, goarch string)
+}
+
+// DeployPlatformDetector is implemented by providers which detect the platform the application must be built for
+// from the target, ex. by querying its hosts
+type DeployPlatformDetector interface {
+	DetectPlatform(target *DeployTarget) (goos string, goarch string, err error)
+}
+
+type registeredProvider struct {
+	plugin   string
+	provider DeployProvider
+}
+
+var deployProviders = make(map[string]*registeredProvider)
+
+// RegisterDeployProvider registers a deploy provider, a provider registered with the name of an existing one replaces it
+func RegisterDeployProvider(name string, provider DeployProvider) {
+	deployProviders[name] = &registeredProvider{plugin: callerPlugin(1), provider: provider}
+}
+
+// GetDeployProvider gets the deploy provider with the specified name, nil if it isn't registered
+func GetDeployProvider(name string) DeployProvider {
+	rp, exists := deployProviders[name]
+	if !exists || IsPluginDisabled(rp.plugin) {
+		return nil
+	}
+	return rp.provider
+}
+
+// DeployProviders gets the names of the registered deploy providers
+func DeployProviders() []string {
+	var names []string
+	for name, rp := range deployProviders {
+		if !IsPluginDisabled(rp.plugin) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
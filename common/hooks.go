@@ -0,0 +1,154 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/project-flogo/cli/internal/pluginhost"
+)
+
+const (
+	HookPreInstall         = "preInstall"
+	HookPostInstall        = "postInstall"
+	HookPreBuild           = "preBuild"
+	HookPostBuild          = "postBuild"
+	HookDescriptorModified = "descriptorModified"
+)
+
+// HookEvent is passed to the hooks registered for a project operation, hooks can mutate the event
+// to alter the operation or return an error to veto it
+type HookEvent struct {
+	Type    string
+	Project AppProject
+
+	Import     string        // the contribution/dependency being installed
+	Options    *BuildOptions // the options of the build
+	Descriptor []byte        // the updated app descriptor about to be written
+}
+
+// Hook is called when the project operation it is registered for is performed
+type Hook func(event *HookEvent) error
+
+type registeredHook struct {
+	plugin string
+	hook   Hook
+}
+
+var hooks = make(map[string][]*registeredHook)
+
+// RegisterHook registers a hook for the specified event type
+func RegisterHook(eventType string, hook Hook) {
+	hooks[eventType] = append(hooks[eventType], &registeredHook{plugin: callerPlugin(1), hook: hook})
+}
+
+// Hooks gets the hooks registered for the specified event type
+func Hooks(eventType string) []Hook {
+	var eventHooks []Hook
+	for _, rh := range enabledHooks(eventType) {
+		eventHooks = append(eventHooks, rh.hook)
+	}
+	return eventHooks
+}
+
+// enabledHooks gets the hooks of the enabled plugins registered for the event type, the ones of the plugins built
+// into the CLI come before the ones of the prebuilt plugins
+func enabledHooks(eventType string) []*registeredHook {
+
+	var eventHooks []*registeredHook
+	for _, rh := range hooks[eventType] {
+		if !IsPluginDisabled(rh.plugin) {
+			eventHooks = append(eventHooks, rh)
+		}
+	}
+
+	pluginhost.Hooks(eventType, func(plugin string, hook interface{}) {
+		if h, ok := hook.(Hook); ok && !IsPluginDisabled(plugin) {
+			eventHooks = append(eventHooks, &registeredHook{plugin: plugin, hook: h})
+		}
+	})
+
+	return eventHooks
+}
+
+// DispatchHook calls all the hooks registered for the event's type, stopping at the first one that vetoes it.
+// Any hook can veto an operation, but a plugin's hook can only alter it if the plugin was granted the
+// corresponding capability
+func DispatchHook(event *HookEvent) error {
+
+	for _, rh := range enabledHooks(event.Type) {
+		// the snapshot doesn't share any memory with the event, a hook changing it in place is detected
+		before := *event
+		before.Descriptor = append([]byte(nil), event.Descriptor...)
+		before.Options = copyBuildOptions(event.Options)
+
+		err := rh.hook(event)
+		if err != nil {
+			return err
+		}
+
+		err = checkHookChanges(rh.plugin, &before, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkHookChanges verifies that the plugin is allowed to make the changes a hook made to the event
+func checkHookChanges(plugin string, before, after *HookEvent) error {
+
+	check := func(changed bool, what, capability string) error {
+		if changed && !HasCapability(plugin, capability) {
+			return fmt.Errorf("plugin '%s' is not allowed to modify the %s, it requires the '%s' capability", plugin, what, capability)
+		}
+		return nil
+	}
+
+	err := check(before.Import != after.Import, "import being installed", CapImportsWrite)
+	if err != nil {
+		return err
+	}
+
+	err = check(!reflect.DeepEqual(before.Options, after.Options), "build options", CapOptionsWrite)
+	if err != nil {
+		return err
+	}
+
+	return check(!bytes.Equal(before.Descriptor, after.Descriptor), "app descriptor", CapDescriptorWrite)
+}
+
+// copyBuildOptions makes a deep copy of the build options
+func copyBuildOptions(options *BuildOptions) *BuildOptions {
+
+	if options == nil {
+		return nil
+	}
+
+	opts := *options
+	opts.Tags = copyStrings(options.Tags)
+	opts.Platforms = copyStrings(options.Platforms)
+	if options.Docker != nil {
+		docker := *options.Docker
+		opts.Docker = &docker
+	}
+	if options.Provenance != nil {
+		provenance := *options.Provenance
+		opts.Provenance = &provenance
+	}
+	if options.Signing != nil {
+		signing := *options.Signing
+		opts.Signing = &signing
+	}
+
+	return &opts
+}
+
+// copyStrings copies the slice, a nil slice stays nil so that the copy is deeply equal to it
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(values)), values...)
+}
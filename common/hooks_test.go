@@ -0,0 +1,118 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/project-flogo/cli/internal/pluginhost"
+	"github.com/stretchr/testify/assert"
+)
+
+func withPluginHook(t *testing.T, capabilities []string, eventType string, hook Hook) {
+	pluginhost.Remove("myplugin")
+	assert.Nil(t, pluginhost.GrantCapabilities("myplugin", capabilities))
+	assert.Nil(t, pluginhost.RegisterHook("myplugin", eventType, hook))
+	t.Cleanup(func() {
+		pluginhost.Remove("myplugin")
+	})
+}
+
+func TestDispatchHookVeto(t *testing.T) {
+
+	withPluginHook(t, nil, HookPreInstall, func(event *HookEvent) error {
+		return errors.New("not allowed")
+	})
+
+	err := DispatchHook(&HookEvent{Type: HookPreInstall, Import: "github.com/project-flogo/contrib/activity/log"})
+	assert.EqualError(t, err, "not allowed")
+}
+
+func TestDispatchHookAllowedMutation(t *testing.T) {
+
+	withPluginHook(t, []string{CapImportsWrite}, HookPreInstall, func(event *HookEvent) error {
+		event.Import = "github.com/myuser/log"
+		return nil
+	})
+
+	event := &HookEvent{Type: HookPreInstall, Import: "github.com/project-flogo/contrib/activity/log"}
+	assert.Nil(t, DispatchHook(event))
+	assert.Equal(t, "github.com/myuser/log", event.Import)
+
+	withPluginHook(t, []string{CapOptionsWrite}, HookPreBuild, func(event *HookEvent) error {
+		event.Options.Tags[0] = "debug"
+		return nil
+	})
+
+	event = &HookEvent{Type: HookPreBuild, Options: &BuildOptions{Tags: []string{"prod"}}}
+	assert.Nil(t, DispatchHook(event))
+	assert.Equal(t, []string{"debug"}, event.Options.Tags)
+}
+
+func TestDispatchHookRejectedMutation(t *testing.T) {
+
+	// the import is rewritten with a capability which doesn't allow it
+	withPluginHook(t, []string{CapOptionsWrite}, HookPreInstall, func(event *HookEvent) error {
+		event.Import = "github.com/myuser/log"
+		return nil
+	})
+	err := DispatchHook(&HookEvent{Type: HookPreInstall, Import: "github.com/project-flogo/contrib/activity/log"})
+	assert.NotNil(t, err)
+
+	// the descriptor is changed in place
+	withPluginHook(t, nil, HookDescriptorModified, func(event *HookEvent) error {
+		event.Descriptor[0] = '['
+		return nil
+	})
+	err = DispatchHook(&HookEvent{Type: HookDescriptorModified, Descriptor: []byte(`{}`)})
+	assert.NotNil(t, err)
+
+	// the slices and the nested options are changed in place
+	for _, mutate := range []func(options *BuildOptions){
+		func(options *BuildOptions) { options.Tags[0] = "debug" },
+		func(options *BuildOptions) { options.Platforms[0] = "linux/arm64" },
+		func(options *BuildOptions) { options.Docker.Base = "alpine" },
+	} {
+		mutate := mutate
+		withPluginHook(t, []string{CapDescriptorWrite}, HookPreBuild, func(event *HookEvent) error {
+			mutate(event.Options)
+			return nil
+		})
+		options := &BuildOptions{Tags: []string{"prod"}, Platforms: []string{"linux/amd64"}, Docker: &DockerOptions{Base: "scratch"}}
+		err = DispatchHook(&HookEvent{Type: HookPreBuild, Options: options})
+		assert.NotNil(t, err)
+	}
+
+	// an unchanged event, whose slices are empty, isn't a change
+	withPluginHook(t, nil, HookPreBuild, func(event *HookEvent) error {
+		return nil
+	})
+	err = DispatchHook(&HookEvent{Type: HookPreBuild, Options: &BuildOptions{Tags: []string{}}, Descriptor: []byte{}})
+	assert.Nil(t, err)
+}
+
+func TestDispatchHookUnknownPlugin(t *testing.T) {
+	t.Log("Testing that a hook which can't be attributed to a plugin can veto an operation but not alter it")
+
+	hooks = make(map[string][]*registeredHook)
+	defer func() {
+		hooks = make(map[string][]*registeredHook)
+	}()
+
+	assert.False(t, HasCapability("", CapImportsWrite))
+	assert.NotNil(t, pluginhost.RegisterHook("", HookPreInstall, Hook(func(event *HookEvent) error { return nil })))
+	assert.NotNil(t, pluginhost.GrantCapabilities("", []string{CapImportsWrite}))
+
+	RegisterHook(HookPreInstall, func(event *HookEvent) error {
+		event.Import = "github.com/myuser/log"
+		return nil
+	})
+	err := DispatchHook(&HookEvent{Type: HookPreInstall, Import: "github.com/project-flogo/contrib/activity/log"})
+	assert.NotNil(t, err)
+
+	hooks = make(map[string][]*registeredHook)
+	RegisterHook(HookPreInstall, func(event *HookEvent) error {
+		return errors.New("not allowed")
+	})
+	err = DispatchHook(&HookEvent{Type: HookPreInstall, Import: "github.com/project-flogo/contrib/activity/log"})
+	assert.EqualError(t, err, "not allowed")
+}
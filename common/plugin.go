@@ -1,24 +1,126 @@
 package common
 
 import (
+	"runtime"
+	"strings"
+
+	"github.com/project-flogo/cli/internal/pluginhost"
 	"github.com/spf13/cobra"
 )
 
+const (
+	CapDescriptorWrite = "descriptor-write"
+	CapImportsWrite    = "imports-write"
+	CapOptionsWrite    = "options-write"
+)
+
 var commands []*cobra.Command
+var commandPlugins = make(map[*cobra.Command]string)
 var pluginPkgs []string
+var pluginCapabilities = make(map[string][]string)
+var disabledPlugins = make(map[string]string)
 
 func RegisterPlugin(command *cobra.Command) {
 	commands = append(commands, command)
+	commandPlugins[command] = callerPlugin(1)
 }
 
 func GetPlugins() []*cobra.Command {
 
-	tmp := make([]*cobra.Command, len(commands))
-	copy(tmp, commands)
+	var tmp []*cobra.Command
+	for _, command := range commands {
+		if !IsPluginDisabled(commandPlugins[command]) {
+			tmp = append(tmp, command)
+		}
+	}
+
+	return tmp
+}
+
+// GetPluginCommands gets the commands registered by the plugin, whether or not it is disabled
+func GetPluginCommands(pluginPkg string) []*cobra.Command {
+
+	var tmp []*cobra.Command
+	for _, command := range commands {
+		if commandPlugins[command] == pluginPkg {
+			tmp = append(tmp, command)
+		}
+	}
 
 	return tmp
 }
 
+// DisablePlugin prevents the commands and hooks of the plugin from being used
+func DisablePlugin(pluginPkg, reason string) {
+	disabledPlugins[pluginPkg] = reason
+}
+
+// IsPluginDisabled determines if the plugin has been disabled
+func IsPluginDisabled(pluginPkg string) bool {
+	_, disabled := disabledPlugins[pluginPkg]
+	return disabled
+}
+
+// GetDisabledReason gets the reason the plugin has been disabled
+func GetDisabledReason(pluginPkg string) string {
+	return disabledPlugins[pluginPkg]
+}
+
 func GetPluginPkgs() []string{
 	return pluginPkgs
 }
+
+// GetPluginCapabilities gets the capabilities granted to the plugin when it was installed
+func GetPluginCapabilities(pluginPkg string) []string {
+	if capabilities, ok := pluginCapabilities[pluginPkg]; ok {
+		return capabilities
+	}
+	return pluginhost.Capabilities(pluginPkg)
+}
+
+// HasCapability determines if the plugin has been granted the capability, code that can't be attributed to
+// a plugin has none
+func HasCapability(pluginPkg, capability string) bool {
+
+	if pluginPkg == "" {
+		return false
+	}
+
+	for _, c := range GetPluginCapabilities(pluginPkg) {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
+// callerPlugin gets the installed plugin the code calling into the CLI belongs to, skip is the
+// number of stack frames to skip as with runtime.Caller
+func callerPlugin(skip int) string {
+
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	// function names are of the form <pkg path>.<func>, the package path can contain dots before its last slash
+	name := fn.Name()
+	pkg := name
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		pkg = name[:slash+1+dot]
+	}
+
+	for _, pluginPkg := range pluginPkgs {
+		if pkg == pluginPkg || strings.HasPrefix(pkg, pluginPkg+"/") {
+			return pluginPkg
+		}
+	}
+
+	return ""
+}
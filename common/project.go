@@ -1,6 +1,10 @@
 package common
 
-import "github.com/project-flogo/cli/util"
+import (
+	"context"
+
+	"github.com/project-flogo/cli/util"
+)
 
 type AppProject interface {
 	Validate() error
@@ -9,7 +13,7 @@ type AppProject interface {
 	BinDir() string
 	SrcDir() string
 	Executable() string
-	AddImports(ignoreError bool, addToJson bool, imports ...util.Import) error
+	AddImports(ctx context.Context, ignoreError bool, addToJson bool, imports ...util.Import) error
 	RemoveImports(imports ...string) error
 	GetPath(flogoImport util.Import) (string, error)
 	DepManager() util.DepManager
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"sort"
+)
+
+const (
+	ArtifactExecutable = "executable"
+	ArtifactSBOM       = "sbom"
+	ArtifactProvenance = "provenance"
+	ArtifactManifest   = "manifest"
+	ArtifactSignature  = "signature"
+)
+
+// Artifact is a file of a release of the application
+type Artifact struct {
+	Name   string `json:"name"` // the name of the file, as published
+	Type   string `json:"type"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	Path   string `json:"-"`
+}
+
+// Release is the artifacts of a version of the application
+type Release struct {
+	App       string      `json:"app"`
+	Version   string      `json:"version"`
+	Artifacts []*Artifact `json:"artifacts"`
+}
+
+// PublishTarget is a destination the artifacts of the application are published to, as defined by the project
+type PublishTarget struct {
+	Name      string
+	Publisher string
+	Settings  map[string]interface{} // publisher specific settings
+}
+
+// Publisher publishes the artifacts of the application to the targets that use it
+type Publisher interface {
+	// Publish uploads the artifacts of the release to the target
+	Publish(project AppProject, target *PublishTarget, release *Release) error
+}
+
+type registeredPublisher struct {
+	plugin    string
+	publisher Publisher
+}
+
+var publishers = make(map[string]*registeredPublisher)
+
+// RegisterPublisher registers a publisher, a publisher registered with the name of an existing one replaces it
+func RegisterPublisher(name string, publisher Publisher) {
+	publishers[name] = &registeredPublisher{plugin: callerPlugin(1), publisher: publisher}
+}
+
+// GetPublisher gets the publisher with the specified name, nil if it isn't registered
+func GetPublisher(name string) Publisher {
+	rp, exists := publishers[name]
+	if !exists || IsPluginDisabled(rp.plugin) {
+		return nil
+	}
+	return rp.publisher
+}
+
+// Publishers gets the names of the registered publishers
+func Publishers() []string {
+	var names []string
+	for name, rp := range publishers {
+		if !IsPluginDisabled(rp.plugin) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
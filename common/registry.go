@@ -0,0 +1,54 @@
+package common
+
+import (
+	"sort"
+)
+
+// RegistryEntry is a contribution found in a contribution index
+type RegistryEntry struct {
+	Ref         string   `json:"ref"`                   // the import path of the contribution
+	Type        string   `json:"type,omitempty"`        // activity, trigger, action or function
+	Name        string   `json:"name,omitempty"`        // the name of the contribution, its descriptor's
+	Description string   `json:"description,omitempty"` // the description of the contribution
+	Version     string   `json:"version,omitempty"`     // the latest version of the contribution
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+// RegistryClient queries a contribution index, ex. the Flogo hub or a private index of an enterprise
+type RegistryClient interface {
+	// Search gets the contributions of the index matching the query, a keyword
+	Search(index string, query string) ([]*RegistryEntry, error)
+}
+
+type registeredRegistryClient struct {
+	plugin string
+	client RegistryClient
+}
+
+var registryClients = make(map[string]*registeredRegistryClient)
+
+// RegisterRegistryClient registers a registry client, a client registered with the name of an existing one replaces it
+func RegisterRegistryClient(name string, client RegistryClient) {
+	registryClients[name] = &registeredRegistryClient{plugin: callerPlugin(1), client: client}
+}
+
+// GetRegistryClient gets the registry client with the specified name, nil if it isn't registered
+func GetRegistryClient(name string) RegistryClient {
+	rc, exists := registryClients[name]
+	if !exists || IsPluginDisabled(rc.plugin) {
+		return nil
+	}
+	return rc.client
+}
+
+// RegistryClients gets the names of the registered registry clients
+func RegistryClients() []string {
+	var names []string
+	for name, rc := range registryClients {
+		if !IsPluginDisabled(rc.plugin) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
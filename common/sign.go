@@ -0,0 +1,46 @@
+package common
+
+import (
+	"context"
+	"sort"
+)
+
+// ArtifactSigner signs the artifacts of a build, ex. using cosign or minisign
+type ArtifactSigner interface {
+	// Sign signs the artifact using the key, the default key of the signer if empty, the files of the signature are
+	// returned
+	Sign(ctx context.Context, artifact string, key string) ([]string, error)
+}
+
+type registeredArtifactSigner struct {
+	plugin string
+	signer ArtifactSigner
+}
+
+var artifactSigners = make(map[string]*registeredArtifactSigner)
+
+// RegisterArtifactSigner registers an artifact signer, a signer registered with the name of an existing one replaces it
+func RegisterArtifactSigner(name string, signer ArtifactSigner) {
+	artifactSigners[name] = &registeredArtifactSigner{plugin: callerPlugin(1), signer: signer}
+}
+
+// GetArtifactSigner gets the artifact signer with the specified name, nil if it isn't registered
+func GetArtifactSigner(name string) ArtifactSigner {
+	rs, exists := artifactSigners[name]
+	if !exists || IsPluginDisabled(rs.plugin) {
+		return nil
+	}
+	return rs.signer
+}
+
+// ArtifactSigners gets the names of the registered artifact signers
+func ArtifactSigners() []string {
+	var names []string
+	for name, rs := range artifactSigners {
+		if !IsPluginDisabled(rs.plugin) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
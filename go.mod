@@ -6,7 +6,9 @@ require (
 	github.com/pkg/errors v0.8.1 // indirect
 	github.com/project-flogo/core v0.9.5-beta.1
 	github.com/spf13/cobra v0.0.5
+	github.com/spf13/pflag v1.0.3
 	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.2.2
 )
 
-go 1.12
\ No newline at end of file
+go 1.12
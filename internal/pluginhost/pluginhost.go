@@ -0,0 +1,64 @@
+// Package pluginhost holds the hooks and the granted capabilities of the prebuilt plugins. Being internal,
+// it can only be used by the CLI itself, plugins built into the CLI can't register hooks on behalf of
+// another plugin nor grant themselves capabilities.
+package pluginhost
+
+import "errors"
+
+type registeredHook struct {
+	plugin string
+	hook   interface{}
+}
+
+var hooks = make(map[string][]*registeredHook)
+var capabilities = make(map[string][]string)
+
+// RegisterHook registers a hook on behalf of the prebuilt plugin, the hook is a common.Hook which can't be
+// referenced here since the common package depends on this one
+func RegisterHook(plugin, eventType string, hook interface{}) error {
+
+	if plugin == "" {
+		return errors.New("a hook can't be registered without a plugin name")
+	}
+
+	hooks[eventType] = append(hooks[eventType], &registeredHook{plugin: plugin, hook: hook})
+	return nil
+}
+
+// Hooks calls fn for each hook registered by the prebuilt plugins for the event type, in registration order
+func Hooks(eventType string, fn func(plugin string, hook interface{})) {
+	for _, rh := range hooks[eventType] {
+		fn(rh.plugin, rh.hook)
+	}
+}
+
+// GrantCapabilities sets the capabilities the user granted to the prebuilt plugin
+func GrantCapabilities(plugin string, granted []string) error {
+
+	if plugin == "" {
+		return errors.New("capabilities can't be granted without a plugin name")
+	}
+
+	capabilities[plugin] = granted
+	return nil
+}
+
+// Capabilities gets the capabilities granted to the prebuilt plugin
+func Capabilities(plugin string) []string {
+	return capabilities[plugin]
+}
+
+// Remove removes the hooks and the capabilities of the prebuilt plugin
+func Remove(plugin string) {
+
+	delete(capabilities, plugin)
+	for eventType, eventHooks := range hooks {
+		var tmp []*registeredHook
+		for _, rh := range eventHooks {
+			if rh.plugin != plugin {
+				tmp = append(tmp, rh)
+			}
+		}
+		hooks[eventType] = tmp
+	}
+}
@@ -0,0 +1,135 @@
+package rpcplugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is a connection to a running RPC plugin
+type Client struct {
+	cmd    *exec.Cmd
+	rpc    *rpc.Client
+	output chan struct{}
+}
+
+// Start starts the plugin binary and connects to it
+func Start(path string) (*Client, error) {
+
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), EnvKeyMagicCookie+"="+MagicCookie)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(stdout)
+
+	addr, err := readHandshake(reader)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("plugin '%s' failed to start: %v", path, err)
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+
+	c := &Client{cmd: cmd, rpc: jsonrpc.NewClient(conn), output: make(chan struct{})}
+
+	// anything else the plugin writes to stdout is shown to the user
+	go func() {
+		_, _ = io.Copy(os.Stdout, reader)
+		close(c.output)
+	}()
+
+	return c, nil
+}
+
+func readHandshake(reader *bufio.Reader) (string, error) {
+
+	lineCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			errCh <- err
+			return
+		}
+		lineCh <- line
+	}()
+
+	var line string
+	select {
+	case line = <-lineCh:
+	case err := <-errCh:
+		return "", err
+	case <-time.After(connectTimeout):
+		return "", fmt.Errorf("timed out waiting for handshake")
+	}
+
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 3 || parts[0] != handshakePrefix {
+		return "", fmt.Errorf("invalid handshake '%s'", strings.TrimSpace(line))
+	}
+
+	if parts[1] != strconv.Itoa(ProtocolVersion) {
+		return "", fmt.Errorf("unsupported plugin protocol version %s, expected %d", parts[1], ProtocolVersion)
+	}
+
+	return parts[2], nil
+}
+
+// Info gets the description of the plugin
+func (c *Client) Info() (*PluginInfo, error) {
+	info := &PluginInfo{}
+	err := c.rpc.Call("Plugin.Info", 0, info)
+	return info, err
+}
+
+// Execute executes one of the plugin's commands
+func (c *Client) Execute(command string, args []string) error {
+	var reply int
+	return c.rpc.Call("Plugin.Execute", &ExecuteArgs{Command: command, Args: args}, &reply)
+}
+
+// Hook calls the plugin's hook for the event, the event is updated with the plugin's changes
+func (c *Client) Hook(event *HookEvent) error {
+
+	reply := &HookEvent{}
+	err := c.rpc.Call("Plugin.Hook", event, reply)
+	if err != nil {
+		return err
+	}
+
+	*event = *reply
+	return nil
+}
+
+// Close disconnects from the plugin and waits for it to exit
+func (c *Client) Close() error {
+	_ = c.rpc.Close()
+	<-c.output
+	return c.cmd.Wait()
+}
@@ -0,0 +1,52 @@
+// Package rpcplugin provides support for CLI plugins which are distributed as prebuilt binaries. The CLI
+// starts the plugin binary and communicates with it using RPC, so the CLI doesn't need to be rebuilt
+// when such a plugin is installed.
+package rpcplugin
+
+import (
+	"github.com/project-flogo/cli/common"
+)
+
+const (
+	ProtocolVersion = 1
+
+	EnvKeyMagicCookie = "FLOGO_PLUGIN_MAGIC_COOKIE"
+	MagicCookie       = "5b4b9c7e-flogo-cli-plugin"
+
+	handshakePrefix = "flogo-plugin"
+)
+
+// PluginInfo describes an RPC plugin, it is obtained from the plugin when it is installed
+type PluginInfo struct {
+	Name         string
+	Version      string
+	Description  string
+	CLIVersion   string   // constraint on the versions of the CLI, ex. ">=0.9.0, <1.0.0"
+	Capabilities []string // see common.CapImportsWrite, common.CapOptionsWrite and common.CapDescriptorWrite
+	Commands     []*CommandInfo
+	Hooks        []string // the types of the events the plugin handles, see common.HookPreInstall etc.
+}
+
+// CommandInfo describes a command provided by an RPC plugin
+type CommandInfo struct {
+	Use   string
+	Short string
+	Long  string
+}
+
+// ExecuteArgs are the arguments of the execution of a plugin command
+type ExecuteArgs struct {
+	Command string
+	Args    []string
+}
+
+// HookEvent is the event passed to the hooks of an RPC plugin, the plugin can mutate it in the same way
+// as common.HookEvent
+type HookEvent struct {
+	Type       string
+	ProjectDir string
+
+	Import     string
+	Options    *common.BuildOptions
+	Descriptor []byte
+}
@@ -0,0 +1,100 @@
+package rpcplugin
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"time"
+)
+
+const (
+	connectTimeout = 10 * time.Second
+)
+
+// Plugin is implemented by RPC plugins
+type Plugin interface {
+	// Info describes the plugin
+	Info() *PluginInfo
+
+	// Execute executes one of the commands of the plugin, the output of the plugin is shown to the user
+	Execute(command string, args []string) error
+}
+
+// HookHandler is implemented by RPC plugins which declare hooks
+type HookHandler interface {
+	HandleHook(event *HookEvent) error
+}
+
+// Serve serves the plugin to the CLI, it is called from the main function of the plugin
+func Serve(p Plugin) {
+
+	if os.Getenv(EnvKeyMagicCookie) != MagicCookie {
+		fmt.Fprintf(os.Stderr, "This binary is a flogo CLI plugin, install it using 'flogo plugin install --binary'\n")
+		os.Exit(1)
+	}
+
+	err := serve(p, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error serving plugin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serve writes the handshake to the CLI and serves the plugin on the connection of the CLI
+func serve(p Plugin, handshake io.Writer) error {
+
+	server := rpc.NewServer()
+	err := server.RegisterName("Plugin", &rpcServer{impl: p})
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	// the CLI connects right after the handshake, don't wait forever if it went away
+	_ = l.(*net.TCPListener).SetDeadline(time.Now().Add(connectTimeout))
+
+	fmt.Fprintf(handshake, "%s|%d|%s\n", handshakePrefix, ProtocolVersion, l.Addr().String())
+
+	conn, err := l.Accept()
+	if err != nil {
+		return err
+	}
+
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+
+	return nil
+}
+
+type rpcServer struct {
+	impl Plugin
+}
+
+func (s *rpcServer) Info(args int, reply *PluginInfo) error {
+	*reply = *s.impl.Info()
+	return nil
+}
+
+func (s *rpcServer) Execute(args *ExecuteArgs, reply *int) error {
+	return s.impl.Execute(args.Command, args.Args)
+}
+
+func (s *rpcServer) Hook(event *HookEvent, reply *HookEvent) error {
+
+	if handler, ok := s.impl.(HookHandler); ok {
+		err := handler.HandleHook(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	*reply = *event
+	return nil
+}
@@ -0,0 +1,80 @@
+package rpcplugin
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/rpc/jsonrpc"
+	"strings"
+	"testing"
+
+	"github.com/project-flogo/cli/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlugin struct {
+	executed []string
+}
+
+func (p *testPlugin) Info() *PluginInfo {
+	return &PluginInfo{Name: "test", Version: "v1.0.0", Commands: []*CommandInfo{{Use: "hello"}}, Hooks: []string{common.HookPreBuild}}
+}
+
+func (p *testPlugin) Execute(command string, args []string) error {
+	if command != "hello" {
+		return errors.New("unknown command " + command)
+	}
+	p.executed = append(p.executed, args...)
+	return nil
+}
+
+func (p *testPlugin) HandleHook(event *HookEvent) error {
+	event.Options.Tags = append(event.Options.Tags, "plugin")
+	return nil
+}
+
+func TestServe(t *testing.T) {
+	t.Log("Testing the handshake and the calls of an RPC plugin")
+
+	r, w := io.Pipe()
+	plugin := &testPlugin{}
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(plugin, w)
+	}()
+
+	addr, err := readHandshake(bufio.NewReader(r))
+	assert.Nil(t, err)
+
+	conn, err := net.Dial("tcp", addr)
+	assert.Nil(t, err)
+	client := &Client{rpc: jsonrpc.NewClient(conn)}
+
+	info, err := client.Info()
+	assert.Nil(t, err)
+	assert.Equal(t, "test", info.Name)
+	assert.Equal(t, "hello", info.Commands[0].Use)
+
+	assert.Nil(t, client.Execute("hello", []string{"world"}))
+	assert.Equal(t, []string{"world"}, plugin.executed)
+	assert.EqualError(t, client.Execute("bye", nil), "unknown command bye")
+
+	event := &HookEvent{Type: common.HookPreBuild, Options: &common.BuildOptions{Tags: []string{"prod"}}}
+	assert.Nil(t, client.Hook(event))
+	assert.Equal(t, []string{"prod", "plugin"}, event.Options.Tags)
+
+	assert.Nil(t, client.rpc.Close())
+	assert.Nil(t, <-served)
+}
+
+func TestReadHandshake(t *testing.T) {
+
+	for _, handshake := range []string{"hello\n", "flogo-plugin|2|127.0.0.1:1234\n", "flogo-plugin|1\n"} {
+		_, err := readHandshake(bufio.NewReader(strings.NewReader(handshake)))
+		assert.NotNil(t, err, handshake)
+	}
+
+	_, err := readHandshake(bufio.NewReader(strings.NewReader("")))
+	assert.NotNil(t, err)
+}
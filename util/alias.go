@@ -0,0 +1,94 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExpandAlias expands an alias with the arguments it was invoked with. Placeholders $1..$n in the expansion
+// are replaced by the corresponding argument and $@ by all the arguments, the arguments not consumed by a
+// placeholder are appended.
+func ExpandAlias(expansion string, args []string) ([]string, error) {
+
+	words, err := SplitArgs(expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make([]bool, len(args))
+	var expanded []string
+
+	for _, word := range words {
+		if word == "$@" {
+			expanded = append(expanded, args...)
+			for i := range used {
+				used[i] = true
+			}
+			continue
+		}
+
+		if strings.HasPrefix(word, "$") {
+			if n, err := strconv.Atoi(word[1:]); err == nil && n > 0 {
+				if n > len(args) {
+					return nil, fmt.Errorf("alias requires at least %d argument(s)", n)
+				}
+				expanded = append(expanded, args[n-1])
+				used[n-1] = true
+				continue
+			}
+		}
+
+		expanded = append(expanded, word)
+	}
+
+	for i, arg := range args {
+		if !used[i] {
+			expanded = append(expanded, arg)
+		}
+	}
+
+	return expanded, nil
+}
+
+// SplitArgs splits a command line into its arguments, single and double quotes can be used to group words
+func SplitArgs(cmdLine string) ([]string, error) {
+
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range cmdLine {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in '%s'", cmdLine)
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
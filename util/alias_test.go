@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitArgs(t *testing.T) {
+	t.Log("Testing splitting of alias expansions")
+
+	args, err := SplitArgs(`build -e  --variant "mock flows" -o`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"build", "-e", "--variant", "mock flows", "-o"}, args)
+
+	_, err = SplitArgs(`build "unterminated`)
+	assert.NotNil(t, err)
+}
+
+func TestExpandAlias(t *testing.T) {
+	t.Log("Testing expansion of aliases with arguments")
+
+	args, err := ExpandAlias("build -e", []string{"-o"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"build", "-e", "-o"}, args)
+
+	args, err = ExpandAlias("flow promote $1 v2", []string{"myflow", "--verbose"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"flow", "promote", "myflow", "v2", "--verbose"}, args)
+
+	args, err = ExpandAlias("install $@ --verbose", []string{"a", "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"install", "a", "b", "--verbose"}, args)
+
+	_, err = ExpandAlias("flow promote $1 $2", []string{"myflow"})
+	assert.NotNil(t, err)
+}
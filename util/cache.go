@@ -0,0 +1,230 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContribCache caches the paths and descriptors of the contributions of a project. The paths are determined by the
+// go.mod of the project, so the cache is keyed by its hash and discarded when it changes. The descriptors of
+// contributions outside of the module cache (ex. replaced by a local directory) are checked for modifications.
+type ContribCache struct {
+	file      string
+	goModFile string
+
+	mu      sync.Mutex
+	loaded  bool
+	content *contribCacheContent
+}
+
+type contribCacheContent struct {
+	GoModHash   string                                 `json:"goModHash"`
+	Paths       map[string]string                      `json:"paths"`
+	Descriptors map[string]*contribCacheDescriptorInfo `json:"descriptors"`
+}
+
+type contribCacheDescriptorInfo struct {
+	File       string                  `json:"file,omitempty"`
+	ModTime    time.Time               `json:"modTime,omitempty"`
+	Descriptor *FlogoContribDescriptor `json:"descriptor,omitempty"`
+	IsLegacy   bool                    `json:"isLegacy,omitempty"`
+}
+
+// NewContribCache creates the cache stored in file for the project whose dependencies are in goModFile
+func NewContribCache(file, goModFile string) *ContribCache {
+	return &ContribCache{file: file, goModFile: goModFile}
+}
+
+// NewCachedDepManager creates a DepManager caching the paths of the contributions resolved by depManager
+func NewCachedDepManager(depManager DepManager, cache *ContribCache) DepManager {
+	return &cachedDepManager{DepManager: depManager, cache: cache}
+}
+
+type cachedDepManager struct {
+	DepManager
+	cache *ContribCache
+}
+
+func (m *cachedDepManager) GetPath(flogoImport Import) (string, error) {
+
+	if path, ok := m.cache.path(flogoImport); ok {
+		return path, nil
+	}
+
+	path, err := m.DepManager.GetPath(flogoImport)
+	if err != nil || path == "" {
+		return path, err
+	}
+
+	m.cache.putPath(flogoImport, path)
+
+	return path, nil
+}
+
+// FileHash gets the SHA-256 hash of the content of the file, "" if it can't be read
+func FileHash(file string) string {
+
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *ContribCache) load() *contribCacheContent {
+
+	goModHash := FileHash(c.goModFile)
+
+	if c.loaded && c.content.GoModHash == goModHash {
+		return c.content
+	}
+
+	content := &contribCacheContent{}
+	if buf, err := ioutil.ReadFile(c.file); err == nil {
+		_ = json.Unmarshal(buf, content)
+	}
+
+	if content.GoModHash != goModHash || content.Paths == nil || content.Descriptors == nil {
+		content = &contribCacheContent{GoModHash: goModHash, Paths: make(map[string]string), Descriptors: make(map[string]*contribCacheDescriptorInfo)}
+	}
+
+	c.loaded = true
+	c.content = content
+
+	return content
+}
+
+func (c *ContribCache) save() {
+
+	buf, err := json.Marshal(c.content)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(c.file), 0755)
+	}
+	if err == nil {
+		err = WriteFileAtomic(c.file, buf, 0644)
+	}
+
+	// failing to cache doesn't affect the command
+	if err != nil && verbose {
+		fmt.Printf("Unable to write contribution cache '%s': %v\n", c.file, err)
+	}
+}
+
+func (c *ContribCache) path(flogoImport Import) (string, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path, ok := c.load().Paths[flogoImport.CanonicalImport()]
+	if ok && !DirExists(path) {
+		return "", false
+	}
+
+	return path, ok
+}
+
+func (c *ContribCache) putPath(flogoImport Import, path string) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// a project without a go.mod isn't cached
+	content := c.load()
+	if content.GoModHash == "" {
+		return
+	}
+
+	content.Paths[flogoImport.CanonicalImport()] = path
+	c.save()
+}
+
+// GetContribDescriptor gets the descriptor of the contribution located at path, as GetContribDescriptor, from the cache
+// if it contains an up to date copy
+func (c *ContribCache) GetContribDescriptor(path string) (*FlogoContribDescriptor, error) {
+
+	if desc, ok := c.descriptor(path); ok {
+		return desc, nil
+	}
+
+	desc, err := GetContribDescriptor(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.putDescriptor(path, desc)
+
+	return desc, nil
+}
+
+func (c *ContribCache) descriptor(path string) (*FlogoContribDescriptor, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, ok := c.load().Descriptors[path]
+	if !ok {
+		return nil, false
+	}
+
+	if info.File != "" {
+		fi, err := os.Stat(info.File)
+		if err != nil || !fi.ModTime().Equal(info.ModTime) {
+			return nil, false
+		}
+	}
+
+	if info.Descriptor != nil {
+		info.Descriptor.IsLegacy = info.IsLegacy
+	}
+
+	return info.Descriptor, true
+}
+
+func (c *ContribCache) putDescriptor(path string, desc *FlogoContribDescriptor) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	content := c.load()
+	if content.GoModHash == "" {
+		return
+	}
+
+	info := &contribCacheDescriptorInfo{Descriptor: desc}
+	if desc != nil {
+		info.IsLegacy = desc.IsLegacy
+	}
+
+	// the content of the module cache is immutable, contributions elsewhere may be modified
+	if !isModuleCachePath(path) {
+		descriptorFile := findContribDescriptorFile(path)
+		if descriptorFile == "" {
+			// the descriptor may be added later
+			return
+		}
+		fi, err := os.Stat(descriptorFile)
+		if err != nil {
+			return
+		}
+		info.File = descriptorFile
+		info.ModTime = fi.ModTime()
+	}
+
+	content.Descriptors[path] = info
+	c.save()
+}
+
+func isModuleCachePath(path string) bool {
+	modCache := filepath.Join(os.Getenv("GOPATH"), "pkg", "mod") + string(filepath.Separator)
+	return strings.HasPrefix(path, modCache)
+}
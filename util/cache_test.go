@@ -0,0 +1,73 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingDepManager struct {
+	DepManager
+	path  string
+	calls int
+}
+
+func (m *countingDepManager) GetPath(flogoImport Import) (string, error) {
+	m.calls++
+	return m.path, nil
+}
+
+func TestContribCache(t *testing.T) {
+	t.Log("Testing caching of the paths and descriptors of contributions")
+
+	tempDir, err := ioutil.TempDir("", "flogo-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	goMod := filepath.Join(tempDir, "go.mod")
+	err = ioutil.WriteFile(goMod, []byte("module main\n"), 0644)
+	assert.Nil(t, err)
+
+	contribDir := filepath.Join(tempDir, "myactivity")
+	err = os.MkdirAll(contribDir, 0755)
+	assert.Nil(t, err)
+	descriptor := filepath.Join(contribDir, "descriptor.json")
+	err = ioutil.WriteFile(descriptor, []byte(`{"name": "myactivity", "type": "flogo:activity"}`), 0644)
+	assert.Nil(t, err)
+
+	cacheFile := filepath.Join(tempDir, ".flogo", "cache", "contribs.json")
+	dm := &countingDepManager{path: contribDir}
+	imp, _ := ParseImport("github.com/myuser/myactivity")
+
+	desc, err := GetContribDescriptorFromImport(NewCachedDepManager(dm, NewContribCache(cacheFile, goMod)), imp)
+	assert.Nil(t, err)
+	assert.Equal(t, "myactivity", desc.Name)
+	assert.Equal(t, 1, dm.calls)
+
+	// a new invocation uses the cache
+	cached := NewCachedDepManager(dm, NewContribCache(cacheFile, goMod))
+	desc, err = GetContribDescriptorFromImport(cached, imp)
+	assert.Nil(t, err)
+	assert.Equal(t, "myactivity", desc.Name)
+	assert.Equal(t, 1, dm.calls)
+
+	// the descriptor of a contribution outside of the module cache is checked for modifications
+	err = ioutil.WriteFile(descriptor, []byte(`{"name": "renamed", "type": "flogo:activity"}`), 0644)
+	assert.Nil(t, err)
+	err = os.Chtimes(descriptor, time.Now(), time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	desc, err = GetContribDescriptorFromImport(cached, imp)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", desc.Name)
+
+	// a modification of the go.mod invalidates the cache
+	err = ioutil.WriteFile(goMod, []byte("module main\n\nrequire github.com/myuser/myactivity v1.0.0\n"), 0644)
+	assert.Nil(t, err)
+	_, err = cached.GetPath(imp)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, dm.calls)
+}
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	EnvKeyFlogoHome = "FLOGO_HOME"
+
+	fileCLIConfig = "config.json"
+	dirCache      = "cache"
+
+	// the caches of the flogo home, which can be pruned using 'flogo cache prune'
+	CacheTemplates  = "templates"
+	CacheToolchains = "toolchains"
+	CacheBundles    = "bundles"
+)
+
+// CLIConfig is the configuration of the CLI, it is shared by all projects
+type CLIConfig struct {
+	Plugins map[string]*PluginConfig `json:"plugins,omitempty"`
+	Aliases map[string]string        `json:"aliases,omitempty"`
+
+	// Registry is the URL or file of the registry index, which carries the deprecations and advisories of contributions
+	Registry string `json:"registry,omitempty"`
+
+	// Locale is the locale of the messages, ex. ja, unless $FLOGO_LANG is set
+	Locale string `json:"locale,omitempty"`
+
+	// CoreVersion is the version of github.com/project-flogo/core used by the created projects, the latest by default
+	CoreVersion string `json:"coreVersion,omitempty"`
+
+	// SearchIndex is the URL or file of the contribution index queried by 'flogo search', the Flogo hub by default
+	SearchIndex string `json:"searchIndex,omitempty"`
+	// SearchClient is the registry client querying the contribution index, hub by default
+	SearchClient string `json:"searchClient,omitempty"`
+
+	// GoEnv is the Go environment of the go commands run by the CLI, ex. GOPROXY, unless a project or the environment
+	// sets it
+	GoEnv map[string]string `json:"goEnv,omitempty"`
+}
+
+// PluginConfig is what is recorded about an installed plugin
+type PluginConfig struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	CLIVersion string `json:"cliVersion,omitempty"` // the versions of the CLI the plugin is compatible with
+	Private    bool   `json:"private,omitempty"`
+	SSH        bool   `json:"ssh,omitempty"`
+
+	// prebuilt plugins, which the CLI communicates with using RPC
+	Binary       string                 `json:"binary,omitempty"`
+	Source       string                 `json:"source,omitempty"`
+	Capabilities []string               `json:"capabilities,omitempty"`
+	Commands     []*PluginCommandConfig `json:"commands,omitempty"`
+	Hooks        []string               `json:"hooks,omitempty"`
+}
+
+// PluginCommandConfig is a command provided by a prebuilt plugin
+type PluginCommandConfig struct {
+	Use   string `json:"use"`
+	Short string `json:"short,omitempty"`
+	Long  string `json:"long,omitempty"`
+}
+
+// GetFlogoHome gets the directory containing the CLI configuration, $FLOGO_HOME or ~/.flogo by default
+func GetFlogoHome() (string, error) {
+
+	if home := os.Getenv(EnvKeyFlogoHome); home != "" {
+		return home, nil
+	}
+
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userHome, ".flogo"), nil
+}
+
+// GetCacheDir gets the directory of a cache of the flogo home, $FLOGO_HOME/cache/<name>
+func GetCacheDir(name string) (string, error) {
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, dirCache, name), nil
+}
+
+// CLIConfigExists determines if the CLI configuration was saved, it isn't on the first run of the CLI
+func CLIConfigExists() bool {
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return false
+	}
+
+	return FileExists(filepath.Join(home, fileCLIConfig))
+}
+
+// LoadCLIConfig loads the CLI configuration, an empty configuration is returned if it doesn't exist yet
+func LoadCLIConfig() (*CLIConfig, error) {
+
+	config := &CLIConfig{}
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return nil, err
+	}
+
+	configFile := filepath.Join(home, fileCLIConfig)
+	if !FileExists(configFile) {
+		return config, nil
+	}
+
+	bytes, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(bytes, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CLI config '%s': %s", configFile, err.Error())
+	}
+
+	return config, nil
+}
+
+// SaveCLIConfig saves the CLI configuration
+func SaveCLIConfig(config *CLIConfig) error {
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(home, 0755)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(home, fileCLIConfig), bytes, 0644)
+}
@@ -25,9 +25,27 @@ type FlogoContribDescriptor struct {
 	Shim        string `json:"shim"`
 	Ref         string `json:"ref"` //legacy
 
+	Settings []*FlogoSettingDescriptor `json:"settings,omitempty"`
+	Handler  *FlogoHandlerDescriptor   `json:"handler,omitempty"`
+	Input    []*FlogoSettingDescriptor `json:"input,omitempty"`
+	Output   []*FlogoSettingDescriptor `json:"output,omitempty"`
+
 	IsLegacy bool `json:"-"`
 }
 
+// FlogoSettingDescriptor is the descriptor for a setting, input or output of a contribution
+type FlogoSettingDescriptor struct {
+	Name     string      `json:"name"`
+	Type     string      `json:"type"`
+	Required bool        `json:"required,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+}
+
+// FlogoHandlerDescriptor is the descriptor for the handler of a trigger
+type FlogoHandlerDescriptor struct {
+	Settings []*FlogoSettingDescriptor `json:"settings,omitempty"`
+}
+
 type FlogoContribBundleDescriptor struct {
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
@@ -43,6 +61,16 @@ func (d *FlogoContribDescriptor) GetContribType() string {
 }
 
 
+// GetSetting gets the descriptor of the named setting
+func (d *FlogoContribDescriptor) GetSetting(name string) *FlogoSettingDescriptor {
+	for _, setting := range d.Settings {
+		if setting.Name == name {
+			return setting
+		}
+	}
+	return nil
+}
+
 func GetContribDescriptorFromImport(depManager DepManager, contribImport Import) (*FlogoContribDescriptor, error) {
 
 	contribPath, err := depManager.GetPath(contribImport)
@@ -50,28 +78,21 @@ func GetContribDescriptorFromImport(depManager DepManager, contribImport Import)
 		return nil, err
 	}
 
+	if cached, ok := depManager.(*cachedDepManager); ok {
+		return cached.cache.GetContribDescriptor(contribPath)
+	}
+
 	return GetContribDescriptor(contribPath)
 }
 
 func GetContribDescriptor(contribPath string) (*FlogoContribDescriptor, error) {
 
-	var descriptorPath string
-	oldDescriptor := false
-
-	for _, descriptorName := range contribDescriptors {
-		dPath := filepath.Join(contribPath, descriptorName)
-		if _, err := os.Stat(dPath); err == nil {
-			if descriptorName != "descriptor.json" {
-				oldDescriptor = true
-			}
-			descriptorPath = dPath
-		}
-	}
-
+	descriptorPath := findContribDescriptorFile(contribPath)
 	if descriptorPath == "" {
 		//descriptor not found
 		return nil, nil
 	}
+	oldDescriptor := filepath.Base(descriptorPath) != "descriptor.json"
 
 	desc, err := ReadContribDescriptor(descriptorPath)
 	if err != nil {
@@ -85,6 +106,21 @@ func GetContribDescriptor(contribPath string) (*FlogoContribDescriptor, error) {
 	return desc, nil
 }
 
+// findContribDescriptorFile finds the descriptor of the contribution located at contribPath, "" if it has none
+func findContribDescriptorFile(contribPath string) string {
+
+	var descriptorPath string
+
+	for _, descriptorName := range contribDescriptors {
+		dPath := filepath.Join(contribPath, descriptorName)
+		if _, err := os.Stat(dPath); err == nil {
+			descriptorPath = dPath
+		}
+	}
+
+	return descriptorPath
+}
+
 func ReadContribDescriptor(descriptorFile string) (*FlogoContribDescriptor, error) {
 
 	descriptorJson, err := os.Open(descriptorFile)
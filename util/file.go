@@ -1,7 +1,11 @@
 package util
 
 import (
+	"bytes"
 	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -15,15 +19,27 @@ func IsRemote(path string) bool {
 	return strings.HasPrefix(path, "http")
 }
 
+// LoadRemoteFile gets the content of the file at the URL, authenticated using the credentials of the netrc file for
+// its host
 func LoadRemoteFile(sourceURL string) (string, error) {
 
-	resp, err := http.Get(sourceURL)
+	req, err := http.NewRequest(http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", err
+	}
+	setNetrcAuth(req)
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return "", err
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s", resp.Status)
+	}
+
 	buf, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return "", err
@@ -54,6 +70,52 @@ func Rename(srcPath string) error {
 	return nil
 }
 
+// WriteFileAtomic writes the file to a temporary file in the same directory which then replaces it, so that an
+// interrupted write never leaves a truncated file
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+
+	if info, err := os.Stat(filename); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpFile := f.Name()
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpFile, filename)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile)
+		return err
+	}
+
+	return nil
+}
+
+// WriteGoFile prints the Go source file and writes it atomically
+func WriteGoFile(filename string, fset *token.FileSet, f *ast.File) error {
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, f); err != nil {
+		return err
+	}
+
+	return WriteFileAtomic(filename, buf.Bytes(), 0644)
+}
+
 func CopyFile(srcFile, destFile string) error {
 	input, err := ioutil.ReadFile(srcFile)
 	if err != nil {
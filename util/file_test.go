@@ -0,0 +1,37 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "flogo-write")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	file := filepath.Join(tempDir, "flogo.json")
+	err = ioutil.WriteFile(file, []byte(`{"name": "old"}`), 0600)
+	assert.Nil(t, err)
+
+	err = WriteFileAtomic(file, []byte(`{"name": "new"}`), 0644)
+	assert.Nil(t, err)
+
+	buf, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "new"}`, string(buf))
+
+	// the mode of the existing file is preserved and no temporary file is left
+	info, err := os.Stat(file)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	files, err := ioutil.ReadDir(tempDir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+}
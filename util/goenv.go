@@ -0,0 +1,175 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	dirProjectConfig  = ".flogo"
+	fileProjectConfig = "config.json"
+)
+
+// the Go environment variables which can be configured for the go commands run by the CLI, by configuration key
+var goEnvSettings = map[string]string{
+	"goproxy":   "GOPROXY",
+	"goprivate": "GOPRIVATE",
+	"gonosumdb": "GONOSUMDB",
+}
+
+// ProjectConfig is the configuration of the CLI specific to a project, it overrides the CLI configuration
+type ProjectConfig struct {
+	// GoEnv is the Go environment of the go commands run in the project, ex. GOPROXY
+	GoEnv map[string]string `json:"goEnv,omitempty"`
+}
+
+// GoEnvSettings gets the configuration keys of the Go environment, ex. goproxy
+func GoEnvSettings() []string {
+	var keys []string
+	for key := range goEnvSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GoEnvVar gets the Go environment variable of the configuration key, ignoring case, empty if it isn't a Go
+// environment setting
+func GoEnvVar(key string) string {
+	return goEnvSettings[strings.ToLower(key)]
+}
+
+// ValidateGoEnv validates the value of a Go environment variable
+func ValidateGoEnv(name, value string) error {
+
+	if strings.ContainsAny(value, " \t\n") {
+		return fmt.Errorf("invalid %s '%s', it can't contain spaces", name, value)
+	}
+
+	if name == "GOPROXY" {
+		// a list of proxies separated by commas, or pipes to fall back on any error
+		for _, proxy := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' }) {
+			if proxy == "direct" || proxy == "off" {
+				continue
+			}
+			u, err := url.Parse(proxy)
+			if err != nil || u.Scheme != "https" && u.Scheme != "http" && u.Scheme != "file" || u.Host == "" && u.Scheme != "file" {
+				return fmt.Errorf("invalid GOPROXY '%s', expected URLs of module proxies, direct or off separated by commas", value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadProjectConfig loads the configuration of the CLI specific to the project, an empty configuration is returned if
+// the project has none
+func LoadProjectConfig(projectDir string) (*ProjectConfig, error) {
+
+	config := &ProjectConfig{}
+
+	configFile := filepath.Join(projectDir, dirProjectConfig, fileProjectConfig)
+	if !FileExists(configFile) {
+		return config, nil
+	}
+
+	bytes, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(bytes, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse project config '%s': %s", configFile, err.Error())
+	}
+
+	return config, nil
+}
+
+// SaveProjectConfig saves the configuration of the CLI specific to the project
+func SaveProjectConfig(projectDir string, config *ProjectConfig) error {
+
+	err := os.MkdirAll(filepath.Join(projectDir, dirProjectConfig), 0755)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFileAtomic(filepath.Join(projectDir, dirProjectConfig, fileProjectConfig), bytes, 0644)
+}
+
+// ConfiguredGoEnv gets the Go environment configured for the project, the values of the project override the ones of
+// the CLI configuration, the project is optional
+func ConfiguredGoEnv(projectDir string) (map[string]string, error) {
+
+	env := make(map[string]string)
+
+	config, err := LoadCLIConfig()
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range config.GoEnv {
+		env[name] = value
+	}
+
+	if projectDir != "" {
+		projectConfig, err := LoadProjectConfig(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range projectConfig.GoEnv {
+			env[name] = value
+		}
+	}
+
+	return env, nil
+}
+
+// GoCmdEnv gets the environment of the go commands run in the project: the environment of the CLI and the Go
+// environment configured, the variables set in the environment of the CLI take precedence as for go env -w
+func GoCmdEnv(projectDir string) []string {
+
+	env := os.Environ()
+
+	configured, err := ConfiguredGoEnv(projectDir)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: unable to load the Go environment configured: %v\n", err)
+		}
+		return env
+	}
+
+	var names []string
+	for name := range configured {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, set := os.LookupEnv(name); !set {
+			env = append(env, name+"="+configured[name])
+		}
+	}
+
+	return env
+}
+
+// goEnvProjectDir gets the directory of the project of a directory the go commands are run in, the parent of the src
+// directory of an application
+func goEnvProjectDir(dir string) string {
+
+	if filepath.Base(dir) == "src" {
+		return filepath.Dir(dir)
+	}
+
+	return dir
+}
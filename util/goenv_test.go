@@ -0,0 +1,56 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoCmdEnv(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "goenv")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	defer os.Setenv(EnvKeyFlogoHome, os.Getenv(EnvKeyFlogoHome))
+	os.Setenv(EnvKeyFlogoHome, filepath.Join(tempDir, "home"))
+	for _, name := range []string{"GOPROXY", "GOPRIVATE", "GONOSUMDB"} {
+		if value, set := os.LookupEnv(name); set {
+			defer os.Setenv(name, value)
+			os.Unsetenv(name)
+		}
+	}
+
+	projectDir := filepath.Join(tempDir, "app")
+	assert.Nil(t, SaveCLIConfig(&CLIConfig{GoEnv: map[string]string{"GOPROXY": "https://proxy.example.com", "GOPRIVATE": "example.com/*"}}))
+	assert.Nil(t, SaveProjectConfig(projectDir, &ProjectConfig{GoEnv: map[string]string{"GOPRIVATE": "git.example.com/*"}}))
+
+	env, err := ConfiguredGoEnv(projectDir)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"GOPROXY": "https://proxy.example.com", "GOPRIVATE": "git.example.com/*"}, env)
+
+	env, err = ConfiguredGoEnv("")
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com/*", env["GOPRIVATE"])
+
+	// the environment takes precedence
+	os.Setenv("GOPROXY", "direct")
+	defer os.Unsetenv("GOPROXY")
+	cmdEnv := GoCmdEnv(goEnvProjectDir(filepath.Join(projectDir, "src")))
+	assert.Contains(t, cmdEnv, "GOPROXY=direct")
+	assert.NotContains(t, cmdEnv, "GOPROXY=https://proxy.example.com")
+	assert.Contains(t, cmdEnv, "GOPRIVATE=git.example.com/*")
+}
+
+func TestValidateGoEnv(t *testing.T) {
+
+	assert.Nil(t, ValidateGoEnv("GOPROXY", "https://proxy.example.com|https://proxy.golang.org,direct"))
+	assert.Nil(t, ValidateGoEnv("GOPROXY", "off"))
+	assert.NotNil(t, ValidateGoEnv("GOPROXY", "proxy.example.com"))
+	assert.NotNil(t, ValidateGoEnv("GOPRIVATE", "example.com/*, git.example.com"))
+	assert.Equal(t, "GONOSUMDB", GoEnvVar("GoNoSumDB"))
+	assert.Equal(t, "", GoEnvVar("gopath"))
+}
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	EnvKeyFlogoLang = "FLOGO_LANG"
+
+	DefaultLocale = "en"
+
+	dirLocales = "locales"
+)
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]map[string]string{
+		"en": messagesEn,
+		"ja": messagesJa,
+		"zh": messagesZh,
+	}
+
+	locale     string
+	localeOnce sync.Once
+)
+
+// RegisterCatalog registers the messages of a locale, ex. zh-tw or de, overriding the messages already registered for
+// it, so a distribution of the CLI adds or adjusts a translation without changing the messages of the CLI
+func RegisterCatalog(locale string, messages map[string]string) {
+
+	locale = normalizeLocale(locale)
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	catalog, exists := catalogs[locale]
+	if !exists {
+		catalog = make(map[string]string)
+		catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// Locale gets the locale of the messages: $FLOGO_LANG, the locale of the CLI configuration, or the one of the
+// environment ($LC_ALL, $LC_MESSAGES or $LANG), en by default. The catalogs of $FLOGO_HOME/locales/<locale>.json
+// are registered when the locale is first resolved.
+func Locale() string {
+
+	localeOnce.Do(func() {
+		locale = resolveLocale()
+		loadLocaleFiles(locale)
+	})
+
+	return locale
+}
+
+// SetLocale sets the locale of the messages, ex. in tests
+func SetLocale(l string) {
+	localeOnce.Do(func() {})
+	locale = normalizeLocale(l)
+}
+
+// T gets the message of the locale, or the English one if it isn't translated, formatted with the arguments if any.
+// The key is returned if there is no such message.
+func T(key string, args ...interface{}) string {
+
+	message, exists := LookupMessage(key)
+	if !exists {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+// LookupMessage gets the message of the locale, or the English one if it isn't translated
+func LookupMessage(key string) (string, bool) {
+
+	candidates := localeCandidates(Locale())
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	for _, l := range candidates {
+		if message, exists := catalogs[l][key]; exists {
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
+// FormatDateTime formats a time using the layout of the locale, ex. 2006-01-02 15:04:05
+func FormatDateTime(t time.Time) string {
+	return t.Format(T("format.datetime"))
+}
+
+// FormatNumber formats an integer with the digits grouped by thousands using the separator of the locale, ex. 12,345
+func FormatNumber(n int64) string {
+
+	digits := fmt.Sprint(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(T("format.group"))
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String()
+}
+
+// localeCandidates gets the locales the messages of a locale are looked up in, ex. zh-tw, zh then en
+func localeCandidates(l string) []string {
+
+	candidates := []string{l}
+	if idx := strings.Index(l, "-"); idx > 0 {
+		candidates = append(candidates, l[:idx])
+	}
+	if l != DefaultLocale {
+		candidates = append(candidates, DefaultLocale)
+	}
+
+	return candidates
+}
+
+func resolveLocale() string {
+
+	if l := os.Getenv(EnvKeyFlogoLang); l != "" {
+		return normalizeLocale(l)
+	}
+	if config, err := LoadCLIConfig(); err == nil && config.Locale != "" {
+		return normalizeLocale(config.Locale)
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l := os.Getenv(env); l != "" {
+			return normalizeLocale(l)
+		}
+	}
+
+	return DefaultLocale
+}
+
+// normalizeLocale normalizes a locale of the environment or a language tag, ex. ja_JP.UTF-8 to ja-jp, C and POSIX are
+// the default locale
+func normalizeLocale(l string) string {
+
+	if idx := strings.IndexAny(l, ".@"); idx >= 0 {
+		l = l[:idx]
+	}
+	l = strings.ToLower(strings.Replace(strings.TrimSpace(l), "_", "-", -1))
+	if l == "" || l == "c" || l == "posix" {
+		return DefaultLocale
+	}
+
+	return l
+}
+
+// loadLocaleFiles registers the catalogs of the flogo home for the locale, ex. locales/zh.json then locales/zh-tw.json,
+// which contain an object of the messages by key
+func loadLocaleFiles(l string) {
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return
+	}
+
+	candidates := localeCandidates(l)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		file := filepath.Join(home, dirLocales, candidates[i]+".json")
+		buf, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(buf, &messages); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: unable to parse catalog '%s': %v\n", file, err)
+			continue
+		}
+		RegisterCatalog(candidates[i], messages)
+	}
+}
@@ -0,0 +1,288 @@
+package util
+
+// messagesEn is the catalog of the English messages, the reference of the other catalogs: a message missing from a
+// catalog is looked up in it. The help of the commands isn't part of it, its English text is the one of the command,
+// translated by the other catalogs using the keys command.<command path>.short, ex. command.ws.sync.short.
+var messagesEn = map[string]string{
+	"format.datetime": "2006-01-02 15:04:05",
+	"format.decimal":  ".",
+	"format.group":    ",",
+
+	"usage.usage":       "Usage:",
+	"usage.aliases":     "Aliases:",
+	"usage.examples":    "Examples:",
+	"usage.commands":    "Available Commands:",
+	"usage.flags":       "Flags:",
+	"usage.globalFlags": "Global Flags:",
+	"usage.helpTopics":  "Additional help topics:",
+	"usage.moreHelp":    `Use "%s [command] --help" for more information about a command.`,
+
+	"error":                          "Error: %v",
+	"error.addConnection":            "Error adding connection: %v",
+	"error.addPackage":               "Error adding package: %v",
+	"error.addPlugin":                "Error adding plugin: %v",
+	"error.analyzeCrash":             "Error analyzing crash: %v",
+	"error.applyScript":              "Error applying script: %v",
+	"error.build":                    "Error building project: %v",
+	"error.build.dockerRequired":     "Error building project: --image, --base-image and --push require --docker",
+	"error.build.offlineDocker":      "Error building project: --offline and --bundle can't be used with --docker",
+	"error.build.provenanceKeySign":  "Error building project: --provenance-key can't be used with --sign, which signs the provenance using the signer",
+	"error.build.provenanceRequired": "Error building project: --provenance-key requires --provenance",
+	"error.build.publishDocker":      "Error building project: --publish can't be used with --docker or --file",
+	"error.build.signRequired":       "Error building project: --signing-key requires --sign",
+	"error.buildTempProject":         "Error building temp project: %v",
+	"error.bundle":                   "Error bundling modules: %v",
+	"error.compose":                  "Error composing descriptors: %v",
+	"error.compose.file":             "Error composing descriptors: --compose can't be used with --file",
+	"error.create":                   "Error creating project: %v",
+	"error.create.interactiveFile":   "Error creating project: --interactive can't be used with --file",
+	"error.createApp":                "Error creating %s: %v",
+	"error.createFlowVersion":        "Error creating flow version: %v",
+	"error.createFromBlueprint":      "Error creating project from blueprint: %v",
+	"error.createProfile":            "Error creating profile: %v",
+	"error.createSnapshot":           "Error creating snapshot: %v",
+	"error.createTempProject":        "Error creating temp project: %v",
+	"error.debugFlow":                "Error debugging flow: %v",
+	"error.deploy":                   "Error deploying application: %v",
+	"error.deploy.noTarget":          "Error deploying application: target not specified",
+	"error.diagnostics":              "Error printing diagnostics: %v",
+	"error.disableTrigger":           "Error disabling trigger: %v",
+	"error.enableTrigger":            "Error enabling trigger: %v",
+	"error.exec":                     "Error executing %s: %v",
+	"error.explain":                  "Error explaining %s: %v",
+	"error.fixPlugin":                "Error fixing plugin '%s': %v",
+	"error.fixPlugins":               "Error fixing plugins: %v",
+	"error.formatImports":            "Error formatting imports: %v",
+	"error.generateCompletion":       "Error generating completion: %v",
+	"error.generateProps":            "Error generating property files: %v",
+	"error.generateSchema":           "Error generating schema: %v",
+	"error.ide":                      "Error serving IDE API: %v",
+	"error.impact":                   "Error analyzing impact of %s: %v",
+	"error.inspect":                  "Error inspecting application: %v",
+	"error.inspect.checksum":         "Error inspecting application: the app descriptor embedded in '%s' doesn't match its SHA-256",
+	"error.install":                  "Error installing contribution/dependency: %v",
+	"error.install.noReplaced":       "Error installing contribution/dependency: the replaced contribution must be specified",
+	"error.installBundle":            "Error installing contribution bundle: %v",
+	"error.installCompletion":        "Error installing completion: %v",
+	"error.latestPluginVersion":      "Error determining latest version of plugin '%s': %v",
+	"error.listAliases":              "Error listing aliases: %v",
+	"error.listBlueprints":           "Error listing blueprints: %v",
+	"error.listConnections":          "Error listing connections: %v",
+	"error.listContribs":             "Error getting list of contributions: %v",
+	"error.listDeployTargets":        "Error listing deploy targets: %v",
+	"error.listFlows":                "Error listing flows: %v",
+	"error.listImports":              "Error listing imports: %v",
+	"error.listPlugins":              "Error listing plugins: %v",
+	"error.listProfiles":             "Error listing profiles: %v",
+	"error.listProps":                "Error listing app properties: %v",
+	"error.listPublishTargets":       "Error listing publish targets: %v",
+	"error.listSettings":             "Error listing settings: %v",
+	"error.listSnapshots":            "Error listing snapshots: %v",
+	"error.listTriggers":             "Error listing triggers: %v",
+	"error.lockImports":              "Error locking imports: %v",
+	"error.logs":                     "Error showing logs: %v",
+	"error.lsp":                      "Error running language server: %v",
+	"error.metrics":                  "Error getting metrics: %v",
+	"error.orphanedRefs":             "Error getting orphaned refs: %v",
+	"error.outdated":                 "Error listing outdated contributions: %v",
+	"error.outdatedPlugins":          "Error listing outdated plugins: %v",
+	"error.pinModule":                "Error pinning module: %v",
+	"error.preview":                  "Error serving preview: %v",
+	"error.printValidation":          "Error printing validation results: %v",
+	"error.promoteFlowVersion":       "Error promoting flow version: %v",
+	"error.pruneCaches":              "Error pruning caches: %v",
+	"error.publish":                  "Error publishing application: %v",
+	"error.publishBlueprint":         "Error publishing blueprint: %v",
+	"error.pushProps":                "Error pushing properties: %v",
+	"error.reconfigure":              "Error reconfiguring application: %v",
+	"error.release":                  "Error releasing application: %v",
+	"error.release.noTarget":         "Error releasing application: target not specified",
+	"error.removeAlias":              "Error removing alias: %v",
+	"error.removeConnection":         "Error removing connection: %v",
+	"error.removeSetting":            "Error removing %s: %v",
+	"error.removeTempDir":            "Error removing temp dir: %v",
+	"error.renameExecutable":         "Error renaming executable: %v",
+	"error.renameSignature":          "Error renaming signature: %v",
+	"error.report":                   "Error reporting project health: %v",
+	"error.report.minScore":          "Error reporting project health: the score %d is lower than %d",
+	"error.resolveImports":           "Error resolving import versions: %v",
+	"error.restart":                  "Error restarting application: %v",
+	"error.restoreSnapshot":          "Error restoring snapshot: %v",
+	"error.rollbackFlowVersion":      "Error rolling back flow version: %v",
+	"error.rotateSecrets":            "Error rotating secrets: %v",
+	"error.run":                      "Error running application: %v",
+	"error.scan":                     "Error scanning for secrets: %v",
+	"error.search":                   "Error searching contributions: %v",
+	"error.setAlias":                 "Error setting alias: %v",
+	"error.setProfile":               "Error setting profile values: %v",
+	"error.setSetting":               "Error setting %s: %v",
+	"error.setup":                    "Error setting up the CLI: %v",
+	"error.size":                     "Error reporting size of %s: %v",
+	"error.size.noBinary":            "Error reporting size: binary not specified",
+	"error.smokeTest":                "Error smoke testing application: %v",
+	"error.start":                    "Error starting application: %v",
+	"error.startPlugin":              "Error starting plugin: %v",
+	"error.status":                   "Error getting application status: %v",
+	"error.stop":                     "Error stopping application: %v",
+	"error.syncImports":              "Error synchronizing imports: %v",
+	"error.tempDir":                  "Error getting temp dir: %v",
+	"error.test":                     "Error testing application: %v",
+	"error.trace":                    "Error viewing trace: %v",
+	"error.ui":                       "Error running ui: %v",
+	"error.unpinModule":              "Error unpinning module: %v",
+	"error.unsetProfile":             "Error unsetting profile values: %v",
+	"error.update":                   "Error updating contribution/dependency: %v",
+	"error.update.noContrib":         "Error updating contribution/dependency: contribution not specified",
+	"error.updateAll":                "Error updating all contributions: %v",
+	"error.updatePlugin":             "Error updating plugin: %v",
+	"error.updatePlugin.noPlugin":    "Error updating plugin: plugin or --all must be specified",
+	"error.updatePlugins":            "Error updating plugins: %v",
+	"error.upgrade":                  "Error upgrading contributions: %v",
+	"error.usage":                    "Error showing contribution usage: %v",
+	"error.validate":                 "Error validating project: %v",
+	"error.verify":                   "Error verifying application: %v",
+	"error.verify.noBinary":          "Error verifying application: binary not specified",
+	"error.version":                  "Error printing version: %v",
+	"error.watch":                    "Error watching project: %v",
+	"error.workingDir":               "Error determining working directory: %v",
+	"error.writeDescriptor":          "Error writing app descriptor: %v",
+	"error.writeSchema":              "Error writing schema: %v",
+	"error.wsAdd":                    "Error adding workspace contributions: %v",
+	"error.wsAlign":                  "Error aligning workspace dependencies: %v",
+	"error.wsPrefetch":               "Error prefetching workspace dependencies: %v",
+	"error.wsRemove":                 "Error removing workspace contributions: %v",
+	"error.wsSync":                   "Error syncing workspace: %v",
+
+	"warning":                       "Warning: %v",
+	"warning.loadPlugins":           "Warning: unable to load plugins: %v",
+	"warning.pluginCommandConflict": "Warning: command '%s' of plugin '%s' conflicts with the '%s' command, it has been skipped",
+	"warning.pluginDisabled":        "Warning: plugin '%s' %v, it has been disabled",
+	"warning.schema":                "Warning: %v",
+	"warning.setup":                 "Warning: unable to set up the CLI: %v",
+
+	"create.creating":   "Creating Flogo App: %s",
+	"create.appDir":     "Setting up app directory: %s",
+	"create.sample":     "Adding sample flogo.json",
+	"create.importing":  "Importing Dependencies...",
+	"create.installing": "Installing: %s",
+	"create.created":    "Created App: %s",
+
+	"install.installed": "Installed %s: %s",
+
+	"build.optimizing":        "Optimizing imports...",
+	"build.embedding":         "Embedding configuration in application...",
+	"build.packagedShim":      "Packaged the shim for %s in %s",
+	"build.upToDate":          "%s is up to date",
+	"build.removingImport":    "Removing Import: %s",
+	"build.composed":          "Composed %d app descriptors",
+	"build.constrainedImport": "Import '%s' is built for: %s",
+	"build.copyingBinary":     "Copying the binary from %s to %s",
+	"build.removingTempDir":   "Removing the temp dir: %s",
+	"build.smokeTestSkipped":  "Skipping the smoke test, the application can't be started on this platform",
+
+	"alias.removed": "Removed alias '%s'",
+	"alias.set":     "Alias '%s' set to: %s",
+
+	"impact.actions":  "Actions  : %s",
+	"impact.flows":    "Flows    : %s",
+	"impact.handlers": "Handlers : %s",
+	"impact.imports":  "Imports  : %s",
+	"impact.title":    "Impact of a change of the %s '%s'",
+	"impact.triggers": "Triggers : %s",
+	"impact.unused":   "The contribution isn't used by the application",
+	"impact.usedBy":   "Used by  : %s",
+
+	"inspect.extracted": "Extracted app descriptor to %s",
+
+	"plugin.broken":         "Plugin '%s' is %s: %s",
+	"plugin.healthy":        "All plugins are healthy",
+	"plugin.info.binary":    "Binary   : %s",
+	"plugin.info.commands":  "Commands : %s",
+	"plugin.info.module":    "Module   : %s",
+	"plugin.info.name":      "Plugin   : %s",
+	"plugin.info.status":    "Status   : %s",
+	"plugin.info.version":   "Version  : %s",
+	"plugin.installed":      "Installed plugin: %s",
+	"plugin.installing":     "Installing plugin: %s",
+	"plugin.noCapabilities": "Plugin '%s' requests no capabilities",
+	"plugin.removed":        "Removed plugin: %s",
+	"plugin.removing":       "Removing plugin: %s",
+	"plugin.upToDate":       "All plugins are up to date",
+	"plugin.updated":        "Updated plugin: %s",
+	"plugin.updatedAll":     "Updated all plugins",
+	"plugin.updatedVersion": "Plugin '%s' updated from %s to %s",
+	"plugin.updating":       "Updating plugin: %s",
+	"plugin.updatingAll":    "Updating all plugins",
+
+	"props.noProfiles":     "No profiles, create one using 'flogo props profile create <name>'",
+	"props.none":           "No app properties declared in %s",
+	"props.profile":        "%-20s %d value(s)",
+	"props.profileCreated": "Created profile '%s' in %s with %d value(s)",
+
+	"search.none":    "No contributions found",
+	"search.warning": "Warning: %s",
+
+	"setup.completion":          "3. Shell completion",
+	"setup.completionConfirm":   "Install the completion of the flogo commands for %s?",
+	"setup.completionFailed":    "Warning: unable to install the completion: %v",
+	"setup.completionInstalled": "Installed %s, it is enabled in the new shells",
+	"setup.completionShells":    "The completion is available for bash and zsh, see 'flogo completion --help'",
+	"setup.confirm":             "Set up the CLI now?",
+	"setup.coreVersion":         "2. Core version",
+	"setup.coreVersionPrompt":   "Version of the core used by the created projects, 'latest' or a version",
+	"setup.later":               "Run 'flogo setup' to set up the CLI at any time",
+	"setup.latestCore":          "The latest version of %s is %s",
+	"setup.noGo":                "Warning: unable to run 'go env', Go must be installed to build the applications: %v",
+	"setup.proxy":               "1. Go module proxy",
+	"setup.proxyDirect":         "GOPROXY is '%s', the modules are downloaded from their repository",
+	"setup.proxyHelp":           "Set HTTPS_PROXY if the internet is accessed through a proxy, or GOPROXY to a reachable module proxy using 'flogo config set goproxy <url>,direct'",
+	"setup.proxyUnreachable":    "Warning: the module proxy %s can't be reached: %v",
+	"setup.proxyUsed":           "The modules are downloaded from %s",
+	"setup.registry":            "4. Registry",
+	"setup.registryHelp":        "The registry index carries the deprecations and advisories of the contributions, 'none' disables it",
+	"setup.registryPrompt":      "URL or file of the registry index",
+	"setup.registryUnavailable": "Warning: the registry index can't be read, it is used once it is available: %v",
+	"setup.saved":               "Saved the configuration in %s, run 'flogo setup' to change it",
+	"setup.unknownCore":         "Warning: %s isn't a released version of the core",
+	"setup.welcome":             "Welcome to the flogo CLI, a short setup configures it for this machine.",
+
+	"size.budgetOver":   "Budget: %s, %d%% used, over the budget",
+	"size.budgetUsage":  "%s: %s of the budget of %s",
+	"size.budgetWithin": "Budget: %s, %d%% used, within the budget",
+	"size.title":        "Size of %s: %s",
+
+	"snapshot.created":      "Created snapshot %s of %d files",
+	"snapshot.none":         "No snapshots",
+	"snapshot.removedFile":  "Removed %s",
+	"snapshot.restored":     "Restored snapshot %s of %s",
+	"snapshot.restoredFile": "Restored %s",
+
+	"wizard.added":                "Added %s",
+	"wizard.application":          "1. Application",
+	"wizard.confirm":              "Create the application?",
+	"wizard.contribs":             "3. Triggers and activities",
+	"wizard.contribsHelp":         "Search the registry for the contributions to install, an import path installs it directly, empty when done",
+	"wizard.coreVersion":          "2. Core version",
+	"wizard.coreVersionPrompt":    "Version of the core, 'latest' or a version",
+	"wizard.created":              "Created '%s', run 'flogo build' in %s to build it",
+	"wizard.dirExists":            "Directory '%s' already exists, choose another name",
+	"wizard.holderPrompt":         "Copyright holder",
+	"wizard.installFailed":        "Warning: unable to install '%s', run 'flogo install %s' in the application: %v",
+	"wizard.installPrompt":        "Install (numbers separated by commas)",
+	"wizard.license":              "5. License",
+	"wizard.licensePrompt":        "License, %s",
+	"wizard.module":               "4. Go module",
+	"wizard.modulePrompt":         "Module path of the application",
+	"wizard.namePrompt":           "Name of the application",
+	"wizard.noMatch":              "No trigger or activity matches '%s'",
+	"wizard.searchFailed":         "Warning: unable to search the registry, enter the import paths of the contributions: %v",
+	"wizard.searchPrompt":         "Search",
+	"wizard.summary":              "Summary",
+	"wizard.summaryContribs":      "Contributions: %s",
+	"wizard.summaryCoreVersion":   "Core version:  %s",
+	"wizard.summaryLicense":       "License:       %s",
+	"wizard.summaryLicenseHolder": "License:       %s, %s",
+	"wizard.summaryModule":        "Go module:     %s",
+	"wizard.summaryName":          "Name:          %s",
+	"wizard.unknownLicense":       "Unknown license '%s'",
+}
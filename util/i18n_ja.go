@@ -0,0 +1,189 @@
+package util
+
+// messagesJa is the catalog of the Japanese messages
+var messagesJa = map[string]string{
+	"format.datetime": "2006年01月02日 15:04:05",
+
+	"usage.usage":          "使い方:",
+	"usage.aliases":        "エイリアス:",
+	"usage.examples":       "例:",
+	"usage.commands":       "利用可能なコマンド:",
+	"usage.flags":          "フラグ:",
+	"usage.globalFlags":    "グローバルフラグ:",
+	"usage.helpTopics":     "その他のヘルプトピック:",
+	"usage.moreHelp":       `コマンドの詳細は "%s [command] --help" を参照してください。`,
+	"error":                "エラー: %v",
+	"error.workingDir":     "作業ディレクトリの特定中にエラーが発生しました: %v",
+	"error.validate":       "プロジェクトの検証中にエラーが発生しました: %v",
+	"warning.schema":       "警告: %v",
+	"create.creating":      "Flogo アプリを作成しています: %s",
+	"create.appDir":        "アプリのディレクトリを準備しています: %s",
+	"create.sample":        "サンプルの flogo.json を追加しています",
+	"create.importing":     "依存関係をインポートしています...",
+	"create.installing":    "インストールしています: %s",
+	"create.created":       "アプリを作成しました: %s",
+	"install.installed":    "%s をインストールしました: %s",
+	"build.optimizing":     "インポートを最適化しています...",
+	"build.embedding":      "アプリケーションに設定を埋め込んでいます...",
+	"build.packagedShim":   "%s 向けのシムを %s にパッケージしました",
+	"build.upToDate":       "%s は最新です",
+	"build.removingImport": "インポートを削除しています: %s",
+
+	"command.alias.short":         "コマンドエイリアスを管理する",
+	"command.analyze-crash.short": "アプリケーションのパニックを分析する",
+	"command.apply.short":         "操作のスクリプトをプロジェクトに適用する",
+	"command.blueprint.short":     "アプリケーションのブループリントを管理する",
+	"command.build.short":         "flogo アプリケーションをビルドする",
+	"command.bundle.short":        "アプリケーションが必要とするモジュールをバンドルする",
+	"command.cache.short":         "CLI のキャッシュを管理する",
+	"command.completion.short":    "シェルの補完スクリプトを生成する",
+	"command.connection.short":    "共有コネクションを管理する",
+	"command.create.short":        "flogo アプリケーションプロジェクトを作成する",
+	"command.debug-flow.short":    "フローをステップ実行でデバッグする",
+	"command.deploy.short":        "アプリケーションをデプロイする",
+	"command.doctor.short":        "ツールチェーンとプロジェクトを診断する",
+	"command.exec.short":          "フローまたはアクションを一度実行する",
+	"command.explain.short":       "アプリケーションの要素を説明する",
+	"command.flow.short":          "アプリケーションのフローを管理する",
+	"command.ide.short":           "エディタ拡張のバックエンド",
+	"command.imports.short":       "プロジェクトのインポートを管理する",
+	"command.inspect.short":       "アプリケーションに埋め込まれたアプリ記述子を調べる",
+	"command.install.short":       "flogo のコントリビューション/依存関係をインストールする",
+	"command.list.short":          "インストール済みの flogo コントリビューションを一覧表示する",
+	"command.logs.short":          "実行中のアプリケーションの出力を表示する",
+	"command.lsp.short":           "flogo.json の言語サーバー",
+	"command.metrics.short":       "実行中のアプリケーションのメトリクスを表示する",
+	"command.outdated.short":      "古いコントリビューションを一覧表示する",
+	"command.plugin.short":        "CLI プラグインを管理する",
+	"command.preview.short":       "ブラウザでアプリケーションをプレビューする",
+	"command.props.short":         "アプリのプロパティを管理する",
+	"command.release.short":       "アプリケーションの成果物を公開する",
+	"command.remote.short":        "実行中のアプリケーションを管理する",
+	"command.report.short":        "プロジェクトの健全性を報告する",
+	"command.restart.short":       "アプリケーションを再起動する",
+	"command.scan.short":          "プロジェクトをスキャンする",
+	"command.schema.short":        "プロジェクトの JSON スキーマを生成する",
+	"command.search.short":        "コントリビューションを検索する",
+	"command.secrets.short":       "プロジェクトのシークレットを管理する",
+	"command.setup.short":         "CLI をセットアップする",
+	"command.snapshot.short":      "プロジェクトのスナップショットを管理する",
+	"command.src.short":           "アプリケーションの Go ソースを管理する",
+	"command.start.short":         "アプリケーションをバックグラウンドで起動する",
+	"command.status.short":        "アプリケーションの状態を表示する",
+	"command.stop.short":          "アプリケーションを停止する",
+	"command.test.short":          "アプリケーションのテストを実行する",
+	"command.trace.short":         "アプリケーションの実行トレースを表示する",
+	"command.trigger.short":       "アプリケーションのトリガーを管理する",
+	"command.ui.short":            "プロジェクトのターミナル UI",
+	"command.update.short":        "プロジェクトのコントリビューション/依存関係を更新する",
+	"command.upgrade.short":       "コントリビューションを最新バージョンにアップグレードする",
+	"command.usage.short":         "コントリビューションの使用状況を表示する",
+	"command.validate.short":      "flogo アプリケーションを検証する",
+	"command.verify.short":        "アプリケーションがプロジェクトと一致することを検証する",
+	"command.watch.short":         "変更時にアプリケーションを再ビルドして再起動する",
+	"command.ws.short":            "アプリケーションのワークスペースを管理する",
+
+	"error.create.interactiveFile": "プロジェクトの作成中にエラーが発生しました: --interactive は --file と併用できません",
+	"error.createProfile":          "プロファイルの作成中にエラーが発生しました: %v",
+	"error.createSnapshot":         "スナップショットの作成中にエラーが発生しました: %v",
+	"error.generateCompletion":     "補完の生成中にエラーが発生しました: %v",
+	"error.generateProps":          "プロパティファイルの生成中にエラーが発生しました: %v",
+	"error.impact":                 "%s の影響の分析中にエラーが発生しました: %v",
+	"error.installCompletion":      "補完のインストール中にエラーが発生しました: %v",
+	"error.listProfiles":           "プロファイルの一覧表示中にエラーが発生しました: %v",
+	"error.listProps":              "アプリのプロパティの一覧表示中にエラーが発生しました: %v",
+	"error.listSnapshots":          "スナップショットの一覧表示中にエラーが発生しました: %v",
+	"error.restoreSnapshot":        "スナップショットの復元中にエラーが発生しました: %v",
+	"error.search":                 "コントリビューションの検索中にエラーが発生しました: %v",
+	"error.setProfile":             "プロファイルの値の設定中にエラーが発生しました: %v",
+	"error.setup":                  "CLI のセットアップ中にエラーが発生しました: %v",
+	"error.size":                   "%s のサイズの報告中にエラーが発生しました: %v",
+	"error.size.noBinary":          "サイズの報告中にエラーが発生しました: バイナリが指定されていません",
+	"error.unsetProfile":           "プロファイルの値の削除中にエラーが発生しました: %v",
+
+	"warning":       "警告: %v",
+	"warning.setup": "警告: CLI をセットアップできません: %v",
+
+	"build.constrainedImport": "インポート '%s' のビルド対象: %s",
+
+	"impact.actions":  "アクション     : %s",
+	"impact.flows":    "フロー         : %s",
+	"impact.handlers": "ハンドラー     : %s",
+	"impact.imports":  "インポート     : %s",
+	"impact.title":    "%s '%s' の変更の影響",
+	"impact.triggers": "トリガー       : %s",
+	"impact.unused":   "このコントリビューションはアプリケーションで使用されていません",
+	"impact.usedBy":   "使用元         : %s",
+
+	"props.noProfiles":     "プロファイルがありません。'flogo props profile create <name>' で作成してください",
+	"props.none":           "%s にアプリのプロパティが宣言されていません",
+	"props.profile":        "%-20s %d 個の値",
+	"props.profileCreated": "プロファイル '%s' を %s に作成しました (%d 個の値)",
+
+	"search.none":    "コントリビューションが見つかりません",
+	"search.warning": "警告: %s",
+
+	"setup.completion":          "3. シェル補完",
+	"setup.completionConfirm":   "%s 用の flogo コマンドの補完をインストールしますか?",
+	"setup.completionFailed":    "警告: 補完をインストールできません: %v",
+	"setup.completionInstalled": "%s をインストールしました。新しいシェルで有効になります",
+	"setup.completionShells":    "補完は bash と zsh で利用できます。'flogo completion --help' を参照してください",
+	"setup.confirm":             "CLI を今すぐセットアップしますか?",
+	"setup.coreVersion":         "2. コアのバージョン",
+	"setup.coreVersionPrompt":   "作成するプロジェクトで使用するコアのバージョン ('latest' またはバージョン)",
+	"setup.later":               "'flogo setup' でいつでも CLI をセットアップできます",
+	"setup.latestCore":          "%s の最新バージョンは %s です",
+	"setup.noGo":                "警告: 'go env' を実行できません。アプリケーションのビルドには Go が必要です: %v",
+	"setup.proxy":               "1. Go モジュールプロキシ",
+	"setup.proxyDirect":         "GOPROXY は '%s' です。モジュールはそのリポジトリからダウンロードされます",
+	"setup.proxyHelp":           "プロキシ経由でインターネットに接続する場合は HTTPS_PROXY を、または 'flogo config set goproxy <url>,direct' で到達可能なモジュールプロキシを GOPROXY に設定してください",
+	"setup.proxyUnreachable":    "警告: モジュールプロキシ %s に接続できません: %v",
+	"setup.proxyUsed":           "モジュールは %s からダウンロードされます",
+	"setup.registry":            "4. レジストリ",
+	"setup.registryHelp":        "レジストリのインデックスにはコントリビューションの非推奨情報とアドバイザリが含まれます。'none' で無効にします",
+	"setup.registryPrompt":      "レジストリのインデックスの URL またはファイル",
+	"setup.registryUnavailable": "警告: レジストリのインデックスを読み取れません。利用可能になり次第使用されます: %v",
+	"setup.saved":               "設定を %s に保存しました。変更するには 'flogo setup' を実行してください",
+	"setup.unknownCore":         "警告: %s はコアのリリース済みバージョンではありません",
+	"setup.welcome":             "flogo CLI へようこそ。短いセットアップでこのマシン用に設定します。",
+
+	"size.budgetOver":   "予算: %s、%d%% 使用、予算超過",
+	"size.budgetUsage":  "%s: %s (予算 %s)",
+	"size.budgetWithin": "予算: %s、%d%% 使用、予算内",
+	"size.title":        "%s のサイズ: %s",
+
+	"snapshot.created":      "スナップショット %s を作成しました (%d 個のファイル)",
+	"snapshot.none":         "スナップショットはありません",
+	"snapshot.removedFile":  "%s を削除しました",
+	"snapshot.restored":     "スナップショット %s (%s) を復元しました",
+	"snapshot.restoredFile": "%s を復元しました",
+
+	"wizard.added":                "%s を追加しました",
+	"wizard.application":          "1. アプリケーション",
+	"wizard.confirm":              "アプリケーションを作成しますか?",
+	"wizard.contribs":             "3. トリガーとアクティビティ",
+	"wizard.contribsHelp":         "インストールするコントリビューションをレジストリで検索します。インポートパスは直接インストールされます。完了したら空のまま入力してください",
+	"wizard.coreVersion":          "2. コアのバージョン",
+	"wizard.coreVersionPrompt":    "コアのバージョン ('latest' またはバージョン)",
+	"wizard.created":              "'%s' を作成しました。ビルドするには %s で 'flogo build' を実行してください",
+	"wizard.dirExists":            "ディレクトリ '%s' は既に存在します。別の名前を選択してください",
+	"wizard.holderPrompt":         "著作権者",
+	"wizard.installFailed":        "警告: '%s' をインストールできません。アプリケーションで 'flogo install %s' を実行してください: %v",
+	"wizard.installPrompt":        "インストール (カンマ区切りの番号)",
+	"wizard.license":              "5. ライセンス",
+	"wizard.licensePrompt":        "ライセンス (%s)",
+	"wizard.module":               "4. Go モジュール",
+	"wizard.modulePrompt":         "アプリケーションのモジュールパス",
+	"wizard.namePrompt":           "アプリケーションの名前",
+	"wizard.noMatch":              "'%s' に一致するトリガーまたはアクティビティはありません",
+	"wizard.searchFailed":         "警告: レジストリを検索できません。コントリビューションのインポートパスを入力してください: %v",
+	"wizard.searchPrompt":         "検索",
+	"wizard.summary":              "概要",
+	"wizard.summaryContribs":      "コントリビューション: %s",
+	"wizard.summaryCoreVersion":   "コアのバージョン:   %s",
+	"wizard.summaryLicense":       "ライセンス:         %s",
+	"wizard.summaryLicenseHolder": "ライセンス:         %s, %s",
+	"wizard.summaryModule":        "Go モジュール:      %s",
+	"wizard.summaryName":          "名前:               %s",
+	"wizard.unknownLicense":       "不明なライセンス '%s'",
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var formatVerbPattern = regexp.MustCompile(`%[a-z]`)
+
+func TestCatalogs(t *testing.T) {
+
+	for _, l := range []string{"ja", "zh"} {
+		for key, message := range catalogs[l] {
+			if strings.HasPrefix(key, "command.") {
+				continue
+			}
+			en, exists := messagesEn[key]
+			assert.True(t, exists, "%s: unknown key %s", l, key)
+			assert.Equal(t, formatVerbPattern.FindAllString(en, -1), formatVerbPattern.FindAllString(message, -1), "%s: %s", l, key)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+
+	defer SetLocale(DefaultLocale)
+
+	assert.Equal(t, "ja-jp", normalizeLocale("ja_JP.UTF-8"))
+	assert.Equal(t, "zh-tw", normalizeLocale("zh-TW"))
+	assert.Equal(t, DefaultLocale, normalizeLocale("C"))
+	assert.Equal(t, DefaultLocale, normalizeLocale(""))
+
+	assert.Equal(t, []string{"zh-tw", "zh", "en"}, localeCandidates("zh-tw"))
+	assert.Equal(t, []string{"en"}, localeCandidates("en"))
+
+	SetLocale("ja_JP.UTF-8")
+	assert.Equal(t, "アプリを作成しました: app", T("create.created", "app"))
+	assert.Equal(t, "2006年01月02日 15:04:05", T("format.datetime"))
+	// untranslated messages are in English
+	assert.Equal(t, ",", T("format.group"))
+	assert.Equal(t, "unknown.key", T("unknown.key"))
+
+	RegisterCatalog("de", map[string]string{"format.decimal": ",", "format.group": ".", "format.datetime": "02.01.2006 15:04:05"})
+	SetLocale("de-DE")
+	assert.Equal(t, "1.234.567", FormatNumber(1234567))
+	assert.Equal(t, "-1.000", FormatNumber(-1000))
+	assert.Equal(t, "1,5 MB", FormatByteSize(1536*1024))
+	assert.Equal(t, "15.10.2026 08:30:00", FormatDateTime(time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "Created App: app", T("create.created", "app"))
+
+	SetLocale(DefaultLocale)
+	assert.Equal(t, "999", FormatNumber(999))
+	assert.Equal(t, "12,345", FormatNumber(12345))
+}
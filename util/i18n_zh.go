@@ -0,0 +1,189 @@
+package util
+
+// messagesZh is the catalog of the Simplified Chinese messages
+var messagesZh = map[string]string{
+	"format.datetime": "2006年01月02日 15:04:05",
+
+	"usage.usage":          "用法:",
+	"usage.aliases":        "别名:",
+	"usage.examples":       "示例:",
+	"usage.commands":       "可用命令:",
+	"usage.flags":          "选项:",
+	"usage.globalFlags":    "全局选项:",
+	"usage.helpTopics":     "其他帮助主题:",
+	"usage.moreHelp":       `使用 "%s [command] --help" 获取命令的更多信息。`,
+	"error":                "错误: %v",
+	"error.workingDir":     "确定工作目录时出错: %v",
+	"error.validate":       "验证项目时出错: %v",
+	"warning.schema":       "警告: %v",
+	"create.creating":      "正在创建 Flogo 应用: %s",
+	"create.appDir":        "正在设置应用目录: %s",
+	"create.sample":        "正在添加示例 flogo.json",
+	"create.importing":     "正在导入依赖...",
+	"create.installing":    "正在安装: %s",
+	"create.created":       "已创建应用: %s",
+	"install.installed":    "已安装 %s: %s",
+	"build.optimizing":     "正在优化导入...",
+	"build.embedding":      "正在将配置嵌入应用...",
+	"build.packagedShim":   "已将 %s 的 shim 打包到 %s",
+	"build.upToDate":       "%s 已是最新",
+	"build.removingImport": "正在移除导入: %s",
+
+	"command.alias.short":         "管理命令别名",
+	"command.analyze-crash.short": "分析应用的 panic",
+	"command.apply.short":         "将操作脚本应用到项目",
+	"command.blueprint.short":     "管理应用蓝图",
+	"command.build.short":         "构建 flogo 应用",
+	"command.bundle.short":        "打包应用所需的模块",
+	"command.cache.short":         "管理 CLI 的缓存",
+	"command.completion.short":    "生成 shell 的补全脚本",
+	"command.connection.short":    "管理共享连接",
+	"command.create.short":        "创建 flogo 应用项目",
+	"command.debug-flow.short":    "单步调试流程",
+	"command.deploy.short":        "部署应用",
+	"command.doctor.short":        "诊断工具链和项目",
+	"command.exec.short":          "运行一次流程或动作",
+	"command.explain.short":       "解释应用的元素",
+	"command.flow.short":          "管理应用流程",
+	"command.ide.short":           "编辑器扩展的后端",
+	"command.imports.short":       "管理项目导入",
+	"command.inspect.short":       "检查应用中嵌入的应用描述符",
+	"command.install.short":       "安装 flogo 贡献/依赖",
+	"command.list.short":          "列出已安装的 flogo 贡献",
+	"command.logs.short":          "显示运行中应用的输出",
+	"command.lsp.short":           "flogo.json 的语言服务器",
+	"command.metrics.short":       "显示运行中应用的指标",
+	"command.outdated.short":      "列出过时的贡献",
+	"command.plugin.short":        "管理 CLI 插件",
+	"command.preview.short":       "在浏览器中预览应用",
+	"command.props.short":         "管理应用属性",
+	"command.release.short":       "发布应用的制品",
+	"command.remote.short":        "管理运行中的应用",
+	"command.report.short":        "报告项目的健康状况",
+	"command.restart.short":       "重启应用",
+	"command.scan.short":          "扫描项目",
+	"command.schema.short":        "为项目生成 JSON schema",
+	"command.search.short":        "搜索贡献",
+	"command.secrets.short":       "管理项目密钥",
+	"command.setup.short":         "设置 CLI",
+	"command.snapshot.short":      "管理项目快照",
+	"command.src.short":           "管理应用的 Go 源码",
+	"command.start.short":         "在后台启动应用",
+	"command.status.short":        "显示应用的状态",
+	"command.stop.short":          "停止应用",
+	"command.test.short":          "运行应用的测试",
+	"command.trace.short":         "查看应用的执行跟踪",
+	"command.trigger.short":       "管理应用触发器",
+	"command.ui.short":            "项目的终端 UI",
+	"command.update.short":        "更新项目的贡献/依赖",
+	"command.upgrade.short":       "将贡献升级到最新版本",
+	"command.usage.short":         "显示贡献的使用情况",
+	"command.validate.short":      "验证 flogo 应用",
+	"command.verify.short":        "验证应用与项目一致",
+	"command.watch.short":         "在变更时重新构建并重启应用",
+	"command.ws.short":            "管理应用工作区",
+
+	"error.create.interactiveFile": "创建项目时出错: --interactive 不能与 --file 一起使用",
+	"error.createProfile":          "创建配置文件时出错: %v",
+	"error.createSnapshot":         "创建快照时出错: %v",
+	"error.generateCompletion":     "生成补全时出错: %v",
+	"error.generateProps":          "生成属性文件时出错: %v",
+	"error.impact":                 "分析 %s 的影响时出错: %v",
+	"error.installCompletion":      "安装补全时出错: %v",
+	"error.listProfiles":           "列出配置文件时出错: %v",
+	"error.listProps":              "列出应用属性时出错: %v",
+	"error.listSnapshots":          "列出快照时出错: %v",
+	"error.restoreSnapshot":        "恢复快照时出错: %v",
+	"error.search":                 "搜索贡献时出错: %v",
+	"error.setProfile":             "设置配置文件的值时出错: %v",
+	"error.setup":                  "设置 CLI 时出错: %v",
+	"error.size":                   "报告 %s 的大小时出错: %v",
+	"error.size.noBinary":          "报告大小时出错: 未指定二进制文件",
+	"error.unsetProfile":           "删除配置文件的值时出错: %v",
+
+	"warning":       "警告: %v",
+	"warning.setup": "警告: 无法设置 CLI: %v",
+
+	"build.constrainedImport": "导入 '%s' 的构建目标: %s",
+
+	"impact.actions":  "动作     : %s",
+	"impact.flows":    "流程     : %s",
+	"impact.handlers": "处理程序 : %s",
+	"impact.imports":  "导入     : %s",
+	"impact.title":    "更改 %s '%s' 的影响",
+	"impact.triggers": "触发器   : %s",
+	"impact.unused":   "应用程序未使用该贡献",
+	"impact.usedBy":   "使用方   : %s",
+
+	"props.noProfiles":     "没有配置文件，请使用 'flogo props profile create <name>' 创建",
+	"props.none":           "%s 中未声明应用属性",
+	"props.profile":        "%-20s %d 个值",
+	"props.profileCreated": "已创建配置文件 '%s'，位于 %s，包含 %d 个值",
+
+	"search.none":    "未找到贡献",
+	"search.warning": "警告: %s",
+
+	"setup.completion":          "3. Shell 补全",
+	"setup.completionConfirm":   "为 %s 安装 flogo 命令的补全?",
+	"setup.completionFailed":    "警告: 无法安装补全: %v",
+	"setup.completionInstalled": "已安装 %s，它将在新的 shell 中生效",
+	"setup.completionShells":    "补全适用于 bash 和 zsh，请参阅 'flogo completion --help'",
+	"setup.confirm":             "现在设置 CLI?",
+	"setup.coreVersion":         "2. 核心版本",
+	"setup.coreVersionPrompt":   "创建的项目使用的核心版本，'latest' 或某个版本",
+	"setup.later":               "随时运行 'flogo setup' 来设置 CLI",
+	"setup.latestCore":          "%s 的最新版本是 %s",
+	"setup.noGo":                "警告: 无法运行 'go env'，构建应用程序需要安装 Go: %v",
+	"setup.proxy":               "1. Go 模块代理",
+	"setup.proxyDirect":         "GOPROXY 为 '%s'，模块从其仓库下载",
+	"setup.proxyHelp":           "如果通过代理访问互联网，请设置 HTTPS_PROXY，或使用 'flogo config set goproxy <url>,direct' 将 GOPROXY 设置为可访问的模块代理",
+	"setup.proxyUnreachable":    "警告: 无法访问模块代理 %s: %v",
+	"setup.proxyUsed":           "模块从 %s 下载",
+	"setup.registry":            "4. 注册表",
+	"setup.registryHelp":        "注册表索引包含贡献的弃用信息和安全公告，'none' 将其禁用",
+	"setup.registryPrompt":      "注册表索引的 URL 或文件",
+	"setup.registryUnavailable": "警告: 无法读取注册表索引，它可用后将被使用: %v",
+	"setup.saved":               "配置已保存到 %s，运行 'flogo setup' 进行更改",
+	"setup.unknownCore":         "警告: %s 不是核心的已发布版本",
+	"setup.welcome":             "欢迎使用 flogo CLI，简短的设置将为本机配置它。",
+
+	"size.budgetOver":   "预算: %s，已使用 %d%%，超出预算",
+	"size.budgetUsage":  "%s: %s (预算 %s)",
+	"size.budgetWithin": "预算: %s，已使用 %d%%，在预算之内",
+	"size.title":        "%s 的大小: %s",
+
+	"snapshot.created":      "已创建快照 %s，包含 %d 个文件",
+	"snapshot.none":         "没有快照",
+	"snapshot.removedFile":  "已删除 %s",
+	"snapshot.restored":     "已恢复快照 %s (%s)",
+	"snapshot.restoredFile": "已恢复 %s",
+
+	"wizard.added":                "已添加 %s",
+	"wizard.application":          "1. 应用程序",
+	"wizard.confirm":              "创建应用程序?",
+	"wizard.contribs":             "3. 触发器和活动",
+	"wizard.contribsHelp":         "在注册表中搜索要安装的贡献，导入路径将直接安装，完成时留空",
+	"wizard.coreVersion":          "2. 核心版本",
+	"wizard.coreVersionPrompt":    "核心版本，'latest' 或某个版本",
+	"wizard.created":              "已创建 '%s'，在 %s 中运行 'flogo build' 进行构建",
+	"wizard.dirExists":            "目录 '%s' 已存在，请选择其他名称",
+	"wizard.holderPrompt":         "版权所有者",
+	"wizard.installFailed":        "警告: 无法安装 '%s'，请在应用程序中运行 'flogo install %s': %v",
+	"wizard.installPrompt":        "安装 (以逗号分隔的编号)",
+	"wizard.license":              "5. 许可证",
+	"wizard.licensePrompt":        "许可证，%s",
+	"wizard.module":               "4. Go 模块",
+	"wizard.modulePrompt":         "应用程序的模块路径",
+	"wizard.namePrompt":           "应用程序的名称",
+	"wizard.noMatch":              "没有与 '%s' 匹配的触发器或活动",
+	"wizard.searchFailed":         "警告: 无法搜索注册表，请输入贡献的导入路径: %v",
+	"wizard.searchPrompt":         "搜索",
+	"wizard.summary":              "摘要",
+	"wizard.summaryContribs":      "贡献:     %s",
+	"wizard.summaryCoreVersion":   "核心版本: %s",
+	"wizard.summaryLicense":       "许可证:   %s",
+	"wizard.summaryLicenseHolder": "许可证:   %s, %s",
+	"wizard.summaryModule":        "Go 模块:  %s",
+	"wizard.summaryName":          "名称:     %s",
+	"wizard.unknownLicense":       "未知许可证 '%s'",
+}
@@ -0,0 +1,153 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportGroupOthers is the prefix of the group of the imports which aren't matched by the prefixes of the other groups
+const ImportGroupOthers = "*"
+
+// ImportGroup is a group of imports of imports.go, the imports are matched to the group with the longest prefix
+type ImportGroup struct {
+	Name     string   `json:"name"`
+	Prefixes []string `json:"prefixes"`
+}
+
+// DefaultImportGroups are the groups of imports.go when the project doesn't configure them
+var DefaultImportGroups = []ImportGroup{
+	{Name: "core", Prefixes: []string{"github.com/project-flogo/core", "github.com/project-flogo/flow"}},
+	{Name: "contrib", Prefixes: []string{"github.com/project-flogo"}},
+	{Name: "third-party", Prefixes: []string{ImportGroupOthers}},
+}
+
+type groupedImport struct {
+	name string
+	path string
+}
+
+// GroupImports rewrites the imports of the Go source into a single import declaration, in which the imports are
+// grouped and sorted by path, each group being preceded by a comment with its name. The imports matched by no group
+// are added to a last group when no group has the ImportGroupOthers prefix.
+func GroupImports(src []byte, groups []ImportGroup) ([]byte, error) {
+
+	if len(groups) == 0 {
+		groups = DefaultImportGroups
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var start, end = -1, -1
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if start < 0 {
+			start = fset.Position(gen.Pos()).Offset
+		}
+		end = fset.Position(gen.End()).Offset
+	}
+	if start < 0 {
+		return src, nil
+	}
+
+	grouped := make([][]groupedImport, len(groups)+1)
+	seen := make(map[groupedImport]bool)
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		imp := groupedImport{path: path}
+		if spec.Name != nil {
+			imp.name = spec.Name.Name
+		}
+		if seen[imp] {
+			continue
+		}
+		seen[imp] = true
+
+		idx := importGroupIndex(groups, path)
+		grouped[idx] = append(grouped[idx], imp)
+	}
+
+	var block bytes.Buffer
+	block.WriteString("import (\n")
+	first := true
+	for idx, imps := range grouped {
+		if len(imps) == 0 {
+			continue
+		}
+		sort.Slice(imps, func(i, j int) bool {
+			if imps[i].path != imps[j].path {
+				return imps[i].path < imps[j].path
+			}
+			return imps[i].name < imps[j].name
+		})
+
+		if !first {
+			block.WriteString("\n")
+		}
+		first = false
+
+		if idx < len(groups) && groups[idx].Name != "" {
+			fmt.Fprintf(&block, "\t// %s\n", groups[idx].Name)
+		}
+		for _, imp := range imps {
+			block.WriteString("\t")
+			if imp.name != "" {
+				block.WriteString(imp.name + " ")
+			}
+			block.WriteString(strconv.Quote(imp.path) + "\n")
+		}
+	}
+	block.WriteString(")")
+
+	var out bytes.Buffer
+	out.Write(src[:start])
+	out.Write(block.Bytes())
+	out.Write(src[end:])
+
+	return format.Source(out.Bytes())
+}
+
+// importGroupIndex gets the index of the group of the import, the number of groups if it isn't matched by any group
+func importGroupIndex(groups []ImportGroup, path string) int {
+
+	match, matchLen, others := -1, -1, -1
+	for idx, group := range groups {
+		for _, prefix := range group.Prefixes {
+			if prefix == ImportGroupOthers {
+				if others < 0 {
+					others = idx
+				}
+				continue
+			}
+			prefix = strings.TrimSuffix(prefix, "/")
+			if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > matchLen {
+				match, matchLen = idx, len(prefix)
+			}
+		}
+	}
+
+	if match >= 0 {
+		return match
+	}
+	if others >= 0 {
+		return others
+	}
+	return len(groups)
+}
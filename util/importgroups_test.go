@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testImportsGo = `package main
+
+import _ "github.com/myorg/activity/audit"
+
+import (
+	_ "github.com/project-flogo/contrib/trigger/rest"
+	_ "github.com/project-flogo/flow"
+	_ "github.com/acme/activity/kafka"
+	_ "github.com/project-flogo/contrib/activity/log"
+	_ "github.com/project-flogo/core/data/expression/script"
+	_ "github.com/project-flogo/contrib/activity/log"
+)
+`
+
+func TestGroupImports(t *testing.T) {
+	t.Log("Testing grouping of the imports of imports.go")
+
+	out, err := GroupImports([]byte(testImportsGo), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `package main
+
+import (
+	// core
+	_ "github.com/project-flogo/core/data/expression/script"
+	_ "github.com/project-flogo/flow"
+
+	// contrib
+	_ "github.com/project-flogo/contrib/activity/log"
+	_ "github.com/project-flogo/contrib/trigger/rest"
+
+	// third-party
+	_ "github.com/acme/activity/kafka"
+	_ "github.com/myorg/activity/audit"
+)
+`, string(out))
+
+	groups := []ImportGroup{
+		{Name: "flogo", Prefixes: []string{"github.com/project-flogo/"}},
+		{Name: "local", Prefixes: []string{"github.com/myorg"}},
+	}
+	out, err = GroupImports([]byte(testImportsGo), groups)
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), "\t// local\n\t_ \"github.com/myorg/activity/audit\"\n\n\t_ \"github.com/acme/activity/kafka\"\n)")
+
+	out, err = GroupImports([]byte("package main\n"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "package main\n", string(out))
+}
+
+func TestImportGroupIndex(t *testing.T) {
+	t.Log("Testing matching of an import to its group")
+
+	assert.Equal(t, 0, importGroupIndex(DefaultImportGroups, "github.com/project-flogo/core"))
+	assert.Equal(t, 1, importGroupIndex(DefaultImportGroups, "github.com/project-flogo/corex"))
+	assert.Equal(t, 2, importGroupIndex(DefaultImportGroups, "github.com/project-flogox/core"))
+	assert.Equal(t, 2, importGroupIndex(DefaultImportGroups[:2], "github.com/other/contrib"))
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,17 +14,26 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/msoap/byline"
 )
 
+// DepManager manages the dependencies of the Go module of an application. It is safe for concurrent use: the
+// commands modifying the go.mod of the module are serialized, and the go commands it runs are killed when their
+// context is done, ex. canceled or timed out.
 type DepManager interface {
-	Init() error
-	AddDependency(flogoImport Import) error
+	Init(ctx context.Context) error
+	AddDependency(ctx context.Context, flogoImport Import) error
 	GetPath(flogoImport Import) (string, error)
-	AddReplacedContribForBuild() error
-	InstallReplacedPkg(string, string) error
+	AddReplacedContribForBuild(ctx context.Context) error
+	InstallReplacedPkg(ctx context.Context, pkg1 string, pkg2 string) error
 	GetAllImports() (map[string]Import, error)
+	Test(ctx context.Context, args ...string) error
+	Tidy(ctx context.Context) error
+	Get(ctx context.Context, args ...string) error
+	Edit(ctx context.Context, flags ...string) error
+	Download(ctx context.Context, modules ...string) error
 }
 
 func NewDepManager(sourceDir string) DepManager {
@@ -31,37 +41,91 @@ func NewDepManager(sourceDir string) DepManager {
 }
 
 type ModDepManager struct {
-	srcDir    string
+	srcDir string
+
+	mu        sync.RWMutex
 	localMods map[string]string
 }
 
-func (m *ModDepManager) Init() error {
+var (
+	moduleLocksMu sync.Mutex
+	moduleLocks   = make(map[string]chan struct{})
+)
 
-	err := ExecCmd(exec.Command("go", "mod", "init", "main"), m.srcDir)
-	if err == nil {
+// lockModule serializes the commands modifying the go.mod of the directory within the process, since go doesn't lock
+// it, the lock is released by calling the returned function
+func lockModule(ctx context.Context, dir string) (func(), error) {
+
+	moduleLocksMu.Lock()
+	lock, exists := moduleLocks[dir]
+	if !exists {
+		lock = make(chan struct{}, 1)
+		moduleLocks[dir] = lock
+	}
+	moduleLocksMu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// execGoCmd runs the go command in the src directory of a project, with the Go environment configured for the project,
+// it is killed when the context is done
+func execGoCmd(ctx context.Context, dir string, args ...string) error {
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Env = GoCmdEnv(goEnvProjectDir(dir))
+
+	err := ExecCmd(cmd, dir)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("go %s: %v", args[0], ctx.Err())
+	}
+
+	return err
+}
+
+func (m *ModDepManager) Init(ctx context.Context) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	return nil
+	err = execGoCmd(ctx, m.srcDir, "mod", "init", "main")
+	if err == nil || ctx.Err() == nil {
+		// the module may already be initialized
+		return nil
+	}
+
+	return err
 }
 
-func (m *ModDepManager) AddDependency(flogoImport Import) error {
+func (m *ModDepManager) AddDependency(ctx context.Context, flogoImport Import) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	// todo: optimize the following
 
 	// use "go mod edit" (instead of "go get") as first method
-	err := ExecCmd(exec.Command("go", "mod", "edit", "-require", flogoImport.GoModImportPath()), m.srcDir)
+	err = execGoCmd(ctx, m.srcDir, "mod", "edit", "-require", flogoImport.GoModImportPath())
 	if err != nil {
 		return err
 	}
 
-
-	err = ExecCmd(exec.Command("go", "mod", "verify"), m.srcDir)
+	err = execGoCmd(ctx, m.srcDir, "mod", "verify")
 	if err == nil {
-		err = ExecCmd(exec.Command("go", "mod", "download", flogoImport.ModulePath()), m.srcDir)
+		err = execGoCmd(ctx, m.srcDir, "mod", "download", flogoImport.ModulePath())
 	}
 
-	if err != nil {
+	if err != nil && ctx.Err() == nil {
 		// if the resolution fails and the Flogo import is "classic"
 		// (meaning it does not separate module path from Go import path):
 		// 1. remove the import manually ("go mod edit -droprequire") would fail
@@ -69,7 +133,7 @@ func (m *ModDepManager) AddDependency(flogoImport Import) error {
 		if flogoImport.IsClassic() {
 			m.RemoveImport(flogoImport)
 
-			err = ExecCmd(exec.Command("go", "get", flogoImport.GoGetImportPath()), m.srcDir)
+			err = execGoCmd(ctx, m.srcDir, "get", flogoImport.GoGetImportPath())
 		}
 	}
 
@@ -83,23 +147,20 @@ func (m *ModDepManager) AddDependency(flogoImport Import) error {
 // GetPath gets the path of where the
 func (m *ModDepManager) GetPath(flogoImport Import) (string, error) {
 
-	currentDir, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
 	pkg := flogoImport.ModulePath()
 
+	m.mu.RLock()
 	path, ok := m.localMods[pkg]
+	m.mu.RUnlock()
 	if ok && path != "" {
 
 		return path, nil
 	}
-	defer os.Chdir(currentDir)
-
-	os.Chdir(m.srcDir)
 
 	file, err := os.Open(filepath.Join(m.srcDir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
 	defer file.Close()
 
 	var pathForPartial string
@@ -151,17 +212,8 @@ func (m *ModDepManager) GetPath(flogoImport Import) (string, error) {
 
 func (m *ModDepManager) RemoveImport(flogoImport Import) error {
 
-	currentDir, err := os.Getwd()
-	if err != nil {
-		return err
-	}
-
 	modulePath := flogoImport.ModulePath()
 
-	defer os.Chdir(currentDir)
-
-	os.Chdir(m.srcDir)
-
 	file, err := os.Open(filepath.Join(m.srcDir, "go.mod"))
 	if err != nil {
 		return err
@@ -186,16 +238,9 @@ func (m *ModDepManager) RemoveImport(flogoImport Import) error {
 	if err != nil {
 		return err
 	}
+	file.Close()
 
-	file, err = os.Create(filepath.Join(m.srcDir, "go.mod"))
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	file.Write(updatedGoMod)
-
-	return nil
+	return WriteFileAtomic(filepath.Join(m.srcDir, "go.mod"), updatedGoMod, 0644)
 }
 func (m *ModDepManager) GetAllImports() (map[string]Import, error) {
 	file, err := ioutil.ReadFile(filepath.Join(m.srcDir, "go.mod"))
@@ -260,6 +305,10 @@ func ExecCmd(cmd *exec.Cmd, workingDir string) error {
 	if workingDir != "" {
 		cmd.Dir = workingDir
 	}
+	// the go commands are run with the Go environment configured for the project of the directory
+	if cmd.Env == nil && strings.TrimSuffix(filepath.Base(cmd.Path), ".exe") == "go" {
+		cmd.Env = GoCmdEnv(goEnvProjectDir(workingDir))
+	}
 
 	var out bytes.Buffer
 
@@ -280,9 +329,15 @@ func ExecCmd(cmd *exec.Cmd, workingDir string) error {
 	return nil
 }
 
-func (m *ModDepManager) AddReplacedContribForBuild() error {
+func (m *ModDepManager) AddReplacedContribForBuild(ctx context.Context) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
-	err := ExecCmd(exec.Command("go", "mod", "download"), m.srcDir)
+	err = execGoCmd(ctx, m.srcDir, "mod", "download")
 	if err != nil {
 		return err
 	}
@@ -296,6 +351,9 @@ func (m *ModDepManager) AddReplacedContribForBuild() error {
 
 	index := strings.Index(data, "replace")
 	if index != -1 {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
 		localModules := strings.Split(data[index-1:], "\n")
 
 		for _, val := range localModules {
@@ -320,21 +378,117 @@ func (m *ModDepManager) AddReplacedContribForBuild() error {
 	return nil
 }
 
-func (m *ModDepManager) InstallReplacedPkg(pkg1 string, pkg2 string) error {
+// Tidy runs 'go mod tidy' in the module, which removes the requirements the sources don't need and adds the missing
+// ones
+func (m *ModDepManager) Tidy(ctx context.Context) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return execGoCmd(ctx, m.srcDir, "mod", "tidy")
+}
+
+// Get runs 'go get' with the arguments in the module, ex. to require a version of a module or to update it
+func (m *ModDepManager) Get(ctx context.Context, args ...string) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return execGoCmd(ctx, m.srcDir, append([]string{"get"}, args...)...)
+}
+
+// Edit runs 'go mod edit' with the flags in the module, ex. -replace or -dropreplace, the replaced modules are resolved
+// again from go.mod
+func (m *ModDepManager) Edit(ctx context.Context, flags ...string) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	m.mu.Lock()
+	for i, flag := range flags {
+		var value string
+		switch {
+		case (flag == "-replace" || flag == "-dropreplace") && i+1 < len(flags):
+			value = flags[i+1]
+		case strings.HasPrefix(flag, "-replace="), strings.HasPrefix(flag, "-dropreplace="):
+			value = flag[strings.Index(flag, "=")+1:]
+		default:
+			continue
+		}
+		if idx := strings.Index(value, "="); idx != -1 {
+			value = value[:idx]
+		}
+		delete(m.localMods, strings.Split(value, "@")[0])
+	}
+	m.mu.Unlock()
+
+	return execGoCmd(ctx, m.srcDir, append([]string{"mod", "edit"}, flags...)...)
+}
+
+// Download runs 'go mod download' for the modules in the module, all its requirements if none is specified
+func (m *ModDepManager) Download(ctx context.Context, modules ...string) error {
+
+	unlock, err := lockModule(ctx, m.srcDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return execGoCmd(ctx, m.srcDir, append([]string{"mod", "download"}, modules...)...)
+}
+
+// Test runs 'go test' with the arguments in the module, its output is written to stdout and stderr
+func (m *ModDepManager) Test(ctx context.Context, args ...string) error {
+
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test"}, args...)...)
+	cmd.Dir = m.srcDir
+	cmd.Env = GoCmdEnv(goEnvProjectDir(m.srcDir))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("go test: %v", ctx.Err())
+	}
+
+	return err
+}
 
-	m.localMods[pkg1] = pkg2
+// InstallReplacedPkg replaces the module pkg1 with pkg2, a local directory relative to the src directory or a module
+// with a version, ex. github.com/fork/activity@v1.0.0
+func (m *ModDepManager) InstallReplacedPkg(ctx context.Context, pkg1 string, pkg2 string) error {
 
-	f, err := os.OpenFile(filepath.Join(m.srcDir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0777)
+	unlock, err := lockModule(ctx, m.srcDir)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	defer unlock()
+
+	if !strings.Contains(pkg2, "@") {
+		dir := pkg2
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(m.srcDir, dir)
+		}
+		m.mu.Lock()
+		m.localMods[pkg1] = dir
+		m.mu.Unlock()
+	}
 
-	if _, err = f.WriteString(fmt.Sprintf("replace %v => %v", pkg1, pkg2)); err != nil {
+	err = execGoCmd(ctx, m.srcDir, "mod", "edit", "-replace", pkg1+"="+pkg2)
+	if err != nil {
 		return err
 	}
 
-	err = ExecCmd(exec.Command("go", "mod", "download"), m.srcDir)
+	err = execGoCmd(ctx, m.srcDir, "mod", "download")
 	if err != nil {
 		return err
 	}
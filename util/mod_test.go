@@ -0,0 +1,110 @@
+package util
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockModule(t *testing.T) {
+
+	unlock, err := lockModule(context.Background(), "/app/src")
+	assert.Nil(t, err)
+
+	// the module is locked until it is released
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = lockModule(ctx, "/app/src")
+	assert.Equal(t, context.Canceled, err)
+
+	// the other modules aren't
+	unlockOther, err := lockModule(context.Background(), "/other/src")
+	assert.Nil(t, err)
+	unlockOther()
+
+	unlock()
+	unlock, err = lockModule(context.Background(), "/app/src")
+	assert.Nil(t, err)
+	unlock()
+}
+
+func TestDepManagerCanceled(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "mod")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = NewDepManager(tempDir).Init(ctx)
+	assert.NotNil(t, err)
+	assert.False(t, FileExists(filepath.Join(tempDir, "go.mod")))
+}
+
+func TestDepManagerGetPathConcurrent(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "mod")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	goMod := "module main\n\nrequire github.com/project-flogo/core v1.0.0\n\nreplace github.com/project-flogo/contrib => ../contrib\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goMod), 0644))
+
+	dm := NewDepManager(tempDir)
+	imp, err := ParseImport("github.com/project-flogo/contrib/activity/log")
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := dm.GetPath(imp)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDepManagerEdit(t *testing.T) {
+
+	tempDir, err := ioutil.TempDir("", "mod")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	goModFile := filepath.Join(tempDir, "go.mod")
+	assert.Nil(t, ioutil.WriteFile(goModFile, []byte("module main\n"), 0644))
+
+	dm := NewDepManager(tempDir).(*ModDepManager)
+
+	err = dm.Edit(context.Background(), "-replace", "github.com/project-flogo/contrib=../contrib")
+	assert.Nil(t, err)
+	goMod, err := ioutil.ReadFile(goModFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(goMod), "replace github.com/project-flogo/contrib => ../contrib")
+
+	// the dropped replacement isn't used to resolve the contributions anymore
+	dm.localMods["github.com/project-flogo/contrib"] = filepath.Join(tempDir, "..", "contrib")
+	err = dm.Edit(context.Background(), "-dropreplace=github.com/project-flogo/contrib")
+	assert.Nil(t, err)
+	assert.NotContains(t, dm.localMods, "github.com/project-flogo/contrib")
+
+	// the edit waits for the module, it isn't run once the context is done
+	unlock, err := lockModule(context.Background(), tempDir)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = dm.Edit(ctx, "-replace", "github.com/project-flogo/core=../core")
+	assert.Equal(t, context.Canceled, err)
+	unlock()
+
+	goMod, err = ioutil.ReadFile(goModFile)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(goMod), "replace")
+}
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// EnvKeyNetrc is the netrc file holding the credentials of the remote files, ~/.netrc by default
+const EnvKeyNetrc = "NETRC"
+
+// netrcMachine is an entry of a netrc file, an empty name is the default entry
+type netrcMachine struct {
+	Name     string
+	Login    string
+	Password string
+}
+
+// setNetrcAuth sets the basic authentication of the request to the credentials of the netrc file for its host, unless
+// the URL has credentials
+func setNetrcAuth(req *http.Request) {
+
+	if req.URL.User != nil {
+		return
+	}
+
+	if machine := findNetrcMachine(netrcFile(), req.URL.Hostname()); machine != nil {
+		req.SetBasicAuth(machine.Login, machine.Password)
+	}
+}
+
+func netrcFile() string {
+
+	if file := os.Getenv(EnvKeyNetrc); file != "" {
+		return file
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "_netrc")
+	}
+
+	return filepath.Join(home, ".netrc")
+}
+
+// findNetrcMachine gets the entry of the host in the netrc file, or the default entry, nil if there is none
+func findNetrcMachine(file, host string) *netrcMachine {
+
+	if file == "" {
+		return nil
+	}
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var defaultMachine *netrcMachine
+	for _, machine := range parseNetrc(string(buf)) {
+		if machine.Name == host {
+			return machine
+		}
+		if machine.Name == "" && defaultMachine == nil {
+			defaultMachine = machine
+		}
+	}
+
+	return defaultMachine
+}
+
+func parseNetrc(content string) []*netrcMachine {
+
+	var machines []*netrcMachine
+	var current *netrcMachine
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		for j := 0; j < len(fields); j++ {
+			if strings.HasPrefix(fields[j], "#") {
+				break
+			}
+
+			switch fields[j] {
+			case "machine", "default":
+				current = &netrcMachine{}
+				if fields[j] == "machine" && j+1 < len(fields) {
+					j++
+					current.Name = fields[j]
+				}
+				machines = append(machines, current)
+			case "login", "password", "account":
+				if j+1 >= len(fields) {
+					continue
+				}
+				j++
+				if current == nil {
+					continue
+				}
+				if fields[j-1] == "login" {
+					current.Login = fields[j]
+				} else if fields[j-1] == "password" {
+					current.Password = fields[j]
+				}
+			case "macdef":
+				// a macro runs until the next empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				j = len(fields)
+			}
+		}
+	}
+
+	return machines
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetrc(t *testing.T) {
+
+	machines := parseNetrc(`# credentials
+machine example.com login user password secret
+macdef init
+  cd /tmp
+  machine ignored.com
+
+machine other.com
+  login other
+  password pass
+default login anonymous password guest
+`)
+	assert.Len(t, machines, 3)
+	assert.Equal(t, &netrcMachine{Name: "example.com", Login: "user", Password: "secret"}, machines[0])
+	assert.Equal(t, &netrcMachine{Name: "other.com", Login: "other", Password: "pass"}, machines[1])
+	assert.Equal(t, &netrcMachine{Login: "anonymous", Password: "guest"}, machines[2])
+}
+
+func TestLoadRemoteFile(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "app"}`))
+	}))
+	defer server.Close()
+
+	tempDir, err := ioutil.TempDir("", "netrc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	netrc := filepath.Join(tempDir, ".netrc")
+	assert.Nil(t, ioutil.WriteFile(netrc, []byte("machine 127.0.0.1 login user password secret\n"), 0600))
+
+	defer os.Setenv(EnvKeyNetrc, os.Getenv(EnvKeyNetrc))
+	assert.Nil(t, os.Setenv(EnvKeyNetrc, filepath.Join(tempDir, "missing")))
+
+	_, err = LoadRemoteFile(server.URL + "/flogo.json")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, os.Setenv(EnvKeyNetrc, netrc))
+	content, err := LoadRemoteFile(server.URL + "/flogo.json")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "app"}`, content)
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/coreos/go-semver/semver"
+)
+
+const (
+	FilePluginJson = "flogo-plugin.json"
+)
+
+// FlogoPluginDescriptor is the optional descriptor of a CLI plugin, located in the plugin's package
+type FlogoPluginDescriptor struct {
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	Description  string   `json:"description"`
+	Capabilities []string `json:"capabilities"`
+	CLIVersion   string   `json:"cliVersion"` // constraint on the versions of the CLI, ex. ">=0.9.0, <1.0.0"
+}
+
+// GetPluginDescriptor gets the descriptor of the plugin located at pluginPath, nil is returned if it doesn't have one
+func GetPluginDescriptor(pluginPath string) (*FlogoPluginDescriptor, error) {
+
+	descriptorFile := filepath.Join(pluginPath, FilePluginJson)
+	if !FileExists(descriptorFile) {
+		return nil, nil
+	}
+
+	bytes, err := ioutil.ReadFile(descriptorFile)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor := &FlogoPluginDescriptor{}
+
+	err = json.Unmarshal(bytes, descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plugin descriptor '%s': %s", descriptorFile, err.Error())
+	}
+
+	return descriptor, nil
+}
+
+// IsCompatibleVersion determines if the version satisfies the constraint, a comma separated list of
+// comparisons (=, !=, <, <=, >, >=) which must all hold
+func IsCompatibleVersion(version, constraint string) (bool, error) {
+
+	v, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(version), "v"))
+	if err != nil {
+		return false, fmt.Errorf("invalid version '%s'", version)
+	}
+
+	for _, c := range strings.Split(constraint, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+
+		op := strings.TrimRight(c, "v0123456789.-+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ ")
+		cv, err := semver.NewVersion(strings.TrimPrefix(strings.TrimSpace(c[len(op):]), "v"))
+		if err != nil {
+			return false, fmt.Errorf("invalid version constraint '%s'", c)
+		}
+
+		var ok bool
+		switch op {
+		case "=", "==", "":
+			ok = v.Equal(*cv)
+		case "!=":
+			ok = !v.Equal(*cv)
+		case "<":
+			ok = v.LessThan(*cv)
+		case "<=":
+			ok = !cv.LessThan(*v)
+		case ">":
+			ok = cv.LessThan(*v)
+		case ">=":
+			ok = !v.LessThan(*cv)
+		default:
+			return false, fmt.Errorf("invalid version constraint '%s'", c)
+		}
+
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCompatibleVersion(t *testing.T) {
+	t.Log("Testing version constraints of plugins")
+
+	ok, err := IsCompatibleVersion("v0.9.2", ">=0.9.0, <1.0.0")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = IsCompatibleVersion("1.0.0", ">=0.9.0, <1.0.0")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = IsCompatibleVersion("0.9.0", "0.9.0")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = IsCompatibleVersion("0.9.0", "!= 0.9.0")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = IsCompatibleVersion("0.9.0", "~>0.9")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,104 @@
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// IsProcessRunning determines if the process with the specified pid is running
+func IsProcessRunning(pid int) bool {
+
+	if pid <= 0 {
+		return false
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		// FindProcess fails on windows if the process doesn't exist
+		_ = process.Release()
+		return true
+	}
+
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// ProcessIdentity identifies a running process, the pid of a process which exited may be reused by another one
+type ProcessIdentity struct {
+	Executable string `json:"executable"` // the path of the executable of the process, as reported by the os
+	Started    string `json:"started"`    // the start time of the process, in a format specific to the os
+}
+
+// GetProcessIdentity gets the identity of the process with the specified pid, it isn't supported on windows
+func GetProcessIdentity(pid int) (*ProcessIdentity, error) {
+
+	switch runtime.GOOS {
+	case "windows":
+		return nil, fmt.Errorf("process identity not supported on %s", runtime.GOOS)
+	case "linux":
+		return getProcIdentity(pid)
+	}
+
+	// the start time and executable of ps are available on darwin and the bsds
+	started, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("process %d not found", pid)
+	}
+	executable, err := exec.Command("ps", "-o", "comm=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("process %d not found", pid)
+	}
+
+	return &ProcessIdentity{Executable: strings.TrimSpace(string(executable)), Started: strings.TrimSpace(string(started))}, nil
+}
+
+// getProcIdentity gets the identity of the process from /proc, the start time is in clock ticks since boot
+func getProcIdentity(pid int) (*ProcessIdentity, error) {
+
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	executable, err := os.Readlink(filepath.Join(procDir, "exe"))
+	if err != nil {
+		return nil, err
+	}
+	// the executable may have been rebuilt since the process started
+	executable = strings.TrimSuffix(executable, " (deleted)")
+
+	buf, err := ioutil.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return nil, err
+	}
+
+	// the name of the process is in parentheses and may contain spaces, the start time is the 22nd field
+	stat := string(buf)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 20 {
+		return nil, fmt.Errorf("invalid stat of process %d", pid)
+	}
+
+	return &ProcessIdentity{Executable: executable, Started: fields[19]}, nil
+}
+
+// Matches determines if the process has the identity, the executable reported by ps may only be the name of the file
+func (p *ProcessIdentity) Matches(other *ProcessIdentity) bool {
+
+	if p.Started != other.Started {
+		return false
+	}
+
+	if filepath.IsAbs(p.Executable) && filepath.IsAbs(other.Executable) {
+		return filepath.Clean(p.Executable) == filepath.Clean(other.Executable)
+	}
+
+	return filepath.Base(p.Executable) == filepath.Base(other.Executable)
+}
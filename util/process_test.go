@@ -0,0 +1,38 @@
+package util
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProcessIdentity(t *testing.T) {
+	t.Log("Testing the identity of a process")
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the identity of processes isn't supported on windows")
+	}
+
+	identity, err := GetProcessIdentity(os.Getpid())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, identity.Executable)
+	assert.NotEmpty(t, identity.Started)
+
+	same, err := GetProcessIdentity(os.Getpid())
+	assert.Nil(t, err)
+	assert.True(t, identity.Matches(same))
+}
+
+func TestProcessIdentityMatches(t *testing.T) {
+	t.Log("Testing the comparison of process identities")
+
+	identity := &ProcessIdentity{Executable: "/home/user/myApp/bin/myApp", Started: "1234"}
+
+	assert.True(t, identity.Matches(&ProcessIdentity{Executable: "/home/user/myApp/bin/../bin/myApp", Started: "1234"}))
+	// ps may only report the name of the executable
+	assert.True(t, identity.Matches(&ProcessIdentity{Executable: "myApp", Started: "1234"}))
+	assert.False(t, identity.Matches(&ProcessIdentity{Executable: "/home/user/myApp/bin/myApp", Started: "1235"}))
+	assert.False(t, identity.Matches(&ProcessIdentity{Executable: "/usr/bin/other", Started: "1234"}))
+}
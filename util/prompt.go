@@ -0,0 +1,47 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// Confirm asks the user a yes/no question, anything but yes is considered a no
+func Confirm(question string) bool {
+
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// Prompt asks the user for a value, the default value is returned if the answer is empty
+func Prompt(question, defaultValue string) (string, error) {
+
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	answer, err := stdinReader.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", err
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue, nil
+	}
+
+	return answer, nil
+}
@@ -0,0 +1,207 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvKeyRegistry is the URL or file of the registry index, it overrides the registry of the CLI configuration
+	EnvKeyRegistry = "FLOGO_REGISTRY"
+
+	dirRegistry          = "registry"
+	fileRegistryIndex    = "index.json"
+	registryCacheTimeout = 24 * time.Hour
+)
+
+// RegistryIndex is the index of the contribution registry, it carries the metadata of the contributions which
+// isn't part of their descriptor, such as deprecations and security advisories
+type RegistryIndex struct {
+	Contributions []*RegistryContrib `json:"contributions"`
+}
+
+// RegistryContrib is the entry of a contribution in the registry index
+type RegistryContrib struct {
+	Ref          string             `json:"ref"`
+	Deprecated   string             `json:"deprecated,omitempty"`   // the reason of the deprecation, the contribution isn't deprecated if empty
+	SupersededBy string             `json:"supersededBy,omitempty"` // the contribution to migrate to
+	Advisories   []*ContribAdvisory `json:"advisories,omitempty"`
+}
+
+// ContribAdvisory is an advisory, ex. a security issue, affecting versions of a contribution
+type ContribAdvisory struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity,omitempty"`
+	Versions string `json:"versions"` // the affected versions, a constraint as used by IsCompatibleVersion, ex. <0.10.1
+	Summary  string `json:"summary"`
+	FixedIn  string `json:"fixedIn,omitempty"`
+}
+
+// LoadRegistryIndex loads the registry index from $FLOGO_REGISTRY or the registry of the CLI configuration, nil is
+// returned if no registry is configured. A remote index is cached in the flogo home for a day, the cached copy is
+// used if the registry can't be reached.
+func LoadRegistryIndex() (*RegistryIndex, error) {
+
+	source := os.Getenv(EnvKeyRegistry)
+	if source == "" {
+		config, err := LoadCLIConfig()
+		if err != nil {
+			return nil, err
+		}
+		source = config.Registry
+	}
+	if source == "" {
+		return nil, nil
+	}
+
+	if !IsRemote(source) {
+		buf, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return parseRegistryIndex(source, buf)
+	}
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return nil, err
+	}
+	cacheFile := filepath.Join(home, dirRegistry, fileRegistryIndex)
+
+	if info, err := os.Stat(cacheFile); err == nil && time.Since(info.ModTime()) < registryCacheTimeout {
+		if buf, err := ioutil.ReadFile(cacheFile); err == nil {
+			return parseRegistryIndex(cacheFile, buf)
+		}
+	}
+
+	buf, err := fetchRegistryIndex(source)
+	if err != nil {
+		if cached, cacheErr := ioutil.ReadFile(cacheFile); cacheErr == nil {
+			if Verbose() {
+				fmt.Printf("Unable to fetch registry index '%s', using cached copy: %v\n", source, err)
+			}
+			return parseRegistryIndex(cacheFile, cached)
+		}
+		return nil, err
+	}
+
+	index, err := parseRegistryIndex(source, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0755); err == nil {
+		_ = WriteFileAtomic(cacheFile, buf, 0644)
+	}
+
+	return index, nil
+}
+
+// GetRegistryCacheDir gets the directory of the cached copy of the registry index
+func GetRegistryCacheDir() (string, error) {
+
+	home, err := GetFlogoHome()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, dirRegistry), nil
+}
+
+func fetchRegistryIndex(url string) ([]byte, error) {
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch registry index '%s': %s", url, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func parseRegistryIndex(source string, buf []byte) (*RegistryIndex, error) {
+
+	index := &RegistryIndex{}
+	err := json.Unmarshal(buf, index)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse registry index '%s': %v", source, err)
+	}
+
+	return index, nil
+}
+
+// Find finds the entry of the contribution, the entry of a module applies to the contributions it contains
+func (idx *RegistryIndex) Find(importPath string) *RegistryContrib {
+
+	if idx == nil {
+		return nil
+	}
+
+	var found *RegistryContrib
+	for _, c := range idx.Contributions {
+		if importPath == c.Ref || strings.HasPrefix(importPath, c.Ref+"/") {
+			// the most specific entry is used
+			if found == nil || len(c.Ref) > len(found.Ref) {
+				found = c
+			}
+		}
+	}
+
+	return found
+}
+
+// Warnings gets the deprecation of the contribution and the advisories affecting the version, the advisories aren't
+// checked if the version is unknown
+func (c *RegistryContrib) Warnings(version string) []string {
+
+	if c == nil {
+		return nil
+	}
+
+	var warnings []string
+
+	if c.Deprecated != "" || c.SupersededBy != "" {
+		warning := fmt.Sprintf("%s is deprecated", c.Ref)
+		if c.Deprecated != "" {
+			warning += ": " + c.Deprecated
+		}
+		if c.SupersededBy != "" {
+			warning += fmt.Sprintf(", migrate to %s", c.SupersededBy)
+		}
+		warnings = append(warnings, warning)
+	}
+
+	if version == "" {
+		return warnings
+	}
+
+	for _, advisory := range c.Advisories {
+		affected, err := IsCompatibleVersion(version, advisory.Versions)
+		if err != nil || !affected {
+			continue
+		}
+
+		warning := fmt.Sprintf("%s %s is affected by %s", c.Ref, version, advisory.ID)
+		if advisory.Severity != "" {
+			warning += fmt.Sprintf(" (%s)", advisory.Severity)
+		}
+		warning += ": " + advisory.Summary
+		if advisory.FixedIn != "" {
+			warning += fmt.Sprintf(", fixed in %s", advisory.FixedIn)
+		}
+		warnings = append(warnings, warning)
+	}
+
+	return warnings
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRegistryIndex = `{
+  "contributions": [
+    {
+      "ref": "github.com/example/contrib",
+      "advisories": [
+        {"id": "FLOGO-2020-1", "severity": "high", "versions": "<0.10.1", "summary": "headers are logged", "fixedIn": "v0.10.1"}
+      ]
+    },
+    {
+      "ref": "github.com/example/contrib/activity/old",
+      "deprecated": "no longer maintained",
+      "supersededBy": "github.com/example/contrib/activity/new"
+    }
+  ]
+}`
+
+func TestRegistryIndex(t *testing.T) {
+	t.Log("Testing the deprecations and advisories of the registry index")
+
+	index, err := parseRegistryIndex("index.json", []byte(testRegistryIndex))
+	assert.Nil(t, err)
+
+	// the most specific entry applies
+	assert.Equal(t, "github.com/example/contrib/activity/old", index.Find("github.com/example/contrib/activity/old").Ref)
+	assert.Equal(t, "github.com/example/contrib", index.Find("github.com/example/contrib/activity/log").Ref)
+	assert.Nil(t, index.Find("github.com/example/contribx"))
+	assert.Nil(t, index.Find("github.com/other/contrib"))
+
+	warnings := index.Find("github.com/example/contrib/activity/old").Warnings("v0.9.0")
+	assert.Equal(t, []string{"github.com/example/contrib/activity/old is deprecated: no longer maintained, migrate to github.com/example/contrib/activity/new"}, warnings)
+
+	warnings = index.Find("github.com/example/contrib/activity/log").Warnings("v0.10.0")
+	assert.Equal(t, []string{"github.com/example/contrib v0.10.0 is affected by FLOGO-2020-1 (high): headers are logged, fixed in v0.10.1"}, warnings)
+
+	assert.Empty(t, index.Find("github.com/example/contrib/activity/log").Warnings("v0.10.1"))
+	assert.Empty(t, index.Find("github.com/example/contrib/activity/log").Warnings(""))
+
+	// an unknown contribution has no warnings
+	assert.Empty(t, index.Find("github.com/other/contrib").Warnings("v1.0.0"))
+	var noIndex *RegistryIndex
+	assert.Nil(t, noIndex.Find("github.com/example/contrib"))
+}
+
+func TestLoadRegistryIndex(t *testing.T) {
+	t.Log("Testing loading the registry index from a file")
+
+	tempDir, err := ioutil.TempDir("", "flogo-registry")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tempDir)
+
+	indexFile := filepath.Join(tempDir, "index.json")
+	assert.Nil(t, ioutil.WriteFile(indexFile, []byte(testRegistryIndex), 0644))
+
+	defer os.Setenv(EnvKeyRegistry, os.Getenv(EnvKeyRegistry))
+	os.Setenv(EnvKeyRegistry, indexFile)
+
+	index, err := LoadRegistryIndex()
+	assert.Nil(t, err)
+	assert.Len(t, index.Contributions, 2)
+
+	assert.Nil(t, ioutil.WriteFile(indexFile, []byte("{"), 0644))
+	_, err = LoadRegistryIndex()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var byteSizeUnits = []string{"B", "KB", "MB", "GB", "TB"}
+
+// ParseByteSize parses a size in bytes, with an optional unit in powers of 1024, ex. 512, 100KB, 1.5G or 2GiB
+func ParseByteSize(str string) (int64, error) {
+
+	s := strings.ToUpper(strings.TrimSpace(str))
+	s = strings.TrimSuffix(strings.Replace(s, "IB", "B", 1), "B")
+
+	multiplier := int64(1)
+	if s != "" {
+		for i, unit := range byteSizeUnits[1:] {
+			if strings.HasSuffix(s, unit[:1]) {
+				multiplier = int64(1) << (10 * uint(i+1))
+				s = s[:len(s)-1]
+				break
+			}
+		}
+	}
+
+	size, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size '%s', expected a number of bytes with an optional unit (ex. 500MB, 2GB)", str)
+	}
+
+	return int64(size * float64(multiplier)), nil
+}
+
+// FormatByteSize formats a size in bytes using the largest unit in which it is at least 1, ex. 1.5 MB, with the decimal
+// separator of the locale
+func FormatByteSize(size int64) string {
+
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(byteSizeUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	return strings.Replace(fmt.Sprintf("%.1f", value), ".", T("format.decimal"), 1) + " " + byteSizeUnits[unit]
+}
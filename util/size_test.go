@@ -0,0 +1,41 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Log("Testing parsing of sizes in bytes")
+
+	sizes := map[string]int64{
+		"512":    512,
+		"512B":   512,
+		"100KB":  100 * 1024,
+		"100k":   100 * 1024,
+		"1.5G":   3 * 512 * 1024 * 1024,
+		"2GiB":   2 * 1024 * 1024 * 1024,
+		" 10 MB": 10 * 1024 * 1024,
+	}
+	for str, expected := range sizes {
+		size, err := ParseByteSize(str)
+		assert.Nil(t, err, str)
+		assert.Equal(t, expected, size, str)
+	}
+
+	for _, str := range []string{"", "MB", "ten", "-1", "10XB"} {
+		_, err := ParseByteSize(str)
+		assert.NotNil(t, err, str)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	t.Log("Testing formatting of sizes in bytes")
+
+	assert.Equal(t, "0 B", FormatByteSize(0))
+	assert.Equal(t, "1023 B", FormatByteSize(1023))
+	assert.Equal(t, "1.0 KB", FormatByteSize(1024))
+	assert.Equal(t, "1.5 MB", FormatByteSize(3*512*1024))
+	assert.Equal(t, "2.0 GB", FormatByteSize(2*1024*1024*1024))
+}
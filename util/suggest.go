@@ -0,0 +1,95 @@
+package util
+
+import (
+	"sort"
+	"strings"
+)
+
+// OfficialContribs are the import paths of the contributions maintained by the Flogo project
+var OfficialContribs = []string{
+	"github.com/project-flogo/flow",
+	"github.com/project-flogo/stream",
+	"github.com/project-flogo/microgateway",
+	"github.com/project-flogo/legacybridge",
+	"github.com/project-flogo/contrib/activity/actreply",
+	"github.com/project-flogo/contrib/activity/actreturn",
+	"github.com/project-flogo/contrib/activity/app",
+	"github.com/project-flogo/contrib/activity/channel",
+	"github.com/project-flogo/contrib/activity/counter",
+	"github.com/project-flogo/contrib/activity/error",
+	"github.com/project-flogo/contrib/activity/jsexec",
+	"github.com/project-flogo/contrib/activity/log",
+	"github.com/project-flogo/contrib/activity/mapper",
+	"github.com/project-flogo/contrib/activity/noop",
+	"github.com/project-flogo/contrib/activity/rest",
+	"github.com/project-flogo/contrib/trigger/channel",
+	"github.com/project-flogo/contrib/trigger/cli",
+	"github.com/project-flogo/contrib/trigger/loadtester",
+	"github.com/project-flogo/contrib/trigger/rest",
+	"github.com/project-flogo/contrib/trigger/timer",
+	"github.com/project-flogo/contrib/function/coerce",
+	"github.com/project-flogo/contrib/function/datetime",
+	"github.com/project-flogo/contrib/function/json",
+	"github.com/project-flogo/contrib/function/number",
+	"github.com/project-flogo/contrib/function/string",
+}
+
+// EditDistance gets the Levenshtein distance between two strings, ignoring case
+func EditDistance(s, t string) int {
+
+	a := []rune(strings.ToLower(s))
+	b := []rune(strings.ToLower(t))
+
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// Suggestions gets the candidates within maxDistance edits of the word, closest first
+func Suggestions(word string, candidates []string, maxDistance int) []string {
+
+	distances := make(map[string]int)
+	var suggestions []string
+
+	for _, candidate := range candidates {
+		if _, exists := distances[candidate]; exists || strings.EqualFold(candidate, word) {
+			continue
+		}
+		if d := EditDistance(word, candidate); d <= maxDistance {
+			distances[candidate] = d
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return distances[suggestions[i]] < distances[suggestions[j]]
+	})
+
+	return suggestions
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
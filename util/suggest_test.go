@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, EditDistance("build", "Build"))
+	assert.Equal(t, 1, EditDistance("buld", "build"))
+	assert.Equal(t, 2, EditDistance("stauts", "status"))
+	assert.Equal(t, 5, EditDistance("", "build"))
+}
+
+func TestSuggestions(t *testing.T) {
+	suggestions := Suggestions("github.com/project-flogo/contrib/activity/rset", OfficialContribs, 3)
+	assert.Equal(t, []string{"github.com/project-flogo/contrib/activity/rest"}, suggestions)
+
+	suggestions = Suggestions("github.com/project-flogo/contrib/trigger/rest", OfficialContribs, 3)
+	assert.Empty(t, suggestions)
+
+	assert.Equal(t, []string{"embed", "embeds"}, Suggestions("embd", []string{"sync", "embeds", "embed"}, 2))
+}
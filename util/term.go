@@ -0,0 +1,75 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// MakeRawTerminal puts the terminal in raw mode, the returned function restores its previous state
+func MakeRawTerminal() (func(), error) {
+
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("raw terminal mode is not supported on windows")
+	}
+
+	state, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get terminal state: %v", err)
+	}
+
+	_, err = stty("raw", "-echo")
+	if err != nil {
+		return nil, fmt.Errorf("unable to set terminal to raw mode: %v", err)
+	}
+
+	return func() {
+		_, _ = stty(strings.TrimSpace(state))
+	}, nil
+}
+
+// IsInteractive determines if the CLI is used interactively: its input and output are terminals and it isn't run by a
+// CI system, which sets $CI
+func IsInteractive() bool {
+
+	if os.Getenv("CI") != "" {
+		return false
+	}
+
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetTerminalSize gets the number of rows and columns of the terminal, 24x80 is returned if it can't be determined
+func GetTerminalSize() (int, int) {
+
+	out, err := stty("size")
+	if err == nil {
+		parts := strings.Fields(out)
+		if len(parts) == 2 {
+			rows, errRows := strconv.Atoi(parts[0])
+			cols, errCols := strconv.Atoi(parts[1])
+			if errRows == nil && errCols == nil && rows > 0 && cols > 0 {
+				return rows, cols
+			}
+		}
+	}
+
+	return 24, 80
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -6,15 +6,108 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"runtime/debug"
 	"strings"
 	"text/template"
 	"time"
+
+	"github.com/coreos/go-semver/semver"
 )
 
 const (
-	cliPackage = "github.com/project-flogo/cli"
+	cliPackage  = "github.com/project-flogo/cli"
+	corePackage = "github.com/project-flogo/core"
+
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+	ChannelDev    = "dev"
 )
 
+// the version of a commit which isn't tagged, a pseudo-version of a module or the output of git describe
+var untaggedVersionPattern = regexp.MustCompile(`(\d{14}-[0-9a-f]{12}|-\d+-g[0-9a-f]+)$`)
+
+// CLIBuildInfo identifies the build of the CLI, the toolchain building the applications
+type CLIBuildInfo struct {
+	Version     string `json:"version"`
+	Channel     string `json:"channel"`               // the release channel: stable, beta or dev
+	Commit      string `json:"commit,omitempty"`      // the git commit the CLI was built from
+	CoreVersion string `json:"coreVersion,omitempty"` // the version of the core the CLI was built with
+	GoVersion   string `json:"goVersion"`             // the version of Go the CLI was built with
+}
+
+// NewCLIBuildInfo gets the build information of the CLI, the channel and commit set when it was released, if any,
+// otherwise the channel is determined by the version and the commit and version are the ones recorded by go build
+func NewCLIBuildInfo(version, channel, commit string) *CLIBuildInfo {
+
+	info := &CLIBuildInfo{Version: version, Channel: channel, Commit: commit, GoVersion: runtime.Version()}
+
+	modified := false
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		// ex. the version of the module installed using go install, (devel) when built from the sources
+		if info.Version == "" {
+			info.Version = bi.Main.Version
+		}
+		for _, dep := range bi.Deps {
+			if dep.Path == corePackage {
+				info.CoreVersion = dep.Version
+				if dep.Replace != nil {
+					info.CoreVersion = dep.Replace.Version
+				}
+			}
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if info.Channel == "" {
+		info.Channel = versionChannel(version, modified)
+	}
+
+	return info
+}
+
+// String describes the build, ex. v1.6.0 (stable, commit 1a2b3c4, core v1.6.0)
+func (info *CLIBuildInfo) String() string {
+
+	details := []string{info.Channel}
+	if info.Commit != "" {
+		commit := info.Commit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		details = append(details, "commit "+commit)
+	}
+	if info.CoreVersion != "" {
+		details = append(details, "core "+info.CoreVersion)
+	}
+
+	return info.Version + " (" + strings.Join(details, ", ") + ")"
+}
+
+// versionChannel gets the release channel of a version of the CLI: dev for an untagged or modified build, beta for a
+// pre-release, stable otherwise
+func versionChannel(version string, modified bool) string {
+
+	v, err := semver.NewVersion(strings.TrimPrefix(version, "v"))
+	if err != nil || modified || untaggedVersionPattern.MatchString(version) || strings.Contains(version, "dirty") {
+		return ChannelDev
+	}
+	if v.PreRelease != "" {
+		return ChannelBeta
+	}
+
+	return ChannelStable
+}
+
 func GetCLIInfo() (string, string, error) {
 
 	path, ver, err := FindOldPackageSrc(cliPackage)
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionChannel(t *testing.T) {
+
+	assert.Equal(t, ChannelStable, versionChannel("v1.6.0", false))
+	assert.Equal(t, ChannelBeta, versionChannel("v1.7.0-rc.1", false))
+	assert.Equal(t, ChannelDev, versionChannel("v1.6.0", true))
+	assert.Equal(t, ChannelDev, versionChannel("v1.6.0-3-gabc1234", false))
+	assert.Equal(t, ChannelDev, versionChannel("v1.6.0-dirty", false))
+	assert.Equal(t, ChannelDev, versionChannel("v0.0.0-20200101120000-abcdef123456", false))
+	assert.Equal(t, ChannelDev, versionChannel("", false))
+}
+
+func TestNewCLIBuildInfo(t *testing.T) {
+
+	info := NewCLIBuildInfo("v1.6.0", "", "")
+	assert.Equal(t, "v1.6.0", info.Version)
+	assert.NotEmpty(t, info.Channel)
+
+	info = NewCLIBuildInfo("v1.6.0", ChannelBeta, "abc1234")
+	assert.Equal(t, ChannelBeta, info.Channel)
+	assert.Equal(t, "abc1234", info.Commit)
+}
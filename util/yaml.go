@@ -0,0 +1,146 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsYAMLFile checks if the file is a YAML file, based on its extension
+func IsYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// YAMLToJSON converts a YAML document to indented JSON, preserving the order of the keys of its mappings
+func YAMLToJSON(buf []byte, indent string) ([]byte, error) {
+
+	var doc yaml.MapSlice
+	err := yaml.Unmarshal(buf, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	err = writeYAMLValueAsJSON(&out, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var indented bytes.Buffer
+	err = json.Indent(&indented, out.Bytes(), "", indent)
+	if err != nil {
+		return nil, err
+	}
+	indented.WriteString("\n")
+
+	return indented.Bytes(), nil
+}
+
+func writeYAMLValueAsJSON(out *bytes.Buffer, val interface{}) error {
+
+	switch t := val.(type) {
+	case yaml.MapSlice:
+		out.WriteString("{")
+		for i, item := range t {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			key, err := json.Marshal(fmt.Sprint(item.Key))
+			if err != nil {
+				return err
+			}
+			out.Write(key)
+			out.WriteString(":")
+			err = writeYAMLValueAsJSON(out, item.Value)
+			if err != nil {
+				return err
+			}
+		}
+		out.WriteString("}")
+	case []interface{}:
+		out.WriteString("[")
+		for i, item := range t {
+			if i > 0 {
+				out.WriteString(",")
+			}
+			err := writeYAMLValueAsJSON(out, item)
+			if err != nil {
+				return err
+			}
+		}
+		out.WriteString("]")
+	default:
+		buf, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("unsupported YAML value '%v': %v", t, err)
+		}
+		out.Write(buf)
+	}
+
+	return nil
+}
+
+// JSONToYAML converts a JSON document to YAML, preserving the order of the keys of its objects
+func JSONToYAML(buf []byte) ([]byte, error) {
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+
+	doc, err := readJSONValueAsYAML(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(doc)
+}
+
+func readJSONValueAsYAML(dec *json.Decoder) (interface{}, error) {
+
+	token, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := yaml.MapSlice{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := readJSONValueAsYAML(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, yaml.MapItem{Key: key, Value: val})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []interface{}{}
+		for dec.More() {
+			val, err := readJSONValueAsYAML(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAppYaml = `name: myApp
+type: flogo:app
+version: 0.0.1
+imports:
+  - github.com/project-flogo/contrib/activity/log
+resources:
+  - id: flow:main
+    data:
+      tasks:
+        - id: log
+          activity:
+            ref: "#log"
+            input:
+              message: "=$flow.name"
+              count: 2
+              enabled: true
+              ratio: 0.5
+              extra: null
+`
+
+const testAppJson = `{
+  "name": "myApp",
+  "type": "flogo:app",
+  "version": "0.0.1",
+  "imports": [
+    "github.com/project-flogo/contrib/activity/log"
+  ],
+  "resources": [
+    {
+      "id": "flow:main",
+      "data": {
+        "tasks": [
+          {
+            "id": "log",
+            "activity": {
+              "ref": "#log",
+              "input": {
+                "message": "=$flow.name",
+                "count": 2,
+                "enabled": true,
+                "ratio": 0.5,
+                "extra": null
+              }
+            }
+          }
+        ]
+      }
+    }
+  ]
+}
+`
+
+func TestYAMLToJSON(t *testing.T) {
+	t.Log("Testing conversion of a YAML app descriptor to JSON")
+
+	out, err := YAMLToJSON([]byte(testAppYaml), "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, testAppJson, string(out))
+
+	_, err = YAMLToJSON([]byte("name: [myApp"), "  ")
+	assert.NotNil(t, err)
+}
+
+func TestJSONToYAML(t *testing.T) {
+	t.Log("Testing conversion of a JSON app descriptor to YAML")
+
+	out, err := JSONToYAML([]byte(testAppJson))
+	assert.Nil(t, err)
+
+	back, err := YAMLToJSON(out, "  ")
+	assert.Nil(t, err)
+	assert.Equal(t, testAppJson, string(back))
+
+	_, err = JSONToYAML([]byte(`{"name": `))
+	assert.NotNil(t, err)
+}
+
+func TestIsYAMLFile(t *testing.T) {
+	t.Log("Testing detection of YAML files")
+
+	assert.True(t, IsYAMLFile("flogo.yaml"))
+	assert.True(t, IsYAMLFile("/tmp/app.YML"))
+	assert.False(t, IsYAMLFile("flogo.json"))
+}